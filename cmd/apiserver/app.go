@@ -2,6 +2,7 @@
 package main
 
 import (
+	"context"
 	"fantom-api-graphql/cmd/apiserver/build"
 	"fantom-api-graphql/internal/config"
 	"fantom-api-graphql/internal/graphql/resolvers"
@@ -14,6 +15,7 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
 )
@@ -24,7 +26,10 @@ type apiServer struct {
 	log          logger.Logger
 	api          resolvers.ApiResolver
 	srv          *http.Server
+	redirect     *http.Server
+	warmup       *handlers.Warmup
 	isVersionReq bool
+	stop         sync.Once
 }
 
 // init initializes the API server
@@ -69,17 +74,26 @@ func (app *apiServer) run() {
 	// run services
 	svc.Manager().Run()
 
+	// pre-populate caches by the warm-up queries in the background
+	app.warmup.Run()
+
 	// start responding to requests
 	app.log.Infof("welcome to Fantom GraphQL API server")
 	app.log.Infof("listening for requests on %s", app.cfg.Server.BindAddress)
 
 	// listen the interface
-	err := app.srv.ListenAndServe()
-	if err != nil {
+	var err error
+	if app.cfg.Server.TLS.Enabled() {
+		app.serveRedirect()
+		err = app.srv.ListenAndServeTLS("", "")
+	} else {
+		err = app.srv.ListenAndServe()
+	}
+	if err != nil && err != http.ErrServerClosed {
 		app.log.Errorf(err.Error())
 	}
 
-	// terminate the app
+	// terminate the app; waits for the shutdown started by a signal, if any
 	app.terminate()
 }
 
@@ -100,6 +114,39 @@ func (app *apiServer) makeHttpServer() {
 
 	// setup handlers
 	app.setupHandlers(srvMux)
+
+	// setup TLS termination, if configured
+	if app.cfg.Server.TLS.Enabled() {
+		h, err := app.setupTls()
+		if err != nil {
+			log.Fatalf("can not setup TLS; %s", err.Error())
+		}
+
+		if app.cfg.Server.TLS.RedirectBind != "" {
+			app.redirect = &http.Server{
+				Addr:              app.cfg.Server.TLS.RedirectBind,
+				ReadTimeout:       time.Second * time.Duration(app.cfg.Server.ReadTimeout),
+				WriteTimeout:      time.Second * time.Duration(app.cfg.Server.WriteTimeout),
+				IdleTimeout:       time.Second * time.Duration(app.cfg.Server.IdleTimeout),
+				ReadHeaderTimeout: time.Second * time.Duration(app.cfg.Server.HeaderTimeout),
+				Handler:           h,
+			}
+		}
+	}
+}
+
+// serveRedirect starts the plain HTTP listener redirecting to HTTPS, if configured.
+func (app *apiServer) serveRedirect() {
+	if app.redirect == nil {
+		return
+	}
+
+	app.log.Infof("redirecting plain HTTP requests on %s", app.redirect.Addr)
+	go func() {
+		if err := app.redirect.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			app.log.Errorf("HTTPS redirect listener failed; %s", err.Error())
+		}
+	}()
 }
 
 // setupHandlers initializes an array of handlers for our HTTP API end-points.
@@ -109,15 +156,29 @@ func (app *apiServer) setupHandlers(mux *http.ServeMux) {
 
 	// setup GraphQL API handler
 	h := http.TimeoutHandler(
-		handlers.Api(app.cfg, app.log, app.api),
-		time.Second*time.Duration(app.cfg.Server.ResolverTimeout),
+		handlers.Api(app.cfg, app.log, app.api, handlers.CorsGroupPublic),
+		handlers.RequestTimeout(app.cfg),
 		"Service timeout.",
 	)
 	mux.Handle("/api", h)
 	mux.Handle("/graphql", h)
 
+	// warm-up queries are executed on the public API handler
+	app.warmup = handlers.NewWarmup(app.cfg, app.log, h)
+
+	// setup GraphQL API handler for administrative clients; only this route serves
+	// the administrative fields and cross origin access is limited to the admin origins
+	mux.Handle("/admin", http.TimeoutHandler(
+		handlers.Api(app.cfg, app.log, app.api, handlers.CorsGroupAdmin),
+		handlers.RequestTimeout(app.cfg),
+		"Service timeout.",
+	))
+
 	// setup gas price estimator REST API resolver
-	mux.Handle("/json/gas", handlers.GasPrice(app.log))
+	mux.Handle("/json/gas", handlers.NewCorsHandler(app.cfg, app.log, handlers.CorsGroupPublic, handlers.GasPrice(app.log)))
+
+	// setup validated contract ABI REST API resolver
+	mux.Handle(handlers.ContractAbiPath, handlers.NewCorsHandler(app.cfg, app.log, handlers.CorsGroupPublic, handlers.ContractAbi(app.log)))
 
 	// handle GraphiQL interface
 	mux.Handle("/graphi", handlers.GraphiHandler(app.cfg.Server.DomainAddress, app.log))
@@ -136,31 +197,54 @@ func (app *apiServer) observeSignals() {
 	go func() {
 		// wait for the signal
 		<-ts
-
-		// terminate HTTP responder
-		app.log.Notice("closing HTTP server")
-		if err := app.srv.Close(); err != nil {
-			app.log.Errorf("could not terminate HTTP listener")
-			os.Exit(0)
-		}
+		app.terminate()
 	}()
 }
 
-// terminate modules of the API server.
+// terminate modules of the API server in order, each within the configured shutdown deadline.
+// The warm-up stops first and the HTTP server is drained, so in-flight requests can finish, then the services
+// stop feeding and processing data, and connections to DB, blockchain, etc. close last; they are not closed
+// while an earlier stage is still running.
 func (app *apiServer) terminate() {
-	// close resolvers
-	app.log.Notice("closing resolver")
-	app.api.Close()
-
-	// terminate observers, scanners and dispatchers, etc.
-	app.log.Notice("closing services")
-	if mgr := svc.Manager(); mgr != nil {
-		mgr.Close()
-	}
+	app.stop.Do(func() {
+		failed := runShutdown(app.log, time.Second*time.Duration(app.cfg.Server.ShutdownTimeout), []shutdownStage{
+			{name: "stopping warm-up", run: func(context.Context) error {
+				app.warmup.Close()
+				return nil
+			}},
+			{name: "draining HTTP server", run: app.shutdownHttp},
+			{name: "closing resolver", run: func(context.Context) error {
+				app.api.Close()
+				return nil
+			}},
+			{name: "closing services", run: func(context.Context) error {
+				if mgr := svc.Manager(); mgr != nil {
+					mgr.Close()
+				}
+				return nil
+			}},
+			{name: "closing repository", barrier: true, run: func(context.Context) error {
+				if repo := repository.R(); repo != nil {
+					repo.Close()
+				}
+				return nil
+			}},
+		})
+
+		if failed > 0 {
+			app.log.Errorf("shutdown finished with %d stages incomplete", failed)
+			return
+		}
+		app.log.Notice("shutdown finished")
+	})
+}
 
-	// terminate connections to DB, blockchain, etc.
-	app.log.Notice("closing repository")
-	if repo := repository.R(); repo != nil {
-		repo.Close()
+// shutdownHttp stops the HTTP listeners and waits for in-flight requests to finish.
+func (app *apiServer) shutdownHttp(ctx context.Context) error {
+	if app.redirect != nil {
+		if err := app.redirect.Shutdown(ctx); err != nil {
+			app.log.Errorf("could not terminate HTTPS redirect listener; %s", err.Error())
+		}
 	}
+	return app.srv.Shutdown(ctx)
 }
@@ -3,6 +3,7 @@ package main
 
 import (
 	"net/http"
+	"os"
 	"time"
 )
 
@@ -18,6 +19,11 @@ func init() {
 
 // main initializes the API server and starts it when ready.
 func main() {
+	// the schema export is used by builds; no server is started
+	if len(os.Args) > 1 && os.Args[1] == cmdExportSchema {
+		os.Exit(exportSchema(os.Args[2:]))
+	}
+
 	app := apiServer{}
 	app.init()
 	app.run()
@@ -0,0 +1,27 @@
+package main
+
+import (
+	gqlSchema "fantom-api-graphql/internal/graphql/schema"
+	"fmt"
+	"io/ioutil"
+	"os"
+)
+
+// cmdExportSchema is the name of the command exporting the GraphQL schema.
+const cmdExportSchema = "schema"
+
+// exportSchema writes the GraphQL schema definition of the API into the given file,
+// or to the standard output, if no file is given. The export does not need
+// any configuration and ignores the introspection setup of the server.
+func exportSchema(args []string) int {
+	if len(args) == 0 || args[0] == "-" {
+		fmt.Print(gqlSchema.Schema())
+		return 0
+	}
+
+	if err := ioutil.WriteFile(args[0], []byte(gqlSchema.Schema()), 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "can not export schema; %s\n", err.Error())
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,87 @@
+// Package main implements the API server entry point.
+package main
+
+import (
+	"context"
+	"fantom-api-graphql/internal/logger"
+	"time"
+)
+
+// shutdownStage represents a single step of the server shutdown sequence.
+type shutdownStage struct {
+	name string
+	run  func(ctx context.Context) error
+
+	// barrier makes the stage wait for the earlier stages abandoned past their deadline;
+	// the stage is skipped if they don't return within its own deadline, so the resources
+	// they may still use are not released under them.
+	barrier bool
+}
+
+// runShutdown executes the shutdown stages in the given order; each stage has its own
+// deadline of the given timeout. A stage still running when its deadline passes is abandoned
+// and the remaining stages are started anyway, so connections get the chance to close.
+// It returns the number of stages which failed, did not finish in time, or were skipped.
+func runShutdown(log logger.Logger, timeout time.Duration, stages []shutdownStage) int {
+	var failed int
+	var abandoned []<-chan struct{}
+
+	for _, st := range stages {
+		log.Noticef("shutdown: %s", st.name)
+		start := time.Now()
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		if st.barrier {
+			if err := waitStages(ctx, abandoned); err != nil {
+				log.Errorf("shutdown: %s skipped, earlier stages still running; %s", st.name, err.Error())
+				cancel()
+				failed++
+				continue
+			}
+			abandoned = nil
+		}
+
+		finished, err := runStage(ctx, st)
+		cancel()
+
+		if err != nil {
+			log.Errorf("shutdown: %s failed; %s", st.name, err.Error())
+			abandoned = append(abandoned, finished)
+			failed++
+			continue
+		}
+		log.Noticef("shutdown: %s done in %s", st.name, time.Since(start).String())
+	}
+	return failed
+}
+
+// runStage executes the shutdown stage and waits for it to finish, or for the stage deadline,
+// whichever comes first. The returned channel is closed once the stage actually returns.
+func runStage(ctx context.Context, st shutdownStage) (<-chan struct{}, error) {
+	finished := make(chan struct{})
+	done := make(chan error, 1)
+	go func() {
+		err := st.run(ctx)
+		close(finished)
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		return finished, err
+	case <-ctx.Done():
+		return finished, ctx.Err()
+	}
+}
+
+// waitStages waits for the given abandoned stages to return, or for the deadline.
+func waitStages(ctx context.Context, list []<-chan struct{}) error {
+	for _, finished := range list {
+		select {
+		case <-finished:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"fantom-api-graphql/internal/config"
+	"fantom-api-graphql/internal/logger"
+	"github.com/onsi/gomega"
+	"net"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// testLog is the logger used by the shutdown tests.
+var testLog = logger.New(&config.Config{Log: config.Log{Level: "CRITICAL", Format: "%{message}"}})
+
+// TestShutdownDrainsInFlightWork tests the shutdown started in the middle of request processing
+// lets the request finish before the following stages close the resources it uses.
+func TestShutdownDrainsInFlightWork(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	g.Expect(err).To(gomega.BeNil())
+
+	// the handler is in the middle of processing when the shutdown starts
+	var closed, servedAfterClose int32
+	started := make(chan bool)
+	srv := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		time.Sleep(200 * time.Millisecond)
+		if atomic.LoadInt32(&closed) == 1 {
+			atomic.StoreInt32(&servedAfterClose, 1)
+		}
+		w.WriteHeader(http.StatusOK)
+	})}
+	go func() { _ = srv.Serve(ln) }()
+
+	status := make(chan int, 1)
+	go func() {
+		res, err := http.Get("http://" + ln.Addr().String())
+		if err != nil {
+			status <- 0
+			return
+		}
+		_ = res.Body.Close()
+		status <- res.StatusCode
+	}()
+	<-started
+
+	failed := runShutdown(testLog, 5*time.Second, []shutdownStage{
+		{name: "draining HTTP server", run: srv.Shutdown},
+		{name: "closing repository", run: func(context.Context) error {
+			atomic.StoreInt32(&closed, 1)
+			return nil
+		}},
+	})
+
+	g.Expect(failed).To(gomega.Equal(0))
+	g.Expect(<-status).To(gomega.Equal(http.StatusOK))
+	g.Expect(atomic.LoadInt32(&servedAfterClose)).To(gomega.Equal(int32(0)))
+	g.Expect(atomic.LoadInt32(&closed)).To(gomega.Equal(int32(1)))
+}
+
+// TestShutdownDeadline tests a stage stuck past its deadline is abandoned, the remaining
+// stages still run, and the barrier stage is skipped while the stuck stage is running.
+func TestShutdownDeadline(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	var resolver, repo int32
+	start := time.Now()
+	failed := runShutdown(testLog, 100*time.Millisecond, []shutdownStage{
+		{name: "closing services", run: func(context.Context) error {
+			time.Sleep(time.Second)
+			return nil
+		}},
+		{name: "closing resolver", run: func(context.Context) error {
+			atomic.StoreInt32(&resolver, 1)
+			return nil
+		}},
+		{name: "closing repository", barrier: true, run: func(context.Context) error {
+			atomic.StoreInt32(&repo, 1)
+			return nil
+		}},
+	})
+
+	g.Expect(time.Since(start)).To(gomega.BeNumerically("<", time.Second))
+	g.Expect(failed).To(gomega.Equal(2))
+	g.Expect(atomic.LoadInt32(&resolver)).To(gomega.Equal(int32(1)))
+	g.Expect(atomic.LoadInt32(&repo)).To(gomega.Equal(int32(0)))
+}
+
+// TestShutdownStageBudget tests each stage has its own deadline and the barrier stage
+// waits for an abandoned stage to return before it runs.
+func TestShutdownStageBudget(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	var services, closedFirst int32
+	failed := runShutdown(testLog, 200*time.Millisecond, []shutdownStage{
+		{name: "closing services", run: func(context.Context) error {
+			time.Sleep(300 * time.Millisecond)
+			atomic.StoreInt32(&services, 1)
+			return nil
+		}},
+		{name: "closing repository", barrier: true, run: func(context.Context) error {
+			if atomic.LoadInt32(&services) == 0 {
+				atomic.StoreInt32(&closedFirst, 1)
+			}
+			return nil
+		}},
+	})
+
+	g.Expect(failed).To(gomega.Equal(1))
+	g.Expect(atomic.LoadInt32(&services)).To(gomega.Equal(int32(1)))
+	g.Expect(atomic.LoadInt32(&closedFirst)).To(gomega.Equal(int32(0)))
+}
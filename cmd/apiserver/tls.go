@@ -0,0 +1,143 @@
+// Package main implements the API server entry point.
+package main
+
+import (
+	"crypto/tls"
+	"fantom-api-graphql/internal/logger"
+	"golang.org/x/crypto/acme/autocert"
+	"net"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+// certCheckInterval is the minimal time between checks of the certificate files for changes.
+const certCheckInterval = 30 * time.Second
+
+// certReloader provides the TLS certificate loaded from files and reloads it
+// when the files change, so rotated certificates are picked up without restart.
+// Established connections keep the certificate they were negotiated with.
+type certReloader struct {
+	mu       sync.RWMutex
+	certFile string
+	keyFile  string
+	cert     *tls.Certificate
+	modTime  time.Time
+	checked  time.Time
+	log      logger.Logger
+}
+
+// newCertReloader creates a new certificate reloader with the certificate loaded.
+func newCertReloader(certFile, keyFile string, log logger.Logger) (*certReloader, error) {
+	cr := &certReloader{certFile: certFile, keyFile: keyFile, log: log}
+	if err := cr.load(); err != nil {
+		return nil, err
+	}
+	return cr, nil
+}
+
+// load reads the certificate and the key from the files.
+func (cr *certReloader) load() error {
+	fi, err := os.Stat(cr.certFile)
+	if err != nil {
+		return err
+	}
+
+	cert, err := tls.LoadX509KeyPair(cr.certFile, cr.keyFile)
+	if err != nil {
+		return err
+	}
+
+	cr.mu.Lock()
+	cr.cert = &cert
+	cr.modTime = fi.ModTime()
+	cr.checked = time.Now()
+	cr.mu.Unlock()
+	return nil
+}
+
+// GetCertificate provides the current certificate for a new TLS connection.
+func (cr *certReloader) GetCertificate(_ *tls.ClientHelloInfo) (*tls.Certificate, error) {
+	cr.reloadIfChanged()
+
+	cr.mu.RLock()
+	defer cr.mu.RUnlock()
+	return cr.cert, nil
+}
+
+// reloadIfChanged reloads the certificate if the certificate file changed.
+// A failed reload keeps the previous certificate in use.
+func (cr *certReloader) reloadIfChanged() {
+	cr.mu.Lock()
+	if time.Since(cr.checked) < certCheckInterval {
+		cr.mu.Unlock()
+		return
+	}
+	cr.checked = time.Now()
+	known := cr.modTime
+	cr.mu.Unlock()
+
+	fi, err := os.Stat(cr.certFile)
+	if err != nil || !fi.ModTime().After(known) {
+		return
+	}
+
+	if err := cr.load(); err != nil {
+		cr.log.Errorf("can not reload TLS certificate; %s", err.Error())
+		return
+	}
+	cr.log.Notice("TLS certificate reloaded")
+}
+
+// setupTls configures the TLS termination of the HTTP server, if enabled.
+// It provides the handler of the plain HTTP redirect listener, which also
+// answers ACME challenges if the autocert is used.
+func (app *apiServer) setupTls() (http.Handler, error) {
+	cfg := &app.cfg.Server.TLS
+	redirect := redirectToHttps(app.cfg.Server.BindAddress)
+
+	// ACME certificates for the server domain
+	if cfg.AutoCert {
+		host, _, err := net.SplitHostPort(app.cfg.Server.DomainAddress)
+		if err != nil {
+			host = app.cfg.Server.DomainAddress
+		}
+
+		mgr := &autocert.Manager{
+			Prompt:     autocert.AcceptTOS,
+			HostPolicy: autocert.HostWhitelist(host),
+			Cache:      autocert.DirCache(cfg.AutoCertCache),
+		}
+		app.srv.TLSConfig = mgr.TLSConfig()
+		app.log.Noticef("TLS autocert enabled for %s", host)
+		return mgr.HTTPHandler(redirect), nil
+	}
+
+	// certificate files
+	cr, err := newCertReloader(cfg.CertFile, cfg.KeyFile, app.log)
+	if err != nil {
+		return nil, err
+	}
+	app.srv.TLSConfig = &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		GetCertificate: cr.GetCertificate,
+	}
+	return redirect, nil
+}
+
+// redirectToHttps provides a handler redirecting plain HTTP requests to the HTTPS version
+// of the URL served on the port of the given HTTPS bind address.
+func redirectToHttps(bind string) http.Handler {
+	_, port, _ := net.SplitHostPort(bind)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host, _, err := net.SplitHostPort(r.Host)
+		if err != nil {
+			host = r.Host
+		}
+		if port != "" && port != "443" {
+			host = net.JoinHostPort(host, port)
+		}
+		http.Redirect(w, r, "https://"+host+r.URL.RequestURI(), http.StatusMovedPermanently)
+	})
+}
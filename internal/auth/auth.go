@@ -0,0 +1,103 @@
+// Package auth implements API key based access scopes of incoming API requests.
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"strings"
+)
+
+const (
+	// ScopeRead identifies access to resources available to authenticated clients only.
+	ScopeRead = "read"
+
+	// ScopeAdmin identifies access to administrative operations.
+	ScopeAdmin = "admin"
+)
+
+// ErrUnauthorized is returned when the request does not have the required scope.
+var ErrUnauthorized = errors.New("unauthorized; the operation requires an API key with sufficient scope")
+
+// ctxKey represents the type of the context key used to store the request identity.
+type ctxKey int
+
+// ctxKeyIdentity is the context key of the request identity.
+const ctxKeyIdentity ctxKey = iota
+
+// Identity represents the authenticated identity of an API request.
+// Identities resolved from an API key are marked as authenticated.
+type Identity struct {
+	Name          string
+	Scopes        []string
+	Authenticated bool
+}
+
+// Anonymous represents the identity of a request without any API key.
+var Anonymous = &Identity{Name: "anonymous"}
+
+// Public represents the identity of a request served with the authentication disabled;
+// the read scope is granted to everybody, the administrative operations are not.
+var Public = &Identity{Name: "public", Scopes: []string{ScopeRead}}
+
+// OpenAdmin represents the identity of a request served with the authentication disabled
+// and the administrative operations explicitly opened to everybody.
+var OpenAdmin = &Identity{Name: "open admin", Scopes: []string{ScopeAdmin}}
+
+// WithIdentity provides a new context with the given request identity attached.
+func WithIdentity(ctx context.Context, id *Identity) context.Context {
+	return context.WithValue(ctx, ctxKeyIdentity, id)
+}
+
+// FromContext provides the request identity stored in the context, if any.
+func FromContext(ctx context.Context) *Identity {
+	id, ok := ctx.Value(ctxKeyIdentity).(*Identity)
+	if !ok {
+		return nil
+	}
+	return id
+}
+
+// Has checks if the identity is granted the given scope.
+// The admin scope implies all the other scopes.
+func (id *Identity) Has(scope string) bool {
+	for _, s := range id.Scopes {
+		if s == scope || s == ScopeAdmin {
+			return true
+		}
+	}
+	return false
+}
+
+// Require checks the request context for the given scope.
+// Contexts without any identity attached are not granted any scope.
+func Require(ctx context.Context, scope string) error {
+	id := FromContext(ctx)
+	if id != nil && id.Has(scope) {
+		return nil
+	}
+	return ErrUnauthorized
+}
+
+// RequireKey checks the request context for the given scope granted by an API key.
+// Scopes granted to everybody with the authentication disabled are not accepted.
+func RequireKey(ctx context.Context, scope string) error {
+	id := FromContext(ctx)
+	if id != nil && id.Authenticated && id.Has(scope) {
+		return nil
+	}
+	return ErrUnauthorized
+}
+
+// HashKey calculates the hex encoded SHA-256 hash of the given API key.
+func HashKey(key string) string {
+	h := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(h[:])
+}
+
+// MatchKey checks if the given API key matches the given hex encoded hash.
+func MatchKey(key string, hash string) bool {
+	return subtle.ConstantTimeCompare([]byte(HashKey(key)), []byte(strings.ToLower(strings.TrimPrefix(hash, "0x")))) == 1
+}
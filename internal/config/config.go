@@ -13,12 +13,20 @@ type Config struct {
 	// AppName holds the name of the application
 	AppName string `mapstructure:"app_name"`
 
+	// Network holds the name of the active network profile, if any.
+	// Profiles are defined in the config file as a map of the profile name
+	// to the configuration options overriding the base configuration.
+	Network string `mapstructure:"network"`
+
 	// MySignature represents a signature of the server on blockchain.
 	MySignature ServerSignature `mapstructure:"me"`
 
 	// Server configuration
 	Server Server `mapstructure:"server"`
 
+	// Auth represents the API key authentication configuration
+	Auth Auth `mapstructure:"auth"`
+
 	// Logger configuration
 	Log Log `mapstructure:"log"`
 
@@ -46,6 +54,30 @@ type Config struct {
 	// Governance configuration
 	Governance Governance `mapstructure:"governance"`
 
+	// NameService configuration
+	NameService NameService `mapstructure:"name_service"`
+
+	// AbiSource configuration of the remote contract verification source
+	AbiSource AbiSource `mapstructure:"abi_source"`
+
+	// Denylist configuration of addresses and calls excluded from the analysis
+	Denylist Denylist `mapstructure:"denylist"`
+
+	// TokenList configuration of the remote token list used for unknown ERC20 tokens
+	TokenList TokenList `mapstructure:"token_list"`
+
+	// Features configuration of the API feature groups enabled on the server
+	Features Features `mapstructure:"features"`
+
+	// Webhooks configuration of the per-address notification webhooks
+	Webhooks Webhooks `mapstructure:"webhooks"`
+
+	// Retention configuration of the pruning of the aged data in the persistent storage
+	Retention Retention `mapstructure:"retention"`
+
+	// Warmup configuration of the queries pre-populating the caches
+	Warmup Warmup `mapstructure:"warmup"`
+
 	// TokenLogoFilePath contains the path to JSON file with the map
 	// of known ERC20 tokens to their logo URLs.
 	// The file will be loaded on configuration loading.
@@ -67,16 +99,118 @@ type RepoCmd struct {
 
 // Server represents the GraphQL server configuration
 type Server struct {
-	BindAddress     string   `mapstructure:"bind"`
-	DomainAddress   string   `mapstructure:"domain"`
-	Origin          string   `mapstructure:"origin"`
-	Peers           []string `mapstructure:"peers"`
-	CorsOrigin      []string `mapstructure:"cors_origins"`
-	ReadTimeout     int64    `mapstructure:"read_timeout"`
-	WriteTimeout    int64    `mapstructure:"write_timeout"`
-	IdleTimeout     int64    `mapstructure:"idle_timeout"`
-	HeaderTimeout   int64    `mapstructure:"header_timeout"`
-	ResolverTimeout int64    `mapstructure:"resolver_timeout"`
+	BindAddress   string   `mapstructure:"bind"`
+	DomainAddress string   `mapstructure:"domain"`
+	Origin        string   `mapstructure:"origin"`
+	Peers         []string `mapstructure:"peers"`
+	CorsOrigin    []string `mapstructure:"cors_origins"`
+
+	// CorsAdminOrigin is the list of origins allowed to access the admin routes;
+	// empty list allows same origin requests only.
+	CorsAdminOrigin []string `mapstructure:"cors_admin_origins"`
+
+	// CorsAdminCredentials allows cross origin admin requests with credentials.
+	CorsAdminCredentials bool `mapstructure:"cors_admin_credentials"`
+
+	// CorsMaxAge is the number of seconds the preflight response can be cached.
+	CorsMaxAge int `mapstructure:"cors_max_age"`
+
+	ReadTimeout     int64 `mapstructure:"read_timeout"`
+	WriteTimeout    int64 `mapstructure:"write_timeout"`
+	IdleTimeout     int64 `mapstructure:"idle_timeout"`
+	HeaderTimeout   int64 `mapstructure:"header_timeout"`
+	ResolverTimeout int64 `mapstructure:"resolver_timeout"`
+
+	// ResolverTimeouts maps names of resolvers to the number of seconds
+	// they are allowed to run, overriding the global ResolverTimeout.
+	// The names are case insensitive.
+	ResolverTimeouts map[string]int64 `mapstructure:"resolver_timeouts"`
+
+	// ShutdownTimeout is the number of seconds each stage of the termination has
+	// to drain in-flight requests and services and close its connections.
+	ShutdownTimeout int64 `mapstructure:"shutdown_timeout"`
+
+	// MaxQueryDepth is the maximal nesting depth of an incoming GraphQL query;
+	// zero disables the check.
+	MaxQueryDepth int `mapstructure:"max_query_depth"`
+
+	// MaxQueryComplexity is the maximal estimated cost of an incoming GraphQL query;
+	// zero disables the check.
+	MaxQueryComplexity int `mapstructure:"max_query_complexity"`
+
+	// AllowIntrospection enables the GraphQL schema introspection queries;
+	// the schema can be exported by the schema command of the server regardless.
+	AllowIntrospection bool `mapstructure:"allow_introspection"`
+
+	// Federation extends the schema with the Apollo Federation service fields
+	// so the API can be composed behind a federation gateway.
+	Federation bool `mapstructure:"federation"`
+
+	// AllowSendTransaction enables mutations submitting signed transactions
+	// to the block chain; read-only deployments can disable it.
+	AllowSendTransaction bool `mapstructure:"allow_send_trx"`
+
+	// AllowStorageAt enables raw reads of contract storage slots;
+	// clients need an API key with the read scope if the authentication is on.
+	AllowStorageAt bool `mapstructure:"allow_storage_at"`
+
+	// AllowSimulateCall enables simulated contract calls with state overrides;
+	// clients need an API key with the read scope if the authentication is on.
+	AllowSimulateCall bool `mapstructure:"allow_simulate_call"`
+
+	// SimulateCallTimeout is the time limit of a single simulated call.
+	SimulateCallTimeout time.Duration `mapstructure:"simulate_call_timeout"`
+
+	// TLS represents the optional TLS termination configuration.
+	TLS TLS `mapstructure:"tls"`
+
+	// MaxBatchAccounts is the maximal number of addresses accepted
+	// by the batch accounts query.
+	MaxBatchAccounts int `mapstructure:"max_batch_accounts"`
+
+	// MaxLogBlockRange is the maximal number of blocks
+	// scanned by a single logs query.
+	MaxLogBlockRange uint64 `mapstructure:"max_log_block_range"`
+
+	// MaxPageSize is the maximal number of edges
+	// a client can request in a single list page.
+	MaxPageSize uint32 `mapstructure:"max_page_size"`
+
+	// FieldProjection enables loading only the parts of the stored documents
+	// needed by the fields selected in the query.
+	FieldProjection bool `mapstructure:"field_projection"`
+
+	// ResultCache maps names of opted-in resolvers to the number of seconds
+	// their results are cached for; resolvers not listed are not cached.
+	// The names are case insensitive.
+	ResultCache map[string]int64 `mapstructure:"result_cache"`
+}
+
+// TLS represents the TLS termination configuration of the built-in server.
+// TLS is disabled if neither the certificate files nor the autocert is configured.
+type TLS struct {
+	// CertFile is the path to the PEM encoded certificate chain.
+	// The certificate is reloaded when the file changes.
+	CertFile string `mapstructure:"cert"`
+
+	// KeyFile is the path to the PEM encoded private key of the certificate.
+	KeyFile string `mapstructure:"key"`
+
+	// AutoCert enables ACME certificates for the server domain.
+	AutoCert bool `mapstructure:"autocert"`
+
+	// AutoCertCache is the directory the ACME certificates are stored in.
+	AutoCertCache string `mapstructure:"autocert_cache"`
+
+	// RedirectBind is the address of the plain HTTP listener redirecting
+	// requests to HTTPS; empty value disables the redirect listener.
+	// The listener answers ACME challenges if the autocert is enabled.
+	RedirectBind string `mapstructure:"redirect_bind"`
+}
+
+// Enabled checks if the TLS termination is configured.
+func (t *TLS) Enabled() bool {
+	return t.AutoCert || t.CertFile != ""
 }
 
 // ServerSignature represents the signature used by this server
@@ -84,17 +218,97 @@ type Server struct {
 type ServerSignature struct {
 	Address    common.Address   `mapstructure:"address"`
 	PrivateKey ecdsa.PrivateKey `mapstructure:"pkey"`
+
+	// SignResponses enables signing of the API responses by the private key
+	// so clients can verify the responses come from this server.
+	SignResponses bool `mapstructure:"sign_responses"`
+}
+
+// HasKey checks if the private key of the server is configured.
+func (s *ServerSignature) HasKey() bool {
+	return s.PrivateKey.D != nil && s.PrivateKey.D.Sign() != 0
+}
+
+// Auth represents the API key authentication configuration.
+type Auth struct {
+	Enabled    bool     `mapstructure:"enabled"`
+	RequireKey bool     `mapstructure:"require_key"`
+	Keys       []ApiKey `mapstructure:"keys"`
+
+	// OpenAdmin grants the administrative operations to all the requests
+	// if the authentication is disabled; meant for local development only.
+	OpenAdmin bool `mapstructure:"open_admin"`
+}
+
+// ApiKey represents a single API key with its access scopes.
+// The key itself is never stored, only its hex encoded SHA-256 hash.
+type ApiKey struct {
+	Name   string   `mapstructure:"name"`
+	Hash   string   `mapstructure:"hash"`
+	Scopes []string `mapstructure:"scopes"`
 }
 
 // Log represents the logger configuration
 type Log struct {
 	Level  string `mapstructure:"level"`
 	Format string `mapstructure:"format"`
+
+	// RequestLevel is the level used to log incoming API requests.
+	RequestLevel string `mapstructure:"request_level"`
+
+	// RequestSampling is the ratio of successful requests being logged;
+	// failed requests are always logged.
+	RequestSampling float64 `mapstructure:"request_sampling"`
+
+	// SlowResolver is the resolver execution time in milliseconds
+	// above which the resolver is reported with the request.
+	SlowResolver int64 `mapstructure:"slow_resolver"`
+
+	// DebugRateLimit is the max number of debug messages of the same kind
+	// logged per second; zero disables the limit.
+	DebugRateLimit int `mapstructure:"debug_rate_limit"`
+
+	// File represents the optional log file output.
+	File LogFile `mapstructure:"file"`
+}
+
+// LogFile represents the log file output configuration with size and age based rotation.
+type LogFile struct {
+	// Path is the path of the log file; empty path disables the file output.
+	Path string `mapstructure:"path"`
+
+	// MaxSize is the size of the log file in megabytes before it gets rotated.
+	MaxSize int `mapstructure:"max_size"`
+
+	// MaxBackups is the max number of rotated log files kept; zero keeps all.
+	MaxBackups int `mapstructure:"max_backups"`
+
+	// MaxAge is the max number of days a rotated log file is kept; zero keeps all.
+	MaxAge int `mapstructure:"max_age"`
+
+	// Compress enables compression of the rotated log files.
+	Compress bool `mapstructure:"compress"`
 }
 
 // Opera represents the Opera node access configuration
 type Opera struct {
 	Url string `mapstructure:"url"`
+
+	// PendingPool enables observing the pending transactions pool of the node;
+	// the node has to support the newPendingTransactions subscription.
+	PendingPool bool `mapstructure:"pending_pool"`
+
+	// PendingTimeout is the number of seconds a pending transaction is kept
+	// in the pool if it is not mined.
+	PendingTimeout int64 `mapstructure:"pending_timeout"`
+
+	// MaxBatchSize is the max number of calls sent to the node in a single JSON-RPC batch;
+	// larger batches are split and the size shrinks if the node rejects a batch.
+	MaxBatchSize int `mapstructure:"max_batch_size"`
+
+	// Archive signals the node keeps the full history of the chain state; historical
+	// state reads are rejected without asking the node if the node is not an archive node.
+	Archive bool `mapstructure:"archive"`
 }
 
 // Database represents the database access configuration.
@@ -107,6 +321,23 @@ type Database struct {
 type Cache struct {
 	Eviction time.Duration `mapstructure:"eviction"`
 	MaxSize  int           `mapstructure:"size"`
+
+	// Disk is the persistent tier of the cache surviving server restarts.
+	Disk DiskCache `mapstructure:"disk"`
+}
+
+// DiskCache represents the configuration of the persistent cache tier.
+// Slowly changing entries, like validator info, token details and contracts
+// with their ABI, are written to the disk and loaded back on the server start.
+type DiskCache struct {
+	Enabled bool   `mapstructure:"enabled"`
+	Path    string `mapstructure:"path"`
+
+	// MaxSize is the max size of the persisted entries in MB.
+	MaxSize int64 `mapstructure:"size"`
+
+	// MaxAge is the age of persisted entries after which they are not loaded.
+	MaxAge time.Duration `mapstructure:"max_age"`
 }
 
 // Compiler represents the contract compilers configuration.
@@ -118,6 +349,179 @@ type Compiler struct {
 // Repository represents the repository configuration.
 type Repository struct {
 	MonitorStakers bool `mapstructure:"stakers"`
+
+	// GasPriceBlocks is the number of recent blocks
+	// the gas price tiers are derived from.
+	GasPriceBlocks int `mapstructure:"gas_price_blocks"`
+
+	// RichListRefresh is the interval in which the ranking
+	// of accounts by their native balance is refreshed; zero disables the ranking.
+	RichListRefresh time.Duration `mapstructure:"rich_list_refresh"`
+
+	// ScanWorkers is the number of workers loading blocks
+	// concurrently during the block scan.
+	ScanWorkers int `mapstructure:"scan_workers"`
+
+	// ScanBatch is the number of blocks loaded by a worker
+	// in a single batch RPC call during the block scan.
+	ScanBatch int `mapstructure:"scan_batch"`
+
+	// Confirmations is the number of blocks on top of a transaction block required
+	// to consider the transaction final; recent blocks are re-verified against the chain
+	// and re-scanned on reorganization. Zero disables the verification.
+	Confirmations uint64 `mapstructure:"confirmations"`
+
+	// MaxDecodedInput is the max length of the transaction input data in bytes
+	// decoded with arguments; only the called method is recognized for longer inputs.
+	MaxDecodedInput int `mapstructure:"max_decoded_input"`
+
+	// BatchSelectors is the list of 4 bytes selectors in hex of the batch calls,
+	// e.g. Gnosis Safe multiSend or Multicall aggregate, decoded into their inner calls.
+	BatchSelectors []string `mapstructure:"batch_selectors"`
+
+	// MaxBatchDepth is the max depth of nested batch calls decoded into inner calls.
+	MaxBatchDepth int `mapstructure:"max_batch_depth"`
+
+	// MaxBatchCalls is the max total number of inner calls decoded from a single call.
+	MaxBatchCalls int `mapstructure:"max_batch_calls"`
+
+	// MaxBatchContracts is the max number of distinct contracts resolved to decode
+	// the inner calls of a single call; calls of other contracts keep the raw selector only.
+	MaxBatchContracts int `mapstructure:"max_batch_contracts"`
+
+	// Multicall is the address of the Multicall2 compatible contract used to aggregate
+	// read-only contract calls; the empty address sends the calls individually.
+	Multicall common.Address `mapstructure:"multicall"`
+
+	// Erc20HeuristicTransfers is the number of standard shaped Transfer events
+	// emitted by a generic contract before the contract is promoted to an ERC20 token;
+	// zero disables the promotion.
+	Erc20HeuristicTransfers int `mapstructure:"erc20_heuristic_transfers"`
+
+	// AnalysisWorkers is the number of workers analyzing the contract calls concurrently.
+	AnalysisWorkers int `mapstructure:"analysis_workers"`
+}
+
+// NameService represents the name service configuration.
+type NameService struct {
+	// Registry is the address of the ENS compatible registry contract
+	// used to resolve names; the empty address disables the name resolution.
+	Registry common.Address `mapstructure:"registry"`
+}
+
+// AbiSource represents the configuration of a remote Sourcify compatible
+// contract verification repository used to obtain ABIs of contracts not validated locally.
+type AbiSource struct {
+	Enabled bool `mapstructure:"enabled"`
+
+	// Url is the base address of the repository serving contract metadata
+	// under the /contracts/{full_match|partial_match}/{chain}/{address}/metadata.json path.
+	Url string `mapstructure:"url"`
+
+	// Timeout is the time limit of a single remote lookup.
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
+// Denylist represents the configuration of addresses and call selectors
+// excluded from the transaction analysis, e.g. spam token contracts.
+type Denylist struct {
+	Addresses []common.Address `mapstructure:"addresses"`
+
+	// Selectors is the list of 4 bytes call selectors in hex, e.g. 0xa9059cbb.
+	Selectors []string `mapstructure:"selectors"`
+
+	// Allow is the optional allowlist of 4 bytes call selectors in hex, or method signatures,
+	// e.g. transfer(address,uint256); if set, only calls of the allowed methods are analyzed.
+	Allow []string `mapstructure:"allow"`
+
+	// File is the path to an optional list of denied addresses and selectors,
+	// one per line, allowed selectors are prefixed with +; the file is reloaded when changed.
+	File string `mapstructure:"file"`
+
+	// Reload is the interval in which the list file is checked for changes.
+	Reload time.Duration `mapstructure:"reload"`
+}
+
+// TokenList represents the configuration of a remote token list in the Uniswap token list
+// format used to obtain details and logos of ERC20 tokens not known otherwise.
+type TokenList struct {
+	Enabled bool `mapstructure:"enabled"`
+
+	// Url is the address of the token list JSON document.
+	Url string `mapstructure:"url"`
+
+	// Refresh is the interval in which the token list is loaded again.
+	Refresh time.Duration `mapstructure:"refresh"`
+
+	// Timeout is the time limit of the token list download.
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
+// Features represents the groups of API features which can be disabled
+// to reduce the surface of the API server. Root fields of a disabled group
+// are rejected with a feature disabled error and background jobs collecting
+// data for the group are not started.
+type Features struct {
+	// Staking enables validators, delegations and rewards.
+	Staking bool `mapstructure:"staking"`
+
+	// DeFi enables fMint, fLend and Uniswap.
+	DeFi bool `mapstructure:"defi"`
+
+	// Tokens enables ERC20, ERC721 and ERC1155 tokens and their transfers.
+	Tokens bool `mapstructure:"tokens"`
+
+	// Mutations enables all the mutations, e.g. the transaction submission.
+	Mutations bool `mapstructure:"mutations"`
+
+	// Admin enables administrative queries and mutations.
+	Admin bool `mapstructure:"admin"`
+
+	// Trace enables internal transactions obtained by tracing on the node.
+	Trace bool `mapstructure:"trace"`
+}
+
+// Webhooks represents the configuration of the notification webhooks fired
+// when a registered address receives a transaction.
+type Webhooks struct {
+	Enabled bool `mapstructure:"enabled"`
+
+	// Secret is the key of the HMAC-SHA256 signature of the delivered payloads.
+	Secret string `mapstructure:"secret"`
+
+	// Timeout is the time limit of a single delivery attempt.
+	Timeout time.Duration `mapstructure:"timeout"`
+
+	// MaxRetries is the number of failed delivery attempts after which the delivery is abandoned.
+	MaxRetries int `mapstructure:"max_retries"`
+
+	// RetryDelay is the delay of the first retry of a failed delivery;
+	// the delay doubles with each next attempt, up to a day.
+	RetryDelay time.Duration `mapstructure:"retry_delay"`
+}
+
+// Retention represents the configuration of the retention windows
+// of the data pruned from the persistent storage. A zero window keeps the data forever.
+type Retention struct {
+	// Sweep is the interval of the retention sweeper runs.
+	Sweep time.Duration `mapstructure:"sweep"`
+
+	// Logs is the age of transactions after which their log records are removed.
+	Logs time.Duration `mapstructure:"logs"`
+
+	// GasPrice is the age of the gas price periods after which they are removed.
+	GasPrice time.Duration `mapstructure:"gas_price"`
+
+	// WebhookDeliveries is the age of the finished webhook deliveries after which they are removed.
+	WebhookDeliveries time.Duration `mapstructure:"webhook_deliveries"`
+
+	// TokenPrices is the age of the token price samples after which they are removed.
+	TokenPrices time.Duration `mapstructure:"token_prices"`
+}
+
+// Enabled checks if any data are pruned by the retention sweeper.
+func (r *Retention) Enabled() bool {
+	return r.Logs > 0 || r.GasPrice > 0 || r.WebhookDeliveries > 0 || r.TokenPrices > 0
 }
 
 // Staking represents the PoS Staking module configuration.
@@ -147,6 +551,27 @@ type DeFiUniswap struct {
 	Core           common.Address   `mapstructure:"core"`
 	Router         common.Address   `mapstructure:"router"`
 	PairsWhiteList []common.Address `mapstructure:"whitelist"`
+
+	// QuoteToken is the default token prices are derived against (e.g. USDC).
+	QuoteToken common.Address `mapstructure:"quote_token"`
+
+	// BaseTokens are the tokens used to route price derivation through,
+	// if there is no direct pair of the token and the quote token (e.g. wFTM).
+	BaseTokens []common.Address `mapstructure:"base_tokens"`
+
+	// MinReserve is the minimal decimals adjusted reserve of a pair
+	// on which the derived price is considered reliable.
+	MinReserve float64 `mapstructure:"min_reserve"`
+
+	// WatchedPairs limits the pairs the swap events are collected for;
+	// all the pairs of the Uniswap core are watched if empty.
+	WatchedPairs []common.Address `mapstructure:"watched_pairs"`
+
+	// PricePairs are the pairs sampled to build the token price history.
+	PricePairs []common.Address `mapstructure:"price_pairs"`
+
+	// PriceSampling is the interval of the token price sampling.
+	PriceSampling time.Duration `mapstructure:"price_sampling"`
 }
 
 // Governance represents the governance module configuration.
@@ -167,3 +592,32 @@ type GovernanceContract struct {
 type DeFiFLend struct {
 	LendingPool common.Address `mapstructure:"lending_pool"`
 }
+
+// Warmup represents the configuration of the warm-up queries executed
+// on the server start and periodically thereafter to pre-populate the caches.
+type Warmup struct {
+	// Interval is the interval of the warm-up runs after the start;
+	// zero runs the queries on the start only.
+	Interval time.Duration `mapstructure:"interval"`
+
+	// Concurrency is the max number of warm-up queries executed in parallel.
+	Concurrency int `mapstructure:"concurrency"`
+
+	// ApiKey is the API key the warm-up queries are authenticated with, if required.
+	ApiKey string `mapstructure:"api_key"`
+
+	// Queries is the list of the warm-up queries.
+	Queries []WarmupQuery `mapstructure:"queries"`
+}
+
+// WarmupQuery represents a single warm-up GraphQL query.
+type WarmupQuery struct {
+	// Name identifies the query in the log.
+	Name string `mapstructure:"name"`
+
+	// Query is the GraphQL query executed.
+	Query string `mapstructure:"query"`
+
+	// Variables are the variables of the query, if any.
+	Variables map[string]interface{} `mapstructure:"variables"`
+}
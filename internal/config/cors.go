@@ -0,0 +1,59 @@
+// Package config handles API server configuration binding and loading.
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// validateCors checks the CORS origins configured for both the public
+// and the admin routes so misconfigured origins are caught on startup.
+func validateCors(cfg *Server) error {
+	for _, o := range cfg.CorsOrigin {
+		if err := validateCorsOrigin(o); err != nil {
+			return err
+		}
+	}
+
+	for _, o := range cfg.CorsAdminOrigin {
+		// any origin with credentials would expose the admin routes to every site
+		if o == "*" && cfg.CorsAdminCredentials {
+			return fmt.Errorf("CORS origin * can not be used for admin routes with credentials")
+		}
+		if err := validateCorsOrigin(o); err != nil {
+			return err
+		}
+	}
+
+	if cfg.CorsMaxAge < 0 {
+		return fmt.Errorf("invalid CORS max age %d", cfg.CorsMaxAge)
+	}
+	return nil
+}
+
+// validateCorsOrigin checks a single CORS origin. The origin is either the "*" wildcard,
+// or the scheme and the host of the origin, optionally with the port.
+// The host may start with the "*." wildcard to match any sub-domain, e.g. https://*.example.com.
+func validateCorsOrigin(o string) error {
+	if o == "*" {
+		return nil
+	}
+
+	u, err := url.Parse(strings.Replace(o, "*.", "wildcard.", 1))
+	if err != nil {
+		return fmt.Errorf("invalid CORS origin %s; %s", o, err.Error())
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("invalid CORS origin %s; http or https scheme expected", o)
+	}
+	if u.Host == "" || u.User != nil || (u.Path != "" && u.Path != "/") || u.RawQuery != "" || u.Fragment != "" {
+		return fmt.Errorf("invalid CORS origin %s; only scheme, host and port expected", o)
+	}
+
+	// the wildcard is allowed only as the leading sub-domain label
+	if strings.Contains(u.Host, "*") || (strings.Contains(o, "*") && !strings.HasPrefix(o, u.Scheme+"://*.")) {
+		return fmt.Errorf("invalid CORS origin %s; wildcard is allowed as the leading sub-domain only", o)
+	}
+	return nil
+}
@@ -17,6 +17,9 @@ const (
 	defSelfAddress    = "0x0000000000000000000000000000000000000000"
 	defSelfPrivateKey = ""
 
+	// defSignResponses represents the default state of the API responses signing
+	defSignResponses = false
+
 	// EmptyAddress defines an empty address
 	EmptyAddress = "0x0000000000000000000000000000000000000000"
 
@@ -29,6 +32,87 @@ const (
 	defIdleTimeout     = 1
 	defHeaderTimeout   = 1
 	defResolverTimeout = 30
+	defShutdownTimeout = 30
+
+	// defSimulateCallTimeout is the default time limit of a simulated call
+	defSimulateCallTimeout = 5 * time.Second
+
+	// defMaxQueryDepth is the default maximal depth of an incoming GraphQL query
+	defMaxQueryDepth = 12
+
+	// defMaxQueryComplexity is the default maximal complexity of an incoming GraphQL query
+	defMaxQueryComplexity = 25000
+
+	// defGasPriceBlocks is the default number of recent blocks used to derive gas price tiers
+	defGasPriceBlocks = 20
+
+	// defRichListRefresh is the default interval of the rich list refresh
+	defRichListRefresh = 30 * time.Minute
+
+	// defAbiSourceUrl is the default remote contract verification repository
+	defAbiSourceUrl = "https://repo.sourcify.dev"
+
+	// defAbiSourceTimeout is the default time limit of a remote ABI lookup
+	defAbiSourceTimeout = 5 * time.Second
+
+	// defDenylistReload is the default interval of the denylist file change check
+	defDenylistReload = time.Minute
+
+	// defTokenListRefresh is the default interval of the remote token list reload
+	defTokenListRefresh = 6 * time.Hour
+
+	// defTokenListTimeout is the default time limit of the remote token list download
+	defTokenListTimeout = 10 * time.Second
+
+	// defWebhooksTimeout is the default time limit of a single webhook delivery attempt
+	defWebhooksTimeout = 10 * time.Second
+
+	// defWebhooksMaxRetries is the default number of failed attempts after which a webhook delivery is abandoned
+	defWebhooksMaxRetries = 8
+
+	// defWebhooksRetryDelay is the default delay of the first retry of a failed webhook delivery
+	defWebhooksRetryDelay = 30 * time.Second
+
+	// defRetentionSweep is the default interval of the retention sweeper runs
+	defRetentionSweep = time.Hour
+
+	// defRetentionWebhookDeliveries is the default retention window of the finished webhook deliveries
+	defRetentionWebhookDeliveries = 30 * 24 * time.Hour
+
+	// defWarmupInterval is the default interval of the warm-up query runs
+	defWarmupInterval = 5 * time.Minute
+
+	// defWarmupConcurrency is the default number of warm-up queries executed in parallel
+	defWarmupConcurrency = 4
+
+	// defScanWorkers is the default number of concurrent block scan workers
+	defScanWorkers = 4
+
+	// defScanBatch is the default number of blocks loaded by a scan worker in a single call
+	defScanBatch = 25
+
+	// defConfirmations is the default number of blocks required on top of a transaction block
+	// to consider the transaction final
+	defConfirmations = 5
+
+	// defMaxDecodedInput is the default max length of the transaction input decoded with arguments
+	defMaxDecodedInput = 128 * 1024
+
+	// defMaxBatchDepth is the default max depth of nested batch calls decoded into inner calls
+	defMaxBatchDepth = 3
+
+	// defMaxBatchCalls is the default max number of inner calls decoded from a single call
+	defMaxBatchCalls = 256
+
+	// defMaxBatchContracts is the default max number of contracts resolved to decode inner calls
+	defMaxBatchContracts = 16
+
+	// defErc20HeuristicTransfers is the default number of Transfer events
+	// of a generic contract promoting the contract to an ERC20 token
+	defErc20HeuristicTransfers = 10
+
+	// defAnalysisWorkers is the default number of concurrent contract call analysis workers
+	defAnalysisWorkers = 4
 
 	// defServerDomain holds default API server domain address
 	defServerDomain = "localhost:16761"
@@ -41,9 +125,37 @@ const (
 	// defLoggingFormat holds default format of the Logger output
 	defLoggingFormat = "%{color}%{level:-8s} %{shortpkg}/%{shortfunc}%{color:reset}: %{message}"
 
+	// defLoggingRequestLevel holds default level of the API requests logging
+	defLoggingRequestLevel = "DEBUG"
+
+	// defLoggingRequestSampling holds default ratio of logged successful API requests
+	defLoggingRequestSampling = 1.0
+
+	// defLoggingSlowResolver holds default threshold of slow resolvers reporting in milliseconds
+	defLoggingSlowResolver = 250
+
+	// defLoggingDebugRateLimit holds default max number of debug messages of the same kind per second
+	defLoggingDebugRateLimit = 10
+
+	// defLoggingFileMaxSize holds default size of the log file in megabytes before rotation
+	defLoggingFileMaxSize = 100
+
+	// defLoggingFileMaxBackups holds default number of rotated log files kept
+	defLoggingFileMaxBackups = 5
+
+	// defLoggingFileMaxAge holds default number of days rotated log files are kept
+	defLoggingFileMaxAge = 30
+
 	// defOperaUrl holds default opera connection string
 	defOperaUrl = "~/.opera/opera.ipc"
 
+	// defOperaPendingTimeout represents the default number of seconds
+	// a pending transaction is kept in the pool if it is not mined
+	defOperaPendingTimeout = 600
+
+	// defOperaMaxBatchSize represents the default max number of calls in a JSON-RPC batch
+	defOperaMaxBatchSize = 100
+
 	// defMongoUrl holds default MongoDB connection string
 	defMongoUrl = "mongodb://localhost:27017"
 
@@ -56,6 +168,15 @@ const (
 	// defCacheMax size represents the default max size of the cache in MB
 	defCacheMaxSize = 4096
 
+	// defCacheDiskPath represents the default directory of the persistent cache tier
+	defCacheDiskPath = "cache"
+
+	// defCacheDiskMaxSize represents the default max size of the persistent cache tier in MB
+	defCacheDiskMaxSize = 256
+
+	// defCacheDiskMaxAge represents the default age of persisted cache entries still loaded on start
+	defCacheDiskMaxAge = 24 * time.Hour
+
 	// defSolCompilerPath represents the default SOL compiler path
 	defSolCompilerPath = "/usr/bin/solc"
 
@@ -80,11 +201,32 @@ const (
 	// defDefiFMintAddressProvider represents the address of the fMintAddressProvider
 	defDefiUniswapRouter = EmptyAddress
 
+	// defDefiUniswapMinReserve represents the minimal pair reserve for reliable prices
+	defDefiUniswapMinReserve = 1000.0
+
+	// defDefiUniswapPriceSampling represents the default interval of the token price sampling
+	defDefiUniswapPriceSampling = 5 * time.Minute
+
 	// defTokenLogoFilePath represents the default path to the tokens map file
 	defTokenLogoFilePath = "tokens.json"
 
 	// defBlockScanRescanDepth represents the amount of blocks re-scanned on server start
 	defBlockScanRescanDepth = 200
+
+	// defMaxBatchAccounts represents the default maximal number of addresses of the batch accounts query
+	defMaxBatchAccounts = 100
+
+	// defMaxLogBlockRange represents the default maximal block range of the logs query
+	defMaxLogBlockRange = 10000
+
+	// defMaxPageSize represents the default maximal number of edges of a list page
+	defMaxPageSize = 250
+
+	// defTlsAutoCertCache represents the default directory of the ACME certificates cache
+	defTlsAutoCertCache = "autocert"
+
+	// defCorsMaxAge represents the number of seconds the CORS preflight response can be cached
+	defCorsMaxAge = 300
 )
 
 // default list of API peers
@@ -93,9 +235,37 @@ var defApiPeers = []string{"https://localhost:16761/api"}
 // defCorsAllowOrigins holds CORS default allowed origins.
 var defCorsAllowOrigins = []string{"*"}
 
+// defResultCache holds the default result cache time to live in seconds
+// of the opted-in expensive read-only resolvers.
+var defResultCache = map[string]int64{
+	"gasPrice":          5,
+	"price":             30,
+	"trxVolume":         300,
+	"trxSpeed":          30,
+	"trxGasSpeed":       30,
+	"contractGasStats":  300,
+	"topContractsByGas": 600,
+}
+
+// defResolverTimeouts holds the default time limits in seconds of resolvers
+// differing from the global resolver timeout.
+var defResolverTimeouts = map[string]int64{
+	"networkInfo": 5,
+	"dailyStats":  120,
+}
+
+// defCorsAdminAllowOrigins holds CORS default allowed origins of the admin routes;
+// no cross origin access is allowed by default.
+var defCorsAdminAllowOrigins = make([]string, 0)
+
 // default list of API peers
 var defVotingSources = make([]string, 0)
 
+// defBatchSelectors holds the default list of batch call selectors decoded into inner calls;
+// Gnosis Safe execTransaction and multiSend, Multicall aggregate, tryAggregate and aggregate3,
+// and the self multicall(bytes[]) of Uniswap style routers.
+var defBatchSelectors = []string{"0x6a761202", "0x8d80ff0a", "0x252dba42", "0xbce38bd7", "0x82ad56cb", "0xac9650d8"}
+
 // defERC20Logo defines default no-URL value for ERC20 logo list
 var defERC20Logo = map[common.Address]string{
 	common.HexToAddress(EmptyAddress): "https://repository.fantom.network/logos/erc20.svg",
@@ -109,9 +279,21 @@ func applyDefaults(cfg *viper.Viper) {
 	cfg.SetDefault(keyDomainAddress, defServerDomain)
 	cfg.SetDefault(keySignatureAddress, defSelfAddress)
 	cfg.SetDefault(keySignaturePrivateKey, defSelfPrivateKey)
+	cfg.SetDefault(keySignResponses, defSignResponses)
 	cfg.SetDefault(keyLoggingLevel, defLoggingLevel)
 	cfg.SetDefault(keyLoggingFormat, defLoggingFormat)
+	cfg.SetDefault(keyLoggingRequestLevel, defLoggingRequestLevel)
+	cfg.SetDefault(keyLoggingRequestSampling, defLoggingRequestSampling)
+	cfg.SetDefault(keyLoggingSlowResolver, defLoggingSlowResolver)
+	cfg.SetDefault(keyLoggingDebugRateLimit, defLoggingDebugRateLimit)
+	cfg.SetDefault(keyLoggingFileMaxSize, defLoggingFileMaxSize)
+	cfg.SetDefault(keyLoggingFileMaxBackups, defLoggingFileMaxBackups)
+	cfg.SetDefault(keyLoggingFileMaxAge, defLoggingFileMaxAge)
 	cfg.SetDefault(keyOperaUrl, defOperaUrl)
+	cfg.SetDefault(keyOperaPendingPool, false)
+	cfg.SetDefault(keyOperaPendingTimeout, defOperaPendingTimeout)
+	cfg.SetDefault(keyOperaMaxBatchSize, defOperaMaxBatchSize)
+	cfg.SetDefault(keyOperaArchive, true)
 	cfg.SetDefault(keyMongoUrl, defMongoUrl)
 	cfg.SetDefault(keyMongoDatabase, defMongoDatabase)
 	cfg.SetDefault(keySolCompilerPath, defSolCompilerPath)
@@ -124,18 +306,105 @@ func applyDefaults(cfg *viper.Viper) {
 	cfg.SetDefault(keyCacheEvictionTime, defCacheEvictionTime)
 	cfg.SetDefault(keyCacheMaxSize, defCacheMaxSize)
 
+	// persistent cache tier is disabled by default
+	cfg.SetDefault(keyCacheDiskEnabled, false)
+	cfg.SetDefault(keyCacheDiskPath, defCacheDiskPath)
+	cfg.SetDefault(keyCacheDiskMaxSize, defCacheDiskMaxSize)
+	cfg.SetDefault(keyCacheDiskMaxAge, defCacheDiskMaxAge)
+
 	// server timeouts
 	cfg.SetDefault(keyTimeoutRead, defReadTimeout)
 	cfg.SetDefault(keyTimeoutWrite, defWriteTimeout)
 	cfg.SetDefault(keyTimeoutHeader, defHeaderTimeout)
 	cfg.SetDefault(keyTimeoutIdle, defIdleTimeout)
 	cfg.SetDefault(keyTimeoutResolver, defResolverTimeout)
+	cfg.SetDefault(keyTimeoutResolvers, defResolverTimeouts)
+	cfg.SetDefault(keyTimeoutShutdown, defShutdownTimeout)
+
+	// API key authentication is disabled by default
+	cfg.SetDefault(keyAuthEnabled, false)
+	cfg.SetDefault(keyAuthRequireKey, false)
+
+	// administrative operations are never open without an API key by default
+	cfg.SetDefault(keyAuthOpenAdmin, false)
+
+	// server query limits
+	cfg.SetDefault(keyMaxQueryDepth, defMaxQueryDepth)
+	cfg.SetDefault(keyMaxQueryComplexity, defMaxQueryComplexity)
+
+	// transactions can be submitted through the API by default
+	cfg.SetDefault(keyAllowSendTransaction, true)
+
+	// raw contract storage reads are a low-level primitive, disabled by default
+	cfg.SetDefault(keyAllowStorageAt, false)
+
+	// simulated calls load the node, disabled by default
+	cfg.SetDefault(keyAllowSimulateCall, false)
+	cfg.SetDefault(keySimulateCallTimeout, defSimulateCallTimeout)
+
+	// schema introspection is enabled by default, production deployments may disable it
+	cfg.SetDefault(keyAllowIntrospection, true)
+
+	// documents are loaded with the fields needed by the query only
+	cfg.SetDefault(keyFieldProjection, true)
+
+	// the schema is not federated by default
+	cfg.SetDefault(keyFederation, false)
+
+	// gas price tiers
+	cfg.SetDefault(keyRepositoryGasPriceBlocks, defGasPriceBlocks)
+
+	// rich list
+	cfg.SetDefault(keyRepositoryRichListRefresh, defRichListRefresh)
+
+	// block scan
+	cfg.SetDefault(keyRepositoryScanWorkers, defScanWorkers)
+	cfg.SetDefault(keyRepositoryScanBatch, defScanBatch)
+
+	// transaction finality
+	cfg.SetDefault(keyRepositoryConfirmations, defConfirmations)
+
+	// input decoding
+	cfg.SetDefault(keyRepositoryMaxDecodedInput, defMaxDecodedInput)
+
+	// batch calls decoding
+	cfg.SetDefault(keyRepositoryBatchSelectors, defBatchSelectors)
+	cfg.SetDefault(keyRepositoryMaxBatchDepth, defMaxBatchDepth)
+	cfg.SetDefault(keyRepositoryMaxBatchCalls, defMaxBatchCalls)
+	cfg.SetDefault(keyRepositoryMaxBatchContracts, defMaxBatchContracts)
+
+	// contract calls are not aggregated unless the Multicall contract is configured
+	cfg.SetDefault(keyRepositoryMulticall, EmptyAddress)
+
+	// generic contracts emitting ERC20 transfers are promoted to tokens
+	cfg.SetDefault(keyRepositoryErc20Heuristic, defErc20HeuristicTransfers)
+
+	// contract calls analysis
+	cfg.SetDefault(keyRepositoryAnalysisWorkers, defAnalysisWorkers)
 
 	// no voting sources by default
 	cfg.SetDefault(keyVotingSources, defVotingSources)
 
 	// cors
 	cfg.SetDefault(keyCorsAllowOrigins, defCorsAllowOrigins)
+	cfg.SetDefault(keyCorsAdminAllowOrigins, defCorsAdminAllowOrigins)
+
+	// batch queries limits
+	cfg.SetDefault(keyMaxBatchAccounts, defMaxBatchAccounts)
+	cfg.SetDefault(keyMaxLogBlockRange, defMaxLogBlockRange)
+	cfg.SetDefault(keyMaxPageSize, defMaxPageSize)
+
+	// expensive read-only resolvers results cache
+	cfg.SetDefault(keyResultCache, defResultCache)
+
+	// TLS is disabled by default, an external proxy is expected to terminate it
+	cfg.SetDefault(keyTlsCertFile, "")
+	cfg.SetDefault(keyTlsKeyFile, "")
+	cfg.SetDefault(keyTlsAutoCert, false)
+	cfg.SetDefault(keyTlsAutoCertCache, defTlsAutoCertCache)
+	cfg.SetDefault(keyTlsRedirectBind, "")
+	cfg.SetDefault(keyCorsAdminCredentials, false)
+	cfg.SetDefault(keyCorsMaxAge, defCorsMaxAge)
 
 	// staking configuration defaults
 	cfg.SetDefault(keyStakingNetworkInitializerContract, defNetworkInitializerContract)
@@ -144,8 +413,63 @@ func applyDefaults(cfg *viper.Viper) {
 	cfg.SetDefault(keyStakingTokenizerContract, EmptyAddress)
 	cfg.SetDefault(keyStakingERC20Token, EmptyAddress)
 
+	// name service is disabled by default
+	cfg.SetDefault(keyNameServiceRegistry, EmptyAddress)
+
+	// remote ABI source is disabled by default
+	cfg.SetDefault(keyAbiSourceEnabled, false)
+	cfg.SetDefault(keyAbiSourceUrl, defAbiSourceUrl)
+	cfg.SetDefault(keyAbiSourceTimeout, defAbiSourceTimeout)
+
+	// nothing is denied by default
+	cfg.SetDefault(keyDenylistAddresses, []string{})
+	cfg.SetDefault(keyDenylistSelectors, []string{})
+	cfg.SetDefault(keyDenylistAllow, []string{})
+	cfg.SetDefault(keyDenylistFile, "")
+	cfg.SetDefault(keyDenylistReload, defDenylistReload)
+
+	// remote token list is disabled by default
+	cfg.SetDefault(keyTokenListEnabled, false)
+	cfg.SetDefault(keyTokenListUrl, "")
+	cfg.SetDefault(keyTokenListRefresh, defTokenListRefresh)
+	cfg.SetDefault(keyTokenListTimeout, defTokenListTimeout)
+
+	// all the API features are enabled by default
+	cfg.SetDefault(keyFeaturesStaking, true)
+	cfg.SetDefault(keyFeaturesDeFi, true)
+	cfg.SetDefault(keyFeaturesTokens, true)
+	cfg.SetDefault(keyFeaturesMutations, true)
+	cfg.SetDefault(keyFeaturesAdmin, true)
+	cfg.SetDefault(keyFeaturesTrace, true)
+
+	// notification webhooks are disabled by default
+	cfg.SetDefault(keyWebhooksEnabled, false)
+	cfg.SetDefault(keyWebhooksSecret, "")
+	cfg.SetDefault(keyWebhooksTimeout, defWebhooksTimeout)
+	cfg.SetDefault(keyWebhooksMaxRetries, defWebhooksMaxRetries)
+	cfg.SetDefault(keyWebhooksRetryDelay, defWebhooksRetryDelay)
+
+	// data retention; logs, gas price periods and token prices are kept forever by default
+	cfg.SetDefault(keyRetentionSweep, defRetentionSweep)
+	cfg.SetDefault(keyRetentionLogs, 0)
+	cfg.SetDefault(keyRetentionGasPrice, 0)
+	cfg.SetDefault(keyRetentionWebhookDeliveries, defRetentionWebhookDeliveries)
+	cfg.SetDefault(keyRetentionTokenPrices, 0)
+
+	// warm-up queries; the list of queries is empty by default
+	cfg.SetDefault(keyWarmupInterval, defWarmupInterval)
+	cfg.SetDefault(keyWarmupConcurrency, defWarmupConcurrency)
+
 	// DeFi configuration
 	cfg.SetDefault(keyDefiFMintAddressProvider, defDefiFMintAddressProvider)
 	cfg.SetDefault(keyDefiUniswapCore, defDefiUniswapCore)
 	cfg.SetDefault(keyDefiUniswapRouter, defDefiUniswapRouter)
+	cfg.SetDefault(keyDefiUniswapQuoteToken, EmptyAddress)
+	cfg.SetDefault(keyDefiUniswapBaseTokens, []string{})
+	cfg.SetDefault(keyDefiUniswapMinReserve, defDefiUniswapMinReserve)
+	cfg.SetDefault(keyDefiUniswapWatchedPairs, []string{})
+
+	// no token prices are sampled by default
+	cfg.SetDefault(keyDefiUniswapPricePairs, []string{})
+	cfg.SetDefault(keyDefiUniswapPriceSampling, defDefiUniswapPriceSampling)
 }
@@ -13,6 +13,11 @@ const (
 	keyConfigCmdBlockScanReScan = "cmd.rescan"
 	keyConfigCmdRestoreStake    = "cmd.fix_stake"
 
+	// network profile related keys
+	keyNetwork         = "network"
+	keyNetworkProfiles = "networks"
+	envNetwork         = "API_NETWORK"
+
 	// server related keys
 	keyBindAddress      = "server.bind"
 	keyDomainAddress    = "server.domain"
@@ -20,23 +25,104 @@ const (
 	keyApiStateOrigin   = "server.origin"
 	keyCorsAllowOrigins = "server.cors_origins"
 
+	// query limits related keys
+	keyMaxBatchAccounts = "server.max_batch_accounts"
+	keyMaxLogBlockRange = "server.max_log_block_range"
+	keyMaxPageSize      = "server.max_page_size"
+
+	// resolver result cache related keys
+	keyResultCache = "server.result_cache"
+
+	// TLS related keys
+	keyTlsCertFile      = "server.tls.cert"
+	keyTlsKeyFile       = "server.tls.key"
+	keyTlsAutoCert      = "server.tls.autocert"
+	keyTlsAutoCertCache = "server.tls.autocert_cache"
+	keyTlsRedirectBind  = "server.tls.redirect_bind"
+
+	// CORS related keys
+	keyCorsAdminAllowOrigins = "server.cors_admin_origins"
+	keyCorsAdminCredentials  = "server.cors_admin_credentials"
+	keyCorsMaxAge            = "server.cors_max_age"
+
 	// server time out related keys
-	keyTimeoutRead     = "server.read_timeout"
-	keyTimeoutWrite    = "server.write_timeout"
-	keyTimeoutIdle     = "server.idle_timeout"
-	keyTimeoutHeader   = "server.header_timeout"
-	keyTimeoutResolver = "server.resolver_timeout"
+	keyTimeoutRead      = "server.read_timeout"
+	keyTimeoutWrite     = "server.write_timeout"
+	keyTimeoutIdle      = "server.idle_timeout"
+	keyTimeoutHeader    = "server.header_timeout"
+	keyTimeoutResolver  = "server.resolver_timeout"
+	keyTimeoutResolvers = "server.resolver_timeouts"
+	keyTimeoutShutdown  = "server.shutdown_timeout"
+
+	// server query limits related keys
+	keyMaxQueryDepth      = "server.max_query_depth"
+	keyMaxQueryComplexity = "server.max_query_complexity"
+
+	// repository related keys
+	keyRepositoryGasPriceBlocks    = "repository.gas_price_blocks"
+	keyRepositoryRichListRefresh   = "repository.rich_list_refresh"
+	keyRepositoryScanWorkers       = "repository.scan_workers"
+	keyRepositoryScanBatch         = "repository.scan_batch"
+	keyRepositoryMaxDecodedInput   = "repository.max_decoded_input"
+	keyRepositoryConfirmations     = "repository.confirmations"
+	keyRepositoryBatchSelectors    = "repository.batch_selectors"
+	keyRepositoryMaxBatchDepth     = "repository.max_batch_depth"
+	keyRepositoryMaxBatchCalls     = "repository.max_batch_calls"
+	keyRepositoryMaxBatchContracts = "repository.max_batch_contracts"
+	keyRepositoryMulticall         = "repository.multicall"
+	keyRepositoryErc20Heuristic    = "repository.erc20_heuristic_transfers"
+	keyRepositoryAnalysisWorkers   = "repository.analysis_workers"
+
+	// transaction submission related keys
+	keyAllowSendTransaction = "server.allow_send_trx"
+
+	// contract storage access related keys
+	keyAllowStorageAt = "server.allow_storage_at"
+
+	// simulated calls related keys
+	keyAllowSimulateCall   = "server.allow_simulate_call"
+	keySimulateCallTimeout = "server.simulate_call_timeout"
+
+	// schema introspection related keys
+	keyAllowIntrospection = "server.allow_introspection"
+
+	// stored documents projection related keys
+	keyFieldProjection = "server.field_projection"
+
+	// federation gateway composition related keys
+	keyFederation = "server.federation"
+
+	// API key authentication related keys
+	keyAuthEnabled    = "auth.enabled"
+	keyAuthRequireKey = "auth.require_key"
+	keyAuthOpenAdmin  = "auth.open_admin"
 
 	// API server signature related keys
 	keySignatureAddress    = "me.address"
 	keySignaturePrivateKey = "me.pkey"
+	keySignResponses       = "me.sign_responses"
 
 	// logging related options
 	keyLoggingLevel  = "log.level"
 	keyLoggingFormat = "log.format"
 
+	// request logging related options
+	keyLoggingRequestLevel    = "log.request_level"
+	keyLoggingRequestSampling = "log.request_sampling"
+	keyLoggingSlowResolver    = "log.slow_resolver"
+
+	// log volume and output related options
+	keyLoggingDebugRateLimit = "log.debug_rate_limit"
+	keyLoggingFileMaxSize    = "log.file.max_size"
+	keyLoggingFileMaxBackups = "log.file.max_backups"
+	keyLoggingFileMaxAge     = "log.file.max_age"
+
 	// node connection related options
-	keyOperaUrl = "opera.url"
+	keyOperaUrl            = "opera.url"
+	keyOperaPendingPool    = "opera.pending_pool"
+	keyOperaPendingTimeout = "opera.pending_timeout"
+	keyOperaMaxBatchSize   = "opera.max_batch_size"
+	keyOperaArchive        = "opera.archive"
 
 	// off-chain database related options
 	keyMongoUrl      = "db.url"
@@ -45,6 +131,10 @@ const (
 	// cache related options
 	keyCacheEvictionTime = "cache.eviction"
 	keyCacheMaxSize      = "cache.size"
+	keyCacheDiskEnabled  = "cache.disk.enabled"
+	keyCacheDiskPath     = "cache.disk.path"
+	keyCacheDiskMaxSize  = "cache.disk.size"
+	keyCacheDiskMaxAge   = "cache.disk.max_age"
 
 	// contract validation related
 	keySolCompilerPath = "compiler.sol"
@@ -61,8 +151,61 @@ const (
 	keyStakingTokenizerContract          = "staking.tokenizer"
 	keyStakingERC20Token                 = "staking.token"
 
+	// name service configuration
+	keyNameServiceRegistry = "name_service.registry"
+
+	// remote ABI source configuration
+	keyAbiSourceEnabled = "abi_source.enabled"
+	keyAbiSourceUrl     = "abi_source.url"
+	keyAbiSourceTimeout = "abi_source.timeout"
+
+	// analysis denylist configuration
+	keyDenylistAddresses = "denylist.addresses"
+	keyDenylistSelectors = "denylist.selectors"
+	keyDenylistAllow     = "denylist.allow"
+	keyDenylistFile      = "denylist.file"
+	keyDenylistReload    = "denylist.reload"
+
+	// remote token list configuration
+	keyTokenListEnabled = "token_list.enabled"
+	keyTokenListUrl     = "token_list.url"
+	keyTokenListRefresh = "token_list.refresh"
+	keyTokenListTimeout = "token_list.timeout"
+
+	// API feature groups configuration
+	keyFeaturesStaking   = "features.staking"
+	keyFeaturesDeFi      = "features.defi"
+	keyFeaturesTokens    = "features.tokens"
+	keyFeaturesMutations = "features.mutations"
+	keyFeaturesAdmin     = "features.admin"
+	keyFeaturesTrace     = "features.trace"
+
+	// notification webhooks configuration
+	keyWebhooksEnabled    = "webhooks.enabled"
+	keyWebhooksSecret     = "webhooks.secret"
+	keyWebhooksTimeout    = "webhooks.timeout"
+	keyWebhooksMaxRetries = "webhooks.max_retries"
+	keyWebhooksRetryDelay = "webhooks.retry_delay"
+
+	// data retention configuration
+	keyRetentionSweep             = "retention.sweep"
+	keyRetentionLogs              = "retention.logs"
+	keyRetentionGasPrice          = "retention.gas_price"
+	keyRetentionWebhookDeliveries = "retention.webhook_deliveries"
+	keyRetentionTokenPrices       = "retention.token_prices"
+
+	// warm-up queries configuration
+	keyWarmupInterval    = "warmup.interval"
+	keyWarmupConcurrency = "warmup.concurrency"
+
 	// defi related configs
 	keyDefiFMintAddressProvider = "defi.fmint.address_provider"
 	keyDefiUniswapCore          = "defi.uniswap.core"
 	keyDefiUniswapRouter        = "defi.uniswap.router"
+	keyDefiUniswapQuoteToken    = "defi.uniswap.quote_token"
+	keyDefiUniswapBaseTokens    = "defi.uniswap.base_tokens"
+	keyDefiUniswapMinReserve    = "defi.uniswap.min_reserve"
+	keyDefiUniswapWatchedPairs  = "defi.uniswap.watched_pairs"
+	keyDefiUniswapPricePairs    = "defi.uniswap.price_pairs"
+	keyDefiUniswapPriceSampling = "defi.uniswap.price_sampling"
 )
@@ -4,6 +4,7 @@ import (
 	"crypto/ecdsa"
 	"encoding/json"
 	"flag"
+	"fmt"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/mitchellh/mapstructure"
@@ -12,8 +13,12 @@ import (
 	"log"
 	"os"
 	"reflect"
+	"strings"
 )
 
+// cliNetwork holds the network profile name requested on the command line.
+var cliNetwork string
+
 // Load provides a loaded configuration for Fantom API server.
 func Load() (*Config, error) {
 	// Get the config reader
@@ -33,6 +38,24 @@ func Load() (*Config, error) {
 		return nil, err
 	}
 
+	// make sure the CORS setup is valid
+	if err = validateCors(&config.Server); err != nil {
+		log.Println("invalid CORS configuration")
+		return nil, err
+	}
+
+	// make sure the TLS setup is complete
+	if err = validateTls(&config.Server); err != nil {
+		log.Println("invalid TLS configuration")
+		return nil, err
+	}
+
+	// make sure we can connect the node
+	if err = validateOpera(&config.Opera); err != nil {
+		log.Println("invalid node connection configuration")
+		return nil, err
+	}
+
 	// try to load the logo map file
 	loadErc20LogMap(&config)
 
@@ -44,6 +67,7 @@ func Load() (*Config, error) {
 func attachCliFlags(cfg *Config) {
 	flag.Uint64Var(&cfg.RepoCommand.BlockScanReScan, keyConfigCmdBlockScanReScan, defBlockScanRescanDepth, "How many blocks are re-scanned on the server start.")
 	flag.StringVar(&cfg.RepoCommand.RestoreStake, keyConfigCmdRestoreStake, "", "Owner of the stake to be restored.")
+	flag.StringVar(&cliNetwork, keyNetwork, "", "Name of the network profile to be used.")
 }
 
 // readConfigFile reads the config file and provides instance
@@ -71,9 +95,48 @@ func readConfigFile() (*viper.Viper, error) {
 		log.Print("configuration file not found, using default values")
 	}
 
+	// apply the selected network profile over the loaded configuration
+	if err := applyNetworkProfile(cfg); err != nil {
+		return nil, err
+	}
 	return cfg, nil
 }
 
+// applyNetworkProfile merges the selected network profile over the configuration.
+// The profile is selected by the CLI flag, the environment variable,
+// or the network option of the config file, in this order.
+func applyNetworkProfile(cfg *viper.Viper) error {
+	name := cliNetwork
+	if name == "" {
+		name = os.Getenv(envNetwork)
+	}
+	if name == "" {
+		name = cfg.GetString(keyNetwork)
+	}
+	if name == "" {
+		return nil
+	}
+
+	// the profile must exist; config keys are case insensitive
+	profile, ok := cfg.GetStringMap(keyNetworkProfiles)[strings.ToLower(name)]
+	if !ok {
+		return fmt.Errorf("network profile %s not found", name)
+	}
+	overrides, ok := profile.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("network profile %s is not a valid configuration", name)
+	}
+
+	if err := cfg.MergeConfigMap(overrides); err != nil {
+		log.Printf("can not apply network profile %s", name)
+		return err
+	}
+	cfg.Set(keyNetwork, name)
+
+	log.Printf("network profile %s active", name)
+	return nil
+}
+
 // loadErc20LogMap loads the map of ERC20 token logos.
 func loadErc20LogMap(cfg *Config) {
 	// is there any path at all?
@@ -0,0 +1,38 @@
+// Package config handles API server configuration binding and loading.
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// operaUrlSchemes lists the supported schemes of the node connection;
+// a path without a scheme connects the local IPC socket.
+var operaUrlSchemes = map[string]bool{
+	"http":  false,
+	"https": false,
+	"ws":    true,
+	"wss":   true,
+}
+
+// validateOpera checks the node connection so an unsupported transport is caught
+// on startup. Subscriptions are available on IPC and WebSocket connections only.
+func validateOpera(cfg *Opera) error {
+	if cfg.Url == "" {
+		return fmt.Errorf("node connection url not set")
+	}
+
+	i := strings.Index(cfg.Url, "://")
+	if i < 0 {
+		return nil
+	}
+
+	subscribe, ok := operaUrlSchemes[strings.ToLower(cfg.Url[:i])]
+	if !ok {
+		return fmt.Errorf("node connection scheme %s not supported, use ws://, wss://, http://, https:// or IPC path", cfg.Url[:i])
+	}
+	if !subscribe && cfg.PendingPool {
+		return fmt.Errorf("pending pool observation requires WebSocket or IPC node connection")
+	}
+	return nil
+}
@@ -0,0 +1,25 @@
+// Package config handles API server configuration binding and loading.
+package config
+
+import (
+	"fmt"
+)
+
+// validateTls checks the TLS termination setup so an incomplete configuration
+// is caught on startup instead of silently serving plain HTTP.
+func validateTls(cfg *Server) error {
+	t := &cfg.TLS
+	if (t.CertFile == "") != (t.KeyFile == "") {
+		return fmt.Errorf("both TLS certificate and key files are required")
+	}
+	if t.AutoCert && t.CertFile != "" {
+		return fmt.Errorf("TLS certificate files can not be combined with autocert")
+	}
+	if t.AutoCert && t.AutoCertCache == "" {
+		return fmt.Errorf("TLS autocert requires a certificate cache directory")
+	}
+	if t.RedirectBind != "" && !t.Enabled() {
+		return fmt.Errorf("HTTPS redirect requires TLS to be configured")
+	}
+	return nil
+}
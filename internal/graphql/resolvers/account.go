@@ -2,6 +2,7 @@
 package resolvers
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository"
 	"fantom-api-graphql/internal/types"
 	"github.com/ethereum/go-ethereum/common"
@@ -17,6 +18,9 @@ const accMaxTransactionsPerRequest = 250
 type Account struct {
 	types.Account
 	cg singleflight.Group
+
+	// balance is the balance pre-loaded by batch queries, if any
+	balance *hexutil.Big
 }
 
 // NewAccount builds new resolvable account structure.
@@ -42,8 +46,27 @@ func (rs *rootResolver) AccountsActive() (hexutil.Uint64, error) {
 	return repository.R().AccountsActive()
 }
 
-// Balance resolves total balance of the account.
-func (acc *Account) Balance() (hexutil.Big, error) {
+// Balance resolves total balance of the account at the block identified by the given tag,
+// or at the latest block if the tag is not provided.
+func (acc *Account) Balance(args struct{ BlockTag *BlockTag }) (hexutil.Big, error) {
+	if args.BlockTag == nil {
+		return acc.currentBalance()
+	}
+
+	val, err := repository.R().AccountBalanceAt(&acc.Address, blockTagOf(args.BlockTag))
+	if err != nil {
+		return hexutil.Big{}, err
+	}
+	return *val, nil
+}
+
+// currentBalance resolves the balance of the account at the latest block.
+func (acc *Account) currentBalance() (hexutil.Big, error) {
+	// pre-loaded already?
+	if acc.balance != nil {
+		return *acc.balance, nil
+	}
+
 	// get the balance
 	val, err, _ := acc.cg.Do("balance", func() (interface{}, error) {
 		return repository.R().AccountBalance(&acc.Address)
@@ -59,7 +82,7 @@ func (acc *Account) Balance() (hexutil.Big, error) {
 // TotalValue resolves account total value including delegated amount and pending rewards.
 func (acc *Account) TotalValue() (hexutil.Big, error) {
 	// get the balance
-	balance, err := acc.Balance()
+	balance, err := acc.currentBalance()
 	if err != nil {
 		return hexutil.Big{}, err
 	}
@@ -87,17 +110,21 @@ func (acc *Account) TxCount() (hexutil.Uint64, error) {
 }
 
 // TxList resolves list of transaction associated with the account.
-func (acc *Account) TxList(args struct {
+func (acc *Account) TxList(ctx context.Context, args struct {
 	Recipient *common.Address
 	Cursor    *Cursor
 	Count     int32
 }) (*TransactionList, error) {
 	// limit query size; the count can be either positive or negative
 	// this controls the loading direction
-	args.Count = listLimitCount(args.Count, accMaxTransactionsPerRequest)
+	count, err := listPageSize(ctx, args.Count, accMaxTransactionsPerRequest)
+	if err != nil {
+		return nil, err
+	}
+	args.Count = count
 
 	// get the transaction hash list from repository
-	bl, err := repository.R().AccountTransactions(&acc.Address, args.Recipient, (*string)(args.Cursor), args.Count)
+	bl, err := repository.R().AccountTransactions(&acc.Address, args.Recipient, (*string)(args.Cursor), args.Count, trxListFields(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -105,8 +132,26 @@ func (acc *Account) TxList(args struct {
 	return NewTransactionList(bl), nil
 }
 
+// UniswapSwaps resolves list of uniswap token swaps made by the account.
+func (acc *Account) UniswapSwaps(ctx context.Context, args struct {
+	Cursor *Cursor
+	Count  int32
+}) (*UniswapActionList, error) {
+	count, err := listPageSize(ctx, args.Count, listMaxEdgesPerRequest)
+	if err != nil {
+		return nil, err
+	}
+	args.Count = count
+
+	al, err := repository.R().UniswapActions(nil, &acc.Address, (*string)(args.Cursor), args.Count, types.SwapTrade)
+	if err != nil {
+		return nil, err
+	}
+	return NewUniswapActionList(al), nil
+}
+
 // Erc20TxList resolves list of ERC20 transactions associated with the account.
-func (acc *Account) Erc20TxList(args struct {
+func (acc *Account) Erc20TxList(ctx context.Context, args struct {
 	Cursor *Cursor
 	Count  int32
 	Token  *common.Address
@@ -114,7 +159,11 @@ func (acc *Account) Erc20TxList(args struct {
 }) (*ERC20TransactionList, error) {
 	// limit query size; the count can be either positive or negative
 	// this controls the loading direction
-	args.Count = listLimitCount(args.Count, accMaxTransactionsPerRequest)
+	count, err := listPageSize(ctx, args.Count, accMaxTransactionsPerRequest)
+	if err != nil {
+		return nil, err
+	}
+	args.Count = count
 
 	// get the transaction hash list from repository
 	tl, err := repository.R().TokenTransactions(
@@ -134,7 +183,7 @@ func (acc *Account) Erc20TxList(args struct {
 }
 
 // Erc721TxList resolves list of ERC721 transactions associated with the account.
-func (acc *Account) Erc721TxList(args struct {
+func (acc *Account) Erc721TxList(ctx context.Context, args struct {
 	Cursor  *Cursor
 	Count   int32
 	Token   *common.Address
@@ -143,7 +192,11 @@ func (acc *Account) Erc721TxList(args struct {
 }) (*ERC721TransactionList, error) {
 	// limit query size; the count can be either positive or negative
 	// this controls the loading direction
-	args.Count = listLimitCount(args.Count, accMaxTransactionsPerRequest)
+	count, err := listPageSize(ctx, args.Count, accMaxTransactionsPerRequest)
+	if err != nil {
+		return nil, err
+	}
+	args.Count = count
 
 	// get the transaction hash list from repository
 	tl, err := repository.R().TokenTransactions(
@@ -163,7 +216,7 @@ func (acc *Account) Erc721TxList(args struct {
 }
 
 // Erc1155TxList resolves list of ERC1155 transactions associated with the account.
-func (acc *Account) Erc1155TxList(args struct {
+func (acc *Account) Erc1155TxList(ctx context.Context, args struct {
 	Cursor  *Cursor
 	Count   int32
 	Token   *common.Address
@@ -172,7 +225,11 @@ func (acc *Account) Erc1155TxList(args struct {
 }) (*ERC1155TransactionList, error) {
 	// limit query size; the count can be either positive or negative
 	// this controls the loading direction
-	args.Count = listLimitCount(args.Count, accMaxTransactionsPerRequest)
+	count, err := listPageSize(ctx, args.Count, accMaxTransactionsPerRequest)
+	if err != nil {
+		return nil, err
+	}
+	args.Count = count
 
 	// get the transaction hash list from repository
 	tl, err := repository.R().TokenTransactions(
@@ -207,13 +264,17 @@ func (acc *Account) Staker() (*Staker, error) {
 }
 
 // Delegations resolves a list of account delegations, if the account is a delegator.
-func (acc *Account) Delegations(args *struct {
+func (acc *Account) Delegations(ctx context.Context, args *struct {
 	Cursor *Cursor
 	Count  int32
 }) (*DelegationList, error) {
 	// limit query size; the count can be either positive or negative
 	// this controls the loading direction
-	args.Count = listLimitCount(args.Count, listMaxEdgesPerRequest)
+	count, err := listPageSize(ctx, args.Count, listMaxEdgesPerRequest)
+	if err != nil {
+		return nil, err
+	}
+	args.Count = count
 
 	// pull the list
 	dl, err := repository.R().DelegationsByAddress(&acc.Address, (*string)(args.Cursor), args.Count)
@@ -227,15 +288,15 @@ func (acc *Account) Delegations(args *struct {
 
 // Contract resolves the account smart contract detail,
 // if the account is a smart contract address.
-func (acc *Account) Contract() (*Contract, error) {
+func (acc *Account) Contract(ctx context.Context) (*Contract, error) {
 	// is this actually a contract account?
 	if acc.ContractTx == nil {
 		return nil, nil
 	}
 
 	// get new contract
-	con, err := repository.R().Contract(&acc.Address)
-	if err != nil {
+	con, err := loadContract(ctx, &acc.Address)
+	if err != nil || con == nil {
 		return nil, err
 	}
 	return NewContract(con), nil
@@ -285,3 +346,15 @@ func (acc *Account) delegationsTotal() (amount *big.Int, inWithdraw *big.Int, re
 
 	return amount, rewards, inWithdraw, nil
 }
+
+// Stats resolves the transaction activity summary of the account.
+func (acc *Account) Stats() (*types.AccountStats, error) {
+	val, err, _ := acc.cg.Do("stats", func() (interface{}, error) {
+		return repository.R().AccountStats(&acc.Address)
+	})
+	if err != nil {
+		log.Errorf("can not get stats of %s; %s", acc.Address.String(), err.Error())
+		return nil, err
+	}
+	return val.(*types.AccountStats), nil
+}
@@ -0,0 +1,150 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"golang.org/x/sync/singleflight"
+)
+
+// AccountActivity represents resolvable list of contracts called by an account.
+type AccountActivity struct {
+	types.AccountActivity
+	cg *singleflight.Group
+}
+
+// AccountActivityEdge represents a single edge of the account activity list.
+type AccountActivityEdge struct {
+	types.ContractActivity
+	list *AccountActivity
+}
+
+// FunctionCallCount represents resolvable number of calls of a contract function.
+type FunctionCallCount struct {
+	types.FunctionCallCount
+}
+
+// AccountActivity resolves a list of contracts called by the given account.
+func (rs *rootResolver) AccountActivity(ctx context.Context, args *struct {
+	Address common.Address
+	Cursor  *Cursor
+	Count   int32
+}) (*AccountActivity, error) {
+	// limit query size; the count can be either positive or negative
+	// this controls the loading direction
+	count, err := listPageSize(ctx, args.Count, listMaxEdgesPerRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	aa, err := repository.R().AccountActivity(&args.Address, (*string)(args.Cursor), count)
+	if err != nil {
+		return nil, err
+	}
+	return &AccountActivity{AccountActivity: *aa, cg: new(singleflight.Group)}, nil
+}
+
+// TotalCount resolves the total number of contracts called by the account.
+func (aa *AccountActivity) TotalCount() hexutil.Uint64 {
+	return hexutil.Uint64(aa.Total)
+}
+
+// PageInfo resolves the current page information for the account activity list.
+func (aa *AccountActivity) PageInfo() (*ListPageInfo, error) {
+	// do we have any items?
+	if aa.Collection == nil || len(aa.Collection) == 0 {
+		return NewListPageInfo(nil, nil, false, false)
+	}
+
+	// get the first and last elements
+	first := Cursor(aa.Collection[0].Contract.String())
+	last := Cursor(aa.Collection[len(aa.Collection)-1].Contract.String())
+	return NewListPageInfo(&first, &last, !aa.IsEnd, !aa.IsStart)
+}
+
+// Edges resolves list of edges of the account activity list.
+func (aa *AccountActivity) Edges() []*AccountActivityEdge {
+	edges := make([]*AccountActivityEdge, len(aa.Collection))
+	for i, ca := range aa.Collection {
+		edges[i] = &AccountActivityEdge{ContractActivity: *ca, list: aa}
+	}
+	return edges
+}
+
+// contracts loads known contracts of the list only once, so the labels
+// of all the edges are resolved in a single batch.
+func (aa *AccountActivity) contracts() (map[common.Address]*types.Contract, error) {
+	sc, err, _ := aa.cg.Do("contracts", func() (interface{}, error) {
+		addr := make([]common.Address, len(aa.Collection))
+		for i, ca := range aa.Collection {
+			addr[i] = ca.Contract
+		}
+		return repository.R().ContractsByAddress(addr)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return sc.(map[common.Address]*types.Contract), nil
+}
+
+// Cursor generates the cursor for the current account activity edge.
+func (aae *AccountActivityEdge) Cursor() Cursor {
+	return Cursor(aae.Contract.String())
+}
+
+// Label resolves the name of the known contract, if available.
+func (aae *AccountActivityEdge) Label() (*string, error) {
+	sc, err := aae.list.contracts()
+	if err != nil {
+		return nil, err
+	}
+
+	c, ok := sc[aae.Contract]
+	if !ok || c.Name == "" {
+		return nil, nil
+	}
+	return &c.Name, nil
+}
+
+// Calls resolves the total number of calls to the contract.
+func (aae *AccountActivityEdge) Calls() hexutil.Uint64 {
+	return hexutil.Uint64(aae.ContractActivity.Calls)
+}
+
+// LastCall resolves the time stamp of the most recent call in unix seconds.
+func (aae *AccountActivityEdge) LastCall() hexutil.Uint64 {
+	return hexutil.Uint64(aae.ContractActivity.LastCall.Unix())
+}
+
+// Functions resolves the breakdown of the calls by the called function.
+func (aae *AccountActivityEdge) Functions() []FunctionCallCount {
+	list := make([]FunctionCallCount, len(aae.ContractActivity.Functions))
+	for i, fn := range aae.ContractActivity.Functions {
+		list[i] = FunctionCallCount{FunctionCallCount: *fn}
+	}
+	return list
+}
+
+// Name resolves the name of the called function, nil if not known from the contract ABI.
+func (fc FunctionCallCount) Name() *string {
+	if fc.FunctionCallCount.Name == "" {
+		return nil
+	}
+	return &fc.FunctionCallCount.Name
+}
+
+// Signature resolves the signature of the called function, nil if not known from the contract ABI.
+func (fc FunctionCallCount) Signature() *string {
+	if fc.FunctionCallCount.Signature == "" {
+		return nil
+	}
+	return &fc.FunctionCallCount.Signature
+}
+
+// Calls resolves the number of calls of the function.
+func (fc FunctionCallCount) Calls() hexutil.Uint64 {
+	return hexutil.Uint64(fc.FunctionCallCount.Calls)
+}
@@ -0,0 +1,102 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"fantom-api-graphql/internal/repository"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"sync"
+)
+
+// accountBatchWorkers is the number of concurrent account loaders of a batch query.
+const accountBatchWorkers = 8
+
+// AccountResult represents resolvable result of a single account of a batch query.
+type AccountResult struct {
+	Address common.Address
+	Account *Account
+	Error   *string
+
+	// balance is the balance pre-loaded for the whole batch, if available
+	balance *hexutil.Big
+}
+
+// Accounts resolves a batch of accounts by their addresses. Repeated addresses are
+// resolved only once. Accounts failing to load are reported with the error
+// instead of failing the whole batch.
+func (rs *rootResolver) Accounts(args struct{ Addresses []common.Address }) ([]*AccountResult, error) {
+	if len(args.Addresses) > cfg.Server.MaxBatchAccounts {
+		return nil, fmt.Errorf("too many addresses requested, %d allowed", cfg.Server.MaxBatchAccounts)
+	}
+
+	// remove duplicates keeping the order of the first appearance
+	seen := make(map[common.Address]bool, len(args.Addresses))
+	list := make([]*AccountResult, 0, len(args.Addresses))
+	for _, adr := range args.Addresses {
+		if !seen[adr] {
+			seen[adr] = true
+			list = append(list, &AccountResult{Address: adr})
+		}
+	}
+
+	// balances of the whole batch are loaded in a single round-trip;
+	// the accounts missing the balance load it on their own
+	addr := make([]common.Address, len(list))
+	for i, ar := range list {
+		addr[i] = ar.Address
+	}
+	if bal, err := repository.R().AccountBalances(addr); err == nil {
+		for i, ar := range list {
+			ar.balance = bal[i]
+		}
+	}
+
+	// load the accounts by a bounded pool of workers
+	queue := make(chan *AccountResult, len(list))
+	for _, ar := range list {
+		queue <- ar
+	}
+	close(queue)
+
+	var wg sync.WaitGroup
+	for i := 0; i < accountBatchWorkers && i < len(list); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ar := range queue {
+				ar.load()
+			}
+		}()
+	}
+	wg.Wait()
+	return list, nil
+}
+
+// load loads the account and its balance into the batch result.
+func (ar *AccountResult) load() {
+	acc, err := repository.R().Account(&ar.Address)
+	if err != nil {
+		ar.fail(err)
+		return
+	}
+
+	bal := ar.balance
+	if bal == nil {
+		bal, err = repository.R().AccountBalance(&ar.Address)
+		if err != nil {
+			ar.fail(err)
+			return
+		}
+	}
+
+	ar.Account = NewAccount(acc)
+	ar.Account.balance = bal
+}
+
+// fail records the error of the account loading.
+func (ar *AccountResult) fail(err error) {
+	log.Errorf("can not load account %s; %s", ar.Address.String(), err.Error())
+	msg := err.Error()
+	ar.Error = &msg
+}
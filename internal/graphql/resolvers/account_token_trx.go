@@ -0,0 +1,138 @@
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"math/big"
+)
+
+const (
+	// tokenTransferDirectionIn represents a transfer received by the account.
+	tokenTransferDirectionIn = "IN"
+
+	// tokenTransferDirectionOut represents a transfer sent by the account.
+	tokenTransferDirectionOut = "OUT"
+
+	// tokenTransferDirectionSelf represents a transfer sent by the account to itself.
+	tokenTransferDirectionSelf = "SELF"
+)
+
+// AccountTokenTransferList represents resolvable list of token transfers of an account.
+type AccountTokenTransferList struct {
+	types.TokenTransactionList
+	account common.Address
+}
+
+// AccountTokenTransferListEdge represents a single edge of the account token transfers list.
+type AccountTokenTransferListEdge struct {
+	Transfer *AccountTokenTransfer
+}
+
+// AccountTokenTransfer represents resolvable token transfer seen from the account side.
+type AccountTokenTransfer struct {
+	TokenTransaction
+	account common.Address
+}
+
+// AccountTokenTransfers resolves list of token transfers of the given account, optionally
+// limited to a token and a range of block time stamps.
+func (rs *rootResolver) AccountTokenTransfers(ctx context.Context, args struct {
+	Account common.Address
+	Token   *common.Address
+	From    *hexutil.Uint64
+	To      *hexutil.Uint64
+	Cursor  *Cursor
+	Count   int32
+}) (*AccountTokenTransferList, error) {
+	if args.From != nil && args.To != nil && *args.From > *args.To {
+		return nil, fmt.Errorf("invalid time range")
+	}
+
+	// limit query size; the count can be either positive or negative
+	// this controls the loading direction
+	count, err := listPageSize(ctx, args.Count, accMaxTransactionsPerRequest)
+	if err != nil {
+		return nil, err
+	}
+	args.Count = count
+
+	tl, err := repository.R().AccountTokenTransfers(
+		&args.Account,
+		args.Token,
+		(*uint64)(args.From),
+		(*uint64)(args.To),
+		(*string)(args.Cursor),
+		args.Count,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &AccountTokenTransferList{TokenTransactionList: *tl, account: args.Account}, nil
+}
+
+// TotalCount resolves the total number of token transfers in the list.
+func (atl *AccountTokenTransferList) TotalCount() hexutil.Big {
+	return hexutil.Big(*new(big.Int).SetUint64(atl.Total))
+}
+
+// PageInfo resolves the current page information for the token transfers list.
+func (atl *AccountTokenTransferList) PageInfo() (*ListPageInfo, error) {
+	// do we have any items?
+	if len(atl.Collection) == 0 {
+		return NewListPageInfo(nil, nil, false, false)
+	}
+
+	// get the first and last elements
+	first := Cursor(atl.Collection[0].ID)
+	last := Cursor(atl.Collection[len(atl.Collection)-1].ID)
+	return NewListPageInfo(&first, &last, !atl.IsEnd, !atl.IsStart)
+}
+
+// Edges resolves list of edges of the token transfers list.
+func (atl *AccountTokenTransferList) Edges() []*AccountTokenTransferListEdge {
+	edges := make([]*AccountTokenTransferListEdge, len(atl.Collection))
+	for i, c := range atl.Collection {
+		edges[i] = &AccountTokenTransferListEdge{
+			Transfer: &AccountTokenTransfer{TokenTransaction: *NewTokenTransaction(c), account: atl.account},
+		}
+	}
+	return edges
+}
+
+// Cursor resolves the token transfer cursor in the edges list.
+func (ate *AccountTokenTransferListEdge) Cursor() Cursor {
+	return Cursor(ate.Transfer.ID)
+}
+
+// Direction resolves the direction of the transfer from the account point of view.
+func (att *AccountTokenTransfer) Direction() string {
+	switch {
+	case att.Sender == att.account && att.Recipient == att.account:
+		return tokenTransferDirectionSelf
+	case att.Sender == att.account:
+		return tokenTransferDirectionOut
+	default:
+		return tokenTransferDirectionIn
+	}
+}
+
+// Counterparty resolves the other side of the transfer.
+func (att *AccountTokenTransfer) Counterparty() common.Address {
+	if att.Sender == att.account {
+		return att.Recipient
+	}
+	return att.Sender
+}
+
+// Transaction resolves the blockchain transaction the transfer originated from.
+func (att *AccountTokenTransfer) Transaction() (*Transaction, error) {
+	trx, err := repository.R().Transaction(&att.TokenTransaction.Transaction, false)
+	if err != nil {
+		return nil, err
+	}
+	return NewTransaction(trx), nil
+}
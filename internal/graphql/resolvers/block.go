@@ -85,3 +85,12 @@ func (blk *Block) TransactionCount() *int32 {
 	count := int32(len(blk.Txs))
 	return &count
 }
+
+// BurntFees resolves the amount of fees burnt by the block, which is the base fee
+// per gas multiplied by the gas used. Nil is resolved for pre-London blocks without base fee.
+func (blk *Block) BurntFees() *hexutil.Big {
+	if blk.BaseFeePerGas == nil {
+		return nil
+	}
+	return (*hexutil.Big)(new(big.Int).Mul(blk.BaseFeePerGas.ToInt(), new(big.Int).SetUint64(uint64(blk.GasUsed))))
+}
@@ -2,8 +2,10 @@
 package resolvers
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository"
 	"fantom-api-graphql/internal/types"
+	"fmt"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 )
 
@@ -28,7 +30,7 @@ func NewBlockList(blocks *types.BlockList, totalCount *hexutil.Big) *BlockList {
 }
 
 // Blocks resolves list of blockchain blocks encapsulated in a listable structure.
-func (rs *rootResolver) Blocks(args *struct {
+func (rs *rootResolver) Blocks(ctx context.Context, args *struct {
 	Cursor *Cursor
 	Count  int32
 }) (*BlockList, error) {
@@ -38,6 +40,7 @@ func (rs *rootResolver) Blocks(args *struct {
 		val, err := hexutil.DecodeUint64(string(*args.Cursor))
 		if err != nil {
 			log.Errorf("invalid block cursor [%s]; %s", args.Cursor, err.Error())
+			return nil, fmt.Errorf("%w; %s", repository.ErrInvalidCursor, err.Error())
 		}
 		num = &val
 	}
@@ -50,7 +53,11 @@ func (rs *rootResolver) Blocks(args *struct {
 
 	// limit query size; the count can be either positive or negative
 	// this controls the loading direction
-	args.Count = listLimitCount(args.Count, listMaxEdgesPerRequest)
+	count, err := listPageSize(ctx, args.Count, listMaxEdgesPerRequest)
+	if err != nil {
+		return nil, err
+	}
+	args.Count = count
 
 	// get the block list from repository
 	bl, err := repository.R().Blocks(num, args.Count)
@@ -0,0 +1,59 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"math/big"
+)
+
+// BlockReward represents resolvable reward attributable to a block.
+type BlockReward struct {
+	types.BlockReward
+}
+
+// BlockReward resolves the reward attributable to the block of the given number.
+func (rs *rootResolver) BlockReward(args *struct{ Block hexutil.Uint64 }) (*BlockReward, error) {
+	br, err := repository.R().BlockReward(&args.Block)
+	if err != nil {
+		return nil, err
+	}
+	return &BlockReward{BlockReward: *br}, nil
+}
+
+// Block resolves the block the reward is attributed to.
+func (br *BlockReward) Block() (*Block, error) {
+	blk, err := repository.R().BlockByNumber(&br.BlockReward.Block)
+	if err != nil {
+		return nil, err
+	}
+	return NewBlock(blk), nil
+}
+
+// Validator resolves the validator producing the block, if known.
+func (br *BlockReward) Validator() (*Staker, error) {
+	if br.Producer == nil {
+		return nil, nil
+	}
+
+	val, err := repository.R().ValidatorByAddress(br.Producer)
+	if err != nil {
+		return nil, err
+	}
+	return NewStaker(val), nil
+}
+
+// Duration resolves the number of seconds since the parent block.
+func (br *BlockReward) Duration() hexutil.Uint64 {
+	return hexutil.Uint64(br.BlockReward.Duration)
+}
+
+// TotalReward resolves the base reward and the transaction fees without the burnt portion.
+func (br *BlockReward) TotalReward() hexutil.Big {
+	total := new(big.Int).Add(br.BaseReward.ToInt(), br.TransactionFees.ToInt())
+	if br.BurntFees != nil {
+		total.Sub(total, br.BurntFees.ToInt())
+	}
+	return hexutil.Big(*total)
+}
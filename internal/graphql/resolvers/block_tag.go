@@ -0,0 +1,47 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"strconv"
+)
+
+// BlockTag represents the block of the state used by a read call, either a named tag or a block number.
+type BlockTag types.BlockTag
+
+// ImplementsGraphQLType notifies the GraphQL that this type resolves BlockTag scalar.
+func (BlockTag) ImplementsGraphQLType(name string) bool {
+	return name == "BlockTag"
+}
+
+// UnmarshalGraphQL decodes incoming block tag name, or block number.
+func (bt *BlockTag) UnmarshalGraphQL(input interface{}) error {
+	var s string
+	switch input := input.(type) {
+	case string:
+		s = input
+	case int32:
+		if input < 0 {
+			return fmt.Errorf("invalid block number %d", input)
+		}
+		s = strconv.Itoa(int(input))
+	default:
+		return fmt.Errorf("wrong block tag type %T", input)
+	}
+
+	tag, err := types.ParseBlockTag(s)
+	if err != nil {
+		return err
+	}
+	*bt = BlockTag(tag)
+	return nil
+}
+
+// blockTagOf provides the block tag of the optional block tag argument; empty for the latest block.
+func blockTagOf(bt *BlockTag) types.BlockTag {
+	if bt == nil {
+		return ""
+	}
+	return types.BlockTag(*bt)
+}
@@ -7,6 +7,7 @@ import (
 	"fantom-api-graphql/internal/types"
 	"fmt"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"html"
 	"regexp"
 )
@@ -78,9 +79,27 @@ func NewContract(con *types.Contract) *Contract {
 }
 
 // DeployedBy resolves the deployment transaction of the contract.
+// Nil is returned if the deployment is not known.
 func (con *Contract) DeployedBy() (*Transaction, error) {
-	tr, err := repository.R().Transaction(&con.TransactionHash, false)
-	return NewTransaction(tr), err
+	if con.TransactionHash == nil {
+		return nil, nil
+	}
+	tr, err := repository.R().Transaction(con.TransactionHash, false)
+	if err != nil {
+		return nil, err
+	}
+	return NewTransaction(tr), nil
+}
+
+// Implementation resolves the current implementation address of an EIP-1967 proxy contract.
+// Nil is returned if the contract is not a proxy.
+func (con *Contract) Implementation() (*common.Address, error) {
+	impl, err := repository.R().ContractImplementation(&con.Address, nil)
+	if err != nil {
+		log.Errorf("can not resolve implementation of %s; %s", con.Address.String(), err.Error())
+		return nil, err
+	}
+	return impl, nil
 }
 
 // sanitizeStringOption sanitizes and validates optional string value from the
@@ -216,3 +235,13 @@ func (rs *rootResolver) ValidateContract(args *struct{ Contract ContractValidati
 	// return the final updated contract
 	return NewContract(sc), nil
 }
+
+// Destroyed resolves the flag of a self-destructed contract.
+func (con *Contract) Destroyed() bool {
+	return con.Contract.Destroyed != nil
+}
+
+// DestroyedAtBlock resolves the number of the block the contract self-destructed at.
+func (con *Contract) DestroyedAtBlock() *hexutil.Uint64 {
+	return con.Contract.Destroyed
+}
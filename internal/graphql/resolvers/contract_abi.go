@@ -0,0 +1,204 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fantom-api-graphql/internal/repository"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"sort"
+	"strings"
+	"time"
+)
+
+// contractAbiCatalogTTL is the time a parsed ABI catalog is kept in the cache.
+const contractAbiCatalogTTL = time.Hour
+
+// ContractAbi represents resolvable ABI of a validated smart contract.
+type ContractAbi struct {
+	raw string
+	abiCatalog
+}
+
+// ContractMethods represents resolvable catalog of functions, events and custom errors
+// of a validated smart contract.
+type ContractMethods struct {
+	Address common.Address
+	abiCatalog
+}
+
+// abiCatalog represents the parsed ABI of a contract with entries ordered by name.
+type abiCatalog struct {
+	Functions []ContractAbiEntry `json:"fn"`
+	Events    []ContractAbiEntry `json:"ev"`
+	Errors    []ContractAbiEntry `json:"er"`
+}
+
+// ContractAbiEntry represents a single function, event or error of a contract ABI.
+type ContractAbiEntry struct {
+	Name            string             `json:"name"`
+	Signature       string             `json:"sig"`
+	Selector        hexutil.Bytes      `json:"sel"`
+	Inputs          []ContractAbiParam `json:"in"`
+	Outputs         []ContractAbiParam `json:"out"`
+	StateMutability *string            `json:"sm,omitempty"`
+	Anonymous       bool               `json:"anon,omitempty"`
+}
+
+// ContractAbiParam represents a single input or output parameter of a contract ABI entry.
+type ContractAbiParam struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Indexed bool   `json:"ix,omitempty"`
+}
+
+// ContractAbi resolves the ABI of a validated smart contract.
+// Returns nil if the contract is not known or not validated.
+func (rs *rootResolver) ContractAbi(args *struct{ Address common.Address }) (*ContractAbi, error) {
+	raw, err := repository.R().ContractAbi(&args.Address)
+	if err != nil {
+		log.Errorf("can not get ABI of contract %s; %s", args.Address.String(), err.Error())
+		return nil, err
+	}
+	if raw == "" {
+		return nil, nil
+	}
+
+	cat, err := contractAbiCatalog(&args.Address, raw)
+	if err != nil {
+		return nil, err
+	}
+	return &ContractAbi{raw: raw, abiCatalog: *cat}, nil
+}
+
+// ContractMethods resolves the catalog of functions, events and custom errors
+// of a validated smart contract. Returns nil if the contract is not known or not validated.
+func (rs *rootResolver) ContractMethods(args *struct{ Address common.Address }) (*ContractMethods, error) {
+	raw, err := repository.R().ContractAbi(&args.Address)
+	if err != nil {
+		log.Errorf("can not get ABI of contract %s; %s", args.Address.String(), err.Error())
+		return nil, err
+	}
+	if raw == "" {
+		return nil, nil
+	}
+
+	cat, err := contractAbiCatalog(&args.Address, raw)
+	if err != nil {
+		return nil, err
+	}
+	return &ContractMethods{Address: args.Address, abiCatalog: *cat}, nil
+}
+
+// Abi resolves the ABI JSON as stored with the validated contract.
+func (ca *ContractAbi) Abi() string {
+	return ca.raw
+}
+
+// contractAbiCatalog provides the parsed catalog of the given contract ABI.
+// Parsed catalogs are cached by the ABI content, so a re-validated contract
+// gets a fresh catalog.
+func contractAbiCatalog(addr *common.Address, raw string) (*abiCatalog, error) {
+	hash := sha256.Sum256([]byte(raw))
+	key := "abi_" + hex.EncodeToString(hash[:16])
+
+	// try the cache first
+	if data := repository.R().CachedResult(key); data != nil {
+		var cat abiCatalog
+		if err := json.Unmarshal(data, &cat); err == nil {
+			return &cat, nil
+		}
+	}
+
+	parsed, err := abi.JSON(strings.NewReader(raw))
+	if err != nil {
+		log.Errorf("invalid ABI of contract %s; %s", addr.String(), err.Error())
+		return nil, err
+	}
+
+	cat := abiCatalog{
+		Functions: abiFunctions(&parsed),
+		Events:    abiEvents(&parsed),
+		Errors:    abiErrors(&parsed),
+	}
+	if data, err := json.Marshal(&cat); err == nil {
+		repository.R().CacheResult(key, data, contractAbiCatalogTTL)
+	}
+	return &cat, nil
+}
+
+// abiFunctions builds the list of functions declared by the ABI ordered by name.
+func abiFunctions(parsed *abi.ABI) []ContractAbiEntry {
+	list := make([]ContractAbiEntry, 0, len(parsed.Methods))
+	for _, m := range parsed.Methods {
+		entry := ContractAbiEntry{
+			Name:      m.RawName,
+			Signature: m.Sig,
+			Selector:  m.ID,
+			Inputs:    abiParams(m.Inputs),
+			Outputs:   abiParams(m.Outputs),
+		}
+		// legacy ABIs may not declare the state mutability
+		if m.StateMutability != "" {
+			sm := m.StateMutability
+			entry.StateMutability = &sm
+		}
+		list = append(list, entry)
+	}
+	return sortAbiEntries(list)
+}
+
+// abiEvents builds the list of events declared by the ABI ordered by name.
+// The selector of an event is its topic hash.
+func abiEvents(parsed *abi.ABI) []ContractAbiEntry {
+	list := make([]ContractAbiEntry, 0, len(parsed.Events))
+	for _, e := range parsed.Events {
+		list = append(list, ContractAbiEntry{
+			Name:      e.RawName,
+			Signature: e.Sig,
+			Selector:  e.ID.Bytes(),
+			Inputs:    abiParams(e.Inputs),
+			Outputs:   []ContractAbiParam{},
+			Anonymous: e.Anonymous,
+		})
+	}
+	return sortAbiEntries(list)
+}
+
+// abiErrors builds the list of custom errors declared by the ABI ordered by name.
+func abiErrors(parsed *abi.ABI) []ContractAbiEntry {
+	list := make([]ContractAbiEntry, 0, len(parsed.Errors))
+	for _, e := range parsed.Errors {
+		list = append(list, ContractAbiEntry{
+			Name:      e.Name,
+			Signature: e.Sig,
+			Selector:  e.ID[:4],
+			Inputs:    abiParams(e.Inputs),
+			Outputs:   []ContractAbiParam{},
+		})
+	}
+	return sortAbiEntries(list)
+}
+
+// abiParams converts ABI arguments into a list of resolvable parameters.
+func abiParams(args abi.Arguments) []ContractAbiParam {
+	list := make([]ContractAbiParam, len(args))
+	for i, a := range args {
+		list[i] = ContractAbiParam{Name: a.Name, Type: a.Type.String(), Indexed: a.Indexed}
+	}
+	return list
+}
+
+// sortAbiEntries orders the ABI entries by name and signature so the output is stable.
+func sortAbiEntries(list []ContractAbiEntry) []ContractAbiEntry {
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Name == list[j].Name {
+			return list[i].Signature < list[j].Signature
+		}
+		return list[i].Name < list[j].Name
+	})
+	return list
+}
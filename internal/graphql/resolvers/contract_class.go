@@ -0,0 +1,35 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/auth"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ClassifyContract sets the type of the given contract manually.
+// The mutation requires the admin scope.
+func (rs *rootResolver) ClassifyContract(ctx context.Context, args *struct {
+	Address common.Address
+	Type    string
+}) (*Contract, error) {
+	if err := auth.Require(ctx, auth.ScopeAdmin); err != nil {
+		return nil, err
+	}
+
+	sc, err := repository.R().ClassifyContract(&args.Address, args.Type, types.ContractClassManual)
+	if err != nil {
+		return nil, err
+	}
+	return &Contract{Contract: *sc}, nil
+}
+
+// Classification resolves the reason the contract has been assigned its type.
+func (con *Contract) Classification() string {
+	if con.Contract.Classification == "" {
+		return types.ContractClassDetected
+	}
+	return con.Contract.Classification
+}
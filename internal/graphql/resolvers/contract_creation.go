@@ -0,0 +1,96 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/auth"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// ContractCreation represents resolvable deployment details of a smart contract.
+type ContractCreation struct {
+	types.ContractCreation
+}
+
+// ContractCreation resolves the deployment details of a smart contract.
+// Returns nil if the contract is not known.
+func (rs *rootResolver) ContractCreation(args *struct{ Address common.Address }) (*ContractCreation, error) {
+	cc, err := repository.R().ContractCreation(&args.Address)
+	if err != nil {
+		log.Errorf("can not get creation of contract %s; %s", args.Address.String(), err.Error())
+		return nil, err
+	}
+	if cc == nil {
+		return nil, nil
+	}
+	return &ContractCreation{ContractCreation: *cc}, nil
+}
+
+// Transaction resolves the deployment transaction of the contract.
+func (cc *ContractCreation) Transaction() (*Transaction, error) {
+	trx, err := repository.R().Transaction(&cc.TransactionHash, false)
+	if err != nil {
+		return nil, err
+	}
+	return NewTransaction(trx), nil
+}
+
+// BackfillContractCreations starts background update of deployment details
+// of contracts stored without them. The mutation requires the admin scope.
+func (rs *rootResolver) BackfillContractCreations(ctx context.Context) (bool, error) {
+	if err := auth.Require(ctx, auth.ScopeAdmin); err != nil {
+		return false, err
+	}
+	return repository.R().BackfillContractCreations(), nil
+}
+
+// ContractCreationCode represents resolvable deployment code of a smart contract.
+type ContractCreationCode struct {
+	types.ContractCreationCode
+}
+
+// ContractCreationCode resolves the init code of a smart contract split into
+// the creation bytecode and the constructor arguments. Returns nil if the contract is not known.
+func (rs *rootResolver) ContractCreationCode(args *struct{ Address common.Address }) (*ContractCreationCode, error) {
+	cc, err := repository.R().ContractCreationCode(&args.Address)
+	if err != nil {
+		log.Errorf("can not get creation code of contract %s; %s", args.Address.String(), err.Error())
+		return nil, err
+	}
+	if cc == nil {
+		return nil, nil
+	}
+	return &ContractCreationCode{ContractCreationCode: *cc}, nil
+}
+
+// IsAvailable resolves the availability of the init code of the contract.
+func (cc *ContractCreationCode) IsAvailable() bool {
+	return cc.ContractCreationCode.InitCode != nil
+}
+
+// InitCode resolves the full init code used to deploy the contract.
+func (cc *ContractCreationCode) InitCode() *hexutil.Bytes {
+	return optionalBytes(cc.ContractCreationCode.InitCode)
+}
+
+// Bytecode resolves the creation bytecode of the contract without the constructor arguments.
+func (cc *ContractCreationCode) Bytecode() *hexutil.Bytes {
+	return optionalBytes(cc.ContractCreationCode.Bytecode)
+}
+
+// ConstructorArgs resolves the ABI encoded constructor arguments.
+func (cc *ContractCreationCode) ConstructorArgs() *hexutil.Bytes {
+	return optionalBytes(cc.ContractCreationCode.ConstructorArgs)
+}
+
+// optionalBytes converts the byte slice to an optional byte array, nil slice is resolved as nil.
+func optionalBytes(b []byte) *hexutil.Bytes {
+	if b == nil {
+		return nil
+	}
+	hb := hexutil.Bytes(b)
+	return &hb
+}
@@ -0,0 +1,120 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"strings"
+)
+
+// ContractEvents represents resolvable events of a contract and their recent emissions.
+type ContractEvents struct {
+	Address   common.Address
+	defs      []types.EventDefinition
+	Emissions *EventLogList
+}
+
+// ContractEventDefinition represents resolvable event declared in the contract ABI.
+type ContractEventDefinition struct {
+	types.EventDefinition
+}
+
+// ContractEvents resolves the events declared in the ABI of the given contract
+// and a page of their recent emissions, optionally limited to a single event.
+func (rs *rootResolver) ContractEvents(ctx context.Context, args struct {
+	Address common.Address
+	Name    *string
+	Cursor  *Cursor
+	Count   int32
+}) (*ContractEvents, error) {
+	defs, err := repository.R().ContractEventDefinitions(&args.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	// select the event, if requested
+	var topics *[]*[]common.Hash
+	if args.Name != nil {
+		topic, err := contractEventTopic(defs, strings.TrimSpace(*args.Name))
+		if err != nil {
+			return nil, err
+		}
+		topics = &[]*[]common.Hash{{topic}}
+	}
+
+	// recent emissions are searched in the widest block range allowed
+	filter, err := eventLogFilter(&args.Address, topics, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	if filter.ToBlock >= cfg.Server.MaxLogBlockRange {
+		filter.FromBlock = filter.ToBlock - cfg.Server.MaxLogBlockRange + 1
+	} else {
+		filter.FromBlock = 0
+	}
+
+	// the emissions are listed forward only
+	if args.Count <= 0 {
+		return nil, fmt.Errorf("count must be positive")
+	}
+	count, err := listPageSize(ctx, args.Count, listMaxEdgesPerRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := repository.R().EventLogs(filter, (*string)(args.Cursor), count)
+	if err != nil {
+		log.Errorf("can not get events of %s; %s", args.Address.String(), err.Error())
+		return nil, err
+	}
+
+	return &ContractEvents{
+		Address:   args.Address,
+		defs:      defs,
+		Emissions: &EventLogList{EventLogList: *list, isStart: args.Cursor == nil},
+	}, nil
+}
+
+// contractEventTopic finds the topic of the event identified by its name, or signature.
+// Events of contracts without known ABI can be selected by the raw topic hash.
+func contractEventTopic(defs []types.EventDefinition, name string) (common.Hash, error) {
+	if strings.HasPrefix(name, "0x") && len(name) == 2+2*common.HashLength && isHexString(name[2:]) {
+		return common.HexToHash(name), nil
+	}
+	if defs == nil {
+		return common.Hash{}, fmt.Errorf("contract ABI not available, select the event by its topic hash")
+	}
+
+	for _, def := range defs {
+		if def.Name != name && def.Signature != name {
+			continue
+		}
+		if def.Topic == nil {
+			return common.Hash{}, fmt.Errorf("event %s is anonymous and can not be selected", def.Signature)
+		}
+		return *def.Topic, nil
+	}
+	return common.Hash{}, fmt.Errorf("event %s not found in the contract ABI", name)
+}
+
+// AbiAvailable resolves the flag of the contract ABI being known.
+func (ce *ContractEvents) AbiAvailable() bool {
+	return ce.defs != nil
+}
+
+// Definitions resolves the list of events declared in the contract ABI.
+func (ce *ContractEvents) Definitions() []*ContractEventDefinition {
+	list := make([]*ContractEventDefinition, len(ce.defs))
+	for i := range ce.defs {
+		list[i] = &ContractEventDefinition{ce.defs[i]}
+	}
+	return list
+}
+
+// Anonymous resolves the flag of an anonymous event not marked with its signature topic.
+func (ed *ContractEventDefinition) Anonymous() bool {
+	return ed.EventDefinition.Topic == nil
+}
@@ -0,0 +1,49 @@
+package resolvers
+
+import (
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/onsi/gomega"
+	"strings"
+	"testing"
+)
+
+// TestContractEventTopic tests selection of events by name, signature and raw topic.
+func TestContractEventTopic(t *testing.T) {
+	transfer := common.HexToHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")
+	raw := "0x" + strings.Repeat("ab", 32)
+	defs := []types.EventDefinition{
+		{Name: "Transfer", Signature: "Transfer(address,address,uint256)", Topic: &transfer},
+		{Name: "Secret", Signature: "Secret(uint256)"},
+	}
+
+	tests := []struct {
+		name  string
+		defs  []types.EventDefinition
+		query string
+		topic common.Hash
+		fail  bool
+	}{
+		{"by name", defs, "Transfer", transfer, false},
+		{"by signature", defs, "Transfer(address,address,uint256)", transfer, false},
+		{"raw topic", defs, raw, common.HexToHash(raw), false},
+		{"raw topic without ABI", nil, raw, common.HexToHash(raw), false},
+		{"name without ABI", nil, "Transfer", common.Hash{}, true},
+		{"anonymous", defs, "Secret", common.Hash{}, true},
+		{"unknown", defs, "Approval", common.Hash{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+
+			topic, err := contractEventTopic(tt.defs, tt.query)
+			if tt.fail {
+				g.Expect(err).NotTo(gomega.BeNil())
+				return
+			}
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(topic).To(gomega.Equal(tt.topic))
+		})
+	}
+}
@@ -0,0 +1,43 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"fantom-api-graphql/internal/repository"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ContractFlag represents resolvable flag of an address being a smart contract.
+type ContractFlag struct {
+	Address    common.Address
+	IsContract *bool
+}
+
+// AreContracts resolves which of the given addresses are smart contracts.
+// Repeated addresses are resolved only once.
+func (rs *rootResolver) AreContracts(args struct{ Addresses []common.Address }) ([]*ContractFlag, error) {
+	if len(args.Addresses) > cfg.Server.MaxBatchAccounts {
+		return nil, fmt.Errorf("too many addresses requested, %d allowed", cfg.Server.MaxBatchAccounts)
+	}
+
+	// remove duplicates keeping the order of the first appearance
+	seen := make(map[common.Address]bool, len(args.Addresses))
+	addr := make([]common.Address, 0, len(args.Addresses))
+	for _, adr := range args.Addresses {
+		if !seen[adr] {
+			seen[adr] = true
+			addr = append(addr, adr)
+		}
+	}
+
+	flags, err := repository.R().AreContracts(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*ContractFlag, len(addr))
+	for i := range addr {
+		list[i] = &ContractFlag{Address: addr[i], IsContract: flags[i]}
+	}
+	return list, nil
+}
@@ -0,0 +1,144 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const (
+	// contractGasDefaultRange is the number of recent blocks covered by the gas statistics by default.
+	contractGasDefaultRange = 100_000
+
+	// contractGasMaxTopRange is the max number of blocks the top contracts by gas are aggregated over.
+	contractGasMaxTopRange = 1_000_000
+
+	// contractGasMaxTop is the max number of top contracts by gas provided.
+	contractGasMaxTop = 100
+)
+
+// ContractGasStats represents resolvable gas consumption of a contract in a range of blocks.
+type ContractGasStats struct {
+	types.ContractGasStats
+}
+
+// ContractGasStats resolves the gas used by transactions sent to the given contract
+// in the given inclusive block range; the recent blocks are covered by default.
+func (rs *rootResolver) ContractGasStats(ctx context.Context, args struct {
+	Address   common.Address
+	FromBlock *hexutil.Uint64
+	ToBlock   *hexutil.Uint64
+}) (*ContractGasStats, error) {
+	var val *ContractGasStats
+	err := cachedResult(ctx, "contractGasStats", args, &val, func() (interface{}, error) {
+		from, to, err := contractGasRange(args.FromBlock, args.ToBlock, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		gs, err := repository.R().ContractGasStats(&args.Address, from, to)
+		if err != nil {
+			log.Errorf("can not get gas stats of %s; %s", args.Address.String(), err.Error())
+			return nil, err
+		}
+		return &ContractGasStats{*gs}, nil
+	})
+	return val, err
+}
+
+// TopContractsByGas resolves the contracts with the highest gas used by transactions
+// sent to them in the given inclusive block range; the recent blocks are covered by default.
+func (rs *rootResolver) TopContractsByGas(ctx context.Context, args struct {
+	FromBlock *hexutil.Uint64
+	ToBlock   *hexutil.Uint64
+	Count     int32
+}) ([]*ContractGasStats, error) {
+	count, err := listPageSize(ctx, args.Count, contractGasMaxTop)
+	if err != nil {
+		return nil, err
+	}
+	args.Count = count
+
+	var val []*ContractGasStats
+	err = cachedResult(ctx, "topContractsByGas", args, &val, func() (interface{}, error) {
+		from, to, err := contractGasRange(args.FromBlock, args.ToBlock, contractGasMaxTopRange)
+		if err != nil {
+			return nil, err
+		}
+
+		top, err := repository.R().TopContractsByGas(from, to, args.Count)
+		if err != nil {
+			log.Errorf("can not get top contracts by gas; %s", err.Error())
+			return nil, err
+		}
+
+		list := make([]*ContractGasStats, len(top))
+		for i, gs := range top {
+			list[i] = &ContractGasStats{*gs}
+		}
+		return list, nil
+	})
+	return val, err
+}
+
+// contractGasRange validates the requested block range of the gas statistics and provides
+// the inclusive range of blocks covered. A zero limit disables the range size check.
+func contractGasRange(fromBlock *hexutil.Uint64, toBlock *hexutil.Uint64, limit uint64) (uint64, uint64, error) {
+	var to uint64
+	if toBlock != nil {
+		to = uint64(*toBlock)
+	} else {
+		lnb, err := repository.R().LastKnownBlock()
+		if err != nil {
+			return 0, 0, err
+		}
+		to = lnb
+	}
+
+	var from uint64
+	if fromBlock != nil {
+		from = uint64(*fromBlock)
+	} else if to > contractGasDefaultRange {
+		from = to - contractGasDefaultRange
+	}
+
+	if from > to {
+		return 0, 0, fmt.Errorf("invalid block range received")
+	}
+	if limit > 0 && to-from > limit {
+		return 0, 0, fmt.Errorf("block range too long, %d blocks allowed", limit)
+	}
+	return from, to, nil
+}
+
+// FromBlock resolves the first block of the range covered.
+func (gs *ContractGasStats) FromBlock() hexutil.Uint64 {
+	return hexutil.Uint64(gs.ContractGasStats.FromBlock)
+}
+
+// ToBlock resolves the last block of the range covered.
+func (gs *ContractGasStats) ToBlock() hexutil.Uint64 {
+	return hexutil.Uint64(gs.ContractGasStats.ToBlock)
+}
+
+// GasUsed resolves the total gas used by the transactions sent to the contract.
+func (gs *ContractGasStats) GasUsed() hexutil.Uint64 {
+	return hexutil.Uint64(gs.ContractGasStats.GasUsed)
+}
+
+// Calls resolves the number of transactions sent to the contract.
+func (gs *ContractGasStats) Calls() hexutil.Uint64 {
+	return hexutil.Uint64(gs.ContractGasStats.Calls)
+}
+
+// AvgGasPerCall resolves the average gas used by a transaction sent to the contract.
+func (gs *ContractGasStats) AvgGasPerCall() hexutil.Uint64 {
+	if gs.ContractGasStats.Calls == 0 {
+		return 0
+	}
+	return hexutil.Uint64(gs.ContractGasStats.GasUsed / gs.ContractGasStats.Calls)
+}
@@ -0,0 +1,73 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/auth"
+	"fantom-api-graphql/internal/repository"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// contractImportMaxBatch is the max number of contracts imported by a single call.
+const contractImportMaxBatch = 1000
+
+// ContractImportInput represents a single contract ABI record to be imported.
+type ContractImportInput struct {
+	Address         common.Address
+	Abi             string
+	Name            *string
+	CompilerVersion *string
+}
+
+// ContractImportResult represents the result of a single contract import.
+type ContractImportResult struct {
+	Address common.Address
+	Success bool
+	Error   *string
+}
+
+// ImportContracts upserts a batch of contract ABIs into the contract store
+// and optionally starts reanalysis of known calls of the imported contracts.
+// The mutation requires the admin scope.
+func (rs *rootResolver) ImportContracts(ctx context.Context, args *struct {
+	Contracts []ContractImportInput
+	Reanalyze bool
+}) ([]*ContractImportResult, error) {
+	if err := auth.Require(ctx, auth.ScopeAdmin); err != nil {
+		return nil, err
+	}
+	if len(args.Contracts) > contractImportMaxBatch {
+		return nil, fmt.Errorf("too many contracts, max %d contracts can be imported at once", contractImportMaxBatch)
+	}
+
+	res := make([]*ContractImportResult, len(args.Contracts))
+	done := make([]common.Address, 0, len(args.Contracts))
+	for i, in := range args.Contracts {
+		res[i] = &ContractImportResult{Address: in.Address}
+
+		err := repository.R().ImportContract(&in.Address, in.Abi, stringOrEmpty(in.Name), stringOrEmpty(in.CompilerVersion))
+		if err != nil {
+			msg := err.Error()
+			res[i].Error = &msg
+			continue
+		}
+
+		res[i].Success = true
+		done = append(done, in.Address)
+	}
+
+	// start the reanalysis of imported contracts
+	if args.Reanalyze && len(done) > 0 && !repository.R().ReanalyzeContractCalls(done) {
+		log.Warningf("contract calls reanalysis is already running, %d imported contracts skipped", len(done))
+	}
+	return res, nil
+}
+
+// stringOrEmpty provides the value of the optional string, or an empty string.
+func stringOrEmpty(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -2,6 +2,7 @@
 package resolvers
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository"
 	"fantom-api-graphql/internal/types"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -26,14 +27,18 @@ func NewContractList(cl *types.ContractList) *ContractList {
 }
 
 // Contracts resolves list of blockchain smart contracts encapsulated in a listable structure.
-func (rs *rootResolver) Contracts(args *struct {
+func (rs *rootResolver) Contracts(ctx context.Context, args *struct {
 	ValidatedOnly bool
 	Cursor        *Cursor
 	Count         int32
 }) (*ContractList, error) {
 	// limit query size; the count can be either positive or negative
 	// this controls the loading direction
-	args.Count = listLimitCount(args.Count, listMaxEdgesPerRequest)
+	count, err := listPageSize(ctx, args.Count, listMaxEdgesPerRequest)
+	if err != nil {
+		return nil, err
+	}
+	args.Count = count
 
 	// get the contract list from repository
 	cl, err := repository.R().Contracts(args.ValidatedOnly, (*string)(args.Cursor), args.Count)
@@ -0,0 +1,34 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/auth"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ContractRefresh represents resolvable outcome of a forced contract refresh.
+type ContractRefresh struct {
+	types.ContractRefresh
+}
+
+// RefreshContract re-reads the on-chain data of the given contract and re-runs its type detection.
+// The mutation requires the admin scope.
+func (rs *rootResolver) RefreshContract(ctx context.Context, args *struct{ Address common.Address }) (*ContractRefresh, error) {
+	if err := auth.Require(ctx, auth.ScopeAdmin); err != nil {
+		return nil, err
+	}
+
+	res, err := repository.R().RefreshContract(&args.Address)
+	if err != nil {
+		return nil, err
+	}
+	return &ContractRefresh{ContractRefresh: *res}, nil
+}
+
+// Contract resolves the refreshed contract.
+func (cr *ContractRefresh) Contract() *Contract {
+	return &Contract{Contract: *cr.ContractRefresh.Contract}
+}
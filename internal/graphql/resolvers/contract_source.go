@@ -0,0 +1,58 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// ContractSource represents resolvable source code of a validated smart contract.
+type ContractSource struct {
+	types.Contract
+}
+
+// ContractSource resolves the source code of a validated smart contract.
+// Returns nil if the contract is not known or not validated.
+func (rs *rootResolver) ContractSource(args *struct{ Address common.Address }) (*ContractSource, error) {
+	sc, err := repository.R().Contract(&args.Address)
+	if err != nil {
+		log.Errorf("can not get contract %s; %s", args.Address.String(), err.Error())
+		return nil, err
+	}
+	if sc == nil || sc.Validated == nil || sc.SourceCode == "" {
+		return nil, nil
+	}
+	return &ContractSource{Contract: *sc}, nil
+}
+
+// Validated resolves the time stamp of the contract validation.
+func (cs *ContractSource) Validated() hexutil.Uint64 {
+	return *cs.Contract.Validated
+}
+
+// EvmVersion resolves the target EVM version used by the compiler, if known.
+func (cs *ContractSource) EvmVersion() *string {
+	if cs.Contract.EvmVersion == "" {
+		return nil
+	}
+	return &cs.Contract.EvmVersion
+}
+
+// Metadata resolves the standard JSON metadata produced by the compiler, if available.
+func (cs *ContractSource) Metadata() *string {
+	if cs.Contract.Metadata == "" {
+		return nil
+	}
+	return &cs.Contract.Metadata
+}
+
+// SourceFiles resolves the list of source files of the validated contract.
+// Contracts validated before the files were tracked expose their single source.
+func (cs *ContractSource) SourceFiles() []types.ContractSourceFile {
+	if len(cs.Contract.SourceFiles) > 0 {
+		return cs.Contract.SourceFiles
+	}
+	return []types.ContractSourceFile{{Name: "contract.sol", Content: cs.SourceCode}}
+}
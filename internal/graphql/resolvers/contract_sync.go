@@ -6,6 +6,7 @@ import (
 	"context"
 	"encoding/json"
 	"fantom-api-graphql/internal/types"
+	"fmt"
 	"net/http"
 	"sync"
 	"time"
@@ -19,8 +20,87 @@ const (
 	// contractSyncCallTimeout represents a time out value used for contract
 	// syncing GraphQL calls.
 	contractSyncCallTimeout = 60 * time.Second
+
+	// contractSyncAttempts represents the number of attempts to deliver
+	// the contract syncing call to an unresponsive peer.
+	contractSyncAttempts = 3
+
+	// contractSyncRetryDelay represents the initial delay between contract syncing
+	// attempts; the delay doubles with each failed attempt.
+	contractSyncRetryDelay = 5 * time.Second
+
+	// contractSyncPeerBackoff represents the initial time an unresponsive peer
+	// is skipped for; the time doubles with each consecutive failed sync.
+	contractSyncPeerBackoff = time.Minute
+
+	// contractSyncPeerMaxBackoff represents the longest time an unresponsive peer is skipped for.
+	contractSyncPeerMaxBackoff = time.Hour
 )
 
+// contractSyncClient is the HTTP client used for contract syncing calls.
+var contractSyncClient = &http.Client{Timeout: contractSyncCallTimeout}
+
+// contractSyncPeers keeps the health of the API peers across contract syncing calls.
+var contractSyncPeers = newPeerHealth()
+
+// peerHealth tracks unresponsive API peers, so they are skipped
+// for a growing period of time instead of being called over and over.
+type peerHealth struct {
+	mu    sync.Mutex
+	peers map[string]*peerState
+}
+
+// peerState represents the health of a single API peer.
+type peerState struct {
+	failures int
+	until    time.Time
+}
+
+// newPeerHealth creates a new empty peer health tracker.
+func newPeerHealth() *peerHealth {
+	return &peerHealth{peers: make(map[string]*peerState)}
+}
+
+// healthy checks if the peer can be called at the given time.
+func (ph *peerHealth) healthy(peer string, now time.Time) bool {
+	ph.mu.Lock()
+	defer ph.mu.Unlock()
+
+	ps, ok := ph.peers[peer]
+	return !ok || !now.Before(ps.until)
+}
+
+// success marks the peer as responsive again.
+func (ph *peerHealth) success(peer string) {
+	ph.mu.Lock()
+	defer ph.mu.Unlock()
+	delete(ph.peers, peer)
+}
+
+// failure marks the peer as unresponsive and backs it off
+// for a period doubling with each consecutive failure.
+func (ph *peerHealth) failure(peer string, now time.Time) time.Duration {
+	ph.mu.Lock()
+	defer ph.mu.Unlock()
+
+	ps, ok := ph.peers[peer]
+	if !ok {
+		ps = new(peerState)
+		ph.peers[peer] = ps
+	}
+	ps.failures++
+
+	backoff := contractSyncPeerBackoff
+	for i := 1; i < ps.failures && backoff < contractSyncPeerMaxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > contractSyncPeerMaxBackoff {
+		backoff = contractSyncPeerMaxBackoff
+	}
+	ps.until = now.Add(backoff)
+	return backoff
+}
+
 // getContractSyncInput prepares input structure used for contract syncing
 // across peer API points.
 func contractSyncInput(con *types.Contract) ContractValidationInput {
@@ -93,13 +173,19 @@ func (rs *rootResolver) syncContract(con types.Contract) {
 	// prep wait group to sync all routines
 	var wg sync.WaitGroup
 
+	// the peers recognize the syncing calls by the API state origin
+	origin := cfg.Server.Origin
+	if origin == "" {
+		origin = cfg.Server.DomainAddress
+	}
+
 	// loop over the peers and sync each of them
 	for _, peer := range cfg.Server.Peers {
 		// add this sync to the wait group
 		wg.Add(1)
 
-		// run the sync
-		go syncContractToPeer(&payload, peer, cfg.Server.DomainAddress, &wg)
+		// run the sync; each peer gets its own copy of the payload
+		go syncContractToPeer(payload.Bytes(), peer, origin, &wg)
 	}
 
 	// wait for all the sync to finish
@@ -111,50 +197,80 @@ func (rs *rootResolver) syncContract(con types.Contract) {
 }
 
 // syncContractToPeer performs the syncing call for the contract validation.
-func syncContractToPeer(payload *bytes.Buffer, peer string, origin string, wg *sync.WaitGroup) {
+// Unresponsive peers are retried with a growing delay, so a peer temporarily
+// down does not miss the contract validation. A peer still not responding is skipped
+// by the following syncs until its back off period passes.
+func syncContractToPeer(payload []byte, peer string, origin string, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	// skip peers known to be down
+	if !contractSyncPeers.healthy(peer, time.Now()) {
+		log.Warningf("peer %s is not responding, contract validation syncing skipped", peer)
+		return
+	}
+
 	// log action
 	log.Debugf("syncing contract validation to %s from %s", peer, origin)
 
-	// make a context with predefined timeout, we don't use the cancel func callback
-	ctx, cancel := context.WithTimeout(context.Background(), contractSyncCallTimeout)
-
 	// don't forget to sign off after we are done
-	defer func() {
-		// log finish
-		cancel()
-		log.Noticef("syncing %s finished", peer)
+	defer log.Noticef("syncing %s finished", peer)
 
-		// signal to wait group we are done
-		wg.Done()
-	}()
+	delay := contractSyncRetryDelay
+	for i := 1; i <= contractSyncAttempts; i++ {
+		retry, err := sendContractSync(payload, peer, origin)
+		if err == nil {
+			log.Debugf("syncing request to %s finished with success", peer)
+			contractSyncPeers.success(peer)
+			return
+		}
+
+		// rejected requests are not retried; the peer is responding
+		if !retry {
+			log.Errorf("syncing request to %s failed; %s", peer, err.Error())
+			contractSyncPeers.success(peer)
+			return
+		}
 
+		// the peer is not responding, back it off
+		if i == contractSyncAttempts {
+			backoff := contractSyncPeers.failure(peer, time.Now())
+			log.Errorf("syncing request to %s failed, peer skipped for %s; %s", peer, backoff.String(), err.Error())
+			return
+		}
+
+		log.Warningf("syncing request to %s failed, attempt %d of %d; %s", peer, i, contractSyncAttempts, err.Error())
+		time.Sleep(delay)
+		delay *= 2
+	}
+}
+
+// sendContractSync sends the syncing call to the peer. The flag signals
+// the failed call should be retried since the peer is not responding.
+func sendContractSync(payload []byte, peer string, origin string) (bool, error) {
 	// create the request
-	req, err := http.NewRequestWithContext(ctx, "POST", peer, payload)
+	req, err := http.NewRequestWithContext(context.Background(), "POST", peer, bytes.NewReader(payload))
 	if err != nil {
-		log.Errorf("can not create new POST request for %s peer", peer)
-		return
+		return false, err
 	}
 
 	// set headers so we can pass the payload correctly
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Origin", origin)
 
-	// make the client and send the request
-	client := &http.Client{}
-
 	// fire the request
-	resp, err := client.Do(req)
+	resp, err := contractSyncClient.Do(req)
 	if err != nil {
-		log.Errorf("can not finalize syncing request for %s peer; %s", peer, err.Error())
-		return
+		return true, err
 	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.Errorf("can not close syncing response of %s; %s", peer, err.Error())
+		}
+	}()
 
-	// log error code response
-	if 200 != resp.StatusCode {
-		log.Errorf("syncing request to %s has been rejected with code %d", peer, resp.StatusCode)
-		return
+	// server side failures may be temporary
+	if resp.StatusCode != http.StatusOK {
+		return resp.StatusCode >= http.StatusInternalServerError, fmt.Errorf("rejected with code %d", resp.StatusCode)
 	}
-
-	// success
-	log.Debugf("syncing request to %s finished with success", peer)
+	return false, nil
 }
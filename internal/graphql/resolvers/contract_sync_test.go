@@ -0,0 +1,77 @@
+package resolvers
+
+import (
+	"fantom-api-graphql/internal/config"
+	"fantom-api-graphql/internal/logger"
+	"github.com/onsi/gomega"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestPeerHealth tests unresponsive peers are backed off for a growing period
+// of time and become healthy again after a successful call.
+func TestPeerHealth(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	ph := newPeerHealth()
+	now := time.Now()
+	g.Expect(ph.healthy("a", now)).To(gomega.BeTrue())
+
+	g.Expect(ph.failure("a", now)).To(gomega.Equal(contractSyncPeerBackoff))
+	g.Expect(ph.healthy("a", now)).To(gomega.BeFalse())
+	g.Expect(ph.healthy("b", now)).To(gomega.BeTrue())
+	g.Expect(ph.healthy("a", now.Add(contractSyncPeerBackoff))).To(gomega.BeTrue())
+
+	// consecutive failures back off longer, up to the limit
+	g.Expect(ph.failure("a", now)).To(gomega.Equal(2 * contractSyncPeerBackoff))
+	for i := 0; i < 64; i++ {
+		ph.failure("a", now)
+	}
+	g.Expect(ph.failure("a", now)).To(gomega.Equal(contractSyncPeerMaxBackoff))
+
+	ph.success("a")
+	g.Expect(ph.healthy("a", now)).To(gomega.BeTrue())
+	g.Expect(ph.failure("a", now)).To(gomega.Equal(contractSyncPeerBackoff))
+}
+
+// TestSyncContractSkipsFailingPeer tests a peer backed off is not called
+// while the healthy peers still receive the contract validation.
+func TestSyncContractSkipsFailingPeer(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	cfg = &config.Config{Log: config.Log{Level: "CRITICAL", Format: "%{message}"}}
+	log = logger.New(cfg)
+	defer func() { cfg, log, contractSyncPeers = nil, nil, newPeerHealth() }()
+
+	var failing, healthy int32
+	bad := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&failing, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer bad.Close()
+	good := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&healthy, 1)
+		_, _ = w.Write([]byte(`{"data":{"validateContract":{"validated":1}}}`))
+	}))
+	defer good.Close()
+
+	// the failing peer exhausted its attempts before
+	contractSyncPeers = newPeerHealth()
+	contractSyncPeers.failure(bad.URL, time.Now())
+
+	var wg sync.WaitGroup
+	for _, peer := range []string{bad.URL, good.URL} {
+		wg.Add(1)
+		syncContractToPeer([]byte(`{}`), peer, "test", &wg)
+	}
+	wg.Wait()
+
+	g.Expect(atomic.LoadInt32(&failing)).To(gomega.BeZero())
+	g.Expect(atomic.LoadInt32(&healthy)).To(gomega.Equal(int32(1)))
+	g.Expect(contractSyncPeers.healthy(bad.URL, time.Now())).To(gomega.BeFalse())
+	g.Expect(contractSyncPeers.healthy(good.URL, time.Now())).To(gomega.BeTrue())
+}
@@ -6,11 +6,17 @@ import (
 	"fantom-api-graphql/internal/types"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"golang.org/x/sync/singleflight"
+	"math/big"
 )
 
+// fMintRatioPrecision represents the 4 digits precision of fMint ratio values.
+var fMintRatioPrecision = big.NewInt(10000)
+
 // FMintAccount represents resolvable DeFi account information.
 type FMintAccount struct {
 	types.FMintAccount
+	cg *singleflight.Group
 }
 
 // FMintTokenBalance represents a resolvable DeFi token balance information.
@@ -22,7 +28,7 @@ type FMintTokenBalance struct {
 
 // NewFMintAccount creates new instance of resolvable DeFi account.
 func NewFMintAccount(ac *types.FMintAccount) *FMintAccount {
-	return &FMintAccount{FMintAccount: *ac}
+	return &FMintAccount{FMintAccount: *ac, cg: new(singleflight.Group)}
 }
 
 // NewFMintTokenBalance creates a new DeFi token balance, either collateral, or debt.
@@ -67,6 +73,19 @@ func (fac *FMintAccount) Debt() []*FMintTokenBalance {
 	return list
 }
 
+// CollateralRatio4 resolves the current ratio between collateral and debt
+// value of the account in 4 digits precision. Accounts without debt
+// do not have any ratio.
+func (fac *FMintAccount) CollateralRatio4() *hexutil.Big {
+	if fac.DebtValue.ToInt().Sign() <= 0 {
+		return nil
+	}
+
+	// ratio = collateral * 10^4 / debt
+	val := new(big.Int).Div(new(big.Int).Mul(fac.CollateralValue.ToInt(), fMintRatioPrecision), fac.DebtValue.ToInt())
+	return (*hexutil.Big)(val)
+}
+
 // RewardsEarned resolves the total amount of rewards
 // accumulated on the account for the excessive collateral deposits.
 func (fac *FMintAccount) RewardsEarned() (hexutil.Big, error) {
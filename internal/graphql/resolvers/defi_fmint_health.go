@@ -0,0 +1,159 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"math/big"
+)
+
+// FMintLiquidationPrice represents a resolvable price of a collateral token
+// on which the fMint account would drop below the minimal collateral ratio.
+type FMintLiquidationPrice struct {
+	TokenAddress common.Address
+	Price        *hexutil.Big
+	CurrentPrice hexutil.Big
+}
+
+// settings loads the DeFi settings the account health is calculated against.
+func (fac *FMintAccount) settings() (*types.DefiSettings, error) {
+	val, err, _ := fac.cg.Do("settings", func() (interface{}, error) {
+		return repository.R().DefiConfiguration()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return val.(*types.DefiSettings), nil
+}
+
+// MinCollateralRatio4 resolves the minimal collateral to debt ratio of the fMint protocol.
+// Accounts below this ratio are subject to liquidation.
+func (fac *FMintAccount) MinCollateralRatio4() (hexutil.Big, error) {
+	ds, err := fac.settings()
+	if err != nil {
+		return hexutil.Big{}, err
+	}
+	return ds.MinCollateralRatio4, nil
+}
+
+// RewardCollateralRatio4 resolves the minimal collateral to debt ratio
+// required for the account to be eligible for rewards.
+func (fac *FMintAccount) RewardCollateralRatio4() (hexutil.Big, error) {
+	ds, err := fac.settings()
+	if err != nil {
+		return hexutil.Big{}, err
+	}
+	return ds.RewardCollateralRatio4, nil
+}
+
+// HealthFactor resolves the ratio between the current collateral ratio
+// and the minimal collateral ratio. Values below 1.0 mean the account
+// can be liquidated. Accounts without debt are infinitely safe and resolve to nil.
+func (fac *FMintAccount) HealthFactor() (*float64, error) {
+	ratio := fac.CollateralRatio4()
+	if ratio == nil {
+		return nil, nil
+	}
+
+	ds, err := fac.settings()
+	if err != nil {
+		return nil, err
+	}
+
+	// no minimal ratio means the account can not be liquidated
+	if ds.MinCollateralRatio4.ToInt().Sign() <= 0 {
+		return nil, nil
+	}
+
+	hf, _ := new(big.Float).Quo(new(big.Float).SetInt(ratio.ToInt()), new(big.Float).SetInt(ds.MinCollateralRatio4.ToInt())).Float64()
+	return &hf, nil
+}
+
+// IsLiquidatable resolves the flag of the account collateral ratio
+// being below the minimal collateral ratio.
+func (fac *FMintAccount) IsLiquidatable() (bool, error) {
+	hf, err := fac.HealthFactor()
+	if err != nil {
+		return false, err
+	}
+	return hf != nil && *hf < 1.0, nil
+}
+
+// LiquidationPrices resolves the liquidation price of each collateral token of the account,
+// assuming the price of all the other tokens stays the same.
+func (fac *FMintAccount) LiquidationPrices() ([]*FMintLiquidationPrice, error) {
+	ds, err := fac.settings()
+	if err != nil {
+		return nil, err
+	}
+
+	// collect balances, prices and values of the collateral
+	balances := make([]*big.Int, len(fac.CollateralList))
+	prices := make([]hexutil.Big, len(fac.CollateralList))
+	total := new(big.Int)
+	for i, token := range fac.CollateralList {
+		bal, err := repository.R().FMintTokenBalance(&fac.Address, &fac.CollateralList[i], types.DefiTokenTypeCollateral)
+		if err != nil {
+			return nil, err
+		}
+
+		prices[i], err = repository.R().DefiTokenPrice(&token)
+		if err != nil {
+			return nil, err
+		}
+
+		balances[i] = bal.ToInt()
+		total.Add(total, new(big.Int).Mul(balances[i], prices[i].ToInt()))
+	}
+
+	// the debt value in the same units as the collateral value
+	debt, err := fac.debtValue()
+	if err != nil {
+		return nil, err
+	}
+
+	// the collateral value required by the minimal ratio
+	required := new(big.Int).Div(new(big.Int).Mul(debt, ds.MinCollateralRatio4.ToInt()), fMintRatioPrecision)
+
+	list := make([]*FMintLiquidationPrice, len(fac.CollateralList))
+	for i, token := range fac.CollateralList {
+		list[i] = &FMintLiquidationPrice{TokenAddress: token, CurrentPrice: prices[i]}
+		if debt.Sign() == 0 || balances[i].Sign() == 0 {
+			continue
+		}
+
+		// price = (required - value of the other collateral) / balance
+		other := new(big.Int).Sub(total, new(big.Int).Mul(balances[i], prices[i].ToInt()))
+		price := new(big.Int).Div(new(big.Int).Sub(required, other), balances[i])
+		if price.Sign() < 0 {
+			price = new(big.Int)
+		}
+		list[i].Price = (*hexutil.Big)(price)
+	}
+	return list, nil
+}
+
+// debtValue calculates the total value of the account debt
+// from the debt balances and the oracle prices.
+func (fac *FMintAccount) debtValue() (*big.Int, error) {
+	total := new(big.Int)
+	for i := range fac.DebtList {
+		val, err := repository.R().FMintTokenValue(&fac.Address, &fac.DebtList[i], types.DefiTokenTypeDebt)
+		if err != nil {
+			return nil, err
+		}
+		total.Add(total, val.ToInt())
+	}
+	return total, nil
+}
+
+// Token resolves the collateral token detail.
+func (lp *FMintLiquidationPrice) Token() (*DefiToken, error) {
+	tk, err := repository.R().DefiToken(&lp.TokenAddress)
+	if err != nil {
+		return nil, err
+	}
+	return NewDefiToken(tk), nil
+}
@@ -2,6 +2,7 @@
 package resolvers
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository"
 	"fantom-api-graphql/internal/types"
 	"github.com/ethereum/go-ethereum/common"
@@ -121,13 +122,17 @@ func (del Delegation) ClaimedReward() (hexutil.Big, error) {
 }
 
 // WithdrawRequests resolves partial withdraw requests of the delegator.
-func (del Delegation) WithdrawRequests(args struct {
+func (del Delegation) WithdrawRequests(ctx context.Context, args struct {
 	Cursor *Cursor
 	Count  int32
 }) ([]WithdrawRequest, error) {
 	// limit query size; the count can be either positive or negative
 	// this controls the loading direction
-	args.Count = listLimitCount(args.Count, listMaxEdgesPerRequest)
+	count, err := listPageSize(ctx, args.Count, listMaxEdgesPerRequest)
+	if err != nil {
+		return nil, err
+	}
+	args.Count = count
 
 	// pull list of withdrawals
 	wr, err := repository.R().WithdrawRequests(&del.Address, del.Delegation.ToStakerId, (*string)(args.Cursor), args.Count)
@@ -146,13 +151,17 @@ func (del Delegation) WithdrawRequests(args struct {
 }
 
 // RewardClaims resolves list of reward claims of the delegation.
-func (del Delegation) RewardClaims(args struct {
+func (del Delegation) RewardClaims(ctx context.Context, args struct {
 	Cursor *Cursor
 	Count  int32
 }) (*RewardClaimList, error) {
 	// limit query size; the count can be either positive or negative
 	// this controls the loading direction
-	args.Count = listLimitCount(args.Count, listMaxEdgesPerRequest)
+	count, err := listPageSize(ctx, args.Count, listMaxEdgesPerRequest)
+	if err != nil {
+		return nil, err
+	}
+	args.Count = count
 
 	// pull list of withdrawals
 	cl, err := repository.R().RewardClaims(&del.Address, (*big.Int)(del.Delegation.ToStakerId), (*string)(args.Cursor), args.Count)
@@ -2,6 +2,7 @@
 package resolvers
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository"
 	"fantom-api-graphql/internal/types"
 	"github.com/ethereum/go-ethereum/common"
@@ -62,14 +63,18 @@ func (dle *DelegationListEdge) Cursor() Cursor {
 }
 
 // DelegationsOf resolves a list of delegations information of a staker.
-func (rs *rootResolver) DelegationsOf(args *struct {
+func (rs *rootResolver) DelegationsOf(ctx context.Context, args *struct {
 	Staker hexutil.Big
 	Cursor *Cursor
 	Count  int32
 }) (*DelegationList, error) {
 	// limit query size; the count can be either positive or negative
 	// this controls the loading direction
-	args.Count = listLimitCount(args.Count, listMaxEdgesPerRequest)
+	count, err := listPageSize(ctx, args.Count, listMaxEdgesPerRequest)
+	if err != nil {
+		return nil, err
+	}
+	args.Count = count
 
 	// get the list
 	dl, err := repository.R().DelegationsOfValidator(&args.Staker, (*string)(args.Cursor), args.Count)
@@ -82,14 +87,18 @@ func (rs *rootResolver) DelegationsOf(args *struct {
 }
 
 // DelegationsByAddress resolves a list of own delegations by the account address.
-func (rs *rootResolver) DelegationsByAddress(args *struct {
+func (rs *rootResolver) DelegationsByAddress(ctx context.Context, args *struct {
 	Address common.Address
 	Cursor  *Cursor
 	Count   int32
 }) (*DelegationList, error) {
 	// limit query size; the count can be either positive or negative
 	// this controls the loading direction
-	args.Count = listLimitCount(args.Count, listMaxEdgesPerRequest)
+	count, err := listPageSize(ctx, args.Count, listMaxEdgesPerRequest)
+	if err != nil {
+		return nil, err
+	}
+	args.Count = count
 
 	// get the list of delegations
 	dl, err := repository.R().DelegationsByAddress(&args.Address, (*string)(args.Cursor), args.Count)
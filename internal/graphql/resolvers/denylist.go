@@ -0,0 +1,57 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/auth"
+	"fantom-api-graphql/internal/svc"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// DenylistStats represents resolvable state and impact of the analysis denylist.
+type DenylistStats struct {
+	svc.DenylistStats
+}
+
+// DenylistStats resolves the state and the impact of the analysis denylist.
+// The query requires the admin scope.
+func (rs *rootResolver) DenylistStats(ctx context.Context) (*DenylistStats, error) {
+	if err := auth.Require(ctx, auth.ScopeAdmin); err != nil {
+		return nil, err
+	}
+	return &DenylistStats{svc.Denylist()}, nil
+}
+
+// Addresses resolves the number of denied addresses.
+func (ds *DenylistStats) Addresses() int32 {
+	return int32(ds.DenylistStats.Addresses)
+}
+
+// Selectors resolves the number of denied call selectors.
+func (ds *DenylistStats) Selectors() int32 {
+	return int32(ds.DenylistStats.Selectors)
+}
+
+// Allowed resolves the number of call selectors on the allowlist.
+func (ds *DenylistStats) Allowed() int32 {
+	return int32(ds.DenylistStats.Allowed)
+}
+
+// SkippedTransactions resolves the number of transactions recorded without the analysis.
+func (ds *DenylistStats) SkippedTransactions() hexutil.Uint64 {
+	return hexutil.Uint64(ds.SkippedTrx)
+}
+
+// SkippedLogs resolves the number of log records skipped by the analysis.
+func (ds *DenylistStats) SkippedLogs() hexutil.Uint64 {
+	return hexutil.Uint64(ds.DenylistStats.SkippedLogs)
+}
+
+// LastReloaded resolves the UNIX time stamp of the last denylist load.
+func (ds *DenylistStats) LastReloaded() *hexutil.Uint64 {
+	if ds.DenylistStats.LastReloaded == nil {
+		return nil
+	}
+	ts := hexutil.Uint64(ds.DenylistStats.LastReloaded.Unix())
+	return &ts
+}
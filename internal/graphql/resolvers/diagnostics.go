@@ -0,0 +1,162 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/auth"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/svc"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"sync/atomic"
+	"time"
+)
+
+// Diagnostics represents resolvable runtime state of the API server.
+type Diagnostics struct {
+	types.SvcDiagnostics
+	Conn                *types.ConnectionStatus
+	Pools               []types.WorkerPoolStatus
+	Started             time.Time
+	ActiveSubscriptions int32
+}
+
+// ScannerStatus represents resolvable progress of the block scanner.
+type ScannerStatus struct {
+	types.ScannerStatus
+}
+
+// QueueStatus represents resolvable depth of a processing queue.
+type QueueStatus struct {
+	types.QueueStatus
+}
+
+// WorkerPoolStatus represents resolvable load of a pool of background workers.
+type WorkerPoolStatus struct {
+	types.WorkerPoolStatus
+}
+
+// SubsystemStatus represents resolvable health of a subsystem.
+type SubsystemStatus struct {
+	types.SubsystemStatus
+}
+
+// Diagnostics resolves the runtime state of the API server.
+// The query requires the admin scope.
+func (rs *rootResolver) Diagnostics(ctx context.Context) (*Diagnostics, error) {
+	if err := auth.Require(ctx, auth.ScopeAdmin); err != nil {
+		return nil, err
+	}
+
+	return &Diagnostics{
+		SvcDiagnostics:      *svc.Manager().Diagnostics(),
+		Conn:                repository.R().ConnectionStatus(),
+		Pools:               repository.R().WorkerPools(),
+		Started:             rs.started,
+		ActiveSubscriptions: atomic.LoadInt32(&rs.activeSubCount),
+	}, nil
+}
+
+// Uptime resolves the number of seconds since the API server start.
+func (d *Diagnostics) Uptime() hexutil.Uint64 {
+	return hexutil.Uint64(time.Since(d.Started) / time.Second)
+}
+
+// NodeHead resolves the current block height of the connected node.
+func (d *Diagnostics) NodeHead() hexutil.Uint64 {
+	return hexutil.Uint64(d.Conn.NodeHead)
+}
+
+// Scanner resolves the progress of the block scanner.
+func (d *Diagnostics) Scanner() *ScannerStatus {
+	return &ScannerStatus{d.SvcDiagnostics.Scanner}
+}
+
+// ScannerLag resolves the number of blocks the scanner is behind the node head.
+// The lag is measured against the last block dispatched in order.
+func (d *Diagnostics) ScannerLag() hexutil.Uint64 {
+	if d.Conn.NodeHead <= d.SvcDiagnostics.Scanner.Dispatched {
+		return 0
+	}
+	return hexutil.Uint64(d.Conn.NodeHead - d.SvcDiagnostics.Scanner.Dispatched)
+}
+
+// Queues resolves the list of the processing queues.
+func (d *Diagnostics) Queues() []*QueueStatus {
+	list := make([]*QueueStatus, len(d.SvcDiagnostics.Queues))
+	for i, q := range d.SvcDiagnostics.Queues {
+		list[i] = &QueueStatus{q}
+	}
+	return list
+}
+
+// Workers resolves the load of the background worker pools.
+func (d *Diagnostics) Workers() []*WorkerPoolStatus {
+	list := make([]*WorkerPoolStatus, len(d.Pools))
+	for i, wp := range d.Pools {
+		list[i] = &WorkerPoolStatus{wp}
+	}
+	return list
+}
+
+// Subsystems resolves the status of the repository connections and the data processing services.
+func (d *Diagnostics) Subsystems() []*SubsystemStatus {
+	list := make([]*SubsystemStatus, 0, len(d.Services)+2)
+	list = append(list, &SubsystemStatus{d.Conn.Rpc}, &SubsystemStatus{d.Conn.Db})
+	for _, s := range d.Services {
+		list = append(list, &SubsystemStatus{s})
+	}
+	return list
+}
+
+// Next resolves the number of the next block to be scanned.
+func (ss *ScannerStatus) Next() hexutil.Uint64 {
+	return hexutil.Uint64(ss.ScannerStatus.Next)
+}
+
+// Target resolves the number of the last block of the current scan range.
+func (ss *ScannerStatus) Target() hexutil.Uint64 {
+	return hexutil.Uint64(ss.ScannerStatus.Target)
+}
+
+// Dispatched resolves the number of the last block processed in order.
+func (ss *ScannerStatus) Dispatched() hexutil.Uint64 {
+	return hexutil.Uint64(ss.ScannerStatus.Dispatched)
+}
+
+// Length resolves the number of items waiting in the queue.
+func (qs *QueueStatus) Length() int32 {
+	return int32(qs.QueueStatus.Length)
+}
+
+// Capacity resolves the max number of items of the queue.
+func (qs *QueueStatus) Capacity() int32 {
+	return int32(qs.QueueStatus.Capacity)
+}
+
+// Active resolves the number of workers busy with a task.
+func (wp *WorkerPoolStatus) Active() int32 {
+	return int32(wp.WorkerPoolStatus.Active)
+}
+
+// Size resolves the number of workers of the pool.
+func (wp *WorkerPoolStatus) Size() int32 {
+	return int32(wp.WorkerPoolStatus.Size)
+}
+
+// LastError resolves the UNIX time stamp of the most recent error of the subsystem.
+func (ss *SubsystemStatus) LastError() *hexutil.Uint64 {
+	if ss.SubsystemStatus.LastError == nil {
+		return nil
+	}
+	ts := hexutil.Uint64(ss.SubsystemStatus.LastError.Unix())
+	return &ts
+}
+
+// LastErrorMessage resolves the message of the most recent error of the subsystem.
+func (ss *SubsystemStatus) LastErrorMessage() *string {
+	if ss.SubsystemStatus.LastError == nil {
+		return nil
+	}
+	return &ss.SubsystemStatus.LastErrorMessage
+}
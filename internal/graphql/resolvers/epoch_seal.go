@@ -0,0 +1,44 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// EpochSeal represents resolvable seal of an epoch.
+type EpochSeal struct {
+	types.EpochSeal
+}
+
+// NewEpochSeal builds new resolvable epoch seal structure.
+func NewEpochSeal(es *types.EpochSeal) *EpochSeal {
+	if es == nil {
+		return nil
+	}
+	return &EpochSeal{EpochSeal: *es}
+}
+
+// EpochSeal resolves the seal of the given epoch, or the last known seal.
+func (rs *rootResolver) EpochSeal(args *struct{ Id *hexutil.Uint64 }) (*EpochSeal, error) {
+	es, err := repository.R().EpochSeal(args.Id)
+	if err != nil {
+		return nil, err
+	}
+	return NewEpochSeal(es), nil
+}
+
+// Timestamp resolves the unix timestamp of the sealing block.
+func (es *EpochSeal) Timestamp() hexutil.Uint64 {
+	return hexutil.Uint64(es.TimeStamp.Unix())
+}
+
+// EpochSeal resolves the seal of the epoch containing the block.
+func (blk *Block) EpochSeal() (*EpochSeal, error) {
+	es, err := repository.R().BlockEpochSeal(blk.Number)
+	if err != nil {
+		return nil, err
+	}
+	return NewEpochSeal(es), nil
+}
@@ -94,9 +94,22 @@ func (token *ERC20Token) TotalSupply() hexutil.Big {
 	return s
 }
 
-// BalanceOf resolves the available balance of the given ERC20 token to a user.
-func (token *ERC20Token) BalanceOf(args *struct{ Owner common.Address }) hexutil.Big {
-	b, err := repository.R().Erc20BalanceOf(&token.Address, &args.Owner)
+// erc20BalanceArgs represents the arguments of the ERC20 balance resolvers.
+type erc20BalanceArgs struct {
+	Owner    common.Address
+	BlockTag *BlockTag
+}
+
+// BalanceOf resolves the available balance of the given ERC20 token to a user
+// at the block identified by the given tag, or at the latest block.
+func (token *ERC20Token) BalanceOf(args *erc20BalanceArgs) hexutil.Big {
+	var b hexutil.Big
+	var err error
+	if args.BlockTag == nil {
+		b, err = repository.R().Erc20BalanceOf(&token.Address, &args.Owner)
+	} else {
+		b, err = repository.R().Erc20BalanceOfAt(&token.Address, &args.Owner, blockTagOf(args.BlockTag))
+	}
 	if err != nil {
 		log.Errorf("balance of %s for %s not known; %s", token.Address.String(), args.Owner.String(), err.Error())
 		return hexutil.Big{}
@@ -104,6 +117,13 @@ func (token *ERC20Token) BalanceOf(args *struct{ Owner common.Address }) hexutil
 	return b
 }
 
+// FormattedBalanceOf resolves the available balance of the given ERC20 token
+// to a user scaled by the token decimals.
+func (token *ERC20Token) FormattedBalanceOf(args *erc20BalanceArgs) (*FormattedTokenAmount, error) {
+	b := token.BalanceOf(args)
+	return newFormattedTokenAmount(&token.Address, b.ToInt())
+}
+
 // Allowance resolves the unlocked allowance of the given ERC20 token from the owner to spender.
 func (token *ERC20Token) Allowance(args *struct {
 	Owner   common.Address
@@ -40,3 +40,8 @@ func (trx *ERC20Transaction) Token() *ERC20Token {
 func (trx *ERC20Transaction) TrxType() string {
 	return ercTrxTypeToName(trx.Type)
 }
+
+// FormattedAmount resolves the amount of tokens involved scaled by the token decimals.
+func (trx *ERC20Transaction) FormattedAmount() (*FormattedTokenAmount, error) {
+	return newFormattedTokenAmount(&trx.TokenAddress, trx.Amount.ToInt())
+}
@@ -2,14 +2,19 @@
 package resolvers
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository"
 )
 
 // Erc721ContractList resolves an instance of ERC721 token list if available.
-func (rs *rootResolver) Erc721ContractList(args struct{ Count int32 }) ([]*ERC721Contract, error) {
+func (rs *rootResolver) Erc721ContractList(ctx context.Context, args struct{ Count int32 }) ([]*ERC721Contract, error) {
 	// limit query size; the count can be either positive or negative
 	// this controls the loading direction
-	args.Count = listLimitCount(args.Count, listMaxEdgesPerRequest)
+	count, err := listPageSize(ctx, args.Count, listMaxEdgesPerRequest)
+	if err != nil {
+		return nil, err
+	}
+	args.Count = count
 
 	// get the list of addresses of active tokens
 	al, err := repository.R().Erc721ContractsList(args.Count)
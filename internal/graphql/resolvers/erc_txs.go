@@ -1,6 +1,7 @@
 package resolvers
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository"
 	"fantom-api-graphql/internal/types"
 	"github.com/ethereum/go-ethereum/common"
@@ -9,7 +10,7 @@ import (
 )
 
 // Erc20Transactions resolves list of ERC20 transactions.
-func (rs *rootResolver) Erc20Transactions(args struct {
+func (rs *rootResolver) Erc20Transactions(ctx context.Context, args struct {
 	Cursor  *Cursor
 	Count   int32
 	Token   *common.Address
@@ -18,7 +19,11 @@ func (rs *rootResolver) Erc20Transactions(args struct {
 }) (*ERC20TransactionList, error) {
 	// limit query size; the count can be either positive or negative
 	// this controls the loading direction
-	args.Count = listLimitCount(args.Count, accMaxTransactionsPerRequest)
+	count, err := listPageSize(ctx, args.Count, accMaxTransactionsPerRequest)
+	if err != nil {
+		return nil, err
+	}
+	args.Count = count
 
 	// get the transaction hash list from repository
 	tl, err := repository.R().TokenTransactions(
@@ -38,7 +43,7 @@ func (rs *rootResolver) Erc20Transactions(args struct {
 }
 
 // Erc721Transactions resolves list of ERC721 transactions.
-func (rs *rootResolver) Erc721Transactions(args struct {
+func (rs *rootResolver) Erc721Transactions(ctx context.Context, args struct {
 	Cursor  *Cursor
 	Count   int32
 	Token   *common.Address
@@ -48,7 +53,11 @@ func (rs *rootResolver) Erc721Transactions(args struct {
 }) (*ERC721TransactionList, error) {
 	// limit query size; the count can be either positive or negative
 	// this controls the loading direction
-	args.Count = listLimitCount(args.Count, accMaxTransactionsPerRequest)
+	count, err := listPageSize(ctx, args.Count, accMaxTransactionsPerRequest)
+	if err != nil {
+		return nil, err
+	}
+	args.Count = count
 
 	// get the transaction hash list from repository
 	tl, err := repository.R().TokenTransactions(
@@ -68,7 +77,7 @@ func (rs *rootResolver) Erc721Transactions(args struct {
 }
 
 // Erc1155Transactions resolves list of ERC1155 transactions.
-func (rs *rootResolver) Erc1155Transactions(args struct {
+func (rs *rootResolver) Erc1155Transactions(ctx context.Context, args struct {
 	Cursor  *Cursor
 	Count   int32
 	Token   *common.Address
@@ -78,7 +87,11 @@ func (rs *rootResolver) Erc1155Transactions(args struct {
 }) (*ERC1155TransactionList, error) {
 	// limit query size; the count can be either positive or negative
 	// this controls the loading direction
-	args.Count = listLimitCount(args.Count, accMaxTransactionsPerRequest)
+	count, err := listPageSize(ctx, args.Count, accMaxTransactionsPerRequest)
+	if err != nil {
+		return nil, err
+	}
+	args.Count = count
 
 	// get the transaction hash list from repository
 	tl, err := repository.R().TokenTransactions(
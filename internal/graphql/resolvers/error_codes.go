@@ -0,0 +1,114 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"errors"
+	"fantom-api-graphql/internal/auth"
+	"fantom-api-graphql/internal/repository"
+	"github.com/ethereum/go-ethereum/rpc"
+	"go.mongodb.org/mongo-driver/mongo"
+	"io"
+	"net"
+	"net/http"
+)
+
+// Error codes reported to API clients in the extensions.code field
+// of the GraphQL errors.
+const (
+	ErrCodeNodeUnavailable    = "NODE_UNAVAILABLE"
+	ErrCodeStorageUnavailable = "STORAGE_UNAVAILABLE"
+	ErrCodeNotFound           = "NOT_FOUND"
+	ErrCodeInvalidCursor      = "INVALID_CURSOR"
+	ErrCodeRateLimited        = "RATE_LIMITED"
+	ErrCodeTimeout            = "TIMEOUT"
+	ErrCodeUnauthorized       = "UNAUTHORIZED"
+	ErrCodeFeatureDisabled    = "FEATURE_DISABLED"
+	ErrCodeNotSupported       = "NOT_SUPPORTED"
+	ErrCodeInvalidArgument    = "INVALID_ARGUMENT"
+	ErrCodeArchiveRequired    = "ARCHIVE_REQUIRED"
+)
+
+// ErrFeatureDisabled is returned for API features disabled by the server configuration.
+var ErrFeatureDisabled = errors.New("feature is disabled on this server")
+
+// errorCodes maps the known sentinel errors to the error code reported to clients.
+var errorCodes = []struct {
+	err  error
+	code string
+}{
+	{err: repository.ErrBlockNotFound, code: ErrCodeNotFound},
+	{err: repository.ErrTransactionNotFound, code: ErrCodeNotFound},
+	{err: repository.ErrValidatorNotFound, code: ErrCodeNotFound},
+	{err: repository.ErrUnknownDelegation, code: ErrCodeNotFound},
+	{err: repository.ErrUniswapPairNotFound, code: ErrCodeNotFound},
+	{err: repository.ErrTokenPriceNotSampled, code: ErrCodeNotFound},
+	{err: repository.ErrGovernanceContractNotFound, code: ErrCodeNotFound},
+	{err: repository.ErrAccountNotFound, code: ErrCodeNotFound},
+	{err: mongo.ErrNoDocuments, code: ErrCodeNotFound},
+	{err: repository.ErrAbiMethodNotFound, code: ErrCodeNotFound},
+	{err: repository.ErrInvalidCursor, code: ErrCodeInvalidCursor},
+	{err: repository.ErrInvalidAbi, code: ErrCodeInvalidArgument},
+	{err: repository.ErrContractCodeMissing, code: ErrCodeInvalidArgument},
+	{err: repository.ErrInvalidTypedData, code: ErrCodeInvalidArgument},
+	{err: repository.ErrWebhookTargetForbidden, code: ErrCodeInvalidArgument},
+	{err: auth.ErrUnauthorized, code: ErrCodeUnauthorized},
+	{err: ErrFeatureDisabled, code: ErrCodeFeatureDisabled},
+	{err: errSimulateCallDisabled, code: ErrCodeFeatureDisabled},
+	{err: errStorageAtDisabled, code: ErrCodeFeatureDisabled},
+	{err: errSendTransactionDisabled, code: ErrCodeFeatureDisabled},
+	{err: repository.ErrUniswapNotConfigured, code: ErrCodeFeatureDisabled},
+	{err: repository.ErrWebhooksDisabled, code: ErrCodeFeatureDisabled},
+	{err: repository.ErrTraceNotSupported, code: ErrCodeNotSupported},
+	{err: repository.ErrBlockTagNotSupported, code: ErrCodeNotSupported},
+	{err: repository.ErrArchiveRequired, code: ErrCodeArchiveRequired},
+	{err: context.DeadlineExceeded, code: ErrCodeTimeout},
+	{err: context.Canceled, code: ErrCodeTimeout},
+	{err: rpc.ErrClientQuit, code: ErrCodeNodeUnavailable},
+	{err: io.EOF, code: ErrCodeNodeUnavailable},
+}
+
+// ErrorCode provides the error code of the given resolver error, or an empty string
+// if the error is not recognized. Errors are recognized by the sentinel errors
+// they wrap and by the type of the failed network communication.
+func ErrorCode(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	for _, ec := range errorCodes {
+		if errors.Is(err, ec.err) {
+			return ec.code
+		}
+	}
+
+	// the node rejected the call on the HTTP level
+	var he rpc.HTTPError
+	if errors.As(err, &he) {
+		if he.StatusCode == http.StatusTooManyRequests {
+			return ErrCodeRateLimited
+		}
+		if he.StatusCode >= http.StatusInternalServerError {
+			return ErrCodeNodeUnavailable
+		}
+		return ""
+	}
+
+	// the database is not reachable
+	if mongo.IsNetworkError(err) {
+		return ErrCodeStorageUnavailable
+	}
+	if mongo.IsTimeout(err) {
+		return ErrCodeTimeout
+	}
+
+	// the node is not reachable
+	var ne net.Error
+	if errors.As(err, &ne) {
+		if ne.Timeout() {
+			return ErrCodeTimeout
+		}
+		return ErrCodeNodeUnavailable
+	}
+	return ""
+}
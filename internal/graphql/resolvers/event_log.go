@@ -0,0 +1,218 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// EventLog represents resolvable log record emitted by a transaction.
+type EventLog struct {
+	*types.EventLog
+}
+
+// EventLogList represents resolvable page of log records.
+type EventLogList struct {
+	types.EventLogList
+	isStart bool
+}
+
+// EventLogListEdge represents a single edge of a log records list.
+type EventLogListEdge struct {
+	Log    *EventLog
+	Cursor Cursor
+}
+
+// Logs resolves a page of log records filtered by the emitting contract and topics
+// inside the given range of blocks, similar to the eth_getLogs call. The range ends at the latest
+// block and starts at the end of the range, if the boundaries are not specified.
+func (rs *rootResolver) Logs(ctx context.Context, args *struct {
+	Address   *common.Address
+	Topics    *[]*[]common.Hash
+	FromBlock *hexutil.Uint64
+	ToBlock   *hexutil.Uint64
+	Cursor    *Cursor
+	Count     int32
+}) (*EventLogList, error) {
+	filter, err := eventLogFilter(args.Address, args.Topics, args.FromBlock, args.ToBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	// the logs are listed forward only
+	if args.Count <= 0 {
+		return nil, fmt.Errorf("count must be positive")
+	}
+	count, err := listPageSize(ctx, args.Count, listMaxEdgesPerRequest)
+	if err != nil {
+		return nil, err
+	}
+	args.Count = count
+
+	list, err := repository.R().EventLogs(filter, (*string)(args.Cursor), args.Count)
+	if err != nil {
+		log.Errorf("can not get logs; %s", err.Error())
+		return nil, err
+	}
+	return &EventLogList{EventLogList: *list, isStart: args.Cursor == nil}, nil
+}
+
+// LogsByEvent resolves a page of log records of the given event of the contract with known ABI
+// filtered by the values of the decoded event arguments inside the given range of blocks.
+func (rs *rootResolver) LogsByEvent(ctx context.Context, args *struct {
+	Address   common.Address
+	Event     string
+	Args      *[]types.EventArgFilter
+	FromBlock *hexutil.Uint64
+	ToBlock   *hexutil.Uint64
+	Cursor    *Cursor
+	Count     int32
+}) (*EventLogList, error) {
+	filter, err := eventLogFilter(&args.Address, nil, args.FromBlock, args.ToBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	// the logs are listed forward only
+	if args.Count <= 0 {
+		return nil, fmt.Errorf("count must be positive")
+	}
+	count, err := listPageSize(ctx, args.Count, listMaxEdgesPerRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	var af []types.EventArgFilter
+	if args.Args != nil {
+		af = *args.Args
+	}
+
+	list, err := repository.R().EventLogsByArgs(filter, args.Event, af, (*string)(args.Cursor), count)
+	if err != nil {
+		return nil, err
+	}
+	return &EventLogList{EventLogList: *list, isStart: args.Cursor == nil}, nil
+}
+
+// eventLogFilter builds the log records filter validating the requested block range.
+func eventLogFilter(addr *common.Address, topics *[]*[]common.Hash, from *hexutil.Uint64, to *hexutil.Uint64) (*types.EventLogFilter, error) {
+	filter := types.EventLogFilter{Address: addr}
+
+	// the range ends at the latest block by default
+	if to != nil {
+		filter.ToBlock = uint64(*to)
+	} else {
+		height, err := repository.R().BlockHeight()
+		if err != nil {
+			log.Errorf("can not get the current block height; %s", err.Error())
+			return nil, err
+		}
+		filter.ToBlock = height.ToInt().Uint64()
+	}
+
+	filter.FromBlock = filter.ToBlock
+	if from != nil {
+		filter.FromBlock = uint64(*from)
+	}
+
+	if filter.FromBlock > filter.ToBlock {
+		return nil, fmt.Errorf("invalid block range %d - %d", filter.FromBlock, filter.ToBlock)
+	}
+	if filter.ToBlock-filter.FromBlock >= cfg.Server.MaxLogBlockRange {
+		return nil, fmt.Errorf("block range too wide, %d blocks allowed", cfg.Server.MaxLogBlockRange)
+	}
+
+	// topic alternatives by position; missing position matches any topic
+	if topics != nil {
+		filter.Topics = make([][]common.Hash, len(*topics))
+		for i, alt := range *topics {
+			if alt != nil {
+				filter.Topics[i] = *alt
+			}
+		}
+	}
+	return &filter, nil
+}
+
+// Edges resolves the list of log record edges.
+func (ell *EventLogList) Edges() []*EventLogListEdge {
+	edges := make([]*EventLogListEdge, len(ell.Collection))
+	for i, lg := range ell.Collection {
+		edges[i] = &EventLogListEdge{
+			Log:    &EventLog{EventLog: lg},
+			Cursor: Cursor(lg.Cursor),
+		}
+	}
+	return edges
+}
+
+// PageInfo resolves the current page information for the log records list.
+// The next page continues after the next cursor of the list, which follows the last record
+// of the page if the records were filtered after loading.
+func (ell *EventLogList) PageInfo() (*ListPageInfo, error) {
+	var first, last *Cursor
+	if len(ell.Collection) > 0 {
+		f := Cursor(ell.Collection[0].Cursor)
+		l := Cursor(ell.Collection[len(ell.Collection)-1].Cursor)
+		first, last = &f, &l
+	}
+	if ell.Next != nil {
+		l := Cursor(*ell.Next)
+		last = &l
+	}
+	return NewListPageInfo(first, last, ell.Next != nil, !ell.isStart)
+}
+
+// Address resolves the address of the contract emitting the log record.
+func (lg *EventLog) Address() common.Address {
+	return lg.EventLog.Address
+}
+
+// Topics resolves the list of indexed topics of the log record.
+func (lg *EventLog) Topics() []common.Hash {
+	return lg.EventLog.Topics
+}
+
+// Data resolves the raw data of the log record.
+func (lg *EventLog) Data() hexutil.Bytes {
+	return lg.EventLog.Data
+}
+
+// BlockNumber resolves the number of the block the log record belongs to.
+func (lg *EventLog) BlockNumber() hexutil.Uint64 {
+	return hexutil.Uint64(lg.EventLog.BlockNumber)
+}
+
+// BlockHash resolves the hash of the block the log record belongs to.
+func (lg *EventLog) BlockHash() common.Hash {
+	return lg.EventLog.BlockHash
+}
+
+// TransactionHash resolves the hash of the transaction emitting the log record.
+func (lg *EventLog) TransactionHash() common.Hash {
+	return lg.TxHash
+}
+
+// TransactionIndex resolves the index of the transaction in the block.
+func (lg *EventLog) TransactionIndex() hexutil.Uint64 {
+	return hexutil.Uint64(lg.TxIndex)
+}
+
+// LogIndex resolves the index of the log record in the block.
+func (lg *EventLog) LogIndex() hexutil.Uint64 {
+	return hexutil.Uint64(lg.Index)
+}
+
+// Removed resolves the flag of a log record reverted by a chain reorganization.
+func (lg *EventLog) Removed() bool {
+	return lg.EventLog.Removed
+}
+
+// Event resolves the event decoded from the log record, if the contract ABI is known.
+func (lg *EventLog) Event() *types.DecodedEvent {
+	return lg.EventLog.Event
+}
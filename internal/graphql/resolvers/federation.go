@@ -0,0 +1,169 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	gqlschema "fantom-api-graphql/internal/graphql/schema"
+	"fantom-api-graphql/internal/repository"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// FederationAny represents an entity representation sent by the federation gateway;
+// it contains the entity type name and the key fields of the entity.
+type FederationAny map[string]interface{}
+
+// FederationService represents the service schema provided to the federation gateway.
+type FederationService struct{}
+
+// FederationEntity represents a resolvable entity referenced by the federation gateway.
+type FederationEntity struct {
+	acc *Account
+	blk *Block
+	trx *Transaction
+}
+
+// entityLoader represents a function loading an entity by its key value; nil is provided
+// for entities not found.
+type entityLoader func(key interface{}) (*FederationEntity, error)
+
+// entityLoaders maps the entity types to their loaders.
+var entityLoaders = map[string]entityLoader{
+	"Account":     loadAccountEntity,
+	"Block":       loadBlockEntity,
+	"Transaction": loadTransactionEntity,
+}
+
+// ImplementsGraphQLType returns true if the type implements the given GraphQL type.
+func (FederationAny) ImplementsGraphQLType(name string) bool {
+	return name == "_Any"
+}
+
+// UnmarshalGraphQL decodes the entity representation object.
+func (fa *FederationAny) UnmarshalGraphQL(input interface{}) error {
+	obj, ok := input.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("entity representation must be an object, %T given", input)
+	}
+	*fa = obj
+	return nil
+}
+
+// Service resolves the schema of the service for the federation gateway.
+func (rs *rootResolver) Service() FederationService {
+	return FederationService{}
+}
+
+// Sdl resolves the schema definition annotated with the federation directives.
+func (FederationService) Sdl() *string {
+	sdl := gqlschema.FederationSDL()
+	return &sdl
+}
+
+// Entities resolves the entities referenced by the given representations
+// in the order of the representations; entities not found are resolved as nil.
+func (rs *rootResolver) Entities(args struct{ Representations []FederationAny }) ([]*FederationEntity, error) {
+	list := make([]*FederationEntity, len(args.Representations))
+	for i, rep := range args.Representations {
+		name, ok := rep["__typename"].(string)
+		if !ok {
+			return nil, fmt.Errorf("entity representation #%d without type name", i)
+		}
+
+		load, ok := entityLoaders[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown entity type %s", name)
+		}
+
+		key, ok := rep[gqlschema.FederationEntityKeys[name]]
+		if !ok {
+			return nil, fmt.Errorf("entity representation #%d without %s key", i, gqlschema.FederationEntityKeys[name])
+		}
+
+		ent, err := load(key)
+		if err != nil {
+			log.Errorf("can not resolve %s entity; %s", name, err.Error())
+			return nil, err
+		}
+		list[i] = ent
+	}
+	return list, nil
+}
+
+// ToAccount resolves the entity as an account, if applicable.
+func (fe *FederationEntity) ToAccount() (*Account, bool) {
+	return fe.acc, fe.acc != nil
+}
+
+// ToBlock resolves the entity as a block, if applicable.
+func (fe *FederationEntity) ToBlock() (*Block, bool) {
+	return fe.blk, fe.blk != nil
+}
+
+// ToTransaction resolves the entity as a transaction, if applicable.
+func (fe *FederationEntity) ToTransaction() (*Transaction, bool) {
+	return fe.trx, fe.trx != nil
+}
+
+// loadAccountEntity loads the account entity by its address.
+func loadAccountEntity(key interface{}) (*FederationEntity, error) {
+	s, ok := key.(string)
+	if !ok || !common.IsHexAddress(s) {
+		return nil, fmt.Errorf("invalid account address %v", key)
+	}
+
+	addr := common.HexToAddress(s)
+	acc, err := repository.R().Account(&addr)
+	if err != nil {
+		return nil, err
+	}
+	return &FederationEntity{acc: NewAccount(acc)}, nil
+}
+
+// loadBlockEntity loads the block entity by its number.
+func loadBlockEntity(key interface{}) (*FederationEntity, error) {
+	var num hexutil.Uint64
+	switch v := key.(type) {
+	case string:
+		n, err := hexutil.DecodeUint64(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid block number %s; %s", v, err.Error())
+		}
+		num = hexutil.Uint64(n)
+	case float64:
+		num = hexutil.Uint64(v)
+	default:
+		return nil, fmt.Errorf("invalid block number %v", key)
+	}
+
+	blk, err := repository.R().BlockByNumber(&num)
+	if err == repository.ErrBlockNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &FederationEntity{blk: NewBlock(blk)}, nil
+}
+
+// loadTransactionEntity loads the transaction entity by its hash.
+func loadTransactionEntity(key interface{}) (*FederationEntity, error) {
+	s, ok := key.(string)
+	if !ok {
+		return nil, fmt.Errorf("invalid transaction hash %v", key)
+	}
+
+	var hash common.Hash
+	if err := hash.UnmarshalText([]byte(s)); err != nil {
+		return nil, fmt.Errorf("invalid transaction hash %s; %s", s, err.Error())
+	}
+
+	trx, err := repository.R().Transaction(&hash, true)
+	if err == repository.ErrTransactionNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &FederationEntity{trx: NewTransaction(trx)}, nil
+}
@@ -0,0 +1,67 @@
+package resolvers
+
+import (
+	"context"
+	"encoding/json"
+	gqlschema "fantom-api-graphql/internal/graphql/schema"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/graph-gophers/graphql-go"
+	"github.com/onsi/gomega"
+	"testing"
+)
+
+// TestFederationEntities tests entity references are resolved by their keys.
+func TestFederationEntities(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	// replace the block loader to avoid the repository
+	hash := common.HexToHash("0x1234")
+	orig := entityLoaders["Block"]
+	defer func() { entityLoaders["Block"] = orig }()
+	entityLoaders["Block"] = func(key interface{}) (*FederationEntity, error) {
+		if key != "0x10" {
+			return nil, nil
+		}
+		return &FederationEntity{blk: NewBlock(&types.Block{Number: 16, Hash: hash})}, nil
+	}
+
+	schema := graphql.MustParseSchema(gqlschema.Federated(), &rootResolver{}, graphql.UseFieldResolvers())
+	res := schema.Exec(context.Background(), `query ($reps: [_Any!]!) {
+		_entities(representations: $reps) { ... on Block { number hash } }
+	}`, "", map[string]interface{}{
+		"reps": []interface{}{
+			map[string]interface{}{"__typename": "Block", "number": "0x10"},
+			map[string]interface{}{"__typename": "Block", "number": "0x11"},
+		},
+	})
+	g.Expect(res.Errors).To(gomega.BeEmpty())
+
+	var data struct {
+		Entities []*struct {
+			Number string `json:"number"`
+			Hash   string `json:"hash"`
+		} `json:"_entities"`
+	}
+	g.Expect(json.Unmarshal(res.Data, &data)).To(gomega.Succeed())
+	g.Expect(data.Entities).To(gomega.HaveLen(2))
+	g.Expect(data.Entities[0].Number).To(gomega.Equal(hexutil.Uint64(16).String()))
+	g.Expect(data.Entities[0].Hash).To(gomega.Equal(hash.String()))
+	g.Expect(data.Entities[1]).To(gomega.BeNil())
+
+	// unknown entity types are rejected
+	res = schema.Exec(context.Background(), `{ _entities(representations: [{__typename: "Epoch", id: "0x1"}]) { __typename } }`, "", nil)
+	g.Expect(res.Errors).NotTo(gomega.BeEmpty())
+}
+
+// TestFederationSDL tests the entity types are annotated with the federation key.
+func TestFederationSDL(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	sdl := *FederationService{}.Sdl()
+	for name, key := range gqlschema.FederationEntityKeys {
+		g.Expect(sdl).To(gomega.ContainSubstring(fmt.Sprintf(`type %s @key(fields: "%s") {`, name, key)))
+	}
+}
@@ -2,6 +2,7 @@
 package resolvers
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository"
 	"fmt"
 	"github.com/ethereum/go-ethereum/common"
@@ -77,14 +78,18 @@ func (gc *GovernanceContract) Proposal(args *struct{ Id hexutil.Big }) (*Governa
 }
 
 // Proposals resolves list of Governance contract proposals encapsulated in a listable structure.
-func (gc *GovernanceContract) Proposals(args *struct {
+func (gc *GovernanceContract) Proposals(ctx context.Context, args *struct {
 	Cursor     *Cursor
 	Count      int32
 	ActiveOnly bool
 }) (*GovernanceProposalList, error) {
 	// limit query size; the count can be either positive or negative
 	// this controls the loading direction
-	args.Count = listLimitCount(args.Count, listMaxEdgesPerRequest)
+	count, err := listPageSize(ctx, args.Count, listMaxEdgesPerRequest)
+	if err != nil {
+		return nil, err
+	}
+	args.Count = count
 
 	// get the list of all proposals
 	list, err := repository.R().GovernanceProposals([]*common.Address{&gc.Address}, (*string)(args.Cursor), args.Count, args.ActiveOnly)
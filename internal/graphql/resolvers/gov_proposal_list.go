@@ -2,6 +2,7 @@
 package resolvers
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository"
 	"fantom-api-graphql/internal/types"
 	"github.com/ethereum/go-ethereum/common"
@@ -28,14 +29,18 @@ func NewGovernanceProposalList(gps *types.GovernanceProposalList) *GovernancePro
 
 // GovProposals resolves list of proposals across all the known governance
 // contracts in a browsable structure.
-func (rs *rootResolver) GovProposals(args struct {
+func (rs *rootResolver) GovProposals(ctx context.Context, args struct {
 	Cursor     *Cursor
 	Count      int32
 	ActiveOnly bool
 }) (*GovernanceProposalList, error) {
 	// limit query size; the count can be either positive or negative
 	// this controls the loading direction
-	args.Count = listLimitCount(args.Count, listMaxEdgesPerRequest)
+	count, err := listPageSize(ctx, args.Count, listMaxEdgesPerRequest)
+	if err != nil {
+		return nil, err
+	}
+	args.Count = count
 
 	// prep list of governance contracts we are interested in
 	gcl := make([]*common.Address, len(cfg.Governance.Contracts))
@@ -20,7 +20,7 @@ type ApiResolver interface {
 	Version() string
 
 	// Epochs resolves a list of epochs for the given cursor and count.
-	Epochs(args struct {
+	Epochs(ctx context.Context, args struct {
 		Cursor *Cursor
 		Count  int32
 	}) (*EpochList, error)
@@ -29,7 +29,7 @@ type ApiResolver interface {
 	Account(struct{ Address common.Address }) (*Account, error)
 
 	// Contracts resolves list of blockchain smart contracts encapsulated in a listable structure.
-	Contracts(*struct {
+	Contracts(context.Context, *struct {
 		ValidatedOnly bool
 		Cursor        *Cursor
 		Count         int32
@@ -47,7 +47,7 @@ type ApiResolver interface {
 	}) (*Block, error)
 
 	// Blocks resolves list of blockchain blocks encapsulated in a listable structure.
-	Blocks(*struct {
+	Blocks(context.Context, *struct {
 		Cursor *Cursor
 		Count  int32
 	}) (*BlockList, error)
@@ -56,7 +56,7 @@ type ApiResolver interface {
 	Transaction(*struct{ Hash common.Hash }) (*Transaction, error)
 
 	// Transactions resolves list of blockchain transactions encapsulated in a listable structure.
-	Transactions(*struct {
+	Transactions(context.Context, *struct {
 		Cursor *Cursor
 		Count  int32
 	}) (*TransactionList, error)
@@ -67,6 +67,9 @@ type ApiResolver interface {
 	// OnTransaction resolves subscription to new transactions' event broadcast.
 	OnTransaction(ctx context.Context) <-chan *Transaction
 
+	// OnPendingTransaction resolves subscription to new pending transactions' event broadcast.
+	OnPendingTransaction(ctx context.Context, args struct{ Address *common.Address }) <-chan *Transaction
+
 	// CurrentEpoch resolves id of the current epoch.
 	CurrentEpoch() (hexutil.Uint64, error)
 
@@ -95,24 +98,24 @@ type ApiResolver interface {
 	}) (*Delegation, error)
 
 	// DelegationsOf a list of delegations information of a staker.
-	DelegationsOf(*struct {
+	DelegationsOf(context.Context, *struct {
 		Staker hexutil.Big
 		Cursor *Cursor
 		Count  int32
 	}) (*DelegationList, error)
 
 	// DelegationsByAddress a list of own delegations by the account address.
-	DelegationsByAddress(*struct {
+	DelegationsByAddress(context.Context, *struct {
 		Address common.Address
 		Cursor  *Cursor
 		Count   int32
 	}) (*DelegationList, error)
 
 	// Price resolves price details of the Opera blockchain token for the given target symbols.
-	Price(*struct{ To string }) (types.Price, error)
+	Price(context.Context, *struct{ To string }) (types.Price, error)
 
 	// GasPrice resolves the current amount of WEI for single Gas.
-	GasPrice() (hexutil.Uint64, error)
+	GasPrice(context.Context) (hexutil.Uint64, error)
 
 	// EstimateGas resolves the estimated amount of Gas required to perform
 	// transaction described by the input params.
@@ -141,6 +144,13 @@ type ApiResolver interface {
 	// SendTransaction sends raw signed and RLP encoded transaction to the blockchain.
 	SendTransaction(*struct{ Tx hexutil.Bytes }) (*Transaction, error)
 
+	// SendRawTransaction sends raw signed and RLP encoded transaction to the blockchain
+	// and resolves the hash of the transaction.
+	SendRawTransaction(*struct {
+		Data           hexutil.Bytes
+		IdempotencyKey *string
+	}) (common.Hash, error)
+
 	// DefiConfiguration resolves the current DeFi contract settings.
 	DefiConfiguration() (*DefiConfiguration, error)
 
@@ -185,10 +195,10 @@ type ApiResolver interface {
 	Erc20Token(*struct{ Token common.Address }) *ERC20Token
 
 	// Erc20TokenList resolves a list of instances of ERC20 tokens.
-	Erc20TokenList(struct{ Count int32 }) ([]*ERC20Token, error)
+	Erc20TokenList(context.Context, struct{ Count int32 }) ([]*ERC20Token, error)
 
 	// Erc20Assets resolves a list of instances of ERC20 tokens for the given owner.
-	Erc20Assets(struct {
+	Erc20Assets(context.Context, struct {
 		Owner common.Address
 		Count int32
 	}) ([]*ERC20Token, error)
@@ -218,7 +228,7 @@ type ApiResolver interface {
 	GovContract(struct{ Address common.Address }) (*GovernanceContract, error)
 
 	// GovProposals represents list of joined proposals across all the Governance contracts.
-	GovProposals(struct {
+	GovProposals(context.Context, struct {
 		Cursor     *Cursor
 		Count      int32
 		ActiveOnly bool
@@ -226,19 +236,19 @@ type ApiResolver interface {
 
 	// TrxVolume resolves list of daily aggregations
 	// of the network transaction flow.
-	TrxVolume(args struct {
+	TrxVolume(ctx context.Context, args struct {
 		From *string
 		To   *string
 	}) ([]*DailyTrxVolume, error)
 
 	// TrxSpeed resolves the recent speed of the network in transactions processed per second.
-	TrxSpeed(args struct {
+	TrxSpeed(ctx context.Context, args struct {
 		Range int32
 	}) (float64, error)
 
 	// TrxGasSpeed resolves the gas consumption speed
 	// of the network in transactions processed per second.
-	TrxGasSpeed(args struct {
+	TrxGasSpeed(ctx context.Context, args struct {
 		Range int32
 		To    *string
 	}) (float64, error)
@@ -0,0 +1,46 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// InternalTransaction represents resolvable internal call of a transaction.
+type InternalTransaction struct {
+	types.InternalTransaction
+}
+
+// InternalTransactions resolves the list of internal calls executed by the given transaction.
+// Nil is resolved if the connected node does not support transaction tracing.
+func (rs *rootResolver) InternalTransactions(args *struct{ Hash common.Hash }) (*[]*InternalTransaction, error) {
+	list, err := repository.R().InternalTransactions(&args.Hash)
+	if err == repository.ErrTraceNotSupported {
+		log.Debugf("internal transactions of %s not available; %s", args.Hash.String(), err.Error())
+		return nil, nil
+	}
+	if err != nil {
+		log.Warningf("can not get internal transactions of %s; %s", args.Hash.String(), err.Error())
+		return nil, err
+	}
+
+	res := make([]*InternalTransaction, len(list))
+	for i, itx := range list {
+		res[i] = &InternalTransaction{InternalTransaction: *itx}
+	}
+	return &res, nil
+}
+
+// Failed resolves the failure status of the internal call.
+func (itx *InternalTransaction) Failed() bool {
+	return itx.Error != ""
+}
+
+// ErrorMessage resolves the error of a failed internal call, nil for successful calls.
+func (itx *InternalTransaction) ErrorMessage() *string {
+	if itx.Error == "" {
+		return nil
+	}
+	return &itx.Error
+}
@@ -0,0 +1,236 @@
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"sync"
+	"time"
+)
+
+const (
+	// loaderWait is the time a loader collects keys before the batch is dispatched.
+	loaderWait = 2 * time.Millisecond
+
+	// loaderMaxBatch is the max number of keys dispatched in a single batch.
+	loaderMaxBatch = 100
+)
+
+// ctxKeyLoaders is the context key of the request scoped data loaders.
+type ctxKeyLoaders struct{}
+
+// loaders represents the set of data loaders attached to a single API request.
+type loaders struct {
+	accounts  *addressLoader
+	contracts *addressLoader
+}
+
+// addressBatchFn loads values for the given list of unique addresses.
+// Failed addresses are reported in the map of errors, so a single failed
+// address does not fail the whole batch.
+type addressBatchFn func([]common.Address) (map[common.Address]interface{}, map[common.Address]error)
+
+// loaderResult represents a pending, or already resolved, value of a loader key.
+type loaderResult struct {
+	done  chan struct{}
+	value interface{}
+	err   error
+}
+
+// addressLoader collects address lookups issued concurrently by resolvers
+// within a short time window and resolves them with a single batch call.
+// Each address is loaded only once and the result is cached for the lifetime
+// of the loader, which is a single API request.
+type addressLoader struct {
+	fetch    addressBatchFn
+	notFound error
+	wait     time.Duration
+	maxBatch int
+
+	mu    sync.Mutex
+	cache map[common.Address]*loaderResult
+	batch []common.Address
+}
+
+// newAddressLoader creates a new address loader using the given batch function.
+// Addresses missing in the batch result resolve to the not found error,
+// or to nil if no such error is given.
+func newAddressLoader(fetch addressBatchFn, notFound error, wait time.Duration, maxBatch int) *addressLoader {
+	return &addressLoader{
+		fetch:    fetch,
+		notFound: notFound,
+		wait:     wait,
+		maxBatch: maxBatch,
+		cache:    make(map[common.Address]*loaderResult),
+	}
+}
+
+// WithLoaders attaches a new set of request scoped data loaders to the context.
+func WithLoaders(ctx context.Context) context.Context {
+	return context.WithValue(ctx, ctxKeyLoaders{}, &loaders{
+		accounts:  newAddressLoader(loadAccountsBatch, repository.ErrAccountNotFound, loaderWait, loaderMaxBatch),
+		contracts: newAddressLoader(loadContractsBatch, nil, loaderWait, loaderMaxBatch),
+	})
+}
+
+// loadersFrom extracts the data loaders from the context, if available.
+func loadersFrom(ctx context.Context) *loaders {
+	if ctx == nil {
+		return nil
+	}
+	ld, _ := ctx.Value(ctxKeyLoaders{}).(*loaders)
+	return ld
+}
+
+// Load resolves the value of the given address, waiting for the batch it belongs to.
+func (l *addressLoader) Load(addr common.Address) (interface{}, error) {
+	l.mu.Lock()
+	res, ok := l.cache[addr]
+	if !ok {
+		res = &loaderResult{done: make(chan struct{})}
+		l.cache[addr] = res
+		l.batch = append(l.batch, addr)
+
+		// the first key of a batch schedules the dispatch; a full batch goes immediately
+		switch {
+		case len(l.batch) >= l.maxBatch:
+			go l.dispatch(l.takeBatch())
+		case len(l.batch) == 1:
+			time.AfterFunc(l.wait, func() {
+				l.mu.Lock()
+				batch := l.takeBatch()
+				l.mu.Unlock()
+				l.dispatch(batch)
+			})
+		}
+	}
+	l.mu.Unlock()
+
+	<-res.done
+	return res.value, res.err
+}
+
+// takeBatch detaches the collected batch of keys from the loader.
+// The loader lock must be held by the caller.
+func (l *addressLoader) takeBatch() []common.Address {
+	batch := l.batch
+	l.batch = nil
+	return batch
+}
+
+// dispatch loads the given batch of keys and resolves the pending results.
+func (l *addressLoader) dispatch(batch []common.Address) {
+	if len(batch) == 0 {
+		return
+	}
+
+	values, errs := l.fetch(batch)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, addr := range batch {
+		res := l.cache[addr]
+
+		var ok bool
+		res.err = errs[addr]
+		if res.err == nil {
+			if res.value, ok = values[addr]; !ok {
+				res.err = l.notFound
+			}
+		}
+
+		// failed keys are not cached so a later lookup can retry
+		if res.err != nil {
+			delete(l.cache, addr)
+		}
+		close(res.done)
+	}
+}
+
+// loadAccountsBatch is the batch function of the accounts loader.
+func loadAccountsBatch(addr []common.Address) (map[common.Address]interface{}, map[common.Address]error) {
+	list, err := repository.R().AccountsByAddress(addr)
+	if err != nil {
+		// the batch failed as a whole; load the accounts one by one to isolate the failed ones
+		return loadEach(addr, func(a *common.Address) (interface{}, error) {
+			return repository.R().Account(a)
+		})
+	}
+
+	res := make(map[common.Address]interface{}, len(list))
+	for a, acc := range list {
+		res[a] = acc
+	}
+	return res, nil
+}
+
+// loadContractsBatch is the batch function of the contracts loader.
+func loadContractsBatch(addr []common.Address) (map[common.Address]interface{}, map[common.Address]error) {
+	list, err := repository.R().ContractsByAddress(addr)
+	if err != nil {
+		// the batch failed as a whole; load the contracts one by one to isolate the failed ones
+		return loadEach(addr, func(a *common.Address) (interface{}, error) {
+			sc, err := repository.R().Contract(a)
+			if err != nil || sc == nil {
+				return nil, err
+			}
+			return sc, nil
+		})
+	}
+
+	res := make(map[common.Address]interface{}, len(list))
+	for a, sc := range list {
+		res[a] = sc
+	}
+	return res, nil
+}
+
+// loadEach loads the given addresses one by one and collects the values
+// and the errors of the individual addresses.
+// Addresses resolved to nil are left out of the values.
+func loadEach(addr []common.Address, load func(*common.Address) (interface{}, error)) (map[common.Address]interface{}, map[common.Address]error) {
+	res := make(map[common.Address]interface{}, len(addr))
+	errs := make(map[common.Address]error)
+	for i := range addr {
+		val, err := load(&addr[i])
+		if err != nil {
+			errs[addr[i]] = err
+			continue
+		}
+		if val != nil {
+			res[addr[i]] = val
+		}
+	}
+	return res, errs
+}
+
+// loadAccount loads the account of the given address using the request data loader,
+// if available, or directly from the repository.
+func loadAccount(ctx context.Context, addr *common.Address) (*types.Account, error) {
+	ld := loadersFrom(ctx)
+	if ld == nil {
+		return repository.R().Account(addr)
+	}
+
+	val, err := ld.accounts.Load(*addr)
+	if err != nil || val == nil {
+		return nil, err
+	}
+	return val.(*types.Account), nil
+}
+
+// loadContract loads the smart contract of the given address using the request data loader,
+// if available, or directly from the repository.
+func loadContract(ctx context.Context, addr *common.Address) (*types.Contract, error) {
+	ld := loadersFrom(ctx)
+	if ld == nil {
+		return repository.R().Contract(addr)
+	}
+
+	val, err := ld.contracts.Load(*addr)
+	if err != nil || val == nil {
+		return nil, err
+	}
+	return val.(*types.Contract), nil
+}
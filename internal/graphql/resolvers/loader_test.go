@@ -0,0 +1,111 @@
+package resolvers
+
+import (
+	"errors"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/onsi/gomega"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestAddressLoaderBatch tests that concurrent lookups of repeated addresses
+// are collapsed into a single batch call.
+func TestAddressLoaderBatch(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	var calls int32
+	var keys []common.Address
+	ld := newAddressLoader(func(addr []common.Address) (map[common.Address]interface{}, map[common.Address]error) {
+		atomic.AddInt32(&calls, 1)
+		keys = addr
+
+		res := make(map[common.Address]interface{}, len(addr))
+		for _, a := range addr {
+			res[a] = a.Hex()
+		}
+		return res, nil
+	}, nil, 10*time.Millisecond, 100)
+
+	list := []common.Address{
+		common.HexToAddress("0x01"),
+		common.HexToAddress("0x02"),
+		common.HexToAddress("0x01"),
+		common.HexToAddress("0x03"),
+		common.HexToAddress("0x02"),
+		common.HexToAddress("0x01"),
+	}
+
+	var wg sync.WaitGroup
+	out := make([]interface{}, len(list))
+	for i := range list {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			val, err := ld.Load(list[i])
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+			out[i] = val
+		}(i)
+	}
+	wg.Wait()
+
+	g.Expect(atomic.LoadInt32(&calls)).To(gomega.Equal(int32(1)))
+	g.Expect(keys).To(gomega.HaveLen(3))
+	for i := range list {
+		g.Expect(out[i]).To(gomega.Equal(list[i].Hex()))
+	}
+
+	// cached values are served without another batch call
+	val, err := ld.Load(list[0])
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(val).To(gomega.Equal(list[0].Hex()))
+	g.Expect(atomic.LoadInt32(&calls)).To(gomega.Equal(int32(1)))
+}
+
+// TestAddressLoaderErrors tests that errors of a batch are reported only for the failed
+// and the missing keys, and only the failed keys are retried.
+func TestAddressLoaderErrors(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	found := common.HexToAddress("0x01")
+	failed := common.HexToAddress("0x02")
+	missing := common.HexToAddress("0x03")
+	errFailed, errNotFound := errors.New("failed"), errors.New("not found")
+
+	var calls int32
+	ld := newAddressLoader(func(addr []common.Address) (map[common.Address]interface{}, map[common.Address]error) {
+		atomic.AddInt32(&calls, 1)
+		return map[common.Address]interface{}{found: found.Hex()}, map[common.Address]error{failed: errFailed}
+	}, errNotFound, 10*time.Millisecond, 100)
+
+	var wg sync.WaitGroup
+	out := make(map[common.Address]error)
+	var mu sync.Mutex
+	for _, a := range []common.Address{found, failed, missing} {
+		wg.Add(1)
+		go func(a common.Address) {
+			defer wg.Done()
+			_, err := ld.Load(a)
+			mu.Lock()
+			out[a] = err
+			mu.Unlock()
+		}(a)
+	}
+	wg.Wait()
+
+	g.Expect(atomic.LoadInt32(&calls)).To(gomega.Equal(int32(1)))
+	g.Expect(out[found]).To(gomega.BeNil())
+	g.Expect(out[failed]).To(gomega.Equal(errFailed))
+	g.Expect(out[missing]).To(gomega.Equal(errNotFound))
+
+	// the resolved key is cached, the failed one is loaded again
+	val, err := ld.Load(found)
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(val).To(gomega.Equal(found.Hex()))
+	g.Expect(atomic.LoadInt32(&calls)).To(gomega.Equal(int32(1)))
+
+	_, err = ld.Load(failed)
+	g.Expect(err).To(gomega.Equal(errFailed))
+	g.Expect(atomic.LoadInt32(&calls)).To(gomega.Equal(int32(2)))
+}
@@ -0,0 +1,19 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"fantom-api-graphql/internal/repository"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Name resolves the name of the given address using the reverse resolution
+// of the configured name service. Returns nil if the address does not have a name.
+func (rs *rootResolver) Name(args struct{ Address common.Address }) (*string, error) {
+	return repository.R().Name(&args.Address)
+}
+
+// ResolveName resolves the address of the given name using the configured name service.
+// Returns nil if the name does not resolve to an address.
+func (rs *rootResolver) ResolveName(args struct{ Name string }) (*common.Address, error) {
+	return repository.R().ResolveName(args.Name)
+}
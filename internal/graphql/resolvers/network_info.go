@@ -0,0 +1,126 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// knownNetworks maps well-known chain IDs to their network names.
+var knownNetworks = map[uint64]string{
+	250:  "mainnet",
+	4002: "testnet",
+}
+
+// NetworkInfo represents resolvable metadata of the connected node and its network.
+type NetworkInfo struct {
+	types.NetworkInfo
+}
+
+// NetworkContracts represents resolvable well-known contracts configured on the API server.
+type NetworkContracts struct{}
+
+// NetworkInfo resolves the metadata of the connected node and its network.
+func (rs *rootResolver) NetworkInfo(ctx context.Context) (*NetworkInfo, error) {
+	ni, err := repository.R().NetworkInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &NetworkInfo{*ni}, nil
+}
+
+// Network resolves the name of the network; the configured network profile
+// takes precedence over the name derived from the chain ID.
+func (ni *NetworkInfo) Network() *string {
+	if cfg.Network != "" {
+		return &cfg.Network
+	}
+	if name, ok := knownNetworks[ni.ChainID.ToInt().Uint64()]; ok {
+		return &name
+	}
+	return nil
+}
+
+// Contracts resolves the well-known contracts of the network.
+func (ni *NetworkInfo) Contracts() NetworkContracts {
+	return NetworkContracts{}
+}
+
+// optionalAddress provides the address, or nil for the empty address of a contract not configured.
+func optionalAddress(addr common.Address) *common.Address {
+	if addr == (common.Address{}) {
+		return nil
+	}
+	return &addr
+}
+
+// Sfc resolves the address of the SFC contract.
+func (NetworkContracts) Sfc() common.Address {
+	return cfg.Staking.SFCContract
+}
+
+// NodeDriver resolves the address of the node driver contract.
+func (NetworkContracts) NodeDriver() *common.Address {
+	return optionalAddress(cfg.Staking.NodeDriverContract)
+}
+
+// NetworkInitializer resolves the address of the network initializer contract.
+func (NetworkContracts) NetworkInitializer() *common.Address {
+	return optionalAddress(cfg.Staking.NetworkInitializerContract)
+}
+
+// Tokenizer resolves the address of the stake tokenizer contract.
+func (NetworkContracts) Tokenizer() *common.Address {
+	return optionalAddress(cfg.Staking.TokenizerContract)
+}
+
+// StakeToken resolves the address of the tokenized stake token.
+func (NetworkContracts) StakeToken() *common.Address {
+	return optionalAddress(cfg.Staking.TokenizedStakeToken)
+}
+
+// FMintAddressProvider resolves the address of the fMint address provider contract.
+func (NetworkContracts) FMintAddressProvider() *common.Address {
+	return optionalAddress(cfg.DeFi.FMint.AddressProvider)
+}
+
+// UniswapCore resolves the address of the Uniswap core factory contract.
+func (NetworkContracts) UniswapCore() *common.Address {
+	return optionalAddress(cfg.DeFi.Uniswap.Core)
+}
+
+// UniswapRouter resolves the address of the Uniswap router contract.
+func (NetworkContracts) UniswapRouter() *common.Address {
+	return optionalAddress(cfg.DeFi.Uniswap.Router)
+}
+
+// QuoteToken resolves the address of the default token prices are derived against.
+func (NetworkContracts) QuoteToken() *common.Address {
+	return optionalAddress(cfg.DeFi.Uniswap.QuoteToken)
+}
+
+// FLendLendingPool resolves the address of the fLend lending pool contract.
+func (NetworkContracts) FLendLendingPool() *common.Address {
+	return optionalAddress(cfg.DeFi.FLend.LendingPool)
+}
+
+// NameServiceRegistry resolves the address of the name service registry contract.
+func (NetworkContracts) NameServiceRegistry() *common.Address {
+	return optionalAddress(cfg.NameService.Registry)
+}
+
+// Governance resolves the addresses of the governance contracts.
+func (NetworkContracts) Governance() []common.Address {
+	list := make([]common.Address, len(cfg.Governance.Contracts))
+	for i, gc := range cfg.Governance.Contracts {
+		list[i] = gc.Address
+	}
+	return list
+}
+
+// Multicall resolves the address of the Multicall contract aggregating contract calls.
+func (NetworkContracts) Multicall() *common.Address {
+	return optionalAddress(cfg.Repository.Multicall)
+}
@@ -0,0 +1,43 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// NonceStatus represents resolvable state of the transaction nonces of a sender account.
+type NonceStatus struct {
+	types.NonceStatus
+}
+
+// NonceStatus resolves the state of the transaction nonces of the given sender account.
+func (rs *rootResolver) NonceStatus(args struct{ Address common.Address }) (*NonceStatus, error) {
+	ns, err := repository.R().AccountNonceStatus(&args.Address)
+	if err != nil {
+		return nil, err
+	}
+	return &NonceStatus{*ns}, nil
+}
+
+// HighestMinedNonce resolves the highest nonce of a stored mined transaction of the account.
+func (ns *NonceStatus) HighestMinedNonce() *hexutil.Uint64 {
+	return ns.HighestMined
+}
+
+// PendingNonces resolves the nonces of pending transactions of the account.
+func (ns *NonceStatus) PendingNonces() []hexutil.Uint64 {
+	return ns.Pending
+}
+
+// UnindexedNonces resolves the recent mined nonces without a stored transaction.
+func (ns *NonceStatus) UnindexedNonces() []hexutil.Uint64 {
+	return ns.Unindexed
+}
+
+// IsStuck resolves the flag of pending transactions blocked by missing nonces.
+func (ns *NonceStatus) IsStuck() bool {
+	return len(ns.NonceStatus.Gaps) > 0
+}
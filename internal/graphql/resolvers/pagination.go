@@ -0,0 +1,69 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+)
+
+// errInvalidPageSize is returned if a list page with no, or a negative number of, edges is requested.
+var errInvalidPageSize = fmt.Errorf("count must be a positive number of list edges")
+
+// PageSizeReport collects the page size applied to the list pages
+// requested over their limit during a single request.
+type PageSizeReport struct {
+	clamped uint32
+}
+
+// ctxKeyPageSize is the context key of the page size report.
+type ctxKeyPageSize struct{}
+
+// WithPageSizeReport attaches a new page size report to the given context.
+func WithPageSizeReport(ctx context.Context) (context.Context, *PageSizeReport) {
+	rep := new(PageSizeReport)
+	return context.WithValue(ctx, ctxKeyPageSize{}, rep), rep
+}
+
+// Clamped provides the page size applied to the clamped list pages, zero if no page was clamped.
+// If more lists were clamped, the smallest applied size is reported.
+func (rep *PageSizeReport) Clamped() uint32 {
+	return atomic.LoadUint32(&rep.clamped)
+}
+
+// add records the page size applied to a clamped list page.
+func (rep *PageSizeReport) add(size uint32) {
+	for {
+		old := atomic.LoadUint32(&rep.clamped)
+		if old != 0 && old <= size {
+			return
+		}
+		if atomic.CompareAndSwapUint32(&rep.clamped, old, size) {
+			return
+		}
+	}
+}
+
+// listPageSize validates the requested size of a list page and clamps it
+// to the given default limit of the list, or to the configured maximal page size
+// if it is lower. The clamp is reported to the client via the page size report
+// of the request context, if any.
+func listPageSize(ctx context.Context, count int32, limit uint32) (int32, error) {
+	if count <= 0 {
+		return 0, errInvalidPageSize
+	}
+
+	// the configured max page size applies to all the lists
+	if cfg != nil && cfg.Server.MaxPageSize > 0 && cfg.Server.MaxPageSize < limit {
+		limit = cfg.Server.MaxPageSize
+	}
+
+	size := listLimitCount(count, limit)
+	if size != count {
+		log.Debugf("list page size %d clamped to %d", count, size)
+		if rep, ok := ctx.Value(ctxKeyPageSize{}).(*PageSizeReport); ok {
+			rep.add(uint32(size))
+		}
+	}
+	return size, nil
+}
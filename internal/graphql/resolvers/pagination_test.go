@@ -0,0 +1,50 @@
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/config"
+	"fantom-api-graphql/internal/logger"
+	"github.com/onsi/gomega"
+	"testing"
+)
+
+// TestListPageSize tests the list page size is clamped to the lower of the list limit
+// and the configured max page size, and the applied size is reported.
+func TestListPageSize(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	cfg = &config.Config{Log: config.Log{Level: "CRITICAL", Format: "%{message}"}}
+	cfg.Server.MaxPageSize = 50
+	log = logger.New(cfg)
+	defer func() { cfg, log = nil, nil }()
+
+	tests := []struct {
+		name    string
+		count   int32
+		limit   uint32
+		size    int32
+		clamped uint32
+	}{
+		{name: "page inside the limits", count: 10, limit: 100, size: 10},
+		{name: "page over the max page size", count: 80, limit: 100, size: 50, clamped: 50},
+		{name: "page over the list limit", count: 40, limit: 25, size: 25, clamped: 25},
+	}
+
+	for _, tc := range tests {
+		ctx, rep := WithPageSizeReport(context.Background())
+		size, err := listPageSize(ctx, tc.count, tc.limit)
+		g.Expect(err).NotTo(gomega.HaveOccurred(), tc.name)
+		g.Expect(size).To(gomega.Equal(tc.size), tc.name)
+		g.Expect(rep.Clamped()).To(gomega.Equal(tc.clamped), tc.name)
+	}
+
+	// the smallest applied size is reported for more clamped lists
+	ctx, rep := WithPageSizeReport(context.Background())
+	_, _ = listPageSize(ctx, 80, 100)
+	_, _ = listPageSize(ctx, 40, 25)
+	_, _ = listPageSize(ctx, 80, 100)
+	g.Expect(rep.Clamped()).To(gomega.Equal(uint32(25)))
+
+	_, err := listPageSize(context.Background(), -10, 25)
+	g.Expect(err).To(gomega.Equal(errInvalidPageSize))
+}
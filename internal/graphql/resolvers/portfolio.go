@@ -0,0 +1,57 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// AccountPortfolio represents resolvable valued assets of an account.
+type AccountPortfolio struct {
+	types.Portfolio
+}
+
+// PortfolioAsset represents resolvable valued asset of an account portfolio.
+type PortfolioAsset struct {
+	types.PortfolioAsset
+}
+
+// AccountPortfolio resolves native and ERC20 token balances of the account valued in the quote token.
+func (rs *rootResolver) AccountPortfolio(args *struct {
+	Address common.Address
+	Quote   *common.Address
+}) (*AccountPortfolio, error) {
+	pf, err := repository.R().AccountPortfolio(&args.Address, args.Quote)
+	if err != nil {
+		return nil, err
+	}
+	return &AccountPortfolio{Portfolio: *pf}, nil
+}
+
+// Native resolves the native token balance of the account.
+func (ap *AccountPortfolio) Native() *PortfolioAsset {
+	return &PortfolioAsset{PortfolioAsset: ap.Portfolio.Native}
+}
+
+// Tokens resolves the list of ERC20 token balances of the account.
+func (ap *AccountPortfolio) Tokens() []*PortfolioAsset {
+	list := make([]*PortfolioAsset, len(ap.Portfolio.Tokens))
+	for i := range ap.Portfolio.Tokens {
+		list[i] = &PortfolioAsset{PortfolioAsset: ap.Portfolio.Tokens[i]}
+	}
+	return list
+}
+
+// Token resolves the ERC20 token of the asset, nil for the native token.
+func (pa *PortfolioAsset) Token() *ERC20Token {
+	if pa.TokenAddress == nil {
+		return nil
+	}
+	return NewErc20Token(pa.TokenAddress)
+}
+
+// IsPriced signals if the price of the asset is known.
+func (pa *PortfolioAsset) IsPriced() bool {
+	return pa.Price != nil
+}
@@ -0,0 +1,147 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fantom-api-graphql/internal/auth"
+	"fantom-api-graphql/internal/repository"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ctxKeyCacheBypass is the context key signalling the result cache should be bypassed.
+type ctxKeyCacheBypass struct{}
+
+// resultCacheStat represents hit and miss counters of a cached resolver.
+type resultCacheStat struct {
+	hits   uint64
+	misses uint64
+}
+
+// resultCacheStats holds the counters of cached resolvers by the resolver name.
+var resultCacheStats sync.Map
+
+// ResultCacheStat represents resolvable result cache statistics of a resolver.
+type ResultCacheStat struct {
+	Field  string
+	Ttl    int32
+	Hits   float64
+	Misses float64
+}
+
+// WithCacheBypass marks the request context to bypass the resolver result cache.
+// Fresh results are loaded and stored in the cache for the following requests.
+func WithCacheBypass(ctx context.Context) context.Context {
+	return context.WithValue(ctx, ctxKeyCacheBypass{}, true)
+}
+
+// IsCacheBypass checks if the request context is marked to bypass the resolver result cache.
+func IsCacheBypass(ctx context.Context) bool {
+	bypass, _ := ctx.Value(ctxKeyCacheBypass{}).(bool)
+	return bypass
+}
+
+// cachedResult loads the result of the opted-in resolver from the result cache,
+// or from the given loader, if the result is not cached. The result is decoded into
+// the out value, which must be a pointer to the type returned by the loader.
+// Resolvers without configured time to live are not cached.
+func cachedResult(ctx context.Context, field string, args interface{}, out interface{}, load func() (interface{}, error)) error {
+	ttl := cfg.Server.ResultCache[strings.ToLower(field)]
+	if ttl <= 0 {
+		return loadResult(out, load)
+	}
+
+	key, err := resultCacheKey(field, args)
+	if err != nil {
+		return err
+	}
+
+	// try the cache first, unless bypassed
+	stat := resultCacheStatOf(field)
+	if !IsCacheBypass(ctx) {
+		if data := repository.R().CachedResult(key); data != nil {
+			if err := json.Unmarshal(data, out); err == nil {
+				atomic.AddUint64(&stat.hits, 1)
+				return nil
+			}
+		}
+	}
+	atomic.AddUint64(&stat.misses, 1)
+
+	val, err := load()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(val)
+	if err != nil {
+		return err
+	}
+	repository.R().CacheResult(key, data, time.Duration(ttl)*time.Second)
+	return json.Unmarshal(data, out)
+}
+
+// loadResult loads the result of a resolver not being cached into the out value.
+func loadResult(out interface{}, load func() (interface{}, error)) error {
+	val, err := load()
+	if err != nil {
+		return err
+	}
+	reflect.ValueOf(out).Elem().Set(reflect.ValueOf(val))
+	return nil
+}
+
+// resultCacheKey builds the result cache key from the resolver name and its arguments.
+func resultCacheKey(field string, args interface{}) (string, error) {
+	data, err := json.Marshal(args)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(data)
+	return field + ":" + hex.EncodeToString(sum[:16]), nil
+}
+
+// resultCacheStatOf provides the counters of the given cached resolver.
+func resultCacheStatOf(field string) *resultCacheStat {
+	stat, _ := resultCacheStats.LoadOrStore(field, new(resultCacheStat))
+	return stat.(*resultCacheStat)
+}
+
+// ResultCacheStats resolves hit and miss statistics of the cached resolvers.
+// The query requires the admin scope.
+func (rs *rootResolver) ResultCacheStats(ctx context.Context) ([]ResultCacheStat, error) {
+	if err := auth.Require(ctx, auth.ScopeAdmin); err != nil {
+		return nil, err
+	}
+
+	list := make([]ResultCacheStat, 0)
+	resultCacheStats.Range(func(key, value interface{}) bool {
+		stat := value.(*resultCacheStat)
+		list = append(list, ResultCacheStat{
+			Field:  key.(string),
+			Ttl:    int32(cfg.Server.ResultCache[strings.ToLower(key.(string))]),
+			Hits:   float64(atomic.LoadUint64(&stat.hits)),
+			Misses: float64(atomic.LoadUint64(&stat.misses)),
+		})
+		return true
+	})
+
+	sort.Slice(list, func(i, j int) bool { return list[i].Field < list[j].Field })
+	return list, nil
+}
+
+// HitRatio resolves the ratio of requests served from the result cache.
+func (st ResultCacheStat) HitRatio() float64 {
+	if st.Hits+st.Misses == 0 {
+		return 0
+	}
+	return st.Hits / (st.Hits + st.Misses)
+}
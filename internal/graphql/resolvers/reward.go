@@ -1,6 +1,7 @@
 package resolvers
 
 import (
+	"fantom-api-graphql/internal/repository"
 	"fantom-api-graphql/internal/types"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -35,3 +36,12 @@ func (rwc RewardClaim) IsRestaked() bool {
 func (rwc RewardClaim) TrxHash() common.Hash {
 	return rwc.ClaimTrx
 }
+
+// Transaction resolves the transaction calling for the rewards to be processed.
+func (rwc RewardClaim) Transaction() (*Transaction, error) {
+	tx, err := repository.R().Transaction(&rwc.ClaimTrx, false)
+	if err != nil {
+		return nil, err
+	}
+	return NewTransaction(tx), nil
+}
@@ -0,0 +1,32 @@
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"math/big"
+)
+
+// RewardClaims resolves list of reward claims filtered by the delegator and/or the validator.
+func (rs *rootResolver) RewardClaims(ctx context.Context, args struct {
+	Delegator *common.Address
+	StakerId  *hexutil.Big
+	Cursor    *Cursor
+	Count     int32
+}) (*RewardClaimList, error) {
+	// limit query size; the count can be either positive or negative
+	// this controls the loading direction
+	count, err := listPageSize(ctx, args.Count, listMaxEdgesPerRequest)
+	if err != nil {
+		return nil, err
+	}
+	args.Count = count
+
+	// pull the list of claims
+	cl, err := repository.R().RewardClaims(args.Delegator, (*big.Int)(args.StakerId), (*string)(args.Cursor), args.Count)
+	if err != nil {
+		return nil, err
+	}
+	return NewRewardClaimList(cl), nil
+}
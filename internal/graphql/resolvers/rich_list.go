@@ -0,0 +1,125 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"golang.org/x/sync/singleflight"
+)
+
+// RichList represents resolvable list of accounts sorted by their native balance.
+type RichList struct {
+	types.RichList
+	cg *singleflight.Group
+}
+
+// RichListEdge represents a single edge of the rich list.
+type RichListEdge struct {
+	types.RichListEntry
+	list *RichList
+}
+
+// RichList resolves a list of the richest accounts.
+func (rs *rootResolver) RichList(ctx context.Context, args *struct {
+	Cursor *Cursor
+	Count  int32
+}) (*RichList, error) {
+	// limit query size; the count can be either positive or negative
+	// this controls the loading direction
+	count, err := listPageSize(ctx, args.Count, listMaxEdgesPerRequest)
+	if err != nil {
+		return nil, err
+	}
+	args.Count = count
+
+	rl, err := repository.R().RichList((*string)(args.Cursor), args.Count)
+	if err != nil {
+		return nil, err
+	}
+	return &RichList{RichList: *rl, cg: new(singleflight.Group)}, nil
+}
+
+// TotalCount resolves the total number of accounts in the rich list.
+func (rl *RichList) TotalCount() hexutil.Uint64 {
+	return hexutil.Uint64(rl.Total)
+}
+
+// PageInfo resolves the current page information for the rich list.
+func (rl *RichList) PageInfo() (*ListPageInfo, error) {
+	// do we have any items?
+	if rl.Collection == nil || len(rl.Collection) == 0 {
+		return NewListPageInfo(nil, nil, false, false)
+	}
+
+	// get the first and last elements
+	first := Cursor(rl.Collection[0].Address.String())
+	last := Cursor(rl.Collection[len(rl.Collection)-1].Address.String())
+	return NewListPageInfo(&first, &last, !rl.IsEnd, !rl.IsStart)
+}
+
+// Edges resolves list of edges of the rich list.
+func (rl *RichList) Edges() []*RichListEdge {
+	edges := make([]*RichListEdge, len(rl.Collection))
+	for i, e := range rl.Collection {
+		edges[i] = &RichListEdge{RichListEntry: *e, list: rl}
+	}
+	return edges
+}
+
+// contracts loads known contracts of the list only once, so the labels
+// of all the edges are resolved in a single batch.
+func (rl *RichList) contracts() (map[common.Address]*types.Contract, error) {
+	sc, err, _ := rl.cg.Do("contracts", func() (interface{}, error) {
+		addr := make([]common.Address, 0, len(rl.Collection))
+		for _, e := range rl.Collection {
+			if e.IsContract {
+				addr = append(addr, e.Address)
+			}
+		}
+		return repository.R().ContractsByAddress(addr)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return sc.(map[common.Address]*types.Contract), nil
+}
+
+// Cursor generates the cursor for the current rich list edge.
+func (rle *RichListEdge) Cursor() Cursor {
+	return Cursor(rle.Address.String())
+}
+
+// Account resolves the account of the rich list edge.
+func (rle *RichListEdge) Account() (*Account, error) {
+	acc, err := repository.R().Account(&rle.Address)
+	if err != nil {
+		return nil, err
+	}
+	return NewAccount(acc), nil
+}
+
+// Updated resolves the time stamp of the last balance update in unix seconds.
+func (rle *RichListEdge) Updated() hexutil.Uint64 {
+	return hexutil.Uint64(rle.RichListEntry.Updated.Unix())
+}
+
+// Label resolves the name of the known contract, if available.
+func (rle *RichListEdge) Label() (*string, error) {
+	if !rle.IsContract {
+		return nil, nil
+	}
+
+	sc, err := rle.list.contracts()
+	if err != nil {
+		return nil, err
+	}
+
+	c, ok := sc[rle.Address]
+	if !ok || c.Name == "" {
+		return nil, nil
+	}
+	return &c.Name, nil
+}
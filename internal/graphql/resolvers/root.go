@@ -10,6 +10,8 @@ import (
 	"fmt"
 	"golang.org/x/sync/singleflight"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
 const (
@@ -41,6 +43,16 @@ type rootResolver struct {
 	unsubscribeOnTrx chan string
 	trxSubscribers   map[string]*subscriptOnTrx
 	onTrxEvents      chan *types.Transaction
+
+	// pending transaction subscriptions management
+	subscribeOnPendingTrx   chan *subscriptOnPendingTrx
+	unsubscribeOnPendingTrx chan string
+	pendingTrxSubscribers   map[string]*subscriptOnPendingTrx
+	onPendingTrxEvents      chan *types.Transaction
+
+	// diagnostics of the resolver
+	started        time.Time
+	activeSubCount int32
 }
 
 // log represents the logger to be used by the repository.
@@ -75,6 +87,7 @@ func New() ApiResolver {
 	rs := rootResolver{
 		// create terminator
 		sigStop: make(chan bool, 1),
+		started: time.Now().UTC(),
 
 		// block events subscription basics
 		subscribeOnBlock:   make(chan *subscriptOnBlock, subscriptionQueueCapacity),
@@ -87,6 +100,12 @@ func New() ApiResolver {
 		unsubscribeOnTrx: make(chan string, subscriptionQueueCapacity),
 		trxSubscribers:   make(map[string]*subscriptOnTrx, subscriptionInitialCapacity),
 		onTrxEvents:      make(chan *types.Transaction, onBlockChannelCapacity),
+
+		// pending transaction events subscription basics
+		subscribeOnPendingTrx:   make(chan *subscriptOnPendingTrx, subscriptionQueueCapacity),
+		unsubscribeOnPendingTrx: make(chan string, subscriptionQueueCapacity),
+		pendingTrxSubscribers:   make(map[string]*subscriptOnPendingTrx, subscriptionInitialCapacity),
+		onPendingTrxEvents:      make(chan *types.Transaction, onTrxChannelCapacity),
 	}
 
 	// pass subscription data source channels to the service manager
@@ -94,6 +113,7 @@ func New() ApiResolver {
 	sm := svc.Manager()
 	sm.SetBlockChannel(rs.onBlockEvents)
 	sm.SetTrxChannel(rs.onTrxEvents)
+	sm.SetPendingTrxChannel(rs.onPendingTrxEvents)
 
 	// handle broadcast and subscriptions in a separate routine
 	rs.wg.Add(1)
@@ -136,18 +156,30 @@ func (rs *rootResolver) run() {
 		case id := <-rs.unsubscribeOnTrx:
 			delete(rs.trxSubscribers, id)
 
+		case id := <-rs.unsubscribeOnPendingTrx:
+			delete(rs.pendingTrxSubscribers, id)
+
 		case sub := <-rs.subscribeOnBlock:
 			rs.addBlockSubscriber(sub)
 
 		case sub := <-rs.subscribeOnTrx:
 			rs.addTrxSubscriber(sub)
 
+		case sub := <-rs.subscribeOnPendingTrx:
+			rs.addPendingTrxSubscriber(sub)
+
 		case evt := <-rs.onBlockEvents:
 			rs.dispatchOnBlock(evt)
 
 		case evt := <-rs.onTrxEvents:
 			rs.dispatchOnTransaction(evt)
+
+		case evt := <-rs.onPendingTrxEvents:
+			rs.dispatchOnPendingTransaction(evt)
 		}
+
+		// subscribers may be added, or dropped on a failed dispatch
+		atomic.StoreInt32(&rs.activeSubCount, int32(len(rs.blockSubscribers)+len(rs.trxSubscribers)+len(rs.pendingTrxSubscribers)))
 	}
 }
 
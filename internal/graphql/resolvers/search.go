@@ -0,0 +1,266 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"strconv"
+	"strings"
+)
+
+const (
+	// searchMaxQueryLength is the max length of the search input accepted.
+	searchMaxQueryLength = 128
+
+	// searchMinPrefixLength is the min number of hex digits
+	// of a partial hash, or address we search by prefix.
+	searchMinPrefixLength = 4
+
+	// searchMinNameLength is the min length of a contract name we search for.
+	searchMinNameLength = 3
+
+	// searchPrefixLimit is the max number of results of a single prefix search.
+	searchPrefixLimit = 10
+)
+
+// searchResult* represent types of search results.
+const (
+	searchResultBlock       = "BLOCK"
+	searchResultTransaction = "TRANSACTION"
+	searchResultAccount     = "ACCOUNT"
+	searchResultContract    = "CONTRACT"
+)
+
+// search* flags identify what the search input can represent.
+const (
+	searchBlockNumber = 1 << iota
+	searchFullHash
+	searchHashPrefix
+	searchFullAddress
+	searchAddressPrefix
+	searchContractName
+)
+
+// searchQuery represents a parsed search input.
+type searchQuery struct {
+	kinds int
+	hex   string
+	block uint64
+	name  string
+}
+
+// SearchResult represents a single typed result of the search.
+type SearchResult struct {
+	Type string
+	blk  *types.Block
+	trx  *types.Transaction
+	acc  *types.Account
+}
+
+// parseSearchQuery detects what the search input may represent. Ambiguous inputs
+// are marked with all the possible meanings, e.g. a short decimal number can be
+// a block number, a hash prefix and a contract name at the same time.
+func parseSearchQuery(q string) (*searchQuery, error) {
+	q = strings.TrimSpace(q)
+	if q == "" {
+		return nil, fmt.Errorf("empty search query")
+	}
+	if len(q) > searchMaxQueryLength {
+		return nil, fmt.Errorf("search query too long")
+	}
+
+	sq := searchQuery{}
+	h, is0x := q, strings.HasPrefix(q, "0x") || strings.HasPrefix(q, "0X")
+	if is0x {
+		h = q[2:]
+	}
+
+	// not a hex input; the only option is a contract name
+	if h == "" || !isHexString(h) {
+		if !is0x || h != "" {
+			sq.setName(q)
+		}
+		return &sq, nil
+	}
+
+	sq.hex = "0x" + h
+	switch {
+	case len(h) == 2*common.HashLength:
+		sq.kinds |= searchFullHash
+	case len(h) == 2*common.AddressLength:
+		sq.kinds |= searchFullAddress | searchHashPrefix
+	case len(h) > 2*common.AddressLength && len(h) < 2*common.HashLength:
+		sq.kinds |= searchHashPrefix
+	case len(h) >= searchMinPrefixLength && len(h) < 2*common.AddressLength:
+		sq.kinds |= searchHashPrefix | searchAddressPrefix
+	}
+
+	// block numbers can be given in hex with the prefix, or in decimal without it
+	base := 10
+	if is0x {
+		base = 16
+	}
+	if num, err := strconv.ParseUint(h, base, 64); err == nil {
+		sq.kinds |= searchBlockNumber
+		sq.block = num
+	}
+
+	// hex digits without the prefix can still be a name
+	if !is0x {
+		sq.setName(q)
+	}
+	return &sq, nil
+}
+
+// setName marks the query as a contract name, if it's long enough.
+func (sq *searchQuery) setName(q string) {
+	if len(q) >= searchMinNameLength && len(q) <= scMaxNameLength {
+		sq.kinds |= searchContractName
+		sq.name = q
+	}
+}
+
+// is checks if the query may represent the given kind of input.
+func (sq *searchQuery) is(kind int) bool {
+	return sq.kinds&kind != 0
+}
+
+// isHexString checks if the string consists of hex digits only.
+func isHexString(s string) bool {
+	for _, c := range s {
+		if !(c >= '0' && c <= '9') && !(c >= 'a' && c <= 'f') && !(c >= 'A' && c <= 'F') {
+			return false
+		}
+	}
+	return true
+}
+
+// Search resolves a partial, or full search input into the list of matching
+// blocks, transactions, accounts and contracts.
+func (rs *rootResolver) Search(args struct{ Query string }) ([]*SearchResult, error) {
+	sq, err := parseSearchQuery(args.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*SearchResult, 0)
+	seen := make(map[string]bool)
+	add := func(key string, sr *SearchResult) {
+		if !seen[key] {
+			seen[key] = true
+			list = append(list, sr)
+		}
+	}
+
+	if sq.is(searchBlockNumber) {
+		num := hexutil.Uint64(sq.block)
+		if blk, err := repository.R().BlockByNumber(&num); err == nil {
+			add(blk.Hash.String(), &SearchResult{Type: searchResultBlock, blk: blk})
+		}
+	}
+
+	if sq.is(searchFullHash) {
+		hash := common.HexToHash(sq.hex)
+		if trx, err := repository.R().Transaction(&hash, false); err == nil && trx.Hash == hash {
+			add(hash.String(), &SearchResult{Type: searchResultTransaction, trx: trx})
+		} else if blk, err := repository.R().BlockByHash(&hash); err == nil {
+			add(hash.String(), &SearchResult{Type: searchResultBlock, blk: blk})
+		}
+	}
+
+	if sq.is(searchFullAddress) {
+		addr := common.HexToAddress(sq.hex)
+		acc, err := repository.R().Account(&addr)
+		if err != nil {
+			return nil, err
+		}
+		add(addr.String(), newAccountSearchResult(acc))
+	}
+
+	if sq.is(searchHashPrefix) {
+		hashes, err := repository.R().SearchTransactions(sq.hex, searchPrefixLimit)
+		if err != nil {
+			return nil, err
+		}
+		for i := range hashes {
+			if trx, err := repository.R().Transaction(&hashes[i], false); err == nil {
+				add(hashes[i].String(), &SearchResult{Type: searchResultTransaction, trx: trx})
+			}
+		}
+	}
+
+	if sq.is(searchAddressPrefix) {
+		accounts, err := repository.R().SearchAccounts(sq.hex, searchPrefixLimit)
+		if err != nil {
+			return nil, err
+		}
+		for _, acc := range accounts {
+			add(acc.Address.String(), newAccountSearchResult(acc))
+		}
+	}
+
+	if sq.is(searchContractName) {
+		addr, err := repository.R().SearchContracts(sq.name, searchPrefixLimit)
+		if err != nil {
+			return nil, err
+		}
+		for i := range addr {
+			add(addr[i].String(), &SearchResult{Type: searchResultContract, acc: &types.Account{Address: addr[i], Type: types.AccountTypeContract}})
+		}
+	}
+	return list, nil
+}
+
+// newAccountSearchResult makes a search result of the given account.
+func newAccountSearchResult(acc *types.Account) *SearchResult {
+	if acc.Type != types.AccountTypeWallet {
+		return &SearchResult{Type: searchResultContract, acc: acc}
+	}
+	return &SearchResult{Type: searchResultAccount, acc: acc}
+}
+
+// Block resolves the block found, if any.
+func (sr *SearchResult) Block() *Block {
+	if sr.blk == nil {
+		return nil
+	}
+	return NewBlock(sr.blk)
+}
+
+// Transaction resolves the transaction found, if any.
+func (sr *SearchResult) Transaction() *Transaction {
+	if sr.trx == nil {
+		return nil
+	}
+	return NewTransaction(sr.trx)
+}
+
+// Account resolves the account found, if any; contracts are accounts too.
+func (sr *SearchResult) Account() (*Account, error) {
+	if sr.acc == nil {
+		return nil, nil
+	}
+
+	// the account may not be complete if found by a partial match
+	acc, err := repository.R().Account(&sr.acc.Address)
+	if err != nil {
+		return nil, err
+	}
+	return NewAccount(acc), nil
+}
+
+// Contract resolves the contract found, if any.
+func (sr *SearchResult) Contract() (*Contract, error) {
+	if sr.Type != searchResultContract {
+		return nil, nil
+	}
+
+	sc, err := repository.R().Contract(&sr.acc.Address)
+	if err != nil || sc == nil {
+		return nil, err
+	}
+	return NewContract(sc), nil
+}
@@ -0,0 +1,61 @@
+package resolvers
+
+import (
+	"github.com/onsi/gomega"
+	"strings"
+	"testing"
+)
+
+// TestParseSearchQuery tests detection of the search input meaning, including ambiguous inputs.
+func TestParseSearchQuery(t *testing.T) {
+	hash := "0x" + strings.Repeat("ab", 32)
+	addr := "0x" + strings.Repeat("Cd", 20)
+
+	tests := []struct {
+		name  string
+		query string
+		kinds int
+		hex   string
+		block uint64
+		text  string
+	}{
+		{"full hash", hash, searchFullHash, hash, 0, ""},
+		{"full address", addr, searchFullAddress | searchHashPrefix, addr, 0, ""},
+		{"hash prefix longer than address", hash[:50], searchHashPrefix, hash[:50], 0, ""},
+		{"short hex prefix is also a block number", "0xabcdef", searchHashPrefix | searchAddressPrefix | searchBlockNumber, "0xabcdef", 11259375, ""},
+		{"too short hex prefix is a block number", "0x1a", searchBlockNumber, "0x1a", 26, ""},
+		{"long hex prefix is not a block number", "0x" + strings.Repeat("10", 9), searchHashPrefix | searchAddressPrefix, "0x" + strings.Repeat("10", 9), 0, ""},
+		{"decimal is a block number, prefix and name", "123456", searchBlockNumber | searchHashPrefix | searchAddressPrefix | searchContractName, "0x123456", 123456, "123456"},
+		{"short decimal is a block number and name", "123", searchBlockNumber | searchContractName, "0x123", 123, "123"},
+		{"hex letters without prefix are also a name", "beef", searchHashPrefix | searchAddressPrefix | searchContractName, "0xbeef", 0, "beef"},
+		{"text is a name", "Uniswap V2", searchContractName, "", 0, "Uniswap V2"},
+		{"surrounding spaces ignored", "  wFTM ", searchContractName, "", 0, "wFTM"},
+		{"short hex text is nothing", "ab", 0, "0xab", 0, ""},
+		{"bare prefix", "0x", 0, "", 0, ""},
+		{"non hex after prefix is a name", "0xSwap", searchContractName, "", 0, "0xSwap"},
+		{"too long hex", "0x" + strings.Repeat("a", 70), 0, "0x" + strings.Repeat("a", 70), 0, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+
+			sq, err := parseSearchQuery(tt.query)
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(sq.kinds).To(gomega.Equal(tt.kinds))
+			g.Expect(sq.hex).To(gomega.Equal(tt.hex))
+			g.Expect(sq.block).To(gomega.Equal(tt.block))
+			g.Expect(sq.name).To(gomega.Equal(tt.text))
+		})
+	}
+}
+
+// TestParseSearchQueryInvalid tests rejection of empty and oversized search inputs.
+func TestParseSearchQueryInvalid(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	for _, q := range []string{"", "   ", strings.Repeat("x", searchMaxQueryLength+1)} {
+		_, err := parseSearchQuery(q)
+		g.Expect(err).NotTo(gomega.BeNil(), q)
+	}
+}
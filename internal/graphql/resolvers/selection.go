@@ -0,0 +1,55 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+)
+
+// ctxKeySelection is the context key of the set of field names selected by the query.
+type ctxKeySelection struct{}
+
+// trxFieldsBySelection maps the fields of the transaction to the optional parts
+// of the stored transaction they need. Fields not listed need the core data only.
+var trxFieldsBySelection = map[string]types.TrxFields{
+	"inputData":    types.TrxFieldInput,
+	"call":         types.TrxFieldInput,
+	"revertReason": types.TrxFieldInput,
+	"revertData":   types.TrxFieldInput,
+	"revertError":  types.TrxFieldInput,
+	"events":       types.TrxFieldLogs,
+}
+
+// WithSelection attaches the set of field names selected anywhere in the query to the context.
+// Resolvers use it to load only the parts of the stored documents the query needs.
+func WithSelection(ctx context.Context, fields map[string]bool) context.Context {
+	return context.WithValue(ctx, ctxKeySelection{}, fields)
+}
+
+// selectionFrom extracts the set of selected field names from the context;
+// nil if the selection is not known.
+func selectionFrom(ctx context.Context) map[string]bool {
+	if ctx == nil {
+		return nil
+	}
+	fields, _ := ctx.Value(ctxKeySelection{}).(map[string]bool)
+	return fields
+}
+
+// trxListFields provides the optional parts of the stored transactions
+// needed by the fields selected in the query. Full transactions are loaded
+// if the selection is not known.
+func trxListFields(ctx context.Context) types.TrxFields {
+	sel := selectionFrom(ctx)
+	if sel == nil {
+		return types.TrxFieldsAll
+	}
+
+	var fields types.TrxFields
+	for name, tf := range trxFieldsBySelection {
+		if sel[name] {
+			fields |= tf
+		}
+	}
+	return fields
+}
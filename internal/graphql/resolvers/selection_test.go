@@ -0,0 +1,32 @@
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+	"github.com/onsi/gomega"
+	"testing"
+)
+
+// TestTrxListFields tests the optional parts of transactions loaded for the selected fields.
+func TestTrxListFields(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	tests := []struct {
+		sel  map[string]bool
+		want types.TrxFields
+	}{
+		{sel: nil, want: types.TrxFieldsAll},
+		{sel: map[string]bool{"hash": true}, want: 0},
+		{sel: map[string]bool{"hash": true, "inputData": true}, want: types.TrxFieldInput},
+		{sel: map[string]bool{"events": true}, want: types.TrxFieldLogs},
+		{sel: map[string]bool{"call": true, "events": true}, want: types.TrxFieldsAll},
+	}
+
+	for _, tc := range tests {
+		ctx := context.Background()
+		if tc.sel != nil {
+			ctx = WithSelection(ctx, tc.sel)
+		}
+		g.Expect(trxListFields(ctx)).To(gomega.Equal(tc.want), "%v", tc.sel)
+	}
+}
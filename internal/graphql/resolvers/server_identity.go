@@ -0,0 +1,31 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// ServerIdentity represents resolvable public identity of the API server
+// used to verify signatures of the API responses.
+type ServerIdentity struct {
+	Address        *common.Address
+	PublicKey      *hexutil.Bytes
+	SignsResponses bool
+}
+
+// ServerIdentity resolves the public identity of the API server.
+func (rs *rootResolver) ServerIdentity() *ServerIdentity {
+	if !cfg.MySignature.HasKey() {
+		return &ServerIdentity{}
+	}
+
+	addr := crypto.PubkeyToAddress(cfg.MySignature.PrivateKey.PublicKey)
+	pk := hexutil.Bytes(crypto.FromECDSAPub(&cfg.MySignature.PrivateKey.PublicKey))
+	return &ServerIdentity{
+		Address:        &addr,
+		PublicKey:      &pk,
+		SignsResponses: cfg.MySignature.SignResponses,
+	}
+}
@@ -1,6 +1,7 @@
 package resolvers
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository"
 	"fantom-api-graphql/internal/types"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -22,13 +23,17 @@ func NewEpochList(el *types.EpochList) *EpochList {
 }
 
 // Epochs resolves a list of epochs for the given cursor and count.
-func (rs *rootResolver) Epochs(args struct {
+func (rs *rootResolver) Epochs(ctx context.Context, args struct {
 	Cursor *Cursor
 	Count  int32
 }) (*EpochList, error) {
 	// limit query size; the count can be either positive or negative
 	// this controls the loading direction
-	args.Count = listLimitCount(args.Count, listMaxEdgesPerRequest)
+	count, err := listPageSize(ctx, args.Count, listMaxEdgesPerRequest)
+	if err != nil {
+		return nil, err
+	}
+	args.Count = count
 
 	// get the transaction hash list from repository
 	epl, err := repository.R().Epochs((*string)(args.Cursor), args.Count)
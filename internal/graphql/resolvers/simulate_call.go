@@ -0,0 +1,199 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"errors"
+	"fantom-api-graphql/internal/auth"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const (
+	// simulateMaxDataLength is the max length of the call data, or the override code in bytes.
+	simulateMaxDataLength = 128 * 1024
+
+	// simulateMaxOverrideAccounts is the max number of accounts in the state override.
+	simulateMaxOverrideAccounts = 32
+
+	// simulateMaxOverrideSlots is the max number of storage slots overridden per account.
+	simulateMaxOverrideSlots = 256
+)
+
+// errSimulateCallDisabled is returned if the simulated calls are disabled on the server.
+var errSimulateCallDisabled = errors.New("simulated calls are disabled on this server")
+
+// StateOverrideInput represents the state changes of a single account of a simulated call.
+type StateOverrideInput struct {
+	Address   common.Address
+	Balance   *hexutil.Big
+	Nonce     *hexutil.Uint64
+	Code      *hexutil.Bytes
+	State     *[]StorageOverrideInput
+	StateDiff *[]StorageOverrideInput
+}
+
+// StorageOverrideInput represents the value of a single storage slot of a simulated call.
+type StorageOverrideInput struct {
+	Slot  common.Hash
+	Value common.Hash
+}
+
+// SimulatedCall represents resolvable result of a simulated contract call.
+type SimulatedCall struct {
+	types.SimulatedCall
+}
+
+// SimulateCall resolves the result of the given contract call executed on the state
+// of the given block, or the latest state, with the state override applied.
+// The call is enabled by the server configuration and requires the read scope.
+func (rs *rootResolver) SimulateCall(ctx context.Context, args *struct {
+	From          *common.Address
+	To            common.Address
+	Data          *hexutil.Bytes
+	Value         *hexutil.Big
+	Block         *hexutil.Uint64
+	BlockTag      *BlockTag
+	StateOverride *[]StateOverrideInput
+}) (*SimulatedCall, error) {
+	if !cfg.Server.AllowSimulateCall {
+		return nil, errSimulateCallDisabled
+	}
+	if err := auth.Require(ctx, auth.ScopeRead); err != nil {
+		return nil, err
+	}
+
+	call := types.SimulationCall{From: args.From, To: args.To, Value: args.Value, Block: args.Block}
+	if args.BlockTag != nil {
+		if args.Block != nil {
+			return nil, fmt.Errorf("block and block tag can not be combined")
+		}
+		call.Tag = blockTagOf(args.BlockTag)
+		if call.Tag.IsNumber() {
+			num, err := hexutil.DecodeUint64(string(call.Tag))
+			if err != nil {
+				return nil, err
+			}
+			call.Block = (*hexutil.Uint64)(&num)
+		}
+	}
+	if args.Data != nil {
+		if len(*args.Data) > simulateMaxDataLength {
+			return nil, fmt.Errorf("call data too long, %d bytes allowed", simulateMaxDataLength)
+		}
+		call.Data = *args.Data
+	}
+
+	if args.StateOverride != nil {
+		so, err := stateOverride(*args.StateOverride)
+		if err != nil {
+			return nil, err
+		}
+		call.Override = so
+	}
+
+	res, err := repository.R().SimulateCall(&call)
+	if err != nil {
+		return nil, err
+	}
+	return &SimulatedCall{*res}, nil
+}
+
+// stateOverride validates the state override input and builds the override set.
+func stateOverride(list []StateOverrideInput) (types.StateOverride, error) {
+	if len(list) > simulateMaxOverrideAccounts {
+		return nil, fmt.Errorf("state override too large, %d accounts allowed", simulateMaxOverrideAccounts)
+	}
+
+	so := make(types.StateOverride, len(list))
+	for _, in := range list {
+		if _, ok := so[in.Address]; ok {
+			return nil, fmt.Errorf("account %s overridden more than once", in.Address.String())
+		}
+		if in.State != nil && in.StateDiff != nil {
+			return nil, fmt.Errorf("account %s can not override both state and state diff", in.Address.String())
+		}
+		if in.Code != nil && len(*in.Code) > simulateMaxDataLength {
+			return nil, fmt.Errorf("override code of %s too long, %d bytes allowed", in.Address.String(), simulateMaxDataLength)
+		}
+
+		acc := types.OverrideAccount{Balance: in.Balance, Nonce: in.Nonce, Code: in.Code}
+		if in.State != nil {
+			st, err := storageOverride(&in.Address, *in.State)
+			if err != nil {
+				return nil, err
+			}
+			acc.State = &st
+		}
+		if in.StateDiff != nil {
+			st, err := storageOverride(&in.Address, *in.StateDiff)
+			if err != nil {
+				return nil, err
+			}
+			acc.StateDiff = &st
+		}
+		so[in.Address] = acc
+	}
+	return so, nil
+}
+
+// storageOverride builds the storage slots override of the given account.
+func storageOverride(addr *common.Address, list []StorageOverrideInput) (map[common.Hash]common.Hash, error) {
+	if len(list) > simulateMaxOverrideSlots {
+		return nil, fmt.Errorf("storage override of %s too large, %d slots allowed", addr.String(), simulateMaxOverrideSlots)
+	}
+
+	st := make(map[common.Hash]common.Hash, len(list))
+	for _, s := range list {
+		st[s.Slot] = s.Value
+	}
+	return st, nil
+}
+
+// Success resolves the flag of the call finished without revert.
+func (sc *SimulatedCall) Success() bool {
+	return !sc.Reverted
+}
+
+// ReturnData resolves the raw data returned by the call.
+func (sc *SimulatedCall) ReturnData() hexutil.Bytes {
+	if sc.SimulatedCall.ReturnData == nil {
+		return hexutil.Bytes{}
+	}
+	return sc.SimulatedCall.ReturnData
+}
+
+// RevertReason resolves the human readable reason of the reverted call.
+func (sc *SimulatedCall) RevertReason() *string {
+	if !sc.Reverted || sc.SimulatedCall.RevertReason == "" {
+		return nil
+	}
+	return &sc.SimulatedCall.RevertReason
+}
+
+// RevertError resolves the custom error the call reverted with, if decoded.
+func (sc *SimulatedCall) RevertError() *RevertCustomError {
+	if sc.SimulatedCall.Error == nil {
+		return nil
+	}
+	return &RevertCustomError{CustomError: *sc.SimulatedCall.Error}
+}
+
+// Call resolves the called method decoded using the contract ABI, if known.
+func (sc *SimulatedCall) Call() *DecodedCall {
+	if sc.SimulatedCall.Call == nil {
+		return nil
+	}
+	return &DecodedCall{DecodedCall: *sc.SimulatedCall.Call}
+}
+
+// Outputs resolves the list of decoded values returned by the call, if known.
+func (sc *SimulatedCall) Outputs() *[]types.DecodedCallArg {
+	if sc.SimulatedCall.Outputs == nil {
+		return nil
+	}
+	return &sc.SimulatedCall.Outputs
+}
@@ -2,6 +2,7 @@
 package resolvers
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository"
 	"fantom-api-graphql/internal/types"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -39,13 +40,17 @@ func NewStaker(st *types.Validator) *Staker {
 }
 
 // Delegations resolves list of delegations associated with the staker.
-func (st Staker) Delegations(args struct {
+func (st Staker) Delegations(ctx context.Context, args struct {
 	Cursor *Cursor
 	Count  int32
 }) (*DelegationList, error) {
 	// limit query size; the count can be either positive or negative
 	// this controls the loading direction
-	args.Count = listLimitCount(args.Count, accMaxTransactionsPerRequest)
+	count, err := listPageSize(ctx, args.Count, accMaxTransactionsPerRequest)
+	if err != nil {
+		return nil, err
+	}
+	args.Count = count
 
 	// get delegations
 	dl, err := repository.R().DelegationsOfValidator(&st.Id, (*string)(args.Cursor), args.Count)
@@ -267,3 +272,41 @@ func (st Staker) downtime() (uint64, uint64, error) {
 	}
 	return val.(dt).Time, val.(dt).Blocks, err
 }
+
+// Commission resolves the commission rate taken by the validator from the rewards of its delegators.
+func (st Staker) Commission() (*types.ValidatorCommission, error) {
+	return repository.R().ValidatorCommission()
+}
+
+// SelfStakeRatio resolves the ratio between the self staked amount and the total stake of the validator.
+func (st Staker) SelfStakeRatio() (float64, error) {
+	// any total stake?
+	if st.TotalStake == nil || st.TotalStake.ToInt().Sign() <= 0 {
+		return 0, nil
+	}
+
+	// get the amount of self staked tokens
+	sf, err := st.Stake()
+	if err != nil {
+		return 0, err
+	}
+
+	ratio, _ := new(big.Float).Quo(new(big.Float).SetInt(sf.ToInt()), new(big.Float).SetInt(st.TotalStake.ToInt())).Float64()
+	return ratio, nil
+}
+
+// IsSelfStakeSufficient signals if the validator self stake meets the minimal self stake required by SFC.
+func (st Staker) IsSelfStakeSufficient() (bool, error) {
+	// get the amount of self staked tokens
+	sf, err := st.Stake()
+	if err != nil {
+		return false, err
+	}
+
+	// get the SFC config with the minimal self stake
+	sc, err := repository.R().SfcConfiguration()
+	if err != nil {
+		return false, err
+	}
+	return sf.ToInt().Cmp(sc.MinValidatorStake.ToInt()) >= 0, nil
+}
@@ -0,0 +1,35 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// StakingApr represents resolvable estimate of the annual staking yield of a validator.
+type StakingApr struct {
+	types.StakingApr
+}
+
+// StakingApr resolves the estimate of the annual yield of the stake delegated to the given validator.
+func (rs *rootResolver) StakingApr(args *struct{ StakerId hexutil.Big }) (*StakingApr, error) {
+	apr, err := repository.R().StakingApr(&args.StakerId)
+	if err != nil {
+		return nil, err
+	}
+	return &StakingApr{StakingApr: *apr}, nil
+}
+
+// StakerId resolves the id of the validator the estimate belongs to.
+func (apr *StakingApr) StakerId() hexutil.Big {
+	return apr.ValidatorID
+}
+
+// Reason resolves the reason the network average is used, if so.
+func (apr *StakingApr) Reason() *string {
+	if apr.StakingApr.Reason == "" {
+		return nil
+	}
+	return &apr.StakingApr.Reason
+}
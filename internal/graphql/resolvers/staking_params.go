@@ -0,0 +1,21 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+)
+
+// StakingParams represents resolvable staking protocol parameters of the SFC contract.
+type StakingParams struct {
+	types.StakingParams
+}
+
+// StakingParams resolves the staking protocol parameters currently enforced by the SFC contract.
+func (rs *rootResolver) StakingParams() (*StakingParams, error) {
+	sp, err := repository.R().StakingParams()
+	if err != nil {
+		return nil, err
+	}
+	return &StakingParams{StakingParams: *sp}, nil
+}
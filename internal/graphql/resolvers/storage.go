@@ -0,0 +1,39 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"errors"
+	"fantom-api-graphql/internal/auth"
+	"fantom-api-graphql/internal/repository"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// errStorageAtDisabled is returned if the raw storage access is disabled on the server.
+var errStorageAtDisabled = errors.New("contract storage access is disabled on this server")
+
+// errInvalidStorageSlot is returned if the storage slot does not fit into 32 bytes.
+var errInvalidStorageSlot = errors.New("invalid storage slot, the slot must fit into 32 bytes")
+
+// StorageAt resolves the raw value of a storage slot of the given contract
+// at the given block, or at the latest state if the block is not specified.
+// The access is enabled by the server configuration and requires the read scope.
+func (rs *rootResolver) StorageAt(ctx context.Context, args *struct {
+	Address common.Address
+	Slot    hexutil.Big
+	Block   *hexutil.Uint64
+}) (common.Hash, error) {
+	if !cfg.Server.AllowStorageAt {
+		return common.Hash{}, errStorageAtDisabled
+	}
+	if err := auth.Require(ctx, auth.ScopeRead); err != nil {
+		return common.Hash{}, err
+	}
+
+	slot := args.Slot.ToInt()
+	if slot.Sign() < 0 || slot.BitLen() > 8*common.HashLength {
+		return common.Hash{}, errInvalidStorageSlot
+	}
+	return repository.R().StorageAt(&args.Address, common.BigToHash(slot), args.Block)
+}
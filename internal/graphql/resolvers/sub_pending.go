@@ -0,0 +1,88 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"time"
+)
+
+// subscriptOnPendingTrx represents reference to a subscriber to onPendingTransaction events broadcast.
+type subscriptOnPendingTrx struct {
+	stop    <-chan struct{}
+	events  chan<- *Transaction
+	address *common.Address
+}
+
+// OnPendingTransaction resolves subscription to new pending transactions' event broadcast.
+// If the address is given, only transactions sent from, or to the address are broadcast.
+func (rs *rootResolver) OnPendingTransaction(ctx context.Context, args struct{ Address *common.Address }) <-chan *Transaction {
+	// make the stream
+	c := make(chan *Transaction, onTrxChannelCapacity)
+
+	// no pending pool, no events
+	if !cfg.Opera.PendingPool {
+		close(c)
+		return c
+	}
+
+	// subscribe to event dispatch
+	rs.subscribeOnPendingTrx <- &subscriptOnPendingTrx{
+		stop:    ctx.Done(),
+		events:  c,
+		address: args.Address,
+	}
+	return c
+}
+
+// addPendingTrxSubscriber adds a new subscription to onPendingTransaction events.
+func (rs *rootResolver) addPendingTrxSubscriber(sub *subscriptOnPendingTrx) {
+	id, err := uuid()
+	if err == nil {
+		// add the subscriber to the map
+		rs.pendingTrxSubscribers[id] = sub
+	} else {
+		// log critical issue
+		log.Critical("can not generate UUID for new onPendingTransaction subscriber")
+		log.Critical(err)
+	}
+}
+
+// dispatchOnPendingTransaction dispatches onPendingTransaction event to registered subscribers.
+func (rs *rootResolver) dispatchOnPendingTransaction(trx *types.Transaction) {
+	transaction := NewTransaction(trx)
+
+	// broadcast the event in separate go routines so we don't block here
+	for id, sub := range rs.pendingTrxSubscribers {
+		if sub.address != nil && trx.From != *sub.address && (trx.To == nil || *trx.To != *sub.address) {
+			continue
+		}
+		go rs.notifyOnPendingTransaction(transaction, sub, id)
+	}
+}
+
+// notifyOnPendingTransaction broadcasts onPendingTransaction event to given subscriber.
+func (rs *rootResolver) notifyOnPendingTransaction(trx *Transaction, sub *subscriptOnPendingTrx, id string) {
+	// check if the context isn't already closed in which case we just unsub and leave
+	select {
+	case <-sub.stop:
+		rs.unsubscribeOnPendingTrx <- id
+		return
+	default:
+	}
+
+	// broadcast
+	select {
+	case <-sub.stop:
+		// just unsub on broken context
+		rs.unsubscribeOnPendingTrx <- id
+
+	case sub.events <- trx:
+		// push the transaction to subscriber
+
+	case <-time.After(time.Second):
+		// timeout reached without response? just remove the subscriber
+		rs.unsubscribeOnPendingTrx <- id
+	}
+}
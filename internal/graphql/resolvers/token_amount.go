@@ -0,0 +1,59 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"fantom-api-graphql/internal/repository"
+	"github.com/ethereum/go-ethereum/common"
+	"math/big"
+	"strings"
+)
+
+// tokenDefaultDecimals is the number of decimals assumed for tokens not providing the value.
+const tokenDefaultDecimals = 18
+
+// FormattedTokenAmount represents a token amount scaled by the decimals of the token.
+type FormattedTokenAmount struct {
+	Value           string
+	Decimals        int32
+	DecimalsAssumed bool
+}
+
+// newFormattedTokenAmount formats the raw amount of the given ERC20 token
+// using the decimals from the token metadata.
+func newFormattedTokenAmount(token *common.Address, amount *big.Int) (*FormattedTokenAmount, error) {
+	tk, err := repository.R().Erc20Token(token)
+	if err != nil {
+		log.Errorf("can not format amount of token %s; %s", token.String(), err.Error())
+		return nil, err
+	}
+
+	fa := FormattedTokenAmount{Decimals: tk.Decimals, DecimalsAssumed: tk.DecimalsUnknown}
+	if fa.DecimalsAssumed {
+		fa.Decimals = tokenDefaultDecimals
+	}
+	fa.Value = formatTokenAmount(amount, fa.Decimals)
+	return &fa, nil
+}
+
+// formatTokenAmount formats the raw integer amount as a decimal number with the given
+// number of decimals. Trailing zeros of the fraction part are dropped.
+func formatTokenAmount(amount *big.Int, decimals int32) string {
+	s := new(big.Int).Abs(amount).String()
+	if decimals > 0 {
+		dec := int(decimals)
+		if len(s) <= dec {
+			s = strings.Repeat("0", dec-len(s)+1) + s
+		}
+
+		frac := strings.TrimRight(s[len(s)-dec:], "0")
+		s = s[:len(s)-dec]
+		if frac != "" {
+			s = s + "." + frac
+		}
+	}
+
+	if amount.Sign() < 0 {
+		return "-" + s
+	}
+	return s
+}
@@ -0,0 +1,35 @@
+package resolvers
+
+import (
+	"github.com/onsi/gomega"
+	"math/big"
+	"testing"
+)
+
+// TestFormatTokenAmount tests formatting of raw token amounts with different decimals.
+func TestFormatTokenAmount(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   string
+		decimals int32
+		want     string
+	}{
+		{"zero decimals", "12345", 0, "12345"},
+		{"whole units", "3000000000000000000", 18, "3"},
+		{"fraction", "1500000000000000000", 18, "1.5"},
+		{"below one unit", "1", 18, "0.000000000000000001"},
+		{"zero amount", "0", 6, "0"},
+		{"above 18 decimals", "1234000000000000000000000", 24, "1.234"},
+		{"negative", "-25", 1, "-2.5"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+
+			amount, ok := new(big.Int).SetString(tt.amount, 10)
+			g.Expect(ok).To(gomega.BeTrue())
+			g.Expect(formatTokenAmount(amount, tt.decimals)).To(gomega.Equal(tt.want))
+		})
+	}
+}
@@ -0,0 +1,108 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"golang.org/x/sync/singleflight"
+)
+
+// TokenApproval represents resolvable outstanding ERC20 allowance.
+type TokenApproval struct {
+	types.TokenTransaction
+	spenders func() (map[common.Address]*types.Contract, error)
+}
+
+// TokenApprovals resolves the outstanding ERC20 allowances granted by the given owner.
+func (rs *rootResolver) TokenApprovals(args *struct {
+	Owner common.Address
+	Token *common.Address
+}) ([]*TokenApproval, error) {
+	list, err := repository.R().TokenApprovals(&args.Owner, args.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	// the spender contracts are loaded only once for all the approvals
+	var cg singleflight.Group
+	spenders := func() (map[common.Address]*types.Contract, error) {
+		sc, err, _ := cg.Do("spenders", func() (interface{}, error) {
+			addr := make([]common.Address, len(list))
+			for i, ap := range list {
+				addr[i] = ap.Recipient
+			}
+			return repository.R().ContractsByAddress(addr)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return sc.(map[common.Address]*types.Contract), nil
+	}
+
+	res := make([]*TokenApproval, len(list))
+	for i, ap := range list {
+		res[i] = &TokenApproval{TokenTransaction: *ap, spenders: spenders}
+	}
+	return res, nil
+}
+
+// Token resolves the ERC20 token detail.
+func (ta *TokenApproval) Token() *ERC20Token {
+	return NewErc20Token(&ta.TokenAddress)
+}
+
+// Owner resolves the address of the token owner granting the allowance.
+func (ta *TokenApproval) Owner() common.Address {
+	return ta.Sender
+}
+
+// Spender resolves the address allowed to spend the tokens.
+func (ta *TokenApproval) Spender() common.Address {
+	return ta.Recipient
+}
+
+// SpenderLabel resolves the name of the spender contract, if known.
+func (ta *TokenApproval) SpenderLabel() (*string, error) {
+	sc, err := ta.spenders()
+	if err != nil {
+		return nil, err
+	}
+
+	c, ok := sc[ta.Recipient]
+	if !ok || c.Name == "" {
+		return nil, nil
+	}
+	return &c.Name, nil
+}
+
+// FormattedAmount resolves the allowance scaled by the token decimals.
+func (ta *TokenApproval) FormattedAmount() (*FormattedTokenAmount, error) {
+	return newFormattedTokenAmount(&ta.TokenAddress, ta.Amount.ToInt())
+}
+
+// IsUnlimited resolves the flag of an infinite approval set to the max uint256 value.
+func (ta *TokenApproval) IsUnlimited() bool {
+	return ta.Amount.ToInt().Cmp(abi.MaxUint256) == 0
+}
+
+// TrxHash resolves the hash of the transaction setting the allowance.
+func (ta *TokenApproval) TrxHash() common.Hash {
+	return ta.TokenTransaction.Transaction
+}
+
+// Transaction resolves the transaction setting the allowance.
+func (ta *TokenApproval) Transaction() (*Transaction, error) {
+	tx, err := repository.R().Transaction(&ta.TokenTransaction.Transaction, false)
+	if err != nil {
+		return nil, err
+	}
+	return NewTransaction(tx), nil
+}
+
+// TimeStamp resolves the Unix epoch time stamp of the approval.
+func (ta *TokenApproval) TimeStamp() hexutil.Uint64 {
+	return ta.TokenTransaction.TimeStamp
+}
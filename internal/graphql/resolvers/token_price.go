@@ -0,0 +1,68 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/graph-gophers/graphql-go"
+	"time"
+)
+
+// TokenPriceHistory represents resolvable price history of a token.
+type TokenPriceHistory struct {
+	types.TokenPriceHistory
+}
+
+// TokenPriceTick represents resolvable price of a token aggregated over a single interval.
+type TokenPriceTick types.TokenPriceTick
+
+// TokenPriceHistory resolves the price history of the token sampled from Uniswap pair reserves.
+func (rs *rootResolver) TokenPriceHistory(args struct {
+	Token    common.Address
+	From     time.Time
+	To       *time.Time
+	Interval *int32
+	Pair     *common.Address
+}) (*TokenPriceHistory, error) {
+	// make sure to set the end time
+	if args.To == nil {
+		now := time.Now()
+		args.To = &now
+	}
+
+	var interval time.Duration
+	if args.Interval != nil {
+		interval = time.Duration(*args.Interval) * time.Second
+	}
+
+	th, err := repository.R().TokenPriceHistory(&args.Token, args.Pair, args.From, *args.To, interval)
+	if err != nil {
+		return nil, err
+	}
+	return &TokenPriceHistory{TokenPriceHistory: *th}, nil
+}
+
+// Interval resolves the length of a single tick of the history in seconds.
+func (th *TokenPriceHistory) Interval() int32 {
+	return int32(th.TokenPriceHistory.Interval / time.Second)
+}
+
+// Ticks resolves the list of price ticks of the history.
+func (th *TokenPriceHistory) Ticks() []*TokenPriceTick {
+	list := make([]*TokenPriceTick, len(th.TokenPriceHistory.Ticks))
+	for i := range th.TokenPriceHistory.Ticks {
+		list[i] = (*TokenPriceTick)(&th.TokenPriceHistory.Ticks[i])
+	}
+	return list
+}
+
+// FromTime resolves the starting time of the tick.
+func (tt *TokenPriceTick) FromTime() graphql.Time {
+	return graphql.Time{Time: tt.From}
+}
+
+// ToTime resolves the ending time of the tick.
+func (tt *TokenPriceTick) ToTime() graphql.Time {
+	return graphql.Time{Time: tt.To}
+}
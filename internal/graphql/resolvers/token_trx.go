@@ -57,3 +57,12 @@ func (ttx *TokenTransaction) TokenSymbol() (sym string, err error) {
 	}
 	return
 }
+
+// FormattedAmount resolves the amount of tokens involved scaled by the token decimals;
+// nil for tokens other than ERC20.
+func (ttx *TokenTransaction) FormattedAmount() (*FormattedTokenAmount, error) {
+	if ttx.TokenTransaction.TokenType != types.AccountTypeERC20Token {
+		return nil, nil
+	}
+	return newFormattedTokenAmount(&ttx.TokenAddress, ttx.Amount.ToInt())
+}
@@ -0,0 +1,150 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"time"
+)
+
+// tokenRankingPeriods maps the token ranking periods to their length.
+var tokenRankingPeriods = map[string]time.Duration{
+	"HOUR":  time.Hour,
+	"DAY":   24 * time.Hour,
+	"WEEK":  7 * 24 * time.Hour,
+	"MONTH": 30 * 24 * time.Hour,
+}
+
+// TopTokenList represents resolvable page of the ERC20 token ranking.
+type TopTokenList struct {
+	types.TokenRanking
+	offset  int
+	total   int
+	isStart bool
+	isEnd   bool
+}
+
+// TopTokenEdge represents a single token of the ranking.
+type TopTokenEdge struct {
+	types.TokenTransferStats
+	rank int32
+}
+
+// TopTokens resolves ERC20 tokens ranked by their transfers over the given period.
+func (rs *rootResolver) TopTokens(ctx context.Context, args *struct {
+	Period string
+	Metric string
+	Cursor *Cursor
+	Count  int32
+}) (*TopTokenList, error) {
+	count, err := listPageSize(ctx, args.Count, listMaxEdgesPerRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	period, ok := tokenRankingPeriods[args.Period]
+	if !ok {
+		return nil, fmt.Errorf("unknown ranking period %s", args.Period)
+	}
+
+	tr, err := repository.R().TokenRanking(period, args.Metric)
+	if err != nil {
+		return nil, err
+	}
+	return newTopTokenList(tr, (*string)(args.Cursor), count), nil
+}
+
+// newTopTokenList cuts the page of the ranking next to the token of the cursor
+// in the direction given by the sign of the count. The page starts at the top,
+// or at the bottom, of the ranking if the cursor token is not ranked anymore.
+func newTopTokenList(tr *types.TokenRanking, cursor *string, count int32) *TopTokenList {
+	at := -1
+	if cursor != nil {
+		for i := range tr.Entries {
+			if tr.Entries[i].Token.String() == *cursor {
+				at = i
+				break
+			}
+		}
+	}
+
+	var start, end int
+	if count > 0 {
+		start = at + 1
+		end = start + int(count)
+		if end > len(tr.Entries) {
+			end = len(tr.Entries)
+		}
+	} else {
+		end = at
+		if at < 0 {
+			end = len(tr.Entries)
+		}
+		start = end + int(count)
+		if start < 0 {
+			start = 0
+		}
+	}
+
+	page := *tr
+	page.Entries = tr.Entries[start:end]
+	return &TopTokenList{TokenRanking: page, offset: start, total: len(tr.Entries), isStart: start == 0, isEnd: end == len(tr.Entries)}
+}
+
+// TotalCount resolves the number of tokens in the ranking.
+func (ttl *TopTokenList) TotalCount() hexutil.Uint64 {
+	return hexutil.Uint64(ttl.total)
+}
+
+// Since resolves the unix timestamp of the beginning of the ranked period.
+func (ttl *TopTokenList) Since() hexutil.Uint64 {
+	return hexutil.Uint64(ttl.TokenRanking.Since.Unix())
+}
+
+// PageInfo resolves the current page information for the token ranking.
+func (ttl *TopTokenList) PageInfo() (*ListPageInfo, error) {
+	if len(ttl.Entries) == 0 {
+		return NewListPageInfo(nil, nil, false, false)
+	}
+
+	first := Cursor(ttl.Entries[0].Token.String())
+	last := Cursor(ttl.Entries[len(ttl.Entries)-1].Token.String())
+	return NewListPageInfo(&first, &last, !ttl.isEnd, !ttl.isStart)
+}
+
+// Edges resolves list of edges of the token ranking page.
+func (ttl *TopTokenList) Edges() []*TopTokenEdge {
+	edges := make([]*TopTokenEdge, len(ttl.Entries))
+	for i := range ttl.Entries {
+		edges[i] = &TopTokenEdge{TokenTransferStats: ttl.Entries[i], rank: int32(ttl.offset + i + 1)}
+	}
+	return edges
+}
+
+// Cursor generates the cursor of the token ranking edge.
+func (tte *TopTokenEdge) Cursor() Cursor {
+	return Cursor(tte.TokenTransferStats.Token.String())
+}
+
+// Rank resolves the position of the token in the ranking.
+func (tte *TopTokenEdge) Rank() int32 {
+	return tte.rank
+}
+
+// Token resolves the ranked ERC20 token.
+func (tte *TopTokenEdge) Token() *ERC20Token {
+	return NewErc20Token(&tte.TokenTransferStats.Token)
+}
+
+// Transfers resolves the number of transfers of the token in the period.
+func (tte *TopTokenEdge) Transfers() hexutil.Uint64 {
+	return hexutil.Uint64(tte.TokenTransferStats.Transfers)
+}
+
+// Holders resolves the number of unique recipients of the token in the period.
+func (tte *TopTokenEdge) Holders() hexutil.Uint64 {
+	return hexutil.Uint64(tte.TokenTransferStats.Holders)
+}
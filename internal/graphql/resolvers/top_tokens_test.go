@@ -0,0 +1,51 @@
+package resolvers
+
+import (
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/onsi/gomega"
+	"math/big"
+	"testing"
+)
+
+// TestTopTokenListPage tests cutting pages of the token ranking by the cursor.
+func TestTopTokenListPage(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	tr := &types.TokenRanking{Entries: make([]types.TokenTransferStats, 5)}
+	for i := range tr.Entries {
+		tr.Entries[i].Token = common.BigToAddress(big.NewInt(int64(i + 1)))
+	}
+	cursor := func(i int) *string {
+		c := tr.Entries[i].Token.String()
+		return &c
+	}
+	unknown := common.HexToAddress("0xdead").String()
+
+	tests := []struct {
+		name    string
+		cursor  *string
+		count   int32
+		offset  int
+		size    int
+		isStart bool
+		isEnd   bool
+	}{
+		{"top", nil, 2, 0, 2, true, false},
+		{"next", cursor(1), 2, 2, 2, false, false},
+		{"last", cursor(3), 2, 4, 1, false, true},
+		{"previous", cursor(3), -2, 1, 2, false, false},
+		{"previous at top", cursor(1), -2, 0, 1, true, false},
+		{"bottom", nil, -2, 3, 2, false, true},
+		{"unknown cursor", &unknown, 10, 0, 5, true, true},
+		{"unknown cursor backwards", &unknown, -10, 0, 5, true, true},
+	}
+	for _, tt := range tests {
+		page := newTopTokenList(tr, tt.cursor, tt.count)
+		g.Expect(page.offset).To(gomega.Equal(tt.offset), tt.name)
+		g.Expect(page.Entries).To(gomega.HaveLen(tt.size), tt.name)
+		g.Expect(page.isStart).To(gomega.Equal(tt.isStart), tt.name)
+		g.Expect(page.isEnd).To(gomega.Equal(tt.isEnd), tt.name)
+		g.Expect(page.TotalCount()).To(gomega.BeEquivalentTo(5), tt.name)
+	}
+}
@@ -2,6 +2,8 @@
 package resolvers
 
 import (
+	"context"
+	"errors"
 	"fantom-api-graphql/internal/repository"
 	"fantom-api-graphql/internal/types"
 	"github.com/ethereum/go-ethereum/common"
@@ -23,6 +25,15 @@ func NewTransaction(trx *types.Transaction) *Transaction {
 	}
 }
 
+// maxIdempotencyKeyLength is the max length of the transaction submission idempotency key.
+const maxIdempotencyKeyLength = 128
+
+// errSendTransactionDisabled is returned if the transaction submission is disabled on the server.
+var errSendTransactionDisabled = errors.New("transaction submission is disabled on this server")
+
+// errInvalidIdempotencyKey is returned if the transaction submission idempotency key is empty, or too long.
+var errInvalidIdempotencyKey = errors.New("invalid idempotency key")
+
 // Transaction resolves blockchain transaction by transaction hash.
 func (rs *rootResolver) Transaction(args *struct{ Hash common.Hash }) (*Transaction, error) {
 	// get the transaction from repository
@@ -37,6 +48,10 @@ func (rs *rootResolver) Transaction(args *struct{ Hash common.Hash }) (*Transact
 
 // SendTransaction sends raw signed and RLP encoded transaction to the blockchain.
 func (rs *rootResolver) SendTransaction(args *struct{ Tx hexutil.Bytes }) (*Transaction, error) {
+	if !cfg.Server.AllowSendTransaction {
+		return nil, errSendTransactionDisabled
+	}
+
 	// get the transaction from repository
 	trx, err := repository.R().SendTransaction(args.Tx)
 	if err != nil {
@@ -47,10 +62,38 @@ func (rs *rootResolver) SendTransaction(args *struct{ Tx hexutil.Bytes }) (*Tran
 	return NewTransaction(trx), nil
 }
 
+// SendRawTransaction sends raw signed and RLP encoded transaction to the blockchain
+// and resolves the hash of the transaction.
+// Repeated submissions with the same idempotency key resolve to the original transaction hash.
+func (rs *rootResolver) SendRawTransaction(args *struct {
+	Data           hexutil.Bytes
+	IdempotencyKey *string
+}) (common.Hash, error) {
+	if !cfg.Server.AllowSendTransaction {
+		return common.Hash{}, errSendTransactionDisabled
+	}
+
+	var hash *common.Hash
+	var err error
+	if args.IdempotencyKey != nil {
+		if len(*args.IdempotencyKey) == 0 || len(*args.IdempotencyKey) > maxIdempotencyKeyLength {
+			return common.Hash{}, errInvalidIdempotencyKey
+		}
+		hash, err = repository.R().SendIdempotentTransaction(args.Data, *args.IdempotencyKey)
+	} else {
+		hash, err = repository.R().SendRawTransaction(args.Data)
+	}
+	if err != nil {
+		log.Warningf("can not send raw transaction; %s", err.Error())
+		return common.Hash{}, err
+	}
+	return *hash, nil
+}
+
 // Sender resolves sender's account of the transaction.
-func (trx *Transaction) Sender() (*Account, error) {
+func (trx *Transaction) Sender(ctx context.Context) (*Account, error) {
 	// get the sender by address
-	acc, err := repository.R().Account(&trx.From)
+	acc, err := loadAccount(ctx, &trx.From)
 	if err != nil {
 		return nil, err
 	}
@@ -59,14 +102,14 @@ func (trx *Transaction) Sender() (*Account, error) {
 }
 
 // Recipient resolves recipient's account of the transaction.
-func (trx *Transaction) Recipient() (*Account, error) {
+func (trx *Transaction) Recipient(ctx context.Context) (*Account, error) {
 	// no recipient available
 	if trx.To == nil {
 		return nil, nil
 	}
 
 	// get the recipient by address
-	acc, err := repository.R().Account(trx.To)
+	acc, err := loadAccount(ctx, trx.To)
 	if err != nil {
 		return nil, err
 	}
@@ -90,6 +133,35 @@ func (trx *Transaction) Block() (*Block, error) {
 	return NewBlock(blk), nil
 }
 
+// Confirmations resolves the number of blocks on top of the transaction block including the block itself,
+// zero if the transaction is pending.
+func (trx *Transaction) Confirmations() (hexutil.Uint64, error) {
+	if trx.BlockNumber == nil {
+		return 0, nil
+	}
+
+	bh, err := repository.R().BlockHeight()
+	if err != nil {
+		return 0, err
+	}
+
+	head := bh.ToInt().Uint64()
+	if head < uint64(*trx.BlockNumber) {
+		return 0, nil
+	}
+	return hexutil.Uint64(head - uint64(*trx.BlockNumber) + 1), nil
+}
+
+// IsFinal resolves the flag of a transaction with more confirmations than configured
+// as the minimal depth; unconfirmed transactions may still be re-scanned on chain reorganization.
+func (trx *Transaction) IsFinal() (bool, error) {
+	c, err := trx.Confirmations()
+	if err != nil {
+		return false, err
+	}
+	return c > 0 && uint64(c) > cfg.Repository.Confirmations, nil
+}
+
 // tokenTransactions loads list of all token transaction related to this transaction call.
 func (trx *Transaction) tokenTransactions() ([]*types.TokenTransaction, error) {
 	// call for it only once
@@ -173,3 +245,18 @@ func (trx *Transaction) Erc1155Transactions() ([]*ERC1155Transaction, error) {
 	}
 	return list, nil
 }
+
+// Events resolves the list of log records emitted by the transaction in the log order,
+// each decoded using the ABI of its emitting contract, if known.
+func (trx *Transaction) Events() ([]*EventLog, error) {
+	logs, err := repository.R().TransactionEvents(&trx.Transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*EventLog, len(logs))
+	for i, lg := range logs {
+		list[i] = &EventLog{EventLog: lg}
+	}
+	return list, nil
+}
@@ -0,0 +1,105 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// decodeInputMaxAbiLength is the max length of the caller supplied ABI in bytes.
+const decodeInputMaxAbiLength = 256 * 1024
+
+// DecodedCall represents resolvable contract call decoded from the transaction input.
+type DecodedCall struct {
+	types.DecodedCall
+}
+
+// Call resolves the contract call of the transaction decoded using the contract ABI.
+func (trx *Transaction) Call() (*DecodedCall, error) {
+	val, err, _ := trx.cg.Do("call", func() (interface{}, error) {
+		// the decoding needs the full input of the call
+		src := &trx.Transaction
+		if src.LargeInput && len(src.InputData) == 0 {
+			full, err := repository.R().LoadTransaction(&trx.Hash)
+			if err != nil {
+				return nil, err
+			}
+			src = full
+		}
+		return repository.R().TransactionCall(src)
+	})
+	if err != nil {
+		log.Errorf("can not decode call of %s; %s", trx.Hash.String(), err.Error())
+		return nil, err
+	}
+
+	dc := val.(*types.DecodedCall)
+	if dc == nil {
+		return nil, nil
+	}
+	return &DecodedCall{DecodedCall: *dc}, nil
+}
+
+// DecodeInput resolves the input data of the given transaction decoded
+// using the caller supplied contract ABI.
+func (rs *rootResolver) DecodeInput(args *struct {
+	Hash common.Hash
+	Abi  string
+}) (*DecodedCall, error) {
+	if len(args.Abi) > decodeInputMaxAbiLength {
+		return nil, fmt.Errorf("%w; the ABI exceeds %d bytes", repository.ErrInvalidAbi, decodeInputMaxAbiLength)
+	}
+
+	dc, err := repository.R().DecodeTransactionInput(&args.Hash, args.Abi)
+	if err != nil {
+		log.Debugf("can not decode input of %s; %s", args.Hash.String(), err.Error())
+		return nil, err
+	}
+	return &DecodedCall{DecodedCall: *dc}, nil
+}
+
+// Selector resolves the 4 bytes selector of the called method.
+func (dc *DecodedCall) Selector() hexutil.Bytes {
+	return dc.DecodedCall.Selector
+}
+
+// Name resolves the name of the called method, nil if not known from the contract ABI.
+func (dc *DecodedCall) Name() *string {
+	if dc.DecodedCall.Name == "" {
+		return nil
+	}
+	return &dc.DecodedCall.Name
+}
+
+// Signature resolves the signature of the called method, nil if not known from the contract ABI.
+func (dc *DecodedCall) Signature() *string {
+	if dc.DecodedCall.Signature == "" {
+		return nil
+	}
+	return &dc.DecodedCall.Signature
+}
+
+// DecodedSubCall represents resolvable inner call of a batch contract call.
+type DecodedSubCall struct {
+	types.DecodedSubCall
+}
+
+// SubCalls resolves the list of inner calls of a recognized batch call.
+func (dc *DecodedCall) SubCalls() []DecodedSubCall {
+	list := make([]DecodedSubCall, len(dc.DecodedCall.SubCalls))
+	for i, sc := range dc.DecodedCall.SubCalls {
+		list[i] = DecodedSubCall{DecodedSubCall: sc}
+	}
+	return list
+}
+
+// Call resolves the decoded inner call, nil for plain value transfers.
+func (sc DecodedSubCall) Call() *DecodedCall {
+	if sc.DecodedSubCall.Call == nil {
+		return nil
+	}
+	return &DecodedCall{DecodedCall: *sc.DecodedSubCall.Call}
+}
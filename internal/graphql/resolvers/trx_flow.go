@@ -2,6 +2,7 @@
 package resolvers
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository"
 	"fantom-api-graphql/internal/types"
 	"fmt"
@@ -19,7 +20,19 @@ type DailyTrxVolume struct {
 }
 
 // TrxVolume resolves list of daily aggregations of the network transaction flow.
-func (rs *rootResolver) TrxVolume(args struct {
+func (rs *rootResolver) TrxVolume(ctx context.Context, args struct {
+	From *string
+	To   *string
+}) ([]*DailyTrxVolume, error) {
+	var val []*DailyTrxVolume
+	err := cachedResult(ctx, "trxVolume", args, &val, func() (interface{}, error) {
+		return rs.trxVolume(args)
+	})
+	return val, err
+}
+
+// trxVolume loads list of daily aggregations of the network transaction flow.
+func (rs *rootResolver) trxVolume(args struct {
 	From *string
 	To   *string
 }) ([]*DailyTrxVolume, error) {
@@ -45,7 +58,19 @@ func (rs *rootResolver) TrxVolume(args struct {
 
 // TrxGasSpeed resolves the gas consumption speed speed
 // of the network in transactions processed per second.
-func (rs *rootResolver) TrxGasSpeed(args struct {
+func (rs *rootResolver) TrxGasSpeed(ctx context.Context, args struct {
+	Range int32
+	To    *string
+}) (float64, error) {
+	var val float64
+	err := cachedResult(ctx, "trxGasSpeed", args, &val, func() (interface{}, error) {
+		return rs.trxGasSpeed(args)
+	})
+	return val, err
+}
+
+// trxGasSpeed calculates the gas consumption speed of the network.
+func (rs *rootResolver) trxGasSpeed(args struct {
 	Range int32
 	To    *string
 }) (val float64, err error) {
@@ -72,14 +97,19 @@ func (rs *rootResolver) TrxGasSpeed(args struct {
 }
 
 // TrxSpeed resolves the recent speed of the network in transactions processed per second.
-func (rs *rootResolver) TrxSpeed(args struct {
+func (rs *rootResolver) TrxSpeed(ctx context.Context, args struct {
 	Range int32
 }) (float64, error) {
 	// make sure to obey the minimal range
 	if args.Range < 60 {
 		args.Range = 60
 	}
-	return repository.R().TrxFlowSpeed(args.Range)
+
+	var val float64
+	err := cachedResult(ctx, "trxSpeed", args, &val, func() (interface{}, error) {
+		return repository.R().TrxFlowSpeed(args.Range)
+	})
+	return val, err
 }
 
 // trxVolumeRange generates the time range for trx volume resolver.
@@ -2,8 +2,10 @@
 package resolvers
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository"
 	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"math/big"
 )
@@ -27,16 +29,20 @@ func NewTransactionList(txs *types.TransactionList) *TransactionList {
 }
 
 // Transactions resolves list of blockchain transactions encapsulated in a listable structure.
-func (rs *rootResolver) Transactions(args *struct {
+func (rs *rootResolver) Transactions(ctx context.Context, args *struct {
 	Cursor *Cursor
 	Count  int32
 }) (*TransactionList, error) {
 	// limit query size; the count can be either positive or negative
 	// this controls the loading direction
-	args.Count = listLimitCount(args.Count, listMaxEdgesPerRequest)
+	count, err := listPageSize(ctx, args.Count, listMaxEdgesPerRequest)
+	if err != nil {
+		return nil, err
+	}
+	args.Count = count
 
 	// get the transaction hash list from repository
-	txs, err := repository.R().Transactions((*string)(args.Cursor), args.Count)
+	txs, err := repository.R().Transactions((*string)(args.Cursor), args.Count, trxListFields(ctx))
 	if err != nil {
 		log.Errorf("can not get transactions list; %s", err.Error())
 		return nil, err
@@ -81,3 +87,37 @@ func (tl *TransactionList) Edges() []*TransactionListEdge {
 	}
 	return edges
 }
+
+// BlockTransactions resolves list of transactions of a block identified by number or hash.
+// The transactions are listed in the order of their execution inside the block.
+func (rs *rootResolver) BlockTransactions(ctx context.Context, args *struct {
+	Number *hexutil.Uint64
+	Hash   *common.Hash
+	Cursor *Cursor
+	Count  int32
+}) (*TransactionList, error) {
+	// find the block
+	blk, err := rs.Block(&struct {
+		Number *hexutil.Uint64
+		Hash   *common.Hash
+	}{Number: args.Number, Hash: args.Hash})
+	if err != nil {
+		return nil, err
+	}
+	if blk == nil {
+		return nil, nil
+	}
+
+	// get the transactions page
+	count, err := listPageSize(ctx, args.Count, listMaxEdgesPerRequest)
+	if err != nil {
+		return nil, err
+	}
+	args.Count = count
+	txs, err := repository.R().BlockTransactions(&blk.Block, (*string)(args.Cursor), args.Count)
+	if err != nil {
+		log.Errorf("can not get transactions of block %d; %s", uint64(blk.Number), err.Error())
+		return nil, err
+	}
+	return NewTransactionList(txs), nil
+}
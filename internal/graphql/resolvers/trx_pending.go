@@ -0,0 +1,23 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"fantom-api-graphql/internal/repository"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// PendingTransactions resolves the list of pending transactions observed on the node,
+// which were sent from, or to the given address. All pending transactions are listed
+// if the address is not given.
+func (rs *rootResolver) PendingTransactions(args struct{ Address *common.Address }) ([]*Transaction, error) {
+	list, err := repository.R().PendingTransactions(args.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*Transaction, len(list))
+	for i, trx := range list {
+		res[i] = NewTransaction(trx)
+	}
+	return res, nil
+}
@@ -0,0 +1,103 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// revertReason loads the decoded revert reason of the failed transaction.
+func (trx *Transaction) revertReason() (*types.RevertReason, error) {
+	// call for it only once
+	val, err, _ := trx.cg.Do("revert", func() (interface{}, error) {
+		// the replay needs the full input of the call
+		src := &trx.Transaction
+		if src.LargeInput && len(src.InputData) == 0 {
+			full, err := repository.R().LoadTransaction(&trx.Hash)
+			if err != nil {
+				return nil, err
+			}
+			src = full
+		}
+		return repository.R().TransactionRevertReason(src)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return val.(*types.RevertReason), nil
+}
+
+// RevertReason resolves the human readable reason of the failed transaction.
+func (trx *Transaction) RevertReason() (*string, error) {
+	rr, err := trx.revertReason()
+	if err != nil {
+		log.Errorf("can not decode revert reason of %s; %s", trx.Hash.String(), err.Error())
+		return nil, err
+	}
+	if rr == nil || rr.Reason == "" {
+		return nil, nil
+	}
+	return &rr.Reason, nil
+}
+
+// RevertData resolves the raw data returned by the reverted call of the failed transaction.
+func (trx *Transaction) RevertData() (*hexutil.Bytes, error) {
+	rr, err := trx.revertReason()
+	if err != nil {
+		return nil, err
+	}
+	if rr == nil || len(rr.Data) == 0 {
+		return nil, nil
+	}
+
+	data := hexutil.Bytes(rr.Data)
+	return &data, nil
+}
+
+// RevertCustomError represents resolvable custom error of a failed transaction.
+type RevertCustomError struct {
+	types.CustomError
+}
+
+// RevertError resolves the custom error the failed transaction reverted with, if any.
+func (trx *Transaction) RevertError() (*RevertCustomError, error) {
+	rr, err := trx.revertReason()
+	if err != nil {
+		return nil, err
+	}
+	if rr == nil || rr.Error == nil {
+		return nil, nil
+	}
+	return &RevertCustomError{CustomError: *rr.Error}, nil
+}
+
+// Selector resolves the 4 bytes selector of the custom error.
+func (ce *RevertCustomError) Selector() hexutil.Bytes {
+	return ce.CustomError.Selector
+}
+
+// Name resolves the name of the custom error, nil if not known from the contract ABI.
+func (ce *RevertCustomError) Name() *string {
+	if ce.CustomError.Name == "" {
+		return nil
+	}
+	return &ce.CustomError.Name
+}
+
+// Signature resolves the signature of the custom error, nil if not known from the contract ABI.
+func (ce *RevertCustomError) Signature() *string {
+	if ce.CustomError.Signature == "" {
+		return nil
+	}
+	return &ce.CustomError.Signature
+}
+
+// Args resolves the list of decoded arguments of the custom error.
+func (ce *RevertCustomError) Args() []*types.CustomErrorArg {
+	list := make([]*types.CustomErrorArg, len(ce.CustomError.Args))
+	for i := range ce.CustomError.Args {
+		list[i] = &ce.CustomError.Args[i]
+	}
+	return list
+}
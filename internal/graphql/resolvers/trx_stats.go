@@ -0,0 +1,128 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"math/big"
+	"strings"
+	"time"
+)
+
+const (
+	// trxStatsDefaultRange is the range of days we provide the statistics for by default.
+	trxStatsDefaultRange = 30 * 24 * time.Hour
+
+	// trxStatsMaxDailyRange is the max range of daily statistics.
+	trxStatsMaxDailyRange = 366 * 24 * time.Hour
+
+	// trxStatsMaxHourlyRange is the max range of hourly statistics.
+	trxStatsMaxHourlyRange = 31 * 24 * time.Hour
+)
+
+// trxStatsGasPriceCorrection converts the average gas price in GWei to WEI.
+var trxStatsGasPriceCorrection = new(big.Float).SetInt64(1_000_000_000)
+
+// TrxStats represents resolvable aggregation of the network transaction flow in a time bucket.
+type TrxStats struct {
+	types.TrxStats
+}
+
+// DailyStats resolves the list of aggregated statistics of the network transaction flow.
+func (rs *rootResolver) DailyStats(ctx context.Context, args struct {
+	FromDate    *string
+	ToDate      *string
+	Granularity string
+}) ([]*TrxStats, error) {
+	var val []*TrxStats
+	err := cachedResult(ctx, "dailyStats", args, &val, func() (interface{}, error) {
+		from, to, err := trxStatsRange(args.FromDate, args.ToDate, args.Granularity)
+		if err != nil {
+			return nil, err
+		}
+
+		stats, err := repository.R().TrxStats(ctx, from, to, args.Granularity)
+		if err != nil {
+			return nil, err
+		}
+
+		list := make([]*TrxStats, len(stats))
+		for i, s := range stats {
+			list[i] = &TrxStats{*s}
+		}
+		return list, nil
+	})
+	return val, err
+}
+
+// trxStatsRange validates the requested date range of the statistics and provides
+// the range of time covered; the end of the range is not included.
+func trxStatsRange(fromDate *string, toDate *string, gran string) (time.Time, time.Time, error) {
+	// the end date is inclusive, today by default
+	to := types.TrxStatsBucketStart(time.Now(), types.TrxStatsDay)
+	if toDate != nil {
+		day, err := time.Parse("2006-01-02", *toDate)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		to = day
+	}
+	to = to.Add(24 * time.Hour)
+
+	from := to.Add(-trxStatsDefaultRange)
+	if fromDate != nil {
+		day, err := time.Parse("2006-01-02", *fromDate)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		from = day
+	}
+
+	if !from.Before(to) {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid date range received")
+	}
+
+	limit := trxStatsMaxDailyRange
+	if gran == types.TrxStatsHour {
+		limit = trxStatsMaxHourlyRange
+	}
+	if to.Sub(from) > limit {
+		return time.Time{}, time.Time{}, fmt.Errorf("date range too long, %d days allowed for %s", int(limit.Hours()/24), strings.ToLower(gran))
+	}
+	return from, to, nil
+}
+
+// Bucket resolves the identification of the time bucket.
+func (ts *TrxStats) Bucket() string {
+	return strings.TrimPrefix(ts.ID, ts.Granularity+":")
+}
+
+// Stamp resolves the UNIX time stamp of the start of the bucket.
+func (ts *TrxStats) Stamp() hexutil.Uint64 {
+	return hexutil.Uint64(ts.TrxStats.Stamp.Unix())
+}
+
+// Transactions resolves the number of transactions processed in the bucket.
+func (ts *TrxStats) Transactions() hexutil.Uint64 {
+	return hexutil.Uint64(ts.Counter)
+}
+
+// ActiveAddresses resolves the number of unique addresses active in the bucket.
+func (ts *TrxStats) ActiveAddresses() hexutil.Uint64 {
+	return hexutil.Uint64(ts.Addresses)
+}
+
+// Amount resolves the amount of native tokens transferred in the bucket.
+func (ts *TrxStats) Amount() hexutil.Big {
+	val := new(big.Int).Mul(new(big.Int).SetInt64(ts.AmountAdjusted), types.TransactionDecimalsCorrection)
+	return hexutil.Big(*val)
+}
+
+// AvgGasPrice resolves the average gas price of transactions in the bucket in WEI.
+func (ts *TrxStats) AvgGasPrice() hexutil.Big {
+	val, _ := new(big.Float).Mul(new(big.Float).SetFloat64(ts.AvgGasGWei), trxStatsGasPriceCorrection).Int(nil)
+	return hexutil.Big(*val)
+}
@@ -0,0 +1,31 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"fantom-api-graphql/internal/repository"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// typedDataMaxLength is the max total length of the JSON encoded typed data accepted for verification.
+const typedDataMaxLength = 64 * 1024
+
+// VerifyTypedData resolves the signer of the EIP-712 typed data signature.
+func (rs *rootResolver) VerifyTypedData(args *struct {
+	Domain      string
+	Types       string
+	Message     string
+	PrimaryType *string
+	Signature   hexutil.Bytes
+}) (common.Address, error) {
+	if len(args.Domain)+len(args.Types)+len(args.Message) > typedDataMaxLength {
+		return common.Address{}, fmt.Errorf("%w; typed data too long, max %d bytes allowed", repository.ErrInvalidTypedData, typedDataMaxLength)
+	}
+
+	addr, err := repository.R().VerifyTypedData(args.Domain, args.Types, args.Message, args.PrimaryType, args.Signature)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return *addr, nil
+}
@@ -2,6 +2,7 @@
 package resolvers
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository"
 	"fantom-api-graphql/internal/types"
 	"github.com/ethereum/go-ethereum/common"
@@ -41,7 +42,7 @@ func NewUniswapActionList(ual *types.UniswapActionList) *UniswapActionList {
 }
 
 // DefiUniswapActions resolves list of blockchain uniswap actions encapsulated in a listable structure.
-func (rs *rootResolver) DefiUniswapActions(args *struct {
+func (rs *rootResolver) DefiUniswapActions(ctx context.Context, args *struct {
 	Cursor      *Cursor
 	Count       int32
 	PairAddress *common.Address
@@ -49,14 +50,18 @@ func (rs *rootResolver) DefiUniswapActions(args *struct {
 }) (*UniswapActionList, error) {
 	// limit query size; the count can be either positive or negative
 	// this controls the loading direction
-	args.Count = listLimitCount(args.Count, listMaxEdgesPerRequest)
+	count, err := listPageSize(ctx, args.Count, listMaxEdgesPerRequest)
+	if err != nil {
+		return nil, err
+	}
+	args.Count = count
 	if args.ActionType == nil {
 		var t int32 = -1
 		args.ActionType = &t
 	}
 
 	// get the uniswap action list from repository
-	al, err := repository.R().UniswapActions(args.PairAddress, (*string)(args.Cursor), args.Count, *args.ActionType)
+	al, err := repository.R().UniswapActions(args.PairAddress, nil, (*string)(args.Cursor), args.Count, *args.ActionType)
 	if err != nil {
 		log.Errorf("can not get uniswap action list; %s", err.Error())
 		return nil, err
@@ -64,6 +69,90 @@ func (rs *rootResolver) DefiUniswapActions(args *struct {
 	return NewUniswapActionList(al), nil
 }
 
+// DefiUniswapSwaps resolves list of token swaps made on uniswap pairs.
+func (rs *rootResolver) DefiUniswapSwaps(ctx context.Context, args *struct {
+	PairAddress *common.Address
+	Cursor      *Cursor
+	Count       int32
+}) (*UniswapActionList, error) {
+	count, err := listPageSize(ctx, args.Count, listMaxEdgesPerRequest)
+	if err != nil {
+		return nil, err
+	}
+	args.Count = count
+
+	al, err := repository.R().UniswapActions(args.PairAddress, nil, (*string)(args.Cursor), args.Count, types.SwapTrade)
+	if err != nil {
+		log.Errorf("can not get uniswap swap list; %s", err.Error())
+		return nil, err
+	}
+	return NewUniswapActionList(al), nil
+}
+
+// TokenIn resolves the address of the token sold by the swap, if any.
+func (ua *UniswapAction) TokenIn() (*common.Address, error) {
+	return ua.swapToken(types.SwapDirection0To1)
+}
+
+// TokenOut resolves the address of the token bought by the swap, if any.
+func (ua *UniswapAction) TokenOut() (*common.Address, error) {
+	return ua.swapToken(types.SwapDirection1To0)
+}
+
+// swapToken resolves the pair token on the given side of the swap direction.
+// Token0 is returned if the swap direction matches the given one, Token1 otherwise.
+func (ua *UniswapAction) swapToken(dir int32) (*common.Address, error) {
+	d := ua.Direction()
+	if d == types.SwapDirectionNone {
+		return nil, nil
+	}
+
+	tokens, err := repository.R().UniswapTokens(&ua.UniswapAction.PairAddress)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) != 2 {
+		return nil, nil
+	}
+
+	if d == dir {
+		return &tokens[0], nil
+	}
+	return &tokens[1], nil
+}
+
+// FormattedAmount0in resolves the incoming amount of Token0 scaled by the token decimals.
+func (ua *UniswapAction) FormattedAmount0in() (*FormattedTokenAmount, error) {
+	return ua.formattedAmount(0, ua.Amount0in.ToInt())
+}
+
+// FormattedAmount0out resolves the outgoing amount of Token0 scaled by the token decimals.
+func (ua *UniswapAction) FormattedAmount0out() (*FormattedTokenAmount, error) {
+	return ua.formattedAmount(0, ua.Amount0out.ToInt())
+}
+
+// FormattedAmount1in resolves the incoming amount of Token1 scaled by the token decimals.
+func (ua *UniswapAction) FormattedAmount1in() (*FormattedTokenAmount, error) {
+	return ua.formattedAmount(1, ua.Amount1in.ToInt())
+}
+
+// FormattedAmount1out resolves the outgoing amount of Token1 scaled by the token decimals.
+func (ua *UniswapAction) FormattedAmount1out() (*FormattedTokenAmount, error) {
+	return ua.formattedAmount(1, ua.Amount1out.ToInt())
+}
+
+// formattedAmount formats the amount of the pair token on the given index, nil if the pair tokens are not known.
+func (ua *UniswapAction) formattedAmount(index int, amount *big.Int) (*FormattedTokenAmount, error) {
+	tokens, err := repository.R().UniswapTokens(&ua.UniswapAction.PairAddress)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) != 2 {
+		return nil, nil
+	}
+	return newFormattedTokenAmount(&tokens[index], amount)
+}
+
 // TotalCount resolves the total number of uniswap actions in the list.
 func (cl *UniswapActionList) TotalCount() hexutil.Big {
 	val := (*hexutil.Big)(new(big.Int).SetUint64(cl.Total))
@@ -0,0 +1,44 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"fantom-api-graphql/internal/repository"
+	"github.com/ethereum/go-ethereum/common"
+	"math/big"
+)
+
+// DefiUniswapPair resolves the Uniswap pair of the given tokens.
+// If the pair does not exist, nil is resolved.
+func (rs *rootResolver) DefiUniswapPair(args *struct {
+	TokenA common.Address
+	TokenB common.Address
+}) (*UniswapPair, error) {
+	pair, err := repository.R().UniswapPair(&args.TokenA, &args.TokenB)
+	if err == repository.ErrUniswapPairNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return NewUniswapPair(pair), nil
+}
+
+// Prices resolves the prices of the pair tokens implied by the current reserves.
+// The first price is the price of the first token in units of the second token
+// and the second price is the reverse one. Both respect the tokens decimals.
+func (up *UniswapPair) Prices() ([]float64, error) {
+	_, adjusted, err := repository.R().UniswapAdjustedReserves(&up.PairAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	// no liquidity, no price
+	prices := make([]float64, 2)
+	if len(adjusted) < 2 || adjusted[0].Sign() == 0 || adjusted[1].Sign() == 0 {
+		return prices, nil
+	}
+
+	prices[0], _ = new(big.Float).Quo(adjusted[1], adjusted[0]).Float64()
+	prices[1], _ = new(big.Float).Quo(adjusted[0], adjusted[1]).Float64()
+	return prices, nil
+}
@@ -0,0 +1,34 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// UniswapTokenPrice represents a resolvable token price derived from Uniswap reserves.
+type UniswapTokenPrice struct {
+	types.UniswapTokenPrice
+}
+
+// DefiUniswapTokenPrice resolves the price of the given token derived from Uniswap reserves.
+func (rs *rootResolver) DefiUniswapTokenPrice(args *struct {
+	Token   common.Address
+	Against *common.Address
+}) (*UniswapTokenPrice, error) {
+	pri, err := repository.R().UniswapTokenPrice(&args.Token, args.Against)
+	if err != nil || pri == nil {
+		return nil, err
+	}
+	return &UniswapTokenPrice{UniswapTokenPrice: *pri}, nil
+}
+
+// Route resolves the list of Uniswap pairs used to derive the price.
+func (utp *UniswapTokenPrice) Route() []*UniswapPair {
+	list := make([]*UniswapPair, len(utp.UniswapTokenPrice.Route))
+	for i := range utp.UniswapTokenPrice.Route {
+		list[i] = NewUniswapPair(&utp.UniswapTokenPrice.Route[i])
+	}
+	return list
+}
@@ -2,6 +2,7 @@
 package resolvers
 
 import (
+	"context"
 	"crypto/rand"
 	"fantom-api-graphql/internal/repository"
 	"fantom-api-graphql/internal/types"
@@ -16,16 +17,30 @@ import (
 var reExpectedPriceSymbol = regexp.MustCompile(`^[\w]{2,4}$`)
 
 // Price resolves price details of the Opera blockchain token for the given target symbols.
-func (rs *rootResolver) Price(args *struct{ To string }) (types.Price, error) {
+func (rs *rootResolver) Price(ctx context.Context, args *struct{ To string }) (types.Price, error) {
 	// is the requested denomination even reasonable
 	if !reExpectedPriceSymbol.Match([]byte(args.To)) {
 		return types.Price{}, fmt.Errorf("invalid denomination received")
 	}
-	return repository.R().Price(args.To)
+
+	var val types.Price
+	err := cachedResult(ctx, "price", args, &val, func() (interface{}, error) {
+		return repository.R().Price(args.To)
+	})
+	return val, err
 }
 
 // GasPrice resolves the current amount of WEI for single Gas.
-func (rs *rootResolver) GasPrice() (hexutil.Uint64, error) {
+func (rs *rootResolver) GasPrice(ctx context.Context) (hexutil.Uint64, error) {
+	var val hexutil.Uint64
+	err := cachedResult(ctx, "gasPrice", nil, &val, func() (interface{}, error) {
+		return rs.gasPrice()
+	})
+	return val, err
+}
+
+// gasPrice loads the current amount of WEI for single Gas.
+func (rs *rootResolver) gasPrice() (hexutil.Uint64, error) {
 	// get the actual value
 	price, err := repository.R().GasPrice()
 	if err != nil {
@@ -43,6 +58,11 @@ func (rs *rootResolver) GasPrice() (hexutil.Uint64, error) {
 	return hexutil.Uint64(price.ToInt().Uint64()), nil
 }
 
+// GasPriceTiers resolves the suggested gas price with tiers derived from recent blocks.
+func (rs *rootResolver) GasPriceTiers() (*types.GasPriceTiers, error) {
+	return repository.R().GasPriceTiers()
+}
+
 // EstimateGas resolves the estimated amount of Gas required to perform
 // transaction described by the input params.
 func (rs *rootResolver) EstimateGas(args struct {
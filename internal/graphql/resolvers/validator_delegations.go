@@ -0,0 +1,89 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	// delegationSortCreated sorts delegations by the time of creation.
+	delegationSortCreated = "CREATED"
+
+	// delegationSortAmount sorts delegations by the active delegated amount.
+	delegationSortAmount = "AMOUNT"
+)
+
+// ValidatorDelegationList represents resolvable list of delegations
+// received by a validator with the summary of all the active delegations.
+type ValidatorDelegationList struct {
+	DelegationList
+	valID hexutil.Big
+	cg    *singleflight.Group
+}
+
+// ValidatorDelegations resolves a list of delegations received by a validator.
+func (rs *rootResolver) ValidatorDelegations(ctx context.Context, args *struct {
+	StakerId hexutil.Big
+	Cursor   *Cursor
+	Count    int32
+	SortBy   string
+}) (*ValidatorDelegationList, error) {
+	// limit query size; the count can be either positive or negative
+	// this controls the loading direction
+	count, err := listPageSize(ctx, args.Count, listMaxEdgesPerRequest)
+	if err != nil {
+		return nil, err
+	}
+	args.Count = count
+
+	// get the list in the requested order
+	var dl *types.DelegationList
+	switch args.SortBy {
+	case delegationSortAmount:
+		dl, err = repository.R().DelegationsOfValidatorByAmount(&args.StakerId, (*string)(args.Cursor), args.Count)
+	default:
+		dl, err = repository.R().DelegationsOfValidator(&args.StakerId, (*string)(args.Cursor), args.Count)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &ValidatorDelegationList{
+		DelegationList: DelegationList{*dl},
+		valID:          args.StakerId,
+		cg:             new(singleflight.Group),
+	}, nil
+}
+
+// summary loads the summary of active delegations of the validator only once.
+func (vdl *ValidatorDelegationList) summary() (*types.DelegationSummary, error) {
+	sum, err, _ := vdl.cg.Do("summary", func() (interface{}, error) {
+		return repository.R().DelegationsSummaryOfValidator(&vdl.valID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return sum.(*types.DelegationSummary), nil
+}
+
+// TotalAmount resolves the total amount of active delegations of the validator.
+func (vdl *ValidatorDelegationList) TotalAmount() (hexutil.Big, error) {
+	sum, err := vdl.summary()
+	if err != nil {
+		return hexutil.Big{}, err
+	}
+	return sum.Amount, nil
+}
+
+// DelegatorCount resolves the number of active delegations of the validator.
+func (vdl *ValidatorDelegationList) DelegatorCount() (hexutil.Uint64, error) {
+	sum, err := vdl.summary()
+	if err != nil {
+		return 0, err
+	}
+	return sum.Count, nil
+}
@@ -0,0 +1,134 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/auth"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// webhookDeliveriesMaxCount is the max number of webhook deliveries provided by a single call.
+const webhookDeliveriesMaxCount = 100
+
+// Webhook represents resolvable notification webhook.
+type Webhook struct {
+	types.Webhook
+}
+
+// WebhookDelivery represents resolvable notification of a webhook.
+type WebhookDelivery struct {
+	types.WebhookDelivery
+}
+
+// Webhooks resolves the list of webhooks registered for the given address, or all the webhooks.
+// The query requires the admin scope.
+func (rs *rootResolver) Webhooks(ctx context.Context, args *struct{ Address *common.Address }) ([]*Webhook, error) {
+	if err := auth.Require(ctx, auth.ScopeAdmin); err != nil {
+		return nil, err
+	}
+
+	list, err := repository.R().Webhooks(args.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*Webhook, len(list))
+	for i, wh := range list {
+		res[i] = &Webhook{Webhook: *wh}
+	}
+	return res, nil
+}
+
+// RegisterWebhook registers a new notification webhook for the given address.
+// The mutation requires an API key with the admin scope, the server makes requests
+// to the registered URL on its own.
+func (rs *rootResolver) RegisterWebhook(ctx context.Context, args *struct {
+	Address common.Address
+	Url     string
+}) (*Webhook, error) {
+	if err := auth.RequireKey(ctx, auth.ScopeAdmin); err != nil {
+		return nil, err
+	}
+
+	wh, err := repository.R().RegisterWebhook(&args.Address, args.Url)
+	if err != nil {
+		return nil, err
+	}
+	return &Webhook{Webhook: *wh}, nil
+}
+
+// RemoveWebhook removes the webhook of the given id.
+// The mutation requires the admin scope.
+func (rs *rootResolver) RemoveWebhook(ctx context.Context, args *struct{ Id string }) (bool, error) {
+	if err := auth.Require(ctx, auth.ScopeAdmin); err != nil {
+		return false, err
+	}
+	return repository.R().RemoveWebhook(args.Id)
+}
+
+// Id resolves the identifier of the webhook.
+func (wh *Webhook) Id() string {
+	return wh.ID
+}
+
+// Created resolves the UNIX time stamp of the webhook registration.
+func (wh *Webhook) Created() hexutil.Uint64 {
+	return hexutil.Uint64(wh.Webhook.Created.Unix())
+}
+
+// Deliveries resolves the list of the most recent deliveries of the webhook.
+func (wh *Webhook) Deliveries(args struct{ Count int32 }) ([]*WebhookDelivery, error) {
+	if args.Count <= 0 || args.Count > webhookDeliveriesMaxCount {
+		args.Count = webhookDeliveriesMaxCount
+	}
+
+	list, err := repository.R().WebhookDeliveries(wh.ID, args.Count)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*WebhookDelivery, len(list))
+	for i, wd := range list {
+		res[i] = &WebhookDelivery{WebhookDelivery: *wd}
+	}
+	return res, nil
+}
+
+// Id resolves the identifier of the delivery.
+func (wd *WebhookDelivery) Id() string {
+	return wd.ID
+}
+
+// LastError resolves the error of the last failed delivery attempt.
+func (wd *WebhookDelivery) LastError() *string {
+	if wd.WebhookDelivery.LastError == "" {
+		return nil
+	}
+	return &wd.WebhookDelivery.LastError
+}
+
+// NextAttempt resolves the UNIX time stamp of the next attempt of a pending delivery.
+func (wd *WebhookDelivery) NextAttempt() *hexutil.Uint64 {
+	if wd.Status != types.WebhookDeliveryPending {
+		return nil
+	}
+	ts := hexutil.Uint64(wd.WebhookDelivery.NextAttempt.Unix())
+	return &ts
+}
+
+// Created resolves the UNIX time stamp of the delivery creation.
+func (wd *WebhookDelivery) Created() hexutil.Uint64 {
+	return hexutil.Uint64(wd.WebhookDelivery.Created.Unix())
+}
+
+// Delivered resolves the UNIX time stamp of the successful delivery.
+func (wd *WebhookDelivery) Delivered() *hexutil.Uint64 {
+	if wd.WebhookDelivery.Delivered == nil {
+		return nil
+	}
+	ts := hexutil.Uint64(wd.WebhookDelivery.Delivered.Unix())
+	return &ts
+}
@@ -5,6 +5,7 @@ import (
 	"fantom-api-graphql/internal/repository"
 	"fantom-api-graphql/internal/types"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"time"
 )
 
 // WithdrawRequest represents resolvable partial withdraw request
@@ -71,3 +72,63 @@ func (wr WithdrawRequest) Staker() (*Staker, error) {
 	// return the staker information
 	return NewStaker(st), nil
 }
+
+// UnlockTime resolves the expected time stamp the request funds can be withdrawn at.
+// The SFC validates the withdrawal period in effect at the moment of the withdrawal,
+// so the current SFC configuration is used for the calculation.
+func (wr WithdrawRequest) UnlockTime() (*hexutil.Uint64, error) {
+	sc, err := repository.R().SfcConfiguration()
+	if err != nil {
+		return nil, err
+	}
+
+	ut := wr.CreatedTime + hexutil.Uint64(sc.WithdrawalPeriodTime.ToInt().Uint64())
+	return &ut, nil
+}
+
+// UnlockEpoch resolves the expected epoch the request funds can be withdrawn in.
+func (wr WithdrawRequest) UnlockEpoch() (*hexutil.Uint64, error) {
+	sc, err := repository.R().SfcConfiguration()
+	if err != nil {
+		return nil, err
+	}
+
+	// find the epoch the request has been made in
+	ep, err := repository.R().EpochAtTime(wr.CreatedTime)
+	if err != nil {
+		return nil, err
+	}
+
+	ue := ep + hexutil.Uint64(sc.WithdrawalPeriodEpochs.ToInt().Uint64())
+	return &ue, nil
+}
+
+// IsWithdrawable resolves the flag signaling the pending request
+// passed both time and epoch withdrawal period and can be finalized.
+func (wr WithdrawRequest) IsWithdrawable() (bool, error) {
+	// finalized requests can not be withdrawn again
+	if wr.WithdrawTime != nil {
+		return false, nil
+	}
+
+	// check the time period first, it's the cheaper one
+	ut, err := wr.UnlockTime()
+	if err != nil {
+		return false, err
+	}
+	if uint64(*ut) > uint64(time.Now().UTC().Unix()) {
+		return false, nil
+	}
+
+	// check the epochs period
+	ue, err := wr.UnlockEpoch()
+	if err != nil {
+		return false, err
+	}
+
+	ce, err := repository.R().CurrentEpoch()
+	if err != nil {
+		return false, err
+	}
+	return *ue <= ce, nil
+}
@@ -173,6 +173,10 @@ type ERC20Transaction {
     # with the correct number of decimals from the ERC20 token detail.
     amount: BigInt!
 
+    # formattedAmount represents the amount of tokens involved
+    # in the transaction scaled by the ERC20 token decimals.
+    formattedAmount: FormattedTokenAmount!
+
     # timeStamp represents the Unix epoch time stamp
     # of the ERC20 transaction processing.
     timeStamp: Long!
@@ -374,6 +378,23 @@ type Transaction {
     # If the transaction is pending, this field will be null.
     gasUsed: Long
 
+    # cumulativeGasUsed is the total amount of gas used in the block
+    # when this transaction was executed.
+    # If the transaction is pending, this field will be null.
+    cumulativeGasUsed: Long
+
+    # effectiveGasPrice is the price of gas per unit in WEI actually paid
+    # by the sender. If the transaction is pending, this field will be null.
+    effectiveGasPrice: BigInt
+
+    # maxFeePerGas is the max total fee per gas in WEI the sender is willing
+    # to pay (EIP-1559); null for legacy transactions.
+    maxFeePerGas: BigInt
+
+    # maxPriorityFeePerGas is the max priority fee per gas in WEI the sender
+    # is willing to pay (EIP-1559); null for legacy transactions.
+    maxPriorityFeePerGas: BigInt
+
     # InputData is the data supplied to the target of the transaction.
     # Contains smart contract byte code if this is contract creation.
     # Contains encoded contract state mutating function call if recipient
@@ -392,12 +413,40 @@ type Transaction {
     # the transaction is pending.
     block: Block
 
+    # confirmations is the number of blocks on top of the transaction block,
+    # including the block itself. Zero if the transaction is pending.
+    confirmations: Long!
+
+    # isFinal signals the transaction has more confirmations than the minimal depth
+    # configured on the API server. Transactions not final yet may be corrected
+    # if the chain is reorganized.
+    isFinal: Boolean!
+
     # Status is the return status of the transaction. This will be 1 if the
     # transaction succeeded, or 0 if it failed (due to a revert, or due to
-    # running out of gas). If the transaction has not yet been processed, this
-    # field will be null.
+    # running out of gas). If the transaction has not yet been processed, or the
+    # receipt does not provide the status, this field will be null.
     status: Long
 
+    # revertReason is the human readable reason of a failed transaction.
+    # The reason is decoded on demand by replaying the transaction call;
+    # null for successful transactions, or if the reason can not be recovered.
+    revertReason: String
+
+    # revertData is the raw data returned by the reverted call of a failed transaction;
+    # null for successful transactions, or if the call did not return any data.
+    revertData: Bytes
+
+    # revertError is the custom error a failed transaction reverted with.
+    # The error is decoded using the verified ABI of the contract, if available,
+    # only the raw selector is provided otherwise.
+    revertError: RevertCustomError
+
+    # call is the contract call of the transaction decoded using the verified ABI
+    # of the target contract, if available, only the raw selector is provided otherwise;
+    # null if the transaction is not a contract call.
+    call: DecodedCall
+
     # tokenTransactions represents a list of generic token transactions executed in the scope
     # of the transaction call; token type and transaction type is provided.
     tokenTransactions: [TokenTransaction!]!
@@ -413,6 +462,11 @@ type Transaction {
     # erc1155Transactions provides list of ERC-1155 NFT transactions executed in the scope
     # of this blockchain transaction call.
     erc1155Transactions: [ERC1155Transaction!]!
+
+    # events is the list of log records emitted by the transaction in the log order.
+    # Each record is decoded using the ABI of its emitting contract, if known;
+    # the raw topics and data are always provided. Empty for pending transactions.
+    events: [EventLog!]!
 }
 
 # Block is an Opera block chain block.
@@ -438,12 +492,28 @@ type Block {
     # GasUsed represents the actual total used gas by all transactions in this block.
     gasUsed: Long!
 
+    # baseFeePerGas is the base fee per gas of the block in WEI (EIP-1559);
+    # null for blocks without the base fee.
+    baseFeePerGas: BigInt
+
+    # burntFees is the amount of fees in WEI burnt by the block, the base fee per gas
+    # multiplied by the gas used; null for blocks without the base fee.
+    burntFees: BigInt
+
     # txHashList is the list of unique hash values of transaction
     # assigned to the block.
     txHashList: [Bytes32!]!
 
     # txList is a list of transactions assigned to the block.
     txList: [Transaction!]!
+
+    # epoch is the id of the Lachesis epoch the block belongs to;
+    # null if not provided by the node.
+    epoch: Long
+
+    # epochSeal is the seal of the epoch containing the block; the block is final
+    # as of the sealed epoch. Null if the epoch has not been sealed yet.
+    epochSeal: EpochSeal
 }
 
 # ERC721Contract represents a generic ERC721 non-fungible tokens (NFT) contract.
@@ -533,7 +603,12 @@ type ERC20Token {
     # on the account regardless of the DeFi usage of the token.
     # It's effectively the amount available held by the ERC20 token
     # on the account behalf.
-    balanceOf(owner: Address!): BigInt!
+    # The balance at a specific block is provided for the given block tag.
+    balanceOf(owner: Address!, blockTag: BlockTag): BigInt!
+
+    # formattedBalanceOf represents the available balance of the token
+    # on the account scaled by the token decimals.
+    formattedBalanceOf(owner: Address!, blockTag: BlockTag): FormattedTokenAmount!
 
     # allowance represents the amount of ERC20 tokens unlocked
     # by the owner / token holder to be accessible for the given spender.
@@ -725,11 +800,14 @@ type Contract {
     "Address represents the contract address."
     address: Address!
 
-    "DeployedBy represents the smart contract deployment transaction reference."
-    deployedBy: Transaction!
+    """
+    DeployedBy represents the smart contract deployment transaction reference.
+    Null if the deployment is not known, e.g. for contracts imported manually.
+    """
+    deployedBy: Transaction
 
-    "transactionHash represents the smart contract deployment transaction hash."
-    transactionHash: Bytes32!
+    "transactionHash represents the smart contract deployment transaction hash, null if not known."
+    transactionHash: Bytes32
 
     "Smart contract name. Empty if not available."
     name: String!
@@ -763,6 +841,31 @@ type Contract {
 
     "Timestamp is the unix timestamp at which this smart contract was deployed."
     timestamp: Long!
+
+    """
+    Implementation is the current implementation address of an EIP-1967
+    proxy contract. Null if the contract is not a proxy.
+    """
+    implementation: Address
+
+    """
+    Destroyed signals the contract self-destructed and no longer has code
+    at its address. A contract re-deployed to the same address is live again.
+    """
+    destroyed: Boolean!
+
+    "DestroyedAtBlock is the number of the block the self-destruct was detected at."
+    destroyedAtBlock: Long
+
+    "Type is the general type of the contract, e.g. ERC20, ERC721, ERC1155, or Contract."
+    type: String!
+
+    """
+    Classification is the reason the contract has been assigned its type;
+    "detected" on deployment, "heuristic" by the observed token events,
+    or "manual" if set by an admin.
+    """
+    classification: String!
 }
 
 # ContractValidationInput represents a set of data sent from client
@@ -858,6 +961,12 @@ scalar Cursor
 # Time represents date and time including time zone information in RFC3339 format.
 scalar Time
 
+# BlockTag identifies the block of the state used by a read call. It's either one
+# of the named tags latest, safe, finalized, pending and earliest, or a block number
+# given as a decimal, or a 0x prefixed hexadecimal number. Named tags not supported
+# by the connected node are rejected.
+scalar BlockTag
+
 # CurrentState represents the current active state
 # of the chain information condensed on one place.
 type CurrentState {
@@ -927,11 +1036,24 @@ type UniswapAction {
     sender: Address!
 
     # type represents action type:
-    # 0 - swap
-    # 1 - mint
-    # 2 - burn
+    # 0 - mint
+    # 1 - burn
+    # 2 - sync
+    # 3 - swap
     type: Int!
 
+    # direction represents the trade direction of a swap:
+    # 0 - not a swap
+    # 1 - Token0 traded for Token1
+    # 2 - Token1 traded for Token0
+    direction: Int!
+
+    # tokenIn is the address of the token sold by a swap, NULL for other actions.
+    tokenIn: Address
+
+    # tokenOut is the address of the token bought by a swap, NULL for other actions.
+    tokenOut: Address
+
     # blockNr is number of the block for this action
     blockNr: Long!
 
@@ -949,6 +1071,18 @@ type UniswapAction {
 
     # amount1out is amount of outgoing tokens for Token1 in this action
     amount1out: BigInt!
+
+    # formattedAmount0in is amount0in scaled by the Token0 decimals.
+    formattedAmount0in: FormattedTokenAmount
+
+    # formattedAmount0out is amount0out scaled by the Token0 decimals.
+    formattedAmount0out: FormattedTokenAmount
+
+    # formattedAmount1in is amount1in scaled by the Token1 decimals.
+    formattedAmount1in: FormattedTokenAmount
+
+    # formattedAmount1out is amount1out scaled by the Token1 decimals.
+    formattedAmount1out: FormattedTokenAmount
 }
 
 # Represents staker information.
@@ -1028,6 +1162,18 @@ type Staker {
 
     # ValidatorInfo represents extended validator information.
     validatorInfo: ValidatorInfo
+
+    # commission represents the commission rate taken by the validator
+    # from the rewards of its delegators.
+    commission: ValidatorCommission!
+
+    # selfStakeRatio is the ratio between the self staked amount
+    # and the total stake of the validator.
+    selfStakeRatio: Float!
+
+    # isSelfStakeSufficient signals if the self stake of the validator
+    # meets the minimal self stake required by the SFC contract.
+    isSelfStakeSufficient: Boolean!
 }
 
 # StakerFlagFilter represents a filter type for stakers with the given flag.
@@ -1083,6 +1229,34 @@ type FMintAccount {
     # in ref. denomination (fUSD).
     debtValue: BigInt!
 
+    # collateralRatio4 represents the current ratio between
+    # collateral and debt values in ref. denomination (fUSD).
+    # Value is represented in 4 digits, e.g. value 30000 = 3.0x.
+    # The value is null if the account does not have any debt.
+    collateralRatio4: BigInt
+
+    # minCollateralRatio4 represents the minimal collateral to debt ratio
+    # of the fMint protocol. Accounts below this ratio can be liquidated.
+    minCollateralRatio4: BigInt!
+
+    # rewardCollateralRatio4 represents the minimal collateral to debt ratio
+    # required to be eligible for rewards.
+    rewardCollateralRatio4: BigInt!
+
+    # healthFactor represents the ratio between the current collateral ratio
+    # and the minimal collateral ratio. Values below 1.0 mean the account
+    # can be liquidated. The value is null if the account does not have
+    # any debt and so it's safe regardless of the collateral price.
+    healthFactor: Float
+
+    # isLiquidatable informs if the account collateral ratio dropped
+    # below the minimal collateral ratio.
+    isLiquidatable: Boolean!
+
+    # liquidationPrices represents the list of collateral token prices
+    # on which the account would become subject to liquidation.
+    liquidationPrices: [FMintLiquidationPrice!]!
+
     # rewardsEarned represents accumulated rewards
     # earned on the DeFi / fMint account for the excessive
     # collateral value. Please note that the rewards could still
@@ -1262,6 +1436,18 @@ type WithdrawRequest {
     # WithdrawTime represents the time stamp of the request finalization.
     # If the request is pending, the withdrawTime will be NULL.
     withdrawTime: Long
+
+    # UnlockTime represents the expected time stamp after which
+    # the request can be finalized, based on the current SFC withdrawal period.
+    unlockTime: Long
+
+    # UnlockEpoch represents the expected epoch in which
+    # the request can be finalized, based on the current SFC withdrawal period.
+    unlockEpoch: Long
+
+    # IsWithdrawable signals the pending request passed the withdrawal
+    # period and can be finalized.
+    isWithdrawable: Boolean!
 }
 
 # UniswapPair represents the information about single
@@ -1283,6 +1469,12 @@ type UniswapPair {
     # with the token position.
     reserves: [BigInt!]!
 
+    # prices of the tokens implied by the current reserves,
+    # adjusted by the tokens decimals. The first price is the price
+    # of the first token in units of the second token,
+    # the second price is the reverse one.
+    prices: [Float!]!
+
     # The timestamp of the block
     # in which this reserves state was reached.
     reservesTimeStamp: Long!
@@ -1550,6 +1742,18 @@ type RewardClaim {
     # trxHash is the hash pf the transaction calling for the rewards
     # to be processed and granted.
     trxHash: Bytes32!
+
+    # transaction represents the transaction calling for the rewards
+    # to be processed and granted.
+    transaction: Transaction!
+
+    # fromEpoch is the first epoch covered by the claim. The epoch range
+    # is available only for claims made on the SFC1 contract.
+    fromEpoch: Long
+
+    # untilEpoch is the last epoch covered by the claim. The epoch range
+    # is available only for claims made on the SFC1 contract.
+    untilEpoch: Long
 }
 # Price represents price information of core Opera token
 type Price {
@@ -1732,6 +1936,10 @@ type TokenTransaction {
     # amount of tokens involved in the transaction.
     amount: BigInt!
 
+    # formattedAmount is the amount of tokens involved in the transaction
+    # scaled by the token decimals; available for ERC20 tokens only.
+    formattedAmount: FormattedTokenAmount
+
     # multi-token contracts (ERC-721/ERC-1155) token ID involved in the transaction.
     tokenId: BigInt!
 
@@ -1744,8 +1952,9 @@ type Account {
     # Address is the address of the account.
     address: Address!
 
-    # Balance is the current balance of the Account in WEI.
-    balance: BigInt!
+    # Balance is the balance of the Account in WEI at the given block,
+    # or the current balance if the block tag is not provided.
+    balance(blockTag: BlockTag): BigInt!
 
     # TotalValue is the current total value of the account in WEI.
     # It includes available balance, delegated amount and pending rewards.
@@ -1761,6 +1970,9 @@ type Account {
     # erc20TxList represents list of ERC20 transactions of the account.
     erc20TxList(cursor:Cursor, count:Int = 25, token: Address, txType: String): ERC20TransactionList!
 
+    # uniswapSwaps represents list of Uniswap token swaps made by the account.
+    uniswapSwaps(cursor:Cursor, count:Int = 25): UniswapActionList!
+
     # erc721TxList represents list of ERC721 transactions of the account.
     erc721TxList(cursor:Cursor, count:Int = 25, token: Address, tokenId: BigInt, txType: String): ERC721TransactionList!
 
@@ -1775,6 +1987,9 @@ type Account {
 
     # Details about smart contract, if the account is a smart contract.
     contract: Contract
+
+    # stats represents the transaction activity summary of the account.
+    stats: AccountStats!
 }
 
 # GovernanceContract represents basic information
@@ -2036,10 +2251,8 @@ type Query {
     account(address:Address!):Account!
 
     # Get list of Contracts with at most <count> edges.
-    # If <count> is positive, return edges after the cursor,
-    # if negative, return edges before the cursor.
-    # For undefined cursor, positive <count> starts the list from top,
-    # negative <count> starts the list from bottom.
+    # Edges after the cursor are returned, or from the top of the list
+    # for undefined cursor; <count> must be positive.
     # ValidatedOnly specifies if the list should contain all the Contracts,
     # or just contracts with validated byte code and available source/ABI.
     contracts(validatedOnly: Boolean = false, cursor:Cursor, count:Int!):ContractList!
@@ -2049,20 +2262,16 @@ type Query {
     block(number:Long, hash: Bytes32):Block
 
     # Get list of Blocks with at most <count> edges.
-    # If <count> is positive, return edges after the cursor,
-    # if negative, return edges before the cursor.
-    # For undefined cursor, positive <count> starts the list from top,
-    # negative <count> starts the list from bottom.
+    # Edges after the cursor are returned, or from the top of the list
+    # for undefined cursor; <count> must be positive.
     blocks(cursor:Cursor, count:Int!):BlockList!
 
     # Get transaction information for given transaction hash.
     transaction(hash:Bytes32!):Transaction
 
     # Get list of Transactions with at most <count> edges.
-    # If <count> is positive, return edges after the cursor,
-    # if negative, return edges before the cursor.
-    # For undefined cursor, positive <count> starts the list from top,
-    # negative <count> starts the list from bottom.
+    # Edges after the cursor are returned, or from the top of the list
+    # for undefined cursor; <count> must be positive.
     transactions(cursor:Cursor, count:Int!):TransactionList!
 
     # Get filtered list of ERC20 Transactions.
@@ -2084,6 +2293,10 @@ type Query {
     # Get a scrollable list of epochs sorted from the last one back by default.
     epochs(cursor: Cursor, count: Int = 25): EpochList!
 
+    # Get the seal of the specified epoch, or the seal of the last sealed epoch
+    # if id is not provided. Null if the seal of the epoch is not known.
+    epochSeal(id: Long): EpochSeal
+
     # The last staker id in Opera blockchain.
     lastStakerId: Long!
 
@@ -2117,8 +2330,14 @@ type Query {
     # Returns the current price per gas in WEI units.
     gasPrice: Long!
 
+    # gasPriceTiers provides the current gas price suggested by the node
+    # along with low, average and high tiers derived from recent blocks.
+    gasPriceTiers: GasPriceTiers!
+
     # estimateGas returns the estimated amount of gas required
     # for the transaction described by the parameters of the call.
+    # If the call reverts, the error provides the decoded revert reason
+    # and the returned data in the extensions with the EXECUTION_REVERTED code.
     estimateGas(from: Address, to: Address, value: BigInt, data: String): Long
 
     # Get price details of the Opera blockchain token for the given target symbols.
@@ -2161,6 +2380,17 @@ type Query {
     # by the Uniswap Core contract on Opera blockchain.
     defiUniswapPairs: [UniswapPair!]!
 
+    # defiUniswapPair resolves the Uniswap pair of the given tokens.
+    # The order of the tokens is irrelevant. If the pair does not exist,
+    # null is returned.
+    defiUniswapPair(tokenA: Address!, tokenB: Address!): UniswapPair
+
+    # defiUniswapTokenPrice resolves the price of the token derived from Uniswap
+    # pair reserves. If the against token is not provided, the configured quote
+    # token is used. Tokens without a direct pair are routed through configured
+    # base tokens. If no route is available, null is returned.
+    defiUniswapTokenPrice(token: Address!, against: Address): UniswapTokenPrice
+
     # defiUniswapAmountsOut calculates the expected output amounts
     # required to finalize a swap operation specified by a list of
     # tokens involved in the swap steps and the input amount.
@@ -2210,17 +2440,21 @@ type Query {
     defiTimeReserves(address:Address!, resolution:String, fromDate:Int, toDate:Int):[DefiTimeReserve!]!
 
     # Get list of Uniswap actions with at most <count> edges.
-    # If <count> is positive, return edges after the cursor,
-    # if negative, return edges before the cursor.
-    # For undefined cursor, positive <count> starts the list from top,
-    # negative <count> starts the list from bottom.
+    # Edges after the cursor are returned, or from the top of the list
+    # for undefined cursor; <count> must be positive.
     # Address can be used for specifying actions for one Uniswap pair.
     # ActionType represents action type:
-    # 0 - swap,
-    # 1 - mint,
-    # 2 - burn,
+    # 0 - mint,
+    # 1 - burn,
+    # 2 - sync,
+    # 3 - swap,
     defiUniswapActions(pairAddress:Address, cursor:Cursor, count:Int!, actionType:Int):UniswapActionList!
 
+    # defiUniswapSwaps provides list of token swaps made on Uniswap pairs.
+    # The list is paginated the same way as the uniswap action list,
+    # pairAddress can be used to limit the list to a single pair.
+    defiUniswapSwaps(pairAddress:Address, cursor:Cursor, count:Int!):UniswapActionList!
+
     # erc20Token provides the information about an ERC20 token specified by it's
     # address, if available. The resolver returns NULL if the token does not exist.
     erc20Token(token: Address!):ERC20Token
@@ -2291,6 +2525,250 @@ type Query {
     # If the end time is not specified, the list is provided up to the current date/time.
     # The maximal date/time span of the list is 30 days.
     gasPriceList(from: Time! to: Time): [GasPriceTick!]!
+
+    # internalTransactions provides the list of internal calls executed
+    # by the transaction specified by the hash. The call trace is loaded
+    # on demand from the connected node; NULL is returned if the node
+    # does not support transaction tracing.
+    internalTransactions(hash: Bytes32!): [InternalTransaction!]
+
+    # contractAbi provides the ABI of a validated smart contract
+    # along with its parsed functions, events and custom errors.
+    # Returns NULL if the contract is not known or not validated.
+    contractAbi(address: Address!): ContractAbi
+
+    # contractSource provides the source code and compiler settings
+    # of a validated smart contract.
+    # Returns NULL if the contract is not known or not validated.
+    contractSource(address: Address!): ContractSource
+
+    # blockTransactions provides list of transactions of a block identified
+    # by number or by hash, in the order of their execution inside the block.
+    # If neither is provided, the most recent block is used.
+    # Edges after the cursor are returned; <count> must be positive.
+    # The cursor is a transaction hash from the block.
+    blockTransactions(number: Long, hash: Bytes32, cursor: Cursor, count: Int = 25): TransactionList
+
+    # contractCreation provides the deployment details of a smart contract,
+    # including the deployer and the deployment transaction.
+    # Returns NULL if the contract is not known.
+    contractCreation(address: Address!): ContractCreation
+
+    # contractCreationCode provides the init code used to deploy a smart contract
+    # split into the creation bytecode and the decoded constructor arguments.
+    # Returns NULL if the contract is not known.
+    contractCreationCode(address: Address!): ContractCreationCode
+
+    # resultCacheStats provides hit and miss statistics of the resolvers
+    # with cached results. Requires the admin scope.
+    resultCacheStats: [ResultCacheStat!]!
+
+    # accounts provides a batch of accounts by their addresses in one request.
+    # Repeated addresses are resolved only once. Accounts failing to load
+    # are reported with the error instead of failing the whole batch.
+    # The number of addresses is limited by the server configuration.
+    accounts(addresses: [Address!]!): [AccountResult!]!
+
+    # logs provides a page of log records emitted inside the given range of blocks,
+    # similar to the eth_getLogs call. The records are filtered by the emitting
+    # contract address and by topics; each topic position lists alternatives
+    # to match, NULL or empty position matches any topic. The range ends
+    # at the latest block and starts at its end, if not specified; the number
+    # of blocks in the range is limited by the server configuration.
+    # Records of contracts with known ABI are decoded.
+    # The cursor continues after the record it identifies, <count> must be positive.
+    logs(address: Address, topics: [[Bytes32!]], fromBlock: Long, toBlock: Long, cursor: Cursor, count: Int = 25): EventLogList!
+
+    # pendingTransactions provides the list of pending transactions observed
+    # on the connected node, which were sent from, or to the given address;
+    # all pending transactions are listed if the address is not given.
+    # The newest transactions are listed first. Transactions are removed
+    # from the list once mined, or if not mined in time.
+    # Raises an error if the pending pool is not enabled on the server.
+    pendingTransactions(address: Address): [Transaction!]!
+
+    # contractMethods provides the catalog of callable functions, events
+    # and custom errors of a validated smart contract with their selectors
+    # and parameters. Returns NULL if the contract is not known or not validated.
+    contractMethods(address: Address!): ContractMethods
+
+    # name provides the name of the address using the reverse resolution
+    # of the configured name service. Returns NULL if the address does not
+    # have a name, or the name does not resolve back to the address.
+    # Raises an error if the name service is not configured.
+    name(address: Address!): String
+
+    # resolveName provides the address the name resolves to using the configured
+    # name service. Returns NULL if the name does not resolve to an address.
+    # Raises an error if the name service is not configured.
+    resolveName(name: String!): Address
+
+    # accountTokenTransfers provides list of token transfers sent, or received
+    # by the account, optionally limited to a single token. Transfers of all the token
+    # types (ERC20/ERC721/ERC1155) are included, approvals are not. The <from> and <to>
+    # limit the range of block time stamps (UNIX time, inclusive) of the transfers.
+    # Edges after the cursor are returned; <count> must be positive.
+    accountTokenTransfers(account: Address!, token: Address, from: Long, to: Long, cursor: Cursor, count: Int = 25): AccountTokenTransferList!
+
+    # rewardClaims provides a list of staking reward claims of the given
+    # delegator and/or on the given staker. Both direct claims and re-stakes
+    # are included. The most recent claims are provided if cursor is omitted.
+    rewardClaims(delegator: Address, stakerId: BigInt, cursor: Cursor, count: Int = 25): RewardClaimList!
+
+    # validatorDelegations provides the list of delegations received by the given
+    # staker along with the summary of all the active delegations. Cursor is used
+    # to obtain specific slice of the list; the list can be sorted by the creation
+    # time, or by the active delegated amount from the highest.
+    validatorDelegations(stakerId: BigInt!, cursor: Cursor, count: Int = 25, sortBy: DelegationSorting = CREATED): ValidatorDelegationList!
+
+    # accountPortfolio provides the native balance and ERC20 token balances
+    # of the given account valued in the quote token. The configured Uniswap quote
+    # token is used if the quote is not provided.
+    accountPortfolio(address: Address!, quote: Address): AccountPortfolio!
+
+    # Get a list of the richest accounts sorted by their native token balance.
+    # The ranking is recalculated periodically, so the balances may be slightly
+    # behind the current state of the chain; see the updated field of the edge.
+    richList(cursor: Cursor, count: Int = 25): RichList!
+
+    # Search for blocks, transactions, accounts and contracts by the given input.
+    # The query can be a block number, a full or partial transaction hash,
+    # a full or partial address, or the beginning of a known contract name.
+    # Ambiguous inputs resolve to all the matching results.
+    search(query: String!): [SearchResult!]!
+
+    # Get the metadata of the connected node and its network, including
+    # the well-known contracts, so clients don't need to hard-code them.
+    # The values are cached for a short time.
+    networkInfo: NetworkInfo!
+
+    # Get the raw 32 bytes value of the storage slot of the given contract.
+    # The slot is a hex encoded number, e.g. 0x0 for the first slot.
+    # The latest state is used if the block is not specified; historical states
+    # are available only on archive nodes. The access may be disabled by the server,
+    # or require an API key with the read scope.
+    storageAt(address: Address!, slot: BigInt!, block: Long): Bytes32!
+
+    # dailyStats provides a list of aggregated statistics of the network transaction flow
+    # grouped by days, or hours. Closed buckets are precomputed, the current bucket
+    # is calculated live. Boundaries are defined in format YYYY-MM-DD and both are inclusive;
+    # the last 30 days are provided by default. Hourly statistics are limited to 31 days.
+    dailyStats(fromDate: String, toDate: String, granularity: TrxStatsGranularity = DAY): [TrxStats!]!
+
+    # denylistStats provides the state and the impact of the denylist
+    # of addresses and call selectors excluded from the transaction analysis.
+    # Requires the admin scope.
+    denylistStats: DenylistStats!
+
+    # contractEvents provides the list of events declared in the ABI of the contract
+    # and a page of their recent emissions; the emissions are searched in the widest
+    # block range allowed for logs ending at the latest block. The event is selected
+    # by its name, or signature, and by the topic hash for contracts without known ABI;
+    # anonymous events can not be selected. The cursor continues after the record
+    # it identifies, <count> must be positive.
+    contractEvents(address: Address!, name: String, cursor: Cursor, count: Int = 25): ContractEvents!
+
+    # simulateCall executes the contract call on the state of the given block,
+    # or the latest state, with the state override applied, without creating
+    # a transaction. The call and its result are decoded using the verified ABI
+    # of the contract, if available. The state override needs node support.
+    # The call is enabled by the server configuration, requires the read scope
+    # and is limited in time and in the size of the data and the override.
+    # The block can be identified by a block tag instead of the number,
+    # e.g. to run the call on the finalized state.
+    simulateCall(from: Address, to: Address!, data: Bytes, value: BigInt, block: Long, blockTag: BlockTag, stateOverride: [StateOverride!]): SimulatedCall!
+
+    # nonceStatus provides the state of the transaction nonces of the given sender account,
+    # including the pending nonces and the gaps preventing them from being mined.
+    # Pending transactions are known only if the pending pool is enabled on the API server.
+    nonceStatus(address: Address!): NonceStatus!
+
+    # contractGasStats provides the total and the average gas used by transactions
+    # sent to the given contract in the given inclusive block range.
+    # The last 100,000 blocks are covered by default.
+    contractGasStats(address: Address!, fromBlock: Long, toBlock: Long): ContractGasStats!
+
+    # topContractsByGas provides the contracts with the highest gas used by transactions
+    # sent to them in the given inclusive block range, the last 100,000 blocks by default.
+    # The range is limited to 1,000,000 blocks; larger counts are clamped to 100 contracts.
+    topContractsByGas(fromBlock: Long, toBlock: Long, count: Int = 10): [ContractGasStats!]!
+
+    # decodeInput decodes the input data of the given transaction using the caller
+    # supplied contract ABI in JSON format instead of the verified ABI of the contract.
+    # The method matching the call selector must be present in the ABI.
+    decodeInput(hash: Bytes32!, abi: String!): DecodedCall!
+
+    # webhooks provides the list of notification webhooks registered for the given address,
+    # or all the registered webhooks if the address is not specified. Requires the admin scope.
+    webhooks(address: Address): [Webhook!]!
+
+    # serverIdentity provides the public identity of the API server
+    # used to verify signatures of the API responses.
+    serverIdentity: ServerIdentity!
+
+    # stakingApr provides an estimate of the annual yield of the stake delegated
+    # to the given validator based on the rewards of the recent epochs.
+    stakingApr(stakerId: BigInt!): StakingApr!
+
+    # areContracts checks which of the given addresses are smart contracts in one request.
+    # Known accounts are answered from the stored account types, the code of unknown ones
+    # is checked on the node. Repeated addresses are resolved only once.
+    # The number of addresses is limited by the server configuration.
+    areContracts(addresses: [Address!]!): [ContractFlag!]!
+
+    # verifyTypedData recovers the address of the signer of the EIP-712 typed data signature.
+    # The domain, the types and the message are expected as JSON encoded objects the way
+    # they were passed to the wallet. The EIP712Domain type is derived from the domain
+    # if not declared, and so is the primary type, if not specified. Callers compare
+    # the recovered address with the expected signer.
+    verifyTypedData(domain: String!, types: String!, message: String!, primaryType: String, signature: Bytes!): Address!
+
+    # accountActivity provides the contracts called by the given account
+    # with the breakdown of the called functions, sorted by the number of calls.
+    # Function names are resolved from the verified contract ABI, if available.
+    accountActivity(address: Address!, cursor: Cursor, count: Int = 25): AccountActivity!
+
+    # diagnostics provides the runtime state of the API server including the block scanner lag,
+    # the depth of the processing queues and the health of the subsystems. Requires the admin scope.
+    diagnostics: Diagnostics!
+
+    # tokenApprovals provides the outstanding ERC20 allowances granted by the given owner,
+    # optionally limited to the given token. Only the most recent approval of each spender
+    # is considered; revoked approvals are not listed.
+    tokenApprovals(owner: Address!, token: Address): [TokenApproval!]!
+
+    # blockReward provides the reward attributable to the block of the given number.
+    # The rewards are distributed per epoch, see BlockReward for the approximation used.
+    blockReward(block: Long!): BlockReward!
+
+    # logsByEvent provides a page of log records of the given event emitted by the contract,
+    # filtered by the values of the decoded event arguments. The contract ABI must be known;
+    # the event is identified by its name, or signature. The block range is limited
+    # the same way as for the logs query.
+    # Conditions on indexed arguments are translated to topic filters and evaluated
+    # by the database. Conditions on non-indexed arguments are evaluated on the decoded
+    # records, which is much slower; up to 5000 records of the event are scanned for a single
+    # page, so the page may contain fewer records than requested even if more records
+    # match. Continue with the cursor of the page info until there is no next page.
+    logsByEvent(address: Address!, event: String!, args: [EventArgFilter!], fromBlock: Long, toBlock: Long, cursor: Cursor, count: Int = 25): EventLogList!
+
+    # topTokens provides ERC20 tokens ranked by their transfers over the given period.
+    # Up to 100 most transferred tokens, or tokens with the most unique recipients,
+    # are ranked. Values are expressed in the configured Uniswap quote token,
+    # tokens without a known price are ranked last by the volume. The ranking
+    # is cached and refreshed in a fraction of the period.
+    topTokens(period: TokenRankingPeriod = DAY, metric: TokenRankingMetric = VOLUME, cursor: Cursor, count: Int = 25): TopTokenList!
+
+    # stakingParams provides the unbonding, delegation and lock-up parameters
+    # currently enforced by the SFC contract. The values are cached for a few minutes.
+    stakingParams: StakingParams!
+
+    # tokenPriceHistory provides the price history of the token in the given time range
+    # aggregated into ticks of the given interval in seconds, 1 hour by default.
+    # Prices are sampled periodically from the reserves of the configured Uniswap
+    # price pair of the token, or the given pair. Ticks without samples carry
+    # the last known price forward.
+    tokenPriceHistory(token: Address!, from: Time!, to: Time, interval: Int, pair: Address): TokenPriceHistory!
 }
 
 # Mutation endpoints for modifying the data
@@ -2299,12 +2777,55 @@ type Mutation {
     # The tx parameter represents raw signed and RLP encoded transaction data.
     sendTransaction(tx: Bytes!):Transaction
 
+    # sendRawTransaction submits a raw signed and RLP encoded transaction
+    # into the block chain and provides its hash. The transaction is available
+    # as pending right away, before the node picks it up. Transactions rejected
+    # by the node are reported with the rejection reason, e.g. nonce too low,
+    # or gas price too low.
+    #
+    # The optional idempotencyKey makes retries of the submission safe. Repeated
+    # submissions with the same key within 24 hours resolve to the hash of the original
+    # transaction without sending it again. Using the key for a different transaction fails.
+    sendRawTransaction(data: Bytes!, idempotencyKey: String): Bytes32!
+
     # Validate a deployed contract byte code with the provided source code
     # so potential users can check the contract source code, access contract ABI
     # to be able to interact with the contract and get the right metadata.
     # Returns updated contract information. If the contract can not be validated,
     # it raises a GraphQL error.
     validateContract(contract: ContractValidationInput!): Contract!
+
+    # backfillContractCreations starts a background update of deployment details
+    # of contracts stored before the deployer has been tracked.
+    # Returns FALSE if the backfill is already running. Requires the admin scope.
+    backfillContractCreations: Boolean!
+
+    # importContracts upserts a batch of contract ABIs into the contract store
+    # so calls of the contracts can be decoded right away. Each record is imported
+    # separately and the result is reported per record. Addresses without contract code
+    # are rejected. The reanalysis of known failed calls of imported contracts
+    # can be requested. Requires the admin scope.
+    importContracts(contracts: [ContractImportInput!]!, reanalyze: Boolean = false): [ContractImportResult!]!
+
+    # registerWebhook registers a notification webhook posting a signed notification
+    # to the given URL whenever the address receives a transaction. Failed deliveries
+    # are retried with an increasing delay. Requires the admin scope.
+    registerWebhook(address: Address!, url: String!): Webhook!
+
+    # removeWebhook removes the webhook with all its pending deliveries.
+    # Returns FALSE if the webhook is not known. Requires the admin scope.
+    removeWebhook(id: String!): Boolean!
+
+    # classifyContract sets the type of the known contract manually, e.g. to ERC20
+    # for a token not detected automatically. Manually classified contracts are not
+    # re-classified by the transfer heuristic. Requires the admin scope.
+    classifyContract(address: Address!, type: String!): Contract!
+
+    # refreshContract re-reads the on-chain data of the known contract, e.g. after
+    # a proxy upgrade. The code presence is verified, the type is detected again,
+    # the proxy implementation is resolved and the cached contract data are dropped.
+    # Manually classified contracts keep their type. Requires the admin scope.
+    refreshContract(address: Address!): ContractRefresh!
 }
 
 # Subscriptions to live events broadcasting
@@ -2314,6 +2835,1599 @@ type Subscription {
 
     # Subscribe to receive information about new transactions in the blockchain.
     onTransaction: Transaction!
+
+    # Subscribe to receive new pending transactions observed on the connected node,
+    # optionally only those sent from, or to the given address.
+    # The subscription ends right away if the pending pool is not enabled on the server.
+    onPendingTransaction(address: Address): Transaction!
+}
+
+# FMintLiquidationPrice represents the price of a collateral token
+# on which an fMint account would drop below the minimal collateral ratio,
+# assuming the price of all the other collateral tokens stays the same.
+type FMintLiquidationPrice {
+    # tokenAddress represents the address of the collateral token.
+    tokenAddress: Address!
+
+    # token represents the detail of the collateral token.
+    token: DefiToken!
+
+    # price represents the oracle price of the token on which
+    # the account becomes subject to liquidation. The value is null
+    # if the account does not have any debt.
+    price: BigInt
+
+    # currentPrice represents the current oracle price of the token.
+    currentPrice: BigInt!
+}
+
+# UniswapTokenPrice represents a token price derived from Uniswap pair reserves.
+type UniswapTokenPrice {
+    # token represents the address of the priced token.
+    token: Address!
+
+    # against represents the address of the token the price is expressed in.
+    against: Address!
+
+    # price represents the decimals adjusted price of one token
+    # in units of the against token.
+    price: Float!
+
+    # route represents the list of Uniswap pairs used to derive the price.
+    route: [UniswapPair!]!
+
+    # isLowConfidence signals that at least one pair of the route
+    # has reserves too small to provide a reliable price.
+    isLowConfidence: Boolean!
+}
+
+# InternalTransaction represents a single call executed inside of a transaction
+# extracted from the call trace of the transaction.
+type InternalTransaction {
+    # hash is the hash of the parent transaction.
+    hash: Bytes32!
+
+    # traceAddress is the position of the call inside the call tree;
+    # sub-calls of the call share its trace address prefix.
+    traceAddress: [Int!]!
+
+    # type is the type of the call, e.g. CALL, DELEGATECALL, STATICCALL, CREATE.
+    type: String!
+
+    # from is the address of the caller.
+    from: Address!
+
+    # to is the address of the callee; NULL for failed contract creation.
+    to: Address
+
+    # value is the amount of native tokens transferred by the call.
+    value: BigInt!
+
+    # gas is the amount of gas provided to the call.
+    gas: Long!
+
+    # gasUsed is the amount of gas consumed by the call.
+    gasUsed: Long!
+
+    # input is the input data of the call.
+    input: Bytes!
+
+    # failed signals the call has been reverted.
+    failed: Boolean!
+
+    # errorMessage is the error of a failed call; NULL for successful calls.
+    errorMessage: String
+}
+
+# GasPriceTiers represents gas price suggestion derived from gas prices
+# paid by transactions in recent blocks. All the prices are in WEI units.
+type GasPriceTiers {
+    # suggested is the gas price suggested by the node.
+    suggested: BigInt!
+
+    # low is the gas price expected to get the transaction processed eventually.
+    low: BigInt!
+
+    # average is the gas price paid by the usual transaction.
+    average: BigInt!
+
+    # high is the gas price expected to get the transaction processed quickly.
+    high: BigInt!
+
+    # blocks is the number of recent blocks inspected.
+    blocks: Int!
+
+    # samples is the number of transaction gas prices the tiers are derived from;
+    # all the tiers equal to the suggested price on low traffic.
+    samples: Int!
+}
+
+# RevertCustomError represents a Solidity custom error a failed transaction reverted with.
+type RevertCustomError {
+    # selector is the 4 bytes selector of the error.
+    selector: Bytes!
+
+    # name is the name of the error; null if the error can not be matched
+    # with the verified ABI of the contract.
+    name: String
+
+    # signature is the canonical signature of the error, e.g. InsufficientBalance(uint256,uint256);
+    # null if the error can not be matched with the verified ABI of the contract.
+    signature: String
+
+    # args is the list of decoded arguments of the error.
+    args: [RevertCustomErrorArg!]!
+}
+
+# RevertCustomErrorArg represents a single decoded argument of a custom error.
+type RevertCustomErrorArg {
+    # name is the name of the argument as declared in the ABI.
+    name: String!
+
+    # type is the Solidity type of the argument.
+    type: String!
+
+    # value is the string representation of the argument value. Numbers are provided
+    # in decimal, fixed point numbers with the decimal point, addresses and bytes in hex.
+    # Arrays and tuples are encoded as JSON arrays and objects keyed by the component names,
+    # with numbers kept as strings.
+    value: String!
+}
+
+# ContractAbi represents the ABI of a validated smart contract.
+type ContractAbi {
+    "Abi is the ABI JSON as stored with the validated contract."
+    abi: String!
+
+    "Functions is the list of functions declared by the contract."
+    functions: [ContractAbiEntry!]!
+
+    "Events is the list of events declared by the contract."
+    events: [ContractAbiEntry!]!
+
+    "Errors is the list of custom errors declared by the contract."
+    errors: [ContractAbiEntry!]!
+}
+
+# ContractAbiEntry represents a single function, event or error of a contract ABI.
+type ContractAbiEntry {
+    "Name of the ABI entry."
+    name: String!
+
+    "Signature is the canonical signature, i.e. transfer(address,uint256)."
+    signature: String!
+
+    """
+    Selector is the 4 bytes selector of a function or an error,
+    or the 32 bytes topic of an event.
+    """
+    selector: Bytes!
+
+    "Inputs is the list of input parameters."
+    inputs: [ContractAbiParam!]!
+
+    "Outputs is the list of output parameters, empty for events and errors."
+    outputs: [ContractAbiParam!]!
+
+    "StateMutability of a function, i.e. view, pure, nonpayable or payable."
+    stateMutability: String
+
+    "Anonymous signals an anonymous event."
+    anonymous: Boolean!
+}
+
+# ContractAbiParam represents an input or output parameter of a contract ABI entry.
+type ContractAbiParam {
+    "Name of the parameter, may be empty."
+    name: String!
+
+    "Type of the parameter, i.e. uint256."
+    type: String!
+
+    "Indexed signals an indexed event parameter."
+    indexed: Boolean!
+}
+
+# ContractSource represents the source code of a validated smart contract.
+type ContractSource {
+    "Address of the contract."
+    address: Address!
+
+    "Name of the contract."
+    name: String!
+
+    "Compiler identifier used for the validation, i.e. Solidity 0.8.4."
+    compiler: String!
+
+    "EvmVersion is the target EVM version used by the compiler, if known."
+    evmVersion: String
+
+    "IsOptimized signals that the compiler optimizer was enabled."
+    isOptimized: Boolean!
+
+    "OptimizeRuns is the number of optimizer runs."
+    optimizeRuns: Int!
+
+    "License of the source code, empty if not specified."
+    license: String!
+
+    "SourceCode is the validated source code."
+    sourceCode: String!
+
+    "SourceFiles is the list of source files of the validated contract."
+    sourceFiles: [ContractSourceFile!]!
+
+    "Metadata is the standard JSON metadata produced by the compiler, if available."
+    metadata: String
+
+    """
+    IsExactMatch signals that the compiled byte code matched the deployed one
+    including the metadata hash. Partial match ignores the metadata hash.
+    """
+    isExactMatch: Boolean!
+
+    "Validated is the unix timestamp of the contract validation."
+    validated: Long!
+}
+
+# ContractSourceFile represents a single source file of a validated contract.
+type ContractSourceFile {
+    "Name of the source file."
+    name: String!
+
+    "Content of the source file."
+    content: String!
+}
+
+# AccountStats represents the transaction activity summary of an account.
+type AccountStats {
+    # address is the address of the account.
+    address: Address!
+
+    # trxCount is the total number of transactions sent or received by the account.
+    trxCount: Long!
+
+    # firstBlock is the block of the first transaction of the account.
+    # Null if the account has no transactions.
+    firstBlock: Long
+
+    # firstSeen is the unix time stamp of the first transaction of the account.
+    # Null if the account has no transactions.
+    firstSeen: Long
+
+    # lastBlock is the block of the most recent transaction of the account.
+    # Null if the account has no transactions.
+    lastBlock: Long
+
+    # lastSeen is the unix time stamp of the most recent transaction of the account.
+    # Null if the account has no transactions.
+    lastSeen: Long
+
+    # isContract signals that the account is a smart contract.
+    isContract: Boolean!
+}
+
+# ContractCreation represents the deployment details of a smart contract.
+type ContractCreation {
+    "Address of the contract."
+    address: Address!
+
+    "TransactionHash is the hash of the deployment transaction."
+    transactionHash: Bytes32!
+
+    "Transaction is the deployment transaction."
+    transaction: Transaction!
+
+    """
+    Deployer is the address of the account which deployed the contract.
+    Contracts created by a factory contract have the factory as the deployer.
+    """
+    deployer: Address!
+
+    "Block is the number of the block the contract was deployed in."
+    block: Long!
+
+    "Timestamp is the unix timestamp of the contract deployment."
+    timeStamp: Long!
+
+    "IsFactoryDeployment signals that the contract was created by another contract."
+    isFactoryDeployment: Boolean!
+}
+
+# ContractCreationCode represents the init code used to deploy a smart contract
+# split into the creation bytecode and the constructor arguments.
+type ContractCreationCode {
+    "Address of the contract."
+    address: Address!
+
+    "TransactionHash is the hash of the deployment transaction."
+    transactionHash: Bytes32!
+
+    """
+    IsFactoryDeployment signals that the contract was created by another contract.
+    The init code is recovered from the call trace of the deployment transaction in that case.
+    """
+    isFactoryDeployment: Boolean!
+
+    """
+    IsAvailable signals the init code could be recovered. The init code of factory
+    deployed contracts is not available if the node does not provide the tracing API.
+    """
+    isAvailable: Boolean!
+
+    "InitCode is the full init code used to deploy the contract."
+    initCode: Bytes
+
+    """
+    Bytecode is the creation bytecode of the contract without the constructor arguments.
+    It's the whole init code if the constructor arguments can not be separated.
+    """
+    bytecode: Bytes
+
+    "ConstructorArgs are the ABI encoded constructor arguments; null if they can not be separated."
+    constructorArgs: Bytes
+
+    "Args is the list of decoded constructor arguments; empty if the contract ABI is not known."
+    args: [DecodedCallArg!]!
+
+    "ArgsSkipped signals the constructor arguments exceed the decoding limit of the API server."
+    argsSkipped: Boolean!
+}
+
+# ResultCacheStat represents result cache statistics of a resolver.
+type ResultCacheStat {
+    # field is the name of the cached resolver.
+    field: String!
+
+    # ttl is the number of seconds the results are cached for.
+    ttl: Int!
+
+    # hits is the number of requests served from the cache.
+    hits: Float!
+
+    # misses is the number of requests loading fresh results.
+    misses: Float!
+
+    # hitRatio is the ratio of requests served from the cache.
+    hitRatio: Float!
+}
+
+# AccountResult represents a single account of a batch accounts query.
+type AccountResult {
+    # address is the requested address.
+    address: Address!
+
+    # account is the account information, NULL if the account failed to load.
+    account: Account
+
+    # error is the reason the account failed to load, if any.
+    error: String
+}
+
+# EventLog represents a log record emitted by a transaction.
+type EventLog {
+    # address is the address of the contract emitting the log record.
+    address: Address!
+
+    # topics is the list of indexed topics of the log record.
+    topics: [Bytes32!]!
+
+    # data is the raw non-indexed data of the log record.
+    data: Bytes!
+
+    # blockNumber is the number of the block the log record belongs to.
+    blockNumber: Long!
+
+    # blockHash is the hash of the block the log record belongs to.
+    blockHash: Bytes32!
+
+    # transactionHash is the hash of the transaction emitting the log record.
+    transactionHash: Bytes32!
+
+    # transactionIndex is the index of the transaction in the block.
+    transactionIndex: Long!
+
+    # logIndex is the index of the log record in the block.
+    logIndex: Long!
+
+    # removed signals the log record was reverted by a chain reorganization.
+    removed: Boolean!
+
+    # event is the event decoded from the log record using the contract ABI;
+    # NULL if the ABI of the contract is not known.
+    event: DecodedEvent
+}
+
+# DecodedEvent represents an event decoded from a log record.
+type DecodedEvent {
+    # name is the name of the event.
+    name: String!
+
+    # signature is the canonical signature of the event, e.g. Transfer(address,address,uint256).
+    signature: String!
+
+    # args is the list of decoded event arguments.
+    args: [DecodedEventArg!]!
+}
+
+# DecodedEventArg represents a single decoded argument of an event.
+# Indexed arguments of dynamic types are available as their hash only.
+type DecodedEventArg {
+    name: String!
+    type: String!
+    indexed: Boolean!
+    value: String!
+}
+
+# EventLogList represents a page of log records.
+type EventLogList {
+    # edges contains the list of log records on the page.
+    edges: [EventLogListEdge!]!
+
+    # pageInfo provides information about the page.
+    pageInfo: ListPageInfo!
+}
+
+# EventLogListEdge represents a single edge of the log records list.
+type EventLogListEdge {
+    cursor: Cursor!
+    log: EventLog!
+}
+
+# ContractMethods represents the catalog of functions, events and custom errors
+# of a validated smart contract.
+type ContractMethods {
+    "Address of the contract."
+    address: Address!
+
+    "Functions is the list of functions declared by the contract."
+    functions: [ContractAbiEntry!]!
+
+    "Events is the list of events declared by the contract."
+    events: [ContractAbiEntry!]!
+
+    "Errors is the list of custom errors declared by the contract."
+    errors: [ContractAbiEntry!]!
+}
+
+# AccountTokenTransferList is a list of token transfers of an account.
+type AccountTokenTransferList {
+    # Edges contains provided edges of the sequential list.
+    edges: [AccountTokenTransferListEdge!]!
+
+    # TotalCount is the maximum number of token transfers available for sequential access.
+    totalCount: BigInt!
+
+    # PageInfo is an information about the current page of token transfer edges.
+    pageInfo: ListPageInfo!
+}
+
+# AccountTokenTransferListEdge is a single edge in a sequential list of token transfers.
+type AccountTokenTransferListEdge {
+    cursor: Cursor!
+    transfer: AccountTokenTransfer!
+}
+
+# AccountTokenTransfer represents a token transfer seen from the account side.
+type AccountTokenTransfer {
+    # Hash is the hash of the executed transaction call.
+    hash: Bytes32!
+
+    # blockNumber represents the number of the block
+    # the transfer was executed in.
+    blockNumber: Long!
+
+    # direction of the transfer from the account point of view (IN/OUT/SELF).
+    direction: String!
+
+    # counterparty is the other side of the transfer.
+    counterparty: Address!
+
+    # tokenAddress represents the address of the token involved.
+    tokenAddress: Address!
+
+    # tokenName represents the name of the token contract.
+    # Is empty, if not provided for the given token.
+    tokenName: String!
+
+    # tokenSymbol represents the symbol of the token contract.
+    # Is empty, if not provided for the given token.
+    tokenSymbol: String!
+
+    # tokenType represents the type of the token (i.e. ERC20/ERC721/ERC1155).
+    tokenType: String!
+
+    # type represents the type of the transfer (i.e. Transfer/Mint/Burn).
+    type: String!
+
+    # amount of tokens transferred.
+    amount: BigInt!
+
+    # formattedAmount is the amount of tokens transferred
+    # scaled by the token decimals; available for ERC20 tokens only.
+    formattedAmount: FormattedTokenAmount
+
+    # multi-token contracts (ERC-721/ERC-1155) token ID transferred.
+    tokenId: BigInt!
+
+    # time stamp of the block processing.
+    timeStamp: Long!
+
+    # transaction is the blockchain transaction the transfer originated from.
+    transaction: Transaction!
+}
+
+# ValidatorCommission represents the commission rate taken by validators
+# from the rewards of their delegators.
+type ValidatorCommission {
+    # rate is the commission rate in SFC decimal units;
+    # 1e18 represents 100%.
+    rate: BigInt!
+
+    # since is the time stamp the current rate has been observed first
+    # in Unix Epoch units.
+    since: Long!
+
+    # observed is the time stamp the current rate has been observed last
+    # in Unix Epoch units.
+    observed: Long!
+}
+
+# ValidatorDelegationList is a list of delegations received by a validator
+# with the summary of all the active delegations of the validator.
+type ValidatorDelegationList {
+    "Edges contains provided edges of the sequential list."
+    edges: [DelegationListEdge!]!
+
+    """
+    TotalCount is the maximum number of delegations
+    available for sequential access.
+    """
+    totalCount: Long!
+
+    "PageInfo is an information about the current page of delegation edges."
+    pageInfo: ListPageInfo!
+
+    "TotalAmount is the total amount of active delegations in WEI."
+    totalAmount: BigInt!
+
+    "DelegatorCount is the number of active delegations."
+    delegatorCount: Long!
+}
+
+# DelegationSorting represents the order of delegations in a list.
+enum DelegationSorting {
+    CREATED
+    AMOUNT
+}
+
+# AccountPortfolio represents the native and ERC20 token balances
+# of an account valued in a quote token.
+type AccountPortfolio {
+    # address is the address of the account.
+    address: Address!
+
+    # quote is the address of the token the values are expressed in.
+    quote: Address!
+
+    # native is the native token balance of the account.
+    native: PortfolioAsset!
+
+    # tokens is the list of non-zero ERC20 token balances of the account.
+    tokens: [PortfolioAsset!]!
+
+    # totalValue is the sum of all the known values of the assets.
+    totalValue: Float!
+
+    # isComplete signals that all the assets have a known value.
+    isComplete: Boolean!
+}
+
+# PortfolioAsset represents a single valued asset of an account portfolio.
+type PortfolioAsset {
+    # token is the ERC20 token of the asset; null for the native token.
+    token: ERC20Token
+
+    # symbol is the symbol of the asset.
+    symbol: String!
+
+    # decimals is the number of decimals of the asset.
+    decimals: Int!
+
+    # balance is the raw balance of the asset.
+    balance: BigInt!
+
+    # isPriced signals the price of the asset is known.
+    isPriced: Boolean!
+
+    # price is the price of one whole unit of the asset in the quote token,
+    # null if the price is not known.
+    price: Float
+
+    # value is the value of the balance in the quote token,
+    # null if the price is not known.
+    value: Float
+
+    # isLowConfidence signals the price was derived from low reserves.
+    isLowConfidence: Boolean!
+}
+
+# ContractImportInput represents a contract ABI record to be imported.
+input ContractImportInput {
+    "Address of the contract."
+    address: Address!
+
+    "JSON ABI of the contract."
+    abi: String!
+
+    "Optional smart contract name."
+    name: String
+
+    "Optional version of the compiler used to compile the contract."
+    compilerVersion: String
+}
+
+# ContractImportResult represents the result of a single contract import.
+type ContractImportResult {
+    # address is the address of the imported contract.
+    address: Address!
+
+    # success signals the contract has been imported.
+    success: Boolean!
+
+    # error is the reason the contract import failed, if any.
+    error: String
+}
+
+# RichList is a list of accounts sorted by their native token balance
+# from the highest to the lowest.
+type RichList {
+    "Edges contains provided edges of the sequential list."
+    edges: [RichListEdge!]!
+
+    """
+    TotalCount is the maximum number of accounts
+    available for sequential access.
+    """
+    totalCount: Long!
+
+    "PageInfo is an information about the current page of rich list edges."
+    pageInfo: ListPageInfo!
+}
+
+# RichListEdge is a single account in the rich list.
+type RichListEdge {
+    "Cursor defines a scroll key to this edge."
+    cursor: Cursor!
+
+    "Address is the address of the account."
+    address: Address!
+
+    "Account is the full detail of the account."
+    account: Account!
+
+    "Balance is the native token balance of the account in WEI."
+    balance: BigInt!
+
+    "IsContract signals the account is a smart contract, not an EOA."
+    isContract: Boolean!
+
+    "Label is the name of the known contract, if available."
+    label: String
+
+    "Updated is the time stamp of the balance update in unix seconds."
+    updated: Long!
+}
+
+# SearchResultType represents the type of the search result.
+enum SearchResultType {
+    BLOCK
+    TRANSACTION
+    ACCOUNT
+    CONTRACT
+}
+
+# SearchResult is a single typed result of the search.
+type SearchResult {
+    "Type is the type of the result; only the field of the type is set."
+    type: SearchResultType!
+
+    "Block is the block found, if the type is BLOCK."
+    block: Block
+
+    "Transaction is the transaction found, if the type is TRANSACTION."
+    transaction: Transaction
+
+    "Account is the account found, if the type is ACCOUNT, or CONTRACT."
+    account: Account
+
+    "Contract is the contract found, if the type is CONTRACT and the contract is known."
+    contract: Contract
+}
+
+# NetworkInfo represents the metadata of the connected node and its network.
+type NetworkInfo {
+    "ChainId is the identifier of the chain used for transaction signing."
+    chainId: BigInt!
+
+    "Network is the name of the network, if known."
+    network: String
+
+    "ClientVersion is the version string of the connected node client."
+    clientVersion: String!
+
+    "IsSyncing signals the node is still catching up with the network."
+    isSyncing: Boolean!
+
+    "HighestBlock is the highest block known to the node, if the node is syncing."
+    highestBlock: Long
+
+    "BlockNumber is the number of the latest block known to the node."
+    blockNumber: Long!
+
+    "BlockHash is the hash of the latest block known to the node."
+    blockHash: Bytes32!
+
+    "BlockTime is the time stamp of the latest block known to the node in unix seconds."
+    blockTime: Long!
+
+    "Contracts are the well-known contracts of the network."
+    contracts: NetworkContracts!
+}
+
+# NetworkContracts represents the well-known contracts of the network
+# configured on the API server. Contracts not configured are null.
+type NetworkContracts {
+    "Sfc is the address of the SFC contract used for PoS staking control."
+    sfc: Address!
+
+    "NodeDriver is the address of the node driver contract."
+    nodeDriver: Address
+
+    "NetworkInitializer is the address of the network initializer contract."
+    networkInitializer: Address
+
+    "Tokenizer is the address of the stake tokenizer contract."
+    tokenizer: Address
+
+    "StakeToken is the address of the tokenized stake token."
+    stakeToken: Address
+
+    "FMintAddressProvider is the address of the fMint address provider contract."
+    fMintAddressProvider: Address
+
+    "UniswapCore is the address of the Uniswap core factory contract."
+    uniswapCore: Address
+
+    "UniswapRouter is the address of the Uniswap router contract."
+    uniswapRouter: Address
+
+    "QuoteToken is the address of the default token prices are derived against."
+    quoteToken: Address
+
+    "FLendLendingPool is the address of the fLend lending pool contract."
+    fLendLendingPool: Address
+
+    "NameServiceRegistry is the address of the name service registry contract."
+    nameServiceRegistry: Address
+
+    "Governance are the addresses of the governance contracts."
+    governance: [Address!]!
+
+    "Multicall is the address of the Multicall contract aggregating contract calls, if configured."
+    multicall: Address
+}
+
+# TrxStatsGranularity represents the length of a single time bucket
+# of the aggregated transaction statistics.
+enum TrxStatsGranularity {
+    DAY
+    HOUR
+}
+
+# TrxStats represents an aggregation of the network transaction flow
+# in a single time bucket.
+type TrxStats {
+    # bucket represents the time bucket of the aggregation in format YYYY-MM-DD
+    # for daily statistics, or YYYY-MM-DDTHH for hourly statistics, in UTC.
+    bucket: String!
+
+    # stamp is the UNIX time stamp of the start of the bucket.
+    stamp: Long!
+
+    # transactions represents the number of transactions processed in the bucket.
+    transactions: Long!
+
+    # activeAddresses represents the number of unique addresses
+    # sending, or receiving transactions in the bucket.
+    activeAddresses: Long!
+
+    # amount represents the total value of native tokens transferred
+    # directly by transactions in the bucket.
+    amount: BigInt!
+
+    # avgGasPrice represents the average gas price of transactions in the bucket in WEI.
+    avgGasPrice: BigInt!
+}
+
+# DecodedCall represents a contract call decoded from the transaction input data.
+type DecodedCall {
+    # selector is the 4 bytes selector of the called method.
+    selector: Bytes!
+
+    # name is the name of the called method; null if the method can not be matched
+    # with the verified ABI of the contract.
+    name: String
+
+    # signature is the canonical signature of the method, e.g. transfer(address,uint256);
+    # null if the method can not be matched with the verified ABI of the contract.
+    signature: String
+
+    # args is the list of decoded arguments of the call.
+    args: [DecodedCallArg!]!
+
+    # argsSkipped signals the arguments were not decoded, since the input data
+    # exceeds the size limit of the decoding configured on the API server.
+    argsSkipped: Boolean!
+
+    # contractDestroyed signals the called contract has self-destructed since
+    # and no longer exists at the address.
+    contractDestroyed: Boolean!
+
+    # subCalls is the list of inner calls of a recognized batch call,
+    # e.g. a Gnosis Safe multiSend or a Multicall aggregate; empty for other calls.
+    subCalls: [DecodedSubCall!]!
+
+    # subCallsSkipped signals some inner calls were not decoded, since the batch
+    # exceeds the depth or size limit configured on the API server.
+    subCallsSkipped: Boolean!
+}
+
+# DecodedSubCall represents an inner call of a batch contract call.
+type DecodedSubCall {
+    # to is the address of the inner call target.
+    to: Address!
+
+    # value is the amount of native tokens sent with the inner call in WEI.
+    value: BigInt!
+
+    # delegateCall signals the inner call is executed as a delegate call
+    # in the context of the calling contract.
+    delegateCall: Boolean!
+
+    # call is the decoded inner call; null for plain value transfers.
+    call: DecodedCall
+}
+
+# DecodedCallArg represents a single decoded argument of a contract call.
+type DecodedCallArg {
+    # name is the name of the argument as declared in the ABI.
+    name: String!
+
+    # type is the Solidity type of the argument.
+    type: String!
+
+    # value is the string representation of the argument value. Numbers are provided
+    # in decimal, fixed point numbers with the decimal point, addresses and bytes in hex.
+    # Arrays and tuples are encoded as JSON arrays and objects keyed by the component names,
+    # with numbers kept as strings.
+    value: String!
+}
+
+# DenylistStats represents the state and the impact of the denylist
+# of addresses and call selectors excluded from the transaction analysis.
+type DenylistStats {
+    # addresses is the number of denied addresses.
+    addresses: Int!
+
+    # selectors is the number of denied 4 bytes call selectors.
+    selectors: Int!
+
+    # allowed is the number of call selectors on the allowlist; if not zero,
+    # only calls of the allowed methods are analyzed.
+    allowed: Int!
+
+    # skippedTransactions is the number of transactions calling a denied address,
+    # or method, or a method outside the allowlist, recorded without the analysis
+    # since the server start.
+    skippedTransactions: Long!
+
+    # skippedLogs is the number of log records excluded from the analysis since the server start.
+    skippedLogs: Long!
+
+    # lastReloaded is the UNIX time stamp of the last load of the denylist.
+    lastReloaded: Long
+}
+
+# ContractEvents represents the events of a contract and their recent emissions.
+type ContractEvents {
+    # address is the address of the contract.
+    address: Address!
+
+    # abiAvailable signals the ABI of the contract, or its implementation, is known.
+    abiAvailable: Boolean!
+
+    # definitions is the list of events declared in the contract ABI;
+    # empty if the ABI is not known.
+    definitions: [ContractEventDefinition!]!
+
+    # emissions is a page of the recent log records of the contract,
+    # decoded if the ABI is known, raw topics and data are provided otherwise.
+    emissions: EventLogList!
+}
+
+# ContractEventDefinition represents an event declared in the contract ABI.
+type ContractEventDefinition {
+    # name is the name of the event.
+    name: String!
+
+    # signature is the canonical signature of the event, e.g. Transfer(address,address,uint256).
+    signature: String!
+
+    # topic is the hash of the signature log records of the event are marked with;
+    # NULL for anonymous events.
+    topic: Bytes32
+
+    # anonymous signals the event is not marked with the signature topic.
+    anonymous: Boolean!
+
+    # inputs is the list of the event arguments.
+    inputs: [ContractEventInput!]!
+}
+
+# ContractEventInput represents an argument of an event declared in the contract ABI.
+type ContractEventInput {
+    name: String!
+    type: String!
+    indexed: Boolean!
+}
+
+# StateOverride represents the state changes of a single account
+# applied before a simulated contract call.
+input StateOverride {
+    # address is the address of the account overridden.
+    address: Address!
+
+    # balance replaces the native balance of the account.
+    balance: BigInt
+
+    # nonce replaces the nonce of the account.
+    nonce: Long
+
+    # code replaces the code of the account.
+    code: Bytes
+
+    # state replaces the whole storage of the account with the given slots.
+    state: [StorageOverride!]
+
+    # stateDiff replaces only the given storage slots of the account.
+    stateDiff: [StorageOverride!]
+}
+
+# StorageOverride represents the value of a single storage slot of a simulated call.
+input StorageOverride {
+    slot: Bytes32!
+    value: Bytes32!
+}
+
+# SimulatedCall represents the result of a simulated contract call.
+type SimulatedCall {
+    # success signals the call finished without revert.
+    success: Boolean!
+
+    # returnData is the raw data returned by the call, the revert data for reverted calls.
+    returnData: Bytes!
+
+    # revertReason is the human readable reason of the reverted call, if known.
+    revertReason: String
+
+    # revertError is the custom error the call reverted with,
+    # if decoded using the verified ABI of the contract.
+    revertError: RevertCustomError
+
+    # call is the called method decoded using the verified ABI of the contract;
+    # null if the method is not known.
+    call: DecodedCall
+
+    # outputs is the list of values returned by the call decoded using
+    # the verified ABI of the contract; null if the values can not be decoded.
+    outputs: [DecodedCallArg!]
+}
+
+# NonceStatus represents the state of the transaction nonces of a sender account.
+type NonceStatus {
+    # address is the address of the sender account.
+    address: Address!
+
+    # nonce is the next nonce of the account on chain, i.e. the number of mined transactions.
+    nonce: Long!
+
+    # pendingNonce is the next nonce of the account including the transactions
+    # the node can execute from its transaction pool.
+    pendingNonce: Long!
+
+    # highestMinedNonce is the highest nonce of a mined transaction
+    # of the account known to the API server; null if none is known.
+    highestMinedNonce: Long
+
+    # pendingNonces is the list of nonces of pending transactions
+    # of the account observed by the API server, but not mined yet.
+    pendingNonces: [Long!]!
+
+    # gaps is the list of missing nonces preventing the pending transactions
+    # of the account from being mined, e.g. nonces of dropped transactions.
+    gaps: [Long!]!
+
+    # isStuck signals some pending transactions of the account
+    # can not be mined until the gaps are filled.
+    isStuck: Boolean!
+
+    # unindexedNonces is the list of recent mined nonces of the account
+    # without a transaction known to the API server.
+    unindexedNonces: [Long!]!
+}
+
+# FormattedTokenAmount represents an amount of tokens
+# scaled by the number of decimals of the token.
+type FormattedTokenAmount {
+    # value is the decimal representation of the amount,
+    # i.e. "1.5" for 1500000000000000000 of an 18 decimals token.
+    value: String!
+
+    # decimals is the number of decimals used to scale the amount.
+    decimals: Int!
+
+    # decimalsAssumed signals the token does not provide its decimals
+    # and the default of 18 decimals was used to scale the amount.
+    decimalsAssumed: Boolean!
+}
+
+# ContractGasStats represents the gas used by transactions sent to a contract
+# in a range of blocks. Internal calls of other transactions are not included.
+type ContractGasStats {
+    # address is the address of the contract.
+    address: Address!
+
+    # fromBlock is the first block of the range covered.
+    fromBlock: Long!
+
+    # toBlock is the last block of the range covered.
+    toBlock: Long!
+
+    # gasUsed is the total gas used by the transactions.
+    gasUsed: Long!
+
+    # calls is the number of the transactions.
+    calls: Long!
+
+    # avgGasPerCall is the average gas used by a single transaction.
+    avgGasPerCall: Long!
+}
+
+# EpochSeal represents the sealing of an epoch by the Lachesis consensus.
+# The epoch is sealed by its last block; blocks of a sealed epoch are final.
+type EpochSeal {
+    # epoch is the id of the sealed epoch.
+    epoch: Long!
+
+    # block is the number of the last block of the epoch, the block sealing it.
+    block: Long!
+
+    # timestamp is the unix timestamp of the sealing block.
+    timestamp: Long!
+}
+
+# Webhook represents a notification webhook registered for an address.
+# The webhook is notified by a signed HTTP POST request when the address
+# receives a transaction.
+type Webhook {
+    # id is the unique identifier of the webhook.
+    id: String!
+
+    # address is the watched address.
+    address: Address!
+
+    # url is the address the notifications are posted to.
+    url: String!
+
+    # created is the UNIX time stamp of the webhook registration.
+    created: Long!
+
+    # deliveries provides the list of the most recent notifications of the webhook.
+    deliveries(count: Int = 25): [WebhookDelivery!]!
+}
+
+# WebhookDelivery represents a single notification of a webhook about a transaction.
+type WebhookDelivery {
+    # id is the unique identifier of the delivery.
+    id: String!
+
+    # transaction is the hash of the transaction the webhook is notified about.
+    transaction: Bytes32!
+
+    # block is the number of the block containing the transaction.
+    block: Long!
+
+    # status is the state of the delivery; "pending", "delivered", or "failed".
+    status: String!
+
+    # attempts is the number of failed delivery attempts.
+    attempts: Int!
+
+    # lastError is the error of the last failed attempt, if any.
+    lastError: String
+
+    # nextAttempt is the UNIX time stamp of the next attempt of a pending delivery.
+    nextAttempt: Long
+
+    # created is the UNIX time stamp of the delivery creation.
+    created: Long!
+
+    # delivered is the UNIX time stamp of the successful delivery.
+    delivered: Long
+}
+
+# ServerIdentity represents the public identity of the API server.
+# If the response signing is enabled, each API response carries the X-Api-Signer
+# header with the server address and the X-Api-Signature header with the Ethereum
+# signed message signature (EIP-191, personal_sign) of the response body.
+# Clients recover the signer from the signature and compare it with the known address.
+type ServerIdentity {
+    # address is the address of the server key; NULL if the server key is not configured.
+    address: Address
+
+    # publicKey is the uncompressed secp256k1 public key of the server.
+    publicKey: Bytes
+
+    # signsResponses signals if the API responses are signed by the server key.
+    signsResponses: Boolean!
+}
+
+# StakingApr represents an ESTIMATE of the annual yield of the stake delegated
+# to a validator. The estimate is derived from the rewards per token the validator
+# delegators received over the recent epochs, annualized; the validator commission
+# is already deducted. Past rewards do not guarantee future yields.
+type StakingApr {
+    # stakerId is the id of the validator the estimate belongs to.
+    stakerId: BigInt!
+
+    # apr is the estimated annual percentage rate in percent.
+    apr: Float!
+
+    # apy is the estimated annual percentage yield in percent,
+    # assuming the rewards are compounded daily.
+    apy: Float!
+
+    # fromEpoch is the first epoch of the estimation window.
+    fromEpoch: Long!
+
+    # toEpoch is the last sealed epoch of the estimation window.
+    toEpoch: Long!
+
+    # isNetworkAverage signals the validator does not have enough reward history
+    # and the network average of the base rewards less the commission is provided instead.
+    isNetworkAverage: Boolean!
+
+    # reason explains why the network average is provided.
+    reason: String
+}
+
+# ContractFlag represents the flag of an address being a smart contract.
+type ContractFlag {
+    # address is the checked address.
+    address: Address!
+
+    # isContract signals the address is a smart contract;
+    # NULL if the address could not be checked.
+    isContract: Boolean
+}
+
+# AccountActivity is a list of contracts called by an account sorted
+# by the number of calls from the highest.
+type AccountActivity {
+    "Edges contains provided edges of the sequential list."
+    edges: [AccountActivityEdge!]!
+
+    """
+    TotalCount is the maximum number of contracts
+    available for sequential access.
+    """
+    totalCount: Long!
+
+    "PageInfo is an information about the current page of account activity edges."
+    pageInfo: ListPageInfo!
+}
+
+# AccountActivityEdge is a single contract called by the account.
+type AccountActivityEdge {
+    "Cursor defines a scroll key to this edge."
+    cursor: Cursor!
+
+    "Contract is the address of the called contract."
+    contract: Address!
+
+    "Label is the name of the known contract, if available."
+    label: String
+
+    "Calls is the total number of calls of the account to the contract."
+    calls: Long!
+
+    "LastCall is the time stamp of the most recent call in unix seconds."
+    lastCall: Long!
+
+    "Functions is the breakdown of the calls by the called function."
+    functions: [FunctionCallCount!]!
+}
+
+# FunctionCallCount represents the number of calls of a single contract function.
+type FunctionCallCount {
+    "Selector is the 4 bytes selector of the called function."
+    selector: Bytes!
+
+    """
+    Name is the name of the called function; null if the function
+    can not be matched with the verified ABI of the contract.
+    """
+    name: String
+
+    """
+    Signature is the canonical signature of the function, e.g. transfer(address,uint256);
+    null if the function can not be matched with the verified ABI of the contract.
+    """
+    signature: String
+
+    "Calls is the number of calls of the function."
+    calls: Long!
+}
+
+# Diagnostics represents the runtime state of the API server and its data processing.
+type Diagnostics {
+    # uptime is the number of seconds since the API server start.
+    uptime: Long!
+
+    # nodeHead is the current block height of the connected node.
+    nodeHead: Long!
+
+    # scanner is the progress of the block scanner.
+    scanner: ScannerStatus!
+
+    # scannerLag is the number of blocks the scanner is behind the node head.
+    scannerLag: Long!
+
+    # queues is the list of the internal processing queues with their depth.
+    queues: [QueueStatus!]!
+
+    # workers is the list of the background worker pools with their load.
+    workers: [WorkerPoolStatus!]!
+
+    # activeSubscriptions is the number of active GraphQL subscriptions.
+    activeSubscriptions: Int!
+
+    # subsystems is the list of the data processing services and the repository
+    # connections with their status.
+    subsystems: [SubsystemStatus!]!
+}
+
+# ScannerStatus represents the progress of the block scanner.
+type ScannerStatus {
+    # next is the number of the next block to be scanned.
+    next: Long!
+
+    # target is the number of the last block of the current scan range.
+    target: Long!
+
+    # dispatched is the number of the last block processed in order.
+    dispatched: Long!
+
+    # isIdle signals the scanner follows the new heads of the node.
+    isIdle: Boolean!
+}
+
+# QueueStatus represents the depth of an internal processing queue.
+type QueueStatus {
+    # name is the name of the queue.
+    name: String!
+
+    # length is the number of items waiting in the queue.
+    length: Int!
+
+    # capacity is the max number of items the queue can hold.
+    capacity: Int!
+}
+
+# WorkerPoolStatus represents the load of a pool of background workers.
+type WorkerPoolStatus {
+    # name is the name of the pool.
+    name: String!
+
+    # active is the number of workers busy with a task.
+    active: Int!
+
+    # size is the number of workers of the pool.
+    size: Int!
+}
+
+# SubsystemStatus represents the health of a subsystem of the API server.
+type SubsystemStatus {
+    # name is the name of the subsystem.
+    name: String!
+
+    # isUp signals the service is running, or the connection is available.
+    isUp: Boolean!
+
+    # lastError is the UNIX time stamp of the most recent error of the subsystem.
+    lastError: Long
+
+    # lastErrorMessage is the message of the most recent error of the subsystem.
+    lastErrorMessage: String
+}
+
+# TokenApproval represents an outstanding ERC20 allowance granted by a token owner to a spender.
+type TokenApproval {
+    # tokenAddress represents the address of the ERC20 token contract.
+    tokenAddress: Address!
+
+    # token represents the token detail involved.
+    token: ERC20Token!
+
+    # owner represents the address of the token owner granting the allowance.
+    owner: Address!
+
+    # spender represents the address allowed to spend the tokens of the owner.
+    spender: Address!
+
+    # spenderLabel is the name of the spender contract, if known.
+    spenderLabel: String
+
+    # amount represents the allowance set by the most recent approval; please make sure
+    # to interpret the amount with the correct number of decimals from the ERC20 token detail.
+    # Spending the tokens may reduce the allowance without a new approval being emitted.
+    amount: BigInt!
+
+    # formattedAmount represents the allowance scaled by the ERC20 token decimals.
+    formattedAmount: FormattedTokenAmount!
+
+    # isUnlimited signals the allowance was set to the max uint256 value,
+    # which is usually treated as an infinite approval by the tokens.
+    isUnlimited: Boolean!
+
+    # trxHash represents the hash of the transaction setting the allowance.
+    trxHash: Bytes32!
+
+    # transaction represents the transaction setting the allowance.
+    transaction: Transaction!
+
+    # timeStamp represents the Unix epoch time stamp of the approval.
+    timeStamp: Long!
+}
+
+# BlockReward represents the reward attributable to a block.
+# Opera does not reward individual blocks; the SFC distributes the rewards to validators
+# and their delegators per sealed epoch, weighted by the stake and the uptime of the validators.
+# The block is attributed the base reward minted over the time since its parent block
+# at the base reward rate of its epoch, and the fees paid by its transactions, which enter
+# the fee pool of the epoch. The values are an approximation of the block share
+# in the epoch rewards, not a payout actually received by the block producer.
+type BlockReward {
+    # block is the block the reward is attributed to.
+    block: Block!
+
+    # epoch is the id of the Lachesis epoch the block belongs to;
+    # null if not provided by the node.
+    epoch: Long
+
+    # rateEpoch is the id of the sealed epoch the base reward rate is taken from;
+    # the last sealed epoch is used for blocks of the epoch not sealed yet.
+    rateEpoch: Long!
+
+    # producer is the address of the block creator; null if not provided by the node.
+    producer: Address
+
+    # validator is the validator producing the block; null if not known.
+    validator: Staker
+
+    # duration is the number of seconds since the parent block.
+    duration: Long!
+
+    # baseRewardPerSecond is the base reward rate of the epoch in WEI per second.
+    baseRewardPerSecond: BigInt!
+
+    # baseReward is the base reward in WEI minted over the duration of the block.
+    baseReward: BigInt!
+
+    # transactionFees is the total of fees in WEI paid by the transactions of the block.
+    transactionFees: BigInt!
+
+    # burntFees is the portion of the transaction fees in WEI burnt by the block, the base
+    # fee per gas multiplied by the gas used; null for blocks without the base fee.
+    burntFees: BigInt
+
+    # totalReward is the base reward and the transaction fees without the burnt portion in WEI.
+    totalReward: BigInt!
+}
+
+# EventArgFilter represents a condition on the value of a decoded event argument.
+input EventArgFilter {
+    # name is the name of the argument in the event declaration;
+    # unnamed arguments are named by their position, e.g. arg0.
+    name: String!
+
+    # value is the expected value of the argument; addresses, fixed bytes and bytes
+    # are expected in hex, numbers in decimal, or hex with the 0x prefix,
+    # booleans as true/false. Dynamic arrays and tuples are not supported.
+    value: String!
+}
+
+# TokenRankingPeriod represents the period the token transfers are ranked over.
+enum TokenRankingPeriod {
+    HOUR
+    DAY
+    WEEK
+    MONTH
+}
+
+# TokenRankingMetric represents the metric the tokens are ranked by.
+enum TokenRankingMetric {
+    "VOLUME ranks tokens by the transferred volume valued in the quote token."
+    VOLUME
+
+    "TRANSFERS ranks tokens by the number of transfers, mints and burns."
+    TRANSFERS
+
+    "HOLDERS ranks tokens by the number of unique accounts receiving the token."
+    HOLDERS
+}
+
+# TopTokenList is a list of ERC20 tokens ranked by their transfers over a period.
+type TopTokenList {
+    "Edges contains provided edges of the sequential list."
+    edges: [TopTokenEdge!]!
+
+    "TotalCount is the number of tokens in the ranking."
+    totalCount: Long!
+
+    "PageInfo is an information about the current page of the ranking."
+    pageInfo: ListPageInfo!
+
+    "Metric is the metric the tokens are ranked by."
+    metric: TokenRankingMetric!
+
+    "Since is the unix timestamp of the beginning of the ranked period."
+    since: Long!
+
+    "Quote is the address of the token the values are expressed in."
+    quote: Address!
+}
+
+# TopTokenEdge is a single token of the token ranking.
+type TopTokenEdge {
+    "Cursor defines a scroll key to this edge."
+    cursor: Cursor!
+
+    "Rank is the position of the token in the ranking starting with 1."
+    rank: Int!
+
+    "Token is the ranked ERC20 token with its metadata."
+    token: ERC20Token!
+
+    "Transfers is the number of transfers, mints and burns of the token in the period."
+    transfers: Long!
+
+    "Holders is the number of unique accounts receiving the token in the period."
+    holders: Long!
+
+    "Volume is the transferred amount in whole tokens."
+    volume: Float!
+
+    "Price is the current price of one whole token in the quote token, null if not known."
+    price: Float
+
+    "Value is the volume valued in the quote token, null if the price is not known."
+    value: Float
+}
+
+# ContractRefresh represents the outcome of a forced refresh of the contract on-chain data.
+type ContractRefresh {
+    # contract is the refreshed contract.
+    contract: Contract!
+
+    # previousType is the type of the contract before the refresh.
+    previousType: String!
+
+    # implementation is the current implementation address of an EIP-1967
+    # proxy contract; null if the contract is not a proxy.
+    implementation: Address
+
+    # changes lists the descriptions of the changes made by the refresh;
+    # empty if the stored contract data matched the chain.
+    changes: [String!]!
+}
+
+# StakingParams represents the staking protocol parameters currently enforced
+# by the SFC contract. All the values are read at the same block.
+type StakingParams {
+    # withdrawalPeriodEpochs is the min number of epochs between an un-delegation
+    # and the withdrawal of the un-delegated amount.
+    withdrawalPeriodEpochs: BigInt!
+
+    # withdrawalPeriodTime is the min number of seconds between an un-delegation
+    # and the withdrawal of the un-delegated amount.
+    withdrawalPeriodTime: BigInt!
+
+    # minSelfStake is the min amount of tokens in WEI self-staked by a validator.
+    minSelfStake: BigInt!
+
+    # minDelegation is the min amount of tokens in WEI of a delegation;
+    # null if the SFC contract does not enforce any.
+    minDelegation: BigInt
+
+    # maxDelegatedRatio is the max ratio between the stake delegated to a validator
+    # and its self stake. The value is provided as a multiplier with 18 decimals.
+    maxDelegatedRatio: BigInt!
+
+    # minLockupDuration is the min number of seconds a delegation can be locked for.
+    minLockupDuration: BigInt!
+
+    # maxLockupDuration is the max number of seconds a delegation can be locked for.
+    maxLockupDuration: BigInt!
+
+    # epoch is the current epoch at the time the parameters were read.
+    epoch: Long!
+
+    # block is the number of the block the parameters were read at.
+    block: Long!
+}
+
+# TokenPriceHistory represents the price history of a token sampled
+# from the reserves of a Uniswap pair.
+type TokenPriceHistory {
+    # token is the address of the token the prices are provided for.
+    token: Address!
+
+    # against is the address of the other token of the pair;
+    # the prices are provided in units of this token.
+    against: Address!
+
+    # pair is the address of the sampled Uniswap pair.
+    pair: Address!
+
+    # interval is the length of a single tick in seconds.
+    interval: Int!
+
+    # ticks is the list of the price ticks ordered by time.
+    ticks: [TokenPriceTick!]!
+}
+
+# TokenPriceTick represents the price of a token aggregated over a single interval.
+type TokenPriceTick {
+    # fromTime is the starting time of the interval.
+    fromTime: Time!
+
+    # toTime is the ending time of the interval.
+    toTime: Time!
+
+    # open is the first price sampled in the interval.
+    open: Float!
+
+    # high is the highest price sampled in the interval.
+    high: Float!
+
+    # low is the lowest price sampled in the interval.
+    low: Float!
+
+    # close is the last price sampled in the interval.
+    close: Float!
+
+    # samples is the number of price samples taken in the interval.
+    samples: Int!
+
+    # isCarried signals there are no samples in the interval
+    # and the last known price is carried forward.
+    isCarried: Boolean!
+
+    # isLowConfidence signals a price of the interval was derived
+    # from low pair reserves and may not be reliable.
+    isLowConfidence: Boolean!
 }
 
 `
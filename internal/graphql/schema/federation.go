@@ -0,0 +1,55 @@
+// Package gqlschema provides GraphQL schema definition used by GraphQL handler
+// to validate requests and build responses on the API interface.
+package gqlschema
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// FederationEntityKeys maps the entity types resolvable by the federation gateway
+// to the fields identifying them.
+var FederationEntityKeys = map[string]string{
+	"Account":     "address",
+	"Block":       "number",
+	"Transaction": "hash",
+}
+
+// federationSchema extends the schema with the Apollo Federation service fields.
+const federationSchema = `
+# _Any represents an entity representation sent by the federation gateway.
+scalar _Any
+
+# _Entity represents any of the entity types resolvable by the federation gateway.
+union _Entity = Account | Block | Transaction
+
+# _Service provides the schema of the service to the federation gateway.
+type _Service {
+    # sdl is the schema definition annotated with the federation directives.
+    sdl: String
+}
+
+extend type Query {
+    # _service provides the schema of the service to the federation gateway.
+    _service: _Service!
+
+    # _entities resolves the entities referenced by the given representations.
+    _entities(representations: [_Any!]!): [_Entity]!
+}
+`
+
+// Federated provides the schema extended with the Apollo Federation service fields.
+func Federated() string {
+	return schema + federationSchema
+}
+
+// FederationSDL provides the schema with the entity types annotated
+// by the federation key directive, as expected by the federation gateway.
+func FederationSDL() string {
+	sdl := schema
+	for name, key := range FederationEntityKeys {
+		re := regexp.MustCompile(`(?m)^type\s+` + name + `\s+{`)
+		sdl = re.ReplaceAllLiteralString(sdl, fmt.Sprintf(`type %s @key(fields: "%s") {`, name, key))
+	}
+	return sdl
+}
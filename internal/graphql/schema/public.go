@@ -0,0 +1,79 @@
+// Package gqlschema provides GraphQL schema definition used by GraphQL handler
+// to validate requests and build responses on the API interface.
+package gqlschema
+
+import (
+	"regexp"
+	"strings"
+)
+
+// reRootType matches the opening line of the root query and mutation types.
+var reRootType = regexp.MustCompile(`^(extend\s+)?type\s+(Query|Mutation)\s*{`)
+
+// reFieldName matches the name of a field on its declaration line.
+var reFieldName = regexp.MustCompile(`^\s*([_A-Za-z][_0-9A-Za-z]*)`)
+
+// WithoutRootFields provides the given schema with the listed fields of the root query
+// and mutation types removed, including their comments. The removed fields can not be
+// selected by any query executed against the schema.
+func WithoutRootFields(sdl string, fields map[string]bool) string {
+	lines := strings.Split(sdl, "\n")
+	out := make([]string, 0, len(lines))
+
+	var inRoot, inDesc, skip bool
+	var pending []string
+	for i := 0; i < len(lines); i++ {
+		ln := lines[i]
+		trim := strings.TrimSpace(ln)
+
+		switch {
+		case !inRoot:
+			inRoot = reRootType.MatchString(ln)
+			out = append(out, ln)
+			continue
+		case inDesc || strings.HasPrefix(trim, `"""`):
+			// block descriptions may span several lines
+			if inDesc {
+				inDesc = !strings.HasSuffix(trim, `"""`)
+			} else {
+				inDesc = len(trim) < 6 || !strings.HasSuffix(trim, `"""`)
+			}
+			pending = append(pending, ln)
+			continue
+		case trim == "}":
+			inRoot = false
+			out = append(append(out, pending...), ln)
+			pending = nil
+			continue
+		case trim == "":
+			// drop the separating blank line of a removed field
+			if !skip {
+				out = append(out, pending...)
+				out = append(out, ln)
+			}
+			pending, skip = nil, false
+			continue
+		case strings.HasPrefix(trim, "#") || strings.HasPrefix(trim, `"`):
+			pending = append(pending, ln)
+			continue
+		}
+
+		// collect the whole declaration; arguments may span several lines
+		decl := []string{ln}
+		depth := strings.Count(ln, "(") - strings.Count(ln, ")")
+		for depth > 0 && i+1 < len(lines) {
+			i++
+			decl = append(decl, lines[i])
+			depth += strings.Count(lines[i], "(") - strings.Count(lines[i], ")")
+		}
+
+		m := reFieldName.FindStringSubmatch(ln)
+		if m != nil && fields[m[1]] {
+			pending, skip = nil, true
+			continue
+		}
+		out = append(append(out, pending...), decl...)
+		pending, skip = nil, false
+	}
+	return strings.Join(out, "\n")
+}
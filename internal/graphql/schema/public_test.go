@@ -0,0 +1,57 @@
+// Package gqlschema provides GraphQL schema definition used by GraphQL handler
+// to validate requests and build responses on the API interface.
+package gqlschema
+
+import (
+	"github.com/onsi/gomega"
+	"testing"
+)
+
+// TestWithoutRootFields tests removal of the root fields with their comments.
+func TestWithoutRootFields(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	sdl := `type Block {
+    admin: Boolean!
+}
+
+type Query {
+    # block provides a block.
+    block: Block
+
+    # admin is removed.
+    admin: Boolean!
+
+    """
+    multi is removed,
+    with the block description.
+    """
+    multi(
+        a: Int = 1,
+        b: Int
+    ): Int!
+    "last is kept."
+    last: Int
+}
+
+type Mutation {
+    admin(x: Int): Boolean!
+}
+`
+	expect := `type Block {
+    admin: Boolean!
+}
+
+type Query {
+    # block provides a block.
+    block: Block
+
+    "last is kept."
+    last: Int
+}
+
+type Mutation {
+}
+`
+	g.Expect(WithoutRootFields(sdl, map[string]bool{"admin": true, "multi": true})).To(gomega.Equal(expect))
+}
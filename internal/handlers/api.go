@@ -9,35 +9,46 @@ import (
 	"github.com/graph-gophers/graphql-go"
 	"github.com/graph-gophers/graphql-go/relay"
 	"github.com/graph-gophers/graphql-transport-ws/graphqlws"
-	"github.com/rs/cors"
 	"net/http"
 )
 
 // Api constructs and return the API HTTP handlers chain for serving GraphQL API calls.
-func Api(cfg *config.Config, log logger.Logger, rs resolvers.ApiResolver) http.Handler {
-	// Create new CORS handler and attach the logger into it so we get information on Debug level if needed
-	corsHandler := cors.New(corsOptions(cfg))
-	corsHandler.Log = log
-
+// The group specifies the CORS setup of the route the handler is served on;
+// the administrative fields are available on the admin route only.
+func Api(cfg *config.Config, log logger.Logger, rs resolvers.ApiResolver, group int) http.Handler {
 	// we don't want to write a method for each type field if it could be matched directly
-	opts := []graphql.SchemaOpt{graphql.UseFieldResolvers()}
-
-	// create new parsed GraphQL schema
-	schema := graphql.MustParseSchema(gqlSchema.Schema(), rs, opts...)
+	// the tracer collects resolvers timing for the request logging and applies resolver time limits
+	opts := []graphql.SchemaOpt{graphql.UseFieldResolvers(), graphql.Tracer(RequestTracer{timeouts: newResolverTimeouts(cfg)})}
+	if !cfg.Server.AllowIntrospection {
+		opts = append(opts, graphql.DisableIntrospection())
+	}
 
-	// return the constructed API handler chain
-	return &LoggingHandler{
-		logger:  log,
-		handler: corsHandler.Handler(graphqlws.NewHandlerFunc(schema, &relay.Handler{Schema: schema})),
+	// create new parsed GraphQL schema; federated deployments extend it with the gateway fields
+	sdl := gqlSchema.Schema()
+	if cfg.Server.Federation {
+		sdl = gqlSchema.Federated()
 	}
-}
 
-// corsOptions constructs new set of options for the CORS handler based on provided configuration.
-func corsOptions(cfg *config.Config) cors.Options {
-	return cors.Options{
-		AllowedOrigins: cfg.Server.CorsOrigin,
-		AllowedMethods: []string{"HEAD", "GET", "POST"},
-		AllowedHeaders: []string{"Origin", "Accept", "Content-Type", "X-Requested-With"},
-		MaxAge:         300,
+	// administrative fields are served on the admin route only
+	if group != CorsGroupAdmin {
+		sdl = gqlSchema.WithoutRootFields(sdl, adminFields())
 	}
+	schema := graphql.MustParseSchema(sdl, rs, opts...)
+
+	// queries exceeding configured depth and complexity are rejected before execution;
+	// the API key identity is resolved first so the limits can respect it
+	// introspection queries are rejected with a clear error if the introspection is disabled
+	// queries using fields of the disabled feature groups are rejected the same way
+	// responses are signed by the server key, if configured, so the rejections are signed too
+	// the fields selected by the query are attached so the resolvers can load only what's needed
+	limits := NewQueryLimitHandler(cfg, log, NewSelectionHandler(cfg, log, &relay.Handler{Schema: schema}))
+	introspection := NewIntrospectionHandler(cfg, log, limits)
+	features := NewFeatureHandler(cfg, log, introspection)
+	gql := NewSigningHandler(cfg, log, features)
+
+	// operations received over the WebSocket are subject to the same checks
+	ws := newWsService(log, schema, features, introspection, limits)
+
+	// return the constructed API handler chain; WebSocket connections keep the request identity
+	return NewLoggingHandler(cfg, log, NewCorsHandler(cfg, log, group, NewAuthHandler(cfg, log, NewCacheBypassHandler(log, NewPageSizeHandler(NewLoadersHandler(graphqlws.NewHandlerFunc(ws, gql, graphqlws.WithContextGenerator(graphqlws.ContextGeneratorFunc(wsIdentity)))))))))
 }
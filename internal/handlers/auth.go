@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/auth"
+	"fantom-api-graphql/internal/config"
+	"fantom-api-graphql/internal/logger"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// AuthHandler defines HTTP handler middleware resolving the API key
+// of incoming requests into an identity with access scopes.
+type AuthHandler struct {
+	logger     logger.Logger
+	handler    http.Handler
+	keys       []config.ApiKey
+	requireKey bool
+}
+
+// NewAuthHandler creates a new API key authentication middleware for the given handler.
+// If the authentication is disabled, requests are granted the read scope only,
+// unless the administrative operations are explicitly opened by the configuration.
+func NewAuthHandler(cfg *config.Config, log logger.Logger, h http.Handler) http.Handler {
+	if !cfg.Auth.Enabled {
+		id := auth.Public
+		if cfg.Auth.OpenAdmin {
+			log.Warning("API key authentication disabled; administrative operations are open to everybody")
+			id = auth.OpenAdmin
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r.WithContext(auth.WithIdentity(r.Context(), id)))
+		})
+	}
+
+	log.Noticef("API key authentication enabled with %d keys", len(cfg.Auth.Keys))
+	return &AuthHandler{
+		logger:     log,
+		handler:    h,
+		keys:       cfg.Auth.Keys,
+		requireKey: cfg.Auth.RequireKey,
+	}
+}
+
+// ServeHTTP resolves the request identity and passes the request down the chain.
+func (h *AuthHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	key := apiKeyFromRequest(r)
+
+	// no key provided; the request is anonymous
+	if key == "" {
+		if h.requireKey {
+			h.reject(w, r, fmt.Errorf("API key is required"))
+			return
+		}
+		h.handler.ServeHTTP(w, r.WithContext(auth.WithIdentity(r.Context(), auth.Anonymous)))
+		return
+	}
+
+	id := h.identity(key)
+	if id == nil {
+		h.reject(w, r, fmt.Errorf("invalid API key"))
+		return
+	}
+	h.handler.ServeHTTP(w, r.WithContext(auth.WithIdentity(r.Context(), id)))
+}
+
+// identity finds the identity of the given API key.
+func (h *AuthHandler) identity(key string) *auth.Identity {
+	for _, k := range h.keys {
+		if auth.MatchKey(key, k.Hash) {
+			return &auth.Identity{Name: k.Name, Scopes: k.Scopes, Authenticated: true}
+		}
+	}
+	return nil
+}
+
+// reject responds with the unauthorized status.
+func (h *AuthHandler) reject(w http.ResponseWriter, r *http.Request, err error) {
+	h.logger.Warningf("request from %s rejected; %s", r.RemoteAddr, err.Error())
+
+	w.Header().Set("WWW-Authenticate", "Bearer")
+	http.Error(w, err.Error(), http.StatusUnauthorized)
+}
+
+// wsIdentity carries the request identity over to the context of the GraphQL over WebSocket
+// connection, which is not derived from the upgraded request context.
+func wsIdentity(ctx context.Context, r *http.Request) (context.Context, error) {
+	if id := auth.FromContext(r.Context()); id != nil {
+		return auth.WithIdentity(ctx, id), nil
+	}
+	return ctx, nil
+}
+
+// apiKeyFromRequest extracts the API key from the Authorization header
+// of the request. Both "Bearer <key>" and plain "<key>" forms are accepted.
+func apiKeyFromRequest(r *http.Request) string {
+	val := strings.TrimSpace(r.Header.Get("Authorization"))
+	if len(val) > 7 && strings.EqualFold(val[:7], "bearer ") {
+		val = strings.TrimSpace(val[7:])
+	}
+	return val
+}
@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/auth"
+	"fantom-api-graphql/internal/config"
+	"fantom-api-graphql/internal/logger"
+	"github.com/onsi/gomega"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// authTest represents a single API key authentication test case.
+type authTest struct {
+	name       string
+	requireKey bool
+	header     string
+	status     int
+}
+
+// the list of API key tests; the protected handler requires the admin scope
+var authTests = []authTest{
+	{name: "missing key", header: "", status: http.StatusForbidden},
+	{name: "missing required key", requireKey: true, header: "", status: http.StatusUnauthorized},
+	{name: "invalid key", header: "Bearer not-a-valid-key", status: http.StatusUnauthorized},
+	{name: "insufficient key", header: "Bearer read-key", status: http.StatusForbidden},
+	{name: "admin key", header: "Bearer admin-key", status: http.StatusOK},
+	{name: "admin key without bearer", header: "admin-key", status: http.StatusOK},
+}
+
+// adminOnlyHandler responds with forbidden status if the request does not have the admin scope.
+var adminOnlyHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	if err := auth.Require(r.Context(), auth.ScopeAdmin); err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+	}
+})
+
+// TestAuthHandler tests the API key authentication middleware.
+func TestAuthHandler(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	for _, at := range authTests {
+		cfg := authTestConfig(true, at.requireKey)
+		h := NewAuthHandler(&cfg, logger.New(&cfg), adminOnlyHandler)
+
+		req := httptest.NewRequest(http.MethodPost, "/graphql", nil)
+		if at.header != "" {
+			req.Header.Set("Authorization", at.header)
+		}
+
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		g.Expect(rec.Code).To(gomega.Equal(at.status), at.name)
+	}
+}
+
+// TestAuthHandlerDisabled tests that disabled authentication does not grant the admin scope,
+// unless the administrative operations are explicitly opened.
+func TestAuthHandlerDisabled(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	cfg := authTestConfig(false, true)
+	h := NewAuthHandler(&cfg, logger.New(&cfg), adminOnlyHandler)
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/graphql", nil))
+	g.Expect(rec.Code).To(gomega.Equal(http.StatusForbidden))
+
+	cfg.Auth.OpenAdmin = true
+	h = NewAuthHandler(&cfg, logger.New(&cfg), adminOnlyHandler)
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/graphql", nil))
+	g.Expect(rec.Code).To(gomega.Equal(http.StatusOK))
+}
+
+// TestRequireWithoutIdentity tests that contexts without any identity are not granted any scope.
+func TestRequireWithoutIdentity(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	g.Expect(auth.Require(context.Background(), auth.ScopeRead)).To(gomega.MatchError(auth.ErrUnauthorized))
+	g.Expect(auth.Require(context.Background(), auth.ScopeAdmin)).To(gomega.MatchError(auth.ErrUnauthorized))
+}
+
+// authTestConfig builds the configuration with a read and an admin key.
+func authTestConfig(enabled bool, requireKey bool) config.Config {
+	cfg := config.Config{Log: config.Log{Level: "CRITICAL", Format: "%{message}"}}
+	cfg.Auth = config.Auth{
+		Enabled:    enabled,
+		RequireKey: requireKey,
+		Keys: []config.ApiKey{
+			{Name: "reader", Hash: auth.HashKey("read-key"), Scopes: []string{auth.ScopeRead}},
+			{Name: "admin", Hash: auth.HashKey("admin-key"), Scopes: []string{auth.ScopeAdmin}},
+		},
+	}
+	return cfg
+}
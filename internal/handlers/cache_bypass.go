@@ -0,0 +1,25 @@
+package handlers
+
+import (
+	"fantom-api-graphql/internal/auth"
+	"fantom-api-graphql/internal/graphql/resolvers"
+	"fantom-api-graphql/internal/logger"
+	"net/http"
+)
+
+// CacheBypassHeader is the name of the request header asking to bypass the resolver result cache.
+const CacheBypassHeader = "X-Cache-Bypass"
+
+// NewCacheBypassHandler creates a middleware marking requests with the cache bypass header
+// to skip the resolver result cache. Only requests authenticated by an API key with the admin
+// scope can bypass the cache; the header is ignored for the others, including the requests
+// granted the admin scope with the authentication disabled.
+func NewCacheBypassHandler(log logger.Logger, h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(CacheBypassHeader) != "" && auth.RequireKey(r.Context(), auth.ScopeAdmin) == nil {
+			log.Debugf("result cache bypassed for %s", r.RemoteAddr)
+			r = r.WithContext(resolvers.WithCacheBypass(r.Context()))
+		}
+		h.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"fantom-api-graphql/internal/auth"
+	"fantom-api-graphql/internal/config"
+	"fantom-api-graphql/internal/graphql/resolvers"
+	"fantom-api-graphql/internal/logger"
+	"github.com/onsi/gomega"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// cacheBypassTest represents a single result cache bypass test case.
+type cacheBypassTest struct {
+	name   string
+	id     *auth.Identity
+	header bool
+	bypass bool
+}
+
+// the list of cache bypass tests; only the admin API key can bypass the cache
+var cacheBypassTests = []cacheBypassTest{
+	{name: "admin key", id: &auth.Identity{Name: "ops", Scopes: []string{auth.ScopeAdmin}, Authenticated: true}, header: true, bypass: true},
+	{name: "admin key without header", id: &auth.Identity{Name: "ops", Scopes: []string{auth.ScopeAdmin}, Authenticated: true}},
+	{name: "read key", id: &auth.Identity{Name: "app", Scopes: []string{auth.ScopeRead}, Authenticated: true}, header: true},
+	{name: "open admin", id: auth.OpenAdmin, header: true},
+	{name: "public", id: auth.Public, header: true},
+	{name: "no identity", header: true},
+}
+
+// TestCacheBypassHandler tests the cache bypass header is honored for the admin API key only.
+func TestCacheBypassHandler(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	cfg := config.Config{Log: config.Log{Level: "CRITICAL", Format: "%{message}"}}
+	for _, ct := range cacheBypassTests {
+		var bypass bool
+		h := NewCacheBypassHandler(logger.New(&cfg), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			bypass = resolvers.IsCacheBypass(r.Context())
+		}))
+
+		req := httptest.NewRequest(http.MethodPost, "/graphql", nil)
+		if ct.id != nil {
+			req = req.WithContext(auth.WithIdentity(req.Context(), ct.id))
+		}
+		if ct.header {
+			req.Header.Set(CacheBypassHeader, "1")
+		}
+
+		h.ServeHTTP(httptest.NewRecorder(), req)
+		g.Expect(bypass).To(gomega.Equal(ct.bypass), ct.name)
+	}
+}
+
+// TestCacheBypassCors tests the cache bypass header is allowed cross origin on the admin routes only.
+func TestCacheBypassCors(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	cfg := config.Config{Log: config.Log{Level: "CRITICAL", Format: "%{message}"}}
+	cfg.Server.CorsOrigin = []string{"*"}
+	cfg.Server.CorsAdminOrigin = []string{"https://ops.example.com"}
+
+	for group, allowed := range map[int]bool{CorsGroupPublic: false, CorsGroupAdmin: true} {
+		h := NewCorsHandler(&cfg, logger.New(&cfg), group, okHandler)
+
+		req := httptest.NewRequest(http.MethodOptions, "/graphql", nil)
+		req.Header.Set("Origin", "https://ops.example.com")
+		req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+		req.Header.Set("Access-Control-Request-Headers", CacheBypassHeader)
+
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		if allowed {
+			g.Expect(rec.Header().Get("Access-Control-Allow-Headers")).To(gomega.Equal(CacheBypassHeader))
+			continue
+		}
+		g.Expect(rec.Header().Get("Access-Control-Allow-Origin")).To(gomega.BeEmpty())
+	}
+}
@@ -0,0 +1,519 @@
+package handlers
+
+import (
+	gqlSchema "fantom-api-graphql/internal/graphql/schema"
+	"fmt"
+	"github.com/graph-gophers/graphql-go"
+	"math"
+	"strconv"
+	"sync"
+)
+
+const (
+	// qcMaxListSize is the largest list multiplier we apply to a list field;
+	// resolvers never return more edges than this in a single request.
+	qcMaxListSize = 250
+
+	// qcMaxFragmentNesting limits how deep we follow fragment spreads.
+	qcMaxFragmentNesting = 32
+)
+
+// qcListArguments lists the names of arguments controlling the size of a list field.
+var qcListArguments = map[string]bool{"count": true, "first": true, "last": true}
+
+// qcListDefaults maps the names of the list fields to the size of the list
+// loaded if the size argument is omitted; it's collected from the schema on first use.
+var (
+	qcListDefaults     map[string]int
+	qcListDefaultsOnce sync.Once
+)
+
+// queryCost represents the result of a GraphQL query depth and complexity analysis.
+type queryCost struct {
+	Depth      int
+	Complexity int
+}
+
+// qcSelection represents a single selection inside a GraphQL selection set.
+// It is either a field, an inline fragment, or a named fragment spread.
+type qcSelection struct {
+	name       string
+	multiplier int
+	isField    bool
+	spread     string
+	children   []*qcSelection
+}
+
+// qcDocument represents a parsed GraphQL document reduced
+// to the structure needed for the cost analysis.
+type qcDocument struct {
+	operations map[string][]*qcSelection
+	fragments  map[string][]*qcSelection
+	anonymous  []*qcSelection
+}
+
+// qcParser implements a minimal GraphQL document parser
+// collecting selection sets and list size arguments.
+type qcParser struct {
+	lex  *qcLexer
+	tok  qcToken
+	vars map[string]interface{}
+}
+
+// analyzeQuery calculates the depth and complexity of the given GraphQL query.
+// If the operation name is provided, only the named operation is analyzed;
+// otherwise the most expensive operation of the document is reported.
+func analyzeQuery(query string, opName string, vars map[string]interface{}) (*queryCost, error) {
+	doc, err := parseQueryDocument(query, vars)
+	if err != nil {
+		return nil, err
+	}
+
+	// pick the operations we analyze
+	ops := make([][]*qcSelection, 0, len(doc.operations)+1)
+	if opName != "" {
+		sel, ok := doc.operations[opName]
+		if !ok {
+			return nil, fmt.Errorf("unknown operation %s", opName)
+		}
+		ops = append(ops, sel)
+	} else {
+		for _, sel := range doc.operations {
+			ops = append(ops, sel)
+		}
+		if doc.anonymous != nil {
+			ops = append(ops, doc.anonymous)
+		}
+	}
+
+	// get the most expensive one
+	res := queryCost{}
+	for _, sel := range ops {
+		c, d := doc.cost(sel, 0)
+		if d > res.Depth {
+			res.Depth = d
+		}
+		if c > res.Complexity {
+			res.Complexity = c
+		}
+	}
+	return &res, nil
+}
+
+// cost calculates the complexity and depth of the given selection set.
+func (doc *qcDocument) cost(set []*qcSelection, nesting int) (int, int) {
+	var cost, depth int
+	for _, sel := range set {
+		var c, d int
+		switch {
+		case sel.isField:
+			c, d = doc.cost(sel.children, nesting)
+			c, d = qcSaturate(1+qcSaturate(sel.multiplier*c)), d+1
+		case sel.spread != "":
+			if nesting >= qcMaxFragmentNesting {
+				return math.MaxInt32, math.MaxInt32
+			}
+			c, d = doc.cost(doc.fragments[sel.spread], nesting+1)
+		default:
+			c, d = doc.cost(sel.children, nesting)
+		}
+
+		cost = qcSaturate(cost + c)
+		if d > depth {
+			depth = d
+		}
+	}
+	return cost, depth
+}
+
+// qcDefaultListSize provides the list multiplier of the given field if the list size argument is omitted.
+func qcDefaultListSize(field string) int {
+	qcListDefaultsOnce.Do(func() { qcListDefaults = listFieldDefaults(gqlSchema.Schema()) })
+	if size, ok := qcListDefaults[field]; ok {
+		return size
+	}
+	return 1
+}
+
+// listFieldDefaults collects the default list size of all the fields of the schema accepting a list size argument.
+// Fields without a default size are treated pessimistically as the largest possible list.
+// If more types share the field name, the largest default is used.
+func listFieldDefaults(sdl string) map[string]int {
+	list := make(map[string]int)
+	for _, t := range graphql.MustParseSchema(sdl, nil).Inspect().Types() {
+		fields := t.Fields(&struct{ IncludeDeprecated bool }{IncludeDeprecated: true})
+		if fields == nil {
+			continue
+		}
+
+		for _, f := range *fields {
+			for _, arg := range f.Args() {
+				if !qcListArguments[arg.Name()] {
+					continue
+				}
+
+				size := qcMaxListSize
+				if def := arg.DefaultValue(); def != nil {
+					if v, err := strconv.Atoi(*def); err == nil && v > 0 && v < size {
+						size = v
+					}
+				}
+				if size > list[f.Name()] {
+					list[f.Name()] = size
+				}
+			}
+		}
+	}
+	return list
+}
+
+// qcSaturate keeps the cost value inside a safe range to prevent overflows.
+func qcSaturate(v int) int {
+	if v > math.MaxInt32 || v < 0 {
+		return math.MaxInt32
+	}
+	return v
+}
+
+// parseQueryDocument parses the GraphQL query document.
+func parseQueryDocument(query string, vars map[string]interface{}) (*qcDocument, error) {
+	p := qcParser{lex: &qcLexer{src: query}, vars: vars}
+	doc := qcDocument{
+		operations: make(map[string][]*qcSelection),
+		fragments:  make(map[string][]*qcSelection),
+	}
+
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	for p.tok.kind != qcEOF {
+		if err := p.definition(&doc); err != nil {
+			return nil, err
+		}
+	}
+	return &doc, nil
+}
+
+// definition parses a single top level definition of the document.
+func (p *qcParser) definition(doc *qcDocument) error {
+	// shorthand query
+	if p.is(qcPunct, "{") {
+		sel, err := p.selectionSet()
+		if err != nil {
+			return err
+		}
+		doc.anonymous = append(doc.anonymous, sel...)
+		return nil
+	}
+
+	if p.tok.kind != qcName {
+		return p.unexpected()
+	}
+
+	switch p.tok.val {
+	case "query", "mutation", "subscription":
+		if err := p.next(); err != nil {
+			return err
+		}
+
+		// optional name
+		var name string
+		if p.tok.kind == qcName {
+			name = p.tok.val
+			if err := p.next(); err != nil {
+				return err
+			}
+		}
+
+		// variables and directives are not important for us
+		if p.is(qcPunct, "(") {
+			if err := p.skipBalanced("(", ")"); err != nil {
+				return err
+			}
+		}
+		if err := p.directives(); err != nil {
+			return err
+		}
+
+		sel, err := p.selectionSet()
+		if err != nil {
+			return err
+		}
+		if name == "" {
+			doc.anonymous = append(doc.anonymous, sel...)
+		} else {
+			doc.operations[name] = sel
+		}
+		return nil
+	case "fragment":
+		if err := p.next(); err != nil {
+			return err
+		}
+		name := p.tok.val
+		if err := p.expect(qcName); err != nil {
+			return err
+		}
+
+		// type condition
+		if !p.is(qcName, "on") {
+			return p.unexpected()
+		}
+		if err := p.next(); err != nil {
+			return err
+		}
+		if err := p.expect(qcName); err != nil {
+			return err
+		}
+		if err := p.directives(); err != nil {
+			return err
+		}
+
+		sel, err := p.selectionSet()
+		if err != nil {
+			return err
+		}
+		doc.fragments[name] = sel
+		return nil
+	}
+	return p.unexpected()
+}
+
+// selectionSet parses a selection set enclosed in curly brackets.
+func (p *qcParser) selectionSet() ([]*qcSelection, error) {
+	if !p.is(qcPunct, "{") {
+		return nil, p.unexpected()
+	}
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+
+	list := make([]*qcSelection, 0)
+	for !p.is(qcPunct, "}") {
+		sel, err := p.selection()
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, sel)
+	}
+	return list, p.next()
+}
+
+// selection parses a single selection of a selection set.
+func (p *qcParser) selection() (*qcSelection, error) {
+	if p.is(qcPunct, "...") {
+		return p.fragment()
+	}
+
+	// field name, or alias followed by the name
+	sel := qcSelection{name: p.tok.val, isField: true, multiplier: 1}
+	if err := p.expect(qcName); err != nil {
+		return nil, err
+	}
+	if p.is(qcPunct, ":") {
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		sel.name = p.tok.val
+		if err := p.expect(qcName); err != nil {
+			return nil, err
+		}
+	}
+
+	// arguments may control the size of the list; list fields load the default size without them
+	sel.multiplier = qcDefaultListSize(sel.name)
+	if p.is(qcPunct, "(") {
+		if err := p.arguments(&sel); err != nil {
+			return nil, err
+		}
+	}
+	if err := p.directives(); err != nil {
+		return nil, err
+	}
+
+	// sub-selection
+	if p.is(qcPunct, "{") {
+		children, err := p.selectionSet()
+		if err != nil {
+			return nil, err
+		}
+		sel.children = children
+	}
+	return &sel, nil
+}
+
+// fragment parses a fragment spread, or an inline fragment.
+func (p *qcParser) fragment() (*qcSelection, error) {
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+
+	// named fragment spread
+	if p.tok.kind == qcName && p.tok.val != "on" {
+		sel := qcSelection{spread: p.tok.val}
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		return &sel, p.directives()
+	}
+
+	// inline fragment with optional type condition
+	if p.is(qcName, "on") {
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		if err := p.expect(qcName); err != nil {
+			return nil, err
+		}
+	}
+	if err := p.directives(); err != nil {
+		return nil, err
+	}
+
+	children, err := p.selectionSet()
+	if err != nil {
+		return nil, err
+	}
+	return &qcSelection{children: children}, nil
+}
+
+// arguments parses field arguments and collects the list size argument value.
+func (p *qcParser) arguments(sel *qcSelection) error {
+	if err := p.next(); err != nil {
+		return err
+	}
+
+	for !p.is(qcPunct, ")") {
+		name := p.tok.val
+		if err := p.expect(qcName); err != nil {
+			return err
+		}
+		if !p.is(qcPunct, ":") {
+			return p.unexpected()
+		}
+		if err := p.next(); err != nil {
+			return err
+		}
+
+		// list size argument?
+		if qcListArguments[name] {
+			sel.multiplier = p.listSize()
+		}
+		if err := p.value(); err != nil {
+			return err
+		}
+	}
+	return p.next()
+}
+
+// listSize decodes the list size from the current value token.
+// Unknown values are treated pessimistically as the largest possible list.
+func (p *qcParser) listSize() int {
+	var size int64 = qcMaxListSize
+	switch {
+	case p.tok.kind == qcInt:
+		if v, err := strconv.ParseInt(p.tok.val, 10, 64); err == nil {
+			size = v
+		}
+	case p.is(qcPunct, "$"):
+		if v, ok := p.vars[p.lex.peekName()].(float64); ok {
+			size = int64(v)
+		}
+	}
+
+	// the direction of the list loading does not matter
+	if size < 0 {
+		size = -size
+	}
+	if size == 0 {
+		return 1
+	}
+	if size > qcMaxListSize {
+		return qcMaxListSize
+	}
+	return int(size)
+}
+
+// value skips a single argument value.
+func (p *qcParser) value() error {
+	switch {
+	case p.is(qcPunct, "$"):
+		if err := p.next(); err != nil {
+			return err
+		}
+		return p.expect(qcName)
+	case p.is(qcPunct, "["):
+		return p.skipBalanced("[", "]")
+	case p.is(qcPunct, "{"):
+		return p.skipBalanced("{", "}")
+	case p.tok.kind == qcName, p.tok.kind == qcInt, p.tok.kind == qcFloat, p.tok.kind == qcString:
+		return p.next()
+	}
+	return p.unexpected()
+}
+
+// directives skips any directives attached to the current element.
+func (p *qcParser) directives() error {
+	for p.is(qcPunct, "@") {
+		if err := p.next(); err != nil {
+			return err
+		}
+		if err := p.expect(qcName); err != nil {
+			return err
+		}
+		if p.is(qcPunct, "(") {
+			if err := p.skipBalanced("(", ")"); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// skipBalanced skips tokens up to and including the matching closing bracket.
+func (p *qcParser) skipBalanced(open string, close string) error {
+	level := 0
+	for {
+		switch {
+		case p.tok.kind == qcEOF:
+			return p.unexpected()
+		case p.is(qcPunct, open):
+			level++
+		case p.is(qcPunct, close):
+			level--
+		}
+
+		if err := p.next(); err != nil {
+			return err
+		}
+		if level == 0 {
+			return nil
+		}
+	}
+}
+
+// expect checks the current token kind and advances to the next token.
+func (p *qcParser) expect(kind int) error {
+	if p.tok.kind != kind {
+		return p.unexpected()
+	}
+	return p.next()
+}
+
+// is checks if the current token is of the given kind and value.
+func (p *qcParser) is(kind int, val string) bool {
+	return p.tok.kind == kind && p.tok.val == val
+}
+
+// next advances the parser to the next token.
+func (p *qcParser) next() (err error) {
+	p.tok, err = p.lex.next()
+	return err
+}
+
+// unexpected builds an error for the current unexpected token.
+func (p *qcParser) unexpected() error {
+	if p.tok.kind == qcEOF {
+		return fmt.Errorf("unexpected end of query")
+	}
+	return fmt.Errorf("unexpected %q at position %d", p.tok.val, p.tok.pos)
+}
@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"fmt"
+	"strings"
+)
+
+// GraphQL token kinds recognized by the lexer
+const (
+	qcEOF = iota
+	qcPunct
+	qcName
+	qcInt
+	qcFloat
+	qcString
+)
+
+// qcToken represents a single lexical token of a GraphQL document.
+type qcToken struct {
+	kind int
+	val  string
+	pos  int
+}
+
+// qcLexer implements a simple GraphQL document tokenizer.
+type qcLexer struct {
+	src string
+	pos int
+}
+
+// next provides the next token of the document.
+func (l *qcLexer) next() (qcToken, error) {
+	l.skipIgnored()
+	if l.pos >= len(l.src) {
+		return qcToken{kind: qcEOF, pos: l.pos}, nil
+	}
+
+	start := l.pos
+	c := l.src[l.pos]
+	switch {
+	case strings.HasPrefix(l.src[l.pos:], "..."):
+		l.pos += 3
+		return qcToken{kind: qcPunct, val: "...", pos: start}, nil
+	case strings.IndexByte("!$&()/:=@[]{}|", c) >= 0:
+		l.pos++
+		return qcToken{kind: qcPunct, val: string(c), pos: start}, nil
+	case isQcNameStart(c):
+		l.pos++
+		for l.pos < len(l.src) && (isQcNameStart(l.src[l.pos]) || isQcDigit(l.src[l.pos])) {
+			l.pos++
+		}
+		return qcToken{kind: qcName, val: l.src[start:l.pos], pos: start}, nil
+	case c == '-' || isQcDigit(c):
+		return l.number()
+	case c == '"':
+		return l.string()
+	}
+	return qcToken{}, fmt.Errorf("unexpected character %q at position %d", c, start)
+}
+
+// peekName provides the name token following the current position
+// without advancing the lexer.
+func (l *qcLexer) peekName() string {
+	pos := l.pos
+	tok, err := l.next()
+	l.pos = pos
+
+	if err != nil || tok.kind != qcName {
+		return ""
+	}
+	return tok.val
+}
+
+// number reads an integer, or a float value.
+func (l *qcLexer) number() (qcToken, error) {
+	start := l.pos
+	kind := qcInt
+	if l.src[l.pos] == '-' {
+		l.pos++
+	}
+	for l.pos < len(l.src) {
+		c := l.src[l.pos]
+		switch {
+		case isQcDigit(c):
+		case c == '.' || c == 'e' || c == 'E' || ((c == '+' || c == '-') && kind == qcFloat):
+			kind = qcFloat
+		default:
+			return qcToken{kind: kind, val: l.src[start:l.pos], pos: start}, nil
+		}
+		l.pos++
+	}
+	return qcToken{kind: kind, val: l.src[start:l.pos], pos: start}, nil
+}
+
+// string reads a regular, or a block string value.
+func (l *qcLexer) string() (qcToken, error) {
+	start := l.pos
+
+	// block string
+	if strings.HasPrefix(l.src[l.pos:], `"""`) {
+		end := strings.Index(l.src[l.pos+3:], `"""`)
+		if end < 0 {
+			return qcToken{}, fmt.Errorf("unterminated string at position %d", start)
+		}
+		l.pos += end + 6
+		return qcToken{kind: qcString, val: l.src[start:l.pos], pos: start}, nil
+	}
+
+	// regular string with escape sequences
+	l.pos++
+	for l.pos < len(l.src) {
+		switch l.src[l.pos] {
+		case '\\':
+			l.pos++
+		case '"':
+			l.pos++
+			return qcToken{kind: qcString, val: l.src[start:l.pos], pos: start}, nil
+		case '\n':
+			return qcToken{}, fmt.Errorf("unterminated string at position %d", start)
+		}
+		l.pos++
+	}
+	return qcToken{}, fmt.Errorf("unterminated string at position %d", start)
+}
+
+// skipIgnored skips white space, commas and comments.
+func (l *qcLexer) skipIgnored() {
+	for l.pos < len(l.src) {
+		switch l.src[l.pos] {
+		case ' ', '\t', '\n', '\r', ',':
+			l.pos++
+		case '#':
+			for l.pos < len(l.src) && l.src[l.pos] != '\n' {
+				l.pos++
+			}
+		default:
+			return
+		}
+	}
+}
+
+// isQcNameStart checks if the character can start a GraphQL name.
+func isQcNameStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// isQcDigit checks if the character is a decimal digit.
+func isQcDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
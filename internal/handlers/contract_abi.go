@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fantom-api-graphql/internal/logger"
+	"fantom-api-graphql/internal/repository"
+	"github.com/ethereum/go-ethereum/common"
+	"net/http"
+	"strings"
+)
+
+// ContractAbiPath is the URL path prefix of the contract ABI REST API end-point.
+// The contract address is expected to follow the prefix, i.e. /json/abi/0x...
+const ContractAbiPath = "/json/abi/"
+
+// contractAbiMaxAge is the number of seconds clients may cache the ABI response.
+// Validated ABI does not change, the ETag catches re-validation.
+const contractAbiMaxAge = "public, max-age=3600"
+
+// ContractAbi constructs and return the REST API HTTP handler for validated contract ABI provider.
+// The handler sets the ETag header derived from the ABI content and responds
+// with 304 Not Modified if the client already has the current version.
+func ContractAbi(log logger.Logger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		// decode the contract address from the path
+		addr := strings.TrimPrefix(r.URL.Path, ContractAbiPath)
+		if !common.IsHexAddress(addr) {
+			http.Error(w, "invalid contract address", http.StatusBadRequest)
+			return
+		}
+		adr := common.HexToAddress(addr)
+
+		// get the ABI
+		abi, err := repository.R().ContractAbi(&adr)
+		if err != nil {
+			log.Errorf("can not get ABI of contract %s; %s", adr.String(), err.Error())
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		if abi == "" {
+			http.Error(w, "contract not found or not validated", http.StatusNotFound)
+			return
+		}
+
+		// the ETag is the hash of the ABI content
+		hash := sha256.Sum256([]byte(abi))
+		tag := `"` + hex.EncodeToString(hash[:16]) + `"`
+
+		w.Header().Set("ETag", tag)
+		w.Header().Set("Cache-Control", contractAbiMaxAge)
+		if match := r.Header.Get("If-None-Match"); match != "" && etagMatches(match, tag) {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		// respond
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodHead {
+			return
+		}
+		if _, err := w.Write([]byte(abi)); err != nil {
+			log.Errorf("can not write ABI of contract %s; %s", adr.String(), err.Error())
+		}
+	})
+}
+
+// etagMatches checks if the If-None-Match header value matches the given ETag.
+func etagMatches(header string, tag string) bool {
+	for _, t := range strings.Split(header, ",") {
+		t = strings.TrimPrefix(strings.TrimSpace(t), "W/")
+		if t == tag || t == "*" {
+			return true
+		}
+	}
+	return false
+}
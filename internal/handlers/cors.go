@@ -0,0 +1,52 @@
+// Package handlers holds HTTP/WS handlers chain along with separate middleware implementations.
+package handlers
+
+import (
+	"fantom-api-graphql/internal/config"
+	"fantom-api-graphql/internal/logger"
+	"github.com/rs/cors"
+	"net/http"
+)
+
+const (
+	// CorsGroupPublic identifies the public routes open to any configured origin.
+	CorsGroupPublic = iota
+
+	// CorsGroupAdmin identifies the admin routes restricted to the admin origins.
+	CorsGroupAdmin
+)
+
+// NewCorsHandler wraps the given handler with CORS handling of the given route group.
+// Preflight requests are answered directly and are not passed to the wrapped handler.
+func NewCorsHandler(cfg *config.Config, log logger.Logger, group int, h http.Handler) http.Handler {
+	// attach the logger so we get information on Debug level if needed
+	ch := cors.New(corsOptions(cfg, group))
+	ch.Log = log
+	return ch.Handler(h)
+}
+
+// corsOptions constructs new set of options for the CORS handler
+// of the given route group based on provided configuration.
+func corsOptions(cfg *config.Config, group int) cors.Options {
+	opt := cors.Options{
+		AllowedOrigins: cfg.Server.CorsOrigin,
+		AllowedMethods: []string{http.MethodHead, http.MethodGet, http.MethodPost},
+		AllowedHeaders: []string{"Origin", "Accept", "Content-Type", "X-Requested-With", "Authorization"},
+		ExposedHeaders: []string{"ETag", pageSizeHeader, headerResponseSigner, headerResponseSignature, headerResponseTimestamp},
+		MaxAge:         cfg.Server.CorsMaxAge,
+	}
+
+	// admin routes are available to the admin origins only
+	if group == CorsGroupAdmin {
+		opt.AllowedOrigins = cfg.Server.CorsAdminOrigin
+		opt.AllowedMethods = []string{http.MethodPost}
+		opt.AllowedHeaders = append(opt.AllowedHeaders, CacheBypassHeader)
+		opt.AllowCredentials = cfg.Server.CorsAdminCredentials
+
+		// no origins means no cross origin access; the CORS handler would allow all
+		if len(opt.AllowedOrigins) == 0 {
+			opt.AllowOriginFunc = func(string) bool { return false }
+		}
+	}
+	return opt
+}
@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"fantom-api-graphql/internal/config"
+	"fantom-api-graphql/internal/logger"
+	"github.com/onsi/gomega"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// corsTest represents a single CORS test case.
+type corsTest struct {
+	name      string
+	group     int
+	preflight bool
+	origin    string
+	method    string
+	allowed   bool
+}
+
+// the list of CORS tests; the admin group is limited to sub-domains of example.com
+var corsTests = []corsTest{
+	{name: "public preflight", group: CorsGroupPublic, preflight: true, origin: "https://any.site", method: http.MethodPost, allowed: true},
+	{name: "public request", group: CorsGroupPublic, origin: "https://any.site", method: http.MethodGet, allowed: true},
+	{name: "public preflight of invalid method", group: CorsGroupPublic, preflight: true, origin: "https://any.site", method: http.MethodDelete, allowed: false},
+	{name: "admin preflight", group: CorsGroupAdmin, preflight: true, origin: "https://ops.example.com", method: http.MethodPost, allowed: true},
+	{name: "admin request", group: CorsGroupAdmin, origin: "https://ops.example.com", method: http.MethodPost, allowed: true},
+	{name: "admin preflight of foreign origin", group: CorsGroupAdmin, preflight: true, origin: "https://any.site", method: http.MethodPost, allowed: false},
+	{name: "admin request of foreign origin", group: CorsGroupAdmin, origin: "https://any.site", method: http.MethodPost, allowed: false},
+	{name: "admin request of the wildcard domain", group: CorsGroupAdmin, origin: "https://example.com", method: http.MethodPost, allowed: false},
+	{name: "admin preflight of invalid method", group: CorsGroupAdmin, preflight: true, origin: "https://ops.example.com", method: http.MethodGet, allowed: false},
+}
+
+// okHandler responds with the OK status to any request.
+var okHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+
+// TestCorsHandler tests the CORS handling of the public and the admin route groups.
+func TestCorsHandler(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	cfg := config.Config{Log: config.Log{Level: "CRITICAL", Format: "%{message}"}}
+	cfg.Server.CorsOrigin = []string{"*"}
+	cfg.Server.CorsAdminOrigin = []string{"https://*.example.com"}
+	cfg.Server.CorsAdminCredentials = true
+	cfg.Server.CorsMaxAge = 600
+
+	for _, ct := range corsTests {
+		h := NewCorsHandler(&cfg, logger.New(&cfg), ct.group, okHandler)
+
+		req := httptest.NewRequest(ct.method, "/graphql", nil)
+		if ct.preflight {
+			req = httptest.NewRequest(http.MethodOptions, "/graphql", nil)
+			req.Header.Set("Access-Control-Request-Method", ct.method)
+			req.Header.Set("Access-Control-Request-Headers", "Content-Type")
+		}
+		req.Header.Set("Origin", ct.origin)
+
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+
+		if !ct.allowed {
+			g.Expect(rec.Header().Get("Access-Control-Allow-Origin")).To(gomega.BeEmpty(), ct.name)
+			continue
+		}
+
+		g.Expect(rec.Header().Get("Access-Control-Allow-Origin")).NotTo(gomega.BeEmpty(), ct.name)
+		if ct.preflight {
+			g.Expect(rec.Header().Get("Access-Control-Allow-Methods")).To(gomega.Equal(ct.method), ct.name)
+			g.Expect(rec.Header().Get("Access-Control-Max-Age")).To(gomega.Equal("600"), ct.name)
+		} else {
+			g.Expect(rec.Header().Get("Access-Control-Expose-Headers")).To(gomega.ContainSubstring(pageSizeHeader), ct.name)
+		}
+		if ct.group == CorsGroupAdmin {
+			g.Expect(rec.Header().Get("Access-Control-Allow-Origin")).To(gomega.Equal(ct.origin), ct.name)
+			g.Expect(rec.Header().Get("Access-Control-Allow-Credentials")).To(gomega.Equal("true"), ct.name)
+		}
+	}
+}
+
+// TestCorsHandlerNoAdminOrigins tests that admin routes without origins deny cross origin access.
+func TestCorsHandlerNoAdminOrigins(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	cfg := config.Config{Log: config.Log{Level: "CRITICAL", Format: "%{message}"}}
+	h := NewCorsHandler(&cfg, logger.New(&cfg), CorsGroupAdmin, okHandler)
+
+	req := httptest.NewRequest(http.MethodPost, "/admin", nil)
+	req.Header.Set("Origin", "https://any.site")
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	g.Expect(rec.Header().Get("Access-Control-Allow-Origin")).To(gomega.BeEmpty())
+}
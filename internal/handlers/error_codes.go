@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"fantom-api-graphql/internal/graphql/resolvers"
+	"github.com/graph-gophers/graphql-go/errors"
+)
+
+// errCodeExtension is the name of the GraphQL error extension carrying the error code.
+const errCodeExtension = "code"
+
+// classifyErrors adds the error code extension to the query errors
+// recognized by the resolvers. Existing error codes are kept.
+func classifyErrors(errs []*errors.QueryError) {
+	for _, qe := range errs {
+		if qe == nil {
+			continue
+		}
+		if _, ok := qe.Extensions[errCodeExtension]; ok {
+			continue
+		}
+
+		err := qe.ResolverError
+		if err == nil {
+			err = qe.Err
+		}
+
+		code := resolvers.ErrorCode(err)
+		if code == "" {
+			continue
+		}
+
+		if qe.Extensions == nil {
+			qe.Extensions = make(map[string]interface{}, 1)
+		}
+		qe.Extensions[errCodeExtension] = code
+	}
+}
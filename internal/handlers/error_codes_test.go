@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository"
+	"fmt"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/graph-gophers/graphql-go/errors"
+	"github.com/onsi/gomega"
+	"testing"
+)
+
+// TestClassifyErrors tests query errors receive the code of the failure they wrap.
+func TestClassifyErrors(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	tests := []struct {
+		name string
+		qe   *errors.QueryError
+		code string
+	}{
+		{name: "block not found", qe: &errors.QueryError{ResolverError: repository.ErrBlockNotFound}, code: "NOT_FOUND"},
+		{name: "wrapped invalid cursor", qe: &errors.QueryError{ResolverError: fmt.Errorf("%w; 0x1", repository.ErrInvalidCursor)}, code: "INVALID_CURSOR"},
+		{name: "deadline", qe: &errors.QueryError{ResolverError: context.DeadlineExceeded}, code: "TIMEOUT"},
+		{name: "node rate limit", qe: &errors.QueryError{ResolverError: rpc.HTTPError{StatusCode: 429}}, code: "RATE_LIMITED"},
+		{name: "node failure", qe: &errors.QueryError{ResolverError: rpc.HTTPError{StatusCode: 502}}, code: "NODE_UNAVAILABLE"},
+		{name: "unknown error", qe: &errors.QueryError{ResolverError: fmt.Errorf("something failed")}, code: ""},
+		{name: "existing code kept", qe: &errors.QueryError{ResolverError: repository.ErrBlockNotFound, Extensions: map[string]interface{}{"code": "CUSTOM"}}, code: "CUSTOM"},
+	}
+
+	for _, tc := range tests {
+		classifyErrors([]*errors.QueryError{tc.qe})
+		code, _ := tc.qe.Extensions[errCodeExtension].(string)
+		g.Expect(code).To(gomega.Equal(tc.code), tc.name)
+	}
+}
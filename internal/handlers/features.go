@@ -0,0 +1,248 @@
+package handlers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/config"
+	"fantom-api-graphql/internal/graphql/resolvers"
+	"fantom-api-graphql/internal/logger"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// API feature groups which can be disabled by the configuration.
+const (
+	featureStaking   = "staking"
+	featureDeFi      = "defi"
+	featureTokens    = "tokens"
+	featureMutations = "mutations"
+	featureAdmin     = "admin"
+	featureTrace     = "trace"
+)
+
+// featureFields maps the root fields of the schema to the feature groups they belong to.
+// A field belonging to several groups is available only if all of them are enabled.
+// Root fields not listed here are always available.
+var featureFields = map[string][]string{
+	// staking
+	"sfcConfig":                 {featureStaking},
+	"lastStakerId":              {featureStaking},
+	"stakersNum":                {featureStaking},
+	"staker":                    {featureStaking},
+	"stakers":                   {featureStaking},
+	"stakersWithFlag":           {featureStaking},
+	"delegationsOf":             {featureStaking},
+	"delegation":                {featureStaking},
+	"delegationsByAddress":      {featureStaking},
+	"estimateRewards":           {featureStaking},
+	"sfcRewardsCollectedAmount": {featureStaking},
+	"rewardClaims":              {featureStaking},
+	"validatorDelegations":      {featureStaking},
+	"stakingApr":                {featureStaking},
+	"stakingParams":             {featureStaking},
+
+	// defi
+	"defiConfiguration":         {featureDeFi},
+	"defiTokens":                {featureDeFi},
+	"defiNativeToken":           {featureDeFi},
+	"fMintAccount":              {featureDeFi},
+	"fMintTokenAllowance":       {featureDeFi},
+	"fMintUserTokens":           {featureDeFi},
+	"defiUniswapPairs":          {featureDeFi},
+	"defiUniswapPair":           {featureDeFi},
+	"defiUniswapTokenPrice":     {featureDeFi},
+	"defiUniswapAmountsOut":     {featureDeFi},
+	"defiUniswapAmountsIn":      {featureDeFi},
+	"defiUniswapQuoteLiquidity": {featureDeFi},
+	"defiUniswapVolumes":        {featureDeFi},
+	"defiTimeVolumes":           {featureDeFi},
+	"defiTimePrices":            {featureDeFi},
+	"defiTimeReserves":          {featureDeFi},
+	"tokenPriceHistory":         {featureDeFi},
+	"defiUniswapActions":        {featureDeFi},
+	"defiUniswapSwaps":          {featureDeFi},
+	"fLendLendingPool":          {featureDeFi},
+
+	// tokens
+	"erc20Transactions":     {featureTokens},
+	"erc721Transactions":    {featureTokens},
+	"erc1155Transactions":   {featureTokens},
+	"erc20Token":            {featureTokens},
+	"erc20TokenList":        {featureTokens},
+	"erc20Assets":           {featureTokens},
+	"ercTotalSupply":        {featureTokens},
+	"ercTokenBalance":       {featureTokens},
+	"ercTokenAllowance":     {featureTokens},
+	"erc721Contract":        {featureTokens},
+	"erc721ContractList":    {featureTokens},
+	"erc1155Contract":       {featureTokens},
+	"erc1155ContractList":   {featureTokens},
+	"accountTokenTransfers": {featureTokens},
+	"accountPortfolio":      {featureTokens},
+	"tokenApprovals":        {featureTokens},
+	"topTokens":             {featureTokens},
+
+	// admin
+	"resultCacheStats": {featureAdmin},
+	"denylistStats":    {featureAdmin},
+	"webhooks":         {featureAdmin},
+	"diagnostics":      {featureAdmin},
+
+	// trace
+	"internalTransactions": {featureTrace},
+
+	// mutations
+	"sendTransaction":           {featureMutations},
+	"sendRawTransaction":        {featureMutations},
+	"validateContract":          {featureMutations},
+	"backfillContractCreations": {featureMutations, featureAdmin},
+	"importContracts":           {featureMutations, featureAdmin},
+	"registerWebhook":           {featureMutations, featureAdmin},
+	"removeWebhook":             {featureMutations, featureAdmin},
+	"classifyContract":          {featureMutations, featureAdmin},
+	"refreshContract":           {featureMutations, featureAdmin},
+}
+
+// adminFields provides the set of root fields of the admin feature group.
+// They are served on the admin route only, the public schema does not contain them.
+func adminFields() map[string]bool {
+	fields := make(map[string]bool)
+	for name, groups := range featureFields {
+		for _, group := range groups {
+			if group == featureAdmin {
+				fields[name] = true
+			}
+		}
+	}
+	return fields
+}
+
+// FeatureHandler defines HTTP handler middleware rejecting GraphQL queries
+// using root fields of the feature groups disabled by the configuration.
+type FeatureHandler struct {
+	logger   logger.Logger
+	handler  http.Handler
+	disabled map[string]bool
+}
+
+// NewFeatureHandler creates a new feature rejecting middleware for the given handler.
+func NewFeatureHandler(cfg *config.Config, log logger.Logger, h http.Handler) http.Handler {
+	disabled := disabledFeatures(&cfg.Features)
+
+	// nothing to reject
+	if len(disabled) == 0 {
+		return h
+	}
+
+	return &FeatureHandler{
+		logger:   log,
+		handler:  h,
+		disabled: disabled,
+	}
+}
+
+// disabledFeatures provides the set of feature groups disabled by the configuration.
+func disabledFeatures(cfg *config.Features) map[string]bool {
+	groups := map[string]bool{
+		featureStaking:   cfg.Staking,
+		featureDeFi:      cfg.DeFi,
+		featureTokens:    cfg.Tokens,
+		featureMutations: cfg.Mutations,
+		featureAdmin:     cfg.Admin,
+		featureTrace:     cfg.Trace,
+	}
+
+	disabled := make(map[string]bool)
+	for name, enabled := range groups {
+		if !enabled {
+			disabled[name] = true
+		}
+	}
+	return disabled
+}
+
+// ServeHTTP rejects the incoming query if it uses a disabled feature.
+func (h *FeatureHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// the GraphQL handler decodes the body of any method, so we analyze all of them
+	if !hasRequestBody(r) {
+		h.handler.ServeHTTP(w, r)
+		return
+	}
+
+	req, err := readGraphQLRequest(w, r)
+	if err != nil {
+		h.logger.Errorf("can not read request body; %s", err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// queries we can not analyze may use a disabled feature
+	if req == nil {
+		h.logger.Debugf("query from %s rejected; invalid request payload", r.RemoteAddr)
+		writeGraphQLError(w, errInvalidRequest)
+		return
+	}
+
+	if err := h.check(r.Context(), req); err != nil {
+		h.logger.Debugf("query from %s rejected; %s", r.RemoteAddr, err.Error())
+		writeGraphQLError(w, err)
+		return
+	}
+	h.handler.ServeHTTP(w, r)
+}
+
+// check verifies the query does not use any root field of a disabled feature group.
+// Queries which can not be analyzed are rejected, they may use a disabled feature.
+func (h *FeatureHandler) check(_ context.Context, req *gqlRequest) error {
+	fields, err := disabledFields(req.Query, h.disabled)
+	if err != nil {
+		return fmt.Errorf("query can not be analyzed; %s", err.Error())
+	}
+	if len(fields) > 0 {
+		return fmt.Errorf("%w; %s", resolvers.ErrFeatureDisabled, strings.Join(fields, ", "))
+	}
+	return nil
+}
+
+// disabledFields lists the root fields of the given query belonging to a disabled feature group.
+func disabledFields(query string, disabled map[string]bool) ([]string, error) {
+	doc, err := parseQueryDocument(query, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	found := make(map[string]bool)
+	doc.disabledFields(doc.anonymous, disabled, found, 0)
+	for _, set := range doc.operations {
+		doc.disabledFields(set, disabled, found, 0)
+	}
+
+	list := make([]string, 0, len(found))
+	for name := range found {
+		list = append(list, name)
+	}
+	sort.Strings(list)
+	return list, nil
+}
+
+// disabledFields collects the root fields of the selection set belonging to a disabled feature group.
+// Fragments are followed since they may be spread directly on the root type.
+func (doc *qcDocument) disabledFields(set []*qcSelection, disabled map[string]bool, found map[string]bool, nesting int) {
+	for _, sel := range set {
+		switch {
+		case sel.isField:
+			for _, group := range featureFields[sel.name] {
+				if disabled[group] {
+					found[sel.name] = true
+				}
+			}
+		case sel.spread != "":
+			if nesting < qcMaxFragmentNesting {
+				doc.disabledFields(doc.fragments[sel.spread], disabled, found, nesting+1)
+			}
+		default:
+			doc.disabledFields(sel.children, disabled, found, nesting)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fantom-api-graphql/internal/config"
+	"fantom-api-graphql/internal/graphql/resolvers"
+	gqlSchema "fantom-api-graphql/internal/graphql/schema"
+	"fantom-api-graphql/internal/logger"
+	"github.com/graph-gophers/graphql-go"
+	"github.com/onsi/gomega"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// TestFeatureFieldsExist tests all the feature fields are root fields of the schema.
+func TestFeatureFieldsExist(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	sdl := gqlSchema.Schema()
+	for name := range featureFields {
+		g.Expect(regexp.MustCompile(`(?m)^\s+`+name+`\s*[(:]`).MatchString(sdl)).To(gomega.BeTrue(), name)
+	}
+}
+
+// TestPublicSchema tests the administrative fields are removed from the public schema only.
+func TestPublicSchema(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	admin := adminFields()
+	g.Expect(admin).To(gomega.HaveKey("importContracts"))
+	g.Expect(admin).To(gomega.HaveKey("diagnostics"))
+
+	sdl := gqlSchema.WithoutRootFields(gqlSchema.Schema(), admin)
+	_, err := graphql.ParseSchema(sdl, nil)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+
+	for name := range featureFields {
+		g.Expect(regexp.MustCompile(`(?m)^\s+`+name+`\s*[(:]`).MatchString(sdl)).To(gomega.Equal(!admin[name]), name)
+	}
+}
+
+// TestDisabledFields tests detection of the disabled root fields in queries.
+func TestDisabledFields(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	disabled := map[string]bool{featureStaking: true, featureAdmin: true}
+	tests := []struct {
+		query  string
+		expect []string
+	}{
+		{`{ block { number } }`, []string{}},
+		{`{ staker(id: "0x1") { id } }`, []string{"staker"}},
+		{`{ s: stakers { id } block { number } }`, []string{"stakers"}},
+		{`query Q { ...F } fragment F on Query { delegation(address: "0x1", staker: "0x1") { amount } }`, []string{"delegation"}},
+		{`mutation { sendTransaction(tx: "0x00") { hash } }`, []string{}},
+		{`mutation { importContracts(contracts: []) }`, []string{"importContracts"}},
+		{`{ account(address: "0x1") { delegations { totalCount } } }`, []string{}},
+	}
+
+	for _, tc := range tests {
+		fields, err := disabledFields(tc.query, disabled)
+		g.Expect(err).NotTo(gomega.HaveOccurred(), tc.query)
+		g.Expect(fields).To(gomega.Equal(tc.expect), tc.query)
+	}
+
+	_, err := disabledFields(`{ broken `, disabled)
+	g.Expect(err).To(gomega.HaveOccurred())
+}
+
+// TestFeatureHandler tests queries using disabled features are rejected with the error code.
+func TestFeatureHandler(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	log := logger.New(&config.Config{Log: config.Log{Level: "CRITICAL", Format: "%{message}"}})
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":{}}`))
+	})
+
+	serveMethod := func(cfg *config.Config, method string, query string) string {
+		rec := httptest.NewRecorder()
+		NewFeatureHandler(cfg, log, next).ServeHTTP(rec, httptest.NewRequest(method, "/api", strings.NewReader(`{"query":"`+query+`"}`)))
+		return rec.Body.String()
+	}
+	serve := func(cfg *config.Config, query string) string {
+		return serveMethod(cfg, http.MethodPost, query)
+	}
+
+	cfg := &config.Config{Features: config.Features{Staking: true, DeFi: true, Tokens: true, Mutations: true, Admin: true, Trace: true}}
+	g.Expect(serve(cfg, "{ defiTokens { address } }")).To(gomega.Equal(`{"data":{}}`))
+
+	cfg.Features.DeFi = false
+	g.Expect(serve(cfg, "{ defiTokens { address } }")).To(gomega.ContainSubstring(resolvers.ErrFeatureDisabled.Error()))
+	g.Expect(serve(cfg, "{ defiTokens { address } }")).To(gomega.ContainSubstring(`"code":"FEATURE_DISABLED"`))
+	g.Expect(serve(cfg, "{ block { number } }")).To(gomega.Equal(`{"data":{}}`))
+
+	// the GraphQL handler decodes the body of any method
+	g.Expect(serveMethod(cfg, http.MethodGet, "{ defiTokens { address } }")).To(gomega.ContainSubstring(`"code":"FEATURE_DISABLED"`))
+	g.Expect(serveMethod(cfg, http.MethodPut, "{ defiTokens { address } }")).To(gomega.ContainSubstring(`"code":"FEATURE_DISABLED"`))
+
+	// queries which can not be analyzed never reach the GraphQL handler
+	g.Expect(serve(cfg, "{ defiTokens { address ")).To(gomega.ContainSubstring("query can not be analyzed"))
+
+	rec := httptest.NewRecorder()
+	NewFeatureHandler(cfg, log, next).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api", strings.NewReader(`{"query": "{ defiTokens`)))
+	g.Expect(rec.Body.String()).To(gomega.ContainSubstring(errInvalidRequest.Error()))
+}
+
+// TestWsServiceFeatures tests WebSocket operations using disabled features are rejected.
+func TestWsServiceFeatures(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	log := logger.New(&config.Config{Log: config.Log{Level: "CRITICAL", Format: "%{message}"}})
+	cfg := &config.Config{Features: config.Features{Staking: true, Tokens: true, Mutations: true, Admin: true, Trace: true}}
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	ws := newWsService(log, nil, NewFeatureHandler(cfg, log, next), next)
+	g.Expect(ws.checks).To(gomega.HaveLen(1))
+
+	_, err := ws.Subscribe(context.Background(), "{ defiTokens { address } }", "", nil)
+	g.Expect(errors.Is(err, resolvers.ErrFeatureDisabled)).To(gomega.BeTrue())
+}
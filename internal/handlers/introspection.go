@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/config"
+	"fantom-api-graphql/internal/logger"
+	"fmt"
+	"net/http"
+)
+
+// qcIntrospectionFields lists the meta fields of the schema introspection.
+var qcIntrospectionFields = map[string]bool{"__schema": true, "__type": true}
+
+// errIntrospectionDisabled is returned for queries using the schema introspection if it's disabled.
+var errIntrospectionDisabled = fmt.Errorf("schema introspection is disabled on this server")
+
+// IntrospectionHandler defines HTTP handler middleware rejecting GraphQL queries
+// using the schema introspection if it's disabled by the configuration.
+type IntrospectionHandler struct {
+	logger  logger.Logger
+	handler http.Handler
+}
+
+// NewIntrospectionHandler creates a new introspection rejecting middleware for the given handler.
+func NewIntrospectionHandler(cfg *config.Config, log logger.Logger, h http.Handler) http.Handler {
+	// nothing to reject
+	if cfg.Server.AllowIntrospection {
+		return h
+	}
+
+	return &IntrospectionHandler{
+		logger:  log,
+		handler: h,
+	}
+}
+
+// ServeHTTP rejects the incoming query if it uses the schema introspection.
+func (h *IntrospectionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// the GraphQL handler decodes the body of any method, so we analyze all of them
+	if !hasRequestBody(r) {
+		h.handler.ServeHTTP(w, r)
+		return
+	}
+
+	req, err := readGraphQLRequest(w, r)
+	if err != nil {
+		h.logger.Errorf("can not read request body; %s", err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if req != nil && h.check(r.Context(), req) != nil {
+		h.logger.Debugf("introspection query from %s rejected", r.RemoteAddr)
+		writeGraphQLError(w, errIntrospectionDisabled)
+		return
+	}
+	h.handler.ServeHTTP(w, r)
+}
+
+// check verifies the query does not use the schema introspection.
+func (h *IntrospectionHandler) check(_ context.Context, req *gqlRequest) error {
+	if usesIntrospection(req.Query) {
+		return errIntrospectionDisabled
+	}
+	return nil
+}
+
+// usesIntrospection checks if any operation, or fragment of the given query
+// selects the schema introspection fields. Queries which can not be parsed
+// are left for the GraphQL handler; the schema itself has the introspection disabled.
+func usesIntrospection(query string) bool {
+	doc, err := parseQueryDocument(query, nil)
+	if err != nil {
+		return false
+	}
+
+	if hasIntrospectionField(doc.anonymous) {
+		return true
+	}
+	for _, set := range doc.operations {
+		if hasIntrospectionField(set) {
+			return true
+		}
+	}
+	for _, set := range doc.fragments {
+		if hasIntrospectionField(set) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasIntrospectionField checks the selection set for the introspection fields recursively.
+func hasIntrospectionField(set []*qcSelection) bool {
+	for _, sel := range set {
+		if sel.isField && qcIntrospectionFields[sel.name] {
+			return true
+		}
+		if hasIntrospectionField(sel.children) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"fantom-api-graphql/internal/config"
+	"fantom-api-graphql/internal/logger"
+	"github.com/onsi/gomega"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestUsesIntrospection tests detection of the introspection fields in queries.
+func TestUsesIntrospection(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	tests := []struct {
+		query  string
+		expect bool
+	}{
+		{`{ block { number } }`, false},
+		{`{ block { __typename number } }`, false},
+		{`{ __schema { types { name } } }`, true},
+		{`query Q { __type(name: "Block") { name } }`, true},
+		{`{ s: __schema { queryType { name } } }`, true},
+		{`query Q { ...F } fragment F on Query { __schema { types { name } } }`, true},
+		{`{ block { ... on Block { number } } __type(name: "Account") { name } }`, true},
+		{`{ broken `, false},
+	}
+
+	for _, tc := range tests {
+		g.Expect(usesIntrospection(tc.query)).To(gomega.Equal(tc.expect), tc.query)
+	}
+}
+
+// TestIntrospectionHandler tests introspection queries are rejected only if the introspection is disabled.
+func TestIntrospectionHandler(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	log := logger.New(&config.Config{Log: config.Log{Level: "CRITICAL", Format: "%{message}"}})
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":{}}`))
+	})
+
+	serve := func(cfg *config.Config, query string) string {
+		rec := httptest.NewRecorder()
+		NewIntrospectionHandler(cfg, log, next).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api", strings.NewReader(`{"query":"`+query+`"}`)))
+		return rec.Body.String()
+	}
+
+	cfg := &config.Config{}
+	g.Expect(serve(cfg, "{ __schema { types { name } } }")).To(gomega.ContainSubstring(errIntrospectionDisabled.Error()))
+	g.Expect(serve(cfg, "{ block { number } }")).To(gomega.Equal(`{"data":{}}`))
+
+	cfg.Server.AllowIntrospection = true
+	g.Expect(serve(cfg, "{ __schema { types { name } } }")).To(gomega.Equal(`{"data":{}}`))
+}
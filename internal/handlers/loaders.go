@@ -0,0 +1,20 @@
+package handlers
+
+import (
+	"fantom-api-graphql/internal/graphql/resolvers"
+	"net/http"
+	"strings"
+)
+
+// NewLoadersHandler creates a middleware attaching a fresh set of data loaders
+// to each request so resolvers of the same request can batch and share
+// their account and contract lookups. WebSocket connections are skipped,
+// the loaders would otherwise cache data for the whole life of the connection.
+func NewLoadersHandler(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.EqualFold(r.Header.Get("Upgrade"), "websocket") {
+			r = r.WithContext(resolvers.WithLoaders(r.Context()))
+		}
+		h.ServeHTTP(w, r)
+	})
+}
@@ -1,22 +1,229 @@
 package handlers
 
 import (
+	"context"
+	"fantom-api-graphql/internal/config"
 	flogger "fantom-api-graphql/internal/logger"
+	"fmt"
+	"math/rand"
+	"net"
 	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/graph-gophers/graphql-go/errors"
+	"github.com/graph-gophers/graphql-go/introspection"
+	"github.com/graph-gophers/graphql-go/trace"
 )
 
+// logMaxSlowResolvers is the max number of slow resolvers reported with a request.
+const logMaxSlowResolvers = 3
+
 // LoggingHandler defines HTTP handler middleware for logging incoming communication through provided Logger.
 type LoggingHandler struct {
-	logger  flogger.Logger
-	handler http.Handler
+	logger       flogger.Logger
+	handler      http.Handler
+	logf         func(string, ...interface{})
+	sampling     float64
+	slowResolver time.Duration
+}
+
+// requestTrace collects details of a single request processing.
+type requestTrace struct {
+	mu        sync.Mutex
+	operation string
+	errors    int
+	resolvers []resolverTiming
+}
+
+// resolverTiming represents the execution time of a single resolver.
+type resolverTiming struct {
+	field    string
+	duration time.Duration
+}
+
+// responseRecorder captures the status and the size of the response.
+type responseRecorder struct {
+	http.ResponseWriter
+	status int
+	size   int
+}
+
+// ctxKeyRequestTrace is the context key of the request trace.
+type ctxKeyRequestTrace struct{}
+
+// NewLoggingHandler creates a new request logging middleware for the given handler.
+func NewLoggingHandler(cfg *config.Config, log flogger.Logger, h http.Handler) *LoggingHandler {
+	return &LoggingHandler{
+		logger:       log,
+		handler:      h,
+		logf:         requestLogFunc(cfg.Log.RequestLevel, log),
+		sampling:     cfg.Log.RequestSampling,
+		slowResolver: time.Duration(cfg.Log.SlowResolver) * time.Millisecond,
+	}
 }
 
 // ServeHTTP handles incoming request by creating a log record with predefined request details
 // and passing it to the next handler in the chain.
 func (h *LoggingHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	// We log incoming requests on Debug level since in production the actual incoming traffic is not very important.
-	h.logger.Debugf("[%s <- %s] %s %s (%s)", r.Proto, r.RemoteAddr, r.Method, r.URL, r.UserAgent())
+	// web socket connections are long living, we log them on connect only
+	if strings.EqualFold(r.Header.Get("Upgrade"), "websocket") {
+		h.logger.Debugf("[%s <- %s] %s %s (%s)", r.Proto, r.RemoteAddr, r.Method, r.URL, r.UserAgent())
+		h.handler.ServeHTTP(w, r)
+		return
+	}
+
+	// pass request down the chain with the trace collector attached
+	rt := new(requestTrace)
+	rec := &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+	start := time.Now()
+	h.handler.ServeHTTP(rec, r.WithContext(context.WithValue(r.Context(), ctxKeyRequestTrace{}, rt)))
+	dur := time.Since(start)
+
+	// errors are always logged, regular requests are sampled
+	failed := rt.errors > 0 || rec.status >= http.StatusBadRequest
+	if !failed && h.sampling < 1 && rand.Float64() >= h.sampling {
+		return
+	}
+
+	h.logf("method=%s path=%s op=%s status=%d size=%d duration=%s ip=%s error=%t%s",
+		r.Method, r.URL.Path, rt.operationName(), rec.status, rec.size,
+		dur.Round(time.Microsecond), clientIP(r), failed, rt.slowResolvers(h.slowResolver))
+}
+
+// WriteHeader captures the response status code.
+func (rec *responseRecorder) WriteHeader(code int) {
+	rec.status = code
+	rec.ResponseWriter.WriteHeader(code)
+}
+
+// Write captures the response size.
+func (rec *responseRecorder) Write(b []byte) (int, error) {
+	n, err := rec.ResponseWriter.Write(b)
+	rec.size += n
+	return n, err
+}
+
+// operationName provides the GraphQL operation name of the request.
+func (rt *requestTrace) operationName() string {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	if rt.operation == "" {
+		return "-"
+	}
+	return rt.operation
+}
+
+// slowResolvers formats the slowest resolvers exceeding the given threshold.
+func (rt *requestTrace) slowResolvers(threshold time.Duration) string {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	if len(rt.resolvers) == 0 {
+		return ""
+	}
+
+	sort.Slice(rt.resolvers, func(i, j int) bool {
+		return rt.resolvers[i].duration > rt.resolvers[j].duration
+	})
+
+	var sb strings.Builder
+	for i, rs := range rt.resolvers {
+		if i >= logMaxSlowResolvers || rs.duration < threshold {
+			break
+		}
+		if i == 0 {
+			sb.WriteString(" slow=")
+		} else {
+			sb.WriteString(",")
+		}
+		sb.WriteString(fmt.Sprintf("%s:%s", rs.field, rs.duration.Round(time.Microsecond)))
+	}
+	return sb.String()
+}
+
+// RequestTracer implements GraphQL tracer collecting operation details
+// and resolver timing for the request logging. Each resolver runs with the deadline
+// of its configured time limit.
+type RequestTracer struct {
+	timeouts *resolverTimeouts
+}
+
+// TraceQuery captures the operation name and errors of the GraphQL query.
+func (RequestTracer) TraceQuery(ctx context.Context, _ string, operationName string, _ map[string]interface{}, _ map[string]*introspection.Type) (context.Context, trace.TraceQueryFinishFunc) {
+	rt, ok := ctx.Value(ctxKeyRequestTrace{}).(*requestTrace)
+	if !ok {
+		return ctx, classifyErrors
+	}
+
+	rt.mu.Lock()
+	rt.operation = operationName
+	rt.mu.Unlock()
+
+	return ctx, func(errs []*errors.QueryError) {
+		classifyErrors(errs)
+
+		rt.mu.Lock()
+		rt.errors += len(errs)
+		rt.mu.Unlock()
+	}
+}
+
+// TraceField measures execution time of non-trivial resolvers
+// and limits them by their configured time limit.
+func (t RequestTracer) TraceField(ctx context.Context, _ string, typeName string, fieldName string, trivial bool, args map[string]interface{}) (context.Context, trace.TraceFieldFinishFunc) {
+	if trivial {
+		return ctx, func(*errors.QueryError) {}
+	}
+
+	cancel := func() {}
+	if t.timeouts != nil {
+		ctx, cancel = t.timeouts.withDeadline(ctx, fieldName)
+	}
+
+	rt, ok := ctx.Value(ctxKeyRequestTrace{}).(*requestTrace)
+	if !ok {
+		return ctx, func(*errors.QueryError) { cancel() }
+	}
+
+	start := time.Now()
+	return ctx, func(*errors.QueryError) {
+		dur := time.Since(start)
+		cancel()
+
+		rt.mu.Lock()
+		rt.resolvers = append(rt.resolvers, resolverTiming{field: typeName + "." + fieldName, duration: dur})
+		rt.mu.Unlock()
+	}
+}
+
+// clientIP extracts the client IP address of the request.
+func clientIP(r *http.Request) string {
+	if fwd := r.Header.Get("X-Forwarded-For"); fwd != "" {
+		return strings.TrimSpace(strings.Split(fwd, ",")[0])
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
 
-	// Pass request down the chain
-	h.handler.ServeHTTP(w, r)
+// requestLogFunc provides the logger function for the configured request log level.
+func requestLogFunc(level string, log flogger.Logger) func(string, ...interface{}) {
+	switch strings.ToUpper(level) {
+	case "ERROR":
+		return log.Errorf
+	case "WARNING":
+		return log.Warningf
+	case "NOTICE":
+		return log.Noticef
+	case "INFO":
+		return log.Infof
+	}
+	return log.Debugf
 }
@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"fantom-api-graphql/internal/graphql/resolvers"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// pageSizeHeader is the response header reporting the page size
+// applied to list pages requested over their limit.
+const pageSizeHeader = "X-Page-Size-Clamped"
+
+// PageSizeHandler defines HTTP handler middleware reporting list pages
+// clamped by the list resolvers back to the client.
+type PageSizeHandler struct {
+	handler http.Handler
+}
+
+// pageSizeWriter sets the page size header before the response is written.
+type pageSizeWriter struct {
+	http.ResponseWriter
+	report      *resolvers.PageSizeReport
+	wroteHeader bool
+}
+
+// NewPageSizeHandler creates a new page size reporting middleware for the given handler.
+func NewPageSizeHandler(h http.Handler) http.Handler {
+	return &PageSizeHandler{handler: h}
+}
+
+// ServeHTTP attaches the page size report to the request and passes it down the chain.
+func (h *PageSizeHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// web socket subscriptions can not report via headers
+	if strings.EqualFold(r.Header.Get("Upgrade"), "websocket") {
+		h.handler.ServeHTTP(w, r)
+		return
+	}
+
+	ctx, rep := resolvers.WithPageSizeReport(r.Context())
+	h.handler.ServeHTTP(&pageSizeWriter{ResponseWriter: w, report: rep}, r.WithContext(ctx))
+}
+
+// WriteHeader adds the page size header to the response.
+func (psw *pageSizeWriter) WriteHeader(code int) {
+	if !psw.wroteHeader {
+		psw.wroteHeader = true
+		if v := psw.report.Clamped(); v > 0 {
+			psw.Header().Set(pageSizeHeader, strconv.FormatUint(uint64(v), 10))
+		}
+	}
+	psw.ResponseWriter.WriteHeader(code)
+}
+
+// Write makes sure the page size header is added before the body.
+func (psw *pageSizeWriter) Write(b []byte) (int, error) {
+	if !psw.wroteHeader {
+		psw.WriteHeader(http.StatusOK)
+	}
+	return psw.ResponseWriter.Write(b)
+}
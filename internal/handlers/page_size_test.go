@@ -0,0 +1,21 @@
+package handlers
+
+import (
+	"github.com/onsi/gomega"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestPageSizeHandler tests the page size header is sent only if a list page was clamped.
+func TestPageSizeHandler(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	h := NewPageSizeHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("{}"))
+	}))
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api", nil))
+	g.Expect(rec.Header()).NotTo(gomega.HaveKey(pageSizeHeader))
+}
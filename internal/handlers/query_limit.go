@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fantom-api-graphql/internal/auth"
+	"fantom-api-graphql/internal/config"
+	"fantom-api-graphql/internal/graphql/resolvers"
+	"fantom-api-graphql/internal/logger"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+)
+
+// QueryLimitHandler defines HTTP handler middleware rejecting GraphQL queries
+// exceeding configured depth and complexity before they are executed.
+type QueryLimitHandler struct {
+	logger        logger.Logger
+	handler       http.Handler
+	maxDepth      int
+	maxComplexity int
+}
+
+// maxRequestBodySize is the max size of the GraphQL request payload we read in bytes.
+const maxRequestBodySize = 1 << 20
+
+// errInvalidRequest is returned for request payloads which can not be decoded.
+var errInvalidRequest = fmt.Errorf("invalid GraphQL request payload")
+
+// gqlRequest represents the GraphQL request payload we analyze.
+type gqlRequest struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// NewQueryLimitHandler creates a new query limiting middleware for the given handler.
+func NewQueryLimitHandler(cfg *config.Config, log logger.Logger, h http.Handler) http.Handler {
+	// no limits? no need to analyze anything
+	if cfg.Server.MaxQueryDepth <= 0 && cfg.Server.MaxQueryComplexity <= 0 {
+		return h
+	}
+
+	return &QueryLimitHandler{
+		logger:        log,
+		handler:       h,
+		maxDepth:      cfg.Server.MaxQueryDepth,
+		maxComplexity: cfg.Server.MaxQueryComplexity,
+	}
+}
+
+// ServeHTTP analyzes the incoming query and passes it down the chain only if it fits the limits.
+func (h *QueryLimitHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// the GraphQL handler decodes the body of any method, so we analyze all of them
+	if !hasRequestBody(r) {
+		h.handler.ServeHTTP(w, r)
+		return
+	}
+
+	req, err := readGraphQLRequest(w, r)
+	if err != nil {
+		h.logger.Errorf("can not read request body; %s", err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// queries we can not analyze are not executed
+	if req == nil {
+		h.logger.Warningf("query from %s rejected; invalid request payload", r.RemoteAddr)
+		writeGraphQLError(w, errInvalidRequest)
+		return
+	}
+
+	if err := h.check(r.Context(), req); err != nil {
+		h.logger.Warningf("query from %s rejected; %s", r.RemoteAddr, err.Error())
+		writeGraphQLError(w, err)
+		return
+	}
+	h.handler.ServeHTTP(w, r)
+}
+
+// hasRequestBody checks if the request carries a body the GraphQL handler would decode.
+func hasRequestBody(r *http.Request) bool {
+	return r.Body != nil && r.Body != http.NoBody
+}
+
+// readGraphQLRequest reads and decodes the GraphQL request payload and restores the body
+// for the next handler. Nil request is returned for payloads which can not be decoded.
+// Payloads larger than maxRequestBodySize are not read.
+func readGraphQLRequest(w http.ResponseWriter, r *http.Request) (*gqlRequest, error) {
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxRequestBodySize))
+	if err != nil {
+		return nil, err
+	}
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	var req gqlRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		return nil, nil
+	}
+	return &req, nil
+}
+
+// check verifies the query depth and complexity against the configured limits.
+// Requests authenticated with the read scope are not subject to the complexity limit.
+// Queries which can not be analyzed are rejected, their cost is unknown.
+func (h *QueryLimitHandler) check(ctx context.Context, req *gqlRequest) error {
+	qc, err := analyzeQuery(req.Query, req.OperationName, req.Variables)
+	if err != nil {
+		return fmt.Errorf("query can not be analyzed; %s", err.Error())
+	}
+
+	if h.maxDepth > 0 && qc.Depth > h.maxDepth {
+		return fmt.Errorf("query depth %d exceeds the maximum allowed depth of %d", qc.Depth, h.maxDepth)
+	}
+	if h.maxComplexity > 0 && qc.Complexity > h.maxComplexity && !isAuthenticated(ctx) {
+		return fmt.Errorf("query complexity %d exceeds the maximum allowed complexity of %d", qc.Complexity, h.maxComplexity)
+	}
+	return nil
+}
+
+// writeGraphQLError writes a GraphQL formatted error response.
+func writeGraphQLError(w http.ResponseWriter, err error) {
+	qe := map[string]interface{}{"message": err.Error()}
+	if code := resolvers.ErrorCode(err); code != "" {
+		qe["extensions"] = map[string]string{errCodeExtension: code}
+	}
+	resp, _ := json.Marshal(map[string]interface{}{
+		"errors": []map[string]interface{}{qe},
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(resp)
+}
+
+// isAuthenticated checks if the request context carries an API key identity with the read scope.
+func isAuthenticated(ctx context.Context) bool {
+	id := auth.FromContext(ctx)
+	return id != nil && id.Authenticated && id.Has(auth.ScopeRead)
+}
@@ -0,0 +1,171 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fantom-api-graphql/internal/config"
+	"fantom-api-graphql/internal/logger"
+	"github.com/onsi/gomega"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// queryLimitTest represents a single query limit test case.
+type queryLimitTest struct {
+	name     string
+	query    string
+	vars     map[string]interface{}
+	accepted bool
+}
+
+// the list of query limit tests; the limits are set to depth 4 and complexity 31
+var queryLimitTests = []queryLimitTest{
+	{
+		name:     "depth just under the limit",
+		query:    `{ account(address: "0x0") { contract { deployedBy { hash } } } }`,
+		accepted: true,
+	},
+	{
+		name:     "depth just over the limit",
+		query:    `{ account(address: "0x0") { contract { deployedBy { block { hash } } } } }`,
+		accepted: false,
+	},
+	{
+		name:     "depth over the limit through fragment",
+		query:    `query Q { account(address: "0x0") { ...C } } fragment C on Account { contract { deployedBy { block { hash } } } }`,
+		accepted: false,
+	},
+	{
+		name:     "complexity just under the limit",
+		query:    `{ blocks(count: 10) { edges { block { number } } } }`,
+		accepted: true,
+	},
+	{
+		name:     "complexity just over the limit",
+		query:    `{ blocks(count: -11) { edges { block { number } } } }`,
+		accepted: false,
+	},
+	{
+		name:     "complexity just under the limit by variable",
+		query:    `query Blocks($cnt: Int!) { blocks(count: $cnt) { edges { block { number } } } }`,
+		vars:     map[string]interface{}{"cnt": 10},
+		accepted: true,
+	},
+	{
+		name:     "complexity just over the limit by variable",
+		query:    `query Blocks($cnt: Int!) { blocks(count: $cnt) { edges { block { number } } } }`,
+		vars:     map[string]interface{}{"cnt": 11},
+		accepted: false,
+	},
+	{
+		name:     "complexity over the limit by aliases",
+		query:    `{ a: blocks(count: 5) { edges { block { number } } } b: blocks(count: 5) { edges { block { number } } } }`,
+		accepted: false,
+	},
+}
+
+// TestAnalyzeQueryDefaultListSize tests list fields without the size argument are costed by their default size.
+func TestAnalyzeQueryDefaultListSize(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	tests := []struct {
+		query      string
+		complexity int
+	}{
+		// rich list and account delegations load 25 edges by default
+		{`{ richList { edges { account { delegations { edges { cursor } } } } } }`, 1 + 25*(1+1+(1+25*(1+1)))},
+		{`{ richList(count: 2) { edges { account { delegations { edges { cursor } } } } } }`, 1 + 2*(1+1+(1+25*(1+1)))},
+		// the size is required, the largest list is assumed
+		{`{ blocks { edges { cursor } } }`, 1 + qcMaxListSize*(1+1)},
+		{`{ block { number } }`, 2},
+	}
+
+	for _, tc := range tests {
+		qc, err := analyzeQuery(tc.query, "", nil)
+		g.Expect(err).NotTo(gomega.HaveOccurred(), tc.query)
+		g.Expect(qc.Complexity).To(gomega.Equal(tc.complexity), tc.query)
+	}
+}
+
+// TestQueryLimitHandler tests rejection of queries exceeding configured limits.
+func TestQueryLimitHandler(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	cfg := config.Config{Log: config.Log{Level: "CRITICAL", Format: "%{message}"}}
+	cfg.Server.MaxQueryDepth = 4
+	cfg.Server.MaxQueryComplexity = 31
+
+	// the next handler only signals it was reached
+	var reached bool
+	h := NewQueryLimitHandler(&cfg, logger.New(&cfg), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reached = true
+	}))
+
+	for _, qt := range queryLimitTests {
+		reached = false
+
+		body, err := json.Marshal(gqlRequest{Query: qt.query, Variables: qt.vars})
+		g.Expect(err).ShouldNot(gomega.HaveOccurred())
+
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(string(body))))
+
+		g.Expect(reached).To(gomega.Equal(qt.accepted), qt.name)
+		if !qt.accepted {
+			g.Expect(rec.Body.String()).To(gomega.ContainSubstring("exceeds the maximum allowed"), qt.name)
+		}
+	}
+}
+
+// TestQueryLimitHandlerRejects tests rejection of requests the limits can not be verified for.
+func TestQueryLimitHandlerRejects(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	cfg := config.Config{Log: config.Log{Level: "CRITICAL", Format: "%{message}"}}
+	cfg.Server.MaxQueryDepth = 4
+	cfg.Server.MaxQueryComplexity = 31
+
+	var reached bool
+	h := NewQueryLimitHandler(&cfg, logger.New(&cfg), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reached = true
+	}))
+
+	tests := []struct {
+		name   string
+		method string
+		body   string
+		status int
+	}{
+		{name: "over the limit by GET", method: http.MethodGet, body: `{"query":"{ account(address: \"0x0\") { contract { deployedBy { block { hash } } } } }"}`, status: http.StatusOK},
+		{name: "invalid payload", method: http.MethodPost, body: `not a json`, status: http.StatusOK},
+		{name: "invalid query", method: http.MethodPost, body: `{"query":"{ block { number "}`, status: http.StatusOK},
+		{name: "unknown operation", method: http.MethodPost, body: `{"query":"query A { block { number } }","operationName":"B"}`, status: http.StatusOK},
+		{name: "too large body", method: http.MethodPost, body: `{"query":"` + strings.Repeat(" ", maxRequestBodySize) + `{ block { number } }"}`, status: http.StatusBadRequest},
+	}
+
+	for _, tc := range tests {
+		reached = false
+
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(tc.method, "/graphql", strings.NewReader(tc.body)))
+
+		g.Expect(reached).To(gomega.BeFalse(), tc.name)
+		g.Expect(rec.Code).To(gomega.Equal(tc.status), tc.name)
+	}
+}
+
+// TestWsServiceQueryLimit tests WebSocket operations exceeding the limits are rejected.
+func TestWsServiceQueryLimit(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	cfg := config.Config{Log: config.Log{Level: "CRITICAL", Format: "%{message}"}}
+	cfg.Server.MaxQueryDepth = 4
+
+	log := logger.New(&cfg)
+	ws := newWsService(log, nil, NewQueryLimitHandler(&cfg, log, http.NotFoundHandler()))
+
+	_, err := ws.Subscribe(context.Background(), `{ account(address: "0x0") { contract { deployedBy { block { hash } } } } }`, "", nil)
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("exceeds the maximum allowed depth")))
+}
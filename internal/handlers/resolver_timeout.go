@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/config"
+	"strings"
+	"time"
+)
+
+// resolverTimeouts represents the time limits of resolvers; resolvers not listed
+// are limited by the global resolver timeout. A resolver can be limited both shorter
+// and longer than the global timeout; the request deadline covers the longest limit.
+type resolverTimeouts struct {
+	global time.Duration
+	fields map[string]time.Duration
+}
+
+// newResolverTimeouts creates the resolver time limits from the server configuration.
+func newResolverTimeouts(cfg *config.Config) *resolverTimeouts {
+	rt := resolverTimeouts{
+		global: time.Duration(cfg.Server.ResolverTimeout) * time.Second,
+		fields: make(map[string]time.Duration, len(cfg.Server.ResolverTimeouts)),
+	}
+	for name, sec := range cfg.Server.ResolverTimeouts {
+		if sec > 0 {
+			rt.fields[strings.ToLower(name)] = time.Duration(sec) * time.Second
+		}
+	}
+	return &rt
+}
+
+// timeout provides the time limit of the given resolver.
+func (rt *resolverTimeouts) timeout(field string) time.Duration {
+	if to, ok := rt.fields[strings.ToLower(field)]; ok {
+		return to
+	}
+	return rt.global
+}
+
+// withDeadline derives the resolver context limited by the time limit of the given resolver.
+// A limit longer than the deadline of the parent context can not extend it.
+func (rt *resolverTimeouts) withDeadline(ctx context.Context, field string) (context.Context, context.CancelFunc) {
+	to := rt.timeout(field)
+	if to <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, to)
+}
+
+// RequestTimeout provides the time limit of the whole API request. It's the longest
+// of the resolver time limits, so the resolvers allowed to run longer than the global
+// resolver timeout are not cut by the request deadline. The other resolvers are still
+// limited by the global resolver timeout each.
+func RequestTimeout(cfg *config.Config) time.Duration {
+	to := newResolverTimeouts(cfg)
+
+	max := to.global
+	for _, ft := range to.fields {
+		if ft > max {
+			max = ft
+		}
+	}
+	return max
+}
@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/config"
+	"github.com/graph-gophers/graphql-go"
+	"github.com/onsi/gomega"
+	"testing"
+	"time"
+)
+
+// timeoutTestResolver resolves fields running for the given time, or until cancelled.
+type timeoutTestResolver struct {
+	run time.Duration
+}
+
+// wait runs for the configured time, or until the context is done.
+func (r *timeoutTestResolver) wait(ctx context.Context) (string, error) {
+	select {
+	case <-time.After(r.run):
+		return "done", nil
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+// Light resolves a field with its own short time limit.
+func (r *timeoutTestResolver) Light(ctx context.Context) (string, error) {
+	return r.wait(ctx)
+}
+
+// Deep resolves a field limited by the global time limit.
+func (r *timeoutTestResolver) Deep(ctx context.Context) (string, error) {
+	return r.wait(ctx)
+}
+
+// TestResolverTimeouts tests a slow resolver is cut at its own time limit, not the global one.
+func TestResolverTimeouts(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	tracer := RequestTracer{timeouts: &resolverTimeouts{
+		global: time.Second,
+		fields: map[string]time.Duration{"light": 50 * time.Millisecond},
+	}}
+	schema := graphql.MustParseSchema(`schema { query: Query } type Query { light: String! deep: String! }`,
+		&timeoutTestResolver{run: 200 * time.Millisecond}, graphql.Tracer(tracer))
+
+	// the light resolver is cut at its own limit
+	start := time.Now()
+	res := schema.Exec(context.Background(), `{ light }`, "", nil)
+	g.Expect(res.Errors).To(gomega.HaveLen(1))
+	g.Expect(res.Errors[0].Message).To(gomega.ContainSubstring(context.DeadlineExceeded.Error()))
+	g.Expect(time.Since(start)).To(gomega.BeNumerically("<", 200*time.Millisecond))
+
+	// the same work fits into the global limit
+	res = schema.Exec(context.Background(), `{ deep }`, "", nil)
+	g.Expect(res.Errors).To(gomega.BeEmpty())
+	g.Expect(string(res.Data)).To(gomega.Equal(`{"deep":"done"}`))
+}
+
+// TestRequestTimeout tests the request deadline covers the longest resolver time limit.
+func TestRequestTimeout(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	cfg := &config.Config{}
+	cfg.Server.ResolverTimeout = 30
+	g.Expect(RequestTimeout(cfg)).To(gomega.Equal(30 * time.Second))
+
+	cfg.Server.ResolverTimeouts = map[string]int64{"networkInfo": 5, "dailyStats": 120, "broken": -1}
+	g.Expect(RequestTimeout(cfg)).To(gomega.Equal(120 * time.Second))
+
+	rt := newResolverTimeouts(cfg)
+	g.Expect(rt.timeout("NetworkInfo")).To(gomega.Equal(5 * time.Second))
+	g.Expect(rt.timeout("dailyStats")).To(gomega.Equal(120 * time.Second))
+	g.Expect(rt.timeout("broken")).To(gomega.Equal(30 * time.Second))
+	g.Expect(rt.timeout("account")).To(gomega.Equal(30 * time.Second))
+}
@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"fantom-api-graphql/internal/config"
+	"fantom-api-graphql/internal/logger"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Headers of the signed API responses.
+const (
+	headerResponseSigner    = "X-Api-Signer"
+	headerResponseSignature = "X-Api-Signature"
+	headerResponseTimestamp = "X-Api-Timestamp"
+)
+
+// SigningHandler defines HTTP handler middleware signing the API responses
+// by the private key of the server. The signature is the Ethereum signed message
+// (EIP-191 personal_sign) of the text
+//
+//	<timestamp>\n<request hash>\n<response hash>
+//
+// where the timestamp is the unix time in seconds sent in the X-Api-Timestamp header,
+// and the hashes are the 0x prefixed hex encoded Keccak256 hashes of the raw request
+// and response bodies. Clients recover the signer address and compare it with the known identity
+// of the server; the signature binds the response to the query it answers and to the time it was made.
+type SigningHandler struct {
+	handler http.Handler
+	key     *ecdsa.PrivateKey
+	signer  string
+}
+
+// signingRecorder buffers the response so it can be signed before sending.
+type signingRecorder struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+// NewSigningHandler creates a new response signing middleware for the given handler.
+func NewSigningHandler(cfg *config.Config, log logger.Logger, h http.Handler) http.Handler {
+	if !cfg.MySignature.SignResponses {
+		return h
+	}
+
+	// we can not sign without the key
+	if !cfg.MySignature.HasKey() {
+		log.Errorf("response signing requested, but the server private key is not configured")
+		return h
+	}
+
+	signer := crypto.PubkeyToAddress(cfg.MySignature.PrivateKey.PublicKey)
+	log.Noticef("API responses are signed by %s", signer.String())
+
+	return &SigningHandler{
+		handler: h,
+		key:     &cfg.MySignature.PrivateKey,
+		signer:  signer.String(),
+	}
+}
+
+// ServeHTTP signs the response of the incoming request.
+func (h *SigningHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// web socket connections are not signed
+	if strings.EqualFold(r.Header.Get("Upgrade"), "websocket") {
+		h.handler.ServeHTTP(w, r)
+		return
+	}
+
+	// keep the request body for the signature
+	var req []byte
+	if hasRequestBody(r) {
+		var err error
+		req, err = ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxRequestBodySize))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		r.Body = ioutil.NopCloser(bytes.NewReader(req))
+	}
+
+	rec := &signingRecorder{ResponseWriter: w, status: http.StatusOK}
+	h.handler.ServeHTTP(rec, r)
+
+	ts := time.Now().Unix()
+	sig, err := signResponse(h.key, signedMessage(ts, req, rec.body.Bytes()))
+	if err == nil {
+		w.Header().Set(headerResponseSigner, h.signer)
+		w.Header().Set(headerResponseTimestamp, strconv.FormatInt(ts, 10))
+		w.Header().Set(headerResponseSignature, hexutil.Encode(sig))
+	}
+
+	w.Header().Set("Content-Length", strconv.Itoa(rec.body.Len()))
+	w.WriteHeader(rec.status)
+	_, _ = w.Write(rec.body.Bytes())
+}
+
+// WriteHeader keeps the status code of the response.
+func (rec *signingRecorder) WriteHeader(status int) {
+	rec.status = status
+}
+
+// Write buffers the response body.
+func (rec *signingRecorder) Write(b []byte) (int, error) {
+	return rec.body.Write(b)
+}
+
+// signedMessage builds the message signed for the given request and response bodies.
+func signedMessage(ts int64, req []byte, resp []byte) []byte {
+	return []byte(strconv.FormatInt(ts, 10) + "\n" + crypto.Keccak256Hash(req).String() + "\n" + crypto.Keccak256Hash(resp).String())
+}
+
+// signResponse calculates the Ethereum signed message signature of the given message.
+// The recovery id of the signature is shifted to 27/28 as expected by the wallets
+// and the client libraries verifying signed messages.
+func signResponse(key *ecdsa.PrivateKey, msg []byte) ([]byte, error) {
+	sig, err := crypto.Sign(accounts.TextHash(msg), key)
+	if err != nil {
+		return nil, err
+	}
+	sig[crypto.RecoveryIDOffset] += 27
+	return sig, nil
+}
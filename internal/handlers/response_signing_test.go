@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"fantom-api-graphql/internal/config"
+	"fantom-api-graphql/internal/logger"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/onsi/gomega"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestSigningHandler tests the signer of the API responses can be recovered from the signature.
+func TestSigningHandler(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	key, err := crypto.GenerateKey()
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	cfg := &config.Config{Log: config.Log{Level: "CRITICAL", Format: "%{message}"}}
+	cfg.MySignature.PrivateKey = *key
+	log := logger.New(cfg)
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte(`{"data":{}}`))
+	})
+	serve := func() *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		NewSigningHandler(cfg, log, next).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api", strings.NewReader(`{"query":"{ version }"}`)))
+		return rec
+	}
+
+	// signing is disabled by default
+	g.Expect(serve().Header().Get(headerResponseSignature)).To(gomega.BeEmpty())
+
+	cfg.MySignature.SignResponses = true
+	rec := serve()
+	g.Expect(rec.Code).To(gomega.Equal(http.StatusAccepted))
+	g.Expect(rec.Body.String()).To(gomega.Equal(`{"data":{}}`))
+	g.Expect(rec.Header().Get(headerResponseSigner)).To(gomega.Equal(crypto.PubkeyToAddress(key.PublicKey).String()))
+
+	sig, err := hexutil.Decode(rec.Header().Get(headerResponseSignature))
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(sig).To(gomega.HaveLen(crypto.SignatureLength))
+
+	// the signed message binds the response to the request and the time stamp
+	ts := rec.Header().Get(headerResponseTimestamp)
+	g.Expect(strconv.ParseInt(ts, 10, 64)).To(gomega.BeNumerically("~", time.Now().Unix(), 5))
+	msg := ts + "\n" + crypto.Keccak256Hash([]byte(`{"query":"{ version }"}`)).String() + "\n" + crypto.Keccak256Hash([]byte(`{"data":{}}`)).String()
+
+	sig[crypto.RecoveryIDOffset] -= 27
+	pub, err := crypto.SigToPub(accounts.TextHash([]byte(msg)), sig)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(crypto.PubkeyToAddress(*pub)).To(gomega.Equal(crypto.PubkeyToAddress(key.PublicKey)))
+}
+
+// TestSigningHandlerRequestBody tests the request body is passed on unchanged
+// and the same response to a different request is signed differently.
+func TestSigningHandlerRequestBody(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	key, err := crypto.GenerateKey()
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	cfg := &config.Config{Log: config.Log{Level: "CRITICAL", Format: "%{message}"}}
+	cfg.MySignature.PrivateKey = *key
+	cfg.MySignature.SignResponses = true
+
+	var received string
+	h := NewSigningHandler(cfg, logger.New(cfg), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		received = string(body)
+		_, _ = w.Write([]byte(`{"data":{}}`))
+	}))
+	serve := func(query string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api", strings.NewReader(query)))
+		return rec
+	}
+
+	first := serve(`{"query":"{ version }"}`)
+	g.Expect(received).To(gomega.Equal(`{"query":"{ version }"}`))
+
+	second := serve(`{"query":"{ block { number } }"}`)
+	g.Expect(received).To(gomega.Equal(`{"query":"{ block { number } }"}`))
+	g.Expect(second.Body.String()).To(gomega.Equal(first.Body.String()))
+
+	// the same response to a different request is signed as a different message
+	g.Expect(signedMessage(1, []byte(`{"query":"{ version }"}`), first.Body.Bytes())).
+		NotTo(gomega.Equal(signedMessage(1, []byte(`{"query":"{ block { number } }"}`), second.Body.Bytes())))
+}
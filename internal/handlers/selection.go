@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"fantom-api-graphql/internal/config"
+	"fantom-api-graphql/internal/graphql/resolvers"
+	"fantom-api-graphql/internal/logger"
+	"net/http"
+)
+
+// SelectionHandler defines HTTP handler middleware attaching the set of fields
+// selected by the GraphQL query to the request context, so the resolvers
+// can load only the parts of the stored documents the query needs.
+type SelectionHandler struct {
+	logger  logger.Logger
+	handler http.Handler
+}
+
+// NewSelectionHandler creates a new field selection middleware for the given handler.
+func NewSelectionHandler(cfg *config.Config, log logger.Logger, h http.Handler) http.Handler {
+	if !cfg.Server.FieldProjection {
+		return h
+	}
+	return &SelectionHandler{logger: log, handler: h}
+}
+
+// ServeHTTP attaches the selected fields of the incoming query to the request.
+func (h *SelectionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// the GraphQL handler decodes the body of any method, so we analyze all of them
+	if !hasRequestBody(r) {
+		h.handler.ServeHTTP(w, r)
+		return
+	}
+
+	req, err := readGraphQLRequest(w, r)
+	if err != nil {
+		h.logger.Errorf("can not read request body; %s", err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if req != nil {
+		if fields := selectedFields(req.Query); fields != nil {
+			r = r.WithContext(resolvers.WithSelection(r.Context(), fields))
+		}
+	}
+	h.handler.ServeHTTP(w, r)
+}
+
+// selectedFields collects names of all the fields selected by any operation, or fragment
+// of the given query. The set is not bound to a path, so a field selected anywhere
+// counts for all the resolvers. Nil is returned for queries which can not be parsed.
+func selectedFields(query string) map[string]bool {
+	doc, err := parseQueryDocument(query, nil)
+	if err != nil {
+		return nil
+	}
+
+	found := make(map[string]bool)
+	collectFieldNames(doc.anonymous, found)
+	for _, set := range doc.operations {
+		collectFieldNames(set, found)
+	}
+	for _, set := range doc.fragments {
+		collectFieldNames(set, found)
+	}
+	return found
+}
+
+// collectFieldNames collects names of the fields of the selection set recursively.
+func collectFieldNames(set []*qcSelection, found map[string]bool) {
+	for _, sel := range set {
+		if sel.isField {
+			found[sel.name] = true
+		}
+		collectFieldNames(sel.children, found)
+	}
+}
@@ -0,0 +1,25 @@
+package handlers
+
+import (
+	"github.com/onsi/gomega"
+	"testing"
+)
+
+// TestSelectedFields tests collection of the fields selected by queries.
+func TestSelectedFields(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	fields := selectedFields(`query Q { transactions(count: 5) { edges { transaction { hash value } } } }`)
+	g.Expect(fields).To(gomega.HaveKey("transactions"))
+	g.Expect(fields).To(gomega.HaveKey("hash"))
+	g.Expect(fields).ToNot(gomega.HaveKey("inputData"))
+
+	// aliases resolve to the field names, fragments are followed
+	fields = selectedFields(`{ account(address: "0x1") { txList { edges { transaction { ...T data: inputData } } } } }
+		fragment T on Transaction { ... on Transaction { call { name } } }`)
+	g.Expect(fields).To(gomega.HaveKey("inputData"))
+	g.Expect(fields).To(gomega.HaveKey("call"))
+	g.Expect(fields).ToNot(gomega.HaveKey("data"))
+
+	g.Expect(selectedFields(`{ broken `)).To(gomega.BeNil())
+}
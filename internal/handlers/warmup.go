@@ -0,0 +1,221 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fantom-api-graphql/internal/config"
+	"fantom-api-graphql/internal/logger"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// warmupRemoteAddr identifies the warm-up requests in the request log.
+const warmupRemoteAddr = "warmup:0"
+
+// Warmup executes the configured warm-up queries through the API handler chain
+// on the server start and periodically thereafter, so the caches are populated
+// before the clients ask for the data. Failed queries are logged and skipped.
+type Warmup struct {
+	log         logger.Logger
+	handler     http.Handler
+	queries     []config.WarmupQuery
+	interval    time.Duration
+	concurrency int
+	apiKey      string
+	ctx         context.Context
+	cancel      context.CancelFunc
+	wg          sync.WaitGroup
+}
+
+// warmupRecorder captures the status and the body of the warm-up response.
+type warmupRecorder struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+// NewWarmup creates a new warm-up runner executing the configured queries on the given handler.
+func NewWarmup(cfg *config.Config, log logger.Logger, h http.Handler) *Warmup {
+	ctx, cancel := context.WithCancel(context.Background())
+	w := Warmup{
+		log:         log,
+		handler:     h,
+		queries:     cfg.Warmup.Queries,
+		interval:    cfg.Warmup.Interval,
+		concurrency: cfg.Warmup.Concurrency,
+		apiKey:      cfg.Warmup.ApiKey,
+		ctx:         ctx,
+		cancel:      cancel,
+	}
+	if w.concurrency < 1 {
+		w.concurrency = 1
+	}
+	return &w
+}
+
+// Run starts the warm-up in the background. It does not block the server start.
+func (w *Warmup) Run() {
+	if len(w.queries) == 0 {
+		return
+	}
+
+	w.log.Noticef("warm-up of %d queries starting", len(w.queries))
+	w.wg.Add(1)
+	go w.run()
+}
+
+// Close stops the warm-up and waits for the queries in progress to finish.
+func (w *Warmup) Close() {
+	w.cancel()
+	w.wg.Wait()
+}
+
+// run executes the warm-up on start and on each interval tick until closed.
+func (w *Warmup) run() {
+	defer w.wg.Done()
+	w.execute()
+
+	// no periodic warm-up
+	if w.interval <= 0 {
+		return
+	}
+
+	tick := time.NewTicker(w.interval)
+	defer tick.Stop()
+
+	for {
+		select {
+		case <-w.ctx.Done():
+			return
+		case <-tick.C:
+			w.execute()
+		}
+	}
+}
+
+// execute runs all the warm-up queries with the configured concurrency.
+func (w *Warmup) execute() {
+	start := time.Now()
+	sem := make(chan struct{}, w.concurrency)
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var failed int
+
+	for i := range w.queries {
+		select {
+		case <-w.ctx.Done():
+			wg.Wait()
+			return
+		case sem <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func(q *config.WarmupQuery) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			if !w.query(q) {
+				mu.Lock()
+				failed++
+				mu.Unlock()
+			}
+		}(&w.queries[i])
+	}
+	wg.Wait()
+
+	w.log.Infof("warm-up of %d queries done in %s, %d failed", len(w.queries), time.Since(start).String(), failed)
+}
+
+// query executes a single warm-up query and reports its success.
+func (w *Warmup) query(q *config.WarmupQuery) (ok bool) {
+	start := time.Now()
+
+	// a broken resolver must not take the server down
+	defer func() {
+		if r := recover(); r != nil {
+			w.log.Errorf("warm-up query %s panicked; %v", q.Name, r)
+			ok = false
+		}
+	}()
+
+	req, err := w.request(q)
+	if err != nil {
+		w.log.Errorf("warm-up query %s can not be prepared; %s", q.Name, err.Error())
+		return false
+	}
+
+	rec := &warmupRecorder{header: make(http.Header), status: http.StatusOK}
+	w.handler.ServeHTTP(rec, req)
+
+	if err := rec.err(); err != nil {
+		w.log.Warningf("warm-up query %s failed in %s; %s", q.Name, time.Since(start).String(), err.Error())
+		return false
+	}
+
+	w.log.Debugf("warm-up query %s done in %s", q.Name, time.Since(start).String())
+	return true
+}
+
+// request builds the API request of the given warm-up query.
+func (w *Warmup) request(q *config.WarmupQuery) (*http.Request, error) {
+	body, err := json.Marshal(struct {
+		Query     string                 `json:"query"`
+		Variables map[string]interface{} `json:"variables,omitempty"`
+	}{Query: q.Query, Variables: q.Variables})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(w.ctx, http.MethodPost, "/api", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	req.RemoteAddr = warmupRemoteAddr
+	req.Header.Set("Content-Type", "application/json")
+	if w.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+w.apiKey)
+	}
+	return req, nil
+}
+
+// Header returns the response headers.
+func (rec *warmupRecorder) Header() http.Header {
+	return rec.header
+}
+
+// WriteHeader keeps the status code of the response.
+func (rec *warmupRecorder) WriteHeader(status int) {
+	rec.status = status
+}
+
+// Write buffers the response body.
+func (rec *warmupRecorder) Write(b []byte) (int, error) {
+	return rec.body.Write(b)
+}
+
+// err checks the recorded response for the HTTP and GraphQL errors.
+func (rec *warmupRecorder) err() error {
+	if rec.status != http.StatusOK {
+		return fmt.Errorf("status %d", rec.status)
+	}
+
+	var res struct {
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal(rec.body.Bytes(), &res); err != nil {
+		return fmt.Errorf("invalid response; %s", err.Error())
+	}
+	if len(res.Errors) > 0 {
+		return fmt.Errorf("%s", res.Errors[0].Message)
+	}
+	return nil
+}
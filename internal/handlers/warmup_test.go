@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fantom-api-graphql/internal/config"
+	"fantom-api-graphql/internal/logger"
+	"github.com/onsi/gomega"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestWarmup tests the warm-up queries are executed with the bounded concurrency
+// and the failing queries do not stop the others.
+func TestWarmup(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	cfg := &config.Config{Log: config.Log{Level: "CRITICAL", Format: "%{message}"}}
+	cfg.Warmup.Concurrency = 2
+	cfg.Warmup.ApiKey = "secret"
+	cfg.Warmup.Queries = []config.WarmupQuery{
+		{Name: "a", Query: "{ a }"},
+		{Name: "b", Query: "{ b }", Variables: map[string]interface{}{"id": 1}},
+		{Name: "panic", Query: "{ panic }"},
+		{Name: "error", Query: "{ error }"},
+		{Name: "c", Query: "{ c }"},
+	}
+
+	var mu sync.Mutex
+	var running, peak int32
+	seen := make(map[string]bool)
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cur := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+
+		var body struct {
+			Query     string                 `json:"query"`
+			Variables map[string]interface{} `json:"variables"`
+		}
+		g.Expect(json.NewDecoder(r.Body).Decode(&body)).To(gomega.Succeed())
+		g.Expect(r.Header.Get("Authorization")).To(gomega.Equal("Bearer secret"))
+
+		mu.Lock()
+		seen[body.Query] = true
+		if cur > peak {
+			peak = cur
+		}
+		mu.Unlock()
+
+		time.Sleep(10 * time.Millisecond)
+		switch body.Query {
+		case "{ panic }":
+			panic("resolver failed")
+		case "{ error }":
+			_, _ = w.Write([]byte(`{"errors":[{"message":"failed"}]}`))
+		default:
+			_, _ = w.Write([]byte(`{"data":{}}`))
+		}
+	})
+
+	wu := NewWarmup(cfg, logger.New(cfg), next)
+	defer wu.Close()
+	wu.execute()
+
+	g.Expect(seen).To(gomega.HaveLen(len(cfg.Warmup.Queries)))
+	g.Expect(peak).To(gomega.BeNumerically("<=", 2))
+
+	g.Expect(wu.query(&cfg.Warmup.Queries[0])).To(gomega.BeTrue())
+	g.Expect(wu.query(&cfg.Warmup.Queries[2])).To(gomega.BeFalse())
+	g.Expect(wu.query(&cfg.Warmup.Queries[3])).To(gomega.BeFalse())
+}
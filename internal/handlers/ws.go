@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/logger"
+	"github.com/graph-gophers/graphql-go"
+	"net/http"
+)
+
+// requestChecker represents a middleware able to check a GraphQL request
+// outside of the HTTP handlers chain.
+type requestChecker interface {
+	check(ctx context.Context, req *gqlRequest) error
+}
+
+// wsService represents the GraphQL service of WebSocket connections.
+// Operations received over the WebSocket bypass the HTTP handlers chain,
+// so the service runs the same checks the chain does before executing them.
+type wsService struct {
+	logger logger.Logger
+	schema *graphql.Schema
+	checks []requestChecker
+}
+
+// newWsService creates a new WebSocket GraphQL service for the given schema checking
+// operations by the given middlewares. Handlers not checking requests are skipped.
+func newWsService(log logger.Logger, schema *graphql.Schema, hh ...http.Handler) *wsService {
+	ws := wsService{logger: log, schema: schema, checks: make([]requestChecker, 0, len(hh))}
+	for _, h := range hh {
+		rc, ok := h.(requestChecker)
+		if !ok || ws.has(rc) {
+			continue
+		}
+		ws.checks = append(ws.checks, rc)
+	}
+	return &ws
+}
+
+// has checks if the given request checker is already registered;
+// disabled middlewares pass their next handler, which may be listed too.
+func (ws *wsService) has(rc requestChecker) bool {
+	for _, c := range ws.checks {
+		if c == rc {
+			return true
+		}
+	}
+	return false
+}
+
+// Subscribe checks the incoming operation and executes it if all the checks pass.
+func (ws *wsService) Subscribe(ctx context.Context, document string, operationName string, variables map[string]interface{}) (<-chan interface{}, error) {
+	req := gqlRequest{Query: document, OperationName: operationName, Variables: variables}
+	for _, c := range ws.checks {
+		if err := c.check(ctx, &req); err != nil {
+			ws.logger.Debugf("websocket operation rejected; %s", err.Error())
+			return nil, err
+		}
+	}
+	return ws.schema.Subscribe(ctx, document, operationName, variables)
+}
@@ -0,0 +1,27 @@
+package logger
+
+import (
+	"fantom-api-graphql/internal/config"
+	"github.com/op/go-logging"
+	"gopkg.in/natefinch/lumberjack.v2"
+	"regexp"
+)
+
+// reColorVerb matches the color verbs of the log format, which are not used in log files.
+var reColorVerb = regexp.MustCompile(`%{color[^}]*}`)
+
+// newFileBackend creates a logging backend writing into the configured log file,
+// rotated by size and age. The log format is used without the terminal colors.
+func newFileBackend(cfg *config.Log) logging.Backend {
+	out := &lumberjack.Logger{
+		Filename:   cfg.File.Path,
+		MaxSize:    cfg.File.MaxSize,
+		MaxBackups: cfg.File.MaxBackups,
+		MaxAge:     cfg.File.MaxAge,
+		Compress:   cfg.File.Compress,
+		LocalTime:  true,
+	}
+
+	format := logging.MustStringFormatter(reColorVerb.ReplaceAllString(cfg.Format, ""))
+	return logging.NewBackendFormatter(logging.NewLogBackend(out, "", 0), format)
+}
@@ -9,6 +9,11 @@ import (
 // ApiLogger defines extended logger with generic no-level logging option
 type ApiLogger struct {
 	logging.Logger
+
+	// dbg is the logger used by the rate limited debug output;
+	// the extra call depth keeps the caller of the wrapper in the log records
+	dbg   *logging.Logger
+	debug *rateLimiter
 }
 
 // Printf implements default non-leveled output.
@@ -17,28 +22,54 @@ func (a ApiLogger) Printf(format string, args ...interface{}) {
 	a.Debugf(format, args...)
 }
 
+// Debugf logs detailed state change with formatting and placeholder constituents replacements.
+// Messages of the same format over the configured rate are dropped and reported
+// with the next message of the format logged.
+func (a ApiLogger) Debugf(format string, args ...interface{}) {
+	if !a.IsEnabledFor(logging.DEBUG) {
+		return
+	}
+
+	ok, dropped := a.debug.allow(format)
+	if !ok {
+		return
+	}
+	if dropped > 0 {
+		a.dbg.Debugf("%d similar messages suppressed", dropped)
+	}
+	a.dbg.Debugf(format, args...)
+}
+
 // New provides pre-configured Logger with stderr output and leveled filtering.
+// The output is copied into a rotated log file, if configured.
 // Modules are not supported at the moment, but may be added in the future to make the logging setup more granular.
 func New(cfg *config.Config) Logger {
 	// Prep the backend for exporting the log records
-	// @todo Allow app to define different logging backend by configuration means.
 	backend := logging.NewLogBackend(os.Stderr, "", 0)
 
 	// Parse log format from configuration and apply it to the backend
 	format := logging.MustStringFormatter(cfg.Log.Format)
-	fmtBackend := logging.NewBackendFormatter(backend, format)
+	backends := []logging.Backend{logging.NewBackendFormatter(backend, format)}
+
+	// add the file output, if any
+	if cfg.Log.File.Path != "" {
+		backends = append(backends, newFileBackend(&cfg.Log))
+	}
 
 	// Parse and apply the configured level on which the recording will be emitted
 	level, err := logging.LogLevel(cfg.Log.Level)
 	if err != nil {
 		level = logging.INFO
 	}
-	lvlBackend := logging.AddModuleLevel(fmtBackend)
+	lvlBackend := logging.MultiLogger(backends...)
 	lvlBackend.SetLevel(level, "")
 
 	// assign the backend and return the new logger
 	logging.SetBackend(lvlBackend)
 	l := logging.MustGetLogger(cfg.AppName)
 
-	return &ApiLogger{*l}
+	dbg := *l
+	dbg.ExtraCalldepth = 1
+
+	return &ApiLogger{Logger: *l, dbg: &dbg, debug: newRateLimiter(cfg.Log.DebugRateLimit)}
 }
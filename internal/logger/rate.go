@@ -0,0 +1,60 @@
+package logger
+
+import (
+	"sync"
+	"time"
+)
+
+// rateLimiter limits the number of messages of the same kind logged per second.
+type rateLimiter struct {
+	limit int
+	mu    sync.Mutex
+	kinds map[string]*rateWindow
+}
+
+// rateWindow represents the messages of a single kind in the current one second window.
+type rateWindow struct {
+	start   int64
+	count   int
+	dropped int
+}
+
+// newRateLimiter creates a new rate limiter allowing the given number of messages
+// of the same kind per second; zero, or negative limit disables the limiter.
+func newRateLimiter(limit int) *rateLimiter {
+	return &rateLimiter{limit: limit, kinds: make(map[string]*rateWindow)}
+}
+
+// allow signals if a message of the given kind can be logged. If so, it also provides
+// the number of messages of the kind dropped since the last one logged.
+func (rl *rateLimiter) allow(kind string) (bool, int) {
+	if rl == nil || rl.limit <= 0 {
+		return true, 0
+	}
+
+	now := time.Now().Unix()
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	w, ok := rl.kinds[kind]
+	if !ok {
+		w = &rateWindow{start: now}
+		rl.kinds[kind] = w
+	}
+
+	// new window starts
+	if w.start != now {
+		w.start = now
+		w.count = 0
+	}
+
+	if w.count >= rl.limit {
+		w.dropped++
+		return false, 0
+	}
+
+	w.count++
+	dropped := w.dropped
+	w.dropped = 0
+	return true, dropped
+}
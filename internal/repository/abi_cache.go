@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"sync"
+)
+
+// contractAbiCacheSize is the max number of parsed contract ABIs kept in memory.
+const contractAbiCacheSize = 1024
+
+// parsedAbi represents the result of a contract ABI parsing.
+type parsedAbi struct {
+	abi *abi.ABI
+	err error
+}
+
+// contractAbis keeps the parsed ABIs of known contracts keyed by the hash of their definition,
+// so the same ABI is not parsed again on every decoded call, log record, or error.
+var contractAbis = struct {
+	sync.Mutex
+	m map[common.Hash]parsedAbi
+}{m: make(map[common.Hash]parsedAbi)}
+
+// cachedContractAbi provides the parsed JSON ABI definition of a contract, parsing it
+// only if it is not in memory already. The parsed ABI is shared and must not be modified.
+func cachedContractAbi(def string) (*abi.ABI, error) {
+	key := crypto.Keccak256Hash([]byte(def))
+
+	contractAbis.Lock()
+	res, ok := contractAbis.m[key]
+	contractAbis.Unlock()
+	if ok {
+		return res.abi, res.err
+	}
+
+	ab, err := parseContractAbi(def)
+	if err == nil {
+		res.abi = &ab
+	}
+	res.err = err
+
+	contractAbis.Lock()
+	defer contractAbis.Unlock()
+
+	// make room for the new ABI by dropping an arbitrary one
+	if len(contractAbis.m) >= contractAbiCacheSize {
+		for k := range contractAbis.m {
+			delete(contractAbis.m, k)
+			break
+		}
+	}
+	contractAbis.m[key] = res
+	return res.abi, res.err
+}
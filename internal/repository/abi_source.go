@@ -0,0 +1,164 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+// abiSourceWorkers is the max number of concurrent remote ABI lookups.
+// Lookups exceeding the limit are skipped and will be requested again later.
+const abiSourceWorkers = 4
+
+// abiSourceMatches are the repository folders searched for the contract metadata
+// in the order of preference.
+var abiSourceMatches = []string{"full_match", "partial_match"}
+
+// abiSourceSlots limits the number of concurrent remote ABI lookups.
+var abiSourceSlots = make(chan struct{}, abiSourceWorkers)
+
+// abiSourceMetadata represents the part of the Solidity contract metadata we need.
+type abiSourceMetadata struct {
+	Compiler struct {
+		Version string `json:"version"`
+	} `json:"compiler"`
+	Output struct {
+		Abi json.RawMessage `json:"abi"`
+	} `json:"output"`
+	Settings struct {
+		CompilationTarget map[string]string `json:"compilationTarget"`
+	} `json:"settings"`
+}
+
+// requestRemoteAbi starts a background lookup of the ABI of the given contract
+// on the remote verification source, if enabled. The ABI found is stored with the contract,
+// so decoding of later interactions can use it. Only contracts already known are looked up;
+// the lookup never creates a new contract record. The analysis in progress is not blocked
+// by the lookup.
+func (p *proxy) requestRemoteAbi(addr common.Address) {
+	if !p.cfg.AbiSource.Enabled || p.abiMisses.has(&addr) {
+		return
+	}
+
+	// skip the lookup if too many are already in progress
+	select {
+	case abiSourceSlots <- struct{}{}:
+	default:
+		return
+	}
+
+	go func() {
+		defer func() { <-abiSourceSlots }()
+
+		_, _, _ = p.apiRequestGroup.Do("abi_src_"+addr.String(), func() (interface{}, error) {
+			sc, err := p.Contract(&addr)
+			if err != nil || sc == nil || sc.Abi != "" {
+				return nil, err
+			}
+
+			md, err := p.remoteAbi(&addr)
+			if err != nil || md == nil {
+				if err != nil {
+					p.log.Warningf("remote ABI lookup of %s failed; %s", addr.String(), err.Error())
+				}
+				p.abiMisses.add(&addr)
+				return nil, err
+			}
+
+			if err := p.storeRemoteAbi(sc, md); err != nil {
+				p.log.Errorf("can not store remote ABI of %s; %s", addr.String(), err.Error())
+				p.abiMisses.add(&addr)
+				return nil, err
+			}
+
+			p.log.Noticef("ABI of %s obtained from the remote source", addr.String())
+			return nil, nil
+		})
+	}()
+}
+
+// storeRemoteAbi stores the ABI, name and compiler version of the remote contract metadata
+// with the given known contract.
+func (p *proxy) storeRemoteAbi(sc *types.Contract, md *abiSourceMetadata) error {
+	if _, err := cachedContractAbi(string(md.Output.Abi)); err != nil {
+		return fmt.Errorf("invalid ABI; %s", err.Error())
+	}
+
+	sc.Abi = string(md.Output.Abi)
+	for _, n := range md.Settings.CompilationTarget {
+		sc.Name = n
+	}
+	if md.Compiler.Version != "" {
+		sc.Compiler = md.Compiler.Version
+	}
+
+	if err := p.db.AddContract(sc); err != nil {
+		return err
+	}
+	p.cache.EvictContract(&sc.Address)
+	return nil
+}
+
+// remoteAbi loads the metadata of the given contract from the remote verification source.
+// Nil is returned if the source does not know the contract.
+func (p *proxy) remoteAbi(addr *common.Address) (*abiSourceMetadata, error) {
+	ni, err := p.NetworkInfo(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	client := &http.Client{Timeout: p.cfg.AbiSource.Timeout}
+	for _, m := range abiSourceMatches {
+		url := fmt.Sprintf("%s/contracts/%s/%s/%s/metadata.json",
+			strings.TrimRight(p.cfg.AbiSource.Url, "/"), m, ni.ChainID.ToInt().String(), addr.String())
+
+		md, err := p.remoteAbiMetadata(client, url)
+		if err != nil {
+			return nil, err
+		}
+		if md != nil {
+			return md, nil
+		}
+	}
+	return nil, nil
+}
+
+// remoteAbiMetadata loads and decodes the contract metadata from the given address.
+// Nil is returned if the metadata is not available.
+func (p *proxy) remoteAbiMetadata(client *http.Client, url string) (*abiSourceMetadata, error) {
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			p.log.Errorf("error closing ABI source response; %s", err.Error())
+		}
+	}()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected response status %s", resp.Status)
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var md abiSourceMetadata
+	if err := json.Unmarshal(body, &md); err != nil {
+		return nil, fmt.Errorf("can not decode contract metadata; %s", err.Error())
+	}
+	if len(md.Output.Abi) == 0 {
+		return nil, nil
+	}
+	return &md, nil
+}
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"sync"
+	"time"
+)
+
+// abiSourceMissTTL is the time we don't ask the remote ABI source
+// again for a contract it failed to provide.
+const abiSourceMissTTL = 6 * time.Hour
+
+// abiSourceMissCapacity is the max number of remote ABI source misses kept.
+const abiSourceMissCapacity = 50000
+
+// abiSourceMisses represents the set of contracts the remote ABI source recently
+// failed to provide. Each miss is kept for its full time to live, independently
+// of the in-memory cache eviction.
+type abiSourceMisses struct {
+	mu   sync.Mutex
+	ttl  time.Duration
+	cap  int
+	list map[common.Address]time.Time
+}
+
+// newAbiSourceMisses creates a new empty set of remote ABI source misses.
+func newAbiSourceMisses(ttl time.Duration, capacity int) *abiSourceMisses {
+	return &abiSourceMisses{ttl: ttl, cap: capacity, list: make(map[common.Address]time.Time)}
+}
+
+// has checks if the remote ABI source recently failed to provide the ABI of the given contract.
+func (m *abiSourceMisses) has(addr *common.Address) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	exp, ok := m.list[*addr]
+	if ok && time.Now().After(exp) {
+		delete(m.list, *addr)
+		return false
+	}
+	return ok
+}
+
+// add marks the given contract as unknown to the remote ABI source.
+func (m *abiSourceMisses) add(addr *common.Address) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(m.list) >= m.cap {
+		m.prune()
+	}
+	m.list[*addr] = time.Now().Add(m.ttl)
+}
+
+// evict clears the remote ABI source miss mark of the given contract.
+func (m *abiSourceMisses) evict(addr *common.Address) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.list, *addr)
+}
+
+// prune drops the expired misses; an arbitrary miss is dropped if none expired
+// so the set does not grow over its capacity. The lock must be held by the caller.
+func (m *abiSourceMisses) prune() {
+	now := time.Now()
+	for addr, exp := range m.list {
+		if now.After(exp) {
+			delete(m.list, addr)
+		}
+	}
+
+	if len(m.list) >= m.cap {
+		for addr := range m.list {
+			delete(m.list, addr)
+			break
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/onsi/gomega"
+	"testing"
+	"time"
+)
+
+// TestAbiSourceMisses tests the misses are kept for their time to live and the set
+// does not grow over its capacity.
+func TestAbiSourceMisses(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	a1, a2, a3 := common.HexToAddress("0x1"), common.HexToAddress("0x2"), common.HexToAddress("0x3")
+	m := newAbiSourceMisses(time.Hour, 2)
+	g.Expect(m.has(&a1)).To(gomega.BeFalse())
+
+	m.add(&a1)
+	m.add(&a2)
+	g.Expect(m.has(&a1)).To(gomega.BeTrue())
+	g.Expect(m.has(&a2)).To(gomega.BeTrue())
+
+	m.evict(&a1)
+	g.Expect(m.has(&a1)).To(gomega.BeFalse())
+
+	m.add(&a1)
+	m.add(&a3)
+	g.Expect(m.list).To(gomega.HaveLen(2))
+	g.Expect(m.has(&a3)).To(gomega.BeTrue())
+
+	// expired misses are not reported
+	m = newAbiSourceMisses(-time.Second, 2)
+	m.add(&a1)
+	g.Expect(m.has(&a1)).To(gomega.BeFalse())
+	g.Expect(m.list).To(gomega.BeEmpty())
+}
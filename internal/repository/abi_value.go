@@ -0,0 +1,295 @@
+package repository
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"math/big"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// abiFixedTypeRegex matches the fixed point ABI types, including arrays of them.
+var abiFixedTypeRegex = regexp.MustCompile(`^(u?fixed)(?:(\d+)x(\d+))?((?:\[\d*])*)$`)
+
+// abiFixedNameRegex matches the canonical name of a fixed point type.
+var abiFixedNameRegex = regexp.MustCompile(`^u?fixed\d+x(\d+)$`)
+
+// parseContractAbi parses the JSON ABI definition of a contract.
+// The ABI decoder does not support the fixed point types, so they are replaced
+// by a single element tuple of the integer of the same size, which has the same encoding.
+// The tuple element is named by the original type, so the value can be scaled on output;
+// signatures and selectors are calculated with the original types.
+func parseContractAbi(def string) (abi.ABI, error) {
+	if !strings.Contains(def, "fixed") {
+		return abi.JSON(strings.NewReader(def))
+	}
+
+	var fields []map[string]interface{}
+	dec := json.NewDecoder(strings.NewReader(def))
+	dec.UseNumber()
+	if err := dec.Decode(&fields); err != nil {
+		return abi.ABI{}, err
+	}
+
+	for _, f := range fields {
+		for _, key := range []string{"inputs", "outputs"} {
+			if args, ok := f[key].([]interface{}); ok {
+				replaceAbiFixedTypes(args)
+			}
+		}
+	}
+
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return abi.ABI{}, err
+	}
+
+	ab, err := abi.JSON(bytes.NewReader(data))
+	if err != nil {
+		return abi.ABI{}, err
+	}
+	restoreAbiSignatures(&ab)
+	return ab, nil
+}
+
+// replaceAbiFixedTypes replaces the fixed point types of the given ABI arguments
+// and their tuple components by the integer tuple of the same encoding.
+func replaceAbiFixedTypes(args []interface{}) {
+	for _, a := range args {
+		arg, ok := a.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		if comp, ok := arg["components"].([]interface{}); ok {
+			replaceAbiFixedTypes(comp)
+		}
+
+		t, _ := arg["type"].(string)
+		m := abiFixedTypeRegex.FindStringSubmatch(t)
+		if m == nil {
+			continue
+		}
+
+		// the size defaults to 128 bits with 18 decimals
+		bits, decimals := m[2], m[3]
+		if bits == "" {
+			bits, decimals = "128", "18"
+		}
+
+		it := "int" + bits
+		if m[1] == "ufixed" {
+			it = "uint" + bits
+		}
+
+		arg["type"] = "tuple" + m[4]
+		arg["components"] = []interface{}{map[string]interface{}{"name": m[1] + bits + "x" + decimals, "type": it}}
+		delete(arg, "internalType")
+	}
+}
+
+// restoreAbiSignatures calculates signatures and identifiers of methods, events and errors
+// using the original names of the replaced fixed point types.
+func restoreAbiSignatures(ab *abi.ABI) {
+	for name, m := range ab.Methods {
+		m.Sig = abiSignature(m.RawName, m.Inputs)
+		m.ID = crypto.Keccak256([]byte(m.Sig))[:4]
+		ab.Methods[name] = m
+	}
+	for name, ev := range ab.Events {
+		ev.Sig = abiSignature(ev.RawName, ev.Inputs)
+		ev.ID = crypto.Keccak256Hash([]byte(ev.Sig))
+		ab.Events[name] = ev
+	}
+	for name, e := range ab.Errors {
+		e.Sig = abiSignature(e.Name, e.Inputs)
+		e.ID = crypto.Keccak256Hash([]byte(e.Sig))
+		ab.Errors[name] = e
+	}
+}
+
+// abiSignature builds the canonical signature of the given name and arguments.
+func abiSignature(name string, args abi.Arguments) string {
+	list := make([]string, len(args))
+	for i, a := range args {
+		list[i] = abiTypeString(a.Type)
+	}
+	return fmt.Sprintf("%s(%s)", name, strings.Join(list, ","))
+}
+
+// abiTypeString provides the canonical name of the ABI type;
+// the replaced fixed point types are reported under their original name.
+func abiTypeString(t abi.Type) string {
+	if _, ok := abiFixedDecimals(t); ok {
+		return t.TupleRawNames[0]
+	}
+
+	switch t.T {
+	case abi.TupleTy:
+		list := make([]string, len(t.TupleElems))
+		for i, el := range t.TupleElems {
+			list[i] = abiTypeString(*el)
+		}
+		return "(" + strings.Join(list, ",") + ")"
+	case abi.SliceTy:
+		return abiTypeString(*t.Elem) + "[]"
+	case abi.ArrayTy:
+		return fmt.Sprintf("%s[%d]", abiTypeString(*t.Elem), t.Size)
+	}
+	return t.String()
+}
+
+// abiFixedDecimals checks the type is a replaced fixed point type
+// and provides the number of its decimals.
+func abiFixedDecimals(t abi.Type) (int, bool) {
+	if t.T != abi.TupleTy || len(t.TupleElems) != 1 || len(t.TupleRawNames) != 1 {
+		return 0, false
+	}
+	if el := t.TupleElems[0]; el.T != abi.IntTy && el.T != abi.UintTy {
+		return 0, false
+	}
+
+	m := abiFixedNameRegex.FindStringSubmatch(t.TupleRawNames[0])
+	if m == nil {
+		return 0, false
+	}
+	dec, err := strconv.Atoi(m[1])
+	if err != nil {
+		return 0, false
+	}
+	return dec, true
+}
+
+// abiValueString provides the text form of a decoded ABI value of the given type.
+// Numbers are provided in decimal, fixed point numbers with the decimal point,
+// addresses, bytes and byte arrays in hex. Arrays and tuples are encoded as JSON arrays
+// and objects keyed by the tuple component names; numbers inside are kept as strings
+// so no precision is lost.
+func abiValueString(t abi.Type, v interface{}) string {
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	if !rv.IsValid() {
+		return ""
+	}
+
+	if _, ok := abiFixedDecimals(t); !ok && (t.T == abi.TupleTy || t.T == abi.SliceTy || t.T == abi.ArrayTy) {
+		var sb strings.Builder
+		writeAbiJSON(&sb, t, rv)
+		return sb.String()
+	}
+	return abiScalarString(t, rv)
+}
+
+// writeAbiJSON writes the JSON form of the decoded ABI value to the builder.
+func writeAbiJSON(sb *strings.Builder, t abi.Type, rv reflect.Value) {
+	rv = reflect.Indirect(rv)
+	if _, ok := abiFixedDecimals(t); ok {
+		sb.WriteString(strconv.Quote(abiScalarString(t, rv)))
+		return
+	}
+
+	switch t.T {
+	case abi.SliceTy, abi.ArrayTy:
+		sb.WriteByte('[')
+		for i := 0; i < rv.Len(); i++ {
+			if i > 0 {
+				sb.WriteByte(',')
+			}
+			writeAbiJSON(sb, *t.Elem, rv.Index(i))
+		}
+		sb.WriteByte(']')
+	case abi.TupleTy:
+		sb.WriteByte('{')
+		for i, el := range t.TupleElems {
+			if i > 0 {
+				sb.WriteByte(',')
+			}
+			sb.WriteString(strconv.Quote(t.TupleRawNames[i]))
+			sb.WriteByte(':')
+			writeAbiJSON(sb, *el, rv.Field(i))
+		}
+		sb.WriteByte('}')
+	case abi.BoolTy:
+		sb.WriteString(strconv.FormatBool(rv.Bool()))
+	default:
+		sb.WriteString(strconv.Quote(abiScalarString(t, rv)))
+	}
+}
+
+// abiScalarString provides the text form of a decoded non-composite ABI value.
+func abiScalarString(t abi.Type, rv reflect.Value) string {
+	if dec, ok := abiFixedDecimals(t); ok {
+		return abiFixedString(abiInteger(rv.Field(0)), dec)
+	}
+
+	switch t.T {
+	case abi.IntTy, abi.UintTy:
+		return abiInteger(rv).String()
+	case abi.BoolTy:
+		return strconv.FormatBool(rv.Bool())
+	case abi.AddressTy:
+		if addr, ok := rv.Interface().(common.Address); ok {
+			return addr.Hex()
+		}
+	case abi.StringTy:
+		return rv.String()
+	case abi.BytesTy:
+		return hexutil.Encode(rv.Bytes())
+	case abi.FixedBytesTy, abi.FunctionTy, abi.HashTy:
+		if rv.Kind() == reflect.Array {
+			b := make([]byte, rv.Len())
+			reflect.Copy(reflect.ValueOf(b), rv)
+			return hexutil.Encode(b)
+		}
+	}
+	return fmt.Sprintf("%v", rv.Interface())
+}
+
+// abiInteger converts the decoded integer value of any size into big integer.
+func abiInteger(rv reflect.Value) *big.Int {
+	rv = reflect.Indirect(rv)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return big.NewInt(rv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return new(big.Int).SetUint64(rv.Uint())
+	}
+	if rv.CanAddr() {
+		if bi, ok := rv.Addr().Interface().(*big.Int); ok {
+			return bi
+		}
+	}
+	if bi, ok := rv.Interface().(big.Int); ok {
+		return &bi
+	}
+	return new(big.Int)
+}
+
+// abiFixedString formats the integer representation of a fixed point number
+// with the given number of decimals; trailing zeros of the fraction are dropped.
+func abiFixedString(n *big.Int, decimals int) string {
+	if decimals == 0 {
+		return n.String()
+	}
+
+	digits := new(big.Int).Abs(n).String()
+	if len(digits) <= decimals {
+		digits = strings.Repeat("0", decimals-len(digits)+1) + digits
+	}
+
+	whole, frac := digits[:len(digits)-decimals], strings.TrimRight(digits[len(digits)-decimals:], "0")
+	out := whole
+	if frac != "" {
+		out = whole + "." + frac
+	}
+	if n.Sign() < 0 {
+		out = "-" + out
+	}
+	return out
+}
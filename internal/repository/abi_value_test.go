@@ -0,0 +1,185 @@
+package repository
+
+import (
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/onsi/gomega"
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestAbiValueString(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	minInt256 := new(big.Int).Neg(new(big.Int).Lsh(big.NewInt(1), 255))
+	maxUint256 := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+
+	tests := []struct {
+		name  string
+		typ   string
+		comp  []abi.ArgumentMarshaling
+		value interface{}
+		want  string
+	}{
+		{name: "int8 negative", typ: "int8", value: int8(-128), want: "-128"},
+		{name: "int64 negative", typ: "int64", value: int64(math.MinInt64), want: "-9223372036854775808"},
+		{name: "int24 negative", typ: "int24", value: big.NewInt(-8388608), want: "-8388608"},
+		{name: "int256 minimum", typ: "int256", value: minInt256, want: minInt256.String()},
+		{name: "uint8", typ: "uint8", value: uint8(255), want: "255"},
+		{name: "uint64 maximum", typ: "uint64", value: uint64(math.MaxUint64), want: "18446744073709551615"},
+		{name: "uint256 maximum", typ: "uint256", value: maxUint256, want: "115792089237316195423570985008687907853269984665640564039457584007913129639935"},
+		{name: "bool", typ: "bool", value: true, want: "true"},
+		{name: "address", typ: "address", value: common.HexToAddress("0xabc00fa001230012300abc0012300fa00face000"), want: "0xabc00FA001230012300aBc0012300Fa00FACE000"},
+		{name: "bytes1", typ: "bytes1", value: [1]byte{0x7f}, want: "0x7f"},
+		{name: "bytes4", typ: "bytes4", value: [4]byte{0xde, 0xad, 0xbe, 0xef}, want: "0xdeadbeef"},
+		{name: "bytes", typ: "bytes", value: []byte{0x01, 0x02}, want: "0x0102"},
+		{name: "empty bytes", typ: "bytes", value: []byte{}, want: "0x"},
+		{name: "string", typ: "string", value: "a \"quoted\" text", want: "a \"quoted\" text"},
+		{name: "int16 array", typ: "int16[2]", value: [2]int16{-1, 1}, want: `["-1","1"]`},
+		{name: "bytes2 slice", typ: "bytes2[]", value: [][2]byte{{0xab, 0xcd}}, want: `["0xabcd"]`},
+		{name: "nested slices", typ: "uint256[][]", value: [][]*big.Int{{big.NewInt(1)}, {}}, want: `[["1"],[]]`},
+		{
+			name: "tuple",
+			typ:  "tuple",
+			comp: []abi.ArgumentMarshaling{{Name: "ok", Type: "bool"}, {Name: "text", Type: "string"}},
+			value: struct {
+				Ok   bool
+				Text string
+			}{true, "x\"y"},
+			want: `{"ok":true,"text":"x\"y"}`,
+		},
+	}
+
+	for _, tc := range tests {
+		typ, err := abi.NewType(tc.typ, "", tc.comp)
+		g.Expect(err).NotTo(gomega.HaveOccurred(), tc.name)
+		g.Expect(abiValueString(typ, tc.value)).To(gomega.Equal(tc.want), tc.name)
+	}
+}
+
+func TestAbiFixedString(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	tests := []struct {
+		value    *big.Int
+		decimals int
+		want     string
+	}{
+		{value: big.NewInt(1500000000000000000), decimals: 18, want: "1.5"},
+		{value: big.NewInt(-1500000000000000000), decimals: 18, want: "-1.5"},
+		{value: big.NewInt(1), decimals: 18, want: "0.000000000000000001"},
+		{value: big.NewInt(-5), decimals: 1, want: "-0.5"},
+		{value: big.NewInt(20), decimals: 1, want: "2"},
+		{value: big.NewInt(0), decimals: 18, want: "0"},
+		{value: big.NewInt(-123), decimals: 0, want: "-123"},
+	}
+
+	for _, tc := range tests {
+		g.Expect(abiFixedString(tc.value, tc.decimals)).To(gomega.Equal(tc.want), tc.value.String())
+	}
+}
+
+func TestDecodeCallMethodTypes(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	const def = `[{"type":"function","name":"store","inputs":[
+		{"name":"small","type":"int8"},
+		{"name":"large","type":"int256"},
+		{"name":"rate","type":"fixed128x18"},
+		{"name":"ratio","type":"ufixed"},
+		{"name":"rates","type":"fixed8x1[]"},
+		{"name":"tag","type":"bytes3"},
+		{"name":"data","type":"tuple","components":[
+			{"name":"id","type":"uint256"},
+			{"name":"items","type":"tuple[]","components":[
+				{"name":"delta","type":"int16"},
+				{"name":"inner","type":"tuple","components":[
+					{"name":"code","type":"bytes2"},
+					{"name":"price","type":"fixed16x2"}
+				]}
+			]}
+		]}
+	],"outputs":[]}]`
+
+	ab, err := parseContractAbi(def)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+
+	const sig = "store(int8,int256,fixed128x18,ufixed128x18,fixed8x1[],bytes3,(uint256,(int16,(bytes2,fixed16x2))[]))"
+	m := ab.Methods["store"]
+	g.Expect(m.Sig).To(gomega.Equal(sig))
+	g.Expect(m.ID).To(gomega.Equal(crypto.Keccak256([]byte(sig))[:4]))
+
+	type fixed8 struct{ Fixed8x1 int8 }
+	type inner struct {
+		Code  [2]byte
+		Price struct{ Fixed16x2 int16 }
+	}
+	type item struct {
+		Delta int16
+		Inner inner
+	}
+	data := struct {
+		Id    *big.Int
+		Items []item
+	}{
+		Id: big.NewInt(7),
+		Items: []item{
+			{Delta: -300, Inner: inner{Code: [2]byte{0x12, 0x34}, Price: struct{ Fixed16x2 int16 }{-1250}}},
+			{Delta: 1, Inner: inner{Code: [2]byte{0xff, 0x00}, Price: struct{ Fixed16x2 int16 }{5}}},
+		},
+	}
+
+	input, err := m.Inputs.Pack(
+		int8(-1),
+		new(big.Int).Neg(new(big.Int).Lsh(big.NewInt(1), 200)),
+		struct{ Fixed128x18 *big.Int }{big.NewInt(-2500000000000000000)},
+		struct{ Ufixed128x18 *big.Int }{big.NewInt(1)},
+		[]fixed8{{-15}, {3}},
+		[3]byte{0xaa, 0xbb, 0xcc},
+		data,
+	)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+
+	dc := types.DecodedCall{}
+	found, err := decodeCallMethod(&ab, append(m.ID, input...), 0, &dc)
+	g.Expect(found).To(gomega.BeTrue())
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(dc.Signature).To(gomega.Equal(sig))
+
+	want := []types.DecodedCallArg{
+		{Name: "small", Type: "int8", Value: "-1"},
+		{Name: "large", Type: "int256", Value: "-1606938044258990275541962092341162602522202993782792835301376"},
+		{Name: "rate", Type: "fixed128x18", Value: "-2.5"},
+		{Name: "ratio", Type: "ufixed128x18", Value: "0.000000000000000001"},
+		{Name: "rates", Type: "fixed8x1[]", Value: `["-1.5","0.3"]`},
+		{Name: "tag", Type: "bytes3", Value: "0xaabbcc"},
+		{
+			Name:  "data",
+			Type:  "(uint256,(int16,(bytes2,fixed16x2))[])",
+			Value: `{"id":"7","items":[{"delta":"-300","inner":{"code":"0x1234","price":"-12.5"}},{"delta":"1","inner":{"code":"0xff00","price":"0.05"}}]}`,
+		},
+	}
+	g.Expect(dc.Args).To(gomega.Equal(want))
+}
+
+func TestIndexedEventValue(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	ab, err := parseContractAbi(`[{"type":"event","name":"Rate","anonymous":false,"inputs":[
+		{"name":"rate","type":"fixed8x1","indexed":true},
+		{"name":"delta","type":"int32","indexed":true}
+	]}]`)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+
+	ev := ab.Events["Rate"]
+	g.Expect(ev.Sig).To(gomega.Equal("Rate(fixed8x1,int32)"))
+	g.Expect(ev.ID).To(gomega.Equal(crypto.Keccak256Hash([]byte("Rate(fixed8x1,int32)"))))
+
+	// negative values are sign extended to the full topic
+	neg := common.BytesToHash(common.FromHex("0xfffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff1"))
+	g.Expect(indexedEventValue(ev.Inputs[0], neg)).To(gomega.Equal("-1.5"))
+	g.Expect(indexedEventValue(ev.Inputs[1], neg)).To(gomega.Equal("-15"))
+}
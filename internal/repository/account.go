@@ -71,20 +71,29 @@ func (p *proxy) AccountBalance(addr *common.Address) (*hexutil.Big, error) {
 	return p.rpc.AccountBalance(addr)
 }
 
+// AccountBalanceAt returns the balance of an account at the block identified by the given tag.
+func (p *proxy) AccountBalanceAt(addr *common.Address, tag types.BlockTag) (*hexutil.Big, error) {
+	if err := p.checkStateAvailableAt(tag); err != nil {
+		return nil, err
+	}
+	return p.rpc.AccountBalanceAt(addr, tag)
+}
+
 // AccountNonce returns the current number of sent transactions of an account at Opera blockchain.
 func (p *proxy) AccountNonce(addr *common.Address) (*hexutil.Uint64, error) {
 	return p.rpc.AccountNonce(addr)
 }
 
 // AccountTransactions returns slice of AccountTransaction structure for a given account at Opera blockchain.
-func (p *proxy) AccountTransactions(addr *common.Address, rec *common.Address, cursor *string, count int32) (*types.TransactionList, error) {
+// Only the given optional parts of the transactions are loaded.
+func (p *proxy) AccountTransactions(addr *common.Address, rec *common.Address, cursor *string, count int32, fields types.TrxFields) (*types.TransactionList, error) {
 	// do we have an account?
 	if addr == nil {
 		return nil, fmt.Errorf("can not get transaction list for empty account")
 	}
 
 	// go to the database for the list of hashes of transaction searched
-	return p.db.AccountTransactions(addr, rec, cursor, count)
+	return p.db.AccountTransactions(addr, rec, cursor, count, fields)
 }
 
 // AccountsActive returns total number of accounts known to repository.
@@ -93,6 +102,70 @@ func (p *proxy) AccountsActive() (hexutil.Uint64, error) {
 	return hexutil.Uint64(val), err
 }
 
+// AreContracts checks which of the given accounts are smart contracts. The stored account
+// types are used for known accounts; the code of the unknown accounts is checked
+// on the node in a single batch. Flags of accounts failing to load are left nil.
+func (p *proxy) AreContracts(addr []common.Address) ([]*bool, error) {
+	res := make([]*bool, len(addr))
+
+	// try the cache first
+	missing := make([]int, 0, len(addr))
+	for i := range addr {
+		if res[i] = p.cache.PullIsContract(&addr[i]); res[i] == nil {
+			missing = append(missing, i)
+		}
+	}
+	if len(missing) == 0 {
+		return res, nil
+	}
+
+	// try the stored account types
+	known, err := p.db.AccountTypes(pickAddresses(addr, missing))
+	if err != nil {
+		return nil, err
+	}
+
+	unknown := make([]int, 0, len(missing))
+	for _, i := range missing {
+		at, ok := known[addr[i]]
+		if !ok {
+			unknown = append(unknown, i)
+			continue
+		}
+		p.setIsContract(res, addr, i, at != types.AccountTypeWallet)
+	}
+	if len(unknown) == 0 {
+		return res, nil
+	}
+
+	// check the code of the accounts not known yet
+	codes, err := p.rpc.AccountCodes(pickAddresses(addr, unknown))
+	if err != nil {
+		return nil, err
+	}
+	for j, i := range unknown {
+		if codes[j] != nil {
+			p.setIsContract(res, addr, i, *codes[j] > 0)
+		}
+	}
+	return res, nil
+}
+
+// setIsContract sets the contract flag of the account at the given index and caches it.
+func (p *proxy) setIsContract(res []*bool, addr []common.Address, i int, val bool) {
+	res[i] = &val
+	p.cache.PushIsContract(&addr[i], val)
+}
+
+// pickAddresses provides the addresses at the given indexes.
+func pickAddresses(addr []common.Address, index []int) []common.Address {
+	list := make([]common.Address, len(index))
+	for j, i := range index {
+		list[j] = addr[i]
+	}
+	return list
+}
+
 // AccountIsKnown checks if the account of the given address is known to the API server.
 func (p *proxy) AccountIsKnown(addr *common.Address) bool {
 	// try cache first
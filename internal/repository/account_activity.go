@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"bytes"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// AccountActivity provides the list of contracts called by the given account
+// with the breakdown of the called functions, sorted by the number of calls.
+// Function names are resolved from the known ABI of the contract, or its implementation
+// for proxy contracts; only the selector is provided for unknown functions.
+func (p *proxy) AccountActivity(addr *common.Address, cursor *string, count int32) (*types.AccountActivity, error) {
+	list, err := p.db.AccountActivity(addr, cursor, count)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, ca := range list.Collection {
+		p.activityFunctionNames(ca)
+	}
+	return list, nil
+}
+
+// activityFunctionNames resolves names of the functions called on the contract.
+func (p *proxy) activityFunctionNames(ca *types.ContractActivity) {
+	abiDef, err := p.decodingAbi(&ca.Contract, nil)
+	if err != nil {
+		p.log.Debugf("can not resolve functions of %s; %s", ca.Contract.String(), err.Error())
+		return
+	}
+	if abiDef == "" {
+		return
+	}
+
+	ab, err := parseContractAbi(abiDef)
+	if err != nil {
+		return
+	}
+
+	for _, fn := range ca.Functions {
+		for _, m := range ab.Methods {
+			if bytes.Equal(m.ID[:callSelectorLength], fn.Selector) {
+				fn.Name = m.Name
+				fn.Signature = m.Sig
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// AccountStats provides the transaction activity summary of the given account.
+func (p *proxy) AccountStats(addr *common.Address) (*types.AccountStats, error) {
+	// try cache first
+	if as := p.cache.PullAccountStats(addr); as != nil {
+		return as, nil
+	}
+
+	as, err := p.db.AccountStats(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	// contracts are registered with the creation transaction and a contract type
+	acc, err := p.db.Account(addr)
+	if err != nil {
+		return nil, err
+	}
+	as.IsContract = acc != nil && (acc.ContractTx != nil || (acc.Type != "" && acc.Type != types.AccountTypeWallet))
+
+	if err := p.cache.PushAccountStats(as); err != nil {
+		p.log.Errorf("can not cache account stats of %s; %s", addr.String(), err.Error())
+	}
+	return as, nil
+}
@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// AccountTokenTransfers provides list of token transfers of the given account,
+// both sent and received, optionally limited to the given token and to the given
+// range of block time stamps (UNIX time, inclusive). Tokens of all the types are included,
+// approvals are not.
+func (p *proxy) AccountTokenTransfers(acc *common.Address, token *common.Address, from *uint64, to *uint64, cursor *string, count int32) (*types.TokenTransactionList, error) {
+	fi := bson.D{
+		{Key: "$or", Value: bson.A{
+			bson.D{{Key: types.FiTokenTransactionSender, Value: acc.String()}},
+			bson.D{{Key: types.FiTokenTransactionRecipient, Value: acc.String()}},
+		}},
+		{Key: types.FiTokenTransactionType, Value: bson.D{{Key: "$in", Value: bson.A{
+			types.TokenTrxTypeTransfer,
+			types.TokenTrxTypeMint,
+			types.TokenTrxTypeBurn,
+		}}}},
+	}
+
+	// filter specific token
+	if token != nil {
+		fi = append(fi, bson.E{Key: types.FiTokenTransactionToken, Value: token.String()})
+	}
+
+	// time range of the transfers
+	if from != nil || to != nil {
+		ts := bson.D{}
+		if from != nil {
+			ts = append(ts, bson.E{Key: "$gte", Value: *from})
+		}
+		if to != nil {
+			ts = append(ts, bson.E{Key: "$lte", Value: *to})
+		}
+		fi = append(fi, bson.E{Key: types.FiTokenTransactionTimeStamp, Value: ts})
+	}
+
+	return p.db.Erc20Transactions(cursor, count, &fi)
+}
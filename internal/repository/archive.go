@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// archiveRecentStateWindow is the number of the most recent blocks a non-archive node
+// is expected to keep the state of.
+const archiveRecentStateWindow = 128
+
+// checkStateAvailable verifies the state of the given block can be read from the connected node.
+// Historical blocks are rejected with ErrArchiveRequired if the node is not configured
+// as an archive node; nil block represents the latest state.
+func (p *proxy) checkStateAvailable(block *hexutil.Uint64) error {
+	if block == nil || p.cfg.Opera.Archive {
+		return nil
+	}
+
+	head, err := p.rpc.BlockHeight()
+	if err != nil {
+		return err
+	}
+	if uint64(*block)+archiveRecentStateWindow < head.ToInt().Uint64() {
+		return fmt.Errorf("%w; block #%d", ErrArchiveRequired, uint64(*block))
+	}
+	return nil
+}
+
+// checkStateAvailableAt verifies the state of the block identified by the given tag
+// can be read from the connected node. Named tags other than earliest refer to recent blocks.
+func (p *proxy) checkStateAvailableAt(tag types.BlockTag) error {
+	if p.cfg.Opera.Archive {
+		return nil
+	}
+
+	switch {
+	case tag == types.BlockTagEarliest:
+		return fmt.Errorf("%w; block %s", ErrArchiveRequired, tag)
+	case tag.IsNumber():
+		num, err := hexutil.DecodeUint64(string(tag))
+		if err != nil {
+			return err
+		}
+		block := hexutil.Uint64(num)
+		return p.checkStateAvailable(&block)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// AccountsByAddress returns accounts for the given list of addresses.
+// The in-memory cache is consulted first and the remaining accounts are loaded
+// from the database in a single batch. Unknown addresses resolve to wallet accounts
+// the same way the Account call does.
+func (p *proxy) AccountsByAddress(addr []common.Address) (map[common.Address]*types.Account, error) {
+	res := make(map[common.Address]*types.Account, len(addr))
+	missing := make([]common.Address, 0, len(addr))
+	for i := range addr {
+		if acc := p.cache.PullAccount(&addr[i]); acc != nil {
+			res[addr[i]] = acc
+			continue
+		}
+		missing = append(missing, addr[i])
+	}
+	if len(missing) == 0 {
+		return res, nil
+	}
+
+	// load the rest from the database
+	found, err := p.db.AccountsByAddress(missing)
+	if err != nil {
+		p.log.Errorf("can not load accounts batch; %s", err.Error())
+		return nil, err
+	}
+
+	for i := range missing {
+		acc, ok := found[missing[i]]
+		if !ok {
+			// not in the database; build it the slow way
+			acc, err = p.getAccount(&missing[i])
+			if err != nil {
+				return nil, err
+			}
+			res[missing[i]] = acc
+			continue
+		}
+
+		if err = p.cache.PushAccount(acc); err != nil {
+			p.log.Warningf("can not keep account [%s] information in memory; %s", acc.Address.Hex(), err.Error())
+		}
+		res[missing[i]] = acc
+	}
+	return res, nil
+}
+
+// ContractsByAddress returns smart contracts for the given list of addresses.
+// Addresses without a known contract are not present in the resulting map.
+func (p *proxy) ContractsByAddress(addr []common.Address) (map[common.Address]*types.Contract, error) {
+	res := make(map[common.Address]*types.Contract, len(addr))
+	missing := make([]common.Address, 0, len(addr))
+	for i := range addr {
+		if sc := p.cache.PullContract(&addr[i]); sc != nil {
+			res[addr[i]] = sc
+			continue
+		}
+		missing = append(missing, addr[i])
+	}
+	if len(missing) == 0 {
+		return res, nil
+	}
+
+	// load the rest from the database
+	found, err := p.db.ContractsByAddress(missing)
+	if err != nil {
+		p.log.Errorf("can not load contracts batch; %s", err.Error())
+		return nil, err
+	}
+
+	for a, sc := range found {
+		if err = p.cache.PushContract(sc); err != nil {
+			p.log.Criticalf("can not cache contract %s; %s", a.String(), err.Error())
+		}
+		res[a] = sc
+	}
+	return res, nil
+}
@@ -73,6 +73,35 @@ func (p *proxy) BlockByHash(hash *common.Hash) (*types.Block, error) {
 	return p.getBlock(hash.String(), p.rpc.BlockByHash)
 }
 
+// BlocksByNumber returns a range of blocks starting at the given number loaded in a single batch.
+// The list ends before the first block not available, so it may be shorter than requested.
+func (p *proxy) BlocksByNumber(from uint64, count int) ([]*types.Block, error) {
+	list, err := p.rpc.BlocksByNumber(from, count)
+	if err != nil {
+		// the node may not support batch calls; load the blocks one by one
+		list = make([]*types.Block, 0, count)
+		for i := 0; i < count; i++ {
+			num := hexutil.Uint64(from + uint64(i))
+			blk, err := p.BlockByNumber(&num)
+			if err != nil {
+				break
+			}
+			list = append(list, blk)
+		}
+		return list, nil
+	}
+
+	for i, blk := range list {
+		if blk == nil {
+			return list[:i], nil
+		}
+		if err := p.cache.PushBlock(hexutil.Uint64(from+uint64(i)).String(), blk); err != nil {
+			p.log.Errorf("can not cache; %s", err.Error())
+		}
+	}
+	return list, nil
+}
+
 // getBlock gets a block of given tag from cache, or from a repository pull function.
 func (p *proxy) getBlock(tag string, pull func(*string) (*types.Block, error)) (*types.Block, error) {
 	// inform what we do
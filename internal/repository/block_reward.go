@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"math/big"
+)
+
+// BlockReward provides the reward attributable to the block of the given number.
+// The rewards are distributed per epoch, so the block is attributed the base reward
+// minted over the time since its parent block at the base reward rate of its epoch,
+// and the fees paid by its transactions.
+func (p *proxy) BlockReward(num *hexutil.Uint64) (*types.BlockReward, error) {
+	blk, err := p.BlockByNumber(num)
+	if err != nil {
+		return nil, err
+	}
+
+	br := types.BlockReward{
+		Block: blk.Number,
+		Epoch: blk.Epoch,
+	}
+
+	// the node may not report the creator of the block
+	if blk.Miner != (common.Address{}) {
+		br.Producer = &blk.Miner
+	}
+
+	if err := p.blockBaseReward(blk, &br); err != nil {
+		return nil, err
+	}
+	if err := p.blockFees(blk, &br); err != nil {
+		return nil, err
+	}
+	return &br, nil
+}
+
+// blockBaseReward calculates the share of the epoch base reward minted over the block duration.
+func (p *proxy) blockBaseReward(blk *types.Block, br *types.BlockReward) error {
+	if blk.Number > 0 {
+		prev := blk.Number - 1
+		parent, err := p.BlockByNumber(&prev)
+		if err != nil {
+			return err
+		}
+		if blk.TimeStamp > parent.TimeStamp {
+			br.Duration = uint64(blk.TimeStamp - parent.TimeStamp)
+		}
+	}
+
+	// the rate of an epoch not sealed yet is not known; the last sealed epoch rate applies
+	ep, err := p.blockRateEpoch(blk)
+	if err != nil {
+		return err
+	}
+
+	br.RateEpoch = ep.Id
+	br.BaseRewardPerSecond = ep.BaseRewardPerSecond
+	br.BaseReward = hexutil.Big(*new(big.Int).Mul(ep.BaseRewardPerSecond.ToInt(), new(big.Int).SetUint64(br.Duration)))
+	return nil
+}
+
+// blockRateEpoch provides the sealed epoch the base reward rate of the block is taken from.
+func (p *proxy) blockRateEpoch(blk *types.Block) (*types.Epoch, error) {
+	last, err := p.rpc.CurrentSealedEpoch()
+	if err != nil {
+		return nil, err
+	}
+	if blk.Epoch != nil && *blk.Epoch <= last {
+		return p.Epoch(blk.Epoch)
+	}
+	return p.Epoch(&last)
+}
+
+// blockFees calculates the fees paid by the transactions of the block and the burnt portion of them.
+func (p *proxy) blockFees(blk *types.Block, br *types.BlockReward) error {
+	fees := new(big.Int)
+	for _, h := range blk.Txs {
+		trx, err := p.Transaction(h, false)
+		if err != nil {
+			return err
+		}
+		if trx.GasUsed == nil {
+			continue
+		}
+
+		price := trx.GasPrice.ToInt()
+		if trx.EffectiveGasPrice != nil {
+			price = trx.EffectiveGasPrice.ToInt()
+		}
+		fees.Add(fees, new(big.Int).Mul(price, new(big.Int).SetUint64(uint64(*trx.GasUsed))))
+	}
+	br.TransactionFees = hexutil.Big(*fees)
+
+	if blk.BaseFeePerGas != nil {
+		br.BurntFees = (*hexutil.Big)(new(big.Int).Mul(blk.BaseFeePerGas.ToInt(), new(big.Int).SetUint64(uint64(blk.GasUsed))))
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// BlockTransactions provides a page of transactions of the given block in the order
+// of their execution. Positive count loads transactions after the cursor, negative
+// count loads transactions before the cursor; the cursor is a transaction hash.
+// The block is immutable, so the pagination is stable.
+func (p *proxy) BlockTransactions(blk *types.Block, cursor *string, count int32) (*types.TransactionList, error) {
+	total := len(blk.Txs)
+
+	// find the range boundaries
+	from, to, err := blockTrxRange(blk, cursor, count)
+	if err != nil {
+		return nil, err
+	}
+
+	list := types.TransactionList{
+		Collection: make([]*types.Transaction, 0, to-from),
+		Total:      uint64(total),
+		First:      uint64(from),
+		Last:       uint64(to),
+		IsStart:    from == 0,
+		IsEnd:      to >= total,
+	}
+
+	// load the transactions
+	for _, hash := range blk.Txs[from:to] {
+		trx, err := p.Transaction(hash, false)
+		if err != nil {
+			p.log.Errorf("can not load transaction %s of block %d; %s", hash.String(), uint64(blk.Number), err.Error())
+			return nil, err
+		}
+		list.Collection = append(list.Collection, trx)
+	}
+	return &list, nil
+}
+
+// blockTrxRange calculates the range of block transactions to be loaded.
+func blockTrxRange(blk *types.Block, cursor *string, count int32) (int, int, error) {
+	total := len(blk.Txs)
+
+	// no cursor; start from the top, or the bottom for negative count
+	if cursor == nil {
+		if count > 0 {
+			return 0, minInt(int(count), total), nil
+		}
+		return maxInt(total+int(count), 0), total, nil
+	}
+
+	// locate the cursor
+	ix := -1
+	hash := common.HexToHash(*cursor)
+	for i, h := range blk.Txs {
+		if *h == hash {
+			ix = i
+			break
+		}
+	}
+	if ix < 0 {
+		return 0, 0, fmt.Errorf("%w; transaction %s not found in block %d", ErrInvalidCursor, *cursor, uint64(blk.Number))
+	}
+
+	if count > 0 {
+		return ix + 1, minInt(ix+1+int(count), total), nil
+	}
+	return maxInt(ix+int(count), 0), ix, nil
+}
+
+// minInt returns the smaller of the two integers.
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// maxInt returns the larger of the two integers.
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -4,12 +4,21 @@ package cache
 import (
 	"fantom-api-graphql/internal/types"
 	"fmt"
+	"github.com/allegro/bigcache"
 	"github.com/ethereum/go-ethereum/common"
 	"strings"
+	"time"
 )
 
 const accountExistenceCacheIdPrefix = "acc_"
 
+// accountIsContractPrefix is the prefix of the cache key of the account contract flag.
+const accountIsContractPrefix = "acc_isc_"
+
+// accountIsContractTTL is the time the account contract flag is kept in cache;
+// contracts may be deployed to, or destroyed on an address.
+const accountIsContractTTL = 10 * time.Minute
+
 // accountId generates cache id for storing account details.
 func accountId(addr *common.Address) string {
 	var sb strings.Builder
@@ -58,6 +67,14 @@ func (b *MemBridge) PushAccount(acc *types.Account) error {
 	return b.cache.Set(accountId(&acc.Address), data)
 }
 
+// EvictAccount removes the account from the in-memory cache.
+func (b *MemBridge) EvictAccount(addr *common.Address) {
+	err := b.cache.Delete(accountId(addr))
+	if err != nil && err != bigcache.ErrEntryNotFound {
+		b.log.Criticalf("cache error %s", err.Error())
+	}
+}
+
 // CheckAccountKnown verifies if the cache is aware of the account existence
 // in the database.
 func (b *MemBridge) CheckAccountKnown(addr *common.Address) *bool {
@@ -80,3 +97,25 @@ func (b *MemBridge) PushAccountKnown(addr *common.Address) {
 		b.log.Errorf("can not cache account %s existence; %s", addr.String(), err.Error())
 	}
 }
+
+// PullIsContract extracts the flag of the account being a contract from cache, if possible.
+func (b *MemBridge) PullIsContract(addr *common.Address) *bool {
+	data := b.getTTL(accountIsContractPrefix + addr.String())
+	if len(data) == 0 {
+		return nil
+	}
+
+	val := data[0] > 0
+	return &val
+}
+
+// PushIsContract stores the flag of the account being a contract in cache.
+func (b *MemBridge) PushIsContract(addr *common.Address, val bool) {
+	data := []byte{0}
+	if val {
+		data[0] = 1
+	}
+	if err := b.setTTL(accountIsContractPrefix+addr.String(), data, accountIsContractTTL); err != nil {
+		b.log.Errorf("can not cache account %s contract flag; %s", addr.String(), err.Error())
+	}
+}
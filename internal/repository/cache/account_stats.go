@@ -0,0 +1,56 @@
+// Package cache implements bridge to fast in-memory object cache.
+package cache
+
+import (
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"strings"
+	"time"
+)
+
+// accountStatsCacheKeyPrefix is the prefix used for cache key to store account stats.
+const accountStatsCacheKeyPrefix = "acs_"
+
+// accountStatsCacheTTL is the time the account stats are kept in cache.
+const accountStatsCacheTTL = 30 * time.Second
+
+// PullAccountStats extracts account stats from the in-memory cache if available.
+func (b *MemBridge) PullAccountStats(addr *common.Address) *types.AccountStats {
+	data := b.getTTL(accountStatsKey(addr))
+	if data == nil {
+		return nil
+	}
+
+	// do we have the data?
+	as, err := types.UnmarshalAccountStats(data)
+	if err != nil {
+		b.log.Criticalf("can not decode account stats from in-memory cache; %s", err.Error())
+		return nil
+	}
+	return as
+}
+
+// PushAccountStats stores provided account stats in the in-memory cache.
+func (b *MemBridge) PushAccountStats(as *types.AccountStats) error {
+	if nil == as {
+		return fmt.Errorf("undefined account stats can not be pushed to the in-memory cache")
+	}
+
+	data, err := as.Marshal()
+	if err != nil {
+		b.log.Criticalf("can not marshal account stats to JSON; %s", err.Error())
+		return err
+	}
+	return b.setTTL(accountStatsKey(&as.Address), data, accountStatsCacheTTL)
+}
+
+// accountStatsKey builds a cache key for account stats of the given account.
+func accountStatsKey(addr *common.Address) string {
+	var sb strings.Builder
+
+	sb.WriteString(accountStatsCacheKeyPrefix)
+	sb.WriteString(addr.String())
+
+	return sb.String()
+}
@@ -25,6 +25,9 @@ type MemBridge struct {
 	// ring of the most recent blocks and transactions
 	blkRing *ring.Ring
 	trxRing *ring.Ring
+
+	// persistent tier of the cache, nil if disabled
+	disk *diskCache
 }
 
 // New creates a new BigCache bridge.
@@ -39,10 +42,21 @@ func New(cfg *config.Config, log logger.Logger) (*MemBridge, error) {
 	// log the event
 	log.Notice("memory cache initialized")
 
+	// make the persistent tier, if enabled
+	dc, err := newDiskCache(&cfg.Cache.Disk, log)
+	if err != nil {
+		log.Critical(err)
+		return nil, err
+	}
+	if dc != nil {
+		dc.load(c)
+	}
+
 	// make a new Bridge
 	return &MemBridge{
 		cache: c,
 		log:   log,
+		disk:  dc,
 
 		// make rings
 		blkRing: ring.New(BlockRingCacheSize),
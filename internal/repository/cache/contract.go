@@ -57,7 +57,7 @@ func (b *MemBridge) PushContract(sc *types.Contract) error {
 	}
 
 	// set the data to cache
-	return b.cache.Set(contractId(&sc.Address), data)
+	return b.setPersistent(contractId(&sc.Address), data)
 }
 
 // EvictContract makes sure the contract of the given address
@@ -70,7 +70,7 @@ func (b *MemBridge) EvictContract(addr *common.Address) {
 	}
 
 	// delete the record, if the is any
-	err := b.cache.Delete(contractId(addr))
+	err := b.deletePersistent(contractId(addr))
 	if err != nil && err != bigcache.ErrEntryNotFound {
 		b.log.Criticalf("cache error %s", err.Error())
 	}
@@ -0,0 +1,348 @@
+// Package cache implements bridge to fast in-memory object cache.
+package cache
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fantom-api-graphql/internal/config"
+	"fantom-api-graphql/internal/logger"
+	"fmt"
+	"github.com/allegro/bigcache"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// diskCacheVersion is the version of the encoding of the values persisted in the disk cache.
+// Make sure to bump the version whenever the serialization of any of the persisted
+// types changes; entries of other versions are dropped on load.
+const diskCacheVersion = 1
+
+// diskEntryMagic identifies files of the persistent cache tier.
+var diskEntryMagic = []byte("FCE")
+
+// diskEntryHeaderLength is the length of the entry header;
+// magic, version, time of the write and the length of the key.
+const diskEntryHeaderLength = 3 + 4 + 8 + 2
+
+// diskQueueCapacity is the number of pending disk writes; writes over the capacity are dropped.
+// Removals are never dropped, they wait for a free slot.
+const diskQueueCapacity = 1000
+
+// diskTempSuffix is the suffix of the entry files being written.
+const diskTempSuffix = ".tmp"
+
+// diskEvictionRatio is the part of the max size the persisted entries are trimmed to
+// when the limit is exceeded.
+const diskEvictionRatio = 0.9
+
+// diskCache implements the persistent tier of the in-memory cache.
+// Each entry is kept in its own file named by the hash of the key.
+type diskCache struct {
+	path    string
+	maxSize int64
+	maxAge  time.Duration
+	log     logger.Logger
+
+	mu      sync.Mutex
+	size    int64
+	entries map[string]*diskEntryInfo
+
+	// qmu guards the queue against sends after close
+	qmu    sync.RWMutex
+	closed bool
+	queue  chan *diskWrite
+	done   chan struct{}
+}
+
+// diskEntryInfo represents a persisted entry in the index of the disk cache.
+type diskEntryInfo struct {
+	size    int64
+	written time.Time
+}
+
+// diskWrite represents a pending write, or removal, of a persisted entry.
+type diskWrite struct {
+	key  string
+	data []byte
+}
+
+// newDiskCache creates the persistent cache tier, if enabled.
+func newDiskCache(cfg *config.DiskCache, log logger.Logger) (*diskCache, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+
+	if err := os.MkdirAll(cfg.Path, 0700); err != nil {
+		return nil, fmt.Errorf("can not create disk cache directory %s; %s", cfg.Path, err.Error())
+	}
+
+	return &diskCache{
+		path:    cfg.Path,
+		maxSize: cfg.MaxSize * 1024 * 1024,
+		maxAge:  cfg.MaxAge,
+		log:     log,
+		entries: make(map[string]*diskEntryInfo),
+		queue:   make(chan *diskWrite, diskQueueCapacity),
+		done:    make(chan struct{}),
+	}, nil
+}
+
+// load pushes the persisted entries into the memory cache and starts the disk writer.
+// Entries of a different version, or too old, are removed.
+func (dc *diskCache) load(mem *bigcache.BigCache) {
+	files, err := ioutil.ReadDir(dc.path)
+	if err != nil {
+		dc.log.Errorf("can not read disk cache directory %s; %s", dc.path, err.Error())
+	}
+
+	var count int
+	for _, fi := range files {
+		if fi.IsDir() {
+			continue
+		}
+
+		// entries not finished before the previous shutdown
+		if strings.HasSuffix(fi.Name(), diskTempSuffix) {
+			dc.drop(fi.Name())
+			continue
+		}
+
+		key, data, written, err := dc.read(fi.Name())
+		if err != nil || (dc.maxAge > 0 && time.Since(written) > dc.maxAge) {
+			dc.drop(fi.Name())
+			continue
+		}
+
+		if err := mem.Set(key, data); err != nil {
+			dc.log.Errorf("can not restore cache entry %s; %s", key, err.Error())
+			continue
+		}
+
+		dc.entries[fi.Name()] = &diskEntryInfo{size: fi.Size(), written: written}
+		dc.size += fi.Size()
+		count++
+	}
+	dc.log.Noticef("%d cache entries restored from disk", count)
+
+	go dc.run()
+}
+
+// put queues the entry to be persisted; the entry is dropped if the queue is full,
+// or the disk cache is already closed.
+func (dc *diskCache) put(key string, data []byte) {
+	dc.qmu.RLock()
+	defer dc.qmu.RUnlock()
+
+	if dc.closed {
+		return
+	}
+
+	select {
+	case dc.queue <- &diskWrite{key: key, data: data}:
+	default:
+		dc.log.Debugf("disk cache queue full, %s not persisted", key)
+	}
+}
+
+// remove queues removal of the persisted entry. The removal waits for a free slot
+// in the queue, a dropped removal would restore the entry on the next start.
+// The removal is processed after the writes of the entry queued before it.
+func (dc *diskCache) remove(key string) {
+	dc.qmu.RLock()
+	defer dc.qmu.RUnlock()
+
+	// the writer is gone, remove the file directly
+	if dc.closed {
+		dc.drop(diskEntryName(key))
+		return
+	}
+	dc.queue <- &diskWrite{key: key}
+}
+
+// close flushes the pending writes and terminates the disk writer.
+func (dc *diskCache) close() {
+	dc.qmu.Lock()
+	if dc.closed {
+		dc.qmu.Unlock()
+		return
+	}
+	dc.closed = true
+	close(dc.queue)
+	dc.qmu.Unlock()
+
+	<-dc.done
+}
+
+// run processes the queue of pending writes.
+func (dc *diskCache) run() {
+	defer close(dc.done)
+
+	for w := range dc.queue {
+		name := diskEntryName(w.key)
+		if w.data == nil {
+			dc.drop(name)
+			continue
+		}
+
+		if err := dc.write(name, w.key, w.data); err != nil {
+			dc.log.Errorf("can not persist cache entry %s; %s", w.key, err.Error())
+			continue
+		}
+		dc.trim()
+	}
+}
+
+// write stores the entry to its file. The file is replaced atomically,
+// so a crash does not leave a partially written entry behind.
+func (dc *diskCache) write(name string, key string, data []byte) error {
+	now := time.Now()
+	buf := encodeDiskEntry(key, data, now)
+
+	tmp := filepath.Join(dc.path, name+diskTempSuffix)
+	if err := ioutil.WriteFile(tmp, buf, 0600); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, filepath.Join(dc.path, name)); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
+
+	if old, ok := dc.entries[name]; ok {
+		dc.size -= old.size
+	}
+	dc.entries[name] = &diskEntryInfo{size: int64(len(buf)), written: now}
+	dc.size += int64(len(buf))
+	return nil
+}
+
+// read loads the entry from its file.
+func (dc *diskCache) read(name string) (string, []byte, time.Time, error) {
+	buf, err := ioutil.ReadFile(filepath.Join(dc.path, name))
+	if err != nil {
+		return "", nil, time.Time{}, err
+	}
+	return decodeDiskEntry(buf)
+}
+
+// drop removes the file of the entry.
+func (dc *diskCache) drop(name string) {
+	if err := os.Remove(filepath.Join(dc.path, name)); err != nil && !os.IsNotExist(err) {
+		dc.log.Errorf("can not remove disk cache entry %s; %s", name, err.Error())
+	}
+
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
+
+	if old, ok := dc.entries[name]; ok {
+		dc.size -= old.size
+		delete(dc.entries, name)
+	}
+}
+
+// trim removes the oldest entries if the size limit is exceeded.
+func (dc *diskCache) trim() {
+	dc.mu.Lock()
+	if dc.maxSize <= 0 || dc.size <= dc.maxSize {
+		dc.mu.Unlock()
+		return
+	}
+
+	names := make([]string, 0, len(dc.entries))
+	for name := range dc.entries {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return dc.entries[names[i]].written.Before(dc.entries[names[j]].written)
+	})
+
+	// collect the oldest entries until we fit below the limit
+	limit := int64(float64(dc.maxSize) * diskEvictionRatio)
+	size := dc.size
+	evict := make([]string, 0)
+	for _, name := range names {
+		if size <= limit {
+			break
+		}
+		size -= dc.entries[name].size
+		evict = append(evict, name)
+	}
+	dc.mu.Unlock()
+
+	for _, name := range evict {
+		dc.drop(name)
+	}
+}
+
+// diskEntryName provides the file name of the entry of the given key.
+func diskEntryName(key string) string {
+	h := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(h[:16])
+}
+
+// encodeDiskEntry builds the content of the entry file.
+func encodeDiskEntry(key string, data []byte, written time.Time) []byte {
+	buf := make([]byte, diskEntryHeaderLength, diskEntryHeaderLength+len(key)+len(data))
+	copy(buf, diskEntryMagic)
+	binary.BigEndian.PutUint32(buf[3:7], diskCacheVersion)
+	binary.BigEndian.PutUint64(buf[7:15], uint64(written.Unix()))
+	binary.BigEndian.PutUint16(buf[15:17], uint16(len(key)))
+
+	buf = append(buf, key...)
+	return append(buf, data...)
+}
+
+// decodeDiskEntry parses the content of the entry file.
+func decodeDiskEntry(buf []byte) (string, []byte, time.Time, error) {
+	if len(buf) < diskEntryHeaderLength || !bytes.Equal(buf[:3], diskEntryMagic) {
+		return "", nil, time.Time{}, fmt.Errorf("not a cache entry")
+	}
+	if v := binary.BigEndian.Uint32(buf[3:7]); v != diskCacheVersion {
+		return "", nil, time.Time{}, fmt.Errorf("cache entry version %d not supported", v)
+	}
+
+	written := time.Unix(int64(binary.BigEndian.Uint64(buf[7:15])), 0)
+	kl := int(binary.BigEndian.Uint16(buf[15:17]))
+	if len(buf) < diskEntryHeaderLength+kl {
+		return "", nil, time.Time{}, fmt.Errorf("cache entry truncated")
+	}
+
+	key := string(buf[diskEntryHeaderLength : diskEntryHeaderLength+kl])
+	return key, buf[diskEntryHeaderLength+kl:], written, nil
+}
+
+// setPersistent stores the value in the memory cache and persists it
+// in the disk tier, if enabled.
+func (b *MemBridge) setPersistent(key string, data []byte) error {
+	if err := b.cache.Set(key, data); err != nil {
+		return err
+	}
+	if b.disk != nil {
+		b.disk.put(key, data)
+	}
+	return nil
+}
+
+// deletePersistent removes the value from the memory cache and from the disk tier, if enabled.
+func (b *MemBridge) deletePersistent(key string) error {
+	if b.disk != nil {
+		b.disk.remove(key)
+	}
+	return b.cache.Delete(key)
+}
+
+// Close flushes the pending writes of the persistent cache tier.
+func (b *MemBridge) Close() {
+	if b.disk != nil {
+		b.disk.close()
+	}
+}
@@ -0,0 +1,126 @@
+package cache
+
+import (
+	"encoding/binary"
+	"fantom-api-graphql/internal/config"
+	"fantom-api-graphql/internal/logger"
+	"github.com/allegro/bigcache"
+	"github.com/onsi/gomega"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestDiskCache tests persisting of cache entries and restoring them on start.
+func TestDiskCache(t *testing.T) {
+	g := gomega.NewWithT(t)
+	log := logger.New(&config.Config{Log: config.Log{Level: "CRITICAL", Format: "%{message}"}})
+	cfg := config.DiskCache{Enabled: true, Path: t.TempDir(), MaxSize: 1, MaxAge: time.Hour}
+
+	// persist a couple of entries
+	dc, err := newDiskCache(&cfg, log)
+	g.Expect(err).To(gomega.BeNil())
+	dc.load(testMemCache(g))
+
+	dc.put("sc_a", []byte("contract a"))
+	dc.put("sc_b", []byte("contract b"))
+	dc.put("sc_c", []byte("contract c"))
+	dc.remove("sc_b")
+	dc.close()
+
+	// make an entry of an old version
+	old := encodeDiskEntry("sc_d", []byte("contract d"), time.Now())
+	binary.BigEndian.PutUint32(old[3:7], diskCacheVersion-1)
+	g.Expect(ioutil.WriteFile(filepath.Join(cfg.Path, diskEntryName("sc_d")), old, 0600)).To(gomega.Succeed())
+
+	// make an entry left unfinished by a crash
+	tmp := filepath.Join(cfg.Path, diskEntryName("sc_e")+diskTempSuffix)
+	g.Expect(ioutil.WriteFile(tmp, encodeDiskEntry("sc_e", []byte("contract e"), time.Now()), 0600)).To(gomega.Succeed())
+
+	// restore the entries into a new memory cache
+	mem := testMemCache(g)
+	dc, err = newDiskCache(&cfg, log)
+	g.Expect(err).To(gomega.BeNil())
+	dc.load(mem)
+	dc.close()
+
+	data, err := mem.Get("sc_a")
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(string(data)).To(gomega.Equal("contract a"))
+
+	_, err = mem.Get("sc_b")
+	g.Expect(err).To(gomega.Equal(bigcache.ErrEntryNotFound))
+
+	_, err = mem.Get("sc_d")
+	g.Expect(err).To(gomega.Equal(bigcache.ErrEntryNotFound))
+	g.Expect(filepath.Join(cfg.Path, diskEntryName("sc_d"))).NotTo(gomega.BeAnExistingFile())
+
+	_, err = mem.Get("sc_e")
+	g.Expect(err).To(gomega.Equal(bigcache.ErrEntryNotFound))
+	g.Expect(tmp).NotTo(gomega.BeAnExistingFile())
+	g.Expect(dc.entries).To(gomega.HaveLen(2))
+}
+
+// TestDiskCacheRemove tests removals are not dropped on a full queue
+// and the disk cache can be used safely after close.
+func TestDiskCacheRemove(t *testing.T) {
+	g := gomega.NewWithT(t)
+	log := logger.New(&config.Config{Log: config.Log{Level: "CRITICAL", Format: "%{message}"}})
+	cfg := config.DiskCache{Enabled: true, Path: t.TempDir()}
+
+	dc, err := newDiskCache(&cfg, log)
+	g.Expect(err).To(gomega.BeNil())
+
+	// fill the queue before the writer runs; the removal has to wait
+	for i := 0; i < diskQueueCapacity; i++ {
+		dc.put("sc_a", []byte("contract a"))
+	}
+	removed := make(chan struct{})
+	go func() {
+		dc.remove("sc_a")
+		close(removed)
+	}()
+	g.Consistently(removed, 50*time.Millisecond).ShouldNot(gomega.BeClosed())
+
+	dc.load(testMemCache(g))
+	g.Eventually(removed).Should(gomega.BeClosed())
+	dc.close()
+	g.Expect(filepath.Join(cfg.Path, diskEntryName("sc_a"))).NotTo(gomega.BeAnExistingFile())
+
+	// writes after close are ignored, removals are done directly
+	g.Expect(dc.write(diskEntryName("sc_b"), "sc_b", []byte("contract b"))).To(gomega.Succeed())
+	dc.put("sc_c", []byte("contract c"))
+	dc.remove("sc_b")
+	dc.close()
+	g.Expect(filepath.Join(cfg.Path, diskEntryName("sc_b"))).NotTo(gomega.BeAnExistingFile())
+	g.Expect(filepath.Join(cfg.Path, diskEntryName("sc_c"))).NotTo(gomega.BeAnExistingFile())
+}
+
+// TestDiskCacheTrim tests the oldest entries are removed when the size limit is exceeded.
+func TestDiskCacheTrim(t *testing.T) {
+	g := gomega.NewWithT(t)
+	log := logger.New(&config.Config{Log: config.Log{Level: "CRITICAL", Format: "%{message}"}})
+
+	dc, err := newDiskCache(&config.DiskCache{Enabled: true, Path: t.TempDir()}, log)
+	g.Expect(err).To(gomega.BeNil())
+	dc.maxSize = 1000
+
+	now := time.Now()
+	for i, key := range []string{"sc_a", "sc_b", "sc_c", "sc_d"} {
+		g.Expect(dc.write(diskEntryName(key), key, make([]byte, 300))).To(gomega.Succeed())
+		dc.entries[diskEntryName(key)].written = now.Add(time.Duration(i) * time.Second)
+	}
+	dc.trim()
+
+	g.Expect(dc.size).To(gomega.BeNumerically("<=", 900))
+	g.Expect(dc.entries).NotTo(gomega.HaveKey(diskEntryName("sc_a")))
+	g.Expect(dc.entries).To(gomega.HaveKey(diskEntryName("sc_d")))
+}
+
+// testMemCache creates a small memory cache for the tests.
+func testMemCache(g *gomega.WithT) *bigcache.BigCache {
+	mem, err := bigcache.NewBigCache(bigcache.DefaultConfig(time.Minute))
+	g.Expect(err).To(gomega.BeNil())
+	return mem
+}
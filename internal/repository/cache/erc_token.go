@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fantom-api-graphql/internal/types"
 	"fmt"
+	"github.com/allegro/bigcache"
 	"github.com/ethereum/go-ethereum/common"
 	"strings"
 )
@@ -59,7 +60,15 @@ func (b *MemBridge) PushErc20Token(token *types.Erc20Token) error {
 	}
 
 	// set the data to cache
-	return b.cache.Set(ErcTokenId(&token.Address, Erc20CacheIdPrefix), data)
+	return b.setPersistent(ErcTokenId(&token.Address, Erc20CacheIdPrefix), data)
+}
+
+// EvictErc20Token removes the ERC20 token from the in-memory cache.
+func (b *MemBridge) EvictErc20Token(addr *common.Address) {
+	err := b.deletePersistent(ErcTokenId(addr, Erc20CacheIdPrefix))
+	if err != nil && err != bigcache.ErrEntryNotFound {
+		b.log.Criticalf("cache error %s", err.Error())
+	}
 }
 
 // PullErc721Contract pulls ERC-721 token contract details from cache, if available.
@@ -92,5 +101,13 @@ func (b *MemBridge) PushErc721Contract(tok *types.Erc721Contract) error {
 		b.log.Criticalf("can not marshal ERC721 token to JSON; %s", err.Error())
 		return err
 	}
-	return b.cache.Set(ErcTokenId(&tok.Address, Erc721CacheIdPrefix), data)
+	return b.setPersistent(ErcTokenId(&tok.Address, Erc721CacheIdPrefix), data)
+}
+
+// EvictErc721Contract removes the ERC721 contract details from the in-memory cache.
+func (b *MemBridge) EvictErc721Contract(addr *common.Address) {
+	err := b.deletePersistent(ErcTokenId(addr, Erc721CacheIdPrefix))
+	if err != nil && err != bigcache.ErrEntryNotFound {
+		b.log.Criticalf("cache error %s", err.Error())
+	}
 }
@@ -0,0 +1,59 @@
+// Package cache implements bridge to fast in-memory object cache.
+package cache
+
+import (
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"strings"
+	"time"
+)
+
+// fMintAccountCacheKeyPrefix is the prefix used for cache key to store fMint account details.
+const fMintAccountCacheKeyPrefix = "fmint_acc_"
+
+// fMintAccountCacheTTL is the time the fMint account details are kept in cache.
+// The position value follows the price oracle, so we keep it only briefly.
+const fMintAccountCacheTTL = 30 * time.Second
+
+// PullFMintAccount extracts fMint account details from the in-memory cache if available.
+func (b *MemBridge) PullFMintAccount(owner *common.Address) *types.FMintAccount {
+	data := b.getTTL(fMintAccountKey(owner))
+	if data == nil {
+		return nil
+	}
+
+	// do we have the data?
+	acc, err := types.UnmarshalFMintAccount(data)
+	if err != nil {
+		b.log.Criticalf("can not decode fMint account data from in-memory cache; %s", err.Error())
+		return nil
+	}
+	return acc
+}
+
+// PushFMintAccount stores provided fMint account details in the in-memory cache.
+func (b *MemBridge) PushFMintAccount(acc *types.FMintAccount) error {
+	// we need valid account
+	if nil == acc {
+		return fmt.Errorf("undefined fMint account can not be pushed to the in-memory cache")
+	}
+
+	// encode account
+	data, err := acc.Marshal()
+	if err != nil {
+		b.log.Criticalf("can not marshal fMint account to JSON; %s", err.Error())
+		return err
+	}
+	return b.setTTL(fMintAccountKey(&acc.Address), data, fMintAccountCacheTTL)
+}
+
+// fMintAccountKey builds a cache key for the given fMint account owner.
+func fMintAccountKey(owner *common.Address) string {
+	var sb strings.Builder
+
+	sb.WriteString(fMintAccountCacheKeyPrefix)
+	sb.WriteString(owner.String())
+
+	return sb.String()
+}
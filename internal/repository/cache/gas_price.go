@@ -0,0 +1,44 @@
+// Package cache implements bridge to fast in-memory object cache.
+package cache
+
+import (
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"time"
+)
+
+// gasPriceTiersCacheKey is the cache key used to store gas price tiers.
+const gasPriceTiersCacheKey = "gas_price_tiers"
+
+// gasPriceTiersCacheTTL is the time the gas price tiers are kept in cache.
+const gasPriceTiersCacheTTL = 5 * time.Second
+
+// PullGasPriceTiers extracts gas price tiers from the in-memory cache if available.
+func (b *MemBridge) PullGasPriceTiers() *types.GasPriceTiers {
+	data := b.getTTL(gasPriceTiersCacheKey)
+	if data == nil {
+		return nil
+	}
+
+	// do we have the data?
+	gt, err := types.UnmarshalGasPriceTiers(data)
+	if err != nil {
+		b.log.Criticalf("can not decode gas price tiers from in-memory cache; %s", err.Error())
+		return nil
+	}
+	return gt
+}
+
+// PushGasPriceTiers stores provided gas price tiers in the in-memory cache.
+func (b *MemBridge) PushGasPriceTiers(gt *types.GasPriceTiers) error {
+	if nil == gt {
+		return fmt.Errorf("undefined gas price tiers can not be pushed to the in-memory cache")
+	}
+
+	data, err := gt.Marshal()
+	if err != nil {
+		b.log.Criticalf("can not marshal gas price tiers to JSON; %s", err.Error())
+		return err
+	}
+	return b.setTTL(gasPriceTiersCacheKey, data, gasPriceTiersCacheTTL)
+}
@@ -0,0 +1,54 @@
+// Package cache implements bridge to fast in-memory object cache.
+package cache
+
+import (
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"strings"
+)
+
+// internalTrxCacheKeyPrefix is the prefix used for cache key to store internal transactions.
+const internalTrxCacheKeyPrefix = "itx_"
+
+// PullInternalTransactions extracts internal transactions of the given transaction
+// from the in-memory cache if available.
+func (b *MemBridge) PullInternalTransactions(hash *common.Hash) []*types.InternalTransaction {
+	// try to get the data from the cache
+	data, err := b.cache.Get(internalTrxKey(hash))
+	if err != nil {
+		// cache returns ErrEntryNotFound if the key does not exist
+		return nil
+	}
+
+	// do we have the data?
+	list, err := types.UnmarshalInternalTransactions(data)
+	if err != nil {
+		b.log.Criticalf("can not decode internal transactions from in-memory cache; %s", err.Error())
+		return nil
+	}
+	return list
+}
+
+// PushInternalTransactions stores internal transactions of the given transaction in the in-memory cache.
+// The call trace of a processed transaction does not change so we don't need to limit the time to live.
+func (b *MemBridge) PushInternalTransactions(hash *common.Hash, list []*types.InternalTransaction) {
+	data, err := types.MarshalInternalTransactions(list)
+	if err != nil {
+		b.log.Criticalf("can not marshal internal transactions of %s; %s", hash.String(), err.Error())
+		return
+	}
+
+	if err := b.cache.Set(internalTrxKey(hash), data); err != nil {
+		b.log.Errorf("can not cache internal transactions of %s; %s", hash.String(), err.Error())
+	}
+}
+
+// internalTrxKey builds a cache key for internal transactions of the given transaction.
+func internalTrxKey(hash *common.Hash) string {
+	var sb strings.Builder
+
+	sb.WriteString(internalTrxCacheKeyPrefix)
+	sb.WriteString(hash.String())
+
+	return sb.String()
+}
@@ -0,0 +1,88 @@
+// Package cache implements bridge to fast in-memory object cache.
+package cache
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"strings"
+	"time"
+)
+
+const (
+	// nameCacheKeyPrefix is the prefix used for cache key to store reverse resolved names.
+	nameCacheKeyPrefix = "ns_name_"
+
+	// nameAddressCacheKeyPrefix is the prefix used for cache key to store forward resolved addresses.
+	nameAddressCacheKeyPrefix = "ns_addr_"
+
+	// nameCacheTTL is the time a resolved name, or address is kept in cache.
+	nameCacheTTL = 10 * time.Minute
+
+	// nameMissingCacheTTL is the time a failed name resolution is kept in cache.
+	nameMissingCacheTTL = 1 * time.Minute
+)
+
+// PullName extracts the reverse resolved name of the address from the in-memory cache.
+// The second value signals if the resolution is cached at all; an empty name
+// represents a missing reverse record.
+func (b *MemBridge) PullName(addr *common.Address) (string, bool) {
+	data := b.getTTL(nameKey(addr))
+	if data == nil {
+		return "", false
+	}
+	return string(data), true
+}
+
+// PushName stores the reverse resolved name of the address in the in-memory cache.
+// An empty name represents a missing record and is kept for a shorter time.
+func (b *MemBridge) PushName(addr *common.Address, name string) error {
+	return b.setTTL(nameKey(addr), []byte(name), nameTTL(name == ""))
+}
+
+// PullNameAddress extracts the forward resolved address of the name from the in-memory cache.
+// The second value signals if the resolution is cached at all; nil address
+// represents a missing record.
+func (b *MemBridge) PullNameAddress(name string) (*common.Address, bool) {
+	data := b.getTTL(nameAddressKey(name))
+	if data == nil {
+		return nil, false
+	}
+	if len(data) != common.AddressLength {
+		return nil, true
+	}
+
+	adr := common.BytesToAddress(data)
+	return &adr, true
+}
+
+// PushNameAddress stores the forward resolved address of the name in the in-memory cache.
+// Nil address represents a missing record and is kept for a shorter time.
+func (b *MemBridge) PushNameAddress(name string, addr *common.Address) error {
+	if addr == nil {
+		return b.setTTL(nameAddressKey(name), []byte{}, nameTTL(true))
+	}
+	return b.setTTL(nameAddressKey(name), addr.Bytes(), nameTTL(false))
+}
+
+// nameTTL provides the time to live of a name resolution.
+func nameTTL(missing bool) time.Duration {
+	if missing {
+		return nameMissingCacheTTL
+	}
+	return nameCacheTTL
+}
+
+// nameKey generates the cache key of the reverse resolved name of the address.
+func nameKey(addr *common.Address) string {
+	var sb strings.Builder
+	sb.WriteString(nameCacheKeyPrefix)
+	sb.WriteString(addr.String())
+	return sb.String()
+}
+
+// nameAddressKey generates the cache key of the forward resolved address of the name.
+func nameAddressKey(name string) string {
+	var sb strings.Builder
+	sb.WriteString(nameAddressCacheKeyPrefix)
+	sb.WriteString(strings.ToLower(name))
+	return sb.String()
+}
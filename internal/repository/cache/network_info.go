@@ -0,0 +1,44 @@
+// Package cache implements bridge to fast in-memory object cache.
+package cache
+
+import (
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"time"
+)
+
+// networkInfoCacheKey is the cache key used to store the network info.
+const networkInfoCacheKey = "network_info"
+
+// networkInfoCacheTTL is the time the network info is kept in cache.
+const networkInfoCacheTTL = 2 * time.Second
+
+// PullNetworkInfo extracts the network info from the in-memory cache if available.
+func (b *MemBridge) PullNetworkInfo() *types.NetworkInfo {
+	data := b.getTTL(networkInfoCacheKey)
+	if data == nil {
+		return nil
+	}
+
+	// do we have the data?
+	ni, err := types.UnmarshalNetworkInfo(data)
+	if err != nil {
+		b.log.Criticalf("can not decode network info from in-memory cache; %s", err.Error())
+		return nil
+	}
+	return ni
+}
+
+// PushNetworkInfo stores the provided network info in the in-memory cache.
+func (b *MemBridge) PushNetworkInfo(ni *types.NetworkInfo) error {
+	if nil == ni {
+		return fmt.Errorf("undefined network info can not be pushed to the in-memory cache")
+	}
+
+	data, err := ni.Marshal()
+	if err != nil {
+		b.log.Criticalf("can not marshal network info to JSON; %s", err.Error())
+		return err
+	}
+	return b.setTTL(networkInfoCacheKey, data, networkInfoCacheTTL)
+}
@@ -0,0 +1,72 @@
+// Package cache implements bridge to fast in-memory object cache.
+package cache
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"strings"
+	"time"
+)
+
+// proxyImplCacheKeyPrefix is the prefix used for cache key to store proxy implementations.
+const proxyImplCacheKeyPrefix = "proxy_impl_"
+
+// proxyImplLatestCacheTTL is the time the implementation of a proxy at the latest state
+// is kept in cache; proxies can be upgraded at any time.
+const proxyImplLatestCacheTTL = time.Minute
+
+// PullProxyImplementation extracts the implementation of the proxy contract at the given block,
+// or at the latest state for nil block, from the in-memory cache if available.
+// The second value signals if the implementation is known; nil implementation
+// marks a contract which is not a proxy.
+func (b *MemBridge) PullProxyImplementation(addr *common.Address, block *hexutil.Uint64) (*common.Address, bool) {
+	var data []byte
+	if block == nil {
+		data = b.getTTL(proxyImplKey(addr, block))
+	} else {
+		data, _ = b.cache.Get(proxyImplKey(addr, block))
+	}
+
+	switch len(data) {
+	case 1:
+		return nil, true
+	case common.AddressLength:
+		impl := common.BytesToAddress(data)
+		return &impl, true
+	}
+	return nil, false
+}
+
+// PushProxyImplementation stores the implementation of the proxy contract at the given block,
+// or at the latest state for nil block, in the in-memory cache.
+// Historical implementations do not change, they are kept until evicted.
+func (b *MemBridge) PushProxyImplementation(addr *common.Address, block *hexutil.Uint64, impl *common.Address) {
+	data := []byte{0}
+	if impl != nil {
+		data = impl.Bytes()
+	}
+
+	var err error
+	if block == nil {
+		err = b.setTTL(proxyImplKey(addr, block), data, proxyImplLatestCacheTTL)
+	} else {
+		err = b.cache.Set(proxyImplKey(addr, block), data)
+	}
+	if err != nil {
+		b.log.Errorf("can not cache implementation of %s; %s", addr.String(), err.Error())
+	}
+}
+
+// proxyImplKey builds a cache key for the implementation of the given proxy at the given block.
+func proxyImplKey(addr *common.Address, block *hexutil.Uint64) string {
+	var sb strings.Builder
+
+	sb.WriteString(proxyImplCacheKeyPrefix)
+	sb.WriteString(addr.String())
+	if block != nil {
+		sb.WriteString("_")
+		sb.WriteString(block.String())
+	}
+
+	return sb.String()
+}
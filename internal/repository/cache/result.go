@@ -0,0 +1,30 @@
+// Package cache implements bridge to fast in-memory object cache.
+package cache
+
+import (
+	"strings"
+	"time"
+)
+
+// resultCacheKeyPrefix is the prefix used for cache key to store resolver results.
+const resultCacheKeyPrefix = "res_"
+
+// PullResult extracts the cached result of a resolver stored under the given key, if available.
+func (b *MemBridge) PullResult(key string) []byte {
+	return b.getTTL(resultKey(key))
+}
+
+// PushResult stores the result of a resolver under the given key for the given time.
+func (b *MemBridge) PushResult(key string, data []byte, ttl time.Duration) error {
+	return b.setTTL(resultKey(key), data, ttl)
+}
+
+// resultKey builds a cache key for the resolver result.
+func resultKey(key string) string {
+	var sb strings.Builder
+
+	sb.WriteString(resultCacheKeyPrefix)
+	sb.WriteString(key)
+
+	return sb.String()
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"math/big"
 	"strings"
+	"time"
 )
 
 // sfcMaxDelegatedRatioKey represents the key used to store SFC delegation ratio.
@@ -17,6 +18,21 @@ const (
 	sfcValidatorAddress     = "val_adr"
 	sfcTotalStakedKey       = "staked_total"
 	sfcValidatorInfoPrefix  = "validator_info_"
+	sfcCommissionKey        = "sfc_commission"
+	sfcStakingAprPrefix     = "staking_apr_"
+	sfcStakingParamsKey     = "staking_params"
+
+	// sfcCommissionTTL is the time the validator commission rate is kept in cache
+	// before it's refreshed from the SFC contract.
+	sfcCommissionTTL = 5 * time.Minute
+
+	// sfcStakingAprTTL is the time the staking yield estimate is kept in cache;
+	// the estimate changes only slowly with new sealed epochs.
+	sfcStakingAprTTL = 10 * time.Minute
+
+	// sfcStakingParamsTTL is the time the staking parameters are kept in cache;
+	// the parameters change only on the SFC upgrades.
+	sfcStakingParamsTTL = 15 * time.Minute
 )
 
 // PullSfcMaxDelegatedRatio extract the ratio from cache, if possible.
@@ -84,6 +100,43 @@ func (b *MemBridge) PushSfcConfig(val *types.SfcConfig) {
 	}
 }
 
+// PullSfcCommission extracts the validator commission from cache, if possible.
+// Expired commission is provided as well so the caller can track rate changes;
+// the second value signals if the commission is still fresh.
+func (b *MemBridge) PullSfcCommission() (*types.ValidatorCommission, bool) {
+	data, fresh := b.getStaleTTL(sfcCommissionKey)
+	if data == nil {
+		return nil, false
+	}
+
+	// decode data
+	var val types.ValidatorCommission
+	if err := val.Unmarshal(data); err != nil {
+		b.log.Errorf("can not decode validator commission; %s", err.Error())
+		return nil, false
+	}
+	return &val, fresh
+}
+
+// PushSfcCommission stores the validator commission in cache, if possible.
+func (b *MemBridge) PushSfcCommission(val *types.ValidatorCommission) {
+	if val == nil {
+		return
+	}
+
+	// get the encoded commission
+	data, err := val.Marshal()
+	if err != nil {
+		b.log.Errorf("can not encode validator commission; %s", err.Error())
+		return
+	}
+
+	// store the data
+	if err := b.setTTL(sfcCommissionKey, data, sfcCommissionTTL); err != nil {
+		b.log.Errorf("can not store validator commission")
+	}
+}
+
 // validatorAddressKey generates cache key for address of the given validator id.
 func validatorAddressKey(valID *hexutil.Big) string {
 	var sb strings.Builder
@@ -156,7 +209,7 @@ func (b *MemBridge) PushValidatorInfo(id *hexutil.Big, sfci *types.ValidatorInfo
 	}
 
 	// set the data to cache by block number
-	return b.cache.Set(getValidatorInfoKey(id), data)
+	return b.setPersistent(getValidatorInfoKey(id), data)
 }
 
 // getPriceKeyBySymbol build a cache key for the given price symbol.
@@ -194,3 +247,75 @@ func (b *MemBridge) PushTotalStaked(amount *hexutil.Big) error {
 	// encode account
 	return b.cache.Set(sfcTotalStakedKey, amount.ToInt().Bytes())
 }
+
+// stakingAprKey generates cache key for the staking yield estimate of the given validator id.
+func stakingAprKey(valID *hexutil.Big) string {
+	var sb strings.Builder
+	sb.WriteString(sfcStakingAprPrefix)
+	sb.WriteString(valID.String())
+	return sb.String()
+}
+
+// PullStakingApr extracts the staking yield estimate of the validator from cache, if possible.
+func (b *MemBridge) PullStakingApr(valID *hexutil.Big) *types.StakingApr {
+	data := b.getTTL(stakingAprKey(valID))
+	if data == nil {
+		return nil
+	}
+
+	apr, err := types.UnmarshalStakingApr(data)
+	if err != nil {
+		b.log.Errorf("can not decode staking yield estimate; %s", err.Error())
+		return nil
+	}
+	return apr
+}
+
+// PushStakingApr stores the staking yield estimate of the validator in cache, if possible.
+func (b *MemBridge) PushStakingApr(apr *types.StakingApr) {
+	if apr == nil {
+		return
+	}
+
+	data, err := apr.Marshal()
+	if err != nil {
+		b.log.Errorf("can not encode staking yield estimate; %s", err.Error())
+		return
+	}
+
+	if err := b.setTTL(stakingAprKey(&apr.ValidatorID), data, sfcStakingAprTTL); err != nil {
+		b.log.Errorf("can not store staking yield estimate of validator #%d", apr.ValidatorID.ToInt().Uint64())
+	}
+}
+
+// PullStakingParams extracts the staking protocol parameters from cache, if possible.
+func (b *MemBridge) PullStakingParams() *types.StakingParams {
+	data := b.getTTL(sfcStakingParamsKey)
+	if data == nil {
+		return nil
+	}
+
+	sp, err := types.UnmarshalStakingParams(data)
+	if err != nil {
+		b.log.Errorf("can not decode staking parameters; %s", err.Error())
+		return nil
+	}
+	return sp
+}
+
+// PushStakingParams stores the staking protocol parameters in cache, if possible.
+func (b *MemBridge) PushStakingParams(sp *types.StakingParams) {
+	if sp == nil {
+		return
+	}
+
+	data, err := sp.Marshal()
+	if err != nil {
+		b.log.Errorf("can not encode staking parameters; %s", err.Error())
+		return
+	}
+
+	if err := b.setTTL(sfcStakingParamsKey, data, sfcStakingParamsTTL); err != nil {
+		b.log.Errorf("can not store staking parameters")
+	}
+}
@@ -0,0 +1,45 @@
+// Package cache implements bridge to fast in-memory object cache.
+package cache
+
+import (
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"time"
+)
+
+// tokenRankingPrefix is the prefix of the cache key of the token ranking.
+const tokenRankingPrefix = "tok_rank_"
+
+// tokenRankingKey generates cache key for the token ranking of the given period and metric.
+func tokenRankingKey(period time.Duration, metric string, quote *common.Address) string {
+	return fmt.Sprintf("%s%d_%s_%s", tokenRankingPrefix, int64(period.Seconds()), metric, quote.String())
+}
+
+// PullTokenRanking extracts the token ranking of the given period and metric from cache, if possible.
+func (b *MemBridge) PullTokenRanking(period time.Duration, metric string, quote *common.Address) *types.TokenRanking {
+	data := b.getTTL(tokenRankingKey(period, metric, quote))
+	if data == nil {
+		return nil
+	}
+
+	tr, err := types.UnmarshalTokenRanking(data)
+	if err != nil {
+		b.log.Errorf("can not decode token ranking; %s", err.Error())
+		return nil
+	}
+	return tr
+}
+
+// PushTokenRanking stores the token ranking in cache for the given time, if possible.
+func (b *MemBridge) PushTokenRanking(tr *types.TokenRanking, ttl time.Duration) {
+	data, err := tr.Marshal()
+	if err != nil {
+		b.log.Errorf("can not encode token ranking; %s", err.Error())
+		return
+	}
+
+	if err := b.setTTL(tokenRankingKey(tr.Period, tr.Metric, &tr.Quote), data, ttl); err != nil {
+		b.log.Errorf("can not store token ranking; %s", err.Error())
+	}
+}
@@ -3,6 +3,7 @@ package cache
 
 import (
 	"fantom-api-graphql/internal/types"
+	"github.com/allegro/bigcache"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/klauspost/compress/s2"
 )
@@ -58,3 +59,10 @@ func (b *MemBridge) PushTransaction(trx *types.Transaction) {
 		b.log.Criticalf("can not cache transaction %s; %s", trx.Hash.String(), err.Error())
 	}
 }
+
+// EvictTransaction removes the transaction from the in-memory cache.
+func (b *MemBridge) EvictTransaction(hash *common.Hash) {
+	if err := b.cache.Delete(hash.String()); err != nil && err != bigcache.ErrEntryNotFound {
+		b.log.Errorf("can not evict transaction %s; %s", hash.String(), err.Error())
+	}
+}
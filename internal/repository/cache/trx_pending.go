@@ -0,0 +1,57 @@
+// Package cache implements bridge to fast in-memory object cache.
+package cache
+
+import (
+	"encoding/json"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"strings"
+	"time"
+)
+
+// pendingTrxCacheKeyPrefix is the prefix used for cache key to store transactions submitted through the API.
+const pendingTrxCacheKeyPrefix = "trx_pending_"
+
+// pendingTrxCacheTTL is the time a submitted transaction is kept in cache.
+// It's expected to be mined, or dropped by the node, long before this.
+const pendingTrxCacheTTL = 10 * time.Minute
+
+// PullPendingTransaction extracts a transaction submitted through the API
+// from the in-memory cache if available.
+func (b *MemBridge) PullPendingTransaction(hash *common.Hash) *types.Transaction {
+	data := b.getTTL(pendingTrxKey(hash))
+	if data == nil {
+		return nil
+	}
+
+	// do we have the data?
+	trx := new(types.Transaction)
+	if err := json.Unmarshal(data, trx); err != nil {
+		b.log.Criticalf("can not decode pending transaction from in-memory cache; %s", err.Error())
+		return nil
+	}
+	return trx
+}
+
+// PushPendingTransaction stores a transaction submitted through the API in the in-memory cache.
+func (b *MemBridge) PushPendingTransaction(trx *types.Transaction) {
+	data, err := trx.Marshal()
+	if err != nil {
+		b.log.Criticalf("can not marshal pending transaction %s; %s", trx.Hash.String(), err.Error())
+		return
+	}
+
+	if err := b.setTTL(pendingTrxKey(&trx.Hash), data, pendingTrxCacheTTL); err != nil {
+		b.log.Errorf("can not cache pending transaction %s; %s", trx.Hash.String(), err.Error())
+	}
+}
+
+// pendingTrxKey builds a cache key for the given pending transaction.
+func pendingTrxKey(hash *common.Hash) string {
+	var sb strings.Builder
+
+	sb.WriteString(pendingTrxCacheKeyPrefix)
+	sb.WriteString(hash.String())
+
+	return sb.String()
+}
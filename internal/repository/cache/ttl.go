@@ -0,0 +1,41 @@
+// Package cache implements bridge to fast in-memory object cache.
+package cache
+
+import (
+	"encoding/binary"
+	"time"
+)
+
+// ttlHeaderLength is the length of the expiration header prepended
+// to values stored with their own time to live.
+const ttlHeaderLength = 8
+
+// getTTL extracts the value stored with its own time to live.
+// Expired values are reported as missing.
+func (b *MemBridge) getTTL(key string) []byte {
+	data, fresh := b.getStaleTTL(key)
+	if !fresh {
+		return nil
+	}
+	return data
+}
+
+// getStaleTTL extracts the value stored with its own time to live
+// even if it already expired. The second value signals if the value is still fresh.
+func (b *MemBridge) getStaleTTL(key string) ([]byte, bool) {
+	data, err := b.cache.Get(key)
+	if err != nil || len(data) < ttlHeaderLength {
+		return nil, false
+	}
+	return data[ttlHeaderLength:], time.Now().UnixNano() <= int64(binary.BigEndian.Uint64(data[:ttlHeaderLength]))
+}
+
+// setTTL stores the value with its own time to live, which is expected to be
+// shorter than the global eviction time of the cache.
+func (b *MemBridge) setTTL(key string, data []byte, ttl time.Duration) error {
+	val := make([]byte, ttlHeaderLength+len(data))
+	binary.BigEndian.PutUint64(val[:ttlHeaderLength], uint64(time.Now().Add(ttl).UnixNano()))
+	copy(val[ttlHeaderLength:], data)
+
+	return b.cache.Set(key, val)
+}
@@ -2,8 +2,11 @@
 package cache
 
 import (
+	"encoding/json"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"strings"
+	"time"
 )
 
 // uniswapPairTokensPrefix represents a prefix used for uniswap pair tokens caching key.
@@ -91,3 +94,43 @@ func (b *MemBridge) PullAllPairsList() []common.Address {
 	}
 	return list
 }
+
+// uniswapReservesPrefix represents a prefix used for uniswap pair reserves caching key.
+const uniswapReservesPrefix = "unr"
+
+// uniswapReservesCacheTTL is the time the pair reserves are kept in cache.
+// Reserves change with every swap, so we keep them only briefly.
+const uniswapReservesCacheTTL = 10 * time.Second
+
+// PushUniswapReserves stores uniswap pair reserves in the in-memory cache.
+func (b *MemBridge) PushUniswapReserves(pair *common.Address, reserves []hexutil.Big) {
+	// nothing to store or bad data?
+	if pair == nil || 2 != len(reserves) {
+		return
+	}
+
+	data, err := json.Marshal(reserves)
+	if err != nil {
+		b.log.Criticalf("can not marshal uniswap pair reserves; %s", err.Error())
+		return
+	}
+
+	if err := b.setTTL(uniswapReservesPrefix+pair.String(), data, uniswapReservesCacheTTL); err != nil {
+		b.log.Errorf("can not store uniswap pair %s reserves; %s", pair.String(), err.Error())
+	}
+}
+
+// PullUniswapReserves tries to load uniswap pair reserves from the cache.
+func (b *MemBridge) PullUniswapReserves(pair *common.Address) []hexutil.Big {
+	data := b.getTTL(uniswapReservesPrefix + pair.String())
+	if data == nil {
+		return nil
+	}
+
+	var reserves []hexutil.Big
+	if err := json.Unmarshal(data, &reserves); err != nil {
+		b.log.Criticalf("can not decode uniswap pair reserves; %s", err.Error())
+		return nil
+	}
+	return reserves
+}
@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	eth "github.com/ethereum/go-ethereum/rpc"
+)
+
+// CanonicalBlock loads the block of the given number from the canonical chain
+// bypassing the in-memory cache; the cached block is refreshed.
+func (p *proxy) CanonicalBlock(num uint64) (*types.Block, error) {
+	tag := hexutil.Uint64(num).String()
+	blk, err := p.rpc.Block(&tag)
+	if err != nil {
+		if err == eth.ErrNoResult {
+			return nil, ErrBlockNotFound
+		}
+		p.log.Errorf("can not load canonical block #%d; %s", num, err.Error())
+		return nil, err
+	}
+
+	if err := p.cache.PushBlock(tag, blk); err != nil {
+		p.log.Errorf("can not cache; %s", err.Error())
+	}
+	return blk, nil
+}
+
+// EvictTransaction removes a transaction from the in-memory cache
+// so it's loaded from the node on the next request.
+func (p *proxy) EvictTransaction(hash *common.Hash) {
+	p.cache.EvictTransaction(hash)
+}
+
+// RemoveTransaction removes the transaction dropped from the chain
+// by a reorganization from the persistent storage and the in-memory cache.
+func (p *proxy) RemoveTransaction(hash *common.Hash) error {
+	p.cache.EvictTransaction(hash)
+	return p.db.RemoveTransaction(hash)
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/ethereum/go-ethereum/common/compiler"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"strings"
+	"time"
 )
 
 // Contract extract a smart contract information by account address, if available.
@@ -67,11 +68,12 @@ func cutCodeMetadata(bc []byte) []byte {
 }
 
 // compareContractCode compares provided compiled code with the transaction input.
-func compareContractCode(tx *types.Transaction, code string) (bool, error) {
+// The second result signals an exact match including the metadata hash.
+func compareContractCode(tx *types.Transaction, code string) (bool, bool, error) {
 	// decode the detail into byte array
-	bc, err := hexutil.Decode(code)
+	full, err := hexutil.Decode(code)
 	if err != nil {
-		return false, err
+		return false, false, err
 	}
 
 	// remove meta data hash from the byte code so we can compare raw
@@ -79,20 +81,23 @@ func compareContractCode(tx *types.Transaction, code string) (bool, error) {
 	// there could be changes in the source code not reflected
 	// in the byte code. (variables renamed, unused code introduced, etc.)
 	// Safer would be to use full CBOR parser here.
-	bc = cutCodeMetadata(bc)
+	bc := cutCodeMetadata(full)
 
 	// Is the transaction input shorter than the compiled contract?
 	// If so there is no chance for pass.
 	if len(tx.InputData) < len(bc) {
-		return false, nil
+		return false, false, nil
 	}
 
 	// compare only up to <bc> length, the rest is metadata
 	// and constructor parameters
-	res := bytes.Compare(bc, tx.InputData[:len(bc)])
+	if !bytes.Equal(bc, tx.InputData[:len(bc)]) {
+		return false, false, nil
+	}
 
-	// return the comparison result
-	return res == 0, nil
+	// the metadata hash matches as well?
+	exact := len(tx.InputData) >= len(full) && bytes.Equal(full, tx.InputData[:len(full)])
+	return true, exact, nil
 }
 
 // updateContractDetails updates local contract details from the provided compiler
@@ -113,6 +118,41 @@ func updateContractDetails(sc *types.Contract, detail *compiler.Contract) {
 
 	// copy the source code
 	sc.SourceCode = detail.Info.Source
+
+	// copy the compiler metadata and settings
+	sc.Metadata = detail.Info.Metadata
+	sc.EvmVersion = metadataEvmVersion(detail.Info.Metadata)
+	sc.SourceFiles = []types.ContractSourceFile{{Name: contractSourceFileName(sc), Content: sc.SourceCode}}
+
+	// mark the validation time
+	ts := hexutil.Uint64(time.Now().UTC().Unix())
+	sc.Validated = &ts
+}
+
+// metadataEvmVersion extracts the target EVM version from the compiler metadata JSON.
+func metadataEvmVersion(meta string) string {
+	if meta == "" {
+		return ""
+	}
+
+	var md struct {
+		Settings struct {
+			EvmVersion string `json:"evmVersion"`
+		} `json:"settings"`
+	}
+	if err := json.Unmarshal([]byte(meta), &md); err != nil {
+		return ""
+	}
+	return md.Settings.EvmVersion
+}
+
+// contractSourceFileName builds the name of the single source file
+// submitted for the contract validation.
+func contractSourceFileName(sc *types.Contract) string {
+	if sc.Name == "" {
+		return "contract.sol"
+	}
+	return sc.Name + ".sol"
 }
 
 // ValidateContract tries to validate contract byte code using
@@ -120,7 +160,10 @@ func updateContractDetails(sc *types.Contract, detail *compiler.Contract) {
 // is updated the the repository.
 func (p *proxy) ValidateContract(sc *types.Contract) error {
 	// get the byte code of the actual contract
-	tx, err := p.Transaction(&sc.TransactionHash, true)
+	if sc.TransactionHash == nil {
+		return fmt.Errorf("deployment of contract %s is not known", sc.Address.String())
+	}
+	tx, err := p.Transaction(sc.TransactionHash, true)
 	if err != nil {
 		p.log.Errorf("can not get contract deployment transaction; %s", err.Error())
 		return err
@@ -136,7 +179,7 @@ func (p *proxy) ValidateContract(sc *types.Contract) error {
 	// loop over contracts ad try to validate one of them
 	for name, detail := range contracts {
 		// check if the compiled byte code match with the deployed contract
-		match, err := compareContractCode(tx, detail.Code)
+		match, exact, err := compareContractCode(tx, detail.Code)
 		if err != nil {
 			p.log.Errorf("contract byte code comparison failed")
 			return err
@@ -150,6 +193,7 @@ func (p *proxy) ValidateContract(sc *types.Contract) error {
 			}
 
 			// update the contract data
+			sc.IsExactMatch = exact
 			updateContractDetails(sc, detail)
 
 			// write update to the database
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ContractAbi returns the ABI JSON of a validated smart contract,
+// or an empty string if the contract is not known or not validated.
+func (p *proxy) ContractAbi(addr *common.Address) (string, error) {
+	sc, err := p.Contract(addr)
+	if err != nil {
+		return "", err
+	}
+
+	// only validated contracts have a trustworthy ABI
+	if sc == nil || sc.Validated == nil || len(sc.Abi) == 0 {
+		return "", nil
+	}
+	return sc.Abi, nil
+}
@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"sync"
+	"sync/atomic"
+)
+
+// contractCallsAnalysisMaxWorkers is the upper bound of the number of concurrent
+// contract call analysis workers.
+const contractCallsAnalysisMaxWorkers = 32
+
+// contractCallsReanalysisRunning signals the contract calls reanalysis is in progress.
+var contractCallsReanalysisRunning int32
+
+// contractCallsAnalysisActive is the number of contract call analysis workers busy with a call.
+var contractCallsAnalysisActive int32
+
+// ReanalyzeContractCalls starts background decoding of the known failed calls
+// of the given contracts against their current ABI. It returns false
+// if a reanalysis is already running. The calls are analyzed concurrently
+// by a pool of workers, no order of the analysis is guaranteed.
+func (p *proxy) ReanalyzeContractCalls(list []common.Address) bool {
+	if !atomic.CompareAndSwapInt32(&contractCallsReanalysisRunning, 0, 1) {
+		return false
+	}
+
+	go func() {
+		defer atomic.StoreInt32(&contractCallsReanalysisRunning, 0)
+
+		workers := p.contractCallsAnalysisWorkers()
+		calls := make(chan common.Hash, workers)
+		go p.feedContractCalls(list, calls)
+
+		done := analyzeContractCalls(calls, workers, &contractCallsAnalysisActive, p.reanalyzeContractCall)
+		p.log.Noticef("reanalysis of %d contracts done, %d calls updated", len(list), done)
+	}()
+	return true
+}
+
+// WorkerPools provides the state of the background worker pools of the repository.
+func (p *proxy) WorkerPools() []types.WorkerPoolStatus {
+	return []types.WorkerPoolStatus{{
+		Name:   "contract call analysis",
+		Active: int(atomic.LoadInt32(&contractCallsAnalysisActive)),
+		Size:   p.contractCallsAnalysisWorkers(),
+	}}
+}
+
+// contractCallsAnalysisWorkers provides the configured size of the call analysis pool
+// bound to a sane range.
+func (p *proxy) contractCallsAnalysisWorkers() int {
+	workers := p.cfg.Repository.AnalysisWorkers
+	if workers < 1 {
+		return 1
+	}
+	if workers > contractCallsAnalysisMaxWorkers {
+		return contractCallsAnalysisMaxWorkers
+	}
+	return workers
+}
+
+// feedContractCalls pushes the failed calls of the given contracts into the analysis queue.
+func (p *proxy) feedContractCalls(list []common.Address, calls chan<- common.Hash) {
+	defer close(calls)
+
+	for i := range list {
+		hashes, err := p.db.RevertedTransactionsTo(&list[i])
+		if err != nil {
+			continue
+		}
+		for _, h := range hashes {
+			calls <- h
+		}
+	}
+}
+
+// reanalyzeContractCall decodes the revert reason of a single failed call again.
+// The contract ABI is read through the shared contract cache, which is safe for concurrent use.
+func (p *proxy) reanalyzeContractCall(hash *common.Hash) bool {
+	trx, err := p.Transaction(hash, false)
+	if err != nil {
+		p.log.Errorf("can not reanalyze call %s; %s", hash.String(), err.Error())
+		return false
+	}
+	if _, err := p.TransactionRevertReason(trx); err != nil {
+		p.log.Errorf("can not reanalyze call %s; %s", hash.String(), err.Error())
+		return false
+	}
+	return true
+}
+
+// analyzeContractCalls drains the queue of calls by the given number of workers
+// and provides the number of calls analyzed successfully. Each call is analyzed exactly once;
+// the active counter reflects the number of workers busy with a call.
+func analyzeContractCalls(calls <-chan common.Hash, workers int, active *int32, analyze func(*common.Hash) bool) int {
+	var done int64
+	var wg sync.WaitGroup
+
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for h := range calls {
+				atomic.AddInt32(active, 1)
+				if analyze(&h) {
+					atomic.AddInt64(&done, 1)
+				}
+				atomic.AddInt32(active, -1)
+			}
+		}()
+	}
+
+	wg.Wait()
+	return int(done)
+}
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/onsi/gomega"
+	"math/big"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestAnalyzeContractCalls(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	const count = 200
+	calls := make(chan common.Hash, count)
+	for i := 0; i < count; i++ {
+		calls <- common.BigToHash(big.NewInt(int64(i)))
+	}
+	close(calls)
+
+	var active, peak int32
+	var mu sync.Mutex
+	seen := make(map[common.Hash]int)
+
+	done := analyzeContractCalls(calls, 4, &active, func(h *common.Hash) bool {
+		if a := atomic.LoadInt32(&active); a > atomic.LoadInt32(&peak) {
+			atomic.StoreInt32(&peak, a)
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		seen[*h]++
+		return h[31]&1 == 0
+	})
+
+	// each call is analyzed exactly once, in no particular order
+	g.Expect(seen).To(gomega.HaveLen(count))
+	for _, n := range seen {
+		g.Expect(n).To(gomega.Equal(1))
+	}
+	g.Expect(done).To(gomega.Equal(count / 2))
+	g.Expect(peak).To(gomega.BeNumerically("<=", 4))
+	g.Expect(atomic.LoadInt32(&active)).To(gomega.BeZero())
+}
+
+func TestAnalyzeContractCallsSlowCall(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	calls := make(chan common.Hash, 10)
+	slow := common.HexToHash("0x01")
+	calls <- slow
+	for i := 2; i <= 10; i++ {
+		calls <- common.BigToHash(big.NewInt(int64(i)))
+	}
+	close(calls)
+
+	// the slow call is released only after the other calls are done by the other worker
+	release := make(chan struct{})
+	var others int32
+	done := analyzeContractCalls(calls, 2, new(int32), func(h *common.Hash) bool {
+		if *h == slow {
+			<-release
+			return true
+		}
+		if atomic.AddInt32(&others, 1) == 9 {
+			close(release)
+		}
+		return true
+	})
+	g.Expect(done).To(gomega.Equal(10))
+}
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"fantom-api-graphql/internal/repository/rpc/contracts"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// contractClassAbi maps the contract types assignable by the classification
+// to the standard ABI used for contracts without a validated one.
+var contractClassAbi = map[string]string{
+	types.AccountTypeContract:        "",
+	types.AccountTypeERC20Token:      contracts.ERCTwentyMetaData.ABI,
+	types.AccountTypeERC721Contract:  contracts.ERC721MetaData.ABI,
+	types.AccountTypeERC1155Contract: contracts.ERC1155MetaData.ABI,
+}
+
+// ClassifyContract changes the type of the given contract and records the reason
+// of the classification. Both the contract and its account are updated.
+func (p *proxy) ClassifyContract(addr *common.Address, ct string, class string) (*types.Contract, error) {
+	std, ok := contractClassAbi[ct]
+	if !ok {
+		return nil, fmt.Errorf("contract type %s can not be assigned", ct)
+	}
+
+	sc, err := p.Contract(addr)
+	if err != nil {
+		return nil, err
+	}
+	if sc == nil {
+		return nil, fmt.Errorf("contract %s not found", addr.String())
+	}
+	if sc.Type == types.AccountTypeSFC {
+		return nil, fmt.Errorf("type of the SFC contract %s can not be changed", addr.String())
+	}
+
+	if err := p.setContractType(sc, ct, std, class); err != nil {
+		return nil, err
+	}
+	return sc, nil
+}
+
+// setContractType stores the new type of the contract and its account
+// and evicts the cached records derived from the type.
+func (p *proxy) setContractType(sc *types.Contract, ct string, std string, class string) error {
+	// validated and imported ABIs are kept, the standard one follows the type
+	if sc.Validated == nil && isContractClassAbi(sc.Abi) {
+		sc.Abi = std
+	}
+	if sc.Name == "" && ct == types.AccountTypeERC20Token {
+		sc.Name, _ = p.Erc20Name(&sc.Address)
+	}
+
+	sc.Type = ct
+	sc.Classification = class
+	if err := p.db.UpdateContract(sc); err != nil {
+		return err
+	}
+	if err := p.db.SetAccountType(&sc.Address, ct); err != nil {
+		return err
+	}
+
+	p.cache.EvictContract(&sc.Address)
+	p.cache.EvictAccount(&sc.Address)
+	p.cache.EvictErc20Token(&sc.Address)
+	p.cache.EvictErc721Contract(&sc.Address)
+
+	p.log.Noticef("contract %s classified as %s (%s)", sc.Address.String(), ct, class)
+	return nil
+}
+
+// Erc20TransferCount counts the ERC20 shaped transfers emitted by the given contract
+// up to the given limit.
+func (p *proxy) Erc20TransferCount(addr *common.Address, limit int) (int, error) {
+	cnt, err := p.db.Erc20TransferCount(addr, int64(limit))
+	return int(cnt), err
+}
+
+// isContractClassAbi checks if the ABI is empty, or one of the standard ABIs
+// assigned by the classification.
+func isContractClassAbi(def string) bool {
+	if def == "" {
+		return true
+	}
+	for _, std := range contractClassAbi {
+		if def == std {
+			return true
+		}
+	}
+	return false
+}
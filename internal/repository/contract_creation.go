@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"sync/atomic"
+)
+
+// contractCreationBackfillRunning signals the contract creation backfill is in progress.
+var contractCreationBackfillRunning int32
+
+// ContractCreation provides the deployment details of the given contract.
+// Nil is returned for unknown contracts and contracts with unknown deployment.
+func (p *proxy) ContractCreation(addr *common.Address) (*types.ContractCreation, error) {
+	sc, err := p.Contract(addr)
+	if err != nil || sc == nil || sc.TransactionHash == nil {
+		return nil, err
+	}
+
+	// older contracts may be missing the deployment details
+	if sc.Deployer == nil || sc.Origin == nil || sc.Block == nil {
+		if err := p.updateContractCreation(sc); err != nil {
+			return nil, err
+		}
+	}
+
+	return &types.ContractCreation{
+		Address:             sc.Address,
+		TransactionHash:     *sc.TransactionHash,
+		Deployer:            *sc.Deployer,
+		Block:               *sc.Block,
+		TimeStamp:           sc.TimeStamp,
+		IsFactoryDeployment: *sc.Deployer != *sc.Origin,
+	}, nil
+}
+
+// BackfillContractCreations starts background update of deployment details
+// of contracts stored without them. It returns false if the backfill
+// is already running.
+func (p *proxy) BackfillContractCreations() bool {
+	if !atomic.CompareAndSwapInt32(&contractCreationBackfillRunning, 0, 1) {
+		return false
+	}
+
+	go func() {
+		defer atomic.StoreInt32(&contractCreationBackfillRunning, 0)
+
+		list, err := p.db.ContractsWithoutCreation()
+		if err != nil {
+			p.log.Errorf("contract creation backfill failed; %s", err.Error())
+			return
+		}
+
+		p.log.Noticef("contract creation backfill of %d contracts started", len(list))
+		var done int
+		for i := range list {
+			if _, err := p.ContractCreation(&list[i]); err != nil {
+				p.log.Errorf("can not backfill contract %s creation; %s", list[i].String(), err.Error())
+				continue
+			}
+			done++
+		}
+		p.log.Noticef("contract creation backfill done, %d of %d contracts updated", done, len(list))
+	}()
+	return true
+}
+
+// updateContractCreation resolves and stores missing deployment details of the contract.
+func (p *proxy) updateContractCreation(sc *types.Contract) error {
+	// the deployment transaction identifies the top level sender
+	trx, err := p.Transaction(sc.TransactionHash, false)
+	if err != nil {
+		return err
+	}
+	if trx.BlockNumber == nil {
+		return fmt.Errorf("deployment transaction %s of %s is pending", trx.Hash.String(), sc.Address.String())
+	}
+
+	dep := sc.Deployer
+	if dep == nil {
+		dep, err = p.contractDeployer(&sc.Address, trx)
+		if err != nil {
+			return err
+		}
+	}
+	if err := p.db.SetContractCreation(&sc.Address, dep, &trx.From, *trx.BlockNumber); err != nil {
+		return err
+	}
+
+	sc.Deployer = dep
+	sc.Origin = &trx.From
+	sc.Block = trx.BlockNumber
+	p.cache.EvictContract(&sc.Address)
+	return nil
+}
+
+// contractDeployer finds the deployer of the contract created by the given transaction.
+// Contracts created directly by the transaction are deployed by the sender. Contracts
+// created by a factory are deployed by the contract executing the creation,
+// which is found in the call trace of the transaction.
+func (p *proxy) contractDeployer(addr *common.Address, trx *types.Transaction) (*common.Address, error) {
+	if trx.ContractAddress != nil && *trx.ContractAddress == *addr {
+		return &trx.From, nil
+	}
+
+	list, err := p.InternalTransactions(&trx.Hash)
+	if err != nil {
+		return nil, err
+	}
+	if dep := tracedDeployer(addr, list); dep != nil {
+		return dep, nil
+	}
+	return nil, fmt.Errorf("creation of %s not found in transaction %s", addr.String(), trx.Hash.String())
+}
+
+// tracedDeployer provides the creator of the given contract found in the given call trace.
+func tracedDeployer(addr *common.Address, list []*types.InternalTransaction) *common.Address {
+	for _, it := range list {
+		if it.IsCreation() && *it.To == *addr {
+			return &it.From
+		}
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// abiWordSize is the size of a single ABI encoded static value.
+const abiWordSize = 32
+
+// ContractCreationCode provides the init code used to deploy the given contract
+// split into the creation bytecode and the constructor arguments. The constructor
+// arguments are decoded if the contract ABI is known. Nil is returned for unknown contracts;
+// the code is left empty if it can not be recovered from the deployment.
+func (p *proxy) ContractCreationCode(addr *common.Address) (*types.ContractCreationCode, error) {
+	cc, err := p.ContractCreation(addr)
+	if err != nil || cc == nil {
+		return nil, err
+	}
+
+	code := types.ContractCreationCode{
+		Address:             cc.Address,
+		TransactionHash:     cc.TransactionHash,
+		IsFactoryDeployment: cc.IsFactoryDeployment,
+		Args:                make([]types.DecodedCallArg, 0),
+	}
+
+	code.InitCode, err = p.contractInitCode(addr, &cc.TransactionHash)
+	if err != nil {
+		p.log.Warningf("init code of contract %s not available; %s", addr.String(), err.Error())
+		return &code, nil
+	}
+
+	// the runtime code helps to find the end of the creation bytecode; destroyed contracts have none
+	runtime, err := p.rpc.AccountCode(addr, nil)
+	if err != nil {
+		p.log.Errorf("can not get code of contract %s; %s", addr.String(), err.Error())
+	} else {
+		p.checkContractDestroyed(addr, runtime)
+	}
+
+	var ctr *abi.Method
+	if def, err := p.ContractAbi(addr); err == nil && def != "" {
+		if ab, err := parseContractAbi(def); err == nil {
+			ctr = &ab.Constructor
+		}
+	}
+
+	code.Bytecode, code.ConstructorArgs = splitInitCode(code.InitCode, runtime, ctr)
+	if ctr != nil && code.ConstructorArgs != nil {
+		code.Args, code.ArgsSkipped = p.decodeConstructorArgs(ctr, code.ConstructorArgs)
+	}
+	return &code, nil
+}
+
+// contractInitCode loads the init code of the contract from the deployment transaction.
+// Contracts created by a factory are found in the call trace of the transaction.
+func (p *proxy) contractInitCode(addr *common.Address, hash *common.Hash) ([]byte, error) {
+	trx, err := p.Transaction(hash, false)
+	if err != nil {
+		return nil, err
+	}
+	if trx.ContractAddress != nil && *trx.ContractAddress == *addr {
+		return trx.InputData, nil
+	}
+
+	list, err := p.InternalTransactions(hash)
+	if err != nil {
+		return nil, err
+	}
+	for _, it := range list {
+		if it.IsCreation() && *it.To == *addr {
+			return it.Input, nil
+		}
+	}
+	return nil, fmt.Errorf("creation call not found in transaction %s", hash.String())
+}
+
+// decodeConstructorArgs decodes the constructor arguments using the given constructor ABI.
+func (p *proxy) decodeConstructorArgs(ctr *abi.Method, data []byte) ([]types.DecodedCallArg, bool) {
+	list := make([]types.DecodedCallArg, 0, len(ctr.Inputs))
+	if len(ctr.Inputs) == 0 {
+		return list, false
+	}
+
+	// pathological inputs are not decoded
+	if p.cfg.Repository.MaxDecodedInput > 0 && len(data) > p.cfg.Repository.MaxDecodedInput {
+		return list, true
+	}
+
+	values, err := ctr.Inputs.Unpack(data)
+	if err != nil {
+		p.log.Debugf("can not unpack constructor arguments; %s", err.Error())
+		return list, false
+	}
+
+	for i, in := range ctr.Inputs {
+		list = append(list, types.DecodedCallArg{
+			Name:  in.Name,
+			Type:  abiTypeString(in.Type),
+			Value: abiValueString(in.Type, values[i]),
+		})
+	}
+	return list, false
+}
+
+// splitInitCode splits the init code into the creation bytecode and the constructor arguments.
+// The runtime code deployed by the init code ends with the compiler metadata, which marks
+// the end of the creation bytecode; the arguments follow it. If the metadata can not be located,
+// the size of a constructor with only static arguments is used instead. Nil arguments
+// are provided if the init code can not be split.
+func splitInitCode(init []byte, runtime []byte, ctr *abi.Method) ([]byte, []byte) {
+	if len(init) == 0 {
+		return init, nil
+	}
+
+	// CBOR encoded metadata is followed by its 2 bytes big endian length
+	if len(runtime) > 2 {
+		size := int(binary.BigEndian.Uint16(runtime[len(runtime)-2:])) + 2
+		if size > 2 && size <= len(runtime) {
+			if at := bytes.LastIndex(init, runtime[len(runtime)-size:]); at >= 0 {
+				return init[:at+size], init[at+size:]
+			}
+		}
+	}
+
+	// a constructor without dynamic arguments has a known size of the arguments
+	if ctr == nil {
+		return init, nil
+	}
+	size := 0
+	for _, in := range ctr.Inputs {
+		if !isStaticAbiType(&in.Type) {
+			return init, nil
+		}
+		size += abiWordSize
+	}
+	if size > len(init) {
+		return init, nil
+	}
+	return init[:len(init)-size], init[len(init)-size:]
+}
+
+// isStaticAbiType checks if the ABI type is an elementary type encoded in a single word.
+func isStaticAbiType(t *abi.Type) bool {
+	switch t.T {
+	case abi.IntTy, abi.UintTy, abi.BoolTy, abi.AddressTy, abi.FixedBytesTy:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// checkContractDestroyed marks the given contract as self-destructed if its code
+// found at the latest block is empty. Contracts which are not known, and contracts
+// already marked, are skipped.
+func (p *proxy) checkContractDestroyed(addr *common.Address, code []byte) {
+	if len(code) > 0 {
+		return
+	}
+
+	sc, err := p.Contract(addr)
+	if err != nil || sc == nil || sc.Destroyed != nil {
+		return
+	}
+	if _, err := p.markDestroyedAtHead(addr); err != nil {
+		p.log.Errorf("can not mark contract %s destroyed; %s", addr.String(), err.Error())
+	}
+}
+
+// markDestroyedAtHead marks the given contract as self-destructed at the current block.
+// The actual block of the destruction is not known without the call trace.
+func (p *proxy) markDestroyedAtHead(addr *common.Address) (hexutil.Uint64, error) {
+	head, err := p.rpc.BlockHeight()
+	if err != nil {
+		return 0, err
+	}
+
+	blk := hexutil.Uint64(head.ToInt().Uint64())
+	return blk, p.markContractDestroyed(addr, blk)
+}
+
+// isLiveAt checks if the contract is not marked destroyed and the given block
+// is not older than its deployment; a destruction observed before the contract
+// has been re-deployed to the same address does not apply to the new deployment.
+func isLiveAt(sc *types.Contract, block hexutil.Uint64) bool {
+	return sc.Destroyed == nil && (sc.Block == nil || *sc.Block <= block)
+}
+
+// markContractDestroyed marks the given contract as self-destructed at the given block.
+func (p *proxy) markContractDestroyed(addr *common.Address, block hexutil.Uint64) error {
+	if err := p.db.SetContractDestroyed(addr, block); err != nil {
+		return err
+	}
+
+	p.log.Noticef("contract %s self-destructed at block #%d", addr.String(), uint64(block))
+	p.cache.EvictContract(addr)
+	return nil
+}
+
+// markTracedSelfDestructs marks contracts destroyed by self-destruct calls
+// found in the given internal transactions of a transaction.
+func (p *proxy) markTracedSelfDestructs(hash *common.Hash, list []*types.InternalTransaction) {
+	var trx *types.Transaction
+	for _, itx := range list {
+		if itx.Type != types.InternalTrxSelfDestruct || itx.Error != "" {
+			continue
+		}
+
+		// the block of the parent transaction is the block of the destruction
+		if trx == nil {
+			var err error
+			trx, err = p.Transaction(hash, false)
+			if err != nil || trx.BlockNumber == nil {
+				return
+			}
+		}
+
+		sc, err := p.Contract(&itx.From)
+		if err != nil || sc == nil || !isLiveAt(sc, *trx.BlockNumber) {
+			continue
+		}
+		if err := p.markContractDestroyed(&itx.From, *trx.BlockNumber); err != nil {
+			p.log.Errorf("can not mark contract %s destroyed; %s", itx.From.String(), err.Error())
+		}
+	}
+}
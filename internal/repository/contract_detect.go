@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// contractDetectTestAddress represents an address used to probe the account reference
+// methods of the detected contract.
+var contractDetectTestAddress = common.HexToAddress("0xabc00FA001230012300aBc0012300Fa00FACE000")
+
+var erc721InterfaceId = [4]byte{0x80, 0xac, 0x58, 0xcd}  // ERC-721: 0x80ac58cd
+var erc1155InterfaceId = [4]byte{0xd9, 0xb6, 0x7a, 0x26} // ERC-1155: 0xd9b67a26
+
+// DetectContractType identifies the type of the contract by probing the standard
+// interfaces it implements. The name of the token is provided, if available;
+// generic contract type is reported if no token standard has been detected.
+func (p *proxy) DetectContractType(addr *common.Address) (string, string) {
+	if ok, err := p.Erc165SupportsInterface(addr, erc1155InterfaceId); err == nil && ok {
+		return types.AccountTypeERC1155Contract, ""
+	}
+
+	if ok, err := p.Erc165SupportsInterface(addr, erc721InterfaceId); err == nil && ok {
+		// the name is optional for ERC721
+		name, _ := p.Erc20Name(addr)
+		return types.AccountTypeERC721Contract, name
+	}
+
+	if ok, name := p.detectErc20Token(addr); ok {
+		return types.AccountTypeERC20Token, name
+	}
+	return types.AccountTypeContract, ""
+}
+
+// detectErc20Token identifies ERC20 token contracts by trying to call specific contract methods.
+func (p *proxy) detectErc20Token(addr *common.Address) (bool, string) {
+	name, err := p.Erc20Name(addr)
+	if err != nil {
+		return false, ""
+	}
+	if _, err := p.Erc20Symbol(addr); err != nil {
+		return false, ""
+	}
+	if _, err := p.Erc20BalanceOf(addr, &contractDetectTestAddress); err != nil {
+		return false, ""
+	}
+	if _, err := p.Erc20TotalSupply(addr); err != nil {
+		return false, ""
+	}
+	return true, name
+}
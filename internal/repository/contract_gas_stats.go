@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ContractGasStats provides the gas used by transactions sent to the given contract
+// in the given inclusive block range.
+func (p *proxy) ContractGasStats(addr *common.Address, from uint64, to uint64) (*types.ContractGasStats, error) {
+	return p.db.ContractGasStats(addr, from, to)
+}
+
+// TopContractsByGas provides the contracts with the highest gas used by transactions
+// sent to them in the given inclusive block range.
+func (p *proxy) TopContractsByGas(from uint64, to uint64, count int32) ([]*types.ContractGasStats, error) {
+	return p.db.TopContractsByGas(from, to, int64(count))
+}
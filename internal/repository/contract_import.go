@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// ImportContract upserts the ABI and the optional name and compiler version
+// of the given contract into the contract store. The ABI must be a valid JSON ABI
+// and the contract code must exist at the address.
+func (p *proxy) ImportContract(addr *common.Address, abiDef string, name string, compiler string) error {
+	if _, err := parseContractAbi(abiDef); err != nil {
+		return fmt.Errorf("invalid ABI; %s", err.Error())
+	}
+
+	// update a known contract, or make a new one
+	sc, err := p.Contract(addr)
+	if err != nil {
+		return err
+	}
+	if sc == nil {
+		if sc, err = p.importedContract(addr); err != nil {
+			return err
+		}
+	}
+
+	sc.Abi = abiDef
+	if name != "" {
+		sc.Name = name
+	}
+	if compiler != "" {
+		sc.Compiler = compiler
+	}
+
+	if err := p.db.AddContract(sc); err != nil {
+		p.log.Errorf("contract %s import failed; %s", addr.String(), err.Error())
+		return err
+	}
+	p.cache.EvictContract(addr)
+	return nil
+}
+
+// importedContract makes a new contract record of an imported contract. The deployment
+// details are filled in if the deployment transaction is known for the account;
+// they are left empty otherwise.
+func (p *proxy) importedContract(addr *common.Address) (*types.Contract, error) {
+	code, err := p.rpc.AccountCode(addr, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(code) == 0 {
+		return nil, ErrContractCodeMissing
+	}
+
+	sc := types.Contract{Type: types.AccountTypeContract, Address: *addr}
+	acc, err := p.db.Account(addr)
+	if err != nil || acc == nil || acc.ContractTx == nil {
+		return &sc, err
+	}
+
+	trx, err := p.Transaction(acc.ContractTx, false)
+	if err != nil || trx.BlockNumber == nil {
+		p.log.Warningf("deployment %s of imported contract %s not available", acc.ContractTx.String(), addr.String())
+		return &sc, nil
+	}
+
+	sc.TransactionHash = &trx.Hash
+	sc.TimeStamp = hexutil.Uint64(trx.TimeStamp.Unix())
+	sc.Block = trx.BlockNumber
+	sc.Origin = &trx.From
+	if dep, err := p.contractDeployer(addr, trx); err == nil {
+		sc.Deployer = dep
+	}
+	return &sc, nil
+}
@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"math/big"
+)
+
+// ContractImplementation resolves the implementation address of an EIP-1967 proxy contract
+// at the given block, or at the latest state for nil block. Proxies can be upgraded,
+// so the implementation may differ between blocks; an error is returned if the historical
+// state is not available on the node. Nil is returned if the contract is not a proxy.
+func (p *proxy) ContractImplementation(addr *common.Address, block *hexutil.Uint64) (*common.Address, error) {
+	if impl, ok := p.cache.PullProxyImplementation(addr, block); ok {
+		return impl, nil
+	}
+	if err := p.checkStateAvailable(block); err != nil {
+		return nil, err
+	}
+
+	var num *big.Int
+	if block != nil {
+		num = new(big.Int).SetUint64(uint64(*block))
+	}
+
+	impl, err := p.rpc.ProxyImplementation(addr, num)
+	if err != nil {
+		return nil, err
+	}
+	p.cache.PushProxyImplementation(addr, block, impl)
+	return impl, nil
+}
+
+// StorageAt provides the value of the given storage slot of the contract
+// at the given block, or at the latest state for nil block.
+func (p *proxy) StorageAt(addr *common.Address, slot common.Hash, block *hexutil.Uint64) (common.Hash, error) {
+	if err := p.checkStateAvailable(block); err != nil {
+		return common.Hash{}, err
+	}
+
+	var num *big.Int
+	if block != nil {
+		num = new(big.Int).SetUint64(uint64(*block))
+	}
+
+	val, err := p.rpc.StorageAt(addr, slot, num)
+	if err != nil {
+		p.log.Errorf("can not read storage slot %s of %s; %s", slot.String(), addr.String(), err.Error())
+		return common.Hash{}, err
+	}
+	return val, nil
+}
+
+// decodingAbi provides the ABI to be used for decoding interactions with the given contract
+// at the given block. Proxy contracts are decoded against the ABI of their implementation,
+// if the implementation has been validated. An empty string is returned if no ABI is known;
+// the remote ABI source is asked for the missing ABI in the background in that case.
+// The implementation of the contract at the given block must be available; the ABI
+// of the latest implementation is never used to decode historical interactions.
+func (p *proxy) decodingAbi(addr *common.Address, block *hexutil.Uint64) (string, error) {
+	con, err := p.Contract(addr)
+	if err != nil || con == nil {
+		return "", err
+	}
+
+	impl, err := p.ContractImplementation(addr, block)
+	if err != nil {
+		p.log.Debugf("can not resolve implementation of %s; %s", addr.String(), err.Error())
+		return "", err
+	}
+
+	if impl != nil {
+		ic, err := p.Contract(impl)
+		if err != nil {
+			return "", err
+		}
+		if ic != nil && ic.Abi != "" {
+			return ic.Abi, nil
+		}
+		if ic != nil && con.Abi == "" {
+			p.requestRemoteAbi(*impl)
+		}
+	}
+
+	if con.Abi == "" {
+		p.requestRemoteAbi(*addr)
+	}
+	return con.Abi, nil
+}
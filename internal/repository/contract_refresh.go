@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// RefreshContract re-reads the on-chain data of the given contract. The code presence
+// is verified, the contract type is detected again and the proxy implementation is resolved;
+// the cached records derived from the contract are dropped. Contracts classified manually
+// and the SFC keep their type.
+func (p *proxy) RefreshContract(addr *common.Address) (*types.ContractRefresh, error) {
+	sc, err := p.Contract(addr)
+	if err != nil {
+		return nil, err
+	}
+	if sc == nil {
+		return nil, fmt.Errorf("contract %s not found", addr.String())
+	}
+
+	res := types.ContractRefresh{PreviousType: sc.Type, Changes: make([]string, 0)}
+	p.evictContractData(addr)
+
+	code, err := p.rpc.AccountCode(addr, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// there is nothing to classify on a destroyed contract
+	if len(code) == 0 {
+		if err := p.refreshContractDestroyed(sc, &res); err != nil {
+			return nil, err
+		}
+		return p.refreshedContract(addr, &res)
+	}
+
+	if err := p.refreshContractType(sc, &res); err != nil {
+		return nil, err
+	}
+
+	res.Implementation, err = p.rpc.ProxyImplementation(addr, nil)
+	if err != nil {
+		return nil, err
+	}
+	if res.Implementation != nil {
+		p.abiMisses.evict(res.Implementation)
+		if ic, err := p.Contract(res.Implementation); err == nil && ic != nil && ic.Abi == "" {
+			p.requestRemoteAbi(*res.Implementation)
+		}
+	}
+
+	if sc.Abi == "" {
+		p.requestRemoteAbi(*addr)
+	}
+	return p.refreshedContract(addr, &res)
+}
+
+// refreshContractDestroyed marks the contract without code as self-destructed
+// at the current block, unless it's already marked.
+func (p *proxy) refreshContractDestroyed(sc *types.Contract, res *types.ContractRefresh) error {
+	if sc.Destroyed != nil {
+		return nil
+	}
+
+	blk, err := p.markDestroyedAtHead(&sc.Address)
+	if err != nil {
+		return err
+	}
+	res.Changes = append(res.Changes, fmt.Sprintf("code not found, marked self-destructed at block #%d", uint64(blk)))
+	return nil
+}
+
+// refreshContractType detects the type of the contract again and stores it, if changed.
+// Contracts promoted to a token by the observed events are not demoted to the generic type.
+func (p *proxy) refreshContractType(sc *types.Contract, res *types.ContractRefresh) error {
+	if sc.Type == types.AccountTypeSFC || sc.Classification == types.ContractClassManual {
+		return nil
+	}
+
+	ct, _ := p.DetectContractType(&sc.Address)
+	if ct == sc.Type || (ct == types.AccountTypeContract && sc.Classification == types.ContractClassHeuristic) {
+		return nil
+	}
+
+	if err := p.setContractType(sc, ct, contractClassAbi[ct], types.ContractClassDetected); err != nil {
+		return err
+	}
+	res.Changes = append(res.Changes, fmt.Sprintf("type changed from %s to %s", res.PreviousType, ct))
+	return nil
+}
+
+// refreshedContract loads the refreshed contract into the refresh result.
+func (p *proxy) refreshedContract(addr *common.Address, res *types.ContractRefresh) (*types.ContractRefresh, error) {
+	sc, err := p.Contract(addr)
+	if err != nil {
+		return nil, err
+	}
+	res.Contract = sc
+
+	p.log.Noticef("contract %s refreshed; %d changes", addr.String(), len(res.Changes))
+	return res, nil
+}
+
+// evictContractData drops the cached records derived from the given contract.
+func (p *proxy) evictContractData(addr *common.Address) {
+	p.cache.EvictContract(addr)
+	p.cache.EvictAccount(addr)
+	p.cache.EvictErc20Token(addr)
+	p.cache.EvictErc721Contract(addr)
+	p.abiMisses.evict(addr)
+}
@@ -170,7 +170,8 @@ func (db *MongoDbBridge) AccountCount() (uint64, error) {
 }
 
 // AccountTransactions loads list of transaction hashes of an account.
-func (db *MongoDbBridge) AccountTransactions(addr *common.Address, rec *common.Address, cursor *string, count int32) (*types.TransactionList, error) {
+// Only the given optional parts of the transactions are loaded.
+func (db *MongoDbBridge) AccountTransactions(addr *common.Address, rec *common.Address, cursor *string, count int32, fields types.TrxFields) (*types.TransactionList, error) {
 	// nothing to load?
 	if count == 0 {
 		return nil, fmt.Errorf("nothing to do, zero blocks requested")
@@ -187,12 +188,12 @@ func (db *MongoDbBridge) AccountTransactions(addr *common.Address, rec *common.A
 	// make the filter for [(from = Account) OR (to = Account)]
 	if rec == nil {
 		filter := bson.D{{Key: "$or", Value: bson.A{bson.D{{Key: "from", Value: addr.String()}}, bson.D{{Key: "to", Value: addr.String()}}}}}
-		return db.Transactions(cursor, count, &filter)
+		return db.Transactions(cursor, count, &filter, fields)
 	}
 
 	// return list of transactions filtered by the account and recipient
 	filter := bson.D{{Key: "from", Value: addr.String()}, {Key: "to", Value: rec.String()}}
-	return db.Transactions(cursor, count, &filter)
+	return db.Transactions(cursor, count, &filter, fields)
 }
 
 // AccountMarkActivity marks the latest account activity in the repository.
@@ -328,3 +329,51 @@ func (db *MongoDbBridge) loadErcContractsList(cursor *mongo.Cursor) ([]common.Ad
 
 	return list, nil
 }
+
+// AccountTypes loads the types of the known accounts of the given addresses.
+// Addresses not known to the database are not included in the result.
+func (db *MongoDbBridge) AccountTypes(addr []common.Address) (map[common.Address]string, error) {
+	ids := make([]string, len(addr))
+	for i := range addr {
+		ids[i] = addr[i].String()
+	}
+
+	col := db.client.Database(db.dbName).Collection(coAccounts)
+	cursor, err := col.Find(context.Background(),
+		bson.D{{Key: fiAccountPk, Value: bson.D{{Key: "$in", Value: ids}}}},
+		options.Find().SetProjection(bson.D{{Key: fiAccountType, Value: 1}, {Key: fiScCreationTx, Value: 1}}))
+	if err != nil {
+		db.log.Errorf("can not load types of %d accounts; %s", len(addr), err.Error())
+		return nil, err
+	}
+	defer db.closeCursor(cursor)
+
+	res := make(map[common.Address]string, len(addr))
+	for cursor.Next(context.Background()) {
+		var row AccountRow
+		if err := cursor.Decode(&row); err != nil {
+			db.log.Errorf("can not decode account type; %s", err.Error())
+			return nil, err
+		}
+
+		// accounts created by a contract creation are contracts regardless of the type
+		if row.Sc != nil && row.Type == types.AccountTypeWallet {
+			row.Type = types.AccountTypeContract
+		}
+		res[common.HexToAddress(row.Address)] = row.Type
+	}
+	return res, nil
+}
+
+// SetAccountType updates the contract type of the given account.
+func (db *MongoDbBridge) SetAccountType(addr *common.Address, at string) error {
+	col := db.client.Database(db.dbName).Collection(coAccounts)
+
+	if _, err := col.UpdateOne(context.Background(),
+		bson.D{{Key: fiAccountPk, Value: addr.String()}},
+		bson.D{{Key: "$set", Value: bson.D{{Key: fiAccountType, Value: at}}}}); err != nil {
+		db.log.Errorf("can not update account %s type; %s", addr.String(), err.Error())
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,225 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"bytes"
+	"context"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"sort"
+	"time"
+)
+
+// fiTransactionSelector is the name of the field of the method selector of a contract call.
+const fiTransactionSelector = "sel"
+
+// contractActivityRow represents an aggregated row of calls to a single contract.
+type contractActivityRow struct {
+	Contract  string    `bson:"_id"`
+	Calls     int64     `bson:"cnt"`
+	LastCall  time.Time `bson:"last"`
+	Functions []struct {
+		Selector string `bson:"sel"`
+		Calls    int64  `bson:"cnt"`
+	} `bson:"fn"`
+}
+
+// accountActivityMatch builds the filter of contract calls sent by the given account.
+// The calls can be narrowed to a contract by the filter.
+func accountActivityMatch(addr *common.Address, filter ...bson.E) bson.D {
+	match := bson.D{
+		{Key: fiTransactionSender, Value: addr.String()},
+		{Key: fiTransactionSelector, Value: bson.D{{Key: "$exists", Value: true}}},
+	}
+	return append(match, filter...)
+}
+
+// accountActivityPipeline builds the aggregation pipeline grouping contract calls
+// of the given account by the called contract and function.
+func accountActivityPipeline(addr *common.Address) mongo.Pipeline {
+	return mongo.Pipeline{
+		{{Key: "$match", Value: accountActivityMatch(addr)}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: bson.D{
+				{Key: "to", Value: "$" + fiTransactionRecipient},
+				{Key: "sel", Value: "$" + fiTransactionSelector},
+			}},
+			{Key: "cnt", Value: bson.D{{Key: "$sum", Value: 1}}},
+			{Key: "last", Value: bson.D{{Key: "$max", Value: "$" + fiTransactionTimeStamp}}},
+		}}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: "$_id.to"},
+			{Key: "cnt", Value: bson.D{{Key: "$sum", Value: "$cnt"}}},
+			{Key: "last", Value: bson.D{{Key: "$max", Value: "$last"}}},
+			{Key: "fn", Value: bson.D{{Key: "$push", Value: bson.D{
+				{Key: "sel", Value: "$_id.sel"},
+				{Key: "cnt", Value: "$cnt"},
+			}}}},
+		}}},
+	}
+}
+
+// AccountActivity pulls a list of contracts called by the given account sorted
+// by the number of calls from the highest, starting at the specified cursor.
+// The cursor is the address of the contract.
+func (db *MongoDbBridge) AccountActivity(addr *common.Address, cursor *string, count int32) (*types.AccountActivity, error) {
+	// nothing to load?
+	if count == 0 {
+		return nil, fmt.Errorf("nothing to do, zero contracts requested")
+	}
+
+	col := db.client.Database(db.dbName).Collection(coTransactions)
+
+	// find how many contracts the account called
+	total, err := db.accountActivityCount(col, addr)
+	if err != nil {
+		db.log.Errorf("can not count activity of %s; %s", addr.String(), err.Error())
+		return nil, err
+	}
+
+	list := types.AccountActivity{
+		Collection: make([]*types.ContractActivity, 0),
+		Total:      total,
+		IsStart:    total == 0,
+		IsEnd:      total == 0,
+	}
+	if total == 0 {
+		return &list, nil
+	}
+
+	if err := db.accountActivityLoad(col, addr, cursor, count, &list); err != nil {
+		db.log.Errorf("can not load activity of %s; %s", addr.String(), err.Error())
+		return nil, err
+	}
+	return &list, nil
+}
+
+// accountActivityCount counts the contracts called by the given account.
+func (db *MongoDbBridge) accountActivityCount(col *mongo.Collection, addr *common.Address) (uint64, error) {
+	pipe := append(accountActivityPipeline(addr), bson.D{{Key: "$count", Value: "total"}})
+	cur, err := col.Aggregate(context.Background(), pipe, options.Aggregate().SetAllowDiskUse(true))
+	if err != nil {
+		return 0, err
+	}
+	defer db.closeCursor(cur)
+
+	if !cur.Next(context.Background()) {
+		return 0, cur.Err()
+	}
+
+	var row struct {
+		Total int64 `bson:"total"`
+	}
+	if err := cur.Decode(&row); err != nil {
+		return 0, err
+	}
+	return uint64(row.Total), nil
+}
+
+// accountActivityFilter builds the filter of the aggregated contracts to continue
+// after the given cursor in the direction given by the sign of the count.
+func (db *MongoDbBridge) accountActivityFilter(col *mongo.Collection, addr *common.Address, cursor *string, count int32) (bson.D, error) {
+	if cursor == nil {
+		return bson.D{}, nil
+	}
+
+	// find the number of calls of the cursor contract
+	id := common.HexToAddress(*cursor).String()
+	calls, err := col.CountDocuments(context.Background(), accountActivityMatch(addr, bson.E{Key: fiTransactionRecipient, Value: id}))
+	if err != nil {
+		return nil, err
+	}
+
+	// we go down the list on positive count, up on negative
+	op := "$lt"
+	if count < 0 {
+		op = "$gt"
+	}
+	return bson.D{{Key: "$or", Value: bson.A{
+		bson.D{{Key: "cnt", Value: bson.D{{Key: op, Value: calls}}}},
+		bson.D{
+			{Key: "cnt", Value: calls},
+			{Key: "_id", Value: bson.D{{Key: op, Value: id}}},
+		},
+	}}}, nil
+}
+
+// accountActivityLoad loads the page of the contracts called by the account into the list.
+func (db *MongoDbBridge) accountActivityLoad(col *mongo.Collection, addr *common.Address, cursor *string, count int32, list *types.AccountActivity) error {
+	ctx := context.Background()
+
+	fi, err := db.accountActivityFilter(col, addr, cursor, count)
+	if err != nil {
+		return err
+	}
+
+	// from high to low number of calls by default; reversed if loading from bottom
+	sd, limit := -1, count
+	if count < 0 {
+		sd, limit = 1, -count
+	}
+
+	// load one more record so we can detect the list end
+	pipe := append(accountActivityPipeline(addr),
+		bson.D{{Key: "$match", Value: fi}},
+		bson.D{{Key: "$sort", Value: bson.D{{Key: "cnt", Value: sd}, {Key: "_id", Value: sd}}}},
+		bson.D{{Key: "$limit", Value: int64(limit) + 1}},
+	)
+	cur, err := col.Aggregate(ctx, pipe, options.Aggregate().SetAllowDiskUse(true))
+	if err != nil {
+		return err
+	}
+	defer db.closeCursor(cur)
+
+	for cur.Next(ctx) {
+		var row contractActivityRow
+		if err := cur.Decode(&row); err != nil {
+			return err
+		}
+		list.Collection = append(list.Collection, contractActivity(&row))
+	}
+
+	// did we reach the boundary?
+	more := len(list.Collection) > int(limit)
+	if more {
+		list.Collection = list.Collection[:limit]
+	}
+	if count > 0 {
+		list.IsStart, list.IsEnd = cursor == nil, !more
+	} else {
+		list.IsStart, list.IsEnd = !more, cursor == nil
+		list.Reverse()
+	}
+	return nil
+}
+
+// contractActivity converts the aggregated row into the contract activity
+// with functions sorted by the number of calls.
+func contractActivity(row *contractActivityRow) *types.ContractActivity {
+	ca := types.ContractActivity{
+		Contract:  common.HexToAddress(row.Contract),
+		Calls:     uint64(row.Calls),
+		LastCall:  row.LastCall,
+		Functions: make([]*types.FunctionCallCount, 0, len(row.Functions)),
+	}
+	for _, fn := range row.Functions {
+		sel, err := hexutil.Decode(fn.Selector)
+		if err != nil {
+			continue
+		}
+		ca.Functions = append(ca.Functions, &types.FunctionCallCount{Selector: sel, Calls: uint64(fn.Calls)})
+	}
+
+	sort.Slice(ca.Functions, func(i, j int) bool {
+		if ca.Functions[i].Calls != ca.Functions[j].Calls {
+			return ca.Functions[i].Calls > ca.Functions[j].Calls
+		}
+		return bytes.Compare(ca.Functions[i].Selector, ca.Functions[j].Selector) < 0
+	})
+	return &ca
+}
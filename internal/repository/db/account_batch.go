@@ -0,0 +1,119 @@
+package db
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// AccountsByAddress loads accounts identified by the given list of addresses
+// from the off-chain database in a single query. Addresses not known to the database
+// are not present in the resulting map.
+func (db *MongoDbBridge) AccountsByAddress(addr []common.Address) (map[common.Address]*types.Account, error) {
+	// get the collection for accounts
+	col := db.client.Database(db.dbName).Collection(coAccounts)
+
+	// find all the accounts in one go
+	cursor, err := col.Find(context.Background(), bson.D{{Key: fiAccountPk, Value: bson.D{{Key: "$in", Value: addressList(addr)}}}})
+	if err != nil {
+		db.log.Errorf("can not load accounts batch; %s", err.Error())
+		return nil, err
+	}
+	defer db.closeCursor(cursor)
+
+	// decode the rows found
+	list := make(map[common.Address]*types.Account, len(addr))
+	for cursor.Next(context.Background()) {
+		var row AccountRow
+		if err := cursor.Decode(&row); err != nil {
+			db.log.Errorf("can not decode account row; %s", err.Error())
+			return nil, err
+		}
+
+		acc := types.Account{
+			Address:      common.HexToAddress(row.Address),
+			Type:         row.Type,
+			LastActivity: hexutil.Uint64(row.Activity),
+			TrxCounter:   hexutil.Uint64(row.Counter),
+		}
+		if row.Sc != nil {
+			h := common.HexToHash(*row.Sc)
+			acc.ContractTx = &h
+		}
+		list[acc.Address] = &acc
+	}
+	return list, nil
+}
+
+// addressList converts the list of addresses into the list of strings
+// used as primary keys across the off-chain database collections.
+func addressList(addr []common.Address) []string {
+	list := make([]string, len(addr))
+	for i := range addr {
+		list[i] = addr[i].String()
+	}
+	return list
+}
+
+// AccountsAfter loads a batch of accounts active since the given time stamp ordered
+// by their address starting right after the given address. The whole collection can be iterated
+// by passing the address of the last account of the previous batch and zero time stamp.
+func (db *MongoDbBridge) AccountsAfter(after *common.Address, since uint64, limit int64) ([]*types.Account, error) {
+	// get the collection for accounts
+	col := db.client.Database(db.dbName).Collection(coAccounts)
+
+	// continue after the given address, if any
+	filter := bson.D{}
+	if after != nil {
+		filter = append(filter, bson.E{Key: fiAccountPk, Value: bson.D{{Key: "$gt", Value: after.String()}}})
+	}
+	if since > 0 {
+		filter = append(filter, bson.E{Key: fiAccountLastActivity, Value: bson.D{{Key: "$gte", Value: since}}})
+	}
+
+	cursor, err := col.Find(context.Background(), filter, options.Find().
+		SetSort(bson.D{{Key: fiAccountPk, Value: 1}}).
+		SetProjection(bson.D{{Key: fiAccountPk, Value: true}, {Key: fiAccountType, Value: true}}).
+		SetLimit(limit))
+	if err != nil {
+		db.log.Errorf("can not load accounts batch; %s", err.Error())
+		return nil, err
+	}
+	defer db.closeCursor(cursor)
+
+	// decode the rows found
+	list := make([]*types.Account, 0, limit)
+	for cursor.Next(context.Background()) {
+		var row AccountRow
+		if err := cursor.Decode(&row); err != nil {
+			db.log.Errorf("can not decode account row; %s", err.Error())
+			return nil, err
+		}
+		list = append(list, &types.Account{Address: common.HexToAddress(row.Address), Type: row.Type})
+	}
+	return list, nil
+}
+
+// AccountsLastActivity provides the time stamp of the latest account activity
+// recorded in the off-chain database, zero if no account is known.
+func (db *MongoDbBridge) AccountsLastActivity() (uint64, error) {
+	// get the collection for accounts
+	col := db.client.Database(db.dbName).Collection(coAccounts)
+
+	var row AccountRow
+	err := col.FindOne(context.Background(), bson.D{}, options.FindOne().
+		SetSort(bson.D{{Key: fiAccountLastActivity, Value: -1}}).
+		SetProjection(bson.D{{Key: fiAccountLastActivity, Value: true}})).Decode(&row)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return 0, nil
+		}
+		db.log.Errorf("can not load the last account activity; %s", err.Error())
+		return 0, err
+	}
+	return row.Activity, nil
+}
@@ -0,0 +1,77 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
+)
+
+// accountStatsRow represents the block and time stamp of an account transaction.
+type accountStatsRow struct {
+	Block *uint64   `bson:"blk"`
+	Stamp time.Time `bson:"stamp"`
+}
+
+// AccountStats calculates the transaction activity summary of the given account
+// from the stored transactions. The stored transactions are unique by hash,
+// so the summary stays consistent if blocks are processed repeatedly.
+func (db *MongoDbBridge) AccountStats(addr *common.Address) (*types.AccountStats, error) {
+	col := db.client.Database(db.dbName).Collection(coTransactions)
+	filter := bson.D{{Key: "$or", Value: bson.A{
+		bson.D{{Key: fiTransactionSender, Value: addr.String()}},
+		bson.D{{Key: fiTransactionRecipient, Value: addr.String()}},
+	}}}
+
+	// count the transactions
+	cnt, err := col.CountDocuments(context.Background(), filter)
+	if err != nil {
+		db.log.Errorf("can not count transactions of %s; %s", addr.String(), err.Error())
+		return nil, err
+	}
+
+	as := types.AccountStats{Address: *addr, TrxCount: hexutil.Uint64(cnt)}
+	if cnt == 0 {
+		return &as, nil
+	}
+
+	// find the first and the most recent transaction
+	as.FirstBlock, as.FirstSeen, err = db.accountStatsEdge(col, &filter, 1)
+	if err != nil {
+		return nil, err
+	}
+	as.LastBlock, as.LastSeen, err = db.accountStatsEdge(col, &filter, -1)
+	if err != nil {
+		return nil, err
+	}
+	return &as, nil
+}
+
+// accountStatsEdge loads the block and time stamp of the first, or the last
+// transaction of the filtered list, based on the sort direction.
+func (db *MongoDbBridge) accountStatsEdge(col *mongo.Collection, filter *bson.D, dir int) (*hexutil.Uint64, *hexutil.Uint64, error) {
+	sr := col.FindOne(context.Background(), *filter, options.FindOne().
+		SetSort(bson.D{{Key: fiTransactionOrdinalIndex, Value: dir}}).
+		SetProjection(bson.D{{Key: fiTransactionBlock, Value: true}, {Key: fiTransactionTimeStamp, Value: true}}))
+	if sr.Err() != nil {
+		if sr.Err() == mongo.ErrNoDocuments {
+			return nil, nil, nil
+		}
+		db.log.Errorf("can not load account transaction; %s", sr.Err().Error())
+		return nil, nil, sr.Err()
+	}
+
+	var row accountStatsRow
+	if err := sr.Decode(&row); err != nil {
+		db.log.Errorf("can not decode account transaction; %s", err.Error())
+		return nil, nil, err
+	}
+
+	ts := hexutil.Uint64(row.Stamp.Unix())
+	return (*hexutil.Uint64)(row.Block), &ts, nil
+}
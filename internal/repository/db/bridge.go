@@ -3,6 +3,7 @@ package db
 
 import (
 	"context"
+	"errors"
 	"fantom-api-graphql/internal/config"
 	"fantom-api-graphql/internal/logger"
 	"fmt"
@@ -15,6 +16,9 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
+// ErrInvalidCursor is returned if the list cursor received can not be decoded.
+var ErrInvalidCursor = errors.New("invalid cursor value")
+
 // MongoDbBridge represents Mongo DB abstraction layer.
 type MongoDbBridge struct {
 	client *mongo.Client
@@ -22,17 +26,24 @@ type MongoDbBridge struct {
 	dbName string
 
 	// init state marks
-	initAccounts     *sync.Once
-	initTransactions *sync.Once
-	initContracts    *sync.Once
-	initSwaps        *sync.Once
-	initDelegations  *sync.Once
-	initWithdrawals  *sync.Once
-	initRewards      *sync.Once
-	initErc20Trx     *sync.Once
-	initFMintTrx     *sync.Once
-	initEpochs       *sync.Once
-	initGasPrice     *sync.Once
+	initAccounts          *sync.Once
+	initTransactions      *sync.Once
+	initContracts         *sync.Once
+	initSwaps             *sync.Once
+	initDelegations       *sync.Once
+	initWithdrawals       *sync.Once
+	initRewards           *sync.Once
+	initErc20Trx          *sync.Once
+	initFMintTrx          *sync.Once
+	initEpochs            *sync.Once
+	initGasPrice          *sync.Once
+	initRichList          *sync.Once
+	initEpochSeals        *sync.Once
+	initWebhooks          *sync.Once
+	initWebhookDeliveries *sync.Once
+	initTokenPrices       *sync.Once
+	initTrxSubmissions    *sync.Once
+	initTrxStats          *sync.Once
 }
 
 // docListCountAggregationTimeout represents a max duration of DB query executed to calculate
@@ -40,6 +51,9 @@ type MongoDbBridge struct {
 // ad we fall back to full collection documents count estimation.
 const docListCountAggregationTimeout = 500 * time.Millisecond
 
+// dbPingTimeout represents a max duration of the database connection check.
+const dbPingTimeout = 2 * time.Second
+
 // intZero represents an empty big value.
 var intZero = new(big.Int)
 
@@ -67,6 +81,9 @@ func New(cfg *config.Config, log logger.Logger) (*MongoDbBridge, error) {
 
 	// check the state
 	db.CheckDatabaseInitState()
+
+	// update records stored by previous versions
+	db.migrateContractNames()
 	return db, nil
 }
 
@@ -176,6 +193,13 @@ func (db *MongoDbBridge) CheckDatabaseInitState() {
 	db.collectionNeedInit("fmint transactions", db.FMintTransactionCount, &db.initFMintTrx)
 	db.collectionNeedInit("epochs", db.EpochsCount, &db.initEpochs)
 	db.collectionNeedInit("gas price periods", db.GasPricePeriodCount, &db.initGasPrice)
+	db.collectionNeedInit("rich list", db.RichListCount, &db.initRichList)
+	db.collectionNeedInit("epoch seals", db.EpochSealsCount, &db.initEpochSeals)
+	db.collectionNeedInit("webhooks", db.WebhooksCount, &db.initWebhooks)
+	db.collectionNeedInit("webhook deliveries", db.WebhookDeliveriesCount, &db.initWebhookDeliveries)
+	db.collectionNeedInit("token prices", db.TokenPriceSamplesCount, &db.initTokenPrices)
+	db.collectionNeedInit("trx submissions", db.TrxSubmissionsCount, &db.initTrxSubmissions)
+	db.collectionNeedInit("trx stats", db.TrxStatsCount, &db.initTrxStats)
 }
 
 // checkAccountCollectionState checks the Accounts collection state.
@@ -253,3 +277,10 @@ func (db *MongoDbBridge) closeCursor(c *mongo.Cursor) {
 		db.log.Errorf("failed to close query cursor; %s", err.Error())
 	}
 }
+
+// Ping checks the database connection is alive.
+func (db *MongoDbBridge) Ping() error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbPingTimeout)
+	defer cancel()
+	return db.client.Ping(ctx, nil)
+}
@@ -23,9 +23,30 @@ const (
 	// db.contract.createIndex({_id:1,orx:-1},{unique:true})
 	fiContractOrdinalIndex = "orx"
 
+	// fiContractTransaction is the name of the field of the contract deployment transaction hash.
+	fiContractTransaction = "trx"
+
+	// fiContractDeployer is the name of the field of the contract deployer address.
+	fiContractDeployer = "dep"
+
+	// fiContractOrigin is the name of the field of the sender of the contract deployment transaction.
+	fiContractOrigin = "org"
+
+	// fiContractBlock is the name of the field of the contract deployment block.
+	fiContractBlock = "blk"
+
 	// fiContractSourceValidated is the name of the contract source code
 	// validation timestamp field.
 	fiContractSourceValidated = "val"
+
+	// fiContractDestroyed is the name of the field of the block the contract self-destructed at.
+	fiContractDestroyed = "destr"
+
+	// fiContractName is the name of the field of the contract name.
+	fiContractName = "name"
+
+	// fiContractLowName is the name of the field of the lower case contract name used for search.
+	fiContractLowName = "lname"
 )
 
 // initContractsCollection initializes the contracts collection with
@@ -43,6 +64,9 @@ func (db *MongoDbBridge) initContractsCollection(col *mongo.Collection) {
 		},
 	})
 
+	// index the lower case name for the name search
+	ix = append(ix, mongo.IndexModel{Keys: bson.D{{Key: fiContractLowName, Value: 1}}})
+
 	// create indexes
 	if _, err := col.Indexes().CreateMany(context.Background(), ix); err != nil {
 		db.log.Panicf("can not create indexes for contracts collection; %s", err.Error())
@@ -52,6 +76,30 @@ func (db *MongoDbBridge) initContractsCollection(col *mongo.Collection) {
 	db.log.Debugf("contracts collection initialized")
 }
 
+// migrateContractNames adds the lower case name used by the name search to contracts
+// stored by previous versions and makes sure the name is indexed. Contracts already
+// migrated do not match, the migration can be executed repeatedly.
+func (db *MongoDbBridge) migrateContractNames() {
+	col := db.client.Database(db.dbName).Collection(coContract)
+
+	res, err := col.UpdateMany(context.Background(),
+		bson.D{{Key: fiContractLowName, Value: bson.D{{Key: "$exists", Value: false}}}},
+		mongo.Pipeline{{{Key: "$set", Value: bson.D{
+			{Key: fiContractLowName, Value: bson.D{{Key: "$toLower", Value: "$" + fiContractName}}},
+		}}}})
+	if err != nil {
+		db.log.Errorf("can not migrate contract names; %s", err.Error())
+		return
+	}
+	if res.ModifiedCount > 0 {
+		db.log.Noticef("lower case name added to %d contracts", res.ModifiedCount)
+	}
+
+	if _, err := col.Indexes().CreateOne(context.Background(), mongo.IndexModel{Keys: bson.D{{Key: fiContractLowName, Value: 1}}}); err != nil {
+		db.log.Errorf("can not index contract names; %s", err.Error())
+	}
+}
+
 // AddContract stores a smart contract reference in connected persistent storage.
 func (db *MongoDbBridge) AddContract(sc *types.Contract) error {
 	// do we have all needed data?
@@ -164,7 +212,7 @@ func (db *MongoDbBridge) ContractTransaction(addr *common.Address) (*common.Hash
 	}
 
 	// return the hash
-	return &c.TransactionHash, nil
+	return c.TransactionHash, nil
 }
 
 // Contract returns details of a smart contract stored in the Mongo database
@@ -237,7 +285,7 @@ func contractListTopFilter(validatedOnly bool, cursor *string) (*bson.D, error)
 		// get the ordinal index based on cursor
 		ix, err = strconv.ParseUint(*cursor, 10, 64)
 		if err != nil {
-			return nil, fmt.Errorf("invalid cursor value; %s", err.Error())
+			return nil, fmt.Errorf("%w; %s", ErrInvalidCursor, err.Error())
 		}
 	}
 
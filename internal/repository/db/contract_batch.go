@@ -0,0 +1,36 @@
+package db
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// ContractsByAddress loads smart contracts identified by the given list of addresses
+// from the off-chain database in a single query. Addresses without a known contract
+// are not present in the resulting map.
+func (db *MongoDbBridge) ContractsByAddress(addr []common.Address) (map[common.Address]*types.Contract, error) {
+	// get the collection for contracts
+	col := db.client.Database(db.dbName).Collection(coContract)
+
+	// find all the contracts in one go
+	cursor, err := col.Find(context.Background(), bson.D{{Key: fiContractPk, Value: bson.D{{Key: "$in", Value: addressList(addr)}}}})
+	if err != nil {
+		db.log.Errorf("can not load contracts batch; %s", err.Error())
+		return nil, err
+	}
+	defer db.closeCursor(cursor)
+
+	// decode the contracts found
+	list := make(map[common.Address]*types.Contract, len(addr))
+	for cursor.Next(context.Background()) {
+		var con types.Contract
+		if err := cursor.Decode(&con); err != nil {
+			db.log.Errorf("can not decode contract; %s", err.Error())
+			return nil, err
+		}
+		list[con.Address] = &con
+	}
+	return list, nil
+}
@@ -0,0 +1,84 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"context"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ContractsWithoutCreation provides the list of addresses of contracts
+// stored without the deployment details, e.g. contracts stored before
+// the deployer and the origin addresses have been tracked. Contracts
+// with unknown deployment transaction are not included.
+func (db *MongoDbBridge) ContractsWithoutCreation() ([]common.Address, error) {
+	col := db.client.Database(db.dbName).Collection(coContract)
+
+	// the null filter matches missing fields as well
+	cursor, err := col.Find(context.Background(),
+		bson.D{
+			{Key: "$or", Value: bson.A{
+				bson.D{{Key: fiContractDeployer, Value: nil}},
+				bson.D{{Key: fiContractOrigin, Value: nil}},
+			}},
+			{Key: fiContractTransaction, Value: bson.D{{Key: "$nin", Value: bson.A{"", common.Hash{}.String()}}}},
+		},
+		options.Find().SetProjection(bson.D{{Key: fiContractPk, Value: true}}))
+	if err != nil {
+		db.log.Errorf("can not load contracts without deployer; %s", err.Error())
+		return nil, err
+	}
+	defer db.closeCursor(cursor)
+
+	list := make([]common.Address, 0)
+	for cursor.Next(context.Background()) {
+		var row struct {
+			Address string `bson:"_id"`
+		}
+		if err := cursor.Decode(&row); err != nil {
+			db.log.Errorf("can not decode contract address; %s", err.Error())
+			return nil, err
+		}
+		list = append(list, common.HexToAddress(row.Address))
+	}
+	return list, nil
+}
+
+// SetContractCreation updates the deployment details of the given contract.
+func (db *MongoDbBridge) SetContractCreation(addr *common.Address, deployer *common.Address, origin *common.Address, block hexutil.Uint64) error {
+	col := db.client.Database(db.dbName).Collection(coContract)
+
+	if _, err := col.UpdateOne(context.Background(),
+		bson.D{{Key: fiContractPk, Value: addr.String()}},
+		bson.D{{Key: "$set", Value: bson.D{
+			{Key: fiContractDeployer, Value: deployer.String()},
+			{Key: fiContractOrigin, Value: origin.String()},
+			{Key: fiContractBlock, Value: uint64(block)},
+		}}}); err != nil {
+		db.log.Errorf("can not update contract %s deployment; %s", addr.String(), err.Error())
+		return err
+	}
+	return nil
+}
+
+// SetContractDestroyed marks the given contract as self-destructed at the given block.
+// The block of the first detection is kept; re-deployment of the contract
+// replaces the whole document, so a contract can be destroyed again later.
+func (db *MongoDbBridge) SetContractDestroyed(addr *common.Address, block hexutil.Uint64) error {
+	col := db.client.Database(db.dbName).Collection(coContract)
+
+	if _, err := col.UpdateOne(context.Background(),
+		bson.D{
+			{Key: fiContractPk, Value: addr.String()},
+			{Key: fiContractDestroyed, Value: nil},
+		},
+		bson.D{{Key: "$set", Value: bson.D{
+			{Key: fiContractDestroyed, Value: uint64(block)},
+		}}}); err != nil {
+		db.log.Errorf("can not mark contract %s destroyed; %s", addr.String(), err.Error())
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// fiTransactionGasUsed is the name of the field of the gas used by the transaction
+// as provided by the transaction receipt.
+const fiTransactionGasUsed = "gas_use"
+
+// contractGasRow represents an aggregated gas consumption row of a contract.
+type contractGasRow struct {
+	Address string `bson:"_id"`
+	Gas     int64  `bson:"gas"`
+	Calls   int64  `bson:"cnt"`
+}
+
+// contractGasPipeline builds the aggregation pipeline summarizing the gas used by transactions
+// sent to contracts in the given inclusive block range. The match can be narrowed by the filter.
+func contractGasPipeline(from uint64, to uint64, filter ...bson.E) mongo.Pipeline {
+	match := bson.D{
+		{Key: fiTransactionBlock, Value: bson.D{{Key: "$gte", Value: from}, {Key: "$lte", Value: to}}},
+		{Key: fiTransactionRecipient, Value: bson.D{{Key: "$ne", Value: nil}}},
+		{Key: fiTransactionGasUsed, Value: bson.D{{Key: "$ne", Value: nil}}},
+	}
+	match = append(match, filter...)
+
+	return mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: "$" + fiTransactionRecipient},
+			{Key: "gas", Value: bson.D{{Key: "$sum", Value: "$" + fiTransactionGasUsed}}},
+			{Key: "cnt", Value: bson.D{{Key: "$sum", Value: 1}}},
+		}}},
+	}
+}
+
+// ContractGasStats aggregates the gas used by transactions sent to the given contract
+// in the given inclusive block range.
+func (db *MongoDbBridge) ContractGasStats(addr *common.Address, from uint64, to uint64) (*types.ContractGasStats, error) {
+	col := db.client.Database(db.dbName).Collection(coTransactions)
+
+	pipe := contractGasPipeline(from, to, bson.E{Key: fiTransactionRecipient, Value: addr.String()})
+	cur, err := col.Aggregate(context.Background(), pipe, options.Aggregate().SetAllowDiskUse(true))
+	if err != nil {
+		db.log.Errorf("can not aggregate gas stats of %s; %s", addr.String(), err.Error())
+		return nil, err
+	}
+
+	defer db.closeCursor(cur)
+	list, err := loadContractGasStats(cur, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	// no transactions to the contract in the range
+	if len(list) == 0 {
+		return &types.ContractGasStats{Address: *addr, FromBlock: from, ToBlock: to}, nil
+	}
+	return list[0], nil
+}
+
+// TopContractsByGas aggregates the gas used by transactions sent to contracts
+// in the given inclusive block range and provides the top consumers.
+func (db *MongoDbBridge) TopContractsByGas(from uint64, to uint64, count int64) ([]*types.ContractGasStats, error) {
+	col := db.client.Database(db.dbName).Collection(coTransactions)
+
+	pipe := append(contractGasPipeline(from, to),
+		bson.D{{Key: "$sort", Value: bson.D{{Key: "gas", Value: -1}}}},
+		bson.D{{Key: "$limit", Value: count}},
+	)
+	cur, err := col.Aggregate(context.Background(), pipe, options.Aggregate().SetAllowDiskUse(true))
+	if err != nil {
+		db.log.Errorf("can not aggregate top contracts by gas; %s", err.Error())
+		return nil, err
+	}
+
+	defer db.closeCursor(cur)
+	return loadContractGasStats(cur, from, to)
+}
+
+// loadContractGasStats loads the list of contract gas stats from the given cursor.
+func loadContractGasStats(cur *mongo.Cursor, from uint64, to uint64) ([]*types.ContractGasStats, error) {
+	list := make([]*types.ContractGasStats, 0)
+	for cur.Next(context.Background()) {
+		var row contractGasRow
+		if err := cur.Decode(&row); err != nil {
+			return nil, err
+		}
+		list = append(list, &types.ContractGasStats{
+			Address:   common.HexToAddress(row.Address),
+			FromBlock: from,
+			ToBlock:   to,
+			GasUsed:   uint64(row.Gas),
+			Calls:     uint64(row.Calls),
+		})
+	}
+	return list, nil
+}
@@ -42,6 +42,9 @@ func (db *MongoDbBridge) initDelegationCollection(col *mongo.Collection) {
 	ix = append(ix, mongo.IndexModel{Keys: bson.D{{Key: types.FiDelegationOrdinal, Value: -1}}})
 	ix = append(ix, mongo.IndexModel{Keys: bson.D{{Key: types.FiDelegationStamp, Value: -1}}})
 
+	// index the receiving validator with the value for delegations sorted by amount
+	ix = append(ix, mongo.IndexModel{Keys: bson.D{{Key: types.FiDelegationToValidator, Value: 1}, {Key: types.FiDelegationValue, Value: -1}}})
+
 	// create indexes
 	if _, err := col.Indexes().CreateMany(context.Background(), ix); err != nil {
 		db.log.Panicf("can not create indexes for delegation collection; %s", err.Error())
@@ -271,7 +274,7 @@ func (db *MongoDbBridge) dlgListCollectRangeMarks(col *mongo.Collection, list *t
 		id, err := primitive.ObjectIDFromHex(*cursor)
 		if err != nil {
 			db.log.Errorf("invalid delegation cursor ID; %s", err.Error())
-			return nil, err
+			return nil, fmt.Errorf("%w; %s", ErrInvalidCursor, err.Error())
 		}
 
 		// look for the first ordinal to make sure it's there
@@ -0,0 +1,189 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"math/big"
+)
+
+// DelegationsByValue pulls list of delegations sorted by their active value
+// from the highest to the lowest, starting at the specified cursor.
+func (db *MongoDbBridge) DelegationsByValue(cursor *string, count int32, filter *bson.D) (*types.DelegationList, error) {
+	// nothing to load?
+	if count == 0 {
+		return nil, fmt.Errorf("nothing to do, zero delegations requested")
+	}
+
+	// make sure some filter is used
+	if nil == filter {
+		filter = &bson.D{}
+	}
+
+	// get the collection
+	col := db.client.Database(db.dbName).Collection(colDelegations)
+
+	// find how many delegations do we have in the database
+	total, err := col.CountDocuments(context.Background(), *filter)
+	if err != nil {
+		db.log.Errorf("can not count delegations; %s", err.Error())
+		return nil, err
+	}
+
+	list := types.DelegationList{
+		Collection: make([]*types.Delegation, 0),
+		Total:      uint64(total),
+		IsStart:    total == 0,
+		IsEnd:      total == 0,
+		Filter:     *filter,
+	}
+	if total == 0 {
+		return &list, nil
+	}
+
+	// load the data
+	if err := db.dlgValueListLoad(col, cursor, count, &list); err != nil {
+		db.log.Errorf("can not load delegation list by value; %s", err.Error())
+		return nil, err
+	}
+	return &list, nil
+}
+
+// dlgValueListFilter extends the list filter to continue after the given cursor
+// in the direction given by the sign of the count.
+func (db *MongoDbBridge) dlgValueListFilter(col *mongo.Collection, cursor *string, count int32, list *types.DelegationList) (bson.D, error) {
+	if cursor == nil {
+		return list.Filter, nil
+	}
+
+	// decode the cursor
+	id, err := primitive.ObjectIDFromHex(*cursor)
+	if err != nil {
+		db.log.Errorf("invalid delegation cursor ID; %s", err.Error())
+		return nil, fmt.Errorf("%w; %s", ErrInvalidCursor, err.Error())
+	}
+
+	// find the value of the cursor delegation
+	var row struct {
+		Value uint64 `bson:"val"`
+	}
+	sr := col.FindOne(context.Background(),
+		append(list.Filter, bson.E{Key: types.FiDelegationPk, Value: id}),
+		options.FindOne().SetProjection(bson.D{{Key: types.FiDelegationValue, Value: true}}))
+	if err := sr.Decode(&row); err != nil {
+		db.log.Errorf("can not find the initial delegation; %s", err.Error())
+		return nil, err
+	}
+
+	// we go down the list on positive count, up on negative
+	op := "$lt"
+	if count < 0 {
+		op = "$gt"
+	}
+	return append(list.Filter, bson.E{Key: "$or", Value: bson.A{
+		bson.D{{Key: types.FiDelegationValue, Value: bson.D{{Key: op, Value: row.Value}}}},
+		bson.D{
+			{Key: types.FiDelegationValue, Value: row.Value},
+			{Key: types.FiDelegationPk, Value: bson.D{{Key: op, Value: id}}},
+		},
+	}}), nil
+}
+
+// dlgValueListLoad loads the initialized list of delegations sorted by value.
+func (db *MongoDbBridge) dlgValueListLoad(col *mongo.Collection, cursor *string, count int32, list *types.DelegationList) error {
+	ctx := context.Background()
+
+	// get the filter
+	fi, err := db.dlgValueListFilter(col, cursor, count, list)
+	if err != nil {
+		return err
+	}
+
+	// from high to low value by default; reversed if loading from bottom
+	sd, limit := -1, count
+	if count < 0 {
+		sd, limit = 1, -count
+	}
+
+	// load one more record so we can detect the list end
+	ld, err := col.Find(ctx, fi, options.Find().
+		SetSort(bson.D{{Key: types.FiDelegationValue, Value: sd}, {Key: types.FiDelegationPk, Value: sd}}).
+		SetLimit(int64(limit)+1))
+	if err != nil {
+		return err
+	}
+	defer db.closeCursor(ld)
+
+	for ld.Next(ctx) {
+		var row types.Delegation
+		if err = ld.Decode(&row); err != nil {
+			db.log.Errorf("can not decode the delegation list row; %s", err.Error())
+			return err
+		}
+		list.Collection = append(list.Collection, &row)
+	}
+
+	// did we reach the boundary?
+	more := len(list.Collection) > int(limit)
+	if more {
+		list.Collection = list.Collection[:limit]
+	}
+	if count > 0 {
+		list.IsStart, list.IsEnd = cursor == nil, !more
+	} else {
+		list.IsStart, list.IsEnd = !more, cursor == nil
+		list.Reverse()
+	}
+	return nil
+}
+
+// DelegationsSummary calculates the number of active delegations and the sum
+// of their active amount for the given filter. The amount is aggregated
+// with the precision of the stored delegation value.
+func (db *MongoDbBridge) DelegationsSummary(filter *bson.D) (*types.DelegationSummary, error) {
+	// make sure some filter is used
+	if nil == filter {
+		filter = &bson.D{}
+	}
+
+	// aggregate active delegations only
+	col := db.client.Database(db.dbName).Collection(colDelegations)
+	cr, err := col.Aggregate(context.Background(), mongo.Pipeline{
+		{{Key: "$match", Value: append(*filter, bson.E{Key: types.FiDelegationValue, Value: bson.D{{Key: "$gt", Value: 0}}})}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: nil},
+			{Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}},
+			{Key: "value", Value: bson.D{{Key: "$sum", Value: "$" + types.FiDelegationValue}}},
+		}}},
+	})
+	if err != nil {
+		db.log.Errorf("can not aggregate delegations; %s", err.Error())
+		return nil, err
+	}
+	defer db.closeCursor(cr)
+
+	// no active delegations found
+	sum := types.DelegationSummary{}
+	if !cr.Next(context.Background()) {
+		return &sum, nil
+	}
+
+	var row struct {
+		Count int64 `bson:"count"`
+		Value int64 `bson:"value"`
+	}
+	if err := cr.Decode(&row); err != nil {
+		db.log.Errorf("can not decode delegations summary; %s", err.Error())
+		return nil, err
+	}
+
+	sum.Count = hexutil.Uint64(row.Count)
+	sum.Amount = (hexutil.Big)(*new(big.Int).Mul(big.NewInt(row.Value), types.DelegationDecimalsCorrection))
+	return &sum, nil
+}
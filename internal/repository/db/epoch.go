@@ -9,6 +9,7 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
 )
 
 const (
@@ -45,7 +46,7 @@ func (db *MongoDbBridge) initEpochsCollection(col *mongo.Collection) {
 func (db *MongoDbBridge) AddEpoch(e *types.Epoch) error {
 	// do we have all needed data? we reject epochs without any stake
 	if e == nil || e.EndTime == 0 {
-		return fmt.Errorf("empty epoch received", e)
+		return fmt.Errorf("empty epoch received")
 	}
 
 	// get the collection for transactions
@@ -96,6 +97,17 @@ func (db *MongoDbBridge) LastKnownEpoch() (uint64, error) {
 	return db.epochListBorderPk(db.client.Database(db.dbName).Collection(colEpochs), options.FindOne().SetSort(bson.D{{Key: fiEpochEndTime, Value: -1}}))
 }
 
+// EpochAtTime provides the id of the epoch in progress at the given time stamp,
+// e.g. the first known epoch sealed at or after the time.
+// The mongo.ErrNoDocuments error is returned if no such epoch has been sealed yet.
+func (db *MongoDbBridge) EpochAtTime(ts int64) (uint64, error) {
+	return db.epochListBorderPk(
+		db.client.Database(db.dbName).Collection(colEpochs),
+		options.FindOne().SetSort(bson.D{{Key: fiEpochEndTime, Value: 1}}),
+		bson.D{{Key: fiEpochEndTime, Value: bson.D{{Key: "$gte", Value: time.Unix(ts, 0)}}}},
+	)
+}
+
 // EpochsCount calculates total number of epochs in the database.
 func (db *MongoDbBridge) EpochsCount() (uint64, error) {
 	return db.EstimateCount(db.client.Database(db.dbName).Collection(colEpochs))
@@ -162,7 +174,7 @@ func (db *MongoDbBridge) epochListCollectRangeMarks(col *mongo.Collection, list
 }
 
 // rewListBorderPk finds the top PK of the reward claims collection based on given filter and options.
-func (db *MongoDbBridge) epochListBorderPk(col *mongo.Collection, opt *options.FindOneOptions) (uint64, error) {
+func (db *MongoDbBridge) epochListBorderPk(col *mongo.Collection, opt *options.FindOneOptions, filter ...bson.D) (uint64, error) {
 	// prep container
 	var row struct {
 		Value uint64 `bson:"_id"`
@@ -171,8 +183,14 @@ func (db *MongoDbBridge) epochListBorderPk(col *mongo.Collection, opt *options.F
 	// make sure we pull only what we need
 	opt.SetProjection(bson.D{{Key: fiEpochPk, Value: true}})
 
+	// apply the optional filter
+	fi := bson.D{}
+	if len(filter) > 0 {
+		fi = filter[0]
+	}
+
 	// try to decode
-	sr := col.FindOne(context.Background(), bson.D{}, opt)
+	sr := col.FindOne(context.Background(), fi, opt)
 	err := sr.Decode(&row)
 	if err != nil {
 		return 0, err
@@ -0,0 +1,99 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// colEpochSeals represents the name of the epoch seals collection in database.
+const colEpochSeals = "epoch_seals"
+
+// initEpochSealsCollection initializes the epoch seals collection with
+// indexes and additional parameters needed by the app.
+func (db *MongoDbBridge) initEpochSealsCollection(col *mongo.Collection) {
+	// prepare index models
+	ix := make([]mongo.IndexModel, 0)
+
+	// index the sealing block to find the seal of the epoch containing a block
+	ix = append(ix, mongo.IndexModel{
+		Keys:    bson.D{{Key: types.FiEpochSealBlock, Value: 1}},
+		Options: new(options.IndexOptions).SetUnique(true),
+	})
+
+	// create indexes
+	if _, err := col.Indexes().CreateMany(context.Background(), ix); err != nil {
+		db.log.Panicf("can not create indexes for epoch seals collection; %s", err.Error())
+	}
+
+	// log we are done that
+	db.log.Debugf("epoch seals collection initialized")
+}
+
+// AddEpochSeal stores the seal of an epoch in the persistent storage.
+// A seal of an epoch already known is replaced, e.g. after a block re-scan.
+func (db *MongoDbBridge) AddEpochSeal(es *types.EpochSeal) error {
+	// do we have anything to store at all?
+	if es == nil {
+		return fmt.Errorf("no epoch seal to store")
+	}
+
+	// get the collection
+	col := db.client.Database(db.dbName).Collection(colEpochSeals)
+
+	// try to do the insert
+	_, err := col.ReplaceOne(context.Background(), bson.D{{Key: types.FiEpochSealEpoch, Value: int64(es.Epoch)}}, es, options.Replace().SetUpsert(true))
+	if err != nil {
+		db.log.Errorf("can not store seal of epoch #%d; %s", uint64(es.Epoch), err.Error())
+		return err
+	}
+
+	// make sure epoch seals collection is initialized
+	if db.initEpochSeals != nil {
+		db.initEpochSeals.Do(func() { db.initEpochSealsCollection(col); db.initEpochSeals = nil })
+	}
+	return nil
+}
+
+// EpochSealsCount calculates total number of epoch seals in the database.
+func (db *MongoDbBridge) EpochSealsCount() (uint64, error) {
+	return db.EstimateCount(db.client.Database(db.dbName).Collection(colEpochSeals))
+}
+
+// EpochSeal provides the seal of the given epoch; nil if the seal is not known.
+func (db *MongoDbBridge) EpochSeal(epoch uint64) (*types.EpochSeal, error) {
+	return db.findEpochSeal(bson.D{{Key: types.FiEpochSealEpoch, Value: int64(epoch)}}, options.FindOne())
+}
+
+// LastEpochSeal provides the seal of the most recent sealed epoch; nil if no seal is known.
+func (db *MongoDbBridge) LastEpochSeal() (*types.EpochSeal, error) {
+	return db.findEpochSeal(bson.D{}, options.FindOne().SetSort(bson.D{{Key: types.FiEpochSealEpoch, Value: -1}}))
+}
+
+// BlockEpochSeal provides the seal of the epoch containing the given block,
+// e.g. the first seal at or after the block; nil if the epoch has not been sealed yet.
+func (db *MongoDbBridge) BlockEpochSeal(block uint64) (*types.EpochSeal, error) {
+	return db.findEpochSeal(
+		bson.D{{Key: types.FiEpochSealBlock, Value: bson.D{{Key: "$gte", Value: int64(block)}}}},
+		options.FindOne().SetSort(bson.D{{Key: types.FiEpochSealBlock, Value: 1}}),
+	)
+}
+
+// findEpochSeal loads a single epoch seal using the given filter and options.
+func (db *MongoDbBridge) findEpochSeal(filter bson.D, opt *options.FindOneOptions) (*types.EpochSeal, error) {
+	col := db.client.Database(db.dbName).Collection(colEpochSeals)
+
+	var es types.EpochSeal
+	if err := col.FindOne(context.Background(), filter, opt).Decode(&es); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		db.log.Errorf("can not load epoch seal; %s", err.Error())
+		return nil, err
+	}
+	return &es, nil
+}
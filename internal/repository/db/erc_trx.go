@@ -9,6 +9,7 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
 )
 
 // colErcTransactions represents the name of the ERC20 transaction collection in database.
@@ -373,3 +374,133 @@ func (db *MongoDbBridge) TokenTransactionsByCall(trxHash *common.Hash) ([]*types
 	}
 	return list, nil
 }
+
+// Erc20Approvals provides the most recent ERC20 approval of each spender of the given owner,
+// optionally limited to the given token. Revoked approvals are included with zero amount.
+func (db *MongoDbBridge) Erc20Approvals(owner *common.Address, token *common.Address) ([]*types.TokenTransaction, error) {
+	col := db.client.Database(db.dbName).Collection(colErcTransactions)
+
+	filter := bson.D{
+		{Key: types.FiTokenTransactionSender, Value: owner.String()},
+		{Key: types.FiTokenTransactionType, Value: types.TokenTrxTypeApproval},
+		{Key: types.FiTokenTransactionTokenType, Value: types.AccountTypeERC20Token},
+	}
+	if token != nil {
+		filter = append(filter, bson.E{Key: types.FiTokenTransactionToken, Value: token.String()})
+	}
+
+	// the PK follows the block and log order, so the first approval of each pair is the last one set
+	ld, err := col.Aggregate(context.Background(), mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$sort", Value: bson.D{{Key: types.FiTokenTransactionPk, Value: -1}}}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: bson.D{
+				{Key: "tok", Value: "$" + types.FiTokenTransactionToken},
+				{Key: "to", Value: "$" + types.FiTokenTransactionRecipient},
+			}},
+			{Key: "last", Value: bson.D{{Key: "$first", Value: "$$ROOT"}}},
+		}}},
+		{{Key: "$replaceRoot", Value: bson.D{{Key: "newRoot", Value: "$last"}}}},
+		{{Key: "$sort", Value: bson.D{{Key: types.FiTokenTransactionPk, Value: -1}}}},
+	})
+	if err != nil {
+		db.log.Errorf("can not load approvals of %s; %s", owner.String(), err.Error())
+		return nil, err
+	}
+	defer db.closeCursor(ld)
+
+	list := make([]*types.TokenTransaction, 0)
+	for ld.Next(context.Background()) {
+		var row types.TokenTransaction
+		if err = ld.Decode(&row); err != nil {
+			db.log.Errorf("can not decode the token approval; %s", err.Error())
+			return nil, err
+		}
+		list = append(list, &row)
+	}
+	return list, nil
+}
+
+// Erc20TransferCount counts the ERC20 transfers, mints and burns of the given token
+// up to the given limit.
+func (db *MongoDbBridge) Erc20TransferCount(token *common.Address, limit int64) (int64, error) {
+	col := db.client.Database(db.dbName).Collection(colErcTransactions)
+
+	cnt, err := col.CountDocuments(context.Background(), bson.D{
+		{Key: types.FiTokenTransactionToken, Value: token.String()},
+		{Key: types.FiTokenTransactionTokenType, Value: types.AccountTypeERC20Token},
+		{Key: types.FiTokenTransactionType, Value: bson.D{{Key: "$in", Value: bson.A{
+			types.TokenTrxTypeTransfer, types.TokenTrxTypeMint, types.TokenTrxTypeBurn,
+		}}}},
+	}, options.Count().SetLimit(limit))
+	if err != nil {
+		db.log.Errorf("can not count transfers of token %s; %s", token.String(), err.Error())
+		return 0, err
+	}
+	return cnt, nil
+}
+
+// erc20TransferStatsRow represents an aggregated row of ERC20 token transfers.
+type erc20TransferStatsRow struct {
+	Token     string  `bson:"_id"`
+	Transfers int64   `bson:"cnt"`
+	Holders   int64   `bson:"hld"`
+	Amount    float64 `bson:"amo"`
+}
+
+// Erc20TransferStats aggregates transfers, mints and burns of ERC20 tokens made since the given time
+// and provides the top tokens by the number of transfers, or by the number of unique recipients.
+func (db *MongoDbBridge) Erc20TransferStats(since time.Time, byHolders bool, count int64) ([]types.TokenTransferStats, error) {
+	col := db.client.Database(db.dbName).Collection(colErcTransactions)
+
+	// the ordinal index starts with the time stamp, so the indexed field limits the period
+	sortBy := "cnt"
+	if byHolders {
+		sortBy = "hld"
+	}
+	pipe := mongo.Pipeline{
+		{{Key: "$match", Value: bson.D{
+			{Key: types.FiTokenTransactionOrdinal, Value: bson.D{{Key: "$gte", Value: uint64(since.Unix()&0x7FFFFFFFFF) << 24}}},
+			{Key: types.FiTokenTransactionTokenType, Value: types.AccountTypeERC20Token},
+			{Key: types.FiTokenTransactionType, Value: bson.D{{Key: "$in", Value: bson.A{
+				types.TokenTrxTypeTransfer, types.TokenTrxTypeMint, types.TokenTrxTypeBurn,
+			}}}},
+		}}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: "$" + types.FiTokenTransactionToken},
+			{Key: "cnt", Value: bson.D{{Key: "$sum", Value: 1}}},
+			{Key: "amo", Value: bson.D{{Key: "$sum", Value: bson.D{{Key: "$toDouble", Value: "$val"}}}}},
+			{Key: "hld", Value: bson.D{{Key: "$addToSet", Value: "$" + types.FiTokenTransactionRecipient}}},
+		}}},
+		{{Key: "$project", Value: bson.D{
+			{Key: "cnt", Value: 1},
+			{Key: "amo", Value: 1},
+			{Key: "hld", Value: bson.D{{Key: "$size", Value: "$hld"}}},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: sortBy, Value: -1}, {Key: "_id", Value: 1}}}},
+		{{Key: "$limit", Value: count}},
+	}
+
+	cur, err := col.Aggregate(context.Background(), pipe, options.Aggregate().SetAllowDiskUse(true))
+	if err != nil {
+		db.log.Errorf("can not aggregate ERC20 transfer stats; %s", err.Error())
+		return nil, err
+	}
+	defer db.closeCursor(cur)
+
+	list := make([]types.TokenTransferStats, 0, count)
+	for cur.Next(context.Background()) {
+		var row erc20TransferStatsRow
+		if err := cur.Decode(&row); err != nil {
+			db.log.Errorf("can not decode ERC20 transfer stats; %s", err.Error())
+			return nil, err
+		}
+		list = append(list, types.TokenTransferStats{
+			Token:     common.HexToAddress(row.Token),
+			Transfers: uint64(row.Transfers),
+			Holders:   uint64(row.Holders),
+			Amount:    row.Amount,
+		})
+	}
+	return list, nil
+}
@@ -0,0 +1,220 @@
+package db
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	retypes "github.com/ethereum/go-ethereum/core/types"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"strconv"
+	"strings"
+)
+
+const (
+	// fiTransactionLogs is the name of the field of the transaction log records.
+	fiTransactionLogs = "logs"
+
+	// fiLogAddress is the name of the log record field of the emitting contract address.
+	// db.transaction.createIndex({"logs.addr":1,blk:1}).
+	fiLogAddress = "addr"
+
+	// fiLogTopics is the name of the log record field of the topics list.
+	fiLogTopics = "top"
+
+	// fiLogIndex is the name of the log record field of the log index in the block.
+	fiLogIndex = "ix"
+)
+
+// eventLogRow represents a single unwound log record with its transaction details.
+type eventLogRow struct {
+	Hash      string        `bson:"_id"`
+	Ordinal   uint64        `bson:"orx"`
+	BlockID   uint64        `bson:"blk"`
+	BlockHash *string       `bson:"blk_h"`
+	BlkIndex  *uint64       `bson:"bix"`
+	Log       types.BsonLog `bson:"logs"`
+}
+
+// logsIndex provides the index model of the transaction collection
+// used by the logs query with the emitting address filter.
+func logsIndex() mongo.IndexModel {
+	name := "logs_addr_blk"
+	return mongo.IndexModel{
+		Keys: bson.D{{Key: fiTransactionLogs + "." + fiLogAddress, Value: 1}, {Key: fiTransactionBlock, Value: 1}},
+		Options: &options.IndexOptions{
+			Name: &name,
+		},
+	}
+}
+
+// EventLogs loads a page of log records matching the given filter from the transactions
+// collection. The records are ordered the way they were emitted, the cursor continues
+// after the log record it identifies.
+func (db *MongoDbBridge) EventLogs(filter *types.EventLogFilter, cursor *string, count int32) (*types.EventLogList, error) {
+	// get the collection for transactions
+	col := db.client.Database(db.dbName).Collection(coTransactions)
+
+	// the log condition used both to pre-select transactions and to filter the unwound records
+	cond := eventLogCondition(filter)
+	after, err := eventLogCursorFilter(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	pipe := mongo.Pipeline{
+		{{Key: "$match", Value: eventLogTrxFilter(filter, cond)}},
+		{{Key: "$sort", Value: bson.D{{Key: fiTransactionOrdinalIndex, Value: 1}}}},
+		{{Key: "$project", Value: bson.D{
+			{Key: fiTransactionOrdinalIndex, Value: 1},
+			{Key: fiTransactionBlock, Value: 1},
+			{Key: "blk_h", Value: 1},
+			{Key: "bix", Value: 1},
+			{Key: fiTransactionLogs, Value: 1},
+		}}},
+		{{Key: "$unwind", Value: "$" + fiTransactionLogs}},
+		{{Key: "$match", Value: eventLogRecordFilter(cond, after)}},
+		{{Key: "$sort", Value: bson.D{{Key: fiTransactionOrdinalIndex, Value: 1}, {Key: fiTransactionLogs + "." + fiLogIndex, Value: 1}}}},
+		{{Key: "$limit", Value: int64(count) + 1}},
+	}
+
+	cur, err := col.Aggregate(context.Background(), pipe)
+	if err != nil {
+		db.log.Errorf("can not load logs; %s", err.Error())
+		return nil, err
+	}
+	defer db.closeCursor(cur)
+
+	list := types.EventLogList{Collection: make([]*types.EventLog, 0, count)}
+	for cur.Next(context.Background()) {
+		// we loaded one record over the page size to know if there is another page
+		if len(list.Collection) == int(count) {
+			next := list.Collection[len(list.Collection)-1].Cursor
+			list.Next = &next
+			break
+		}
+
+		var row eventLogRow
+		if err := cur.Decode(&row); err != nil {
+			db.log.Errorf("can not decode log record; %s", err.Error())
+			return nil, err
+		}
+		list.Collection = append(list.Collection, eventLogFromRow(&row))
+	}
+	return &list, nil
+}
+
+// eventLogCondition builds the condition of a single log record from the filter.
+func eventLogCondition(filter *types.EventLogFilter) bson.D {
+	cond := bson.D{}
+	if filter.Address != nil {
+		cond = append(cond, bson.E{Key: fiLogAddress, Value: filter.Address.String()})
+	}
+
+	for i, alt := range filter.Topics {
+		if len(alt) == 0 {
+			continue
+		}
+
+		list := make([]string, len(alt))
+		for j := range alt {
+			list[j] = alt[j].String()
+		}
+		cond = append(cond, bson.E{Key: fmt.Sprintf("%s.%d", fiLogTopics, i), Value: bson.D{{Key: "$in", Value: list}}})
+	}
+	return cond
+}
+
+// eventLogTrxFilter builds the filter of transactions containing matching log records.
+func eventLogTrxFilter(filter *types.EventLogFilter, cond bson.D) bson.D {
+	match := bson.D{{Key: fiTransactionBlock, Value: bson.D{
+		{Key: "$gte", Value: filter.FromBlock},
+		{Key: "$lte", Value: filter.ToBlock},
+	}}}
+
+	if len(cond) == 0 {
+		return append(match, bson.E{Key: fiTransactionLogs + ".0", Value: bson.D{{Key: "$exists", Value: true}}})
+	}
+	return append(match, bson.E{Key: fiTransactionLogs, Value: bson.D{{Key: "$elemMatch", Value: cond}}})
+}
+
+// eventLogRecordFilter builds the filter of unwound log records.
+func eventLogRecordFilter(cond bson.D, after bson.D) bson.D {
+	match := make(bson.D, 0, len(cond)+len(after))
+	for _, e := range cond {
+		match = append(match, bson.E{Key: fiTransactionLogs + "." + e.Key, Value: e.Value})
+	}
+	return append(match, after...)
+}
+
+// eventLogCursorFilter builds the filter of log records following the given cursor.
+func eventLogCursorFilter(cursor *string) (bson.D, error) {
+	if cursor == nil {
+		return bson.D{}, nil
+	}
+
+	orx, ix, err := parseEventLogCursor(*cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	return bson.D{{Key: "$or", Value: bson.A{
+		bson.D{{Key: fiTransactionOrdinalIndex, Value: bson.D{{Key: "$gt", Value: orx}}}},
+		bson.D{{Key: fiTransactionOrdinalIndex, Value: orx}, {Key: fiTransactionLogs + "." + fiLogIndex, Value: bson.D{{Key: "$gt", Value: ix}}}},
+	}}}, nil
+}
+
+// eventLogCursor builds the cursor of the log record from the transaction ordinal index
+// and the index of the log record.
+func eventLogCursor(orx uint64, ix uint) string {
+	return fmt.Sprintf("%x.%x", orx, ix)
+}
+
+// parseEventLogCursor decodes the transaction ordinal index and the index
+// of the log record from the cursor.
+func parseEventLogCursor(cursor string) (uint64, uint64, error) {
+	parts := strings.Split(cursor, ".")
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("%w %s", ErrInvalidCursor, cursor)
+	}
+
+	orx, err := strconv.ParseUint(parts[0], 16, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("%w %s", ErrInvalidCursor, cursor)
+	}
+
+	ix, err := strconv.ParseUint(parts[1], 16, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("%w %s", ErrInvalidCursor, cursor)
+	}
+	return orx, ix, nil
+}
+
+// eventLogFromRow converts the unwound log record row into the log record.
+func eventLogFromRow(row *eventLogRow) *types.EventLog {
+	lg := types.EventLog{
+		Log: retypes.Log{
+			Address:     common.HexToAddress(row.Log.Address),
+			Topics:      make([]common.Hash, len(row.Log.Topics)),
+			Data:        row.Log.Data,
+			BlockNumber: row.BlockID,
+			TxHash:      common.HexToHash(row.Hash),
+			Index:       row.Log.Index,
+			Removed:     row.Log.Removed,
+		},
+		Cursor: eventLogCursor(row.Ordinal, row.Log.Index),
+	}
+
+	if row.BlockHash != nil {
+		lg.BlockHash = common.HexToHash(*row.BlockHash)
+	}
+	if row.BlkIndex != nil {
+		lg.TxIndex = uint(*row.BlkIndex)
+	}
+	for i, t := range row.Log.Topics {
+		lg.Topics[i] = common.HexToHash(t)
+	}
+	return &lg
+}
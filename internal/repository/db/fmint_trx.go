@@ -13,6 +13,10 @@ import (
 // colFMintTransactions represents the name of the fMint transaction collection in database.
 const colFMintTransactions = "fmint_trx"
 
+// fiFMintTransactionTrx is the name of the field of the fMint transaction
+// keeping the hash of the blockchain transaction.
+const fiFMintTransactionTrx = "trx"
+
 // initFMintTrxCollection initializes the fMint transaction list collection with
 // indexes and additional parameters needed by the app.
 func (db *MongoDbBridge) initFMintTrxCollection(col *mongo.Collection) {
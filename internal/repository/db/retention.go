@@ -0,0 +1,93 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
+)
+
+// pruneLogsBatchSize is the max number of transactions stripped of their logs in one update.
+const pruneLogsBatchSize = 1000
+
+// PruneTransactionLogs removes the log records of transactions older than the given time.
+// The transactions themselves are kept. It returns the number of transactions stripped.
+func (db *MongoDbBridge) PruneTransactionLogs(before time.Time) (int64, error) {
+	col := db.client.Database(db.dbName).Collection(coTransactions)
+	filter := bson.D{
+		{Key: fiTransactionTimeStamp, Value: bson.D{{Key: "$lt", Value: before}}},
+		{Key: fiTransactionLogs + ".0", Value: bson.D{{Key: "$exists", Value: true}}},
+	}
+
+	var total int64
+	for {
+		// pick a batch of transactions with logs to keep the update reasonably small
+		cursor, err := col.Find(context.Background(), filter, options.Find().
+			SetProjection(bson.D{{Key: fiTransactionPk, Value: 1}}).
+			SetLimit(pruneLogsBatchSize))
+		if err != nil {
+			db.log.Errorf("can not find transactions with logs to prune; %s", err.Error())
+			return total, err
+		}
+
+		ids := make([]string, 0, pruneLogsBatchSize)
+		for cursor.Next(context.Background()) {
+			var row struct {
+				ID string `bson:"_id"`
+			}
+			if err := cursor.Decode(&row); err != nil {
+				db.log.Errorf("can not decode transaction id; %s", err.Error())
+				db.closeCursor(cursor)
+				return total, err
+			}
+			ids = append(ids, row.ID)
+		}
+		db.closeCursor(cursor)
+
+		if len(ids) == 0 {
+			return total, nil
+		}
+
+		res, err := col.UpdateMany(context.Background(),
+			bson.D{{Key: fiTransactionPk, Value: bson.D{{Key: "$in", Value: ids}}}},
+			bson.D{{Key: "$unset", Value: bson.D{{Key: fiTransactionLogs, Value: ""}}}})
+		if err != nil {
+			db.log.Errorf("can not prune transaction logs; %s", err.Error())
+			return total, err
+		}
+		total += res.ModifiedCount
+
+		if len(ids) < pruneLogsBatchSize {
+			return total, nil
+		}
+	}
+}
+
+// PruneGasPricePeriods removes the gas price periods closed before the given time.
+// It returns the number of periods removed.
+func (db *MongoDbBridge) PruneGasPricePeriods(before time.Time) (int64, error) {
+	col := db.client.Database(db.dbName).Collection(colGasPrice)
+	res, err := col.DeleteMany(context.Background(), bson.D{{Key: types.FiGasPriceTimeTo, Value: bson.D{{Key: "$lt", Value: before}}}})
+	if err != nil {
+		db.log.Errorf("can not prune gas price periods; %s", err.Error())
+		return 0, err
+	}
+	return res.DeletedCount, nil
+}
+
+// PruneWebhookDeliveries removes the finished webhook deliveries created before the given time.
+// Pending deliveries are kept regardless of their age. It returns the number of deliveries removed.
+func (db *MongoDbBridge) PruneWebhookDeliveries(before time.Time) (int64, error) {
+	col := db.client.Database(db.dbName).Collection(colWebhookDeliveries)
+	res, err := col.DeleteMany(context.Background(), bson.D{
+		{Key: fiWebhookDeliveryStatus, Value: bson.D{{Key: "$ne", Value: types.WebhookDeliveryPending}}},
+		{Key: fiWebhookDeliveryCreated, Value: bson.D{{Key: "$lt", Value: before}}},
+	})
+	if err != nil {
+		db.log.Errorf("can not prune webhook deliveries; %s", err.Error())
+		return 0, err
+	}
+	return res.DeletedCount, nil
+}
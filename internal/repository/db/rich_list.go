@@ -0,0 +1,226 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"math/big"
+	"time"
+)
+
+const (
+	// colRichList represents the name of the rich list collection in database.
+	colRichList = "rich_list"
+
+	// fiRichListBalance is the name of the field keeping the full account balance.
+	fiRichListBalance = "bal"
+
+	// fiRichListContract is the name of the field marking contract accounts.
+	fiRichListContract = "sc"
+)
+
+// richListRow represents a single row of the rich list collection.
+type richListRow struct {
+	Address  string    `bson:"_id"`
+	Value    uint64    `bson:"val"`
+	Balance  string    `bson:"bal"`
+	Contract bool      `bson:"sc"`
+	Updated  time.Time `bson:"upd"`
+}
+
+// initRichListCollection initializes the rich list collection with
+// indexes and additional parameters needed by the app.
+func (db *MongoDbBridge) initRichListCollection(col *mongo.Collection) {
+	// prepare index models
+	ix := make([]mongo.IndexModel, 0)
+
+	// index the value with the address to sort the ranking
+	ix = append(ix, mongo.IndexModel{Keys: bson.D{{Key: types.FiRichListValue, Value: -1}, {Key: types.FiRichListAddress, Value: -1}}})
+
+	// create indexes
+	if _, err := col.Indexes().CreateMany(context.Background(), ix); err != nil {
+		db.log.Panicf("can not create indexes for rich list collection; %s", err.Error())
+	}
+
+	// log we are done that
+	db.log.Debugf("rich list collection initialized")
+}
+
+// RichListCount calculates total number of accounts in the rich list.
+func (db *MongoDbBridge) RichListCount() (uint64, error) {
+	return db.EstimateCount(db.client.Database(db.dbName).Collection(colRichList))
+}
+
+// UpdateRichList stores the given balances in the rich list collection.
+// Accounts with zero balance are removed from the ranking.
+func (db *MongoDbBridge) UpdateRichList(list []*types.RichListEntry) error {
+	// anything to do?
+	if len(list) == 0 {
+		return nil
+	}
+
+	// get the collection
+	col := db.client.Database(db.dbName).Collection(colRichList)
+
+	// prep the write models
+	wm := make([]mongo.WriteModel, len(list))
+	for i, e := range list {
+		if e.Balance.ToInt().Sign() <= 0 {
+			wm[i] = mongo.NewDeleteOneModel().SetFilter(bson.D{{Key: types.FiRichListAddress, Value: e.Address.String()}})
+			continue
+		}
+
+		wm[i] = mongo.NewUpdateOneModel().
+			SetFilter(bson.D{{Key: types.FiRichListAddress, Value: e.Address.String()}}).
+			SetUpdate(bson.D{{Key: "$set", Value: bson.D{
+				{Key: types.FiRichListValue, Value: new(big.Int).Div(e.Balance.ToInt(), types.RichListDecimalsCorrection).Uint64()},
+				{Key: fiRichListBalance, Value: e.Balance.String()},
+				{Key: fiRichListContract, Value: e.IsContract},
+				{Key: types.FiRichListUpdated, Value: e.Updated},
+			}}}).
+			SetUpsert(true)
+	}
+
+	if _, err := col.BulkWrite(context.Background(), wm, options.BulkWrite().SetOrdered(false)); err != nil {
+		db.log.Errorf("can not update rich list; %s", err.Error())
+		return err
+	}
+
+	// make sure the rich list collection is initialized
+	if db.initRichList != nil {
+		db.initRichList.Do(func() { db.initRichListCollection(col); db.initRichList = nil })
+	}
+	return nil
+}
+
+// RichList pulls a list of accounts sorted by their balance
+// from the highest to the lowest, starting at the specified cursor.
+func (db *MongoDbBridge) RichList(cursor *string, count int32) (*types.RichList, error) {
+	// nothing to load?
+	if count == 0 {
+		return nil, fmt.Errorf("nothing to do, zero accounts requested")
+	}
+
+	// get the collection
+	col := db.client.Database(db.dbName).Collection(colRichList)
+
+	// find how many accounts do we have in the ranking
+	total, err := db.RichListCount()
+	if err != nil {
+		db.log.Errorf("can not count rich list; %s", err.Error())
+		return nil, err
+	}
+
+	list := types.RichList{
+		Collection: make([]*types.RichListEntry, 0),
+		Total:      total,
+		IsStart:    total == 0,
+		IsEnd:      total == 0,
+	}
+	if total == 0 {
+		return &list, nil
+	}
+
+	// load the data
+	if err := db.richListLoad(col, cursor, count, &list); err != nil {
+		db.log.Errorf("can not load rich list; %s", err.Error())
+		return nil, err
+	}
+	return &list, nil
+}
+
+// richListFilter builds the list filter to continue after the given cursor
+// in the direction given by the sign of the count.
+func (db *MongoDbBridge) richListFilter(col *mongo.Collection, cursor *string, count int32) (bson.D, error) {
+	if cursor == nil {
+		return bson.D{}, nil
+	}
+
+	// find the value of the cursor account
+	id := common.HexToAddress(*cursor).String()
+	var row richListRow
+	sr := col.FindOne(context.Background(), bson.D{{Key: types.FiRichListAddress, Value: id}})
+	if err := sr.Decode(&row); err != nil {
+		db.log.Errorf("can not find the initial rich list account; %s", err.Error())
+		return nil, err
+	}
+
+	// we go down the list on positive count, up on negative
+	op := "$lt"
+	if count < 0 {
+		op = "$gt"
+	}
+	return bson.D{{Key: "$or", Value: bson.A{
+		bson.D{{Key: types.FiRichListValue, Value: bson.D{{Key: op, Value: row.Value}}}},
+		bson.D{
+			{Key: types.FiRichListValue, Value: row.Value},
+			{Key: types.FiRichListAddress, Value: bson.D{{Key: op, Value: id}}},
+		},
+	}}}, nil
+}
+
+// richListLoad loads the initialized rich list.
+func (db *MongoDbBridge) richListLoad(col *mongo.Collection, cursor *string, count int32, list *types.RichList) error {
+	ctx := context.Background()
+
+	// get the filter
+	fi, err := db.richListFilter(col, cursor, count)
+	if err != nil {
+		return err
+	}
+
+	// from high to low value by default; reversed if loading from bottom
+	sd, limit := -1, count
+	if count < 0 {
+		sd, limit = 1, -count
+	}
+
+	// load one more record so we can detect the list end
+	ld, err := col.Find(ctx, fi, options.Find().
+		SetSort(bson.D{{Key: types.FiRichListValue, Value: sd}, {Key: types.FiRichListAddress, Value: sd}}).
+		SetLimit(int64(limit)+1))
+	if err != nil {
+		return err
+	}
+	defer db.closeCursor(ld)
+
+	for ld.Next(ctx) {
+		var row richListRow
+		if err = ld.Decode(&row); err != nil {
+			db.log.Errorf("can not decode the rich list row; %s", err.Error())
+			return err
+		}
+
+		bal, err := hexutil.DecodeBig(row.Balance)
+		if err != nil {
+			db.log.Errorf("invalid rich list balance of %s; %s", row.Address, err.Error())
+			return err
+		}
+
+		list.Collection = append(list.Collection, &types.RichListEntry{
+			Address:    common.HexToAddress(row.Address),
+			Balance:    hexutil.Big(*bal),
+			IsContract: row.Contract,
+			Updated:    row.Updated,
+		})
+	}
+
+	// did we reach the boundary?
+	more := len(list.Collection) > int(limit)
+	if more {
+		list.Collection = list.Collection[:limit]
+	}
+	if count > 0 {
+		list.IsStart, list.IsEnd = cursor == nil, !more
+	} else {
+		list.IsStart, list.IsEnd = !more, cursor == nil
+		list.Reverse()
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"regexp"
+	"strings"
+)
+
+// searchMaxCaseLetters is the max number of hex letters of an address prefix
+// expanded into all the case variants of the checksum encoded address.
+// Letters beyond this limit are matched case-insensitive without the index support.
+const searchMaxCaseLetters = 6
+
+// SearchTransactions finds hashes of transactions starting with the given hex prefix.
+func (db *MongoDbBridge) SearchTransactions(prefix string, limit int64) ([]common.Hash, error) {
+	col := db.client.Database(db.dbName).Collection(coTransactions)
+
+	// hashes are stored in lowercase, so the prefix match can use the primary index
+	cursor, err := col.Find(context.Background(),
+		bson.D{{Key: fiTransactionPk, Value: primitive.Regex{Pattern: "^" + strings.ToLower(prefix)}}},
+		options.Find().SetProjection(bson.D{{Key: fiTransactionPk, Value: true}}).SetLimit(limit))
+	if err != nil {
+		db.log.Errorf("can not search transactions; %s", err.Error())
+		return nil, err
+	}
+	defer db.closeCursor(cursor)
+
+	list := make([]common.Hash, 0)
+	for cursor.Next(context.Background()) {
+		var row struct {
+			Hash string `bson:"_id"`
+		}
+		if err := cursor.Decode(&row); err != nil {
+			db.log.Errorf("can not decode transaction hash; %s", err.Error())
+			return nil, err
+		}
+		list = append(list, common.HexToHash(row.Hash))
+	}
+	return list, nil
+}
+
+// SearchAccounts finds accounts with the address starting with the given hex prefix.
+func (db *MongoDbBridge) SearchAccounts(prefix string, limit int64) ([]*types.Account, error) {
+	col := db.client.Database(db.dbName).Collection(coAccounts)
+
+	cursor, err := col.Find(context.Background(),
+		bson.D{{Key: fiAccountPk, Value: bson.D{{Key: "$in", Value: addressPrefixPatterns(prefix)}}}},
+		options.Find().SetProjection(bson.D{{Key: fiAccountPk, Value: true}, {Key: fiAccountType, Value: true}}).SetLimit(limit))
+	if err != nil {
+		db.log.Errorf("can not search accounts; %s", err.Error())
+		return nil, err
+	}
+	defer db.closeCursor(cursor)
+
+	list := make([]*types.Account, 0)
+	for cursor.Next(context.Background()) {
+		var row AccountRow
+		if err := cursor.Decode(&row); err != nil {
+			db.log.Errorf("can not decode account row; %s", err.Error())
+			return nil, err
+		}
+		list = append(list, &types.Account{Address: common.HexToAddress(row.Address), Type: row.Type})
+	}
+	return list, nil
+}
+
+// SearchContracts finds addresses of contracts with the name starting with the given text.
+// The name is matched case-insensitive using the lower case name, so the prefix match
+// can use the index.
+func (db *MongoDbBridge) SearchContracts(name string, limit int64) ([]common.Address, error) {
+	col := db.client.Database(db.dbName).Collection(coContract)
+
+	cursor, err := col.Find(context.Background(),
+		bson.D{{Key: fiContractLowName, Value: primitive.Regex{Pattern: "^" + regexp.QuoteMeta(strings.ToLower(name))}}},
+		options.Find().SetProjection(bson.D{{Key: fiContractPk, Value: true}}).SetLimit(limit))
+	if err != nil {
+		db.log.Errorf("can not search contracts; %s", err.Error())
+		return nil, err
+	}
+
+	return db.loadAddressList(cursor)
+}
+
+// loadAddressList decodes addresses from the primary keys of the rows of the given cursor.
+func (db *MongoDbBridge) loadAddressList(cursor *mongo.Cursor) ([]common.Address, error) {
+	defer db.closeCursor(cursor)
+
+	list := make([]common.Address, 0)
+	for cursor.Next(context.Background()) {
+		var row struct {
+			Address string `bson:"_id"`
+		}
+		if err := cursor.Decode(&row); err != nil {
+			db.log.Errorf("can not decode address; %s", err.Error())
+			return nil, err
+		}
+		list = append(list, common.HexToAddress(row.Address))
+	}
+	return list, nil
+}
+
+// addressPrefixPatterns builds regular expressions matching checksum encoded addresses
+// starting with the given hex prefix. Mixed case prefix is expected to follow
+// the checksum already and is used as is. Otherwise, we don't know the case of the letters
+// in the stored address, so all the case variants of the prefix are generated to keep
+// the match anchored on the index.
+func addressPrefixPatterns(prefix string) []primitive.Regex {
+	hex := strings.TrimPrefix(strings.TrimPrefix(prefix, "0x"), "0X")
+	if hex != strings.ToLower(hex) && hex != strings.ToUpper(hex) {
+		return []primitive.Regex{{Pattern: "^0x" + hex}}
+	}
+
+	// find the part of the prefix we expand into case variants
+	hex = strings.ToLower(hex)
+	cut, letters := len(hex), 0
+	for i := 0; i < len(hex); i++ {
+		if hex[i] < 'a' {
+			continue
+		}
+		if letters == searchMaxCaseLetters {
+			cut = i
+			break
+		}
+		letters++
+	}
+
+	// the rest of the prefix is matched case-insensitive
+	var rest string
+	if cut < len(hex) {
+		rest = "(?i:" + hex[cut:] + ")"
+	}
+
+	variants := []string{""}
+	for i := 0; i < cut; i++ {
+		if hex[i] < 'a' {
+			for j := range variants {
+				variants[j] += hex[i : i+1]
+			}
+			continue
+		}
+		up := make([]string, len(variants))
+		for j := range variants {
+			up[j] = variants[j] + strings.ToUpper(hex[i:i+1])
+			variants[j] += hex[i : i+1]
+		}
+		variants = append(variants, up...)
+	}
+
+	list := make([]primitive.Regex, len(variants))
+	for i := range variants {
+		list[i] = primitive.Regex{Pattern: "^0x" + variants[i] + rest}
+	}
+	return list
+}
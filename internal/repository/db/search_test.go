@@ -0,0 +1,54 @@
+package db
+
+import (
+	"context"
+	"fantom-api-graphql/internal/config"
+	"fantom-api-graphql/internal/logger"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/onsi/gomega"
+	"go.mongodb.org/mongo-driver/bson"
+	"os"
+	"testing"
+	"time"
+)
+
+// TestSearchContracts tests the contract names are matched case-insensitive,
+// including the contracts stored without the lower case name.
+func TestSearchContracts(t *testing.T) {
+	url := os.Getenv(testDbUrlEnv)
+	if url == "" {
+		t.Skipf("%s not set", testDbUrlEnv)
+	}
+
+	g := gomega.NewWithT(t)
+	cfg := &config.Config{
+		Log: config.Log{Level: "CRITICAL", Format: "%{message}"},
+		Db:  config.Database{Url: url, DbName: fmt.Sprintf("fantom_api_test_%d", time.Now().UnixNano())},
+	}
+	db, err := New(cfg, logger.New(cfg))
+	g.Expect(err).To(gomega.BeNil())
+	defer func() {
+		g.Expect(db.client.Database(db.dbName).Drop(context.Background())).To(gomega.Succeed())
+		db.Close()
+	}()
+
+	router := common.HexToAddress("0x1")
+	g.Expect(db.AddContract(&types.Contract{Address: router, Type: types.AccountTypeContract, Name: "SpookyRouter"})).To(gomega.Succeed())
+
+	// a contract stored by a previous version
+	legacy := common.HexToAddress("0x2")
+	_, err = db.client.Database(db.dbName).Collection(coContract).InsertOne(context.Background(),
+		bson.D{{Key: fiContractPk, Value: legacy.String()}, {Key: fiContractName, Value: "SPOOKY.Token"}})
+	g.Expect(err).To(gomega.BeNil())
+	db.migrateContractNames()
+
+	list, err := db.SearchContracts("spooky", 10)
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(list).To(gomega.ConsistOf(router, legacy))
+
+	list, err = db.SearchContracts("Spooky.", 10)
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(list).To(gomega.Equal([]common.Address{legacy}))
+}
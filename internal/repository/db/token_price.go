@@ -0,0 +1,147 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
+)
+
+const (
+	// colTokenPrices represents the name of the token price samples collection in database.
+	colTokenPrices = "token_prices"
+
+	// fiTokenPricePair is the name of the field of the sampled pair address.
+	fiTokenPricePair = "pair"
+
+	// fiTokenPriceTime is the name of the field of the sample time.
+	fiTokenPriceTime = "ts"
+)
+
+// tokenPriceRow represents a single row of the token price samples collection.
+type tokenPriceRow struct {
+	Pair  string    `bson:"pair"`
+	Price float64   `bson:"price"`
+	Low   bool      `bson:"low"`
+	Time  time.Time `bson:"ts"`
+}
+
+// initTokenPricesCollection initializes the token price samples collection with
+// indexes and additional parameters needed by the app.
+func (db *MongoDbBridge) initTokenPricesCollection(col *mongo.Collection) {
+	// samples are always loaded by the pair and time range
+	ix := []mongo.IndexModel{{Keys: bson.D{{Key: fiTokenPricePair, Value: 1}, {Key: fiTokenPriceTime, Value: 1}}}}
+
+	// create indexes
+	if _, err := col.Indexes().CreateMany(context.Background(), ix); err != nil {
+		db.log.Panicf("can not create indexes for token prices collection; %s", err.Error())
+	}
+	db.log.Debugf("token prices collection initialized")
+}
+
+// TokenPriceSamplesCount calculates total number of token price samples in the database.
+func (db *MongoDbBridge) TokenPriceSamplesCount() (uint64, error) {
+	return db.EstimateCount(db.client.Database(db.dbName).Collection(colTokenPrices))
+}
+
+// AddTokenPriceSample stores a new token price sample in the persistent storage.
+func (db *MongoDbBridge) AddTokenPriceSample(ps *types.TokenPriceSample) error {
+	if ps == nil {
+		return fmt.Errorf("no token price sample to store")
+	}
+
+	col := db.client.Database(db.dbName).Collection(colTokenPrices)
+	if _, err := col.InsertOne(context.Background(), &tokenPriceRow{
+		Pair:  ps.Pair.String(),
+		Price: ps.Price,
+		Low:   ps.IsLowConfidence,
+		Time:  ps.Time,
+	}); err != nil {
+		db.log.Errorf("can not store price sample of pair %s; %s", ps.Pair.String(), err.Error())
+		return err
+	}
+
+	// make sure token prices collection is initialized
+	if db.initTokenPrices != nil {
+		db.initTokenPrices.Do(func() { db.initTokenPricesCollection(col); db.initTokenPrices = nil })
+	}
+	return nil
+}
+
+// TokenPriceSamples loads the price samples of the given pair taken in the given time range,
+// ordered by the time of the sample.
+func (db *MongoDbBridge) TokenPriceSamples(pair *common.Address, from time.Time, to time.Time) ([]types.TokenPriceSample, error) {
+	col := db.client.Database(db.dbName).Collection(colTokenPrices)
+	cursor, err := col.Find(context.Background(), bson.D{
+		{Key: fiTokenPricePair, Value: pair.String()},
+		{Key: fiTokenPriceTime, Value: bson.D{{Key: "$gte", Value: from}, {Key: "$lt", Value: to}}},
+	}, options.Find().SetSort(bson.D{{Key: fiTokenPriceTime, Value: 1}}))
+	if err != nil {
+		db.log.Errorf("can not load price samples of pair %s; %s", pair.String(), err.Error())
+		return nil, err
+	}
+	defer db.closeCursor(cursor)
+
+	list := make([]types.TokenPriceSample, 0)
+	for cursor.Next(context.Background()) {
+		var row tokenPriceRow
+		if err := cursor.Decode(&row); err != nil {
+			db.log.Errorf("can not decode token price sample; %s", err.Error())
+			return nil, err
+		}
+		list = append(list, row.sample())
+	}
+	return list, nil
+}
+
+// LastTokenPriceSample loads the latest price sample of the given pair taken before the given time.
+// Nil is returned if there is no such sample.
+func (db *MongoDbBridge) LastTokenPriceSample(pair *common.Address, before time.Time) (*types.TokenPriceSample, error) {
+	col := db.client.Database(db.dbName).Collection(colTokenPrices)
+	sr := col.FindOne(context.Background(), bson.D{
+		{Key: fiTokenPricePair, Value: pair.String()},
+		{Key: fiTokenPriceTime, Value: bson.D{{Key: "$lt", Value: before}}},
+	}, options.FindOne().SetSort(bson.D{{Key: fiTokenPriceTime, Value: -1}}))
+	if sr.Err() != nil {
+		if sr.Err() == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		db.log.Errorf("can not load last price sample of pair %s; %s", pair.String(), sr.Err().Error())
+		return nil, sr.Err()
+	}
+
+	var row tokenPriceRow
+	if err := sr.Decode(&row); err != nil {
+		db.log.Errorf("can not decode token price sample; %s", err.Error())
+		return nil, err
+	}
+	ps := row.sample()
+	return &ps, nil
+}
+
+// PruneTokenPriceSamples removes the token price samples taken before the given time.
+// It returns the number of samples removed.
+func (db *MongoDbBridge) PruneTokenPriceSamples(before time.Time) (int64, error) {
+	col := db.client.Database(db.dbName).Collection(colTokenPrices)
+	res, err := col.DeleteMany(context.Background(), bson.D{{Key: fiTokenPriceTime, Value: bson.D{{Key: "$lt", Value: before}}}})
+	if err != nil {
+		db.log.Errorf("can not prune token price samples; %s", err.Error())
+		return 0, err
+	}
+	return res.DeletedCount, nil
+}
+
+// sample converts the collection row into the token price sample.
+func (row *tokenPriceRow) sample() types.TokenPriceSample {
+	return types.TokenPriceSample{
+		Pair:            common.HexToAddress(row.Pair),
+		Price:           row.Price,
+		IsLowConfidence: row.Low,
+		Time:            row.Time,
+	}
+}
@@ -16,6 +16,8 @@ const (
 	coTransactions = "transaction"
 
 	// fiTransactionPk is the name of the primary key field of the transaction collection.
+	// The transaction hash is the key, so the default unique index on _id
+	// guards against duplicate transaction records.
 	fiTransactionPk = "_id"
 
 	// fiTransactionOrdinalIndex is the name of the transaction ordinal index in the blockchain field.
@@ -25,6 +27,15 @@ const (
 	// fiTransactionBlock is the name of the block number field of the transaction.
 	fiTransactionBlock = "blk"
 
+	// fiTransactionBlockHash is the name of the block hash field of the transaction.
+	fiTransactionBlockHash = "blk_h"
+
+	// fiTransactionBlockIndex is the name of the field of the transaction index in the block.
+	fiTransactionBlockIndex = "bix"
+
+	// fiTransactionStatus is the name of the receipt status field of the transaction.
+	fiTransactionStatus = "stat"
+
 	// fiTransactionSender is the name of the address field of the sender account.
 	// db.transaction.createIndex({from:1}).
 	fiTransactionSender = "from"
@@ -39,6 +50,9 @@ const (
 
 	// fiTransactionTimeStamp is the name of the field of the transaction time stamp.
 	fiTransactionTimeStamp = "stamp"
+
+	// fiTransactionInput is the name of the field of the transaction input data.
+	fiTransactionInput = "input"
 )
 
 // initTransactionsCollection initializes the transaction collection with
@@ -81,6 +95,16 @@ func (db *MongoDbBridge) initTransactionsCollection(col *mongo.Collection) {
 		},
 	})
 
+	// recipient + block index used by the contract gas statistics
+	rbx := "to_blk"
+	ix = append(ix, mongo.IndexModel{
+		Keys:    bson.D{{Key: fiTransactionRecipient, Value: 1}, {Key: fiTransactionBlock, Value: 1}},
+		Options: &options.IndexOptions{Name: &rbx},
+	})
+
+	// emitting contract of log records
+	ix = append(ix, logsIndex())
+
 	// create indexes
 	if _, err := col.Indexes().CreateMany(context.Background(), ix); err != nil {
 		db.log.Panicf("can not create indexes for transaction collection; %s", err.Error())
@@ -90,20 +114,25 @@ func (db *MongoDbBridge) initTransactionsCollection(col *mongo.Collection) {
 	db.log.Debugf("transactions collection initialized")
 }
 
-// shouldAddTransaction validates if the transaction should be added to the persistent storage.
-func (db *MongoDbBridge) shouldAddTransaction(col *mongo.Collection, trx *types.Transaction) bool {
-	// check if the transaction already exists
-	exists, err := db.IsTransactionKnown(col, &trx.Hash)
-	if err != nil {
-		db.log.Critical(err)
-		return false
-	}
-
-	// if the transaction already exists, we don't need to do anything here
-	return !exists
+// transactionUpdateFields lists the fields of the transaction document refreshed
+// on every update; other fields are written only when the document is created.
+// The block position and the receipt are refreshed so a transaction re-included
+// in a different block after a chain reorganization is corrected.
+var transactionUpdateFields = map[string]bool{
+	fiTransactionOrdinalIndex: true,
+	fiTransactionBlock:        true,
+	fiTransactionBlockHash:    true,
+	fiTransactionBlockIndex:   true,
+	fiTransactionSender:       true,
+	fiTransactionValue:        true,
+	fiTransactionTimeStamp:    true,
+	fiTransactionStatus:       true,
+	fiTransactionGasUsed:      true,
+	fiTransactionLogs:         true,
 }
 
 // AddTransaction stores a transaction reference in connected persistent storage.
+// Existing transactions are updated instead, see UpdateTransaction.
 func (db *MongoDbBridge) AddTransaction(block *types.Block, trx *types.Transaction) error {
 	// do we have all needed data?
 	if block == nil || trx == nil {
@@ -113,56 +142,84 @@ func (db *MongoDbBridge) AddTransaction(block *types.Block, trx *types.Transacti
 	// get the collection for transactions
 	col := db.client.Database(db.dbName).Collection(coTransactions)
 
-	// if the transaction already exists, we don't need to add it
-	// just make sure the transaction accounts were processed
-	if !db.shouldAddTransaction(col, trx) {
-		return db.UpdateTransaction(col, trx)
-	}
-
-	// try to do the insert
-	if _, err := col.InsertOne(context.Background(), trx); err != nil {
-		db.log.Critical(err)
+	// upsert the transaction
+	if err := db.UpdateTransaction(col, trx); err != nil {
 		return err
 	}
 
-	// add transaction to the db
-	db.log.Debugf("transaction %s added to database", trx.Hash.String())
-
 	// make sure transactions collection is initialized
 	if db.initTransactions != nil {
 		db.initTransactions.Do(func() { db.initTransactionsCollection(col); db.initTransactions = nil })
 	}
-
 	return nil
 }
 
 // UpdateTransaction updates transaction data in the database collection.
+// The update is an atomic upsert keyed by the transaction hash; the base record
+// is created if missing, and concurrent updates of the same transaction merge
+// on the field level instead of replacing each other.
 func (db *MongoDbBridge) UpdateTransaction(col *mongo.Collection, trx *types.Transaction) error {
 	// notify
 	db.log.Debugf("updating transaction %s", trx.Hash.String())
 
-	// try to update a delegation by replacing it in the database
-	// we use address and validator ID to identify unique delegation
-	er, err := col.UpdateOne(context.Background(), bson.D{
-		{Key: fiTransactionPk, Value: trx.Hash.String()},
-	}, bson.D{{Key: "$set", Value: bson.D{
-		{Key: fiTransactionOrdinalIndex, Value: trx.Uid()},
-		{Key: fiTransactionSender, Value: trx.From.String()},
-		{Key: fiTransactionValue, Value: trx.Value.String()},
-		{Key: fiTransactionTimeStamp, Value: trx.TimeStamp},
-	}}}, new(options.UpdateOptions).SetUpsert(false))
+	// prep the update document
+	upd, err := transactionUpsert(trx)
+	if err != nil {
+		db.log.Errorf("can not encode transaction %s; %s", trx.Hash.String(), err.Error())
+		return err
+	}
+
+	// concurrent upserts of a new document may collide on the unique key;
+	// the document exists on retry, so it's a plain update the second time
+	filter := bson.D{{Key: fiTransactionPk, Value: trx.Hash.String()}}
+	er, err := col.UpdateOne(context.Background(), filter, upd, options.Update().SetUpsert(true))
+	if mongo.IsDuplicateKeyError(err) {
+		er, err = col.UpdateOne(context.Background(), filter, upd, options.Update().SetUpsert(true))
+	}
 	if err != nil {
 		db.log.Critical(err)
 		return err
 	}
 
-	// do we actually have the document
-	if 0 == er.MatchedCount {
-		return fmt.Errorf("can not update, the transaction not found in database")
+	if er.UpsertedCount > 0 {
+		db.log.Debugf("transaction %s added to database", trx.Hash.String())
 	}
 	return nil
 }
 
+// transactionUpsert builds the upsert document of the given transaction.
+// Fields refreshed on every update go to $set, the rest is written
+// by $setOnInsert only when the document is created.
+func transactionUpsert(trx *types.Transaction) (bson.D, error) {
+	raw, err := bson.Marshal(trx)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc bson.D
+	if err := bson.Unmarshal(raw, &doc); err != nil {
+		return nil, err
+	}
+
+	set, ins := bson.D{}, bson.D{}
+	for _, e := range doc {
+		switch {
+		case e.Key == fiTransactionPk:
+			continue
+		case transactionUpdateFields[e.Key]:
+			set = append(set, e)
+		default:
+			ins = append(ins, e)
+		}
+	}
+
+	upd := bson.D{{Key: "$set", Value: set}}
+	if len(ins) > 0 {
+		upd = append(upd, bson.E{Key: "$setOnInsert", Value: ins})
+	}
+	return upd, nil
+}
+
 // IsTransactionKnown checks if a transaction document already exists in the database.
 func (db *MongoDbBridge) IsTransactionKnown(col *mongo.Collection, hash *common.Hash) (bool, error) {
 	// try to find the transaction in the database (it may already exist)
@@ -190,6 +247,32 @@ func (db *MongoDbBridge) IsTransactionKnown(col *mongo.Collection, hash *common.
 	return true, nil
 }
 
+// RemoveTransaction removes the transaction from the database,
+// e.g. when its block has been dropped from the chain by a reorganization.
+// The log records are stored with the transaction and go with it; the token
+// and fMint transactions derived from the logs are removed as well.
+func (db *MongoDbBridge) RemoveTransaction(hash *common.Hash) error {
+	// derived records first, so a failure leaves the transaction to be removed again
+	derived := map[string]string{
+		colErcTransactions:   types.FiTokenTransactionCallHash,
+		colFMintTransactions: fiFMintTransactionTrx,
+	}
+	for name, field := range derived {
+		col := db.client.Database(db.dbName).Collection(name)
+		if _, err := col.DeleteMany(context.Background(), bson.D{{Key: field, Value: hash.String()}}); err != nil {
+			db.log.Errorf("can not remove %s records of transaction %s; %s", name, hash.String(), err.Error())
+			return err
+		}
+	}
+
+	col := db.client.Database(db.dbName).Collection(coTransactions)
+	if _, err := col.DeleteOne(context.Background(), bson.D{{Key: fiTransactionPk, Value: hash.String()}}); err != nil {
+		db.log.Errorf("can not remove transaction %s; %s", hash.String(), err.Error())
+		return err
+	}
+	return nil
+}
+
 // initTrxList initializes list of transactions based on provided cursor and count.
 func (db *MongoDbBridge) initTrxList(col *mongo.Collection, cursor *string, count int32, filter *bson.D) (*types.TransactionList, error) {
 	// make sure some filter is used
@@ -315,9 +398,13 @@ func (db *MongoDbBridge) txListFilter(cursor *string, count int32, list *types.T
 }
 
 // txListOptions creates a filter options set for transactions list search.
-func (db *MongoDbBridge) txListOptions(count int32) *options.FindOptions {
+// Optional parts of the transactions not in the given set are not loaded.
+func (db *MongoDbBridge) txListOptions(count int32, fields types.TrxFields) *options.FindOptions {
 	// prep options
 	opt := options.Find()
+	if proj := trxListProjection(fields); len(proj) > 0 {
+		opt.SetProjection(proj)
+	}
 
 	// how to sort results in the collection
 	if count > 0 {
@@ -340,13 +427,26 @@ func (db *MongoDbBridge) txListOptions(count int32) *options.FindOptions {
 	return opt
 }
 
+// trxListProjection builds the projection excluding the optional parts
+// of the stored transactions not in the given set.
+func trxListProjection(fields types.TrxFields) bson.D {
+	proj := bson.D{}
+	if !fields.Has(types.TrxFieldInput) {
+		proj = append(proj, bson.E{Key: fiTransactionInput, Value: 0})
+	}
+	if !fields.Has(types.TrxFieldLogs) {
+		proj = append(proj, bson.E{Key: fiTransactionLogs, Value: 0})
+	}
+	return proj
+}
+
 // txListLoad load the initialized list from database
-func (db *MongoDbBridge) txListLoad(col *mongo.Collection, cursor *string, count int32, list *types.TransactionList) error {
+func (db *MongoDbBridge) txListLoad(col *mongo.Collection, cursor *string, count int32, fields types.TrxFields, list *types.TransactionList) error {
 	// get the context for loader
 	ctx := context.Background()
 
 	// load the data
-	ld, err := col.Find(ctx, db.txListFilter(cursor, count, list), db.txListOptions(count))
+	ld, err := col.Find(ctx, db.txListFilter(cursor, count, list), db.txListOptions(count, fields))
 	if err != nil {
 		db.log.Errorf("error loading transactions list; %s", err.Error())
 		return err
@@ -370,6 +470,7 @@ func (db *MongoDbBridge) txListLoad(col *mongo.Collection, cursor *string, count
 		}
 
 		// we have one
+		row.LogsOmitted = !fields.Has(types.TrxFieldLogs)
 		trx = &row
 	}
 
@@ -390,7 +491,8 @@ func (db *MongoDbBridge) TransactionsCount() (uint64, error) {
 }
 
 // Transactions pulls list of transaction hashes starting on the specified cursor.
-func (db *MongoDbBridge) Transactions(cursor *string, count int32, filter *bson.D) (*types.TransactionList, error) {
+// Only the given optional parts of the transactions are loaded.
+func (db *MongoDbBridge) Transactions(cursor *string, count int32, filter *bson.D, fields types.TrxFields) (*types.TransactionList, error) {
 	// nothing to load?
 	if count == 0 {
 		return nil, fmt.Errorf("nothing to do, zero transactions requested")
@@ -408,7 +510,7 @@ func (db *MongoDbBridge) Transactions(cursor *string, count int32, filter *bson.
 
 	// load data if there are any
 	if list.Total > 0 {
-		err = db.txListLoad(col, cursor, count, list)
+		err = db.txListLoad(col, cursor, count, fields, list)
 		if err != nil {
 			db.log.Errorf("can not load transactions list from database; %s", err.Error())
 			return nil, err
@@ -0,0 +1,179 @@
+package db
+
+import (
+	"context"
+	"fantom-api-graphql/internal/config"
+	"fantom-api-graphql/internal/logger"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	retypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/onsi/gomega"
+	"go.mongodb.org/mongo-driver/bson"
+	"math/big"
+	"os"
+	"sync"
+	"testing"
+	"time"
+)
+
+// testDbUrlEnv is the name of the environment variable with the URL of the Mongo database
+// used by tests requiring a live database; the tests are skipped if not set.
+const testDbUrlEnv = "FANTOM_API_TEST_DB_URL"
+
+// testTransaction makes a mined transaction with the given value and time stamp.
+func testTransaction(value int64, stamp time.Time) *types.Transaction {
+	bn := hexutil.Uint64(1000)
+	bh := common.HexToHash("0xb10c")
+	ix := hexutil.Uint64(3)
+	gas := hexutil.Uint64(21000)
+	to := common.HexToAddress("0x2")
+
+	return &types.Transaction{
+		BlockHash:         &bh,
+		BlockNumber:       &bn,
+		Index:             &ix,
+		GasUsed:           &gas,
+		CumulativeGasUsed: &gas,
+		Hash:              common.HexToHash("0xabcdef"),
+		From:              common.HexToAddress("0x1"),
+		To:                &to,
+		Gas:               21000,
+		GasPrice:          hexutil.Big(*big.NewInt(1_000_000_000)),
+		Nonce:             7,
+		Value:             hexutil.Big(*new(big.Int).Mul(big.NewInt(value), types.TransactionDecimalsCorrection)),
+		TimeStamp:         stamp,
+	}
+}
+
+// TestTransactionUpsert tests the upsert document splits refreshed and insert only fields.
+func TestTransactionUpsert(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	upd, err := transactionUpsert(testTransaction(5, time.Unix(1600000000, 0)))
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(upd).To(gomega.HaveLen(2))
+
+	set, ins := upd.Map()["$set"].(bson.D).Map(), upd.Map()["$setOnInsert"].(bson.D).Map()
+	g.Expect(set).To(gomega.HaveLen(len(transactionUpdateFields)))
+	for k := range transactionUpdateFields {
+		g.Expect(set).To(gomega.HaveKey(k))
+		g.Expect(ins).NotTo(gomega.HaveKey(k))
+	}
+	g.Expect(set).NotTo(gomega.HaveKey(fiTransactionPk))
+	g.Expect(ins).NotTo(gomega.HaveKey(fiTransactionPk))
+	g.Expect(ins).To(gomega.HaveKey("nonce"))
+	g.Expect(ins).To(gomega.HaveKey("amo"))
+}
+
+// TestUpdateTransactionConcurrent tests overlapping upserts of the same transaction
+// leave a single consistent document in the database.
+func TestUpdateTransactionConcurrent(t *testing.T) {
+	url := os.Getenv(testDbUrlEnv)
+	if url == "" {
+		t.Skipf("%s not set", testDbUrlEnv)
+	}
+
+	g := gomega.NewWithT(t)
+	cfg := &config.Config{
+		Log: config.Log{Level: "CRITICAL", Format: "%{message}"},
+		Db:  config.Database{Url: url, DbName: fmt.Sprintf("fantom_api_test_%d", time.Now().UnixNano())},
+	}
+	db, err := New(cfg, logger.New(cfg))
+	g.Expect(err).To(gomega.BeNil())
+	defer func() {
+		g.Expect(db.client.Database(db.dbName).Drop(context.Background())).To(gomega.Succeed())
+		db.Close()
+	}()
+
+	// fire overlapping updates, each writer with its own value and time stamp
+	const writers = 32
+	base := time.Unix(1600000000, 0).UTC()
+	var wg sync.WaitGroup
+	errs := make(chan error, writers)
+	wg.Add(writers)
+	for i := 0; i < writers; i++ {
+		go func(i int) {
+			defer wg.Done()
+			errs <- db.AddTransaction(&types.Block{}, testTransaction(int64(i+1), base.Add(time.Duration(i)*time.Second)))
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		g.Expect(err).To(gomega.BeNil())
+	}
+
+	col := db.client.Database(db.dbName).Collection(coTransactions)
+	filter := bson.D{{Key: fiTransactionPk, Value: common.HexToHash("0xabcdef").String()}}
+	cnt, err := col.CountDocuments(context.Background(), filter)
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(cnt).To(gomega.Equal(int64(1)))
+
+	// the refreshed fields must come from a single writer
+	var row types.BsonTransaction
+	g.Expect(col.FindOne(context.Background(), filter).Decode(&row)).To(gomega.Succeed())
+
+	writer := int(row.Stamp.Sub(base) / time.Second)
+	g.Expect(writer).To(gomega.BeNumerically(">=", 0))
+	g.Expect(writer).To(gomega.BeNumerically("<", writers))
+	g.Expect(row.Value).To(gomega.Equal(testTransaction(int64(writer+1), base).Value.String()))
+	g.Expect(row.Nonce).To(gomega.Equal(int64(7)))
+	g.Expect(row.Ordinal).To(gomega.Equal(testTransaction(1, base).Uid()))
+}
+
+// TestTrxListProjection tests the list projection leaves out the optional parts
+// of the stored transaction and measures the size of the loaded document.
+func TestTrxListProjection(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	trx := testTransaction(5, time.Unix(1600000000, 0))
+	trx.InputData = make([]byte, 4+6*32)
+	trx.Logs = make([]retypes.Log, 4)
+	for i := range trx.Logs {
+		trx.Logs[i] = retypes.Log{
+			Address: common.HexToAddress("0x2"),
+			Topics:  []common.Hash{common.HexToHash("0xddf252ad"), common.HexToHash("0x1"), common.HexToHash("0x2")},
+			Data:    make([]byte, 32),
+			Index:   uint(i),
+		}
+	}
+
+	raw, err := bson.Marshal(trx)
+	g.Expect(err).To(gomega.BeNil())
+
+	// apply the exclusion projection the same way the database does
+	project := func(fields types.TrxFields) []byte {
+		var doc bson.D
+		g.Expect(bson.Unmarshal(raw, &doc)).To(gomega.Succeed())
+
+		excluded := trxListProjection(fields).Map()
+		out := make(bson.D, 0, len(doc))
+		for _, e := range doc {
+			if _, ok := excluded[e.Key]; !ok {
+				out = append(out, e)
+			}
+		}
+
+		b, err := bson.Marshal(out)
+		g.Expect(err).To(gomega.BeNil())
+		return b
+	}
+
+	g.Expect(trxListProjection(types.TrxFieldsAll)).To(gomega.BeEmpty())
+	g.Expect(project(types.TrxFieldsAll)).To(gomega.HaveLen(len(raw)))
+
+	core := project(0)
+	withInput := project(types.TrxFieldInput)
+	t.Logf("transaction document %d bytes, with input only %d bytes, core only %d bytes", len(raw), len(withInput), len(core))
+	g.Expect(len(core)).To(gomega.BeNumerically("<", len(withInput)))
+	g.Expect(len(withInput)).To(gomega.BeNumerically("<", len(raw)))
+
+	// the projected document still decodes into a usable transaction
+	var row types.Transaction
+	g.Expect(bson.Unmarshal(withInput, &row)).To(gomega.Succeed())
+	g.Expect(row.Hash).To(gomega.Equal(trx.Hash))
+	g.Expect([]byte(row.InputData)).To(gomega.Equal([]byte(trx.InputData)))
+	g.Expect(row.Logs).To(gomega.BeEmpty())
+}
@@ -0,0 +1,43 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"context"
+	"github.com/ethereum/go-ethereum/common"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// fiTransactionNonce is the name of the field of the sender nonce of the transaction.
+const fiTransactionNonce = "nonce"
+
+// SentTransactionNonces provides nonces of the latest stored transactions sent
+// by the given account, the newest first. The sender index is used to pick them.
+func (db *MongoDbBridge) SentTransactionNonces(addr *common.Address, limit int64) ([]uint64, error) {
+	col := db.client.Database(db.dbName).Collection(coTransactions)
+
+	cursor, err := col.Find(context.Background(),
+		bson.D{{Key: fiTransactionSender, Value: addr.String()}},
+		options.Find().
+			SetSort(bson.D{{Key: fiTransactionOrdinalIndex, Value: -1}}).
+			SetLimit(limit).
+			SetProjection(bson.D{{Key: fiTransactionNonce, Value: true}}))
+	if err != nil {
+		db.log.Errorf("can not load nonces of %s; %s", addr.String(), err.Error())
+		return nil, err
+	}
+	defer db.closeCursor(cursor)
+
+	list := make([]uint64, 0, limit)
+	for cursor.Next(context.Background()) {
+		var row struct {
+			Nonce int64 `bson:"nonce"`
+		}
+		if err := cursor.Decode(&row); err != nil {
+			db.log.Errorf("can not decode transaction nonce; %s", err.Error())
+			return nil, err
+		}
+		list = append(list, uint64(row.Nonce))
+	}
+	return list, nil
+}
@@ -0,0 +1,83 @@
+package db
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// fiTransactionRevert is the name of the field of the decoded revert reason of a failed transaction.
+const fiTransactionRevert = "revert"
+
+// TransactionRevertReason provides the revert reason of a failed transaction
+// stored in the database, if it has been decoded already.
+func (db *MongoDbBridge) TransactionRevertReason(hash *common.Hash) (*types.RevertReason, error) {
+	col := db.client.Database(db.dbName).Collection(coTransactions)
+
+	var row struct {
+		Revert *types.RevertReason `bson:"revert"`
+	}
+	err := col.FindOne(context.Background(), bson.D{
+		{Key: fiTransactionPk, Value: hash.String()},
+	}, options.FindOne().SetProjection(bson.D{
+		{Key: fiTransactionRevert, Value: true},
+	})).Decode(&row)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+
+		db.log.Errorf("can not load revert reason of %s; %s", hash.String(), err.Error())
+		return nil, err
+	}
+	return row.Revert, nil
+}
+
+// SetTransactionRevertReason stores the decoded revert reason of a failed transaction.
+func (db *MongoDbBridge) SetTransactionRevertReason(hash *common.Hash, rr *types.RevertReason) error {
+	col := db.client.Database(db.dbName).Collection(coTransactions)
+
+	_, err := col.UpdateOne(context.Background(), bson.D{
+		{Key: fiTransactionPk, Value: hash.String()},
+	}, bson.D{{Key: "$set", Value: bson.D{
+		{Key: fiTransactionRevert, Value: rr},
+	}}})
+	if err != nil {
+		db.log.Errorf("can not store revert reason of %s; %s", hash.String(), err.Error())
+		return err
+	}
+	return nil
+}
+
+// RevertedTransactionsTo provides hashes of failed transactions sent to the given
+// address with the revert reason already known.
+func (db *MongoDbBridge) RevertedTransactionsTo(addr *common.Address) ([]common.Hash, error) {
+	col := db.client.Database(db.dbName).Collection(coTransactions)
+	ctx := context.Background()
+
+	ld, err := col.Find(ctx, bson.D{
+		{Key: fiTransactionRecipient, Value: addr.String()},
+		{Key: fiTransactionRevert, Value: bson.D{{Key: "$exists", Value: true}}},
+	}, options.Find().SetProjection(bson.D{{Key: fiTransactionPk, Value: true}}))
+	if err != nil {
+		db.log.Errorf("can not load reverted transactions of %s; %s", addr.String(), err.Error())
+		return nil, err
+	}
+	defer db.closeCursor(ld)
+
+	list := make([]common.Hash, 0)
+	for ld.Next(ctx) {
+		var row struct {
+			Hash string `bson:"_id"`
+		}
+		if err := ld.Decode(&row); err != nil {
+			db.log.Errorf("can not decode reverted transaction; %s", err.Error())
+			continue
+		}
+		list = append(list, common.HexToHash(row.Hash))
+	}
+	return list, nil
+}
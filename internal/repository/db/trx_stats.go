@@ -0,0 +1,217 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
+)
+
+const (
+	// colTrxStats represents the name of the transaction statistics rollup collection.
+	colTrxStats = "trx_stats"
+
+	// fiTrxStatsGranularity is the name of the field of the statistics bucket granularity.
+	fiTrxStatsGranularity = "gran"
+
+	// fiTrxStatsStamp is the name of the field of the statistics bucket start.
+	fiTrxStatsStamp = "stamp"
+
+	// trxStatsMaxBuckets is the max number of statistics buckets loaded at once.
+	trxStatsMaxBuckets = 1000
+)
+
+// trxStatsFormats maps the statistics granularity to the bucket identification format.
+var trxStatsFormats = map[string]string{
+	types.TrxStatsDay:  "%Y-%m-%d",
+	types.TrxStatsHour: "%Y-%m-%dT%H",
+}
+
+// trxStatsPipeline builds the aggregation pipeline collecting transaction statistics
+// of the given granularity in the given time range; the end of the range is not included.
+// Unique addresses are counted by grouping, so a busy bucket does not have to fit
+// the set of its addresses into a single document.
+func trxStatsPipeline(from time.Time, to time.Time, gran string) mongo.Pipeline {
+	format := trxStatsFormats[gran]
+	return mongo.Pipeline{
+		{{Key: "$match", Value: bson.D{
+			{Key: fiTransactionTimeStamp, Value: bson.D{{Key: "$gte", Value: from}, {Key: "$lt", Value: to}}},
+		}}},
+		// each transaction is split into its sender and recipient; only the sender part
+		// carries the transaction values so they are counted once
+		{{Key: "$project", Value: bson.D{
+			{Key: "bck", Value: bson.D{
+				{Key: "$dateToString", Value: bson.D{
+					{Key: "format", Value: format},
+					{Key: "date", Value: "$stamp"},
+				}},
+			}},
+			{Key: "prt", Value: bson.A{
+				bson.D{{Key: "adr", Value: "$from"}, {Key: "cnt", Value: 1}, {Key: "amo", Value: "$amo"}, {Key: "gas", Value: "$gwx100"}},
+				bson.D{{Key: "adr", Value: bson.D{{Key: "$ifNull", Value: bson.A{"$to", "$from"}}}}, {Key: "cnt", Value: 0}, {Key: "amo", Value: 0}, {Key: "gas", Value: 0}},
+			}},
+		}}},
+		{{Key: "$unwind", Value: "$prt"}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: bson.D{{Key: "bck", Value: "$bck"}, {Key: "adr", Value: "$prt.adr"}}},
+			{Key: "cnt", Value: bson.D{{Key: "$sum", Value: "$prt.cnt"}}},
+			{Key: "vol", Value: bson.D{{Key: "$sum", Value: "$prt.amo"}}},
+			{Key: "gas", Value: bson.D{{Key: "$sum", Value: "$prt.gas"}}},
+		}}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: "$_id.bck"},
+			{Key: "cnt", Value: bson.D{{Key: "$sum", Value: "$cnt"}}},
+			{Key: "vol", Value: bson.D{{Key: "$sum", Value: "$vol"}}},
+			{Key: "gas", Value: bson.D{{Key: "$sum", Value: "$gas"}}},
+			{Key: "addr", Value: bson.D{{Key: "$sum", Value: 1}}},
+		}}},
+		{{Key: "$project", Value: bson.D{
+			{Key: "_id", Value: bson.D{{Key: "$concat", Value: bson.A{gran, ":", "$_id"}}}},
+			{Key: fiTrxStatsGranularity, Value: bson.D{{Key: "$literal", Value: gran}}},
+			{Key: fiTrxStatsStamp, Value: bson.D{{Key: "$dateFromString", Value: bson.D{
+				{Key: "dateString", Value: "$_id"},
+				{Key: "format", Value: format},
+			}}}},
+			{Key: "cnt", Value: 1},
+			{Key: "vol", Value: 1},
+			{Key: "gas", Value: bson.D{{Key: "$divide", Value: bson.A{"$gas", bson.D{{Key: "$multiply", Value: bson.A{"$cnt", 100}}}}}}},
+			{Key: "addr", Value: 1},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: fiTrxStatsStamp, Value: 1}}}},
+	}
+}
+
+// initTrxStatsCollection initializes the transaction statistics collection with
+// indexes and additional parameters needed by the app.
+func (db *MongoDbBridge) initTrxStatsCollection(col *mongo.Collection) {
+	// prepare index models
+	ix := []mongo.IndexModel{{Keys: bson.D{{Key: fiTrxStatsGranularity, Value: 1}, {Key: fiTrxStatsStamp, Value: 1}}}}
+
+	// create indexes
+	if _, err := col.Indexes().CreateMany(context.Background(), ix); err != nil {
+		db.log.Panicf("can not create indexes for trx stats collection; %s", err.Error())
+	}
+	db.log.Debugf("trx stats collection initialized")
+}
+
+// TrxStatsCount calculates total number of transaction statistics buckets stored.
+func (db *MongoDbBridge) TrxStatsCount() (uint64, error) {
+	return db.EstimateCount(db.client.Database(db.dbName).Collection(colTrxStats))
+}
+
+// TrxStats loads the precomputed transaction statistics of the given granularity
+// in the given time range; the end of the range is not included.
+func (db *MongoDbBridge) TrxStats(ctx context.Context, from time.Time, to time.Time, gran string) ([]*types.TrxStats, error) {
+	col := db.client.Database(db.dbName).Collection(colTrxStats)
+
+	cur, err := col.Find(ctx, bson.D{
+		{Key: fiTrxStatsGranularity, Value: gran},
+		{Key: fiTrxStatsStamp, Value: bson.D{{Key: "$gte", Value: from}, {Key: "$lt", Value: to}}},
+	}, options.Find().SetSort(bson.D{{Key: fiTrxStatsStamp, Value: 1}}).SetLimit(trxStatsMaxBuckets))
+	if err != nil {
+		db.log.Errorf("can not load trx stats; %s", err.Error())
+		return nil, err
+	}
+
+	defer db.closeCursor(cur)
+	return loadTrxStats(ctx, cur)
+}
+
+// TrxStatsLive aggregates the transaction statistics of the given granularity
+// in the given time range directly from the transactions collection.
+func (db *MongoDbBridge) TrxStatsLive(ctx context.Context, from time.Time, to time.Time, gran string) ([]*types.TrxStats, error) {
+	col := db.client.Database(db.dbName).Collection(coTransactions)
+
+	cur, err := col.Aggregate(ctx, trxStatsPipeline(from, to, gran), options.Aggregate().SetAllowDiskUse(true))
+	if err != nil {
+		db.log.Errorf("can not aggregate trx stats; %s", err.Error())
+		return nil, err
+	}
+
+	defer db.closeCursor(cur)
+	return loadTrxStats(ctx, cur)
+}
+
+// TrxStatsUpdate aggregates the transaction statistics of the given granularity
+// in the given time range and stores them in the rollup collection.
+func (db *MongoDbBridge) TrxStatsUpdate(from time.Time, to time.Time, gran string) error {
+	col := db.client.Database(db.dbName).Collection(coTransactions)
+
+	pipe := append(trxStatsPipeline(from, to, gran), bson.D{{Key: "$merge", Value: bson.D{
+		{Key: "into", Value: colTrxStats},
+		{Key: "on", Value: "_id"},
+		{Key: "whenMatched", Value: "replace"},
+		{Key: "whenNotMatched", Value: "insert"},
+	}}})
+
+	cur, err := col.Aggregate(context.Background(), pipe, options.Aggregate().SetAllowDiskUse(true))
+	if err != nil {
+		db.log.Errorf("can not update trx stats; %s", err.Error())
+		return err
+	}
+
+	// close the cursor, we don't really need the data
+	db.closeCursor(cur)
+
+	// make sure trx stats collection is initialized
+	if db.initTrxStats != nil {
+		db.initTrxStats.Do(func() {
+			db.initTrxStatsCollection(db.client.Database(db.dbName).Collection(colTrxStats))
+			db.initTrxStats = nil
+		})
+	}
+	return nil
+}
+
+// TrxStatsLatest provides the start of the latest precomputed statistics bucket
+// of the given granularity, or nil if no statistics have been stored yet.
+func (db *MongoDbBridge) TrxStatsLatest(gran string) (*time.Time, error) {
+	col := db.client.Database(db.dbName).Collection(colTrxStats)
+
+	var row types.TrxStats
+	err := col.FindOne(context.Background(), bson.D{{Key: fiTrxStatsGranularity, Value: gran}},
+		options.FindOne().SetSort(bson.D{{Key: fiTrxStatsStamp, Value: -1}})).Decode(&row)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		db.log.Errorf("can not load latest trx stats; %s", err.Error())
+		return nil, err
+	}
+	return &row.Stamp, nil
+}
+
+// TransactionOldestStamp provides the time stamp of the oldest known transaction.
+func (db *MongoDbBridge) TransactionOldestStamp() (*time.Time, error) {
+	col := db.client.Database(db.dbName).Collection(coTransactions)
+
+	var row struct {
+		Stamp time.Time `bson:"stamp"`
+	}
+	err := col.FindOne(context.Background(), bson.D{},
+		options.FindOne().SetSort(bson.D{{Key: fiTransactionOrdinalIndex, Value: 1}}).SetProjection(bson.D{{Key: fiTransactionTimeStamp, Value: 1}})).Decode(&row)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		db.log.Errorf("can not load the oldest transaction; %s", err.Error())
+		return nil, err
+	}
+	return &row.Stamp, nil
+}
+
+// loadTrxStats loads the list of transaction statistics from the given cursor.
+func loadTrxStats(ctx context.Context, cur *mongo.Cursor) ([]*types.TrxStats, error) {
+	list := make([]*types.TrxStats, 0)
+	for cur.Next(ctx) {
+		var row types.TrxStats
+		if err := cur.Decode(&row); err != nil {
+			return nil, err
+		}
+		list = append(list, &row)
+	}
+	return list, cur.Err()
+}
@@ -0,0 +1,100 @@
+package db
+
+import (
+	"context"
+	"github.com/ethereum/go-ethereum/common"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
+)
+
+const (
+	// colTrxSubmissions represents the name of the collection of transaction submissions
+	// identified by a client provided idempotency key.
+	colTrxSubmissions = "trx_submissions"
+
+	// fiTrxSubmissionPk is the name of the primary key of the submission, the idempotency key.
+	fiTrxSubmissionPk = "_id"
+
+	// fiTrxSubmissionExpires is the name of the field of the submission expiration time.
+	fiTrxSubmissionExpires = "exp"
+)
+
+// trxSubmissionRow represents a single row of the transaction submissions collection.
+type trxSubmissionRow struct {
+	Key     string    `bson:"_id"`
+	Hash    string    `bson:"trx"`
+	Sum     string    `bson:"sum"`
+	Expires time.Time `bson:"exp"`
+}
+
+// initTrxSubmissionsCollection initializes the transaction submissions collection with
+// indexes and additional parameters needed by the app.
+func (db *MongoDbBridge) initTrxSubmissionsCollection(col *mongo.Collection) {
+	// expired submissions are removed by the database
+	ix := []mongo.IndexModel{{
+		Keys:    bson.D{{Key: fiTrxSubmissionExpires, Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(0),
+	}}
+
+	// create indexes
+	if _, err := col.Indexes().CreateMany(context.Background(), ix); err != nil {
+		db.log.Panicf("can not create indexes for trx submissions collection; %s", err.Error())
+	}
+	db.log.Debugf("trx submissions collection initialized")
+}
+
+// TrxSubmissionsCount calculates total number of transaction submissions kept.
+func (db *MongoDbBridge) TrxSubmissionsCount() (uint64, error) {
+	return db.EstimateCount(db.client.Database(db.dbName).Collection(colTrxSubmissions))
+}
+
+// IdempotentTransaction provides the hash of the transaction submitted with the given
+// idempotency key and the hash of the submitted data. Nil is returned if the submission
+// is not known, or it already expired.
+func (db *MongoDbBridge) IdempotentTransaction(key string) (*common.Hash, *common.Hash, error) {
+	col := db.client.Database(db.dbName).Collection(colTrxSubmissions)
+
+	// the database removes expired rows only periodically
+	var row trxSubmissionRow
+	err := col.FindOne(context.Background(), bson.D{
+		{Key: fiTrxSubmissionPk, Value: key},
+		{Key: fiTrxSubmissionExpires, Value: bson.D{{Key: "$gt", Value: time.Now().UTC()}}},
+	}).Decode(&row)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil, nil
+		}
+
+		db.log.Errorf("can not load trx submission %s; %s", key, err.Error())
+		return nil, nil, err
+	}
+
+	hash, sum := common.HexToHash(row.Hash), common.HexToHash(row.Sum)
+	return &hash, &sum, nil
+}
+
+// StoreIdempotentTransaction stores the hash of the transaction submitted with the given
+// idempotency key along with the hash of the submitted data for the given time.
+func (db *MongoDbBridge) StoreIdempotentTransaction(key string, hash *common.Hash, sum *common.Hash, ttl time.Duration) error {
+	col := db.client.Database(db.dbName).Collection(colTrxSubmissions)
+
+	// an expired row may still be there, replace it
+	_, err := col.ReplaceOne(context.Background(), bson.D{{Key: fiTrxSubmissionPk, Value: key}}, &trxSubmissionRow{
+		Key:     key,
+		Hash:    hash.String(),
+		Sum:     sum.String(),
+		Expires: time.Now().UTC().Add(ttl),
+	}, options.Replace().SetUpsert(true))
+	if err != nil {
+		db.log.Errorf("can not store trx submission %s; %s", key, err.Error())
+		return err
+	}
+
+	// make sure trx submissions collection is initialized
+	if db.initTrxSubmissions != nil {
+		db.initTrxSubmissions.Do(func() { db.initTrxSubmissionsCollection(col); db.initTrxSubmissions = nil })
+	}
+	return nil
+}
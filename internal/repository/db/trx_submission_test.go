@@ -0,0 +1,63 @@
+package db
+
+import (
+	"context"
+	"fantom-api-graphql/internal/config"
+	"fantom-api-graphql/internal/logger"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/onsi/gomega"
+	"os"
+	"testing"
+	"time"
+)
+
+// TestIdempotentTransaction tests the transaction submissions are kept for their own time
+// to live and an expired submission does not block a new one with the same key.
+func TestIdempotentTransaction(t *testing.T) {
+	url := os.Getenv(testDbUrlEnv)
+	if url == "" {
+		t.Skipf("%s not set", testDbUrlEnv)
+	}
+
+	g := gomega.NewWithT(t)
+	cfg := &config.Config{
+		Log: config.Log{Level: "CRITICAL", Format: "%{message}"},
+		Db:  config.Database{Url: url, DbName: fmt.Sprintf("fantom_api_test_%d", time.Now().UnixNano())},
+	}
+	db, err := New(cfg, logger.New(cfg))
+	g.Expect(err).To(gomega.BeNil())
+	defer func() {
+		g.Expect(db.client.Database(db.dbName).Drop(context.Background())).To(gomega.Succeed())
+		db.Close()
+	}()
+
+	// unknown key
+	hash, sum, err := db.IdempotentTransaction("k1")
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(hash).To(gomega.BeNil())
+	g.Expect(sum).To(gomega.BeNil())
+
+	// the submission outlives the in-memory cache window
+	h1, s1 := common.HexToHash("0x1"), common.HexToHash("0xa")
+	g.Expect(db.StoreIdempotentTransaction("k1", &h1, &s1, 24*time.Hour)).To(gomega.Succeed())
+	hash, sum, err = db.IdempotentTransaction("k1")
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(*hash).To(gomega.Equal(h1))
+	g.Expect(*sum).To(gomega.Equal(s1))
+
+	// expired submissions are not provided even before the database removes them
+	h2, s2 := common.HexToHash("0x2"), common.HexToHash("0xb")
+	g.Expect(db.StoreIdempotentTransaction("k2", &h2, &s2, -time.Second)).To(gomega.Succeed())
+	hash, _, err = db.IdempotentTransaction("k2")
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(hash).To(gomega.BeNil())
+
+	// and the key can be used again
+	h3, s3 := common.HexToHash("0x3"), common.HexToHash("0xc")
+	g.Expect(db.StoreIdempotentTransaction("k2", &h3, &s3, time.Hour)).To(gomega.Succeed())
+	hash, sum, err = db.IdempotentTransaction("k2")
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(*hash).To(gomega.Equal(h3))
+	g.Expect(*sum).To(gomega.Equal(s3))
+}
@@ -114,6 +114,28 @@ func isZeroSwap(swap *types.Swap) bool {
 	return am0small == 0 || am1small == 0
 }
 
+// uniswapActionTypeFilter creates the filter of uniswap actions of the given type; negative type matches any action.
+// Previous versions stored trades as mints, so the trades also match mints with an output amount;
+// mints never have one. The stored type is kept so clients filtering by it get the same rows as before.
+func uniswapActionTypeFilter(actionType int32) bson.D {
+	switch {
+	case actionType < 0:
+		return bson.D{}
+	case actionType == types.SwapTrade:
+		return bson.D{{Key: "$or", Value: bson.A{
+			bson.D{{Key: fiSwapType, Value: actionType}},
+			bson.D{
+				{Key: fiSwapType, Value: types.SwapMint},
+				{Key: "$or", Value: bson.A{
+					bson.D{{Key: fiSwapAmount0out, Value: bson.D{{Key: "$gt", Value: 0}}}},
+					bson.D{{Key: fiSwapAmount1out, Value: bson.D{{Key: "$gt", Value: 0}}}},
+				}},
+			},
+		}}}
+	}
+	return bson.D{{Key: fiSwapType, Value: actionType}}
+}
+
 // UniswapAdd stores a swap reference in connected persistent storage.
 func (db *MongoDbBridge) UniswapAdd(swap *types.Swap) error {
 	// do we have all needed data?
@@ -667,7 +689,7 @@ func (db *MongoDbBridge) UniswapTimeReserves(pairAddress *common.Address, resolu
 }
 
 // UniswapActions provides list of uniswap actions stored in the persistent storage.
-func (db *MongoDbBridge) UniswapActions(pairAddress *common.Address, cursor *string, count int32, actionType int32) (*types.UniswapActionList, error) {
+func (db *MongoDbBridge) UniswapActions(pairAddress *common.Address, sender *common.Address, cursor *string, count int32, actionType int32) (*types.UniswapActionList, error) {
 	// nothing to load?
 	if count == 0 {
 		return nil, fmt.Errorf("nothing to do, zero uniswap actions requested")
@@ -677,14 +699,14 @@ func (db *MongoDbBridge) UniswapActions(pairAddress *common.Address, cursor *str
 	col := db.client.Database(db.dbName).Collection(coUniswap)
 
 	// init the list
-	list, err := db.uniswapActionListInit(col, pairAddress, cursor, count, actionType)
+	list, err := db.uniswapActionListInit(col, pairAddress, sender, cursor, count, actionType)
 	if err != nil {
 		db.log.Errorf("can not build uniswap action list; %s", err.Error())
 		return nil, err
 	}
 
 	// load data
-	err = db.uniswapActionListLoad(col, pairAddress, actionType, cursor, count, list)
+	err = db.uniswapActionListLoad(col, pairAddress, sender, actionType, cursor, count, list)
 	if err != nil {
 		db.log.Errorf("can not load uniswap action list from database; %s", err.Error())
 		return nil, err
@@ -699,7 +721,7 @@ func (db *MongoDbBridge) UniswapActions(pairAddress *common.Address, cursor *str
 }
 
 // contractListInit initializes list of contracts based on provided cursor and count.
-func (db *MongoDbBridge) uniswapActionListInit(col *mongo.Collection, pairAddress *common.Address, cursor *string, count int32, actionType int32) (*types.UniswapActionList, error) {
+func (db *MongoDbBridge) uniswapActionListInit(col *mongo.Collection, pairAddress *common.Address, sender *common.Address, cursor *string, count int32, actionType int32) (*types.UniswapActionList, error) {
 	// make the list
 	list := types.UniswapActionList{
 		Collection: make([]*types.UniswapAction, 0),
@@ -711,7 +733,7 @@ func (db *MongoDbBridge) uniswapActionListInit(col *mongo.Collection, pairAddres
 	}
 
 	// calculate the total number of contracts in the list
-	if err := db.uniswapActionListTotal(col, pairAddress, &list, actionType); err != nil {
+	if err := db.uniswapActionListTotal(col, pairAddress, sender, &list, actionType); err != nil {
 		return nil, err
 	}
 
@@ -719,7 +741,7 @@ func (db *MongoDbBridge) uniswapActionListInit(col *mongo.Collection, pairAddres
 	db.log.Debugf("Found %d uniswap actions in off-chain database for specified criteria", list.Total)
 
 	// find the top uniswap action of the list
-	if err := db.uniswapActionListTop(col, pairAddress, actionType, cursor, count, &list); err != nil {
+	if err := db.uniswapActionListTop(col, pairAddress, sender, actionType, cursor, count, &list); err != nil {
 		return nil, err
 	}
 
@@ -729,25 +751,28 @@ func (db *MongoDbBridge) uniswapActionListInit(col *mongo.Collection, pairAddres
 }
 
 // uniswapActionListTotal find the total amount of uniswap events for the criteria and populates the list
-func (db *MongoDbBridge) uniswapActionListTotal(col *mongo.Collection, pairAddress *common.Address, list *types.UniswapActionList, actionType int32) error {
+func (db *MongoDbBridge) uniswapActionListTotal(col *mongo.Collection, pairAddress *common.Address, sender *common.Address, list *types.UniswapActionList, actionType int32) error {
 	// prep the empty filter
 	filter := bson.D{}
 	filterPair := bson.D{}
-	filterType := bson.D{}
+	filterSender := bson.D{}
 
 	// validation filter for pair address
 	if pairAddress != nil {
 		filterPair = bson.D{{Key: fiSwapPair, Value: pairAddress.String()}}
 	}
 
-	// validation filter for action type
-	if actionType >= 0 {
-		filterType = bson.D{{Key: fiSwapType, Value: actionType}}
+	// filter for sender account
+	if sender != nil {
+		filterSender = bson.D{{Key: fiSwapSender, Value: sender.String()}}
 	}
 
+	// validation filter for action type
+	filterType := uniswapActionTypeFilter(actionType)
+
 	filterBlk := bson.D{{Key: fiSwapBlock, Value: bson.D{{Key: "$exists", Value: true}}}}
 
-	filter = bson.D{{Key: "$and", Value: bson.A{filterPair, filterType, filterBlk}}}
+	filter = bson.D{{Key: "$and", Value: bson.A{filterPair, filterSender, filterType, filterBlk}}}
 
 	// find how many uniswap events do we have in the database
 	total, err := col.CountDocuments(context.Background(), filter)
@@ -762,9 +787,9 @@ func (db *MongoDbBridge) uniswapActionListTotal(col *mongo.Collection, pairAddre
 }
 
 // uniswapActionListTop find the first uniswap action of the list based on provided criteria and populates the list.
-func (db *MongoDbBridge) uniswapActionListTop(col *mongo.Collection, pairAddress *common.Address, actionType int32, cursor *string, count int32, list *types.UniswapActionList) error {
+func (db *MongoDbBridge) uniswapActionListTop(col *mongo.Collection, pairAddress *common.Address, sender *common.Address, actionType int32, cursor *string, count int32, list *types.UniswapActionList) error {
 	// get the filter
-	filter, err := uniswapActionListTopFilter(pairAddress, cursor, actionType)
+	filter, err := uniswapActionListTopFilter(pairAddress, sender, cursor, actionType)
 	if err != nil {
 		db.log.Errorf("can not find top uniswap action for the list; %s", err.Error())
 		return err
@@ -802,7 +827,7 @@ func (db *MongoDbBridge) uniswapActionListTop(col *mongo.Collection, pairAddress
 }
 
 // uniswapActionListTopFilter constructs a filter for finding the top item of the list.
-func uniswapActionListTopFilter(pairAddress *common.Address, cursor *string, actionType int32) (*bson.D, error) {
+func uniswapActionListTopFilter(pairAddress *common.Address, sender *common.Address, cursor *string, actionType int32) (*bson.D, error) {
 	// what is the requested ordinal index from cursor, if any
 	var ix uint64
 	if cursor != nil {
@@ -811,14 +836,14 @@ func uniswapActionListTopFilter(pairAddress *common.Address, cursor *string, act
 		// get the ordinal index based on cursor
 		ix, err = strconv.ParseUint(*cursor, 10, 64)
 		if err != nil {
-			return nil, fmt.Errorf("invalid cursor value; %s", err.Error())
+			return nil, fmt.Errorf("%w; %s", ErrInvalidCursor, err.Error())
 		}
 	}
 
 	// prep the empty filter (no cursor and any validation status)
 	filter := bson.D{}
 	filterPair := bson.D{}
-	filterType := bson.D{}
+	filterSender := bson.D{}
 	filterCursor := bson.D{}
 
 	// filter for pair address
@@ -826,28 +851,31 @@ func uniswapActionListTopFilter(pairAddress *common.Address, cursor *string, act
 		filterPair = bson.D{{Key: fiSwapPair, Value: pairAddress.String()}}
 	}
 
-	// filter for action type
-	if actionType >= 0 {
-		filterType = bson.D{{Key: fiSwapType, Value: actionType}}
+	// filter for sender account
+	if sender != nil {
+		filterSender = bson.D{{Key: fiSwapSender, Value: sender.String()}}
 	}
 
+	// filter for action type
+	filterType := uniswapActionTypeFilter(actionType)
+
 	// filter for cursor
 	if cursor != nil {
 		filterCursor = bson.D{{Key: fiSwapOrdIndex, Value: ix}}
 	}
 
-	filter = bson.D{{Key: "$and", Value: bson.A{filterPair, filterType, filterCursor}}}
+	filter = bson.D{{Key: "$and", Value: bson.A{filterPair, filterSender, filterType, filterCursor}}}
 
 	return &filter, nil
 }
 
 // uniswapActionListLoad loads the initialized uniswap action list from persistent database.
-func (db *MongoDbBridge) uniswapActionListLoad(col *mongo.Collection, pairAddress *common.Address, actionType int32, cursor *string, count int32, list *types.UniswapActionList) error {
+func (db *MongoDbBridge) uniswapActionListLoad(col *mongo.Collection, pairAddress *common.Address, sender *common.Address, actionType int32, cursor *string, count int32, list *types.UniswapActionList) error {
 	// get the context for loader
 	ctx := context.Background()
 
 	// load the data
-	ld, err := col.Find(ctx, db.uniswapActionListFilter(pairAddress, actionType, cursor, count, list), db.uniswapActionListOptions(count))
+	ld, err := col.Find(ctx, db.uniswapActionListFilter(pairAddress, sender, actionType, cursor, count, list), db.uniswapActionListOptions(count))
 	if err != nil {
 		db.log.Errorf("error loading uniswap action list; %s", err.Error())
 		return err
@@ -925,7 +953,7 @@ func (db *MongoDbBridge) uniswapActionListLoad(col *mongo.Collection, pairAddres
 }
 
 // uniswapActionListFilter creates a filter for uniswap action list search.
-func (db *MongoDbBridge) uniswapActionListFilter(pairAddress *common.Address, actionType int32, cursor *string, count int32, list *types.UniswapActionList) *bson.D {
+func (db *MongoDbBridge) uniswapActionListFilter(pairAddress *common.Address, sender *common.Address, actionType int32, cursor *string, count int32, list *types.UniswapActionList) *bson.D {
 	// inform what we are about to do
 	db.log.Debugf("uniswap action filter starts from index %d", list.First)
 
@@ -950,7 +978,7 @@ func (db *MongoDbBridge) uniswapActionListFilter(pairAddress *common.Address, ac
 	// prep the empty filter (no cursor and any validation status)
 	filter := bson.D{}
 	filterPair := bson.D{}
-	filterType := bson.D{}
+	filterSender := bson.D{}
 	filterCursor := bson.D{}
 
 	// filter for cursor
@@ -961,12 +989,15 @@ func (db *MongoDbBridge) uniswapActionListFilter(pairAddress *common.Address, ac
 		filterPair = bson.D{{Key: fiSwapPair, Value: pairAddress.String()}}
 	}
 
-	// filter for action type
-	if actionType >= 0 {
-		filterType = bson.D{{Key: fiSwapType, Value: actionType}}
+	// filter for sender account
+	if sender != nil {
+		filterSender = bson.D{{Key: fiSwapSender, Value: sender.String()}}
 	}
 
-	filter = bson.D{{Key: "$and", Value: bson.A{filterPair, filterType, filterCursor}}}
+	// filter for action type
+	filterType := uniswapActionTypeFilter(actionType)
+
+	filter = bson.D{{Key: "$and", Value: bson.A{filterPair, filterSender, filterType, filterCursor}}}
 
 	return &filter
 }
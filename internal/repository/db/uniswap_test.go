@@ -0,0 +1,65 @@
+package db
+
+import (
+	"context"
+	"fantom-api-graphql/internal/config"
+	"fantom-api-graphql/internal/logger"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/onsi/gomega"
+	"go.mongodb.org/mongo-driver/bson"
+	"os"
+	"testing"
+	"time"
+)
+
+// TestUniswapActionTypeFilter tests trades stored as mints by previous versions are listed
+// as trades, while the stored type of the actions is kept.
+func TestUniswapActionTypeFilter(t *testing.T) {
+	url := os.Getenv(testDbUrlEnv)
+	if url == "" {
+		t.Skipf("%s not set", testDbUrlEnv)
+	}
+
+	g := gomega.NewWithT(t)
+	cfg := &config.Config{
+		Log: config.Log{Level: "CRITICAL", Format: "%{message}"},
+		Db:  config.Database{Url: url, DbName: fmt.Sprintf("fantom_api_test_%d", time.Now().UnixNano())},
+	}
+	db, err := New(cfg, logger.New(cfg))
+	g.Expect(err).To(gomega.BeNil())
+	defer func() {
+		g.Expect(db.client.Database(db.dbName).Drop(context.Background())).To(gomega.Succeed())
+		db.Close()
+	}()
+
+	col := db.client.Database(db.dbName).Collection(coUniswap)
+	_, err = col.InsertMany(context.Background(), []interface{}{
+		bson.D{{Key: fiSwapPk, Value: "legacy"}, {Key: fiSwapType, Value: types.SwapMint}, {Key: fiSwapAmount0in, Value: 5}, {Key: fiSwapAmount1out, Value: 7}},
+		bson.D{{Key: fiSwapPk, Value: "trade"}, {Key: fiSwapType, Value: types.SwapTrade}, {Key: fiSwapAmount0in, Value: 5}, {Key: fiSwapAmount1out, Value: 7}},
+		bson.D{{Key: fiSwapPk, Value: "mint"}, {Key: fiSwapType, Value: types.SwapMint}, {Key: fiSwapAmount0in, Value: 5}, {Key: fiSwapAmount1in, Value: 7}, {Key: fiSwapAmount0out, Value: 0}, {Key: fiSwapAmount1out, Value: 0}},
+		bson.D{{Key: fiSwapPk, Value: "burn"}, {Key: fiSwapType, Value: types.SwapBurn}, {Key: fiSwapAmount0out, Value: 5}, {Key: fiSwapAmount1out, Value: 7}},
+	})
+	g.Expect(err).To(gomega.BeNil())
+
+	for typ, ids := range map[int32][]string{
+		-1:              {"legacy", "trade", "mint", "burn"},
+		types.SwapTrade: {"legacy", "trade"},
+		types.SwapMint:  {"legacy", "mint"},
+		types.SwapBurn:  {"burn"},
+	} {
+		cursor, err := col.Find(context.Background(), uniswapActionTypeFilter(typ))
+		g.Expect(err).To(gomega.BeNil())
+
+		var rows []struct {
+			ID string `bson:"_id"`
+		}
+		g.Expect(cursor.All(context.Background(), &rows)).To(gomega.Succeed())
+
+		found := make([]string, len(rows))
+		for i, row := range rows {
+			found[i] = row.ID
+		}
+		g.Expect(found).To(gomega.ConsistOf(ids), "type %d", typ)
+	}
+}
@@ -0,0 +1,296 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
+)
+
+const (
+	// colWebhooks represents the name of the webhooks collection in database.
+	colWebhooks = "webhooks"
+
+	// colWebhookDeliveries represents the name of the webhook deliveries collection in database.
+	colWebhookDeliveries = "webhook_deliveries"
+
+	// fiWebhookPk is the name of the primary key of the webhook collections.
+	fiWebhookPk = "_id"
+
+	// fiWebhookAddress is the name of the field of the webhook address.
+	fiWebhookAddress = "addr"
+
+	// fiWebhookDeliveryHook is the name of the field of the delivery webhook id.
+	fiWebhookDeliveryHook = "hook"
+
+	// fiWebhookDeliveryStatus is the name of the field of the delivery status.
+	fiWebhookDeliveryStatus = "st"
+
+	// fiWebhookDeliveryNext is the name of the field of the next delivery attempt time.
+	fiWebhookDeliveryNext = "next"
+
+	// fiWebhookDeliveryCreated is the name of the field of the delivery creation time.
+	fiWebhookDeliveryCreated = "created"
+)
+
+// webhookRow represents a single row of the webhooks collection.
+type webhookRow struct {
+	ID      string    `bson:"_id"`
+	Address string    `bson:"addr"`
+	Url     string    `bson:"url"`
+	Created time.Time `bson:"created"`
+}
+
+// webhookDeliveryRow represents a single row of the webhook deliveries collection.
+type webhookDeliveryRow struct {
+	ID          string     `bson:"_id"`
+	WebhookID   string     `bson:"hook"`
+	Url         string     `bson:"url"`
+	Transaction string     `bson:"trx"`
+	Block       int64      `bson:"blk"`
+	Payload     string     `bson:"payload"`
+	Status      string     `bson:"st"`
+	Attempts    int32      `bson:"att"`
+	LastError   string     `bson:"err"`
+	NextAttempt time.Time  `bson:"next"`
+	Created     time.Time  `bson:"created"`
+	Delivered   *time.Time `bson:"dlv"`
+}
+
+// initWebhooksCollection initializes the webhooks collection with
+// indexes and additional parameters needed by the app.
+func (db *MongoDbBridge) initWebhooksCollection(col *mongo.Collection) {
+	// index the address the webhooks are looked up by
+	ix := []mongo.IndexModel{{Keys: bson.D{{Key: fiWebhookAddress, Value: 1}}}}
+
+	// create indexes
+	if _, err := col.Indexes().CreateMany(context.Background(), ix); err != nil {
+		db.log.Panicf("can not create indexes for webhooks collection; %s", err.Error())
+	}
+	db.log.Debugf("webhooks collection initialized")
+}
+
+// initWebhookDeliveriesCollection initializes the webhook deliveries collection with
+// indexes and additional parameters needed by the app.
+func (db *MongoDbBridge) initWebhookDeliveriesCollection(col *mongo.Collection) {
+	// index the pending deliveries by the next attempt and the deliveries of a webhook
+	ix := []mongo.IndexModel{
+		{Keys: bson.D{{Key: fiWebhookDeliveryStatus, Value: 1}, {Key: fiWebhookDeliveryNext, Value: 1}}},
+		{Keys: bson.D{{Key: fiWebhookDeliveryHook, Value: 1}, {Key: fiWebhookDeliveryCreated, Value: -1}}},
+	}
+
+	// create indexes
+	if _, err := col.Indexes().CreateMany(context.Background(), ix); err != nil {
+		db.log.Panicf("can not create indexes for webhook deliveries collection; %s", err.Error())
+	}
+	db.log.Debugf("webhook deliveries collection initialized")
+}
+
+// WebhooksCount calculates total number of registered webhooks.
+func (db *MongoDbBridge) WebhooksCount() (uint64, error) {
+	return db.EstimateCount(db.client.Database(db.dbName).Collection(colWebhooks))
+}
+
+// WebhookDeliveriesCount calculates total number of webhook deliveries.
+func (db *MongoDbBridge) WebhookDeliveriesCount() (uint64, error) {
+	return db.EstimateCount(db.client.Database(db.dbName).Collection(colWebhookDeliveries))
+}
+
+// AddWebhook stores a new webhook in the persistent storage.
+func (db *MongoDbBridge) AddWebhook(wh *types.Webhook) error {
+	if wh == nil {
+		return fmt.Errorf("no webhook to store")
+	}
+
+	col := db.client.Database(db.dbName).Collection(colWebhooks)
+	if _, err := col.InsertOne(context.Background(), &webhookRow{
+		ID:      wh.ID,
+		Address: wh.Address.String(),
+		Url:     wh.Url,
+		Created: wh.Created,
+	}); err != nil {
+		db.log.Errorf("can not store webhook %s; %s", wh.ID, err.Error())
+		return err
+	}
+
+	// make sure webhooks collection is initialized
+	if db.initWebhooks != nil {
+		db.initWebhooks.Do(func() { db.initWebhooksCollection(col); db.initWebhooks = nil })
+	}
+	return nil
+}
+
+// RemoveWebhook removes the webhook and its pending deliveries from the persistent storage.
+// It reports if the webhook was found.
+func (db *MongoDbBridge) RemoveWebhook(id string) (bool, error) {
+	col := db.client.Database(db.dbName).Collection(colWebhooks)
+	res, err := col.DeleteOne(context.Background(), bson.D{{Key: fiWebhookPk, Value: id}})
+	if err != nil {
+		db.log.Errorf("can not remove webhook %s; %s", id, err.Error())
+		return false, err
+	}
+
+	// pending deliveries of the webhook are not needed anymore
+	_, err = db.client.Database(db.dbName).Collection(colWebhookDeliveries).DeleteMany(context.Background(), bson.D{
+		{Key: fiWebhookDeliveryHook, Value: id},
+		{Key: fiWebhookDeliveryStatus, Value: types.WebhookDeliveryPending},
+	})
+	if err != nil {
+		db.log.Errorf("can not remove pending deliveries of webhook %s; %s", id, err.Error())
+		return false, err
+	}
+	return res.DeletedCount > 0, nil
+}
+
+// Webhooks loads the webhooks registered for the given address, or all the webhooks
+// if the address is not specified.
+func (db *MongoDbBridge) Webhooks(addr *common.Address) ([]*types.Webhook, error) {
+	filter := bson.D{}
+	if addr != nil {
+		filter = bson.D{{Key: fiWebhookAddress, Value: addr.String()}}
+	}
+
+	col := db.client.Database(db.dbName).Collection(colWebhooks)
+	cursor, err := col.Find(context.Background(), filter)
+	if err != nil {
+		db.log.Errorf("can not load webhooks; %s", err.Error())
+		return nil, err
+	}
+	defer db.closeCursor(cursor)
+
+	list := make([]*types.Webhook, 0)
+	for cursor.Next(context.Background()) {
+		var row webhookRow
+		if err := cursor.Decode(&row); err != nil {
+			db.log.Errorf("can not decode webhook; %s", err.Error())
+			return nil, err
+		}
+		list = append(list, &types.Webhook{
+			ID:      row.ID,
+			Address: common.HexToAddress(row.Address),
+			Url:     row.Url,
+			Created: row.Created,
+		})
+	}
+	return list, nil
+}
+
+// AddWebhookDeliveries stores new webhook deliveries in the persistent storage.
+// Deliveries already known, e.g. after a block re-scan, are not changed.
+func (db *MongoDbBridge) AddWebhookDeliveries(list []*types.WebhookDelivery) error {
+	if len(list) == 0 {
+		return nil
+	}
+
+	wm := make([]mongo.WriteModel, len(list))
+	for i, wd := range list {
+		wm[i] = mongo.NewUpdateOneModel().
+			SetFilter(bson.D{{Key: fiWebhookPk, Value: wd.ID}}).
+			SetUpdate(bson.D{{Key: "$setOnInsert", Value: webhookDeliveryToRow(wd)}}).
+			SetUpsert(true)
+	}
+
+	col := db.client.Database(db.dbName).Collection(colWebhookDeliveries)
+	if _, err := col.BulkWrite(context.Background(), wm, options.BulkWrite().SetOrdered(false)); err != nil {
+		db.log.Errorf("can not store webhook deliveries; %s", err.Error())
+		return err
+	}
+
+	// make sure webhook deliveries collection is initialized
+	if db.initWebhookDeliveries != nil {
+		db.initWebhookDeliveries.Do(func() { db.initWebhookDeliveriesCollection(col); db.initWebhookDeliveries = nil })
+	}
+	return nil
+}
+
+// UpdateWebhookDelivery stores the result of a delivery attempt.
+func (db *MongoDbBridge) UpdateWebhookDelivery(wd *types.WebhookDelivery) error {
+	col := db.client.Database(db.dbName).Collection(colWebhookDeliveries)
+	_, err := col.UpdateOne(context.Background(), bson.D{{Key: fiWebhookPk, Value: wd.ID}}, bson.D{{Key: "$set", Value: bson.D{
+		{Key: fiWebhookDeliveryStatus, Value: wd.Status},
+		{Key: "att", Value: wd.Attempts},
+		{Key: "err", Value: wd.LastError},
+		{Key: fiWebhookDeliveryNext, Value: wd.NextAttempt},
+		{Key: "dlv", Value: wd.Delivered},
+	}}})
+	if err != nil {
+		db.log.Errorf("can not update webhook delivery %s; %s", wd.ID, err.Error())
+	}
+	return err
+}
+
+// DueWebhookDeliveries loads pending webhook deliveries with the next attempt due at the given time,
+// the longest waiting first.
+func (db *MongoDbBridge) DueWebhookDeliveries(now time.Time, limit int64) ([]*types.WebhookDelivery, error) {
+	return db.webhookDeliveries(bson.D{
+		{Key: fiWebhookDeliveryStatus, Value: types.WebhookDeliveryPending},
+		{Key: fiWebhookDeliveryNext, Value: bson.D{{Key: "$lte", Value: now}}},
+	}, options.Find().SetSort(bson.D{{Key: fiWebhookDeliveryNext, Value: 1}}).SetLimit(limit))
+}
+
+// WebhookDeliveries loads the most recent deliveries of the given webhook.
+func (db *MongoDbBridge) WebhookDeliveries(id string, limit int64) ([]*types.WebhookDelivery, error) {
+	return db.webhookDeliveries(
+		bson.D{{Key: fiWebhookDeliveryHook, Value: id}},
+		options.Find().SetSort(bson.D{{Key: fiWebhookDeliveryCreated, Value: -1}}).SetLimit(limit),
+	)
+}
+
+// webhookDeliveries loads webhook deliveries using the given filter and options.
+func (db *MongoDbBridge) webhookDeliveries(filter bson.D, opt *options.FindOptions) ([]*types.WebhookDelivery, error) {
+	col := db.client.Database(db.dbName).Collection(colWebhookDeliveries)
+	cursor, err := col.Find(context.Background(), filter, opt)
+	if err != nil {
+		db.log.Errorf("can not load webhook deliveries; %s", err.Error())
+		return nil, err
+	}
+	defer db.closeCursor(cursor)
+
+	list := make([]*types.WebhookDelivery, 0)
+	for cursor.Next(context.Background()) {
+		var row webhookDeliveryRow
+		if err := cursor.Decode(&row); err != nil {
+			db.log.Errorf("can not decode webhook delivery; %s", err.Error())
+			return nil, err
+		}
+		list = append(list, &types.WebhookDelivery{
+			ID:          row.ID,
+			WebhookID:   row.WebhookID,
+			Url:         row.Url,
+			Transaction: common.HexToHash(row.Transaction),
+			Block:       hexutil.Uint64(row.Block),
+			Payload:     row.Payload,
+			Status:      row.Status,
+			Attempts:    row.Attempts,
+			LastError:   row.LastError,
+			NextAttempt: row.NextAttempt,
+			Created:     row.Created,
+			Delivered:   row.Delivered,
+		})
+	}
+	return list, nil
+}
+
+// webhookDeliveryToRow converts the webhook delivery to the collection row.
+func webhookDeliveryToRow(wd *types.WebhookDelivery) *webhookDeliveryRow {
+	return &webhookDeliveryRow{
+		ID:          wd.ID,
+		WebhookID:   wd.WebhookID,
+		Url:         wd.Url,
+		Transaction: wd.Transaction.String(),
+		Block:       int64(wd.Block),
+		Payload:     wd.Payload,
+		Status:      wd.Status,
+		Attempts:    wd.Attempts,
+		LastError:   wd.LastError,
+		NextAttempt: wd.NextAttempt,
+		Created:     wd.Created,
+		Delivered:   wd.Delivered,
+	}
+}
@@ -30,8 +30,25 @@ func (p *proxy) DefiTokenPrice(token *common.Address) (hexutil.Big, error) {
 }
 
 // FMintAccount loads details of a DeFi/fMint account identified by the owner address.
+// Accounts without any fMint position are resolved with empty token lists and zero values.
 func (p *proxy) FMintAccount(owner common.Address) (*types.FMintAccount, error) {
-	return p.rpc.FMintAccount(&owner)
+	// try the cache first
+	if acc := p.cache.PullFMintAccount(&owner); acc != nil {
+		return acc, nil
+	}
+
+	// pull the account from the fMint contracts
+	acc, err := p.rpc.FMintAccount(&owner)
+	if err != nil {
+		p.log.Errorf("fMint account %s not available; %s", owner.String(), err.Error())
+		return nil, err
+	}
+
+	// store the account for a short time
+	if err := p.cache.PushFMintAccount(acc); err != nil {
+		p.log.Errorf("can not cache fMint account %s; %s", owner.String(), err.Error())
+	}
+	return acc, nil
 }
 
 // FMintTokenBalance loads balance of a single DeFi token by it's address.
@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"fantom-api-graphql/internal/types"
+)
+
+// ConnectionStatus checks the connections to the blockchain node and to the persistent storage.
+// The current block height of the node is provided as well, if the node is available.
+func (p *proxy) ConnectionStatus() *types.ConnectionStatus {
+	var cs types.ConnectionStatus
+
+	bh, err := p.rpc.BlockHeight()
+	if err != nil {
+		p.log.Errorf("node connection check failed; %s", err.Error())
+		p.rpcErrors.Fail(err.Error())
+	} else {
+		cs.NodeHead = bh.ToInt().Uint64()
+	}
+	cs.Rpc = p.rpcErrors.Status("rpc", err == nil)
+
+	err = p.db.Ping()
+	if err != nil {
+		p.log.Errorf("database connection check failed; %s", err.Error())
+		p.dbErrors.Fail(err.Error())
+	}
+	cs.Db = p.dbErrors.Status("db", err == nil)
+	return &cs
+}
@@ -0,0 +1,34 @@
+/*
+Package repository implements repository for handling fast and efficient access to data required
+by the resolvers of the API server.
+
+Internally it utilizes RPC to access Opera/Lachesis full node for blockchain interaction. Mongo database
+for fast, robust and scalable off-chain data storage, especially for aggregated and pre-calculated data mining
+results. BigCache for in-memory object storage to speed up loading of frequently accessed entities.
+*/
+package repository
+
+import (
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// AddEpochSeal stores the seal of an epoch in the persistent storage.
+func (p *proxy) AddEpochSeal(es *types.EpochSeal) error {
+	return p.db.AddEpochSeal(es)
+}
+
+// EpochSeal provides the seal of the given epoch, or the most recent known seal
+// if the epoch is not specified. Nil is returned if the seal is not known.
+func (p *proxy) EpochSeal(epoch *hexutil.Uint64) (*types.EpochSeal, error) {
+	if epoch == nil {
+		return p.db.LastEpochSeal()
+	}
+	return p.db.EpochSeal(uint64(*epoch))
+}
+
+// BlockEpochSeal provides the seal of the epoch containing the given block;
+// nil if the epoch has not been sealed yet.
+func (p *proxy) BlockEpochSeal(block hexutil.Uint64) (*types.EpochSeal, error) {
+	return p.db.BlockEpochSeal(uint64(block))
+}
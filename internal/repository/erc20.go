@@ -8,6 +8,9 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 )
 
+// erc20UnknownSymbol is the symbol used for tokens not providing their symbol.
+const erc20UnknownSymbol = "-"
+
 // Erc20Token returns an ERC20 token for the given address, if available.
 func (p *proxy) Erc20Token(addr *common.Address) (*types.Erc20Token, error) {
 	// get the token
@@ -59,7 +62,7 @@ func (p *proxy) loadErc20TokenDetails(token *types.Erc20Token) (*types.Erc20Toke
 	token.Symbol, err = p.rpc.Erc20Symbol(&token.Address)
 	if err != nil {
 		p.log.Errorf("ERC20 token symbol not recognized at %s; %s", token.Address.String(), err.Error())
-		token.Symbol = "-"
+		token.Symbol = erc20UnknownSymbol
 	}
 
 	// get decimals
@@ -67,6 +70,21 @@ func (p *proxy) loadErc20TokenDetails(token *types.Erc20Token) (*types.Erc20Toke
 	if err != nil {
 		p.log.Errorf("ERC20 token decimals not recognized at %s; %s", token.Address.String(), err.Error())
 		token.Decimals = 0
+		token.DecimalsUnknown = true
+	}
+
+	// fill the missing details from the remote token list, if the token is listed there
+	if tl := remoteToken(&token.Address); tl != nil {
+		if token.Name == token.Address.String() {
+			token.Name = tl.Name
+		}
+		if token.Symbol == erc20UnknownSymbol {
+			token.Symbol = tl.Symbol
+		}
+		if token.DecimalsUnknown {
+			token.Decimals = tl.Decimals
+			token.DecimalsUnknown = false
+		}
 	}
 
 	return token, nil
@@ -105,6 +123,15 @@ func (p *proxy) Erc20BalanceOf(token *common.Address, owner *common.Address) (he
 	return p.rpc.Erc20BalanceOf(token, owner)
 }
 
+// Erc20BalanceOfAt loads the balance of an ERC20 token for the given owner
+// at the block identified by the given tag.
+func (p *proxy) Erc20BalanceOfAt(token *common.Address, owner *common.Address, tag types.BlockTag) (hexutil.Big, error) {
+	if err := p.checkStateAvailableAt(tag); err != nil {
+		return hexutil.Big{}, err
+	}
+	return p.rpc.Erc20BalanceOfAt(token, owner, tag)
+}
+
 // Erc20Allowance loads the current amount of ERC20 tokens unlocked for DeFi
 // contract by the token owner.
 func (p *proxy) Erc20Allowance(token *common.Address, owner *common.Address, spender *common.Address) (hexutil.Big, error) {
@@ -125,8 +152,13 @@ func (p *proxy) Erc20TokensList(count int32) ([]common.Address, error) {
 func (p *proxy) Erc20LogoURL(addr *common.Address) string {
 	// do we know the token?
 	logo, ok := p.cfg.TokenLogo[*addr]
-	if !ok {
-		logo = p.cfg.TokenLogo[common.HexToAddress(config.EmptyAddress)]
+	if ok {
+		return logo
+	}
+
+	// is the token on the remote token list?
+	if tl := remoteToken(addr); tl != nil && tl.LogoURI != "" {
+		return tl.LogoURI
 	}
-	return logo
+	return p.cfg.TokenLogo[common.HexToAddress(config.EmptyAddress)]
 }
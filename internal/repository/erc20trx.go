@@ -84,3 +84,21 @@ func (p *proxy) TokenTransactions(tokenType string, token *common.Address, token
 func (p *proxy) Erc20Assets(owner common.Address, count int32) ([]common.Address, error) {
 	return p.db.Erc20Assets(owner, count)
 }
+
+// TokenApprovals provides the outstanding ERC20 allowances granted by the given owner,
+// optionally limited to the given token. Only the most recent approval of each spender
+// is considered and the revoked approvals are not included.
+func (p *proxy) TokenApprovals(owner *common.Address, token *common.Address) ([]*types.TokenTransaction, error) {
+	list, err := p.db.Erc20Approvals(owner, token)
+	if err != nil {
+		return nil, err
+	}
+
+	res := list[:0]
+	for _, ap := range list {
+		if ap.Amount.ToInt().Sign() > 0 {
+			res = append(res, ap)
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"errors"
+	"fantom-api-graphql/internal/repository/db"
+	"fantom-api-graphql/internal/repository/rpc"
+)
+
+// ErrInvalidCursor represents an error returned if the list cursor can not be decoded,
+// or does not point to a list item.
+var ErrInvalidCursor = db.ErrInvalidCursor
+
+// ErrValidatorNotFound represents an error returned if the requested validator does not exist.
+var ErrValidatorNotFound = rpc.ErrValidatorNotFound
+
+// ErrUnknownDelegation represents an error returned if the requested delegation does not exist.
+var ErrUnknownDelegation = db.ErrUnknownDelegation
+
+// ErrBlockTagNotSupported represents an error returned if the connected node
+// does not recognize the requested block tag.
+var ErrBlockTagNotSupported = rpc.ErrBlockTagNotSupported
+
+// ErrArchiveRequired represents an error returned if the requested historical state
+// is not available on the connected node.
+var ErrArchiveRequired = rpc.ErrArchiveRequired
+
+// ErrAccountNotFound represents an error returned if the requested account is not available.
+var ErrAccountNotFound = errors.New("account not found")
+
+// ErrGovernanceContractNotFound represents an error returned if the requested
+// governance contract is not configured.
+var ErrGovernanceContractNotFound = errors.New("governance contract not found")
+
+// ErrContractCodeMissing represents an error returned if there is no contract code
+// at the address of a contract being imported.
+var ErrContractCodeMissing = errors.New("no contract code at the address")
+
+// ErrInvalidAbi represents an error returned if a caller supplied contract ABI can not be parsed.
+var ErrInvalidAbi = errors.New("invalid contract ABI")
+
+// ErrAbiMethodNotFound represents an error returned if the called method selector
+// is not found in the contract ABI.
+var ErrAbiMethodNotFound = errors.New("method selector not found in the contract ABI")
+
+// ErrInvalidTypedData represents an error returned if the EIP-712 typed data,
+// or their signature are malformed.
+var ErrInvalidTypedData = errors.New("invalid typed data")
@@ -0,0 +1,198 @@
+package repository
+
+import (
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"reflect"
+	"sort"
+	"time"
+)
+
+// EventLogs provides a page of log records matching the given filter.
+// Log records of contracts with known ABI are decoded, the raw topics and data
+// are provided for the others.
+func (p *proxy) EventLogs(filter *types.EventLogFilter, cursor *string, count int32) (*types.EventLogList, error) {
+	list, err := p.db.EventLogs(filter, cursor, count)
+	if err != nil {
+		p.log.Errorf("can not load logs; %s", err.Error())
+		return nil, err
+	}
+
+	p.decodeEventLogs(list.Collection)
+	return list, nil
+}
+
+// TransactionEvents provides the log records emitted by the given transaction in the log order.
+// Each record is decoded using the ABI of its emitting contract, if known. Logs not loaded
+// with the transaction, or pruned from the database by the retention policy, are loaded
+// with the full transaction.
+func (p *proxy) TransactionEvents(trx *types.Transaction) ([]*types.EventLog, error) {
+	logs := trx.Logs
+	if len(logs) == 0 && trx.BlockNumber != nil && (trx.LogsOmitted || p.isLogPruned(trx)) {
+		rt, err := p.Transaction(&trx.Hash, false)
+		if err != nil {
+			p.log.Errorf("can not load logs of transaction %s; %s", trx.Hash.String(), err.Error())
+			return nil, err
+		}
+		logs = rt.Logs
+	}
+
+	list := make([]*types.EventLog, len(logs))
+	for i := range logs {
+		list[i] = &types.EventLog{Log: logs[i]}
+	}
+	sort.SliceStable(list, func(i, j int) bool {
+		return list[i].Index < list[j].Index
+	})
+
+	p.decodeEventLogs(list)
+	return list, nil
+}
+
+// isLogPruned checks if the log records of the transaction may have been pruned
+// from the database by the retention policy.
+func (p *proxy) isLogPruned(trx *types.Transaction) bool {
+	return p.cfg.Retention.Logs > 0 && time.Since(trx.TimeStamp) > p.cfg.Retention.Logs
+}
+
+// decodeEventLogs decodes the given log records using the ABI of their emitting contracts;
+// the ABI of each contract is parsed only once.
+func (p *proxy) decodeEventLogs(list []*types.EventLog) {
+	abis := make(map[common.Address]*abi.ABI)
+	for _, lg := range list {
+		ab, ok := abis[lg.Address]
+		if !ok {
+			ab = p.eventLogAbi(&lg.Address, lg.BlockNumber)
+			abis[lg.Address] = ab
+		}
+		if ab != nil {
+			lg.Event = decodeEventLog(ab, lg)
+		}
+	}
+}
+
+// ContractEventDefinitions provides the list of events declared in the ABI of the given contract,
+// or its implementation for proxy contracts, sorted by name. Nil is returned if the ABI is not known.
+func (p *proxy) ContractEventDefinitions(addr *common.Address) ([]types.EventDefinition, error) {
+	abiDef, err := p.decodingAbi(addr, nil)
+	if err != nil {
+		return nil, err
+	}
+	if abiDef == "" {
+		return nil, nil
+	}
+
+	ab, err := parseContractAbi(abiDef)
+	if err != nil {
+		p.log.Errorf("invalid ABI of contract %s; %s", addr.String(), err.Error())
+		return nil, err
+	}
+
+	list := make([]types.EventDefinition, 0, len(ab.Events))
+	for _, ev := range ab.Events {
+		def := types.EventDefinition{Name: ev.Name, Signature: ev.Sig, Inputs: make([]types.EventDefinitionInput, len(ev.Inputs))}
+		if !ev.Anonymous {
+			id := ev.ID
+			def.Topic = &id
+		}
+		for i, in := range ev.Inputs {
+			def.Inputs[i] = types.EventDefinitionInput{Name: in.Name, Type: abiTypeString(in.Type), Indexed: in.Indexed}
+		}
+		list = append(list, def)
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Name != list[j].Name {
+			return list[i].Name < list[j].Name
+		}
+		return list[i].Signature < list[j].Signature
+	})
+	return list, nil
+}
+
+// eventLogAbi provides the parsed ABI used to decode log records of the given contract,
+// nil if the ABI is not available.
+func (p *proxy) eventLogAbi(addr *common.Address, block uint64) *abi.ABI {
+	blk := hexutil.Uint64(block)
+	abiDef, err := p.decodingAbi(addr, &blk)
+	if err != nil {
+		p.log.Debugf("can not decode logs of contract %s; %s", addr.String(), err.Error())
+		return nil
+	}
+	if abiDef == "" {
+		return nil
+	}
+
+	ab, err := cachedContractAbi(abiDef)
+	if err != nil {
+		p.log.Debugf("invalid ABI of contract %s; %s", addr.String(), err.Error())
+		return nil
+	}
+	return ab
+}
+
+// decodeEventLog decodes the log record using the given contract ABI.
+// It returns nil if the event is not known, or the record does not match the event.
+func decodeEventLog(ab *abi.ABI, lg *types.EventLog) *types.DecodedEvent {
+	if len(lg.Topics) == 0 {
+		return nil
+	}
+
+	ev, err := ab.EventByID(lg.Topics[0])
+	if err != nil {
+		return nil
+	}
+
+	// non-indexed arguments are packed in the data
+	values, err := ev.Inputs.NonIndexed().Unpack(lg.Data)
+	if err != nil {
+		return nil
+	}
+
+	// indexed arguments are kept in the topics following the event signature
+	indexed := make([]abi.Argument, 0, len(ev.Inputs))
+	for _, in := range ev.Inputs {
+		if in.Indexed {
+			indexed = append(indexed, in)
+		}
+	}
+	if len(indexed) != len(lg.Topics)-1 {
+		return nil
+	}
+
+	de := types.DecodedEvent{Name: ev.Name, Signature: ev.Sig, Args: make([]types.DecodedEventArg, 0, len(ev.Inputs))}
+	var ti, vi int
+	for _, in := range ev.Inputs {
+		arg := types.DecodedEventArg{Name: in.Name, Type: abiTypeString(in.Type), Indexed: in.Indexed}
+		if in.Indexed {
+			ti++
+			arg.Value = indexedEventValue(in, lg.Topics[ti])
+		} else {
+			arg.Value = abiValueString(in.Type, values[vi])
+			vi++
+		}
+		de.Args = append(de.Args, arg)
+	}
+	return &de
+}
+
+// indexedEventValue decodes the value of an indexed event argument from its topic.
+// Dynamic types are indexed by their hash, so the hash is the only value available.
+func indexedEventValue(in abi.Argument, topic common.Hash) string {
+	if dec, ok := abiFixedDecimals(in.Type); ok {
+		return abiFixedString(abiInteger(reflect.ValueOf(abi.ReadInteger(*in.Type.TupleElems[0], topic.Bytes()))), dec)
+	}
+
+	switch in.Type.T {
+	case abi.StringTy, abi.BytesTy, abi.SliceTy, abi.ArrayTy, abi.TupleTy:
+		return topic.String()
+	}
+
+	out := make(map[string]interface{})
+	if err := abi.ParseTopicsIntoMap(out, abi.Arguments{in}, []common.Hash{topic}); err != nil {
+		return topic.String()
+	}
+	return abiValueString(in.Type, out[in.Name])
+}
@@ -0,0 +1,281 @@
+package repository
+
+import (
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// eventLogsByArgsMaxScan is the max number of log records of the event scanned for a single page
+// when filtering by non-indexed arguments; a shorter page is provided if the limit is reached.
+const eventLogsByArgsMaxScan = 5000
+
+// eventLogsByArgsBatch is the number of log records loaded in one step of the scan.
+const eventLogsByArgsBatch = 250
+
+// eventArgMatch represents a condition on a non-indexed event argument checked on the decoded record.
+type eventArgMatch struct {
+	name  string
+	value string
+}
+
+// EventLogsByArgs provides a page of log records of the given event of the contract
+// with the decoded arguments matching the given values. The event is identified
+// by its name, or signature, in the ABI of the contract. Conditions on indexed arguments
+// are translated to topic filters, the non-indexed arguments are matched on the decoded records,
+// so only a limited number of records is scanned for a single page.
+func (p *proxy) EventLogsByArgs(filter *types.EventLogFilter, event string, args []types.EventArgFilter, cursor *string, count int32) (*types.EventLogList, error) {
+	abiDef, err := p.decodingAbi(filter.Address, nil)
+	if err != nil {
+		return nil, err
+	}
+	if abiDef == "" {
+		return nil, fmt.Errorf("ABI of contract %s not available", filter.Address.String())
+	}
+
+	ab, err := parseContractAbi(abiDef)
+	if err != nil {
+		p.log.Errorf("invalid ABI of contract %s; %s", filter.Address.String(), err.Error())
+		return nil, err
+	}
+
+	ev, err := abiEvent(&ab, event)
+	if err != nil {
+		return nil, err
+	}
+
+	topics, matches, err := eventArgsFilter(ev, args)
+	if err != nil {
+		return nil, err
+	}
+	filter.Topics = topics
+
+	// indexed arguments only, the database does all the work
+	if len(matches) == 0 {
+		return p.EventLogs(filter, cursor, count)
+	}
+	return p.eventLogsMatching(filter, matches, cursor, count)
+}
+
+// eventLogsMatching scans the log records of the filter for records with decoded arguments
+// matching the given conditions. The scan ends when the page is full, or the scan limit is reached.
+func (p *proxy) eventLogsMatching(filter *types.EventLogFilter, matches []eventArgMatch, cursor *string, count int32) (*types.EventLogList, error) {
+	list := types.EventLogList{Collection: make([]*types.EventLog, 0, count)}
+
+	var scanned int
+	for {
+		page, err := p.EventLogs(filter, cursor, eventLogsByArgsBatch)
+		if err != nil {
+			return nil, err
+		}
+
+		for i, lg := range page.Collection {
+			if !eventArgsMatch(lg.Event, matches) {
+				continue
+			}
+
+			list.Collection = append(list.Collection, lg)
+			if len(list.Collection) == int(count) {
+				// the page is full; continue after this record, if there is anything left
+				if i < len(page.Collection)-1 || page.Next != nil {
+					list.Next = &lg.Cursor
+				}
+				return &list, nil
+			}
+		}
+
+		// continue the scan, if allowed
+		scanned += len(page.Collection)
+		if page.Next == nil || scanned >= eventLogsByArgsMaxScan {
+			list.Next = page.Next
+			return &list, nil
+		}
+		cursor = page.Next
+	}
+}
+
+// abiEvent finds the event identified by its name, or signature, in the ABI.
+func abiEvent(ab *abi.ABI, name string) (*abi.Event, error) {
+	name = strings.TrimSpace(name)
+	for _, ev := range ab.Events {
+		if ev.Name != name && ev.Sig != name {
+			continue
+		}
+		if ev.Anonymous {
+			return nil, fmt.Errorf("event %s is anonymous and can not be selected", ev.Sig)
+		}
+		e := ev
+		return &e, nil
+	}
+	return nil, fmt.Errorf("event %s not found in the contract ABI", name)
+}
+
+// eventArgsFilter builds the topic filter of the indexed arguments and the list of conditions
+// checked on the decoded records for the non-indexed arguments of the event.
+func eventArgsFilter(ev *abi.Event, args []types.EventArgFilter) ([][]common.Hash, []eventArgMatch, error) {
+	topics := [][]common.Hash{{ev.ID}}
+	matches := make([]eventArgMatch, 0)
+
+	for _, af := range args {
+		pos, in, err := eventInput(ev, af.Name)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		val, err := eventArgValue(in.Type, strings.TrimSpace(af.Value))
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid value of argument %s; %s", af.Name, err.Error())
+		}
+
+		if !in.Indexed {
+			matches = append(matches, eventArgMatch{name: in.Name, value: abiValueString(in.Type, val)})
+			continue
+		}
+
+		topic, err := eventArgTopic(in.Type, val)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid value of argument %s; %s", af.Name, err.Error())
+		}
+		for len(topics) <= pos {
+			topics = append(topics, nil)
+		}
+		if topics[pos] != nil {
+			return nil, nil, fmt.Errorf("argument %s filtered more than once", af.Name)
+		}
+		topics[pos] = []common.Hash{topic}
+	}
+	return topics, matches, nil
+}
+
+// eventInput finds the argument of the event by its name. For indexed arguments,
+// it provides the position of the topic carrying the argument value.
+func eventInput(ev *abi.Event, name string) (int, *abi.Argument, error) {
+	pos := 0
+	for i, in := range ev.Inputs {
+		if in.Indexed {
+			pos++
+		}
+		if in.Name == name {
+			return pos, &ev.Inputs[i], nil
+		}
+	}
+	return 0, nil, fmt.Errorf("argument %s not found in event %s", name, ev.Sig)
+}
+
+// eventArgsMatch checks the decoded event matches all the conditions.
+func eventArgsMatch(de *types.DecodedEvent, matches []eventArgMatch) bool {
+	if de == nil {
+		return false
+	}
+
+	for _, m := range matches {
+		found := false
+		for _, arg := range de.Args {
+			if arg.Name == m.name {
+				found = arg.Value == m.value
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// eventArgTopic calculates the topic of the indexed argument value.
+// Dynamic types are indexed by the hash of their content.
+func eventArgTopic(t abi.Type, val interface{}) (common.Hash, error) {
+	switch v := val.(type) {
+	case string:
+		return crypto.Keccak256Hash([]byte(v)), nil
+	case []byte:
+		return crypto.Keccak256Hash(v), nil
+	}
+
+	data, err := abi.Arguments{{Type: t}}.Pack(val)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return common.BytesToHash(data), nil
+}
+
+// eventArgValue parses the text form of the argument value into the type
+// used by the ABI decoder for the argument type.
+func eventArgValue(t abi.Type, value string) (interface{}, error) {
+	switch t.T {
+	case abi.AddressTy:
+		if !common.IsHexAddress(value) {
+			return nil, fmt.Errorf("address expected")
+		}
+		return common.HexToAddress(value), nil
+
+	case abi.BoolTy:
+		return strconv.ParseBool(value)
+
+	case abi.IntTy, abi.UintTy:
+		return eventArgNumber(t, value)
+
+	case abi.FixedBytesTy:
+		b, err := hexutil.Decode(value)
+		if err != nil {
+			return nil, err
+		}
+		if len(b) != t.Size {
+			return nil, fmt.Errorf("%d bytes expected", t.Size)
+		}
+		v := reflect.New(t.GetType()).Elem()
+		reflect.Copy(v, reflect.ValueOf(b))
+		return v.Interface(), nil
+
+	case abi.StringTy:
+		return value, nil
+
+	case abi.BytesTy:
+		return hexutil.Decode(value)
+	}
+	return nil, fmt.Errorf("filter on type %s not supported", abiTypeString(t))
+}
+
+// eventArgNumber parses the decimal, or hex, number into the integer type of the argument.
+func eventArgNumber(t abi.Type, value string) (interface{}, error) {
+	n, ok := new(big.Int).SetString(value, 0)
+	if !ok {
+		return nil, fmt.Errorf("number expected")
+	}
+
+	// check the value fits the type
+	bits := n.BitLen()
+	if t.T == abi.IntTy {
+		if n.Sign() < 0 {
+			bits = new(big.Int).Add(n, big.NewInt(1)).BitLen()
+		}
+		bits++
+	} else if n.Sign() < 0 {
+		return nil, fmt.Errorf("unsigned number expected")
+	}
+	if bits > t.Size {
+		return nil, fmt.Errorf("number out of range of %s", t.String())
+	}
+
+	// small integers are decoded into native types
+	typ := t.GetType()
+	if typ.Kind() == reflect.Ptr {
+		return n, nil
+	}
+
+	v := reflect.New(typ).Elem()
+	if t.T == abi.IntTy {
+		v.SetInt(n.Int64())
+	} else {
+		v.SetUint(n.Uint64())
+	}
+	return v.Interface(), nil
+}
@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"math/big"
+	"sort"
+)
+
+const (
+	// gasTierSamplesPerBlock is the number of the lowest gas prices collected from each block.
+	gasTierSamplesPerBlock = 3
+
+	// gasTierMinSamples is the minimal number of samples we need to derive the tiers;
+	// the node suggestion is used on low traffic.
+	gasTierMinSamples = 5
+
+	// gasTierLowPercentile, gasTierAveragePercentile and gasTierHighPercentile are
+	// the percentiles of the collected gas prices used for the tiers.
+	gasTierLowPercentile     = 10
+	gasTierAveragePercentile = 50
+	gasTierHighPercentile    = 90
+)
+
+// GasPriceTiers provides the gas price suggested by the node along with low, average
+// and high tiers derived from gas prices paid in recent blocks.
+func (p *proxy) GasPriceTiers() (*types.GasPriceTiers, error) {
+	// try the cache first
+	if gt := p.cache.PullGasPriceTiers(); gt != nil {
+		return gt, nil
+	}
+
+	// get the node suggestion
+	sug, err := p.rpc.GasPrice()
+	if err != nil {
+		return nil, err
+	}
+
+	prices, blocks, err := p.rpc.RecentGasPrices(p.cfg.Repository.GasPriceBlocks, gasTierSamplesPerBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	gt := types.GasPriceTiers{
+		Suggested: sug,
+		Low:       sug,
+		Average:   sug,
+		High:      sug,
+		Blocks:    int32(blocks),
+		Samples:   int32(len(prices)),
+	}
+
+	// derive tiers only if there is enough traffic; the node suggestion
+	// is the floor since cheaper transactions would not be accepted anyway
+	if len(prices) >= gasTierMinSamples {
+		sort.Slice(prices, func(i, j int) bool {
+			return prices[i].Cmp(prices[j]) < 0
+		})
+		gt.Low = gasPriceAtLeast(gasPricePercentile(prices, gasTierLowPercentile), sug.ToInt())
+		gt.Average = gasPriceAtLeast(gasPricePercentile(prices, gasTierAveragePercentile), sug.ToInt())
+		gt.High = gasPriceAtLeast(gasPricePercentile(prices, gasTierHighPercentile), sug.ToInt())
+	}
+
+	if err := p.cache.PushGasPriceTiers(&gt); err != nil {
+		p.log.Errorf("can not cache gas price tiers; %s", err.Error())
+	}
+	return &gt, nil
+}
+
+// gasPricePercentile picks the given percentile of the sorted list of gas prices.
+func gasPricePercentile(sorted []*big.Int, pct int) *big.Int {
+	return sorted[(len(sorted)-1)*pct/100]
+}
+
+// gasPriceAtLeast provides the gas price not lower than the given floor.
+func gasPriceAtLeast(val *big.Int, floor *big.Int) hexutil.Big {
+	if val.Cmp(floor) < 0 {
+		return hexutil.Big(*floor)
+	}
+	return hexutil.Big(*val)
+}
@@ -61,7 +61,7 @@ func (p *proxy) GovernanceContractBy(addr *common.Address) (*config.GovernanceCo
 	}
 
 	// contract not found
-	return nil, fmt.Errorf("governance contract %s not found", addr.String())
+	return nil, fmt.Errorf("%w; %s", ErrGovernanceContractNotFound, addr.String())
 }
 
 // GovernanceProposalFee returns the fee payable for a new proposal
@@ -79,7 +79,7 @@ func (p *proxy) GovernanceTotalWeight(gov *common.Address) (hexutil.Big, error)
 		// get the governance config
 		cfg, ok := p.govContracts[gov.String()]
 		if !ok {
-			return hexutil.Big{}, fmt.Errorf("unknown governance %s", gov.String())
+			return hexutil.Big{}, fmt.Errorf("%w; %s", ErrGovernanceContractNotFound, gov.String())
 		}
 
 		// do it slow way
@@ -9,6 +9,7 @@ results. BigCache for in-memory object storage to speed up loading of frequently
 package repository
 
 import (
+	"context"
 	"fantom-api-graphql/internal/config"
 	"fantom-api-graphql/internal/repository/rpc/contracts"
 	"fantom-api-graphql/internal/types"
@@ -25,12 +26,26 @@ type Repository interface {
 	// Account returns account at Opera blockchain for an address, nil if not found.
 	Account(*common.Address) (*types.Account, error)
 
+	// AccountsByAddress returns accounts for the given list of addresses loaded in a single batch.
+	AccountsByAddress([]common.Address) (map[common.Address]*types.Account, error)
+
 	// AccountBalance returns the current balance of an account at Opera blockchain.
 	AccountBalance(*common.Address) (*hexutil.Big, error)
 
+	// AccountBalanceAt returns the balance of an account at the block identified by the given tag.
+	AccountBalanceAt(*common.Address, types.BlockTag) (*hexutil.Big, error)
+
+	// AccountBalances returns the current balances of the given accounts at Opera blockchain.
+	// Balances of accounts failing to load are left nil.
+	AccountBalances([]common.Address) ([]*hexutil.Big, error)
+
 	// AccountNonce returns the current number of sent transactions of an account at Opera blockchain.
 	AccountNonce(*common.Address) (*hexutil.Uint64, error)
 
+	// AccountNonceStatus provides the state of the transaction nonces of the given sender account,
+	// including the pending nonces and the gaps blocking them.
+	AccountNonceStatus(*common.Address) (*types.NonceStatus, error)
+
 	// AccountTransactions returns list of transaction hashes for account at Opera blockchain.
 	//
 	// String cursor represents cursor based on which the list is loaded. If null,
@@ -44,12 +59,27 @@ type Repository interface {
 	// (or at the bottom without one) and loads at most defined number
 	// of transactions newer than that.
 	//
-	// Transactions are always sorted from newer to older.
-	AccountTransactions(*common.Address, *common.Address, *string, int32) (*types.TransactionList, error)
+	// Transactions are always sorted from newer to older. Only the given optional
+	// parts of the transactions are loaded.
+	AccountTransactions(*common.Address, *common.Address, *string, int32, types.TrxFields) (*types.TransactionList, error)
+
+	// AccountStats provides the transaction activity summary of the given account.
+	AccountStats(*common.Address) (*types.AccountStats, error)
+
+	// AccountActivity provides the list of contracts called by the given account
+	// with the breakdown of the called functions, sorted by the number of calls.
+	AccountActivity(*common.Address, *string, int32) (*types.AccountActivity, error)
 
 	// AccountsActive total number of accounts known to repository.
 	AccountsActive() (hexutil.Uint64, error)
 
+	// VerifyTypedData recovers the signer of the EIP-712 typed data signature.
+	VerifyTypedData(string, string, string, *string, hexutil.Bytes) (*common.Address, error)
+
+	// AreContracts checks which of the given accounts are smart contracts.
+	// Flags of accounts failing to load are left nil.
+	AreContracts([]common.Address) ([]*bool, error)
+
 	// AccountIsKnown checks if the account of the given address is known to the API server.
 	AccountIsKnown(*common.Address) bool
 
@@ -77,6 +107,14 @@ type Repository interface {
 	// If the block is not found, ErrBlockNotFound error is returned.
 	BlockByNumber(*hexutil.Uint64) (*types.Block, error)
 
+	// NetworkInfo provides the metadata of the connected node and its network.
+	// The node is not queried past the deadline of the given context.
+	NetworkInfo(context.Context) (*types.NetworkInfo, error)
+
+	// BlocksByNumber returns a range of blocks starting at the given number loaded in a single batch.
+	// The list ends before the first block not available, so it may be shorter than requested.
+	BlocksByNumber(uint64, int) ([]*types.Block, error)
+
 	// BlockByHash returns a block at Opera blockchain represented by a hash.
 	// Top block is returned if the hash is not provided.
 	// If the block is not found, ErrBlockNotFound error is returned.
@@ -89,9 +127,29 @@ type Repository interface {
 	// CacheBlock puts a block to the internal block ring cache.
 	CacheBlock(blk *types.Block)
 
+	// CanonicalBlock loads the block of the given number from the canonical chain bypassing the cache.
+	CanonicalBlock(num uint64) (*types.Block, error)
+
 	// Contract extract a smart contract information by address if available.
 	Contract(*common.Address) (*types.Contract, error)
 
+	// EventLogs provides a page of log records matching the given filter, decoded if the contract ABI is known.
+	EventLogs(*types.EventLogFilter, *string, int32) (*types.EventLogList, error)
+
+	// TransactionEvents provides the log records emitted by the given transaction
+	// in the log order, decoded using the ABI of their emitting contracts, if known.
+	TransactionEvents(*types.Transaction) ([]*types.EventLog, error)
+
+	// EventLogsByArgs provides a page of log records of the given event of the contract
+	// with the decoded arguments matching the given values.
+	EventLogsByArgs(*types.EventLogFilter, string, []types.EventArgFilter, *string, int32) (*types.EventLogList, error)
+
+	// ContractEventDefinitions provides the list of events declared in the ABI of the given contract.
+	ContractEventDefinitions(*common.Address) ([]types.EventDefinition, error)
+
+	// ContractsByAddress returns smart contracts for the given list of addresses loaded in a single batch.
+	ContractsByAddress([]common.Address) (map[common.Address]*types.Contract, error)
+
 	// Contracts returns list of smart contracts at Opera blockchain.
 	Contracts(bool, *string, int32) (*types.ContractList, error)
 
@@ -103,6 +161,64 @@ type Repository interface {
 	// StoreContract updates the contract in repository.
 	StoreContract(*types.Contract) error
 
+	// ContractAbi returns the ABI JSON of a validated smart contract,
+	// or an empty string if the contract is not known or not validated.
+	ContractAbi(*common.Address) (string, error)
+
+	// ContractCreation provides the deployment details of the given contract.
+	ContractCreation(*common.Address) (*types.ContractCreation, error)
+
+	// ContractGasStats provides the gas used by transactions sent to the given contract
+	// in the given inclusive block range.
+	ContractGasStats(*common.Address, uint64, uint64) (*types.ContractGasStats, error)
+
+	// TopContractsByGas provides the contracts with the highest gas used by transactions
+	// sent to them in the given inclusive block range.
+	TopContractsByGas(uint64, uint64, int32) ([]*types.ContractGasStats, error)
+
+	// ContractCreationCode provides the init code of the given contract split into
+	// the creation bytecode and the decoded constructor arguments.
+	ContractCreationCode(*common.Address) (*types.ContractCreationCode, error)
+
+	// BackfillContractCreations starts background update of deployment details
+	// of contracts stored without them. It returns false if the backfill is already running.
+	BackfillContractCreations() bool
+
+	// ImportContract upserts the ABI, name and compiler version of a contract
+	// into the contract store.
+	ImportContract(*common.Address, string, string, string) error
+
+	// ClassifyContract changes the type of the given contract and records
+	// the reason of the classification.
+	ClassifyContract(*common.Address, string, string) (*types.Contract, error)
+
+	// Erc20TransferCount counts the ERC20 shaped transfers emitted by the given contract
+	// up to the given limit.
+	Erc20TransferCount(*common.Address, int) (int, error)
+
+	// DetectContractType identifies the type of the contract by probing the standard
+	// interfaces it implements; the token name is provided, if available.
+	DetectContractType(*common.Address) (string, string)
+
+	// RefreshContract re-reads the on-chain data of the given contract, detects its type again
+	// and drops the cached records derived from it. The changes made are reported.
+	RefreshContract(*common.Address) (*types.ContractRefresh, error)
+
+	// ReanalyzeContractCalls starts background decoding of known calls
+	// of the given contracts against their current ABI.
+	ReanalyzeContractCalls([]common.Address) bool
+
+	// WorkerPools provides the state of the background worker pools of the repository.
+	WorkerPools() []types.WorkerPoolStatus
+
+	// ContractImplementation resolves the implementation address of an EIP-1967 proxy
+	// contract at the given block, or at the latest state for nil block.
+	ContractImplementation(*common.Address, *hexutil.Uint64) (*common.Address, error)
+
+	// StorageAt provides the value of the given storage slot of the contract
+	// at the given block, or at the latest state for nil block.
+	StorageAt(*common.Address, common.Hash, *hexutil.Uint64) (common.Hash, error)
+
 	// SfcVersion returns current version of the SFC contract.
 	SfcVersion() (hexutil.Uint64, error)
 
@@ -115,6 +231,9 @@ type Repository interface {
 	// LastKnownEpoch returns the id of the last known and scanned epoch.
 	LastKnownEpoch() (uint64, error)
 
+	// EpochAtTime returns the id of the epoch in progress at the given time stamp.
+	EpochAtTime(ts hexutil.Uint64) (hexutil.Uint64, error)
+
 	// AddEpoch stores an epoch reference in connected persistent storage.
 	AddEpoch(e *types.Epoch) error
 
@@ -124,6 +243,42 @@ type Repository interface {
 	// CurrentSealedEpoch returns the data of the latest sealed epoch.
 	CurrentSealedEpoch() (*types.Epoch, error)
 
+	// AddEpochSeal stores the seal of an epoch in the persistent storage.
+	AddEpochSeal(*types.EpochSeal) error
+
+	// EpochSeal provides the seal of the given epoch, or the most recent known seal.
+	EpochSeal(*hexutil.Uint64) (*types.EpochSeal, error)
+
+	// BlockEpochSeal provides the seal of the epoch containing the given block, nil if not sealed yet.
+	BlockEpochSeal(hexutil.Uint64) (*types.EpochSeal, error)
+
+	// BlockReward provides the reward attributable to the block of the given number.
+	BlockReward(*hexutil.Uint64) (*types.BlockReward, error)
+
+	// RegisterWebhook registers a new notification webhook for the given address.
+	RegisterWebhook(*common.Address, string) (*types.Webhook, error)
+
+	// RemoveWebhook removes the webhook of the given id; it reports if the webhook was found.
+	RemoveWebhook(string) (bool, error)
+
+	// Webhooks provides the list of webhooks registered for the given address, or all of them.
+	Webhooks(*common.Address) ([]*types.Webhook, error)
+
+	// WebhookDeliveries provides the list of the most recent deliveries of the given webhook.
+	WebhookDeliveries(string, int32) ([]*types.WebhookDelivery, error)
+
+	// QueueWebhookDeliveries queues deliveries of the webhooks watching the recipient of the transaction.
+	QueueWebhookDeliveries(*types.Block, *types.Transaction) error
+
+	// DeliverWebhooks attempts the pending webhook deliveries which are due.
+	DeliverWebhooks() bool
+
+	// PruneStorage removes the data older than their configured retention windows.
+	PruneStorage()
+
+	// ConnectionStatus checks the connections to the blockchain node and to the persistent storage.
+	ConnectionStatus() *types.ConnectionStatus
+
 	// Epochs pulls list of epochs starting at the specified cursor.
 	Epochs(cursor *string, count int32) (*types.EpochList, error)
 
@@ -139,6 +294,13 @@ type Repository interface {
 	// StoreTransaction adds a new incoming transaction from blockchain to the repository.
 	StoreTransaction(*types.Block, *types.Transaction) error
 
+	// RemoveTransaction removes a transaction dropped from the chain by a reorganization.
+	RemoveTransaction(hash *common.Hash) error
+
+	// EvictTransaction removes a transaction from the in-memory cache
+	// so it's loaded from the node on the next request.
+	EvictTransaction(hash *common.Hash)
+
 	// LoadTransaction returns a transaction at Opera blockchain
 	// by a hash loaded directly from the node.
 	LoadTransaction(hash *common.Hash) (*types.Transaction, error)
@@ -147,7 +309,12 @@ type Repository interface {
 	Transaction(*common.Hash, bool) (*types.Transaction, error)
 
 	// Transactions returns list of transaction hashes at Opera blockchain.
-	Transactions(*string, int32) (*types.TransactionList, error)
+	// Only the given optional parts of the transactions are loaded.
+	Transactions(*string, int32, types.TrxFields) (*types.TransactionList, error)
+
+	// BlockTransactions provides a page of transactions of the given block
+	// in the order of their execution.
+	BlockTransactions(*types.Block, *string, int32) (*types.TransactionList, error)
 
 	// TransactionsCount returns total number of transactions in the block chain.
 	TransactionsCount() (uint64, error)
@@ -167,6 +334,31 @@ type Repository interface {
 	// SendTransaction sends raw signed and RLP encoded transaction to the block chain.
 	SendTransaction(hexutil.Bytes) (*types.Transaction, error)
 
+	// SendRawTransaction sends raw signed and RLP encoded transaction to the block chain
+	// and provides its hash. The transaction is recorded as pending right away.
+	SendRawTransaction(hexutil.Bytes) (*common.Hash, error)
+
+	// SendIdempotentTransaction sends raw signed and RLP encoded transaction to the block chain
+	// and provides its hash. Repeated submissions with the same idempotency key
+	// provide the hash of the original transaction without sending it again.
+	SendIdempotentTransaction(hexutil.Bytes, string) (*common.Hash, error)
+
+	// TransactionRevertReason provides the decoded revert reason of the given failed transaction.
+	TransactionRevertReason(*types.Transaction) (*types.RevertReason, error)
+
+	// TransactionCall decodes the contract call of the given transaction using the ABI of the target contract.
+	TransactionCall(*types.Transaction) (*types.DecodedCall, error)
+
+	// DecodeTransactionInput decodes the input data of the given transaction using the caller supplied ABI.
+	DecodeTransactionInput(*common.Hash, string) (*types.DecodedCall, error)
+
+	// SimulateCall executes the given contract call with the state override applied
+	// and decodes the result using the contract ABI, if available.
+	SimulateCall(*types.SimulationCall) (*types.SimulatedCall, error)
+
+	// InternalTransactions provides the list of internal calls executed by the given transaction.
+	InternalTransactions(*common.Hash) ([]*types.InternalTransaction, error)
+
 	// LastValidatorId returns the last validator id in Opera blockchain.
 	LastValidatorId() (uint64, error)
 
@@ -194,6 +386,15 @@ type Repository interface {
 	// SfcMaxDelegatedRatio extracts a ratio between self delegation and received stake.
 	SfcMaxDelegatedRatio() (*big.Int, error)
 
+	// ValidatorCommission provides the validator commission rate of the SFC contract.
+	ValidatorCommission() (*types.ValidatorCommission, error)
+
+	// StakingApr provides an estimate of the annual yield of the stake delegated to the given validator.
+	StakingApr(*hexutil.Big) (*types.StakingApr, error)
+
+	// StakingParams provides the staking protocol parameters currently enforced by the SFC contract.
+	StakingParams() (*types.StakingParams, error)
+
 	// UpdateValidatorInfo extracts extended validator information.
 	UpdateValidatorInfo(*hexutil.Big) (*types.ValidatorInfo, error)
 
@@ -228,6 +429,12 @@ type Repository interface {
 	// DelegationsOfValidator extracts a list of delegations for a validator by its ID.
 	DelegationsOfValidator(*hexutil.Big, *string, int32) (*types.DelegationList, error)
 
+	// DelegationsOfValidatorByAmount extracts a list of delegations for a validator sorted by the amount.
+	DelegationsOfValidatorByAmount(*hexutil.Big, *string, int32) (*types.DelegationList, error)
+
+	// DelegationsSummaryOfValidator provides the number and total amount of active delegations of a validator.
+	DelegationsSummaryOfValidator(*hexutil.Big) (*types.DelegationSummary, error)
+
 	// DelegationLock returns delegation lock information using SFC contract binding.
 	DelegationLock(*common.Address, *hexutil.Big) (*types.DelegationLock, error)
 
@@ -286,6 +493,10 @@ type Repository interface {
 	// GasPriceExtended provides extended gas price information.
 	GasPriceExtended() (*types.GasPrice, error)
 
+	// GasPriceTiers provides the suggested gas price along with low, average
+	// and high tiers derived from gas prices paid in recent blocks.
+	GasPriceTiers() (*types.GasPriceTiers, error)
+
 	// StoreGasPricePeriod stores gas price period data into the persistent storage.
 	StoreGasPricePeriod(*types.GasPricePeriod) error
 
@@ -312,6 +523,7 @@ type Repository interface {
 	DefiTokenPrice(*common.Address) (hexutil.Big, error)
 
 	// FMintAccount loads details of a DeFi/fMint account identified by the owner address.
+	// Accounts without fMint position are resolved as an empty zeroed account.
 	FMintAccount(common.Address) (*types.FMintAccount, error)
 
 	// FMintTokenBalance loads balance of a single DeFi token by it's address.
@@ -357,6 +569,7 @@ type Repository interface {
 	UniswapKnownPairs() ([]common.Address, error)
 
 	// UniswapPair returns an address of an Uniswap pair for the given tokens.
+	// ErrUniswapPairNotFound is returned if the pair does not exist.
 	UniswapPair(*common.Address, *common.Address) (*common.Address, error)
 
 	// UniswapAmountsOut resolves a list of output amounts for the given
@@ -371,12 +584,28 @@ type Repository interface {
 	// self reserves of the analyzed token.
 	UniswapQuoteInput(amountIn hexutil.Big, reserveMy hexutil.Big, reserveSibling hexutil.Big) (hexutil.Big, error)
 
+	// SampleTokenPrices samples the current prices of the configured price pairs
+	// and stores them in the persistent storage.
+	SampleTokenPrices()
+
+	// TokenPriceHistory provides the price history of a token sampled from the given,
+	// or the configured, price pair aggregated into ticks of the given interval.
+	TokenPriceHistory(token *common.Address, pair *common.Address, from time.Time, to time.Time, interval time.Duration) (*types.TokenPriceHistory, error)
+
+	// UniswapTokenPrice derives the price of a token against another token
+	// from Uniswap pair reserves, routing through configured base tokens if needed.
+	UniswapTokenPrice(*common.Address, *common.Address) (*types.UniswapTokenPrice, error)
+
 	// UniswapTokens returns list of addresses of tokens involved in an Uniswap pair.
 	UniswapTokens(*common.Address) ([]common.Address, error)
 
 	// UniswapReserves returns list of token reserve amounts in a Uniswap pair.
 	UniswapReserves(*common.Address) ([]hexutil.Big, error)
 
+	// UniswapAdjustedReserves returns list of tokens involved in a Uniswap pair
+	// and their reserves adjusted by the tokens decimals.
+	UniswapAdjustedReserves(*common.Address) ([]common.Address, []*big.Float, error)
+
 	// UniswapReservesTimeStamp returns the timestamp of the reserves of a Uniswap pair.
 	UniswapReservesTimeStamp(*common.Address) (hexutil.Uint64, error)
 
@@ -413,8 +642,9 @@ type Repository interface {
 	// UniswapTimeReserves returns grouped reserves for specified pair, time and resolution
 	UniswapTimeReserves(*common.Address, string, int64, int64) ([]types.DefiTimeReserve, error)
 
-	// UniswapActions provides list of uniswap actions stored in the persistent db.
-	UniswapActions(*common.Address, *string, int32, int32) (*types.UniswapActionList, error)
+	// UniswapActions provides list of uniswap actions stored in the persistent db
+	// optionally filtered by the pair, the sender account and the action type.
+	UniswapActions(*common.Address, *common.Address, *string, int32, int32) (*types.UniswapActionList, error)
 
 	// NativeTokenAddress returns address of the native token wrapper, if available.
 	NativeTokenAddress() (*common.Address, error)
@@ -422,6 +652,10 @@ type Repository interface {
 	// TokenTransactions provides list of ERC20/ERC721/ERC1155 transactions based on given filters.
 	TokenTransactions(tokenType string, token *common.Address, tokenId *big.Int, acc *common.Address, txType *int32, cursor *string, count int32) (*types.TokenTransactionList, error)
 
+	// AccountTokenTransfers provides list of token transfers of the given account,
+	// optionally limited to a token and to a range of block time stamps.
+	AccountTokenTransfers(acc *common.Address, token *common.Address, from *uint64, to *uint64, cursor *string, count int32) (*types.TokenTransactionList, error)
+
 	// TokenTransactionsByCall provides a list of token transaction made inside a specific
 	// transaction call (blockchain transaction).
 	TokenTransactionsByCall(*common.Hash) ([]*types.TokenTransaction, error)
@@ -435,10 +669,28 @@ type Repository interface {
 	// Erc20Assets provides list of ERC20 tokens involved with the given owner.
 	Erc20Assets(common.Address, int32) ([]common.Address, error)
 
+	// TokenRanking provides ERC20 tokens ranked by their transfers made over the given period.
+	TokenRanking(time.Duration, string) (*types.TokenRanking, error)
+
+	// TokenApprovals provides the outstanding ERC20 allowances granted by the given owner.
+	TokenApprovals(*common.Address, *common.Address) ([]*types.TokenTransaction, error)
+
+	// AccountPortfolio provides native and ERC20 token balances of an account
+	// valued in the given quote token.
+	AccountPortfolio(*common.Address, *common.Address) (*types.Portfolio, error)
+
 	// Erc20BalanceOf load the current available balance of and ERC20 token identified by the token
 	// contract address for an identified owner address.
 	Erc20BalanceOf(*common.Address, *common.Address) (hexutil.Big, error)
 
+	// Erc20BalanceOfAt loads the balance of an ERC20 token for the given owner
+	// at the block identified by the given tag.
+	Erc20BalanceOfAt(*common.Address, *common.Address, types.BlockTag) (hexutil.Big, error)
+
+	// Erc20BalancesOf returns the current balances of the given ERC20 tokens of the owner.
+	// Balances of tokens failing to respond are left nil.
+	Erc20BalancesOf(*common.Address, []common.Address) ([]*hexutil.Big, error)
+
 	// Erc20Allowance loads the current amount of ERC20 tokens unlocked for DeFi
 	// contract by the token owner.
 	Erc20Allowance(*common.Address, *common.Address, *common.Address) (hexutil.Big, error)
@@ -458,6 +710,10 @@ type Repository interface {
 	// Erc20LogoURL provides URL address of a logo of the ERC20 token.
 	Erc20LogoURL(*common.Address) string
 
+	// RefreshTokenList loads the configured remote token list used to complete details
+	// and logos of ERC20 tokens. The previous list stays active if the new one can not be loaded.
+	RefreshTokenList() error
+
 	// StoreTokenTransaction stores ERC20/ERC721/ERC1155 transaction into the repository.
 	StoreTokenTransaction(*types.TokenTransaction) error
 
@@ -575,9 +831,66 @@ type Repository interface {
 	// TrxFlowUpdate executes the trx flow update in the database.
 	TrxFlowUpdate()
 
+	// TrxStats provides the transaction statistics of the given granularity in the given time range.
+	// The database is not queried past the deadline of the given context.
+	TrxStats(ctx context.Context, from time.Time, to time.Time, gran string) ([]*types.TrxStats, error)
+
+	// TrxStatsUpdate precomputes the transaction statistics of closed time buckets.
+	TrxStatsUpdate()
+
+	// RichList provides a list of accounts sorted by their native balance.
+	RichList(*string, int32) (*types.RichList, error)
+
+	// RichListUpdate refreshes the ranking of accounts active since the given time stamp
+	// by their native balance and returns the time stamp the next update continues from.
+	RichListUpdate(context.Context, uint64) (uint64, error)
+
+	// SearchTransactions finds hashes of transactions starting with the given hex prefix.
+	SearchTransactions(string, int64) ([]common.Hash, error)
+
+	// SearchAccounts finds accounts with the address starting with the given hex prefix.
+	SearchAccounts(string, int64) ([]*types.Account, error)
+
+	// SearchContracts finds addresses of contracts with the name starting with the given text.
+	SearchContracts(string, int64) ([]common.Address, error)
+
 	// TrxFlowSpeed provides speed of transaction per second for the last <sec> seconds.
 	TrxFlowSpeed(sec int32) (float64, error)
 
 	// Close and cleanup the repository.
 	Close()
+
+	// CachedResult provides the cached result of a resolver stored under the given key, if available.
+	CachedResult(string) []byte
+
+	// CacheResult stores the result of a resolver under the given key for the given time.
+	CacheResult(string, []byte, time.Duration)
+
+	// IsPendingPoolEnabled signals if the pending transactions pool of the node is observed.
+	IsPendingPoolEnabled() bool
+
+	// ObservedPendingProxy provides a channel fed with hashes of new pending transactions
+	// observed on the connected node; nil if the pending pool is not observed.
+	ObservedPendingProxy() chan common.Hash
+
+	// AddPendingTransaction loads the pending transaction from the node and adds it to the pool.
+	AddPendingTransaction(*common.Hash) (*types.Transaction, error)
+
+	// DropPendingTransaction removes the transaction from the pool of pending transactions.
+	DropPendingTransaction(*common.Hash)
+
+	// PrunePendingTransactions removes pending transactions not mined within the configured timeout.
+	PrunePendingTransactions() int
+
+	// PendingTransactions provides the list of pending transactions of the given address.
+	PendingTransactions(*common.Address) ([]*types.Transaction, error)
+
+	// IsNameServiceEnabled signals if the name service registry is configured.
+	IsNameServiceEnabled() bool
+
+	// Name provides the name of the given address using the reverse resolution of the name service.
+	Name(*common.Address) (*string, error)
+
+	// ResolveName provides the address the given name resolves to using the name service.
+	ResolveName(string) (*common.Address, error)
 }
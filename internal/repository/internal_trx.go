@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"fantom-api-graphql/internal/repository/rpc"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ErrTraceNotSupported represents an error returned if the connected node
+// does not provide the transaction tracing API.
+var ErrTraceNotSupported = rpc.ErrTraceNotSupported
+
+// InternalTransactions provides the list of internal calls executed
+// by the given transaction. The call trace is expensive to get, so it's loaded
+// only on demand and the result is kept in the in-memory cache.
+func (p *proxy) InternalTransactions(hash *common.Hash) ([]*types.InternalTransaction, error) {
+	// try to use the in-memory cache
+	if list := p.cache.PullInternalTransactions(hash); list != nil {
+		p.log.Debugf("internal transactions of %s loaded from cache", hash.String())
+		return list, nil
+	}
+
+	list, err := p.rpc.InternalTransactions(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	// the trace reveals contracts destroyed by the transaction
+	p.markTracedSelfDestructs(hash, list)
+
+	p.cache.PushInternalTransactions(hash, list)
+	return list, nil
+}
@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"fantom-api-graphql/internal/repository/rpc"
+	"fantom-api-graphql/internal/repository/rpc/contracts"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"math/big"
+	"strings"
+)
+
+// multicallMaxCalls is the max number of calls aggregated into a single multicall.
+const multicallMaxCalls = 100
+
+// multicallAbi and erc20Abi are the parsed ABIs used to pack aggregated calls.
+var (
+	multicallAbi, _ = abi.JSON(strings.NewReader(rpc.MulticallAbi))
+	erc20Abi, _     = abi.JSON(strings.NewReader(contracts.ERCTwentyMetaData.ABI))
+)
+
+// aggregateCalls executes the given read-only calls in as few RPC round-trips as possible.
+// The configured Multicall contract aggregates the calls; if it's not configured,
+// or fails, the calls are sent as individual calls in a single batch.
+func (p *proxy) aggregateCalls(calls []types.ContractCall) ([]types.ContractCallResult, error) {
+	if len(calls) == 0 {
+		return []types.ContractCallResult{}, nil
+	}
+	if !p.hasMulticall() {
+		return p.rpc.ContractCalls(calls)
+	}
+
+	res := make([]types.ContractCallResult, 0, len(calls))
+	for from := 0; from < len(calls); from += multicallMaxCalls {
+		to := from + multicallMaxCalls
+		if to > len(calls) {
+			to = len(calls)
+		}
+
+		part, err := p.rpc.Multicall(&p.cfg.Repository.Multicall, calls[from:to])
+		if err != nil {
+			p.log.Warningf("multicall failed, using individual calls; %s", err.Error())
+			part, err = p.rpc.ContractCalls(calls[from:to])
+			if err != nil {
+				return nil, err
+			}
+		}
+		res = append(res, part...)
+	}
+	return res, nil
+}
+
+// hasMulticall checks if the Multicall contract is configured.
+func (p *proxy) hasMulticall() bool {
+	return p.cfg.Repository.Multicall != (common.Address{})
+}
+
+// AccountBalances returns the current balances of the given accounts at Opera blockchain.
+// Balances of accounts failing to load are left nil.
+func (p *proxy) AccountBalances(addr []common.Address) ([]*hexutil.Big, error) {
+	if !p.hasMulticall() {
+		return p.rpc.AccountBalances(addr)
+	}
+
+	calls := make([]types.ContractCall, len(addr))
+	for i := range addr {
+		data, err := multicallAbi.Pack("getEthBalance", addr[i])
+		if err != nil {
+			return nil, err
+		}
+		calls[i] = types.ContractCall{Target: p.cfg.Repository.Multicall, Data: data}
+	}
+	return p.aggregatedAmounts(calls)
+}
+
+// Erc20BalancesOf returns the current balances of the given ERC20 tokens of the owner.
+// Balances of tokens failing to respond are left nil.
+func (p *proxy) Erc20BalancesOf(owner *common.Address, tokens []common.Address) ([]*hexutil.Big, error) {
+	data, err := erc20Abi.Pack("balanceOf", *owner)
+	if err != nil {
+		return nil, err
+	}
+
+	calls := make([]types.ContractCall, len(tokens))
+	for i := range tokens {
+		calls[i] = types.ContractCall{Target: tokens[i], Data: data}
+	}
+	return p.aggregatedAmounts(calls)
+}
+
+// aggregatedAmounts executes the given calls returning a single uint256 value
+// and decodes the values. Values of failed calls are left nil.
+func (p *proxy) aggregatedAmounts(calls []types.ContractCall) ([]*hexutil.Big, error) {
+	res, err := p.aggregateCalls(calls)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*hexutil.Big, len(res))
+	for i := range res {
+		if !res[i].Success || len(res[i].Data) != common.HashLength {
+			continue
+		}
+		list[i] = (*hexutil.Big)(new(big.Int).SetBytes(res[i].Data))
+	}
+	return list, nil
+}
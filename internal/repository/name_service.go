@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// IsNameServiceEnabled signals if the name service registry is configured.
+func (p *proxy) IsNameServiceEnabled() bool {
+	return p.cfg.NameService.Registry != (common.Address{})
+}
+
+// Name provides the name of the given address using the reverse resolution
+// of the configured name service. The reverse record is accepted only if the name
+// resolves back to the address. Nil is returned if the address does not have a valid name.
+func (p *proxy) Name(addr *common.Address) (*string, error) {
+	if !p.IsNameServiceEnabled() {
+		return nil, fmt.Errorf("name service not available")
+	}
+
+	// try the cache first
+	if name, ok := p.cache.PullName(addr); ok {
+		if name == "" {
+			return nil, nil
+		}
+		return &name, nil
+	}
+
+	name, err := p.rpc.NameServiceName(&p.cfg.NameService.Registry, addr)
+	if err != nil {
+		// failed calls are cached briefly as missing to avoid repeating them
+		p.log.Debugf("reverse resolution of %s failed; %s", addr.String(), err.Error())
+		name = ""
+	}
+
+	// make sure the reverse record is not spoofed
+	if name != "" {
+		fwd, err := p.ResolveName(name)
+		if err != nil || fwd == nil || *fwd != *addr {
+			name = ""
+		}
+	}
+
+	if err := p.cache.PushName(addr, name); err != nil {
+		p.log.Errorf("can not cache name of %s; %s", addr.String(), err.Error())
+	}
+	if name == "" {
+		return nil, nil
+	}
+	return &name, nil
+}
+
+// ResolveName provides the address the given name resolves to using the configured name service.
+// Nil is returned if the name does not resolve to an address.
+func (p *proxy) ResolveName(name string) (*common.Address, error) {
+	if !p.IsNameServiceEnabled() {
+		return nil, fmt.Errorf("name service not available")
+	}
+
+	// try the cache first
+	if adr, ok := p.cache.PullNameAddress(name); ok {
+		return adr, nil
+	}
+
+	adr, err := p.rpc.NameServiceAddress(&p.cfg.NameService.Registry, name)
+	if err != nil {
+		// failed calls are cached briefly as missing to avoid repeating them
+		p.log.Debugf("resolution of name %s failed; %s", name, err.Error())
+		adr = nil
+	}
+
+	if err := p.cache.PushNameAddress(name, adr); err != nil {
+		p.log.Errorf("can not cache address of name %s; %s", name, err.Error())
+	}
+	return adr, nil
+}
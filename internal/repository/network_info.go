@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+)
+
+// NetworkInfo provides the metadata of the connected node and its network.
+func (p *proxy) NetworkInfo(ctx context.Context) (*types.NetworkInfo, error) {
+	// try the cache first
+	if ni := p.cache.PullNetworkInfo(); ni != nil {
+		return ni, nil
+	}
+
+	ni, err := p.rpc.NetworkInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.cache.PushNetworkInfo(ni); err != nil {
+		p.log.Errorf("can not cache network info; %s", err.Error())
+	}
+	return ni, nil
+}
@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"sort"
+)
+
+const (
+	// nonceIndexWindow is the number of the most recent mined nonces
+	// checked against the stored transactions.
+	nonceIndexWindow = 256
+
+	// nonceMaxGaps is the max number of missing pending nonces reported.
+	nonceMaxGaps = 256
+)
+
+// AccountNonceStatus provides the state of the transaction nonces of the given sender account.
+// The on-chain nonces come from the node, the mined nonces from the stored transactions
+// and the pending nonces from the pending transactions pool, if enabled.
+func (p *proxy) AccountNonceStatus(addr *common.Address) (*types.NonceStatus, error) {
+	nonce, err := p.rpc.AccountNonce(addr)
+	if err != nil {
+		return nil, err
+	}
+	pn, err := p.rpc.AccountPendingNonce(addr)
+	if err != nil {
+		return nil, err
+	}
+	ns := types.NonceStatus{Address: *addr, Nonce: *nonce, PendingNonce: *pn}
+
+	// the stored transactions of the recent mined nonces
+	mined, err := p.db.SentTransactionNonces(addr, nonceIndexWindow)
+	if err != nil {
+		return nil, err
+	}
+	ns.HighestMined, ns.Unindexed = unindexedNonces(uint64(*nonce), mined)
+
+	// pending transactions observed by the API server
+	var pending []*types.Transaction
+	if p.IsPendingPoolEnabled() {
+		pending, err = p.PendingTransactions(addr)
+		if err != nil {
+			return nil, err
+		}
+	}
+	ns.Pending, ns.Gaps = pendingNonceGaps(addr, uint64(*nonce), uint64(*pn), pending)
+	return &ns, nil
+}
+
+// unindexedNonces finds the highest stored mined nonce and the recent mined nonces
+// below the on-chain nonce without a stored transaction.
+func unindexedNonces(nonce uint64, mined []uint64) (*hexutil.Uint64, []hexutil.Uint64) {
+	var highest *hexutil.Uint64
+	seen := make(map[uint64]bool, len(mined))
+	for _, n := range mined {
+		seen[n] = true
+		if highest == nil || uint64(*highest) < n {
+			val := hexutil.Uint64(n)
+			highest = &val
+		}
+	}
+
+	var from uint64
+	if nonce > nonceIndexWindow {
+		from = nonce - nonceIndexWindow
+	}
+
+	missing := make([]hexutil.Uint64, 0)
+	for n := from; n < nonce; n++ {
+		if !seen[n] {
+			missing = append(missing, hexutil.Uint64(n))
+		}
+	}
+	return highest, missing
+}
+
+// pendingNonceGaps collects the nonces of pending transactions sent by the account
+// and the missing nonces between the on-chain nonce and the highest pending nonce.
+// Nonces executable by the node, i.e. below its pending nonce, are never missing.
+func pendingNonceGaps(addr *common.Address, nonce uint64, pn uint64, pending []*types.Transaction) ([]hexutil.Uint64, []hexutil.Uint64) {
+	seen := make(map[uint64]bool)
+	list := make([]hexutil.Uint64, 0)
+	top := pn
+	for _, trx := range pending {
+		n := uint64(trx.Nonce)
+		if trx.From != *addr || n < nonce || seen[n] {
+			continue
+		}
+
+		seen[n] = true
+		list = append(list, hexutil.Uint64(n))
+		if n >= top {
+			top = n + 1
+		}
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i] < list[j] })
+
+	gaps := make([]hexutil.Uint64, 0)
+	for n := pn; n < top && len(gaps) < nonceMaxGaps; n++ {
+		if !seen[n] {
+			gaps = append(gaps, hexutil.Uint64(n))
+		}
+	}
+	return list, gaps
+}
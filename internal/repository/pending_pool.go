@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"sort"
+	"sync"
+	"time"
+)
+
+// pendingPool represents the in-memory pool of pending transactions
+// observed on the connected node.
+type pendingPool struct {
+	mu   sync.RWMutex
+	list map[common.Hash]*pendingTrx
+}
+
+// pendingTrx represents a pending transaction in the pool.
+type pendingTrx struct {
+	trx  *types.Transaction
+	seen time.Time
+}
+
+// newPendingPool creates a new empty pool of pending transactions.
+func newPendingPool() *pendingPool {
+	return &pendingPool{list: make(map[common.Hash]*pendingTrx)}
+}
+
+// IsPendingPoolEnabled signals if the pending transactions pool of the node is observed.
+func (p *proxy) IsPendingPoolEnabled() bool {
+	return p.cfg.Opera.PendingPool
+}
+
+// ObservedPendingProxy provides a channel fed with hashes of new pending transactions
+// observed on the connected node; nil if the pending pool is not observed.
+func (p *proxy) ObservedPendingProxy() chan common.Hash {
+	return p.rpc.ObservedPendingProxy()
+}
+
+// AddPendingTransaction loads the pending transaction of the given hash from the node
+// and adds it to the pool. It returns nil if the transaction is already mined, or not known.
+func (p *proxy) AddPendingTransaction(hash *common.Hash) (*types.Transaction, error) {
+	trx, err := p.rpc.Transaction(hash)
+	if err != nil {
+		p.log.Debugf("pending transaction %s not available; %s", hash.String(), err.Error())
+		return nil, err
+	}
+
+	// the node may have dropped the transaction already, or it's mined
+	if trx == nil || trx.Hash != *hash || trx.BlockNumber != nil {
+		return nil, nil
+	}
+
+	p.pending.mu.Lock()
+	p.pending.list[*hash] = &pendingTrx{trx: trx, seen: time.Now()}
+	p.pending.mu.Unlock()
+	return trx, nil
+}
+
+// DropPendingTransaction removes the transaction from the pool of pending transactions,
+// e.g. when the transaction is mined.
+func (p *proxy) DropPendingTransaction(hash *common.Hash) {
+	p.pending.mu.Lock()
+	delete(p.pending.list, *hash)
+	p.pending.mu.Unlock()
+}
+
+// PrunePendingTransactions removes pending transactions not mined within the configured
+// timeout from the pool. It returns the number of transactions removed.
+func (p *proxy) PrunePendingTransactions() int {
+	limit := time.Now().Add(-time.Duration(p.cfg.Opera.PendingTimeout) * time.Second)
+
+	p.pending.mu.Lock()
+	defer p.pending.mu.Unlock()
+
+	var count int
+	for h, pt := range p.pending.list {
+		if pt.seen.Before(limit) {
+			delete(p.pending.list, h)
+			count++
+		}
+	}
+	return count
+}
+
+// PendingTransactions provides the list of pending transactions of the given address,
+// either sent or received, or all pending transactions if the address is not given.
+// The transactions are sorted by the time they were observed, the newest first.
+func (p *proxy) PendingTransactions(addr *common.Address) ([]*types.Transaction, error) {
+	if !p.IsPendingPoolEnabled() {
+		return nil, fmt.Errorf("pending transactions pool not available")
+	}
+
+	p.pending.mu.RLock()
+	list := make([]*pendingTrx, 0)
+	for _, pt := range p.pending.list {
+		if addr == nil || pt.trx.From == *addr || (pt.trx.To != nil && *pt.trx.To == *addr) {
+			list = append(list, pt)
+		}
+	}
+	p.pending.mu.RUnlock()
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].seen.After(list[j].seen)
+	})
+
+	res := make([]*types.Transaction, len(list))
+	for i, pt := range list {
+		res[i] = pt.trx
+	}
+	return res, nil
+}
@@ -0,0 +1,98 @@
+/*
+Package repository implements repository for handling fast and efficient access to data required
+by the resolvers of the API server.
+
+Internally it utilizes RPC to access Opera/Lachesis full node for blockchain interaction. Mongo database
+for fast, robust and scalable off-chain data storage, especially for aggregated and pre-calculated data mining
+results. BigCache for in-memory object storage to speed up loading of frequently accessed entities.
+*/
+package repository
+
+import (
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"math/big"
+)
+
+const (
+	// portfolioMaxTokens is the max number of ERC20 tokens included in an account portfolio.
+	portfolioMaxTokens = 100
+
+	// portfolioNativeSymbol is the symbol of the native token in the portfolio.
+	portfolioNativeSymbol = "FTM"
+
+	// portfolioNativeDecimals is the number of decimals of the native token.
+	portfolioNativeDecimals = 18
+)
+
+// AccountPortfolio provides the native balance and ERC20 token balances of the account
+// valued in the given quote token. The configured Uniswap quote token is used if the quote
+// token is not provided. Assets without a known price are included without a value.
+func (p *proxy) AccountPortfolio(addr *common.Address, quote *common.Address) (*types.Portfolio, error) {
+	if quote == nil {
+		quote = &p.cfg.DeFi.Uniswap.QuoteToken
+	}
+	pf := types.Portfolio{Address: *addr, Quote: *quote, Tokens: make([]types.PortfolioAsset, 0), IsComplete: true}
+
+	// the native balance is priced by the native token wrapper
+	bal, err := p.AccountBalance(addr)
+	if err != nil {
+		return nil, err
+	}
+	pf.Native = types.PortfolioAsset{Symbol: portfolioNativeSymbol, Decimals: portfolioNativeDecimals, Balance: *bal}
+	if wrapper, err := p.NativeTokenAddress(); err == nil && wrapper != nil {
+		p.valuePortfolioAsset(&pf, &pf.Native, wrapper)
+	} else {
+		pf.IsComplete = false
+	}
+
+	// collect ERC20 tokens the account is involved with
+	assets, err := p.Erc20Assets(*addr, portfolioMaxTokens)
+	if err != nil {
+		return nil, err
+	}
+
+	// the balances are loaded in a single aggregated round-trip
+	balances, err := p.Erc20BalancesOf(addr, assets)
+	if err != nil {
+		return nil, err
+	}
+	for i := range assets {
+		if balances[i] == nil || balances[i].ToInt().Sign() == 0 {
+			continue
+		}
+		bal := *balances[i]
+
+		token, err := p.Erc20Token(&assets[i])
+		if err != nil {
+			continue
+		}
+
+		pa := types.PortfolioAsset{TokenAddress: &assets[i], Symbol: token.Symbol, Decimals: token.Decimals, Balance: bal}
+		p.valuePortfolioAsset(&pf, &pa, &assets[i])
+		pf.Tokens = append(pf.Tokens, pa)
+	}
+	return &pf, nil
+}
+
+// valuePortfolioAsset calculates the value of the portfolio asset priced by the given token
+// and adds it to the portfolio total.
+func (p *proxy) valuePortfolioAsset(pf *types.Portfolio, pa *types.PortfolioAsset, token *common.Address) {
+	pri, err := p.UniswapTokenPrice(token, &pf.Quote)
+	if err != nil || pri == nil {
+		pf.IsComplete = false
+		return
+	}
+
+	// value = balance / 10^decimals * price
+	amo := new(big.Float).Quo(
+		new(big.Float).SetInt(pa.Balance.ToInt()),
+		new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(pa.Decimals)), nil)),
+	)
+	val, _ := new(big.Float).Mul(amo, big.NewFloat(pri.Price)).Float64()
+
+	pa.Price = &pri.Price
+	pa.Value = &val
+	pa.IsLowConfidence = pri.IsLowConfidence
+	pf.TotalValue += val
+}
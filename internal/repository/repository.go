@@ -64,7 +64,7 @@ func R() Repository {
 type proxy struct {
 	cache *cache.MemBridge
 	db    *db.MongoDbBridge
-	rpc   *rpc.FtmBridge
+	rpc   rpc.Bridge
 	log   logger.Logger
 	cfg   *config.Config
 
@@ -79,6 +79,25 @@ type proxy struct {
 
 	// smart contract compilers
 	solCompiler string
+
+	// pool of pending transactions observed on the node
+	pending *pendingPool
+
+	// contracts the remote ABI source recently failed to provide
+	abiMisses *abiSourceMisses
+
+	// set of batch call selectors decoded into inner calls
+	batchSelectors map[string]bool
+
+	// registry of addresses watched by the notification webhooks
+	webhooks *webhookRegistry
+
+	// end of the last processed transaction statistics chunk by granularity
+	trxStatsDone sync.Map
+
+	// last errors of the connection checks
+	rpcErrors types.ErrorTracker
+	dbErrors  types.ErrorTracker
 }
 
 // newRepository creates new instance of Repository implementation, namely proxy structure.
@@ -110,6 +129,18 @@ func newRepository() Repository {
 
 		// keep reference to the SOL compiler
 		solCompiler: cfg.Compiler.DefaultSolCompilerPath,
+
+		// pending transactions pool
+		pending: newPendingPool(),
+
+		// remote ABI source misses
+		abiMisses: newAbiSourceMisses(abiSourceMissTTL, abiSourceMissCapacity),
+
+		// batch calls recognized by the call decoder
+		batchSelectors: batchSelectorsMap(&cfg.Repository, log),
+
+		// notification webhooks
+		webhooks: newWebhookRegistry(),
 	}
 
 	registerSystemContracts(&p)
@@ -132,7 +163,7 @@ func governanceContractsMap(cfg *config.Governance) map[string]*config.Governanc
 }
 
 // connect opens connections to the external sources we need.
-func connect(cfg *config.Config, log logger.Logger) (*cache.MemBridge, *db.MongoDbBridge, *rpc.FtmBridge, error) {
+func connect(cfg *config.Config, log logger.Logger) (*cache.MemBridge, *db.MongoDbBridge, rpc.Bridge, error) {
 	// create new in-memory cache bridge
 	caBridge, err := cache.New(cfg, log)
 	if err != nil {
@@ -217,6 +248,7 @@ func (p *proxy) Close() {
 	// close connections
 	p.db.Close()
 	p.rpc.Close()
+	p.cache.Close()
 
 	// inform about actions
 	p.log.Notice("repository done")
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"time"
+)
+
+// CachedResult provides the cached result of a resolver stored under the given key, if available.
+func (p *proxy) CachedResult(key string) []byte {
+	return p.cache.PullResult(key)
+}
+
+// CacheResult stores the result of a resolver under the given key for the given time.
+func (p *proxy) CacheResult(key string, data []byte, ttl time.Duration) {
+	if err := p.cache.PushResult(key, data, ttl); err != nil {
+		p.log.Errorf("can not cache resolver result %s; %s", key, err.Error())
+	}
+}
@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"time"
+)
+
+// retentionMinWindow is the shortest retention window applied by the sweeper.
+// Data younger than this are never pruned, so paged queries running over the recent data
+// are not cut off by the sweeper in the middle of their traversal.
+const retentionMinWindow = time.Hour
+
+// PruneStorage removes the data older than their configured retention windows
+// from the persistent storage.
+func (p *proxy) PruneStorage() {
+	now := time.Now().UTC()
+
+	p.pruneAged("transaction logs", p.cfg.Retention.Logs, now, p.db.PruneTransactionLogs)
+	p.pruneAged("gas price periods", p.cfg.Retention.GasPrice, now, p.db.PruneGasPricePeriods)
+	p.pruneAged("webhook deliveries", p.cfg.Retention.WebhookDeliveries, now, p.db.PruneWebhookDeliveries)
+	p.pruneAged("token price samples", p.cfg.Retention.TokenPrices, now, p.db.PruneTokenPriceSamples)
+}
+
+// pruneAged removes the data older than the given retention window using the given pruning function.
+func (p *proxy) pruneAged(name string, window time.Duration, now time.Time, prune func(time.Time) (int64, error)) {
+	// zero window keeps the data forever
+	if window <= 0 {
+		return
+	}
+	if window < retentionMinWindow {
+		p.log.Warningf("retention window %s of %s is too short, using %s", window, name, retentionMinWindow)
+		window = retentionMinWindow
+	}
+
+	before := now.Add(-window)
+	count, err := prune(before)
+	if err != nil {
+		p.log.Errorf("can not prune %s older than %s; %s", name, before.Format(time.RFC3339), err.Error())
+		return
+	}
+	if count > 0 {
+		p.log.Noticef("pruned %d %s older than %s", count, name, before.Format(time.RFC3339))
+	}
+}
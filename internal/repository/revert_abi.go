@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"bytes"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"strings"
+)
+
+// customErrorSelectorLength is the length of the custom error selector in the revert data.
+const customErrorSelectorLength = 4
+
+// decodeCustomError tries to decode the revert data of the given failed transaction
+// as a custom error of the target contract. The error is matched against the contract ABI,
+// or the implementation ABI for proxy contracts, if the contract has been validated;
+// only the raw selector is provided otherwise.
+// It returns true if the revert reason has been updated.
+func (p *proxy) decodeCustomError(trx *types.Transaction, rr *types.RevertReason) bool {
+	// standard errors are decoded already; custom errors need at least the selector
+	if rr.Reason != "" || len(rr.Data) < customErrorSelectorLength || (rr.Error != nil && rr.Error.Name != "") {
+		return false
+	}
+
+	// the raw selector is the fallback
+	ce := types.CustomError{Selector: rr.Data[:customErrorSelectorLength], Args: make([]types.CustomErrorArg, 0)}
+	if trx.To != nil {
+		abiDef, err := p.decodingAbi(trx.To, trx.BlockNumber)
+		if err != nil {
+			p.log.Debugf("can not decode custom error of %s; %s", trx.Hash.String(), err.Error())
+		}
+		if abiDef != "" {
+			matchCustomError(abiDef, rr.Data, &ce)
+		}
+	}
+
+	// nothing new?
+	if ce.Name == "" && rr.Error != nil {
+		return false
+	}
+
+	rr.Error = &ce
+	if ce.Name != "" {
+		rr.Reason = formatCustomError(&ce)
+	}
+	return true
+}
+
+// matchCustomError matches the revert data against custom errors of the given contract ABI
+// and decodes the error name and arguments, if a matching error is found.
+func matchCustomError(abiDef string, data []byte, ce *types.CustomError) {
+	ab, err := cachedContractAbi(abiDef)
+	if err != nil {
+		return
+	}
+
+	for _, e := range ab.Errors {
+		if !bytes.Equal(e.ID[:customErrorSelectorLength], data[:customErrorSelectorLength]) {
+			continue
+		}
+
+		ce.Name = e.Name
+		ce.Signature = e.Sig
+		values, err := e.Inputs.Unpack(data[customErrorSelectorLength:])
+		if err != nil {
+			return
+		}
+
+		for i, in := range e.Inputs {
+			ce.Args = append(ce.Args, types.CustomErrorArg{
+				Name:  in.Name,
+				Type:  abiTypeString(in.Type),
+				Value: abiValueString(in.Type, values[i]),
+			})
+		}
+		return
+	}
+}
+
+// formatCustomError builds the human readable representation of the decoded custom error.
+func formatCustomError(ce *types.CustomError) string {
+	args := make([]string, len(ce.Args))
+	for i, a := range ce.Args {
+		args[i] = a.Value
+	}
+	return fmt.Sprintf("%s(%s)", ce.Name, strings.Join(args, ", "))
+}
@@ -0,0 +1,94 @@
+/*
+Package repository implements repository for handling fast and efficient access to data required
+by the resolvers of the API server.
+
+Internally it utilizes RPC to access Opera/Lachesis full node for blockchain interaction. Mongo database
+for fast, robust and scalable off-chain data storage, especially for aggregated and pre-calculated data mining
+results. BigCache for in-memory object storage to speed up loading of frequently accessed entities.
+*/
+package repository
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"time"
+)
+
+// richListBatchSize is the number of accounts
+// processed in a single step of the rich list update.
+const richListBatchSize = 500
+
+// RichList provides a list of accounts sorted by their native balance.
+func (p *proxy) RichList(cursor *string, count int32) (*types.RichList, error) {
+	return p.db.RichList(cursor, count)
+}
+
+// RichListUpdate refreshes the ranking of accounts active since the given time stamp
+// by their native balance; zero time stamp refreshes all the known accounts.
+// It returns the time stamp the next update should continue from.
+func (p *proxy) RichListUpdate(ctx context.Context, since uint64) (uint64, error) {
+	// accounts marked active from now on are picked by the next update
+	next, err := p.db.AccountsLastActivity()
+	if err != nil {
+		return since, err
+	}
+
+	start := time.Now()
+	var after *common.Address
+	var done int
+	for {
+		// stop early on shutdown
+		if err := ctx.Err(); err != nil {
+			return since, err
+		}
+
+		list, err := p.db.AccountsAfter(after, since, richListBatchSize)
+		if err != nil {
+			return since, err
+		}
+		if len(list) == 0 {
+			break
+		}
+
+		if err := p.richListUpdateBatch(list); err != nil {
+			return since, err
+		}
+
+		done += len(list)
+		after = &list[len(list)-1].Address
+	}
+
+	p.log.Noticef("rich list updated with %d accounts in %s", done, time.Since(start).String())
+	return next, nil
+}
+
+// richListUpdateBatch pulls current balances of the given accounts and stores them in the rich list.
+func (p *proxy) richListUpdateBatch(list []*types.Account) error {
+	addr := make([]common.Address, len(list))
+	for i, acc := range list {
+		addr[i] = acc.Address
+	}
+
+	bal, err := p.AccountBalances(addr)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	entries := make([]*types.RichListEntry, 0, len(list))
+	for i, acc := range list {
+		// accounts we failed to load keep their previous rank
+		if bal[i] == nil {
+			continue
+		}
+
+		entries = append(entries, &types.RichListEntry{
+			Address:    acc.Address,
+			Balance:    *bal[i],
+			IsContract: acc.Type != types.AccountTypeWallet,
+			Updated:    now,
+		})
+	}
+	return p.db.UpdateRichList(entries)
+}
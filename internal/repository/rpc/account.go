@@ -16,6 +16,7 @@ package rpc
 import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	eth "github.com/ethereum/go-ethereum/rpc"
 )
 
 // AccountBalance reads balance of account from Lachesis node.
@@ -38,6 +39,63 @@ func (ftm *FtmBridge) AccountBalance(addr *common.Address) (*hexutil.Big, error)
 	return (*hexutil.Big)(val), nil
 }
 
+// AccountBalances reads balances of the given accounts from Lachesis node in a single batch.
+// Balances of accounts failing to load are left nil.
+func (ftm *FtmBridge) AccountBalances(addr []common.Address) ([]*hexutil.Big, error) {
+	list := make([]hexutil.Big, len(addr))
+	batch := make([]eth.BatchElem, len(addr))
+	for i := range addr {
+		batch[i] = eth.BatchElem{
+			Method: "ftm_getBalance",
+			Args:   []interface{}{addr[i].Hex(), "latest"},
+			Result: &list[i],
+		}
+	}
+	if err := ftm.batchCall(batch); err != nil {
+		ftm.log.Errorf("can not get balances of %d accounts; %s", len(addr), err.Error())
+		return nil, err
+	}
+
+	res := make([]*hexutil.Big, len(addr))
+	for i := range batch {
+		if batch[i].Error != nil {
+			ftm.log.Debugf("can not get balance of account [%s]; %s", addr[i].Hex(), batch[i].Error.Error())
+			continue
+		}
+		res[i] = &list[i]
+	}
+	return res, nil
+}
+
+// AccountCodes reads the code size of the given accounts at the latest block in a single batch.
+// Sizes of accounts failing to load are left nil.
+func (ftm *FtmBridge) AccountCodes(addr []common.Address) ([]*int, error) {
+	list := make([]hexutil.Bytes, len(addr))
+	batch := make([]eth.BatchElem, len(addr))
+	for i := range addr {
+		batch[i] = eth.BatchElem{
+			Method: "ftm_getCode",
+			Args:   []interface{}{addr[i].Hex(), BlockTypeLatest},
+			Result: &list[i],
+		}
+	}
+	if err := ftm.batchCall(batch); err != nil {
+		ftm.log.Errorf("can not get code of %d accounts; %s", len(addr), err.Error())
+		return nil, err
+	}
+
+	res := make([]*int, len(addr))
+	for i := range batch {
+		if batch[i].Error != nil {
+			ftm.log.Debugf("can not get code of account [%s]; %s", addr[i].Hex(), batch[i].Error.Error())
+			continue
+		}
+		size := len(list[i])
+		res[i] = &size
+	}
+	return res, nil
+}
+
 // AccountNonce returns the total number of transaction of account from Lachesis node.
 func (ftm *FtmBridge) AccountNonce(addr *common.Address) (*hexutil.Uint64, error) {
 	var nonce hexutil.Uint64
@@ -48,3 +106,32 @@ func (ftm *FtmBridge) AccountNonce(addr *common.Address) (*hexutil.Uint64, error
 	}
 	return &nonce, nil
 }
+
+// AccountPendingNonce returns the number of transaction of account including
+// the transactions waiting in the transaction pool of the node.
+func (ftm *FtmBridge) AccountPendingNonce(addr *common.Address) (*hexutil.Uint64, error) {
+	var nonce hexutil.Uint64
+	err := ftm.rpc.Call(&nonce, "ftm_getTransactionCount", addr.Hex(), BlockTypePending)
+	if err != nil {
+		ftm.log.Errorf("can not get number of pending transaction of account [%s]", addr.Hex())
+		return nil, err
+	}
+	return &nonce, nil
+}
+
+// AccountCode reads the code of the account at the given block, or at the latest block
+// if the block is not specified. Empty code is returned for wallets and destroyed contracts.
+func (ftm *FtmBridge) AccountCode(addr *common.Address, block *hexutil.Uint64) (hexutil.Bytes, error) {
+	var tag interface{} = BlockTypeLatest
+	if block != nil {
+		tag = *block
+	}
+
+	var code hexutil.Bytes
+	err := ftm.rpc.Call(&code, "ftm_getCode", addr.Hex(), tag)
+	if err != nil {
+		ftm.log.Errorf("can not get code of account [%s]; %s", addr.Hex(), err.Error())
+		return nil, err
+	}
+	return code, nil
+}
@@ -0,0 +1,36 @@
+package rpc
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrArchiveRequired is returned if the requested historical state has been pruned
+// by the connected node; an archive node is needed to serve the request.
+var ErrArchiveRequired = errors.New("historical state is not available on the node, archive node required")
+
+// archiveErrorSignatures lists the fragments of the node errors signaling the requested state is pruned.
+var archiveErrorSignatures = []string{
+	"missing trie node",
+	"state not available",
+	"state is not available",
+	"state unavailable",
+	"required historical state",
+}
+
+// archiveError converts the node error of a pruned state into ErrArchiveRequired
+// keeping the original message; other errors are returned unchanged.
+func archiveError(err error) error {
+	if err == nil || errors.Is(err, ErrArchiveRequired) {
+		return err
+	}
+
+	msg := strings.ToLower(err.Error())
+	for _, sig := range archiveErrorSignatures {
+		if strings.Contains(msg, sig) {
+			return fmt.Errorf("%w; %s", ErrArchiveRequired, err.Error())
+		}
+	}
+	return err
+}
@@ -0,0 +1,26 @@
+package rpc
+
+import (
+	"errors"
+	"github.com/onsi/gomega"
+	"testing"
+)
+
+func TestArchiveError(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	for _, msg := range []string{
+		"missing trie node 7c4e1ab2c0f1 (path )",
+		"required historical state unavailable (reexec=128)",
+		"State not available for block 0x1",
+	} {
+		err := archiveError(errors.New(msg))
+		g.Expect(errors.Is(err, ErrArchiveRequired)).To(gomega.BeTrue(), msg)
+		g.Expect(err.Error()).To(gomega.ContainSubstring(msg))
+		g.Expect(errors.Is(archiveError(err), ErrArchiveRequired)).To(gomega.BeTrue())
+	}
+
+	other := errors.New("execution reverted")
+	g.Expect(archiveError(other)).To(gomega.Equal(other))
+	g.Expect(archiveError(nil)).To(gomega.BeNil())
+}
@@ -0,0 +1,152 @@
+/*
+Package rpc implements bridge to Lachesis full node API interface.
+
+We recommend using local IPC for fast and the most efficient inter-process communication between the API server
+and an Opera/Lachesis node. Any remote RPC connection will work, but the performance may be significantly degraded
+by extra networking overhead of remote RPC calls.
+
+You should also consider security implications of opening Lachesis RPC interface for a remote access.
+If you considering it as your deployment strategy, you should establish encrypted channel between the API server
+and Lachesis RPC interface with connection limited to specified endpoints.
+
+We strongly discourage opening Lachesis RPC interface for unrestricted Internet access.
+*/
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fantom-api-graphql/internal/logger"
+	"net/http"
+	"strings"
+	"time"
+
+	eth "github.com/ethereum/go-ethereum/rpc"
+	"go.uber.org/atomic"
+)
+
+const (
+	// batchBackoffBase is the pause before a rejected batch is retried with a smaller size;
+	// the pause grows with each consecutive rejection.
+	batchBackoffBase = 50 * time.Millisecond
+
+	// batchMaxRejections is the max number of consecutive rejections of a batch
+	// before the error is returned to the caller.
+	batchMaxRejections = 8
+)
+
+// batchRejectMarks are fragments of error messages nodes and proxies use to reject too large batches.
+var batchRejectMarks = []string{
+	"batch too large",
+	"batch size",
+	"batch limit",
+	"too many requests in batch",
+	"request entity too large",
+}
+
+// batchCaller splits JSON-RPC batches into chunks of the limited size. The limit shrinks
+// automatically if the node rejects a batch as too large.
+type batchCaller struct {
+	call  func(context.Context, []eth.BatchElem) error
+	limit *atomic.Int32
+	log   logger.Logger
+}
+
+// newBatchCaller creates a new batch splitter with the given initial batch size limit.
+func newBatchCaller(call func(context.Context, []eth.BatchElem) error, limit int, log logger.Logger) *batchCaller {
+	if limit <= 0 {
+		limit = 1
+	}
+	return &batchCaller{call: call, limit: atomic.NewInt32(int32(limit)), log: log}
+}
+
+// batchCall sends the given batch of calls to the node in chunks not exceeding the batch size limit.
+// Results are written into the batch elements, so the order of the batch is kept.
+func (ftm *FtmBridge) batchCall(batch []eth.BatchElem) error {
+	return ftm.batch.do(context.Background(), batch)
+}
+
+// batchCallContext sends the given batch of calls to the node in chunks
+// observing the deadline of the given context.
+func (ftm *FtmBridge) batchCallContext(ctx context.Context, batch []eth.BatchElem) error {
+	return ftm.batch.do(ctx, batch)
+}
+
+// do sends the batch in chunks, shrinking the chunk size if a chunk is rejected.
+func (bc *batchCaller) do(ctx context.Context, batch []eth.BatchElem) error {
+	var rejected int
+	for from := 0; from < len(batch); {
+		size := int(bc.limit.Load())
+		to := from + size
+		if to > len(batch) {
+			to = len(batch)
+		}
+
+		// errors of a rejected attempt must not leak into the retry
+		chunk := batch[from:to]
+		for i := range chunk {
+			chunk[i].Error = nil
+		}
+
+		err := bc.call(ctx, chunk)
+		if !isBatchRejected(err, chunk) {
+			if err != nil {
+				return err
+			}
+			from, rejected = to, 0
+			continue
+		}
+
+		// a single call can not be split any further
+		rejected++
+		if size <= 1 || rejected > batchMaxRejections {
+			if err == nil {
+				err = batch[from].Error
+			}
+			return err
+		}
+
+		bc.shrink(size)
+		select {
+		case <-time.After(time.Duration(rejected) * batchBackoffBase):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// shrink halves the batch size limit, unless it was already changed by another call.
+func (bc *batchCaller) shrink(size int) {
+	next := size / 2
+	if next < 1 {
+		next = 1
+	}
+	if bc.limit.CAS(int32(size), int32(next)) {
+		bc.log.Warningf("node rejected batch of %d calls, batch size limited to %d", size, next)
+	}
+}
+
+// isBatchRejected checks if the batch call failed since the batch is too large.
+// Some nodes reject the whole batch, others answer each call of the batch with the error.
+func isBatchRejected(err error, batch []eth.BatchElem) bool {
+	if err == nil {
+		if len(batch) == 0 || batch[0].Error == nil {
+			return false
+		}
+		err = batch[0].Error
+	}
+
+	var he eth.HTTPError
+	if errors.As(err, &he) && he.StatusCode == http.StatusRequestEntityTooLarge {
+		return true
+	}
+
+	msg := strings.ToLower(err.Error())
+	for _, mark := range batchRejectMarks {
+		if strings.Contains(msg, mark) {
+			return true
+		}
+	}
+	return false
+}
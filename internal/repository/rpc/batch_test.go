@@ -0,0 +1,96 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fantom-api-graphql/internal/config"
+	"fantom-api-graphql/internal/logger"
+	"github.com/onsi/gomega"
+	"testing"
+
+	eth "github.com/ethereum/go-ethereum/rpc"
+)
+
+// testBatchNode simulates a node accepting batches up to the given size.
+// The result of each call is the index of the call in the original batch.
+func testBatchNode(max int, perCall bool, calls *int) func(context.Context, []eth.BatchElem) error {
+	return func(_ context.Context, batch []eth.BatchElem) error {
+		*calls++
+		if len(batch) > max {
+			if perCall {
+				for i := range batch {
+					batch[i].Error = errors.New("batch too large")
+				}
+				return nil
+			}
+			return eth.HTTPError{StatusCode: 413, Status: "413 Request Entity Too Large"}
+		}
+		for i := range batch {
+			*batch[i].Result.(*int) = batch[i].Args[0].(int)
+		}
+		return nil
+	}
+}
+
+// testBatch makes a batch of the given size.
+func testBatch(size int) ([]eth.BatchElem, []int) {
+	res := make([]int, size)
+	batch := make([]eth.BatchElem, size)
+	for i := range batch {
+		batch[i] = eth.BatchElem{Method: "test", Args: []interface{}{i}, Result: &res[i]}
+	}
+	return batch, res
+}
+
+// TestBatchCaller tests batches are split into accepted chunks and results are kept in order.
+func TestBatchCaller(t *testing.T) {
+	log := logger.New(&config.Config{Log: config.Log{Level: "CRITICAL", Format: "%{message}"}})
+
+	tests := []struct {
+		name    string
+		limit   int
+		max     int
+		perCall bool
+		size    int
+		expect  int32
+	}{
+		{"fits the limit", 100, 100, false, 50, 100},
+		{"split by the limit", 10, 100, false, 95, 10},
+		{"whole batch rejected", 100, 30, false, 95, 25},
+		{"each call rejected", 64, 20, true, 70, 16},
+		{"invalid limit", 0, 100, false, 3, 1},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+
+			var calls int
+			bc := newBatchCaller(testBatchNode(tc.max, tc.perCall, &calls), tc.limit, log)
+			batch, res := testBatch(tc.size)
+
+			g.Expect(bc.do(context.Background(), batch)).To(gomega.BeNil())
+			g.Expect(bc.limit.Load()).To(gomega.Equal(tc.expect))
+			for i := range res {
+				g.Expect(res[i]).To(gomega.Equal(i))
+			}
+		})
+	}
+}
+
+// TestBatchCallerFailure tests errors unrelated to the batch size are returned without retries.
+func TestBatchCallerFailure(t *testing.T) {
+	g := gomega.NewWithT(t)
+	log := logger.New(&config.Config{Log: config.Log{Level: "CRITICAL", Format: "%{message}"}})
+
+	var calls int
+	bc := newBatchCaller(func(context.Context, []eth.BatchElem) error {
+		calls++
+		return errors.New("connection refused")
+	}, 10, log)
+
+	batch, _ := testBatch(30)
+	g.Expect(bc.do(context.Background(), batch)).NotTo(gomega.BeNil())
+	g.Expect(calls).To(gomega.Equal(1))
+	g.Expect(bc.limit.Load()).To(gomega.Equal(int32(10)))
+}
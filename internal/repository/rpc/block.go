@@ -17,14 +17,17 @@ import (
 	"fantom-api-graphql/internal/types"
 	"fmt"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	eth "github.com/ethereum/go-ethereum/rpc"
 	"math/big"
 	"time"
 )
 
 // BlockTypeLatest represents the latest available block in blockchain.
+// BlockTypePending represents the state including the pending transactions.
 const (
 	BlockTypeLatest   = "latest"
 	BlockTypeEarliest = "earliest"
+	BlockTypePending  = "pending"
 )
 
 // MustBlockHeight returns the current block height
@@ -82,6 +85,35 @@ func (ftm *FtmBridge) Block(numTag *string) (*types.Block, error) {
 	return &block, nil
 }
 
+// BlocksByNumber loads a range of blocks starting at the given number in a single batch call.
+// Blocks failing to load are left nil in the resulting list.
+func (ftm *FtmBridge) BlocksByNumber(from uint64, count int) ([]*types.Block, error) {
+	// keep track of the operation
+	ftm.log.Debugf("loading %d blocks from #%d", count, from)
+
+	list := make([]*types.Block, count)
+	batch := make([]eth.BatchElem, count)
+	for i := range batch {
+		batch[i] = eth.BatchElem{
+			Method: "ftm_getBlockByNumber",
+			Args:   []interface{}{hexutil.EncodeUint64(from + uint64(i)), false},
+			Result: &list[i],
+		}
+	}
+	if err := ftm.batchCall(batch); err != nil {
+		ftm.log.Errorf("can not load blocks batch; %s", err.Error())
+		return nil, err
+	}
+
+	for i := range batch {
+		if batch[i].Error != nil {
+			ftm.log.Debugf("block #%d not available; %s", from+uint64(i), batch[i].Error.Error())
+			list[i] = nil
+		}
+	}
+	return list, nil
+}
+
 // BlockByHash returns information about a blockchain block by hash.
 func (ftm *FtmBridge) BlockByHash(hash *string) (*types.Block, error) {
 	// keep track of the operation
@@ -0,0 +1,109 @@
+package rpc
+
+import (
+	"encoding/json"
+	"errors"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ftm "github.com/ethereum/go-ethereum/rpc"
+)
+
+// ErrBlockTagNotSupported is returned if the connected node does not recognize the requested block tag.
+var ErrBlockTagNotSupported = errors.New("block tag is not supported by the node")
+
+// rpcInvalidParamsCode is the JSON-RPC error code of a call with invalid arguments.
+const rpcInvalidParamsCode = -32602
+
+// erc20BalanceOfSelector is the selector of the ERC20 balanceOf(address) call.
+var erc20BalanceOfSelector = hexutil.MustDecode("0x70a08231")
+
+// blockTagSupport keeps the result of the node support detection of the named block tags.
+var blockTagSupport sync.Map
+
+// blockTag provides the RPC block argument of the given block tag; the latest block is used
+// if the tag is empty. Named tags not known to the node are rejected with ErrBlockTagNotSupported.
+func (ftm *FtmBridge) blockTag(tag types.BlockTag) (string, error) {
+	switch {
+	case tag == "":
+		return BlockTypeLatest, nil
+	case tag == types.BlockTagLatest, tag == types.BlockTagPending, tag == types.BlockTagEarliest, tag.IsNumber():
+		return string(tag), nil
+	}
+
+	if ok, known := blockTagSupport.Load(tag); known {
+		if !ok.(bool) {
+			return "", fmt.Errorf("%w; %s", ErrBlockTagNotSupported, tag)
+		}
+		return string(tag), nil
+	}
+
+	// probe the node with the tag; nodes not knowing it reject the argument
+	var head json.RawMessage
+	err := ftm.rpc.Call(&head, "ftm_getBlockByNumber", string(tag), false)
+	if err != nil && !isInvalidParams(err) {
+		ftm.log.Errorf("can not check block tag %s; %s", tag, err.Error())
+		return "", err
+	}
+
+	ok := err == nil && len(head) > 0 && string(head) != "null"
+	blockTagSupport.Store(tag, ok)
+	if !ok {
+		ftm.log.Noticef("block tag %s is not supported by the node", tag)
+		return "", fmt.Errorf("%w; %s", ErrBlockTagNotSupported, tag)
+	}
+	return string(tag), nil
+}
+
+// AccountBalanceAt reads balance of account at the block identified by the given tag.
+func (ftm *FtmBridge) AccountBalanceAt(addr *common.Address, tag types.BlockTag) (*hexutil.Big, error) {
+	block, err := ftm.blockTag(tag)
+	if err != nil {
+		return nil, err
+	}
+
+	var balance hexutil.Big
+	if err := ftm.rpc.Call(&balance, "ftm_getBalance", addr.Hex(), block); err != nil {
+		ftm.log.Errorf("can not get balance of account [%s] at %s; %s", addr.Hex(), block, err.Error())
+		return nil, archiveError(err)
+	}
+	return &balance, nil
+}
+
+// Erc20BalanceOfAt loads the balance of an ERC20 token for the given owner
+// at the block identified by the given tag.
+func (ftm *FtmBridge) Erc20BalanceOfAt(token *common.Address, owner *common.Address, tag types.BlockTag) (hexutil.Big, error) {
+	block, err := ftm.blockTag(tag)
+	if err != nil {
+		return hexutil.Big{}, err
+	}
+
+	data := append(append([]byte{}, erc20BalanceOfSelector...), common.LeftPadBytes(owner.Bytes(), 32)...)
+	var res hexutil.Bytes
+	err = ftm.rpc.Call(&res, "eth_call", map[string]interface{}{
+		"to":   token,
+		"data": hexutil.Bytes(data),
+	}, block)
+	if err != nil {
+		ftm.log.Errorf("can not ERC20 %s balance for %s at %s; %s", token.String(), owner.String(), block, err.Error())
+		return hexutil.Big{}, archiveError(err)
+	}
+	if len(res) < 32 {
+		return hexutil.Big{}, fmt.Errorf("invalid ERC20 %s balance response", token.String())
+	}
+	return hexutil.Big(*new(big.Int).SetBytes(res[:32])), nil
+}
+
+// isInvalidParams checks if the RPC error signals the call arguments were rejected by the node.
+func isInvalidParams(err error) bool {
+	var re ftm.Error
+	if errors.As(err, &re) {
+		return re.ErrorCode() == rpcInvalidParamsCode
+	}
+	return strings.Contains(err.Error(), "invalid argument")
+}
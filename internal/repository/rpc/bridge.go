@@ -4,6 +4,8 @@ Package rpc implements bridge to Opera/Lachesis full node API interface.
 We recommend using local IPC for fast and the most efficient inter-process communication between the API server
 and an Opera/Lachesis node. Any remote RPC connection will work, but the performance may be significantly degraded
 by extra networking overhead of remote RPC calls.
+Remote nodes should be connected over WebSocket (ws:// or wss://), so new blocks are received
+by subscription; the block scanner polls the node over plain HTTP connections.
 
 You should also consider security implications of opening Opera/Lachesis RPC interface for remote access.
 If you considering it as your deployment strategy, you should establish encrypted channel between the API server
@@ -20,6 +22,7 @@ import (
 	"fantom-api-graphql/internal/repository/rpc/contracts"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
 	etc "github.com/ethereum/go-ethereum/core/types"
 	eth "github.com/ethereum/go-ethereum/ethclient"
 	ftm "github.com/ethereum/go-ethereum/rpc"
@@ -31,6 +34,9 @@ import (
 // rpcHeadProxyChannelCapacity represents the capacity of the new received blocks proxy channel.
 const rpcHeadProxyChannelCapacity = 10000
 
+// rpcPendingProxyChannelCapacity represents the capacity of the new pending transactions proxy channel.
+const rpcPendingProxyChannelCapacity = 5000
+
 // FtmBridge represents Opera/Lachesis RPC abstraction layer.
 type FtmBridge struct {
 	rpc *ftm.Client
@@ -38,6 +44,9 @@ type FtmBridge struct {
 	log logger.Logger
 	cg  *singleflight.Group
 
+	// batch splits JSON-RPC batches into chunks the node accepts
+	batch *batchCaller
+
 	// fMintCfg represents the configuration of the fMint protocol
 	sigConfig     *config.ServerSignature
 	sfcConfig     *config.Staking
@@ -55,6 +64,9 @@ type FtmBridge struct {
 	wg       *sync.WaitGroup
 	sigClose chan bool
 	headers  chan *etc.Header
+
+	// received pending transactions proxy; nil if the pending pool is not observed
+	pending chan common.Hash
 }
 
 // New creates new Opera/Lachesis RPC connection bridge.
@@ -72,6 +84,8 @@ func New(cfg *config.Config, log logger.Logger) (*FtmBridge, error) {
 		log: log,
 		cg:  new(singleflight.Group),
 
+		batch: newBatchCaller(cli.BatchCallContext, cfg.Opera.MaxBatchSize, log),
+
 		// special configuration options below this line
 		sigConfig:     &cfg.MySignature,
 		sfcConfig:     &cfg.Staking,
@@ -87,6 +101,11 @@ func New(cfg *config.Config, log logger.Logger) (*FtmBridge, error) {
 		headers:  make(chan *etc.Header, rpcHeadProxyChannelCapacity),
 	}
 
+	// observe the pending pool only if enabled; not all the nodes expose it
+	if cfg.Opera.PendingPool {
+		br.pending = make(chan common.Hash, rpcPendingProxyChannelCapacity)
+	}
+
 	// inform about the local address of the API node
 	log.Noticef("using signature address %s", br.sigConfig.Address.String())
 
@@ -124,11 +143,16 @@ func connect(cfg *config.Config, log logger.Logger) (*ftm.Client, *eth.Client, e
 func (ftm *FtmBridge) run() {
 	ftm.wg.Add(1)
 	go ftm.observeBlocks()
+
+	if ftm.pending != nil {
+		ftm.wg.Add(1)
+		go ftm.observePending()
+	}
 }
 
 // terminate kills the bridge threads to end the bridge gracefully.
 func (ftm *FtmBridge) terminate() {
-	ftm.sigClose <- true
+	close(ftm.sigClose)
 	ftm.wg.Wait()
 	ftm.log.Noticef("rpc threads terminated")
 }
@@ -198,3 +222,9 @@ func (ftm *FtmBridge) SfcAbi() *abi.ABI {
 func (ftm *FtmBridge) ObservedBlockProxy() chan *etc.Header {
 	return ftm.headers
 }
+
+// ObservedPendingProxy provides a channel fed with hashes of new pending transactions
+// observed by the connected blockchain node; nil if the pending pool is not observed.
+func (ftm *FtmBridge) ObservedPendingProxy() chan common.Hash {
+	return ftm.pending
+}
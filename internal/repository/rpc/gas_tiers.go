@@ -0,0 +1,90 @@
+/*
+Package rpc implements bridge to Lachesis full node API interface.
+
+We recommend using local IPC for fast and the most efficient inter-process communication between the API server
+and an Opera/Lachesis node. Any remote RPC connection will work, but the performance may be significantly degraded
+by extra networking overhead of remote RPC calls.
+
+You should also consider security implications of opening Lachesis RPC interface for a remote access.
+If you considering it as your deployment strategy, you should establish encrypted channel between the API server
+and Lachesis RPC interface with connection limited to specified endpoints.
+
+We strongly discourage opening Lachesis RPC interface for unrestricted Internet access.
+*/
+package rpc
+
+import (
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	eth "github.com/ethereum/go-ethereum/rpc"
+)
+
+// gasPriceBlock represents the part of a block with full transactions we need to collect gas prices.
+type gasPriceBlock struct {
+	Transactions []struct {
+		GasPrice hexutil.Big `json:"gasPrice"`
+	} `json:"transactions"`
+}
+
+// RecentGasPrices collects gas prices paid by transactions in the given number of the most recent blocks.
+// Only the lowest perBlock prices of each block are collected, so a single busy block
+// with a lot of expensive transactions does not skew the result.
+// The number of blocks actually inspected is returned along with the prices.
+func (ftm *FtmBridge) RecentGasPrices(blocks int, perBlock int) ([]*big.Int, int, error) {
+	// keep track of the operation
+	ftm.log.Debugf("collecting gas prices of %d recent blocks", blocks)
+
+	head, err := ftm.BlockHeight()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// we can not go below the genesis
+	top := head.ToInt().Int64()
+	if int64(blocks) > top+1 {
+		blocks = int(top + 1)
+	}
+
+	// load all the blocks in a single batch
+	list := make([]gasPriceBlock, blocks)
+	batch := make([]eth.BatchElem, blocks)
+	for i := range batch {
+		batch[i] = eth.BatchElem{
+			Method: "ftm_getBlockByNumber",
+			Args:   []interface{}{hexutil.EncodeUint64(uint64(top - int64(i))), true},
+			Result: &list[i],
+		}
+	}
+	if err := ftm.batchCall(batch); err != nil {
+		ftm.log.Errorf("can not load recent blocks; %s", err.Error())
+		return nil, 0, err
+	}
+
+	prices := make([]*big.Int, 0, blocks*perBlock)
+	for i, blk := range list {
+		if batch[i].Error != nil {
+			ftm.log.Debugf("recent block not available; %s", batch[i].Error.Error())
+			continue
+		}
+		prices = append(prices, lowestGasPrices(&blk, perBlock)...)
+	}
+	return prices, blocks, nil
+}
+
+// lowestGasPrices provides up to the given number of the lowest gas prices paid in the block.
+func lowestGasPrices(blk *gasPriceBlock, count int) []*big.Int {
+	prices := make([]*big.Int, len(blk.Transactions))
+	for i := range blk.Transactions {
+		prices[i] = blk.Transactions[i].GasPrice.ToInt()
+	}
+
+	sort.Slice(prices, func(i, j int) bool {
+		return prices[i].Cmp(prices[j]) < 0
+	})
+	if len(prices) > count {
+		prices = prices[:count]
+	}
+	return prices
+}
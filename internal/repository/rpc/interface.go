@@ -0,0 +1,489 @@
+/*
+Package rpc implements bridge to Opera/Lachesis full node API interface.
+
+We recommend using local IPC for fast and the most efficient inter-process communication between the API server
+and an Opera/Lachesis node. Any remote RPC connection will work, but the performance may be significantly degraded
+by extra networking overhead of remote RPC calls.
+
+You should also consider security implications of opening Opera/Lachesis RPC interface for remote access.
+If you considering it as your deployment strategy, you should establish encrypted channel between the API server
+and Opera/Lachesis RPC interface with connection limited to specified endpoints.
+
+We strongly discourage opening Opera/Lachesis RPC interface for unrestricted Internet access.
+*/
+package rpc
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository/rpc/contracts"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	etc "github.com/ethereum/go-ethereum/core/types"
+	"math/big"
+	"time"
+)
+
+// Bridge defines the interface to the Opera/Lachesis blockchain node used by the repository.
+// The JSON-RPC based FtmBridge is the only implementation and the only transport;
+// Opera nodes do not provide a gRPC API, so there is no alternative to select.
+type Bridge interface {
+	// AccountBalance reads balance of account from Lachesis node.
+	AccountBalance(addr *common.Address) (*hexutil.Big, error)
+
+	// AccountBalanceAt reads balance of account at the block identified by the given tag.
+	AccountBalanceAt(addr *common.Address, tag types.BlockTag) (*hexutil.Big, error)
+
+	// AccountBalances reads balances of the given accounts from Lachesis node in a single batch.
+	// Balances of accounts failing to load are left nil.
+	AccountBalances(addr []common.Address) ([]*hexutil.Big, error)
+
+	// AccountCodes reads the code size of the given accounts in a single batch.
+	// Sizes of accounts failing to load are left nil.
+	AccountCodes(addr []common.Address) ([]*int, error)
+
+	// AccountNonce returns the total number of transaction of account from Lachesis node.
+	AccountNonce(addr *common.Address) (*hexutil.Uint64, error)
+
+	// AccountPendingNonce returns the number of transaction of account including
+	// the transactions waiting in the transaction pool of the node.
+	AccountPendingNonce(addr *common.Address) (*hexutil.Uint64, error)
+
+	// Multicall executes the given read-only calls in a single aggregated call
+	// of the Multicall contract at the given address. Failing calls do not fail
+	// the aggregation; they are reported in their result.
+	Multicall(mc *common.Address, calls []types.ContractCall) ([]types.ContractCallResult, error)
+
+	// ContractCalls executes the given read-only calls as individual calls
+	// sent to the node in a single batch. Failing calls are reported in their result.
+	ContractCalls(calls []types.ContractCall) ([]types.ContractCallResult, error)
+
+	// AccountCode reads the code of the account at the given block, or at the latest block
+	// if the block is not specified. Empty code is returned for wallets and destroyed contracts.
+	AccountCode(addr *common.Address, block *hexutil.Uint64) (hexutil.Bytes, error)
+
+	// MustBlockHeight returns the current block height
+	// of the blockchain. It returns nil if the block height can not be pulled.
+	MustBlockHeight() *big.Int
+
+	// BlockHeight returns the current block height of the Opera blockchain.
+	BlockHeight() (*hexutil.Big, error)
+
+	// Block returns information about a blockchain block by encoded hex number, or by a type tag.
+	// For tag based loading use predefined BlockType contacts.
+	Block(numTag *string) (*types.Block, error)
+
+	// BlocksByNumber loads a range of blocks starting at the given number in a single batch call.
+	// Blocks failing to load are left nil in the resulting list.
+	BlocksByNumber(from uint64, count int) ([]*types.Block, error)
+
+	// BlockByHash returns information about a blockchain block by hash.
+	BlockByHash(hash *string) (*types.Block, error)
+
+	// Close will finish all pending operations and terminate the Opera/Lachesis RPC connection
+	Close()
+
+	// SfcAbi returns a parse ABI of the AFC contract.
+	SfcAbi() *abi.ABI
+
+	// ObservedBlockProxy provides a channel fed with new headers observed
+	// by the connected blockchain node.
+	ObservedBlockProxy() chan *etc.Header
+
+	// ObservedPendingProxy provides a channel fed with hashes of new pending transactions
+	// observed by the connected blockchain node; nil if the pending pool is not observed.
+	ObservedPendingProxy() chan common.Hash
+
+	// FLendGetLendingPool resolves Lending pool contract instance
+	FLendGetLendingPool() (*contracts.ILendingPool, error)
+
+	// FLendGetLendingPoolReserveData resolves reserve data
+	FLendGetLendingPoolReserveData(assetAddress *common.Address) (*types.ReserveData, error)
+
+	// FLendGetReserveList resolves list of reserve addresses
+	FLendGetReserveList() ([]common.Address, error)
+
+	// FLendGetUserAccountData resolves user account data for fLend
+	FLendGetUserAccountData(userAddress *common.Address) (*types.FLendUserAccountData, error)
+
+	// FLendGetUserDepositHistory resolves deposit event history data for specified user and asset address
+	FLendGetUserDepositHistory(userAddress *common.Address, assetAddress *common.Address) ([]*types.FLendDeposit, error)
+
+	// FMintAccount loads details of a DeFi/fMint protocol account identified by the owner address.
+	FMintAccount(owner *common.Address) (*types.FMintAccount, error)
+
+	// FMintFilterTokens filter list of tokens to include only tokens with a balance of given type.
+	FMintFilterTokens(owner *common.Address, list []common.Address, tp types.DefiTokenType) []common.Address
+
+	// FMintPoolBalance loads balance of an fMint token from the given pool contract.
+	FMintPoolBalance(pool *contracts.DeFiTokenStorage, owner *common.Address, token *common.Address) (hexutil.Big, error)
+
+	// FMintTokenBalance loads balance of a single DeFi token in fMint contract by its address.
+	FMintTokenBalance(owner *common.Address, token *common.Address, tp types.DefiTokenType) (hexutil.Big, error)
+
+	// FMintTokenTotalBalance loads total balance of a single DeFi token by it's address.
+	FMintTokenTotalBalance(token *common.Address, tp types.DefiTokenType) (hexutil.Big, error)
+
+	// FMintTokenValue loads value of a single DeFi token by it's address in fUSD.
+	FMintTokenValue(owner *common.Address, token *common.Address, tp types.DefiTokenType) (hexutil.Big, error)
+
+	// FMintTokenPrice loads the current price of the given token from on-chain price oracle.
+	FMintTokenPrice(token *common.Address) (hexutil.Big, error)
+
+	// FMintRewardsEarned resolves the total amount of rewards
+	// accumulated on the account for the excessive collateral deposits.
+	FMintRewardsEarned(addr *common.Address) (hexutil.Big, error)
+
+	// FMintRewardsStashed resolves the total amount of rewards
+	// accumulated on the account for the excessive collateral deposits.
+	FMintRewardsStashed(addr *common.Address) (hexutil.Big, error)
+
+	// FMintCanClaimRewards resolves the fMint account flag for being allowed
+	// to claim earned rewards.
+	FMintCanClaimRewards(addr *common.Address) (bool, error)
+
+	// FMintCanReceiveRewards resolves the fMint account flag for being eligible
+	// to receive earned rewards. If the collateral to debt ration drop below
+	// certain value, earned rewards are burned.
+	FMintCanReceiveRewards(addr *common.Address) (bool, error)
+
+	// FMintCanPushRewards signals if there are any rewards unlocked
+	// on the rewards distribution contract and can be pushed to accounts.
+	FMintCanPushRewards() (bool, error)
+
+	// DefiConfiguration resolves the current DeFi contract settings.
+	DefiConfiguration() (*types.DefiSettings, error)
+
+	// DefiTokens resolves list of DeFi tokens available for the DeFi functions.
+	DefiTokens() ([]types.DefiToken, error)
+
+	// DefiTokenList creates a list of addresses / identifiers of all the ERC20 tokens
+	// involved with the fMint protocol.
+	DefiTokenList() ([]common.Address, error)
+
+	// DefiToken loads details of a single DeFi token by it's address.
+	DefiToken(token *common.Address) (*types.DefiToken, error)
+
+	// Erc1155Uri provides URI of Metadata JSON Schema of the ERC1155 token.
+	Erc1155Uri(token *common.Address, tokenId *big.Int) (string, error)
+
+	// Erc1155BalanceOf provides amount of tokens owned by given owner in given ERC1155 contract.
+	Erc1155BalanceOf(token *common.Address, owner *common.Address, tokenId *big.Int) (*big.Int, error)
+
+	// Erc1155BalanceOfBatch provides amounts of tokens owned by given owners in given ERC1155 contract.
+	Erc1155BalanceOfBatch(token *common.Address, owners *[]common.Address, tokenIds []*big.Int) ([]*big.Int, error)
+
+	// Erc1155IsApprovedForAll provides information about operator approved to manipulate with tokens of given owner.
+	Erc1155IsApprovedForAll(token *common.Address, owner *common.Address, operator *common.Address) (bool, error)
+
+	//
+	Erc165SupportsInterface(address *common.Address, interfaceID [4]byte) (bool, error)
+
+	// Erc20Name provides information about the name of the ERC20 token.
+	Erc20Name(token *common.Address) (string, error)
+
+	// Erc20Symbol provides information about the symbol of the ERC20 token.
+	Erc20Symbol(token *common.Address) (string, error)
+
+	// Erc20Decimals provides information about the decimals of the ERC20 token.
+	Erc20Decimals(token *common.Address) (int32, error)
+
+	// Erc20BalanceOf loads the current available balance of and ERC20 token identified by the token
+	// contract address for an identified owner address.
+	Erc20BalanceOf(token *common.Address, owner *common.Address) (hexutil.Big, error)
+
+	// Erc20BalanceOfAt loads the balance of an ERC20 token for the given owner
+	// at the block identified by the given tag.
+	Erc20BalanceOfAt(token *common.Address, owner *common.Address, tag types.BlockTag) (hexutil.Big, error)
+
+	// Erc20Allowance loads the current amount of ERC20 tokens unlocked for DeFi
+	// contract by the token owner.
+	Erc20Allowance(token *common.Address, owner *common.Address, spender *common.Address) (hexutil.Big, error)
+
+	// Erc20TotalSupply provides information about all available tokens
+	Erc20TotalSupply(token *common.Address) (hexutil.Big, error)
+
+	// Erc721Name provides information about the name of the ERC721 token.
+	Erc721Name(token *common.Address) (string, error)
+
+	// Erc721Symbol provides information about the symbol of the ERC721 token.
+	Erc721Symbol(token *common.Address) (string, error)
+
+	// Erc721BalanceOf provides amount of NFT tokens owned by given owner in given ERC721 contract.
+	Erc721BalanceOf(token *common.Address, owner *common.Address) (hexutil.Big, error)
+
+	// Erc721TotalSupply provides information about all available tokens
+	Erc721TotalSupply(token *common.Address) (hexutil.Big, error)
+
+	// Erc721TokenURI provides URI of Metadata JSON Schema of the ERC721 token.
+	Erc721TokenURI(token *common.Address, tokenId *big.Int) (string, error)
+
+	// Erc721OwnerOf provides information about NFT token ownership
+	Erc721OwnerOf(token *common.Address, tokenId *big.Int) (common.Address, error)
+
+	// Erc721GetApproved provides information about operator approved to manipulate with the NFT token.
+	Erc721GetApproved(token *common.Address, tokenId *big.Int) (common.Address, error)
+
+	// Erc721IsApprovedForAll provides information about operator approved to manipulate with NFT tokens of given owner.
+	Erc721IsApprovedForAll(token *common.Address, owner *common.Address, operator *common.Address) (bool, error)
+
+	// RecentGasPrices collects gas prices paid by transactions in the given number of the most recent blocks.
+	// Only the lowest perBlock prices of each block are collected, so a single busy block
+	// with a lot of expensive transactions does not skew the result.
+	// The number of blocks actually inspected is returned along with the prices.
+	RecentGasPrices(blocks int, perBlock int) ([]*big.Int, int, error)
+
+	// GovernanceProposalsCount provides the total number of proposals
+	// in a given Governance contract.
+	GovernanceProposalsCount(gov *common.Address) (hexutil.Big, error)
+
+	// GovernanceProposal provides a detail of Proposal of a governance contract
+	// specified by its id.
+	GovernanceProposal(gov *common.Address, id *hexutil.Big) (*types.GovernanceProposal, error)
+
+	// GovernanceProposalState provides a state of Proposal of a governance contract
+	// specified by its id.
+	GovernanceProposalState(gov *common.Address, id *hexutil.Big) (*types.GovernanceProposalState, error)
+
+	// GovernanceOptionState returns a state of the given option of a proposal.
+	GovernanceOptionState(gov *common.Address, propId *hexutil.Big, optId *hexutil.Big) (*types.GovernanceOptionState, error)
+
+	// GovernanceOptionStates returns a list of states of options of a proposal.
+	GovernanceOptionStates(gov *common.Address, propId *hexutil.Big, optRange int) ([]*types.GovernanceOptionState, error)
+
+	// GovernanceVote provides a single vote in the Governance Proposal context.
+	GovernanceVote(
+		gov *common.Address,
+		propId *hexutil.Big,
+		from *common.Address,
+		delegatedTo *common.Address) (*types.GovernanceVote, error)
+
+	// GovernanceProposalsBy loads list of proposals of the given Governance contract.
+	GovernanceProposalsBy(gov *common.Address) ([]*types.GovernanceProposal, error)
+
+	// GovernanceProposalFee returns the fee payable for a new proposal
+	// in given Governance contract context.
+	GovernanceProposalFee(gov *common.Address) (hexutil.Big, error)
+
+	// GovernanceTotalWeight returns the total available voting weight for all proposals
+	// of a governance contract. The address given must be the Governable contract linked
+	// to the core Governance.
+	GovernanceTotalWeight(ge *common.Address) (*hexutil.Big, error)
+
+	// NameServiceName performs the reverse resolution of the given address using the given
+	// registry contract. An empty name is returned if the address does not have a reverse record.
+	NameServiceName(registry *common.Address, addr *common.Address) (string, error)
+
+	// NameServiceAddress performs the forward resolution of the given name using the given
+	// registry contract. Nil is returned if the name does not resolve to an address.
+	NameServiceAddress(registry *common.Address, name string) (*common.Address, error)
+
+	// NetworkInfo collects the metadata of the connected node and its network in a single batch call.
+	NetworkInfo(context.Context) (*types.NetworkInfo, error)
+
+	// ProxyImplementation reads the EIP-1967 implementation slot of the given contract
+	// at the given block; the latest state is used for nil block.
+	// Nil is returned if the contract is not an EIP-1967 proxy.
+	ProxyImplementation(addr *common.Address, block *big.Int) (*common.Address, error)
+
+	// StorageAt reads the value of the given storage slot of the contract
+	// at the given block; the latest state is used for nil block.
+	StorageAt(addr *common.Address, slot common.Hash, block *big.Int) (common.Hash, error)
+
+	// SimulateCall executes the given call on the state of the given block, or the latest state,
+	// with the state override applied, if any. Calls reverted by the contract are not errors,
+	// the revert is reported in the result instead.
+	SimulateCall(call *types.SimulationCall, timeout time.Duration) (*types.SimulatedCall, error)
+
+	// TransactionRevertReason replays the call of the given failed transaction on the state
+	// of the block preceding the transaction block and decodes the reason of the revert.
+	// The state of the transactions preceding the replayed one in the same block is not available,
+	// so the replay may not fail; nil is returned in that case.
+	TransactionRevertReason(trx *types.Transaction) (*types.RevertReason, error)
+
+	// SfcVersion returns current version of the SFC contract as a single number.
+	SfcVersion() (hexutil.Uint64, error)
+
+	// CurrentEpoch extract the current epoch id from SFC smart contract.
+	CurrentEpoch() (hexutil.Uint64, error)
+
+	// CurrentSealedEpoch extract the current sealed epoch id from SFC smart contract.
+	CurrentSealedEpoch() (hexutil.Uint64, error)
+
+	// Epoch extract information about an epoch from SFC smart contract.
+	Epoch(id hexutil.Uint64) (*types.Epoch, error)
+
+	// RewardsAllowed returns if the rewards can be manipulated with.
+	RewardsAllowed() (bool, error)
+
+	// LockingAllowed indicates if the stake locking has been enabled in SFC.
+	LockingAllowed() (bool, error)
+
+	// TotalStaked returns the total amount of staked tokens.
+	TotalStaked() (*big.Int, error)
+
+	// SfcMinValidatorStake extracts a value of minimal validator self stake.
+	SfcMinValidatorStake() (*big.Int, error)
+
+	// SfcMaxDelegatedRatio extracts a ratio between self delegation and received stake.
+	SfcMaxDelegatedRatio() (*big.Int, error)
+
+	// SfcValidatorCommission extracts the validator commission rate.
+	SfcValidatorCommission() (*big.Int, error)
+
+	// SfcMinLockupDuration extracts a minimal lockup duration.
+	SfcMinLockupDuration() (*big.Int, error)
+
+	// SfcMaxLockupDuration extracts a maximal lockup duration.
+	SfcMaxLockupDuration() (*big.Int, error)
+
+	// SfcWithdrawalPeriodEpochs extracts a minimal number of epochs between un-delegate and withdraw.
+	SfcWithdrawalPeriodEpochs() (*big.Int, error)
+
+	// SfcWithdrawalPeriodTime extracts a minimal number of seconds between un-delegate and withdraw.
+	SfcWithdrawalPeriodTime() (*big.Int, error)
+
+	// SfcStakingParams reads the staking protocol parameters of the SFC contract at the latest block.
+	SfcStakingParams() (*types.StakingParams, error)
+
+	// AmountStaked returns the current amount at stake for the given staker address and target validator
+	AmountStaked(addr *common.Address, valID *big.Int) (*big.Int, error)
+
+	// AmountStakeLocked returns the current locked amount at stake for the given staker address and target validator.
+	AmountStakeLocked(addr *common.Address, valID *big.Int) (*big.Int, error)
+
+	// AmountStakeUnlocked returns the current unlocked amount at stake for the given staker address and target validator.
+	AmountStakeUnlocked(addr *common.Address, valID *big.Int) (*big.Int, error)
+
+	// StakeUnlockPenalty returns the expected penalty of a premature stake unlock.
+	StakeUnlockPenalty(addr *common.Address, valID *big.Int, amount *big.Int) (*big.Int, error)
+
+	// PendingRewards returns a detail of delegation rewards waiting to be claimed for the given delegation.
+	PendingRewards(addr *common.Address, valID *big.Int) (*types.PendingRewards, error)
+
+	// DelegationLock returns delegation lock information using SFC contract binding.
+	DelegationLock(addr *common.Address, valID *hexutil.Big) (dll *types.DelegationLock, err error)
+
+	// DelegationOutstandingSFTM returns the amount of sFTM tokens for the delegation
+	// identified by the delegator address and the stakerId.
+	DelegationOutstandingSFTM(addr *common.Address, valID *big.Int) (*big.Int, error)
+
+	// DelegationTokenizerUnlocked returns the status of SFC Tokenizer lock
+	// for a delegation identified by the address and staker id.
+	DelegationTokenizerUnlocked(addr *common.Address, valID *big.Int) (bool, error)
+
+	// ValidatorDowntime pulls information about validator downtime from the RPC interface.
+	ValidatorDowntime(valID *hexutil.Big) (uint64, uint64, error)
+
+	// ValidatorEpochUptime pulls information about validator uptime on the given epoch.
+	ValidatorEpochUptime(valID *hexutil.Big) (uint64, error)
+
+	// ValidatorEpochRewardPerToken pulls the reward per token accumulated by the validator delegators up to the epoch.
+	ValidatorEpochRewardPerToken(valID *hexutil.Big, epoch hexutil.Uint64) (*big.Int, error)
+
+	// LastValidatorId returns the last staker id in Opera blockchain.
+	LastValidatorId() (uint64, error)
+
+	// ValidatorsCount returns the number of validators in Opera blockchain.
+	ValidatorsCount() (uint64, error)
+
+	// Validator extract a staker information by numeric id.
+	Validator(valID *big.Int) (*types.Validator, error)
+
+	// ValidatorAddress extract a staker address for the given staker ID.
+	ValidatorAddress(valID *big.Int) (*common.Address, error)
+
+	// IsValidator returns if the given address is an SFC validator.
+	IsValidator(addr *common.Address) (bool, error)
+
+	// ValidatorByAddress extracts a validator information by address.
+	ValidatorByAddress(addr *common.Address) (*types.Validator, error)
+
+	// ValidatorInfo extracts extended information for a validator.
+	ValidatorInfo(id *hexutil.Big) (*types.ValidatorInfo, error)
+
+	// InternalTransactions extracts internal calls of the given transaction from its call trace.
+	// The debug tracing API is used if available, the trace API is used as a fallback.
+	// The top level call, which is the transaction itself, is not included.
+	InternalTransactions(hash *common.Hash) ([]*types.InternalTransaction, error)
+
+	// Transaction returns information about a blockchain transaction by hash.
+	Transaction(hash *common.Hash) (*types.Transaction, error)
+
+	// SendTransaction sends raw signed and RLP encoded transaction to the block chain.
+	SendTransaction(tx hexutil.Bytes) (*common.Hash, error)
+
+	// NativeTokenAddress returns an address of native token.
+	NativeTokenAddress() (*common.Address, error)
+
+	// UniswapPair returns an address of an Uniswap pair for the given tokens.
+	UniswapPair(tokenA *common.Address, tokenB *common.Address) (*common.Address, error)
+
+	// UniswapPairs returns list of all token pairs managed by Uniswap core.
+	UniswapPairs(whiteListedOnly bool) ([]common.Address, error)
+
+	// UniswapQuoteInput calculates optimal input on sibling token based on input amount and
+	// self reserves of the analyzed token.
+	UniswapQuoteInput(
+		amountA hexutil.Big,
+		reserveA hexutil.Big,
+		reserveB hexutil.Big,
+	) (hexutil.Big, error)
+
+	// UniswapAmountsOut resolves a list of output amounts for the given
+	// input amount and a list of tokens to be used to make the swap operation.
+	UniswapAmountsOut(amountIn hexutil.Big, tokens []common.Address) ([]hexutil.Big, error)
+
+	// UniswapAmountsIn resolves a list of input amounts for the given
+	// output amount and a list of tokens to be used to make the swap operation.
+	UniswapAmountsIn(amountOut hexutil.Big, tokens []common.Address) ([]hexutil.Big, error)
+
+	// UniswapTokens returns list of addresses of tokens involved in a Uniswap pair.
+	UniswapTokens(pair *common.Address) ([]common.Address, error)
+
+	// UniswapReserves returns list of token reserve amounts in a Uniswap pair.
+	UniswapReserves(pair *common.Address) ([]hexutil.Big, error)
+
+	// UniswapReservesTimeStamp returns the timestamp of the reserves of a Uniswap pair.
+	UniswapReservesTimeStamp(pair *common.Address) (hexutil.Uint64, error)
+
+	// UniswapCumulativePrices returns list of token cumulative prices of a Uniswap pair.
+	UniswapCumulativePrices(pair *common.Address) ([]hexutil.Big, error)
+
+	// UniswapLastKValue returns the last value of the pool control coefficient.
+	UniswapLastKValue(pair *common.Address) (hexutil.Big, error)
+
+	// UniswapPairContract returns instance of this contract according to given pair address
+	UniswapPairContract(pairAddres *common.Address) (*contracts.UniswapPair, error)
+
+	// UniswapFactoryContract returns an instance of an Uniswap factory
+	UniswapFactoryContract() (*contracts.UniswapFactory, error)
+
+	// GasPrice pulls the current amount of WEI for single Gas.
+	GasPrice() (hexutil.Big, error)
+
+	// GasEstimate calculates the estimated amount of Gas required to perform
+	// transaction described by the input params. The estimation is limited by the gas cap
+	// and a short timeout; reverted calls are reported as RevertError with the decoded reason.
+	GasEstimate(trx *struct {
+		From  *common.Address
+		To    *common.Address
+		Value *hexutil.Big
+		Data  *string
+	}) (*hexutil.Uint64, error)
+
+	// GasEstimateWithBlock calculates the estimated amount of Gas required to perform
+	// transaction described by the input params with specifying the block on which the calculation
+	// should happen (new RPC API compatibility).
+	// @TODO Replace the old gas estimate call once the API gets upgraded on all nodes.
+	GasEstimateWithBlock(trx *struct {
+		From  *common.Address
+		To    *common.Address
+		Value *hexutil.Big
+		Data  *string
+	}) (*hexutil.Uint64, error)
+}
+
+// make sure the JSON-RPC bridge implements the interface
+var _ Bridge = (*FtmBridge)(nil)
@@ -0,0 +1,128 @@
+/*
+Package rpc implements bridge to Lachesis full node API interface.
+
+We recommend using local IPC for fast and the most efficient inter-process communication between the API server
+and an Opera/Lachesis node. Any remote RPC connection will work, but the performance may be significantly degraded
+by extra networking overhead of remote RPC calls.
+
+You should also consider security implications of opening Lachesis RPC interface for a remote access.
+If you considering it as your deployment strategy, you should establish encrypted channel between the API server
+and Lachesis RPC interface with connection limited to specified endpoints.
+
+We strongly discourage opening Lachesis RPC interface for unrestricted Internet access.
+*/
+package rpc
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	eth "github.com/ethereum/go-ethereum/rpc"
+)
+
+// multicallTimeout is the time limit of a single aggregated call.
+const multicallTimeout = 10 * time.Second
+
+// MulticallAbi is the ABI of the Multicall2/Multicall3 contract methods we use.
+const MulticallAbi = `[
+{"inputs":[{"name":"requireSuccess","type":"bool"},{"components":[{"name":"target","type":"address"},{"name":"callData","type":"bytes"}],"name":"calls","type":"tuple[]"}],"name":"tryAggregate","outputs":[{"components":[{"name":"success","type":"bool"},{"name":"returnData","type":"bytes"}],"name":"returnData","type":"tuple[]"}],"stateMutability":"payable","type":"function"},
+{"inputs":[{"name":"addr","type":"address"}],"name":"getEthBalance","outputs":[{"name":"balance","type":"uint256"}],"stateMutability":"view","type":"function"}
+]`
+
+// multicallAbi is the parsed ABI of the Multicall contract.
+var multicallAbi = mustParseAbi(MulticallAbi)
+
+// multicallCall represents a single call of the Multicall aggregation input.
+type multicallCall struct {
+	Target   common.Address
+	CallData []byte
+}
+
+// multicallResult represents a single result of the Multicall aggregation output.
+type multicallResult struct {
+	Success    bool
+	ReturnData []byte
+}
+
+// mustParseAbi parses the given ABI definition, or panics.
+func mustParseAbi(def string) abi.ABI {
+	ab, err := abi.JSON(strings.NewReader(def))
+	if err != nil {
+		panic(err)
+	}
+	return ab
+}
+
+// Multicall executes the given read-only calls in a single aggregated call
+// of the Multicall contract at the given address. Failing calls do not fail
+// the aggregation; they are reported in their result.
+func (ftm *FtmBridge) Multicall(mc *common.Address, calls []types.ContractCall) ([]types.ContractCallResult, error) {
+	in := make([]multicallCall, len(calls))
+	for i := range calls {
+		in[i] = multicallCall{Target: calls[i].Target, CallData: calls[i].Data}
+	}
+
+	data, err := multicallAbi.Pack("tryAggregate", false, in)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), multicallTimeout)
+	defer cancel()
+
+	raw, err := ftm.eth.CallContract(ctx, ethereum.CallMsg{To: mc, Data: data}, nil)
+	if err != nil {
+		ftm.log.Errorf("multicall of %d calls failed; %s", len(calls), err.Error())
+		return nil, err
+	}
+
+	out, err := multicallAbi.Unpack("tryAggregate", raw)
+	if err != nil || len(out) != 1 {
+		return nil, fmt.Errorf("invalid multicall response; %v", err)
+	}
+
+	res, ok := abi.ConvertType(out[0], new([]multicallResult)).(*[]multicallResult)
+	if !ok || len(*res) != len(calls) {
+		return nil, fmt.Errorf("invalid multicall response size")
+	}
+
+	list := make([]types.ContractCallResult, len(calls))
+	for i, r := range *res {
+		list[i] = types.ContractCallResult{Success: r.Success, Data: r.ReturnData}
+	}
+	return list, nil
+}
+
+// ContractCalls executes the given read-only calls as individual calls
+// sent to the node in a single batch. Failing calls are reported in their result.
+func (ftm *FtmBridge) ContractCalls(calls []types.ContractCall) ([]types.ContractCallResult, error) {
+	out := make([]hexutil.Bytes, len(calls))
+	batch := make([]eth.BatchElem, len(calls))
+	for i := range calls {
+		batch[i] = eth.BatchElem{
+			Method: "eth_call",
+			Args: []interface{}{map[string]interface{}{
+				"to":   calls[i].Target,
+				"data": hexutil.Bytes(calls[i].Data),
+			}, BlockTypeLatest},
+			Result: &out[i],
+		}
+	}
+	if err := ftm.batchCall(batch); err != nil {
+		ftm.log.Errorf("can not execute %d contract calls; %s", len(calls), err.Error())
+		return nil, err
+	}
+
+	list := make([]types.ContractCallResult, len(calls))
+	for i := range batch {
+		list[i] = types.ContractCallResult{Success: batch[i].Error == nil, Data: out[i]}
+	}
+	return list, nil
+}
@@ -0,0 +1,37 @@
+package rpc
+
+import (
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/onsi/gomega"
+	"testing"
+)
+
+// TestMulticallEncoding tests the aggregated calls are packed and their results decoded.
+func TestMulticallEncoding(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	calls := []multicallCall{
+		{Target: common.HexToAddress("0x01"), CallData: []byte{0x70, 0xa0, 0x82, 0x31}},
+		{Target: common.HexToAddress("0x02"), CallData: []byte{}},
+	}
+	data, err := multicallAbi.Pack("tryAggregate", false, calls)
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(data[:4]).To(gomega.Equal(multicallAbi.Methods["tryAggregate"].ID))
+
+	raw, err := multicallAbi.Methods["tryAggregate"].Outputs.Pack([]multicallResult{
+		{Success: true, ReturnData: common.LeftPadBytes([]byte{0x2a}, 32)},
+		{Success: false, ReturnData: []byte{}},
+	})
+	g.Expect(err).To(gomega.BeNil())
+
+	out, err := multicallAbi.Unpack("tryAggregate", raw)
+	g.Expect(err).To(gomega.BeNil())
+
+	res, ok := abi.ConvertType(out[0], new([]multicallResult)).(*[]multicallResult)
+	g.Expect(ok).To(gomega.BeTrue())
+	g.Expect(*res).To(gomega.HaveLen(2))
+	g.Expect((*res)[0].Success).To(gomega.BeTrue())
+	g.Expect((*res)[0].ReturnData[31]).To(gomega.Equal(byte(0x2a)))
+	g.Expect((*res)[1].Success).To(gomega.BeFalse())
+}
@@ -0,0 +1,133 @@
+/*
+Package rpc implements bridge to Lachesis full node API interface.
+
+We recommend using local IPC for fast and the most efficient inter-process communication between the API server
+and an Opera/Lachesis node. Any remote RPC connection will work, but the performance may be significantly degraded
+by extra networking overhead of remote RPC calls.
+
+You should also consider security implications of opening Lachesis RPC interface for a remote access.
+If you considering it as your deployment strategy, you should establish encrypted channel between the API server
+and Lachesis RPC interface with connection limited to specified endpoints.
+
+We strongly discourage opening Lachesis RPC interface for unrestricted Internet access.
+*/
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"strings"
+	"time"
+)
+
+// nameServiceAbi is the minimal ABI of the ENS compatible registry and resolver contracts.
+const nameServiceAbi = `[
+{"type":"function","name":"resolver","stateMutability":"view","inputs":[{"name":"node","type":"bytes32"}],"outputs":[{"name":"","type":"address"}]},
+{"type":"function","name":"name","stateMutability":"view","inputs":[{"name":"node","type":"bytes32"}],"outputs":[{"name":"","type":"string"}]},
+{"type":"function","name":"addr","stateMutability":"view","inputs":[{"name":"node","type":"bytes32"}],"outputs":[{"name":"","type":"address"}]}
+]`
+
+// nameServiceCallTimeout is the max time a single name service call can take.
+const nameServiceCallTimeout = 5 * time.Second
+
+// nameServiceReverseSuffix is the suffix of names used for the reverse resolution.
+const nameServiceReverseSuffix = ".addr.reverse"
+
+// nsAbi is the parsed name service ABI.
+var nsAbi, _ = abi.JSON(strings.NewReader(nameServiceAbi))
+
+// NameHash calculates the ENS name hash of the given name.
+func NameHash(name string) common.Hash {
+	var node common.Hash
+	if name == "" {
+		return node
+	}
+
+	labels := strings.Split(strings.ToLower(name), ".")
+	for i := len(labels) - 1; i >= 0; i-- {
+		node = crypto.Keccak256Hash(node.Bytes(), crypto.Keccak256([]byte(labels[i])))
+	}
+	return node
+}
+
+// NameServiceName performs the reverse resolution of the given address using the given
+// registry contract. An empty name is returned if the address does not have a reverse record.
+func (ftm *FtmBridge) NameServiceName(registry *common.Address, addr *common.Address) (string, error) {
+	node := NameHash(strings.ToLower(addr.Hex()[2:]) + nameServiceReverseSuffix)
+
+	resolver, err := ftm.nameServiceResolver(registry, node)
+	if err != nil || resolver == nil {
+		return "", err
+	}
+
+	out, err := ftm.nameServiceCall(resolver, "name", node)
+	if err != nil {
+		return "", err
+	}
+	return *abi.ConvertType(out[0], new(string)).(*string), nil
+}
+
+// NameServiceAddress performs the forward resolution of the given name using the given
+// registry contract. Nil is returned if the name does not resolve to an address.
+func (ftm *FtmBridge) NameServiceAddress(registry *common.Address, name string) (*common.Address, error) {
+	node := NameHash(name)
+
+	resolver, err := ftm.nameServiceResolver(registry, node)
+	if err != nil || resolver == nil {
+		return nil, err
+	}
+
+	out, err := ftm.nameServiceCall(resolver, "addr", node)
+	if err != nil {
+		return nil, err
+	}
+
+	adr := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+	if adr == (common.Address{}) {
+		return nil, nil
+	}
+	return &adr, nil
+}
+
+// nameServiceResolver provides the address of the resolver contract responsible
+// for the given node, nil if the node does not have a resolver.
+func (ftm *FtmBridge) nameServiceResolver(registry *common.Address, node common.Hash) (*common.Address, error) {
+	out, err := ftm.nameServiceCall(registry, "resolver", node)
+	if err != nil {
+		return nil, err
+	}
+
+	resolver := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+	if resolver == (common.Address{}) {
+		return nil, nil
+	}
+	return &resolver, nil
+}
+
+// nameServiceCall calls the given name service contract function with the node
+// and decodes the output.
+func (ftm *FtmBridge) nameServiceCall(contract *common.Address, fn string, node common.Hash) ([]interface{}, error) {
+	data, err := nsAbi.Pack(fn, node)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), nameServiceCallTimeout)
+	defer cancel()
+
+	res, err := ftm.eth.CallContract(ctx, ethereum.CallMsg{To: contract, Data: data}, nil)
+	if err != nil {
+		ftm.log.Debugf("name service call %s at %s failed; %s", fn, contract.String(), err.Error())
+		return nil, err
+	}
+
+	out, err := nsAbi.Unpack(fn, res)
+	if err != nil || len(out) == 0 {
+		return nil, fmt.Errorf("invalid name service %s response from %s", fn, contract.String())
+	}
+	return out, nil
+}
@@ -0,0 +1,16 @@
+package rpc
+
+import (
+	"github.com/onsi/gomega"
+	"testing"
+)
+
+func TestNameHash(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	// reference values from EIP-137
+	g.Expect(NameHash("").Hex()).To(gomega.Equal("0x0000000000000000000000000000000000000000000000000000000000000000"))
+	g.Expect(NameHash("eth").Hex()).To(gomega.Equal("0x93cdeb708b7545dc668eb9280176169d1c33cfd8ed6f04690a0bcc88a93fc4ae"))
+	g.Expect(NameHash("foo.eth").Hex()).To(gomega.Equal("0xde9b09fd7c5f901e23a3f19fecc54828e9c848539801e86591bd9801b019f84f"))
+	g.Expect(NameHash("Foo.ETH")).To(gomega.Equal(NameHash("foo.eth")))
+}
@@ -0,0 +1,69 @@
+/*
+Package rpc implements bridge to Lachesis full node API interface.
+
+We recommend using local IPC for fast and the most efficient inter-process communication between the API server
+and an Opera/Lachesis node. Any remote RPC connection will work, but the performance may be significantly degraded
+by extra networking overhead of remote RPC calls.
+
+You should also consider security implications of opening Lachesis RPC interface for a remote access.
+If you considering it as your deployment strategy, you should establish encrypted channel between the API server
+and Lachesis RPC interface with connection limited to specified endpoints.
+
+We strongly discourage opening Lachesis RPC interface for unrestricted Internet access.
+*/
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	eth "github.com/ethereum/go-ethereum/rpc"
+)
+
+// NetworkInfo collects the metadata of the connected node and its network in a single batch call.
+func (ftm *FtmBridge) NetworkInfo(ctx context.Context) (*types.NetworkInfo, error) {
+	var ni types.NetworkInfo
+	var syncing json.RawMessage
+	var head struct {
+		Number    hexutil.Uint64 `json:"number"`
+		Hash      common.Hash    `json:"hash"`
+		TimeStamp hexutil.Uint64 `json:"timestamp"`
+	}
+
+	batch := []eth.BatchElem{
+		{Method: "eth_chainId", Result: &ni.ChainID},
+		{Method: "web3_clientVersion", Result: &ni.ClientVersion},
+		{Method: "eth_syncing", Result: &syncing},
+		{Method: "ftm_getBlockByNumber", Args: []interface{}{BlockTypeLatest, false}, Result: &head},
+	}
+	if err := ftm.batchCallContext(ctx, batch); err != nil {
+		ftm.log.Errorf("can not collect network info; %s", err.Error())
+		return nil, err
+	}
+	for _, be := range batch {
+		if be.Error != nil {
+			ftm.log.Errorf("can not collect network info; %s failed; %s", be.Method, be.Error.Error())
+			return nil, fmt.Errorf("%s failed; %s", be.Method, be.Error.Error())
+		}
+	}
+
+	// the node responds with false if not syncing, or with the sync progress
+	if s := string(syncing); s != "false" && s != "null" {
+		ni.IsSyncing = true
+
+		var progress struct {
+			HighestBlock hexutil.Uint64 `json:"highestBlock"`
+		}
+		if err := json.Unmarshal(syncing, &progress); err == nil {
+			ni.HighestBlock = &progress.HighestBlock
+		}
+	}
+
+	ni.BlockNumber = head.Number
+	ni.BlockHash = head.Hash
+	ni.BlockTime = head.TimeStamp
+	return &ni, nil
+}
@@ -0,0 +1,27 @@
+/*
+Package rpc implements bridge to Lachesis full node API interface.
+
+We recommend using local IPC for fast and the most efficient inter-process communication between the API server
+and an Opera/Lachesis node. Any remote RPC connection will work, but the performance may be significantly degraded
+by extra networking overhead of remote RPC calls.
+
+You should also consider security implications of opening Lachesis RPC interface for a remote access.
+If you considering it as your deployment strategy, you should establish encrypted channel between the API server
+and Lachesis RPC interface with connection limited to specified endpoints.
+
+We strongly discourage opening Lachesis RPC interface for unrestricted Internet access.
+*/
+package rpc
+
+import (
+	"context"
+	"github.com/ethereum/go-ethereum"
+)
+
+// observePending collects hashes of new pending transactions from the blockchain node
+// and posts them into the proxy channel for processing.
+func (ftm *FtmBridge) observePending() {
+	ftm.observe("pending transactions", func() (ethereum.Subscription, error) {
+		return ftm.rpc.EthSubscribe(context.Background(), ftm.pending, "newPendingTransactions")
+	})
+}
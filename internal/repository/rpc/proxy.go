@@ -0,0 +1,58 @@
+/*
+Package rpc implements bridge to Lachesis full node API interface.
+
+We recommend using local IPC for fast and the most efficient inter-process communication between the API server
+and an Opera/Lachesis node. Any remote RPC connection will work, but the performance may be significantly degraded
+by extra networking overhead of remote RPC calls.
+
+You should also consider security implications of opening Lachesis RPC interface for a remote access.
+If you considering it as your deployment strategy, you should establish encrypted channel between the API server
+and Lachesis RPC interface with connection limited to specified endpoints.
+
+We strongly discourage opening Lachesis RPC interface for unrestricted Internet access.
+*/
+package rpc
+
+import (
+	"context"
+	"github.com/ethereum/go-ethereum/common"
+	"math/big"
+	"time"
+)
+
+// eip1967ImplementationSlot is the storage slot of the implementation address
+// used by EIP-1967 transparent and UUPS proxies; keccak256("eip1967.proxy.implementation") - 1
+var eip1967ImplementationSlot = common.HexToHash("0x360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc")
+
+// proxySlotTimeout is the time limit of a storage slot lookup.
+const proxySlotTimeout = 5 * time.Second
+
+// ProxyImplementation reads the EIP-1967 implementation slot of the given contract
+// at the given block; the latest state is used for nil block.
+// Nil is returned if the contract is not an EIP-1967 proxy.
+func (ftm *FtmBridge) ProxyImplementation(addr *common.Address, block *big.Int) (*common.Address, error) {
+	val, err := ftm.StorageAt(addr, eip1967ImplementationSlot, block)
+	if err != nil {
+		ftm.log.Debugf("can not read proxy slot of %s; %s", addr.String(), err.Error())
+		return nil, err
+	}
+
+	impl := common.BytesToAddress(val.Bytes())
+	if impl == (common.Address{}) {
+		return nil, nil
+	}
+	return &impl, nil
+}
+
+// StorageAt reads the value of the given storage slot of the contract
+// at the given block; the latest state is used for nil block.
+func (ftm *FtmBridge) StorageAt(addr *common.Address, slot common.Hash, block *big.Int) (common.Hash, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), proxySlotTimeout)
+	defer cancel()
+
+	val, err := ftm.eth.StorageAt(ctx, *addr, slot, block)
+	if err != nil {
+		return common.Hash{}, archiveError(err)
+	}
+	return common.BytesToHash(val), nil
+}
@@ -0,0 +1,174 @@
+/*
+Package rpc implements bridge to Lachesis full node API interface.
+
+We recommend using local IPC for fast and the most efficient inter-process communication between the API server
+and an Opera/Lachesis node. Any remote RPC connection will work, but the performance may be significantly degraded
+by extra networking overhead of remote RPC calls.
+
+You should also consider security implications of opening Lachesis RPC interface for a remote access.
+If you considering it as your deployment strategy, you should establish encrypted channel between the API server
+and Lachesis RPC interface with connection limited to specified endpoints.
+
+We strongly discourage opening Lachesis RPC interface for unrestricted Internet access.
+*/
+package rpc
+
+import (
+	"bytes"
+	"errors"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	eth "github.com/ethereum/go-ethereum/rpc"
+)
+
+// revertErrorCode is the code of the revert error provided in GraphQL error extensions.
+const revertErrorCode = "EXECUTION_REVERTED"
+
+// revertRpcErrorCode is the JSON-RPC error code of a reverted call carrying the returned data.
+const revertRpcErrorCode = 3
+
+// revertMessagePrefix is the prefix of the node error message of a reverted call.
+const revertMessagePrefix = "execution reverted"
+
+// panicSelector is the selector of the Solidity Panic(uint256) error.
+var panicSelector = crypto.Keccak256([]byte("Panic(uint256)"))[:4]
+
+// panicReasons maps the Solidity panic codes to their description.
+var panicReasons = map[uint64]string{
+	0x00: "generic compiler panic",
+	0x01: "assertion failed",
+	0x11: "arithmetic overflow or underflow",
+	0x12: "division or modulo by zero",
+	0x21: "invalid enum value",
+	0x22: "invalid storage byte array encoding",
+	0x31: "pop on empty array",
+	0x32: "array index out of bounds",
+	0x41: "out of memory",
+	0x51: "call to uninitialized internal function",
+}
+
+// RevertError represents an error of a reverted contract call
+// with the revert reason decoded from the returned data, if possible.
+type RevertError struct {
+	// Reason is the human readable revert reason; empty if not known.
+	Reason string
+
+	// Data is the raw data returned by the reverted call.
+	Data hexutil.Bytes
+}
+
+// Error returns the error message of the reverted call.
+func (e *RevertError) Error() string {
+	if e.Reason == "" {
+		return revertMessagePrefix
+	}
+	return fmt.Sprintf("%s: %s", revertMessagePrefix, e.Reason)
+}
+
+// Extensions provides structured details of the revert for the GraphQL error response.
+func (e *RevertError) Extensions() map[string]interface{} {
+	return map[string]interface{}{
+		"code":   revertErrorCode,
+		"reason": e.Reason,
+		"data":   e.Data.String(),
+	}
+}
+
+// DecodeRevertReason decodes the human readable revert reason from the data
+// returned by a reverted call. Both the Error(string) and the Panic(uint256)
+// standard errors are recognized; an empty string is returned otherwise.
+func DecodeRevertReason(data []byte) string {
+	if reason, err := abi.UnpackRevert(data); err == nil {
+		return reason
+	}
+
+	// panic carries a single uint256 code
+	if len(data) == 4+32 && bytes.Equal(data[:4], panicSelector) {
+		code := new(big.Int).SetBytes(data[4:])
+		if code.IsUint64() {
+			if reason, ok := panicReasons[code.Uint64()]; ok {
+				return fmt.Sprintf("panic: %s (0x%x)", reason, code.Uint64())
+			}
+		}
+		return fmt.Sprintf("panic: 0x%x", code)
+	}
+	return ""
+}
+
+// revertError converts the node error of a reverted call into the revert error.
+// The revert is recognized by the error code, or the message of the node error.
+// Other errors are returned unchanged.
+func revertError(err error) error {
+	if err == nil {
+		return err
+	}
+
+	var ee eth.Error
+	isCode := errors.As(err, &ee) && ee.ErrorCode() == revertRpcErrorCode
+	if !isCode && !strings.HasPrefix(err.Error(), revertMessagePrefix) {
+		return err
+	}
+
+	// the node may provide the returned data along with the error
+	re := RevertError{Data: make(hexutil.Bytes, 0)}
+	var de eth.DataError
+	if errors.As(err, &de) {
+		if s, ok := de.ErrorData().(string); ok {
+			if data, err := hexutil.Decode(s); err == nil {
+				re.Data = data
+				re.Reason = DecodeRevertReason(data)
+			}
+		}
+	}
+
+	// use the reason from the message, if the data did not provide any
+	if re.Reason == "" {
+		re.Reason = strings.TrimSpace(strings.TrimPrefix(strings.TrimPrefix(err.Error(), revertMessagePrefix), ":"))
+	}
+	return &re
+}
+
+// TransactionRevertReason replays the call of the given failed transaction on the state
+// of the block preceding the transaction block and decodes the reason of the revert.
+// The state of the transactions preceding the replayed one in the same block is not available,
+// so the replay may not fail; nil is returned in that case.
+func (ftm *FtmBridge) TransactionRevertReason(trx *types.Transaction) (*types.RevertReason, error) {
+	if trx.BlockNumber == nil || *trx.BlockNumber == 0 {
+		return nil, fmt.Errorf("transaction %s has not been processed", trx.Hash.String())
+	}
+
+	// keep track of the operation
+	ftm.log.Debugf("replaying transaction %s", trx.Hash.String())
+
+	args := map[string]interface{}{
+		"from":     trx.From,
+		"gas":      trx.Gas,
+		"gasPrice": trx.GasPrice,
+		"value":    trx.Value,
+		"data":     trx.InputData,
+	}
+	if trx.To != nil {
+		args["to"] = trx.To
+	}
+
+	var res hexutil.Bytes
+	err := ftm.rpc.Call(&res, "ftm_call", args, hexutil.Uint64(*trx.BlockNumber-1))
+	if err == nil {
+		return nil, nil
+	}
+
+	// we are looking for the revert; other errors, e.g. a missing state of a pruned node,
+	// do not explain the failure and must not be remembered as the reason
+	if re, ok := revertError(err).(*RevertError); ok {
+		return &types.RevertReason{Reason: re.Reason, Data: re.Data}, nil
+	}
+
+	ftm.log.Errorf("can not replay transaction %s; %s", trx.Hash.String(), err.Error())
+	return nil, archiveError(err)
+}
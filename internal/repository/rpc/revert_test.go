@@ -0,0 +1,83 @@
+package rpc
+
+import (
+	"errors"
+	"fantom-api-graphql/internal/config"
+	"fantom-api-graphql/internal/logger"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	eth "github.com/ethereum/go-ethereum/rpc"
+	"github.com/onsi/gomega"
+	"testing"
+)
+
+// testCallError represents a JSON-RPC error of a simulated call.
+type testCallError struct {
+	code int
+	msg  string
+	data interface{}
+}
+
+func (e *testCallError) Error() string          { return e.msg }
+func (e *testCallError) ErrorCode() int         { return e.code }
+func (e *testCallError) ErrorData() interface{} { return e.data }
+
+// testCallNode simulates the ftm namespace of a node failing the call by the given error.
+type testCallNode struct {
+	err error
+}
+
+// Call implements the ftm_call method.
+func (n *testCallNode) Call(args map[string]interface{}, block hexutil.Uint64) (hexutil.Bytes, error) {
+	return nil, n.err
+}
+
+// TestTransactionRevertReason tests only reverts are provided as the revert reason,
+// other errors of the replay are returned.
+func TestTransactionRevertReason(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	reason, err := abi.Arguments{{Type: abi.Type{T: abi.StringTy}}}.Pack("not allowed")
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	data := append([]byte{0x08, 0xc3, 0x79, 0xa0}, reason...)
+
+	blk := hexutil.Uint64(10)
+	trx := &types.Transaction{BlockNumber: &blk}
+
+	tests := []struct {
+		name    string
+		err     error
+		revert  bool
+		reason  string
+		archive bool
+	}{
+		{name: "revert with data", err: &testCallError{code: 3, msg: "execution reverted: not allowed", data: hexutil.Encode(data)}, revert: true, reason: "not allowed"},
+		{name: "revert by code", err: &testCallError{code: 3, msg: "reverted", data: hexutil.Encode(data)}, revert: true, reason: "not allowed"},
+		{name: "revert without data", err: &testCallError{code: -32000, msg: "execution reverted"}, revert: true},
+		{name: "pruned state", err: &testCallError{code: -32000, msg: "missing trie node 7c4e1ab2c0f1 (path )"}, archive: true},
+		{name: "out of gas", err: &testCallError{code: -32000, msg: "out of gas"}},
+	}
+
+	for _, tc := range tests {
+		srv := eth.NewServer()
+		g.Expect(srv.RegisterName("ftm", &testCallNode{err: tc.err})).To(gomega.Succeed())
+
+		br := &FtmBridge{
+			rpc: eth.DialInProc(srv),
+			log: logger.New(&config.Config{Log: config.Log{Level: "CRITICAL", Format: "%{message}"}}),
+		}
+
+		rr, err := br.TransactionRevertReason(trx)
+		if tc.revert {
+			g.Expect(err).NotTo(gomega.HaveOccurred(), tc.name)
+			g.Expect(rr).NotTo(gomega.BeNil(), tc.name)
+			g.Expect(rr.Reason).To(gomega.Equal(tc.reason), tc.name)
+		} else {
+			g.Expect(err).To(gomega.HaveOccurred(), tc.name)
+			g.Expect(rr).To(gomega.BeNil(), tc.name)
+			g.Expect(errors.Is(err, ErrArchiveRequired)).To(gomega.Equal(tc.archive), tc.name)
+		}
+		srv.Stop()
+	}
+}
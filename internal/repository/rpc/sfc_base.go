@@ -116,6 +116,11 @@ func (ftm *FtmBridge) SfcMaxDelegatedRatio() (*big.Int, error) {
 	return ftm.SfcContract().MaxDelegatedRatio(ftm.DefaultCallOpts())
 }
 
+// SfcValidatorCommission extracts the validator commission rate.
+func (ftm *FtmBridge) SfcValidatorCommission() (*big.Int, error) {
+	return ftm.SfcContract().ValidatorCommission(ftm.DefaultCallOpts())
+}
+
 // SfcMinLockupDuration extracts a minimal lockup duration.
 func (ftm *FtmBridge) SfcMinLockupDuration() (*big.Int, error) {
 	return ftm.SfcContract().MinLockupDuration(ftm.DefaultCallOpts())
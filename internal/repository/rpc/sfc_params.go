@@ -0,0 +1,70 @@
+package rpc
+
+import (
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"math/big"
+)
+
+// sfcMinDelegationSelector is the selector of the minDelegation() call
+// provided by the SFC contracts enforcing a minimal delegation.
+var sfcMinDelegationSelector = crypto.Keccak256([]byte("minDelegation()"))[:4]
+
+// SfcStakingParams reads the staking protocol parameters of the SFC contract.
+// All the values are read at the same block, so they are consistent with each other.
+func (ftm *FtmBridge) SfcStakingParams() (*types.StakingParams, error) {
+	head, err := ftm.BlockHeight()
+	if err != nil {
+		return nil, err
+	}
+
+	opts := *ftm.DefaultCallOpts()
+	opts.BlockNumber = head.ToInt()
+
+	sfc := ftm.SfcContract()
+	sp := types.StakingParams{Block: hexutil.Uint64(head.ToInt().Uint64())}
+	for _, v := range []struct {
+		to   *hexutil.Big
+		call func(*bind.CallOpts) (*big.Int, error)
+	}{
+		{&sp.WithdrawalPeriodEpochs, sfc.WithdrawalPeriodEpochs},
+		{&sp.WithdrawalPeriodTime, sfc.WithdrawalPeriodTime},
+		{&sp.MinSelfStake, sfc.MinSelfStake},
+		{&sp.MaxDelegatedRatio, sfc.MaxDelegatedRatio},
+		{&sp.MinLockupDuration, sfc.MinLockupDuration},
+		{&sp.MaxLockupDuration, sfc.MaxLockupDuration},
+	} {
+		val, err := v.call(&opts)
+		if err != nil {
+			ftm.log.Errorf("can not read SFC staking parameters; %s", err.Error())
+			return nil, err
+		}
+		*v.to = hexutil.Big(*val)
+	}
+
+	ep, err := sfc.CurrentEpoch(&opts)
+	if err != nil {
+		ftm.log.Errorf("can not read SFC current epoch; %s", err.Error())
+		return nil, err
+	}
+	sp.Epoch = hexutil.Uint64(ep.Uint64())
+
+	sp.MinDelegation = ftm.sfcMinDelegation(head)
+	return &sp, nil
+}
+
+// sfcMinDelegation reads the minimal delegation of the SFC contract at the given block;
+// nil is returned if the contract does not provide it.
+func (ftm *FtmBridge) sfcMinDelegation(block *hexutil.Big) *hexutil.Big {
+	var res hexutil.Bytes
+	err := ftm.rpc.Call(&res, "eth_call", map[string]interface{}{
+		"to":   ftm.sfcConfig.SFCContract,
+		"data": hexutil.Bytes(sfcMinDelegationSelector),
+	}, block.String())
+	if err != nil || len(res) < 32 {
+		return nil
+	}
+	return (*hexutil.Big)(new(big.Int).SetBytes(res[:32]))
+}
@@ -14,6 +14,7 @@ We strongly discourage opening Lachesis RPC interface for unrestricted Internet
 package rpc
 
 import (
+	"errors"
 	"fantom-api-graphql/internal/types"
 	"fmt"
 	"github.com/ethereum/go-ethereum/common"
@@ -21,6 +22,9 @@ import (
 	"math/big"
 )
 
+// ErrValidatorNotFound is returned if the requested validator does not exist.
+var ErrValidatorNotFound = errors.New("validator not found")
+
 // ValidatorDowntime pulls information about validator downtime from the RPC interface.
 func (ftm *FtmBridge) ValidatorDowntime(valID *hexutil.Big) (uint64, uint64, error) {
 	// use rather the public API, it should be faster since it does not involve contract call
@@ -48,6 +52,18 @@ func (ftm *FtmBridge) ValidatorEpochUptime(valID *hexutil.Big) (uint64, error) {
 	return uint64(ut), nil
 }
 
+// ValidatorEpochRewardPerToken pulls the reward per staked token accumulated
+// by the delegators of the validator since its creation up to the given epoch.
+// The value is in SFC decimal units and it's already reduced by the validator commission.
+func (ftm *FtmBridge) ValidatorEpochRewardPerToken(valID *hexutil.Big, epoch hexutil.Uint64) (*big.Int, error) {
+	val, err := ftm.SfcContract().GetEpochAccumulatedRewardPerToken(ftm.DefaultCallOpts(), new(big.Int).SetUint64(uint64(epoch)), valID.ToInt())
+	if err != nil {
+		ftm.log.Errorf("failed to get reward per token of validator #%d at epoch #%d; %s", valID.ToInt().Uint64(), uint64(epoch), err.Error())
+		return nil, err
+	}
+	return val, nil
+}
+
 // LastValidatorId returns the last staker id in Opera blockchain.
 func (ftm *FtmBridge) LastValidatorId() (uint64, error) {
 	// get the value from the contract
@@ -103,7 +119,7 @@ func (ftm *FtmBridge) validatorById(valID *big.Int) (*types.Validator, error) {
 	// any creation record?
 	if 0 == val.CreatedTime.Uint64() {
 		ftm.log.Errorf("validator #%d has zero created time, assuming empty record", valID.Uint64())
-		return nil, fmt.Errorf("validator #%d not found", valID.Uint64())
+		return nil, fmt.Errorf("%w; #%d", ErrValidatorNotFound, valID.Uint64())
 	}
 
 	// any deactivation epoch?
@@ -144,7 +160,7 @@ func (ftm *FtmBridge) ValidatorAddress(valID *big.Int) (*common.Address, error)
 	// any creation record?
 	if 0 == val.CreatedTime.Uint64() {
 		ftm.log.Errorf("validator #%d has zero created time, assuming empty record", valID.Uint64())
-		return nil, fmt.Errorf("validator #%d not found", valID.Uint64())
+		return nil, fmt.Errorf("%w; #%d", ErrValidatorNotFound, valID.Uint64())
 	}
 
 	ftm.log.Debugf("validator #%d is %s", valID.Uint64(), val.Auth.String())
@@ -0,0 +1,72 @@
+/*
+Package rpc implements bridge to Opera/Lachesis full node API interface.
+
+We recommend using local IPC for fast and the most efficient inter-process communication between the API server
+and an Opera/Lachesis node. Any remote RPC connection will work, but the performance may be significantly degraded
+by extra networking overhead of remote RPC calls.
+
+You should also consider security implications of opening Opera/Lachesis RPC interface for remote access.
+If you considering it as your deployment strategy, you should establish encrypted channel between the API server
+and Opera/Lachesis RPC interface with connection limited to specified endpoints.
+
+We strongly discourage opening Opera/Lachesis RPC interface for unrestricted Internet access.
+*/
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"strings"
+	"time"
+)
+
+// SimulateCall executes the given call on the state of the given block, or the latest state,
+// with the state override applied, if any. Calls reverted by the contract are not errors,
+// the revert is reported in the result instead.
+func (ftm *FtmBridge) SimulateCall(call *types.SimulationCall, timeout time.Duration) (*types.SimulatedCall, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var block interface{} = BlockTypeLatest
+	if call.Block != nil {
+		block = *call.Block
+	}
+	if call.Tag != "" {
+		tag, err := ftm.blockTag(call.Tag)
+		if err != nil {
+			return nil, err
+		}
+		block = tag
+	}
+
+	// the override is an optional extension not all the nodes support
+	var res hexutil.Bytes
+	var err error
+	if len(call.Override) > 0 {
+		err = ftm.rpc.CallContext(ctx, &res, "eth_call", call, block, call.Override)
+	} else {
+		err = ftm.rpc.CallContext(ctx, &res, "eth_call", call, block)
+	}
+
+	if err == nil {
+		return &types.SimulatedCall{ReturnData: res}, nil
+	}
+
+	var re *RevertError
+	if errors.As(revertError(err), &re) {
+		return &types.SimulatedCall{ReturnData: re.Data, Reverted: true, RevertReason: re.Reason}, nil
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return nil, fmt.Errorf("simulated call exceeded the time limit of %s", timeout.String())
+	}
+	if len(call.Override) > 0 && strings.Contains(err.Error(), "too many arguments") {
+		return nil, fmt.Errorf("state override is not supported by the connected node")
+	}
+
+	ftm.log.Errorf("can not simulate call of %s; %s", call.To.String(), err.Error())
+	return nil, archiveError(err)
+}
@@ -0,0 +1,88 @@
+package rpc
+
+import (
+	"errors"
+	"github.com/ethereum/go-ethereum"
+	eth "github.com/ethereum/go-ethereum/rpc"
+	"time"
+)
+
+const (
+	// ftmSubscribeRetryMin represents the initial delay between subscription attempts.
+	ftmSubscribeRetryMin = time.Second
+
+	// ftmSubscribeRetryMax represents the max delay between subscription attempts.
+	ftmSubscribeRetryMax = 30 * time.Second
+)
+
+// observe keeps the subscription made by the given function alive until the bridge is closed.
+// A failed subscription, e.g. on a lost WebSocket connection, is re-established
+// with an increasing delay; the client re-dials the node on the next request,
+// so the subscription attempt also restores the connection.
+func (ftm *FtmBridge) observe(name string, subscribe func() (ethereum.Subscription, error)) {
+	var sub ethereum.Subscription
+	defer func() {
+		if sub != nil {
+			sub.Unsubscribe()
+		}
+		ftm.log.Noticef("%s observer done", name)
+		ftm.wg.Done()
+	}()
+
+	delay := ftmSubscribeRetryMin
+	for {
+		if sub == nil {
+			s, err := subscribe()
+
+			// HTTP connections can not subscribe at all, no point in trying again
+			if errors.Is(err, eth.ErrNotificationsUnsupported) {
+				ftm.log.Warningf("%s can not be observed, the node connection does not support subscriptions", name)
+				<-ftm.sigClose
+				return
+			}
+
+			if err != nil {
+				ftm.log.Errorf("can not observe %s, retry in %s; %s", name, delay.String(), err.Error())
+				if !ftm.wait(delay) {
+					return
+				}
+				if delay *= 2; delay > ftmSubscribeRetryMax {
+					delay = ftmSubscribeRetryMax
+				}
+				continue
+			}
+
+			sub = s
+			ftm.log.Noticef("observing %s", name)
+			delay = ftmSubscribeRetryMin
+		}
+
+		select {
+		case <-ftm.sigClose:
+			return
+		case err := <-sub.Err():
+			if err != nil {
+				ftm.log.Errorf("%s subscription failed; %s", name, err.Error())
+			}
+			sub = nil
+
+			// give the connection a moment before re-subscribing
+			if !ftm.wait(delay) {
+				return
+			}
+		}
+	}
+}
+
+// wait pauses for the given time; it returns false if the bridge is being closed.
+func (ftm *FtmBridge) wait(delay time.Duration) bool {
+	tm := time.NewTimer(delay)
+	defer tm.Stop()
+
+	select {
+	case <-ftm.sigClose:
+		return false
+	case <-tm.C:
+		return true
+	}
+}
@@ -0,0 +1,145 @@
+package rpc
+
+import (
+	"context"
+	"fantom-api-graphql/internal/config"
+	"fantom-api-graphql/internal/logger"
+	"github.com/onsi/gomega"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	etc "github.com/ethereum/go-ethereum/core/types"
+	eth "github.com/ethereum/go-ethereum/rpc"
+)
+
+// testHeadsNode simulates the eth namespace of a node sending new heads to subscribers.
+type testHeadsNode struct {
+	srv  *eth.Server
+	mu   sync.Mutex
+	subs map[eth.ID]*eth.Notifier
+}
+
+// NewHeads implements the newHeads subscription.
+func (n *testHeadsNode) NewHeads(ctx context.Context) (*eth.Subscription, error) {
+	notifier, _ := eth.NotifierFromContext(ctx)
+	sub := notifier.CreateSubscription()
+
+	n.mu.Lock()
+	n.subs[sub.ID] = notifier
+	n.mu.Unlock()
+	return sub, nil
+}
+
+// push sends the header of the given number to all the subscribers.
+func (n *testHeadsNode) push(num int64) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for id, notifier := range n.subs {
+		_ = notifier.Notify(id, &etc.Header{Number: big.NewInt(num), Difficulty: big.NewInt(0)})
+	}
+}
+
+// testWsServer starts a WebSocket node server on the given listener.
+func testWsServer(l net.Listener) (*httptest.Server, *testHeadsNode) {
+	node := &testHeadsNode{srv: eth.NewServer(), subs: make(map[eth.ID]*eth.Notifier)}
+	if err := node.srv.RegisterName("eth", node); err != nil {
+		panic(err)
+	}
+
+	ts := httptest.NewUnstartedServer(node.srv.WebsocketHandler([]string{"*"}))
+	ts.Listener = l
+	ts.Start()
+	return ts, node
+}
+
+// TestObserveBlocksWs tests receiving new heads over WebSocket,
+// including the re-subscription after the node connection is lost.
+func TestObserveBlocksWs(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	g.Expect(err).To(gomega.BeNil())
+	addr := l.Addr().String()
+	ts, node := testWsServer(l)
+
+	cli, err := eth.Dial("ws://" + addr)
+	g.Expect(err).To(gomega.BeNil())
+	defer cli.Close()
+
+	br := &FtmBridge{
+		rpc:      cli,
+		log:      logger.New(&config.Config{Log: config.Log{Level: "CRITICAL", Format: "%{message}"}}),
+		wg:       new(sync.WaitGroup),
+		sigClose: make(chan bool, 1),
+		headers:  make(chan *etc.Header, 10),
+	}
+	br.wg.Add(1)
+	go br.observeBlocks()
+	defer br.terminate()
+
+	// wait for the subscription and receive a head
+	g.Eventually(func() int64 {
+		node.push(1)
+		select {
+		case h := <-br.headers:
+			return h.Number.Int64()
+		case <-time.After(50 * time.Millisecond):
+			return 0
+		}
+	}, 5*time.Second).Should(gomega.Equal(int64(1)))
+
+	// drop the node and bring it back on the same address
+	node.srv.Stop()
+	ts.Close()
+
+	l, err = net.Listen("tcp", addr)
+	g.Expect(err).To(gomega.BeNil())
+	ts, node = testWsServer(l)
+	defer ts.Close()
+
+	g.Eventually(func() int64 {
+		node.push(2)
+		select {
+		case h := <-br.headers:
+			return h.Number.Int64()
+		case <-time.After(50 * time.Millisecond):
+			return 0
+		}
+	}, 10*time.Second).Should(gomega.Equal(int64(2)))
+}
+
+// TestObserveBlocksHttp tests the observer gives up on connections without subscriptions.
+func TestObserveBlocksHttp(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	srv := eth.NewServer()
+	ts := httptest.NewServer(http.Handler(srv))
+	defer ts.Close()
+
+	cli, err := eth.Dial(ts.URL)
+	g.Expect(err).To(gomega.BeNil())
+	defer cli.Close()
+
+	br := &FtmBridge{
+		rpc:      cli,
+		log:      logger.New(&config.Config{Log: config.Log{Level: "CRITICAL", Format: "%{message}"}}),
+		wg:       new(sync.WaitGroup),
+		sigClose: make(chan bool, 1),
+		headers:  make(chan *etc.Header, 10),
+	}
+	br.wg.Add(1)
+	go br.observeBlocks()
+
+	// the observer waits for the close signal only
+	done := make(chan struct{})
+	go func() {
+		br.terminate()
+		close(done)
+	}()
+	g.Eventually(done, 2*time.Second).Should(gomega.BeClosed())
+}
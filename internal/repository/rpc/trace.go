@@ -0,0 +1,193 @@
+/*
+Package rpc implements bridge to Lachesis full node API interface.
+
+We recommend using local IPC for fast and the most efficient inter-process communication between the API server
+and an Opera/Lachesis node. Any remote RPC connection will work, but the performance may be significantly degraded
+by extra networking overhead of remote RPC calls.
+
+You should also consider security implications of opening Lachesis RPC interface for a remote access.
+If you considering it as your deployment strategy, you should establish encrypted channel between the API server
+and Lachesis RPC interface with connection limited to specified endpoints.
+
+We strongly discourage opening Lachesis RPC interface for unrestricted Internet access.
+*/
+package rpc
+
+import (
+	"errors"
+	"fantom-api-graphql/internal/types"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ftm "github.com/ethereum/go-ethereum/rpc"
+)
+
+// ErrTraceNotSupported is returned if the connected node does not provide any transaction tracing API.
+var ErrTraceNotSupported = errors.New("transaction tracing is not supported by the node")
+
+// rpcMethodNotFoundCode is the JSON-RPC error code of a call to an unknown method.
+const rpcMethodNotFoundCode = -32601
+
+// parityTraceSelfDestruct is the type of the trace API record of a contract self-destruct.
+const parityTraceSelfDestruct = "suicide"
+
+// callTrace represents a single frame of the debug API call tracer output.
+type callTrace struct {
+	Type    string          `json:"type"`
+	From    common.Address  `json:"from"`
+	To      *common.Address `json:"to"`
+	Value   *hexutil.Big    `json:"value"`
+	Gas     hexutil.Uint64  `json:"gas"`
+	GasUsed hexutil.Uint64  `json:"gasUsed"`
+	Input   hexutil.Bytes   `json:"input"`
+	Error   string          `json:"error"`
+	Calls   []callTrace     `json:"calls"`
+}
+
+// parityTrace represents a single record of the trace API output.
+type parityTrace struct {
+	Type   string `json:"type"`
+	Action struct {
+		CallType string          `json:"callType"`
+		From     common.Address  `json:"from"`
+		To       *common.Address `json:"to"`
+		Value    *hexutil.Big    `json:"value"`
+		Gas      hexutil.Uint64  `json:"gas"`
+		Input    hexutil.Bytes   `json:"input"`
+		Init     hexutil.Bytes   `json:"init"`
+
+		// self-destruct details
+		Address       *common.Address `json:"address"`
+		RefundAddress *common.Address `json:"refundAddress"`
+		Balance       *hexutil.Big    `json:"balance"`
+	} `json:"action"`
+	Result *struct {
+		GasUsed hexutil.Uint64  `json:"gasUsed"`
+		Address *common.Address `json:"address"`
+	} `json:"result"`
+	TraceAddress []int32 `json:"traceAddress"`
+	Error        string  `json:"error"`
+}
+
+// InternalTransactions extracts internal calls of the given transaction from its call trace.
+// The debug tracing API is used if available, the trace API is used as a fallback.
+// The top level call, which is the transaction itself, is not included.
+func (ftm *FtmBridge) InternalTransactions(hash *common.Hash) ([]*types.InternalTransaction, error) {
+	// keep track of the operation
+	ftm.log.Debugf("tracing transaction %s", hash.String())
+
+	// try the debug call tracer first
+	var root callTrace
+	err := ftm.rpc.Call(&root, "debug_traceTransaction", hash, map[string]string{"tracer": "callTracer"})
+	if err == nil {
+		list := make([]*types.InternalTransaction, 0)
+		for i := range root.Calls {
+			list = flattenCallTrace(hash, &root.Calls[i], []int32{int32(i)}, list)
+		}
+		return list, nil
+	}
+	if !isMethodNotFound(err) {
+		ftm.log.Errorf("can not trace transaction %s; %s", hash.String(), err.Error())
+		return nil, archiveError(err)
+	}
+
+	// try the trace API
+	var traces []parityTrace
+	err = ftm.rpc.Call(&traces, "trace_transaction", hash)
+	if err != nil {
+		if isMethodNotFound(err) {
+			return nil, ErrTraceNotSupported
+		}
+
+		ftm.log.Errorf("can not trace transaction %s; %s", hash.String(), err.Error())
+		return nil, archiveError(err)
+	}
+
+	list := make([]*types.InternalTransaction, 0, len(traces))
+	for i := range traces {
+		// skip the top level call
+		if len(traces[i].TraceAddress) == 0 {
+			continue
+		}
+		list = append(list, parityTraceToInternal(hash, &traces[i]))
+	}
+	return list, nil
+}
+
+// flattenCallTrace converts the call trace frame and all its sub-calls into a flat list of internal transactions.
+func flattenCallTrace(hash *common.Hash, ct *callTrace, addr []int32, list []*types.InternalTransaction) []*types.InternalTransaction {
+	itx := types.InternalTransaction{
+		Hash:         *hash,
+		TraceAddress: addr,
+		Type:         strings.ToUpper(ct.Type),
+		From:         ct.From,
+		To:           ct.To,
+		Gas:          ct.Gas,
+		GasUsed:      ct.GasUsed,
+		Input:        ct.Input,
+		Error:        ct.Error,
+	}
+	if ct.Value != nil {
+		itx.Value = *ct.Value
+	}
+	list = append(list, &itx)
+
+	for i := range ct.Calls {
+		sub := make([]int32, len(addr)+1)
+		copy(sub, addr)
+		sub[len(addr)] = int32(i)
+
+		list = flattenCallTrace(hash, &ct.Calls[i], sub, list)
+	}
+	return list
+}
+
+// parityTraceToInternal converts the trace API record into an internal transaction.
+func parityTraceToInternal(hash *common.Hash, pt *parityTrace) *types.InternalTransaction {
+	itx := types.InternalTransaction{
+		Hash:         *hash,
+		TraceAddress: pt.TraceAddress,
+		Type:         strings.ToUpper(pt.Action.CallType),
+		From:         pt.Action.From,
+		To:           pt.Action.To,
+		Gas:          pt.Action.Gas,
+		Input:        pt.Action.Input,
+		Error:        pt.Error,
+	}
+
+	// contract creation has no call type and the target comes with the result
+	if pt.Type != "call" {
+		itx.Type = strings.ToUpper(pt.Type)
+		itx.Input = pt.Action.Init
+	}
+	if pt.Action.Value != nil {
+		itx.Value = *pt.Action.Value
+	}
+
+	// self-destruct moves the remaining balance of the contract to the refund address
+	if pt.Type == parityTraceSelfDestruct && pt.Action.Address != nil {
+		itx.Type = types.InternalTrxSelfDestruct
+		itx.From = *pt.Action.Address
+		itx.To = pt.Action.RefundAddress
+		if pt.Action.Balance != nil {
+			itx.Value = *pt.Action.Balance
+		}
+	}
+	if pt.Result != nil {
+		itx.GasUsed = pt.Result.GasUsed
+		if pt.Result.Address != nil {
+			itx.To = pt.Result.Address
+		}
+	}
+	return &itx
+}
+
+// isMethodNotFound checks if the RPC error signals the called method is not available on the node.
+func isMethodNotFound(err error) bool {
+	var re ftm.Error
+	if errors.As(err, &re) {
+		return re.ErrorCode() == rpcMethodNotFoundCode
+	}
+	return strings.Contains(err.Error(), "does not exist/is not available")
+}
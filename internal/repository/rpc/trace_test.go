@@ -0,0 +1,34 @@
+package rpc
+
+import (
+	"encoding/json"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/onsi/gomega"
+	"testing"
+)
+
+// TestParityTraceSelfDestruct tests the self-destruct record of the trace API
+// is converted into an internal transaction from the destroyed contract.
+func TestParityTraceSelfDestruct(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	var pt parityTrace
+	err := json.Unmarshal([]byte(`{
+		"type": "suicide",
+		"action": {
+			"address": "0x1111111111111111111111111111111111111111",
+			"refundAddress": "0x2222222222222222222222222222222222222222",
+			"balance": "0x64"
+		},
+		"traceAddress": [0]
+	}`), &pt)
+	g.Expect(err).To(gomega.BeNil())
+
+	hash := common.HexToHash("0x01")
+	itx := parityTraceToInternal(&hash, &pt)
+	g.Expect(itx.Type).To(gomega.Equal(types.InternalTrxSelfDestruct))
+	g.Expect(itx.From).To(gomega.Equal(common.HexToAddress("0x1111111111111111111111111111111111111111")))
+	g.Expect(*itx.To).To(gomega.Equal(common.HexToAddress("0x2222222222222222222222222222222222222222")))
+	g.Expect(itx.Value.ToInt().Int64()).To(gomega.Equal(int64(100)))
+}
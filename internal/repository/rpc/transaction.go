@@ -41,7 +41,9 @@ func (ftm *FtmBridge) Transaction(hash *common.Hash) (*types.Transaction, error)
 			CumulativeGasUsed hexutil.Uint64  `json:"cumulativeGasUsed"`
 			GasUsed           hexutil.Uint64  `json:"gasUsed"`
 			ContractAddress   *common.Address `json:"contractAddress,omitempty"`
-			Status            hexutil.Uint64  `json:"status"`
+			EffectiveGasPrice *hexutil.Big    `json:"effectiveGasPrice"`
+			Status            *hexutil.Uint64 `json:"status"`
+			Root              hexutil.Bytes   `json:"root"`
 			Logs              []retypes.Log   `json:"logs"`
 		}
 
@@ -57,8 +59,19 @@ func (ftm *FtmBridge) Transaction(hash *common.Hash) (*types.Transaction, error)
 		trx.CumulativeGasUsed = &rec.CumulativeGasUsed
 		trx.GasUsed = &rec.GasUsed
 		trx.ContractAddress = rec.ContractAddress
-		trx.Status = &rec.Status
+		trx.Status = rec.Status
 		trx.Logs = rec.Logs
+
+		// pre-Byzantium receipts carry the post transaction state root instead of the status
+		if rec.Status == nil {
+			ftm.log.Debugf("receipt of %s does not provide status; state root %s", hash.String(), rec.Root.String())
+		}
+
+		// the effective gas price is not provided before London, the gas price is paid in full
+		trx.EffectiveGasPrice = rec.EffectiveGasPrice
+		if trx.EffectiveGasPrice == nil {
+			trx.EffectiveGasPrice = &trx.GasPrice
+		}
 	}
 
 	// keep track of the operation
@@ -14,6 +14,7 @@ We strongly discourage opening Lachesis RPC interface for unrestricted Internet
 package rpc
 
 import (
+	"context"
 	"fmt"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -22,6 +23,12 @@ import (
 	"time"
 )
 
+// gasEstimateCap is the upper bound of the gas amount estimation.
+const gasEstimateCap = 50_000_000
+
+// gasEstimateTimeout is the max time we wait for the gas amount estimation.
+const gasEstimateTimeout = 5 * time.Second
+
 // maxAcceptedGasPrice defines max accepted gas price, everything above invokes additional check.
 var maxAcceptedGasPrice = big.NewInt(1_000_000_000_000_000_000)
 
@@ -59,7 +66,8 @@ func (ftm *FtmBridge) GasPrice() (hexutil.Big, error) {
 }
 
 // GasEstimate calculates the estimated amount of Gas required to perform
-// transaction described by the input params.
+// transaction described by the input params. The estimation is limited by the gas cap
+// and a short timeout; reverted calls are reported as RevertError with the decoded reason.
 func (ftm *FtmBridge) GasEstimate(trx *struct {
 	From  *common.Address
 	To    *common.Address
@@ -69,8 +77,7 @@ func (ftm *FtmBridge) GasEstimate(trx *struct {
 	// keep track of the operation
 	ftm.log.Debugf("calling for gas amount estimation")
 
-	var val hexutil.Uint64
-	err := ftm.rpc.Call(&val, "ftm_estimateGas", trx)
+	val, err := ftm.gasEstimate(gasEstimateArgs(trx))
 	if err != nil {
 		// missing required argument? incompatibility between old and new RPC API
 		if strings.Contains(err.Error(), "missing value") {
@@ -79,10 +86,10 @@ func (ftm *FtmBridge) GasEstimate(trx *struct {
 
 		// return error
 		ftm.log.Errorf("can not estimate gas; %s", err.Error())
-		return nil, err
+		return nil, revertError(err)
 	}
 
-	return &val, nil
+	return val, nil
 }
 
 // GasEstimateWithBlock calculates the estimated amount of Gas required to perform
@@ -98,13 +105,47 @@ func (ftm *FtmBridge) GasEstimateWithBlock(trx *struct {
 	// keep track of the operation
 	ftm.log.Debugf("calling for gas amount estimation with block details")
 
-	var val hexutil.Uint64
-	err := ftm.rpc.Call(&val, "ftm_estimateGas", trx, BlockTypeLatest)
+	val, err := ftm.gasEstimate(gasEstimateArgs(trx), BlockTypeLatest)
 	if err != nil {
 		// return error
 		ftm.log.Errorf("can not estimate gas; %s", err.Error())
-		return nil, err
+		return nil, revertError(err)
 	}
 
+	return val, nil
+}
+
+// gasEstimate executes the gas estimation call with the given arguments limited by the estimation timeout.
+func (ftm *FtmBridge) gasEstimate(args ...interface{}) (*hexutil.Uint64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), gasEstimateTimeout)
+	defer cancel()
+
+	var val hexutil.Uint64
+	if err := ftm.rpc.CallContext(ctx, &val, "ftm_estimateGas", args...); err != nil {
+		return nil, err
+	}
 	return &val, nil
 }
+
+// gasEstimateArgs builds the call arguments of the gas estimation limited by the gas cap.
+func gasEstimateArgs(trx *struct {
+	From  *common.Address
+	To    *common.Address
+	Value *hexutil.Big
+	Data  *string
+}) map[string]interface{} {
+	args := map[string]interface{}{"gas": hexutil.Uint64(gasEstimateCap)}
+	if trx.From != nil {
+		args["from"] = trx.From
+	}
+	if trx.To != nil {
+		args["to"] = trx.To
+	}
+	if trx.Value != nil {
+		args["value"] = trx.Value
+	}
+	if trx.Data != nil {
+		args["data"] = trx.Data
+	}
+	return args
+}
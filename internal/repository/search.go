@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// SearchTransactions finds hashes of transactions starting with the given hex prefix.
+func (p *proxy) SearchTransactions(prefix string, limit int64) ([]common.Hash, error) {
+	return p.db.SearchTransactions(prefix, limit)
+}
+
+// SearchAccounts finds accounts with the address starting with the given hex prefix.
+func (p *proxy) SearchAccounts(prefix string, limit int64) ([]*types.Account, error) {
+	return p.db.SearchAccounts(prefix, limit)
+}
+
+// SearchContracts finds addresses of contracts with the name starting with the given text.
+func (p *proxy) SearchContracts(name string, limit int64) ([]common.Address, error) {
+	return p.db.SearchContracts(name, limit)
+}
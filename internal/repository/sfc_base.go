@@ -11,6 +11,7 @@ package repository
 import (
 	"fantom-api-graphql/internal/types"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go.mongodb.org/mongo-driver/mongo"
 	"math/big"
 )
 
@@ -152,6 +153,23 @@ func (p *proxy) LastKnownEpoch() (uint64, error) {
 	return p.db.LastKnownEpoch()
 }
 
+// EpochAtTime returns the id of the epoch in progress at the given time stamp.
+// If the epoch has not been sealed yet, the current epoch id is provided.
+func (p *proxy) EpochAtTime(ts hexutil.Uint64) (hexutil.Uint64, error) {
+	id, err := p.db.EpochAtTime(int64(ts))
+	if err == nil {
+		return hexutil.Uint64(id), nil
+	}
+
+	// the epoch is still open, use the current one
+	if err == mongo.ErrNoDocuments {
+		return p.rpc.CurrentEpoch()
+	}
+
+	p.log.Errorf("can not find epoch at %d; %s", uint64(ts), err.Error())
+	return 0, err
+}
+
 // AddEpoch stores an epoch reference in connected persistent storage.
 func (p *proxy) AddEpoch(e *types.Epoch) error {
 	return p.db.AddEpoch(e)
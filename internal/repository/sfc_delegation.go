@@ -136,6 +136,19 @@ func (p *proxy) DelegationsOfValidator(valID *hexutil.Big, cursor *string, count
 	return p.db.Delegations(cursor, count, &bson.D{{Key: types.FiDelegationToValidator, Value: valID.String()}})
 }
 
+// DelegationsOfValidatorByAmount extract a list of delegations for a given validator
+// sorted by the active delegated amount.
+func (p *proxy) DelegationsOfValidatorByAmount(valID *hexutil.Big, cursor *string, count int32) (*types.DelegationList, error) {
+	p.log.Debugf("loading delegations of #%d by amount", valID.ToInt().Uint64())
+	return p.db.DelegationsByValue(cursor, count, &bson.D{{Key: types.FiDelegationToValidator, Value: valID.String()}})
+}
+
+// DelegationsSummaryOfValidator provides the number of active delegations
+// and the total delegated amount of a validator.
+func (p *proxy) DelegationsSummaryOfValidator(valID *hexutil.Big) (*types.DelegationSummary, error) {
+	return p.db.DelegationsSummary(&bson.D{{Key: types.FiDelegationToValidator, Value: valID.String()}})
+}
+
 // DelegationLock returns delegation lock information using SFC contract binding.
 func (p *proxy) DelegationLock(addr *common.Address, valID *hexutil.Big) (*types.DelegationLock, error) {
 	p.log.Debugf("loading lock information for %s to #%d", addr.String(), valID.ToInt().Uint64())
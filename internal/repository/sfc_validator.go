@@ -13,6 +13,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"math/big"
+	"time"
 )
 
 // LastValidatorId returns the last staker id in Opera blockchain.
@@ -78,6 +79,33 @@ func (p *proxy) SfcMaxDelegatedRatio() (*big.Int, error) {
 	return val, nil
 }
 
+// ValidatorCommission provides the validator commission rate of the SFC contract
+// along with the time the rate was observed.
+func (p *proxy) ValidatorCommission() (*types.ValidatorCommission, error) {
+	// try cache first
+	prev, fresh := p.cache.PullSfcCommission()
+	if fresh {
+		return prev, nil
+	}
+
+	// pull from the SFC contract
+	val, err := p.rpc.SfcValidatorCommission()
+	if err != nil {
+		return nil, err
+	}
+
+	// keep the time the rate was first observed if it did not change
+	now := hexutil.Uint64(time.Now().UTC().Unix())
+	vc := types.ValidatorCommission{Rate: (hexutil.Big)(*val), Since: now, Observed: now}
+	if prev != nil && prev.Rate.ToInt().Cmp(val) == 0 {
+		vc.Since = prev.Since
+	}
+
+	// store for future use
+	p.cache.PushSfcCommission(&vc)
+	return &vc, nil
+}
+
 // ValidatorDowntime pulls information about validator downtime from the RPC interface.
 func (p *proxy) ValidatorDowntime(valID *hexutil.Big) (uint64, uint64, error) {
 	return p.rpc.ValidatorDowntime(valID)
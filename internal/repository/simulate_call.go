@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"fantom-api-graphql/internal/types"
+)
+
+// SimulateCall executes the given contract call with the state override applied, if any,
+// and decodes the call, its return values, or the custom error it reverted with
+// using the ABI of the target contract, if available.
+func (p *proxy) SimulateCall(call *types.SimulationCall) (*types.SimulatedCall, error) {
+	if err := p.checkStateAvailable(call.Block); err != nil {
+		return nil, err
+	}
+	if err := p.checkStateAvailableAt(call.Tag); err != nil {
+		return nil, err
+	}
+
+	res, err := p.rpc.SimulateCall(call, p.cfg.Server.SimulateCallTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	// decode the call if we know the contract
+	if len(call.Data) < callSelectorLength {
+		return res, nil
+	}
+	abiDef, err := p.decodingAbi(&call.To, call.Block)
+	if err != nil {
+		return nil, err
+	}
+	if abiDef == "" {
+		return res, nil
+	}
+
+	dc := types.DecodedCall{Selector: call.Data[:callSelectorLength], Args: make([]types.DecodedCallArg, 0)}
+	matchCallMethod(abiDef, call.Data, p.cfg.Repository.MaxDecodedInput, &dc)
+	if dc.Name == "" {
+		return res, nil
+	}
+	res.Call = &dc
+
+	// reverted calls may carry a custom error
+	if res.Reverted {
+		if res.RevertReason == "" && len(res.ReturnData) >= customErrorSelectorLength {
+			ce := types.CustomError{Selector: res.ReturnData[:customErrorSelectorLength], Args: make([]types.CustomErrorArg, 0)}
+			matchCustomError(abiDef, res.ReturnData, &ce)
+			if ce.Name != "" {
+				res.Error = &ce
+				res.RevertReason = formatCustomError(&ce)
+			}
+		}
+		return res, nil
+	}
+
+	res.Outputs = decodeCallOutputs(abiDef, call.Data, res.ReturnData)
+	return res, nil
+}
+
+// decodeCallOutputs decodes the values returned by the call of the given input
+// using the contract ABI. Nil is returned if the values can not be decoded.
+func decodeCallOutputs(abiDef string, input []byte, data []byte) []types.DecodedCallArg {
+	ab, err := cachedContractAbi(abiDef)
+	if err != nil {
+		return nil
+	}
+
+	m, err := ab.MethodById(input[:callSelectorLength])
+	if err != nil {
+		return nil
+	}
+
+	values, err := m.Outputs.Unpack(data)
+	if err != nil {
+		return nil
+	}
+
+	out := make([]types.DecodedCallArg, len(m.Outputs))
+	for i, o := range m.Outputs {
+		out[i] = types.DecodedCallArg{Name: o.Name, Type: abiTypeString(o.Type), Value: abiValueString(o.Type, values[i])}
+	}
+	return out
+}
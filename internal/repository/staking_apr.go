@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"math"
+	"math/big"
+)
+
+const (
+	// stakingAprEpochWindow is the number of recent sealed epochs the staking yield is estimated from.
+	stakingAprEpochWindow = 500
+
+	// stakingAprSecondsInYear is the number of seconds in an average year.
+	stakingAprSecondsInYear = 31556926
+
+	// stakingAprCompoundingPeriods is the number of compounding periods per year used
+	// to derive the annual yield from the annual rate.
+	stakingAprCompoundingPeriods = 365
+)
+
+// stakingAprUnit represents the decimal unit of the SFC values as a float number.
+var stakingAprUnit = new(big.Float).SetInt(sfcDecimalUnit)
+
+// StakingApr provides an estimate of the annual yield of the stake delegated to the given validator.
+// The rate is derived from the rewards per token accumulated by the validator delegators
+// over the recent epochs, so it's already reduced by the validator commission.
+// The network average is provided for validators without enough reward history.
+func (p *proxy) StakingApr(valID *hexutil.Big) (*types.StakingApr, error) {
+	// try cache first
+	if apr := p.cache.PullStakingApr(valID); apr != nil {
+		return apr, nil
+	}
+
+	// make sure the validator exists
+	if _, err := p.Validator(valID); err != nil {
+		return nil, err
+	}
+
+	apr, err := p.stakingApr(valID)
+	if err != nil {
+		return nil, err
+	}
+
+	p.cache.PushStakingApr(apr)
+	return apr, nil
+}
+
+// stakingApr calculates the staking yield estimate of the validator from the recent epochs.
+func (p *proxy) stakingApr(valID *hexutil.Big) (*types.StakingApr, error) {
+	last, err := p.rpc.CurrentSealedEpoch()
+	if err != nil {
+		return nil, err
+	}
+
+	// the window starts with the first epoch on young networks
+	from := hexutil.Uint64(1)
+	if last > stakingAprEpochWindow {
+		from = last - stakingAprEpochWindow
+	}
+	if from >= last {
+		return p.networkStakingApr(valID, last, "not enough sealed epochs")
+	}
+
+	// the reward per token is accumulated since the validator creation
+	rptFrom, err := p.rpc.ValidatorEpochRewardPerToken(valID, from)
+	if err != nil {
+		return nil, err
+	}
+	if rptFrom.Sign() == 0 {
+		return p.networkStakingApr(valID, last, "validator has no reward history over the estimation window")
+	}
+
+	rptTo, err := p.rpc.ValidatorEpochRewardPerToken(valID, last)
+	if err != nil {
+		return nil, err
+	}
+
+	// get the time span of the window
+	epFrom, err := p.Epoch(&from)
+	if err != nil {
+		return nil, err
+	}
+	epTo, err := p.Epoch(&last)
+	if err != nil {
+		return nil, err
+	}
+	if epTo.EndTime <= epFrom.EndTime {
+		return p.networkStakingApr(valID, last, "estimation window has no duration")
+	}
+
+	// rate = (rptTo - rptFrom) / unit * year / elapsed
+	rate := new(big.Float).SetInt(new(big.Int).Sub(rptTo, rptFrom))
+	rate.Quo(rate, stakingAprUnit)
+	rate.Mul(rate, big.NewFloat(stakingAprSecondsInYear))
+	rate.Quo(rate, new(big.Float).SetUint64(uint64(epTo.EndTime-epFrom.EndTime)))
+	r, _ := rate.Float64()
+
+	return newStakingApr(valID, r, from, last), nil
+}
+
+// networkStakingApr calculates the network average staking yield estimate
+// from the base reward rate of the last sealed epoch reduced by the validator commission.
+// Rewards from transaction fees are not included.
+func (p *proxy) networkStakingApr(valID *hexutil.Big, last hexutil.Uint64, reason string) (*types.StakingApr, error) {
+	ep, err := p.Epoch(&last)
+	if err != nil {
+		return nil, err
+	}
+
+	vc, err := p.ValidatorCommission()
+	if err != nil {
+		return nil, err
+	}
+
+	var r float64
+	if ep.StakeTotalAmount.ToInt().Sign() > 0 {
+		// rate = perSecond * year / totalStake * (1 - commission)
+		rate := new(big.Float).SetInt(ep.BaseRewardPerSecond.ToInt())
+		rate.Mul(rate, big.NewFloat(stakingAprSecondsInYear))
+		rate.Quo(rate, new(big.Float).SetInt(ep.StakeTotalAmount.ToInt()))
+
+		keep := new(big.Float).Quo(new(big.Float).SetInt(vc.Rate.ToInt()), stakingAprUnit)
+		keep.Sub(big.NewFloat(1), keep)
+		rate.Mul(rate, keep)
+		r, _ = rate.Float64()
+	}
+
+	apr := newStakingApr(valID, r, last, last)
+	apr.IsNetworkAverage = true
+	apr.Reason = reason
+	return apr, nil
+}
+
+// newStakingApr creates the staking yield estimate of the given annual rate.
+// Both the rate and the yield are provided in percent.
+func newStakingApr(valID *hexutil.Big, rate float64, from hexutil.Uint64, to hexutil.Uint64) *types.StakingApr {
+	return &types.StakingApr{
+		ValidatorID: *valID,
+		Apr:         rate * 100,
+		Apy:         (math.Pow(1+rate/stakingAprCompoundingPeriods, stakingAprCompoundingPeriods) - 1) * 100,
+		FromEpoch:   from,
+		ToEpoch:     to,
+	}
+}
@@ -0,0 +1,25 @@
+package repository
+
+import "fantom-api-graphql/internal/types"
+
+// StakingParams provides the staking protocol parameters currently enforced by the SFC contract.
+// The parameters change only on the SFC upgrades, so they are cached for a while.
+func (p *proxy) StakingParams() (*types.StakingParams, error) {
+	if sp := p.cache.PullStakingParams(); sp != nil {
+		return sp, nil
+	}
+
+	sp, err, _ := p.apiRequestGroup.Do("staking-params", func() (interface{}, error) {
+		sp, err := p.rpc.SfcStakingParams()
+		if err != nil {
+			return nil, err
+		}
+
+		p.cache.PushStakingParams(sp)
+		return sp, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return sp.(*types.StakingParams), nil
+}
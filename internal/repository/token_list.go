@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"io/ioutil"
+	"net/http"
+	"sync"
+)
+
+// tokenListMaxSize is the max size of the remote token list document accepted.
+const tokenListMaxSize = 16 * 1024 * 1024
+
+// tokenListEntry represents a single token of a token list in the Uniswap token list format.
+type tokenListEntry struct {
+	ChainId  uint64         `json:"chainId"`
+	Address  common.Address `json:"address"`
+	Name     string         `json:"name"`
+	Symbol   string         `json:"symbol"`
+	Decimals int32          `json:"decimals"`
+	LogoURI  string         `json:"logoURI"`
+}
+
+// tokenListDocument represents the token list document in the Uniswap token list format.
+type tokenListDocument struct {
+	Name   string           `json:"name"`
+	Tokens []tokenListEntry `json:"tokens"`
+}
+
+// remoteTokens keeps the tokens of the recently loaded remote token list.
+var remoteTokens = struct {
+	sync.RWMutex
+	tokens map[common.Address]*tokenListEntry
+}{tokens: make(map[common.Address]*tokenListEntry)}
+
+// RefreshTokenList loads the configured remote token list and replaces the known
+// remote tokens with its content. Only tokens of the connected chain are used.
+// The previous list stays active if the new one can not be loaded.
+func (p *proxy) RefreshTokenList() error {
+	if !p.cfg.TokenList.Enabled || p.cfg.TokenList.Url == "" {
+		return nil
+	}
+
+	ni, err := p.NetworkInfo(context.Background())
+	if err != nil {
+		return err
+	}
+
+	doc, err := p.loadTokenList()
+	if err != nil {
+		return err
+	}
+
+	chain := ni.ChainID.ToInt().Uint64()
+	tokens := make(map[common.Address]*tokenListEntry, len(doc.Tokens))
+	for i := range doc.Tokens {
+		if doc.Tokens[i].ChainId == chain {
+			tokens[doc.Tokens[i].Address] = &doc.Tokens[i]
+		}
+	}
+
+	remoteTokens.Lock()
+	remoteTokens.tokens = tokens
+	remoteTokens.Unlock()
+
+	// cached tokens may miss the details we have now
+	for addr := range tokens {
+		if tk := p.cache.PullErc20Token(&addr); tk != nil && (tk.DecimalsUnknown || tk.Symbol == erc20UnknownSymbol) {
+			p.cache.EvictErc20Token(&addr)
+		}
+	}
+
+	p.log.Noticef("token list %s loaded with %d tokens", doc.Name, len(tokens))
+	return nil
+}
+
+// loadTokenList downloads and decodes the configured remote token list.
+func (p *proxy) loadTokenList() (*tokenListDocument, error) {
+	client := &http.Client{Timeout: p.cfg.TokenList.Timeout}
+	resp, err := client.Get(p.cfg.TokenList.Url)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			p.log.Errorf("error closing token list response; %s", err.Error())
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("token list not available, status %d", resp.StatusCode)
+	}
+
+	data, err := ioutil.ReadAll(http.MaxBytesReader(nil, resp.Body, tokenListMaxSize))
+	if err != nil {
+		return nil, err
+	}
+
+	var doc tokenListDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("invalid token list; %s", err.Error())
+	}
+	return &doc, nil
+}
+
+// remoteToken provides the token of the remote token list, nil if the token is not listed.
+func remoteToken(addr *common.Address) *tokenListEntry {
+	remoteTokens.RLock()
+	defer remoteTokens.RUnlock()
+	return remoteTokens.tokens[*addr]
+}
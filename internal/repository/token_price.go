@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"errors"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"time"
+)
+
+// ErrTokenPriceNotSampled represents an error returned if the price history
+// of the token is not collected by any configured price pair.
+var ErrTokenPriceNotSampled = errors.New("token price is not sampled")
+
+const (
+	// tokenPriceDefaultInterval is the default length of a single tick of the token price history.
+	tokenPriceDefaultInterval = time.Hour
+
+	// tokenPriceMaxTicks is the max number of ticks of a single token price history.
+	tokenPriceMaxTicks = 1000
+)
+
+// SampleTokenPrices takes a sample of the current price of each configured price pair
+// and stores it in the persistent storage.
+func (p *proxy) SampleTokenPrices() {
+	now := time.Now().UTC()
+	for i := range p.cfg.DeFi.Uniswap.PricePairs {
+		pair := &p.cfg.DeFi.Uniswap.PricePairs[i]
+		if err := p.sampleTokenPrice(pair, now); err != nil {
+			p.log.Errorf("can not sample price of pair %s; %s", pair.String(), err.Error())
+		}
+	}
+}
+
+// sampleTokenPrice takes a sample of the price of the first token of the pair
+// in units of the second token from the current reserves of the pair.
+func (p *proxy) sampleTokenPrice(pair *common.Address, now time.Time) error {
+	tokens, err := p.UniswapTokens(pair)
+	if err != nil {
+		return err
+	}
+
+	pq, err := p.uniswapQuoteOfPair(pair, &tokens[0], &tokens[1])
+	if err != nil {
+		return err
+	}
+
+	price, _ := pq.price.Float64()
+	return p.db.AddTokenPriceSample(&types.TokenPriceSample{
+		Pair:            *pair,
+		Price:           price,
+		IsLowConfidence: pq.isLowReserve,
+		Time:            now,
+	})
+}
+
+// TokenPriceHistory provides the price history of the token in the given time range aggregated
+// into ticks of the given interval. The prices are sampled from the given pair, or the first
+// configured price pair of the token; intervals without samples carry the last known price.
+func (p *proxy) TokenPriceHistory(token *common.Address, pair *common.Address, from time.Time, to time.Time, interval time.Duration) (*types.TokenPriceHistory, error) {
+	if !to.After(from) {
+		return nil, fmt.Errorf("invalid time range %s - %s", from.Format(time.RFC3339), to.Format(time.RFC3339))
+	}
+
+	if interval <= 0 {
+		interval = tokenPriceDefaultInterval
+	}
+
+	// finer intervals than the sampling would carry the price only
+	if interval < p.cfg.DeFi.Uniswap.PriceSampling {
+		interval = p.cfg.DeFi.Uniswap.PriceSampling
+	}
+	if (to.Sub(from)+interval-1)/interval > tokenPriceMaxTicks {
+		return nil, fmt.Errorf("too many ticks of %s in the time range, at most %d allowed", interval, tokenPriceMaxTicks)
+	}
+
+	res, invert, err := p.tokenPricePair(token, pair)
+	if err != nil {
+		return nil, err
+	}
+	res.Interval = interval
+
+	last, err := p.db.LastTokenPriceSample(&res.Pair, from)
+	if err != nil {
+		return nil, err
+	}
+	samples, err := p.db.TokenPriceSamples(&res.Pair, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	res.Ticks = tokenPriceTicks(samples, last, from, to, interval, invert)
+	return res, nil
+}
+
+// tokenPricePair finds the configured price pair sampling the price of the token.
+// If the pair is given, it must be one of the configured price pairs and contain the token.
+// The sampled prices need to be inverted, if the token is the second token of the pair.
+func (p *proxy) tokenPricePair(token *common.Address, pair *common.Address) (*types.TokenPriceHistory, bool, error) {
+	for i := range p.cfg.DeFi.Uniswap.PricePairs {
+		pp := &p.cfg.DeFi.Uniswap.PricePairs[i]
+		if pair != nil && *pair != *pp {
+			continue
+		}
+
+		tokens, err := p.UniswapTokens(pp)
+		if err != nil {
+			return nil, false, err
+		}
+		for j, t := range tokens {
+			if t == *token {
+				return &types.TokenPriceHistory{Token: t, Against: tokens[1-j], Pair: *pp}, j == 1, nil
+			}
+		}
+	}
+	return nil, false, fmt.Errorf("%w; token %s", ErrTokenPriceNotSampled, token.String())
+}
+
+// tokenPriceTicks aggregates the price samples ordered by time into ticks of the given interval
+// covering the time range. Ticks without samples carry the last known price, starting with the given
+// last sample taken before the range; ticks before any known price are skipped. Sampled prices
+// are inverted, if the price of the second token of the pair is requested.
+func tokenPriceTicks(samples []types.TokenPriceSample, last *types.TokenPriceSample, from time.Time, to time.Time, interval time.Duration, invert bool) []types.TokenPriceTick {
+	list := make([]types.TokenPriceTick, 0)
+
+	var i int
+	for start := from; start.Before(to); start = start.Add(interval) {
+		tick := types.TokenPriceTick{From: start, To: start.Add(interval)}
+		if tick.To.After(to) {
+			tick.To = to
+		}
+
+		for ; i < len(samples) && samples[i].Time.Before(tick.To); i++ {
+			price := tokenPrice(&samples[i], invert)
+			if tick.Samples == 0 || price > tick.High {
+				tick.High = price
+			}
+			if tick.Samples == 0 || price < tick.Low {
+				tick.Low = price
+			}
+			if tick.Samples == 0 {
+				tick.Open = price
+			}
+			tick.Close = price
+			tick.IsLowConfidence = tick.IsLowConfidence || samples[i].IsLowConfidence
+			tick.Samples++
+			last = &samples[i]
+		}
+
+		// nothing sampled in the interval; carry the last known price, if any
+		if tick.Samples == 0 {
+			if last == nil {
+				continue
+			}
+			price := tokenPrice(last, invert)
+			tick.Open, tick.High, tick.Low, tick.Close = price, price, price, price
+			tick.IsLowConfidence = last.IsLowConfidence
+			tick.IsCarried = true
+		}
+		list = append(list, tick)
+	}
+	return list
+}
+
+// tokenPrice provides the price of the sample, inverted if requested.
+func tokenPrice(ps *types.TokenPriceSample, invert bool) float64 {
+	if invert && ps.Price != 0 {
+		return 1 / ps.Price
+	}
+	return ps.Price
+}
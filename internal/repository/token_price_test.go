@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"fantom-api-graphql/internal/types"
+	"github.com/onsi/gomega"
+	"testing"
+	"time"
+)
+
+func TestTokenPriceTicks(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	from := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+	at := func(min int, price float64, low bool) types.TokenPriceSample {
+		return types.TokenPriceSample{Price: price, IsLowConfidence: low, Time: from.Add(time.Duration(min) * time.Minute)}
+	}
+
+	samples := []types.TokenPriceSample{
+		at(0, 2, false),
+		at(20, 4, false),
+		at(40, 1, true),
+		at(130, 5, false),
+	}
+	last := at(-10, 8, false)
+
+	tests := []struct {
+		name    string
+		samples []types.TokenPriceSample
+		last    *types.TokenPriceSample
+		to      time.Duration
+		invert  bool
+		want    []types.TokenPriceTick
+	}{
+		{
+			name:    "gap carries the last price",
+			samples: samples,
+			to:      4 * time.Hour,
+			want: []types.TokenPriceTick{
+				{From: from, To: from.Add(time.Hour), Open: 2, High: 4, Low: 1, Close: 1, Samples: 3, IsLowConfidence: true},
+				{From: from.Add(time.Hour), To: from.Add(2 * time.Hour), Open: 1, High: 1, Low: 1, Close: 1, IsCarried: true, IsLowConfidence: true},
+				{From: from.Add(2 * time.Hour), To: from.Add(3 * time.Hour), Open: 5, High: 5, Low: 5, Close: 5, Samples: 1},
+				{From: from.Add(3 * time.Hour), To: from.Add(4 * time.Hour), Open: 5, High: 5, Low: 5, Close: 5, IsCarried: true},
+			},
+		},
+		{
+			name:    "last tick cut at the range end",
+			samples: samples[3:],
+			to:      150 * time.Minute,
+			want: []types.TokenPriceTick{
+				{From: from.Add(2 * time.Hour), To: from.Add(150 * time.Minute), Open: 5, High: 5, Low: 5, Close: 5, Samples: 1},
+			},
+		},
+		{
+			name:    "price before the range is carried",
+			samples: samples[3:],
+			last:    &last,
+			to:      150 * time.Minute,
+			want: []types.TokenPriceTick{
+				{From: from, To: from.Add(time.Hour), Open: 8, High: 8, Low: 8, Close: 8, IsCarried: true},
+				{From: from.Add(time.Hour), To: from.Add(2 * time.Hour), Open: 8, High: 8, Low: 8, Close: 8, IsCarried: true},
+				{From: from.Add(2 * time.Hour), To: from.Add(150 * time.Minute), Open: 5, High: 5, Low: 5, Close: 5, Samples: 1},
+			},
+		},
+		{
+			name:    "inverted price",
+			samples: samples[:3],
+			to:      time.Hour,
+			invert:  true,
+			want: []types.TokenPriceTick{
+				{From: from, To: from.Add(time.Hour), Open: 0.5, High: 1, Low: 0.25, Close: 1, Samples: 3, IsLowConfidence: true},
+			},
+		},
+		{
+			name: "nothing known",
+			to:   2 * time.Hour,
+			want: []types.TokenPriceTick{},
+		},
+	}
+
+	for _, tc := range tests {
+		got := tokenPriceTicks(tc.samples, tc.last, from, from.Add(tc.to), time.Hour, tc.invert)
+		g.Expect(got).To(gomega.Equal(tc.want), tc.name)
+	}
+}
@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+const (
+	// tokenRankingMaxEntries is the max number of tokens in a ranking.
+	tokenRankingMaxEntries = 100
+
+	// tokenRankingCacheRatio is the part of the period a ranking is kept in cache for;
+	// a daily ranking is refreshed every 5 minutes.
+	tokenRankingCacheRatio = 288
+
+	// tokenRankingMinTTL is the minimal time a ranking is kept in cache.
+	tokenRankingMinTTL = 30 * time.Second
+)
+
+// tokenRankingDecimalsCorrection is the number of decimals removed from the amounts
+// of the stored token transfers, see types.TransactionDecimalsCorrection.
+const tokenRankingDecimalsCorrection = 9
+
+// TokenRanking provides ERC20 tokens ranked by their transfers made over the given period.
+// Tokens ranked by the volume are valued in the configured Uniswap quote token; the candidates
+// are the most transferred tokens and those without a known price are ranked last.
+func (p *proxy) TokenRanking(period time.Duration, metric string) (*types.TokenRanking, error) {
+	quote := p.cfg.DeFi.Uniswap.QuoteToken
+	if tr := p.cache.PullTokenRanking(period, metric, &quote); tr != nil {
+		return tr, nil
+	}
+
+	// the ranking is expensive, build it only once for parallel requests
+	tr, err, _ := p.apiRequestGroup.Do(fmt.Sprintf("token_ranking_%d_%s", int64(period.Seconds()), metric), func() (interface{}, error) {
+		tr := types.TokenRanking{Period: period, Metric: metric, Quote: quote, Since: time.Now().UTC().Add(-period)}
+
+		var err error
+		tr.Entries, err = p.db.Erc20TransferStats(tr.Since, metric == types.TokenRankingByHolders, tokenRankingMaxEntries)
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range tr.Entries {
+			p.valueTokenTransferStats(&tr.Entries[i], &tr)
+		}
+		if metric == types.TokenRankingByVolume {
+			sortTokenRankingByValue(tr.Entries)
+		}
+
+		ttl := period / tokenRankingCacheRatio
+		if ttl < tokenRankingMinTTL {
+			ttl = tokenRankingMinTTL
+		}
+		p.cache.PushTokenRanking(&tr, ttl)
+		return &tr, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return tr.(*types.TokenRanking), nil
+}
+
+// valueTokenTransferStats calculates the transferred volume of the token in whole tokens
+// and values it in the quote token of the ranking, if the price is known.
+func (p *proxy) valueTokenTransferStats(ts *types.TokenTransferStats, tr *types.TokenRanking) {
+	token, err := p.Erc20Token(&ts.Token)
+	if err != nil {
+		return
+	}
+	ts.Volume = ts.Amount * math.Pow10(tokenRankingDecimalsCorrection-int(token.Decimals))
+
+	pri, err := p.UniswapTokenPrice(&ts.Token, &tr.Quote)
+	if err != nil || pri == nil {
+		return
+	}
+
+	val := ts.Volume * pri.Price
+	ts.Price = &pri.Price
+	ts.Value = &val
+}
+
+// sortTokenRankingByValue sorts the tokens by their transferred value;
+// tokens without a known value follow ordered by the number of transfers.
+func sortTokenRankingByValue(list []types.TokenTransferStats) {
+	sort.SliceStable(list, func(i, j int) bool {
+		if list[i].Value == nil || list[j].Value == nil {
+			return list[i].Value != nil && list[j].Value == nil
+		}
+		return *list[i].Value > *list[j].Value
+	})
+}
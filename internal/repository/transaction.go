@@ -55,6 +55,14 @@ func (p *proxy) Transaction(hash *common.Hash, needBinary bool) (*types.Transact
 		return nil, err
 	}
 
+	// the node does not know the transaction (yet); was it submitted through the API?
+	if trx.Hash != *hash {
+		if pt := p.cache.PullPendingTransaction(hash); pt != nil {
+			p.log.Debugf("transaction %s loaded from pending cache", hash.String())
+			return pt, nil
+		}
+	}
+
 	// push the transaction to the cache to speed things up next time
 	// we don't cache pending transactions since it would cause issues
 	// when re-loading data of such transactions on the client side
@@ -122,7 +130,10 @@ func (p *proxy) SendTransaction(tx hexutil.Bytes) (*types.Transaction, error) {
 // No-number boundaries are handled as follows:
 // 	- For positive count we start from the most recent transaction and scan to older transactions.
 // 	- For negative count we start from the first transaction and scan to newer transactions.
-func (p *proxy) Transactions(cursor *string, count int32) (*types.TransactionList, error) {
+//
+// Only the given optional parts of the transactions are loaded from the database;
+// the recent transactions served from the cache are always complete.
+func (p *proxy) Transactions(cursor *string, count int32, fields types.TrxFields) (*types.TransactionList, error) {
 	// we may be able to pull the list faster than from the db
 	if cursor == nil && count > 0 && count < cache.TransactionRingCacheSize {
 		// pull the quick list
@@ -143,7 +154,7 @@ func (p *proxy) Transactions(cursor *string, count int32) (*types.TransactionLis
 	}
 
 	// use slow trx list pulling
-	return p.db.Transactions(cursor, count, nil)
+	return p.db.Transactions(cursor, count, nil, fields)
 }
 
 // StoreGasPricePeriod stores the given gas price period data in the persistent storage
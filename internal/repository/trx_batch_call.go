@@ -0,0 +1,278 @@
+package repository
+
+import (
+	"fantom-api-graphql/internal/config"
+	"fantom-api-graphql/internal/logger"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"math/big"
+	"reflect"
+	"strings"
+)
+
+// batchCallAbi is the ABI of the recognized batch call wrappers carrying inner contract calls
+// in their input data; Gnosis Safe, its MultiSend library, Multicall contracts
+// and the self multicall of Uniswap style routers.
+const batchCallAbi = `[
+{"type":"function","name":"execTransaction","inputs":[{"name":"to","type":"address"},{"name":"value","type":"uint256"},{"name":"data","type":"bytes"},{"name":"operation","type":"uint8"},{"name":"safeTxGas","type":"uint256"},{"name":"baseGas","type":"uint256"},{"name":"gasPrice","type":"uint256"},{"name":"gasToken","type":"address"},{"name":"refundReceiver","type":"address"},{"name":"signatures","type":"bytes"}],"outputs":[{"name":"success","type":"bool"}]},
+{"type":"function","name":"multiSend","inputs":[{"name":"transactions","type":"bytes"}],"outputs":[]},
+{"type":"function","name":"aggregate","inputs":[{"name":"calls","type":"tuple[]","components":[{"name":"target","type":"address"},{"name":"callData","type":"bytes"}]}],"outputs":[]},
+{"type":"function","name":"tryAggregate","inputs":[{"name":"requireSuccess","type":"bool"},{"name":"calls","type":"tuple[]","components":[{"name":"target","type":"address"},{"name":"callData","type":"bytes"}]}],"outputs":[]},
+{"type":"function","name":"aggregate3","inputs":[{"name":"calls","type":"tuple[]","components":[{"name":"target","type":"address"},{"name":"allowFailure","type":"bool"},{"name":"callData","type":"bytes"}]}],"outputs":[]},
+{"type":"function","name":"multicall","inputs":[{"name":"data","type":"bytes[]"}],"outputs":[]}
+]`
+
+// batchAbi is the parsed ABI of the recognized batch call wrappers.
+var batchAbi = mustParseAbi(batchCallAbi)
+
+// batchInnerCall represents a single inner call unpacked from a batch call.
+type batchInnerCall struct {
+	to       common.Address
+	value    *big.Int
+	delegate bool
+	data     []byte
+}
+
+// batchDecoding represents the state of the inner calls decoding of a single call.
+// The parsed ABI of each called contract is resolved only once and the number
+// of resolved contracts is limited, so a large batch does not cost a contract
+// and proxy lookup per inner call.
+type batchDecoding struct {
+	block     *hexutil.Uint64
+	budget    int
+	contracts int
+	abis      map[common.Address]*abi.ABI
+}
+
+// batchCallDecoder unpacks the inner calls from the decoded arguments of a batch call
+// sent to the given contract.
+type batchCallDecoder func(to common.Address, args []interface{}) ([]batchInnerCall, error)
+
+// batchCallDecoders maps the recognized batch methods to their inner calls decoders.
+var batchCallDecoders = map[string]batchCallDecoder{
+	"execTransaction": decodeSafeExecTransaction,
+	"multiSend":       decodeMultiSend,
+	"aggregate":       decodeAggregateArg(0),
+	"tryAggregate":    decodeAggregateArg(1),
+	"aggregate3":      decodeAggregateArg(0),
+	"multicall":       decodeSelfMulticall,
+}
+
+// mustParseAbi parses the given ABI definition; it panics on an invalid definition.
+func mustParseAbi(def string) abi.ABI {
+	ab, err := abi.JSON(strings.NewReader(def))
+	if err != nil {
+		panic(fmt.Errorf("invalid ABI; %s", err.Error()))
+	}
+	return ab
+}
+
+// batchSelectorsMap creates the set of configured batch call selectors supported by the decoder.
+func batchSelectorsMap(cfg *config.Repository, log logger.Logger) map[string]bool {
+	sel := make(map[string]bool, len(cfg.BatchSelectors))
+	for _, s := range cfg.BatchSelectors {
+		b, err := hexutil.Decode(strings.TrimSpace(s))
+		if err != nil || len(b) != callSelectorLength {
+			log.Errorf("invalid batch call selector %s", s)
+			continue
+		}
+		if _, err := batchAbi.MethodById(b); err != nil {
+			log.Errorf("batch call selector %s is not supported", s)
+			continue
+		}
+		sel[string(b)] = true
+	}
+	return sel
+}
+
+// newBatchDecoding creates the state of the inner calls decoding of a call at the given block.
+func (p *proxy) newBatchDecoding(block *hexutil.Uint64) *batchDecoding {
+	return &batchDecoding{
+		block:     block,
+		budget:    p.cfg.Repository.MaxBatchCalls,
+		contracts: p.cfg.Repository.MaxBatchContracts,
+		abis:      make(map[common.Address]*abi.ABI),
+	}
+}
+
+// batchCallAbi provides the parsed decoding ABI of the given contract called by an inner call,
+// or nil if the ABI is not known. Contracts over the configured limit are not resolved;
+// their calls are provided with the raw selector only.
+func (p *proxy) batchCallAbi(bd *batchDecoding, addr common.Address) *abi.ABI {
+	if ab, ok := bd.abis[addr]; ok {
+		return ab
+	}
+	if bd.contracts <= 0 {
+		return nil
+	}
+	bd.contracts--
+
+	abiDef, err := p.decodingAbi(&addr, bd.block)
+	if err != nil {
+		p.log.Debugf("can not decode calls of %s; %s", addr.String(), err.Error())
+	}
+
+	var ab *abi.ABI
+	if abiDef != "" {
+		ab, _ = cachedContractAbi(abiDef)
+	}
+	bd.abis[addr] = ab
+	return ab
+}
+
+// batchSubCalls decodes the inner calls of the given call input, if the input is a recognized batch call.
+// The decoding state limits the number of inner calls decoded; the flag signals
+// some inner calls were skipped due to the configured depth or size limit.
+func (p *proxy) batchSubCalls(to common.Address, data []byte, depth int, bd *batchDecoding) ([]types.DecodedSubCall, bool) {
+	if len(data) < callSelectorLength || !p.batchSelectors[string(data[:callSelectorLength])] {
+		return nil, false
+	}
+
+	// pathological batches are recognized, but not decoded
+	if depth >= p.cfg.Repository.MaxBatchDepth || bd.budget <= 0 ||
+		(p.cfg.Repository.MaxDecodedInput > 0 && len(data) > p.cfg.Repository.MaxDecodedInput) {
+		return nil, true
+	}
+
+	m, err := batchAbi.MethodById(data[:callSelectorLength])
+	if err != nil {
+		return nil, false
+	}
+
+	values, err := m.Inputs.Unpack(data[callSelectorLength:])
+	if err != nil {
+		p.log.Debugf("can not unpack batch call %s; %s", m.Name, err.Error())
+		return nil, false
+	}
+
+	inner, err := batchCallDecoders[m.Name](to, values)
+	if err != nil {
+		p.log.Debugf("can not decode inner calls of %s; %s", m.Name, err.Error())
+		return nil, false
+	}
+
+	skipped := false
+	list := make([]types.DecodedSubCall, 0, len(inner))
+	for _, ic := range inner {
+		if bd.budget <= 0 {
+			return list, true
+		}
+		bd.budget--
+
+		sc := types.DecodedSubCall{To: ic.to, DelegateCall: ic.delegate}
+		if ic.value != nil {
+			sc.Value = hexutil.Big(*ic.value)
+		}
+
+		if len(ic.data) >= callSelectorLength {
+			dc := types.DecodedCall{Selector: ic.data[:callSelectorLength], Args: make([]types.DecodedCallArg, 0)}
+			if ab := p.batchCallAbi(bd, ic.to); ab != nil {
+				decodeCallMethod(ab, ic.data, p.cfg.Repository.MaxDecodedInput, &dc)
+			}
+			dc.SubCalls, dc.SubCallsSkipped = p.batchSubCalls(ic.to, ic.data, depth+1, bd)
+			skipped = skipped || dc.SubCallsSkipped
+			sc.Call = &dc
+		}
+		list = append(list, sc)
+	}
+	return list, skipped
+}
+
+// decodeSafeExecTransaction unpacks the single inner call of the Gnosis Safe execTransaction.
+func decodeSafeExecTransaction(_ common.Address, args []interface{}) ([]batchInnerCall, error) {
+	to, ok1 := args[0].(common.Address)
+	value, ok2 := args[1].(*big.Int)
+	data, ok3 := args[2].([]byte)
+	op, ok4 := args[3].(uint8)
+	if !ok1 || !ok2 || !ok3 || !ok4 {
+		return nil, fmt.Errorf("unexpected execTransaction arguments")
+	}
+	return []batchInnerCall{{to: to, value: value, delegate: op == 1, data: data}}, nil
+}
+
+// decodeMultiSend unpacks the inner calls of the Gnosis MultiSend library call.
+// Each call is packed as operation (1 byte), target (20 bytes), value (32 bytes),
+// data length (32 bytes) and the data itself.
+func decodeMultiSend(_ common.Address, args []interface{}) ([]batchInnerCall, error) {
+	packed, ok := args[0].([]byte)
+	if !ok {
+		return nil, fmt.Errorf("unexpected multiSend arguments")
+	}
+
+	const head = 1 + common.AddressLength + 32 + 32
+	list := make([]batchInnerCall, 0)
+	for len(packed) > 0 {
+		if len(packed) < head {
+			return nil, fmt.Errorf("truncated multiSend call")
+		}
+
+		length := new(big.Int).SetBytes(packed[head-32 : head])
+		if !length.IsUint64() || length.Uint64() > uint64(len(packed)-head) {
+			return nil, fmt.Errorf("invalid multiSend call data length")
+		}
+		end := head + int(length.Uint64())
+
+		list = append(list, batchInnerCall{
+			to:       common.BytesToAddress(packed[1 : 1+common.AddressLength]),
+			value:    new(big.Int).SetBytes(packed[1+common.AddressLength : head-32]),
+			delegate: packed[0] == 1,
+			data:     packed[head:end],
+		})
+		packed = packed[end:]
+	}
+	return list, nil
+}
+
+// decodeAggregateArg creates a decoder of the Multicall aggregate style calls
+// with the list of (target, callData) tuples at the given argument position.
+func decodeAggregateArg(pos int) batchCallDecoder {
+	return func(_ common.Address, args []interface{}) ([]batchInnerCall, error) {
+		calls := reflect.ValueOf(args[pos])
+		if calls.Kind() != reflect.Slice {
+			return nil, fmt.Errorf("unexpected aggregate arguments")
+		}
+
+		list := make([]batchInnerCall, 0, calls.Len())
+		for i := 0; i < calls.Len(); i++ {
+			call := calls.Index(i)
+			if call.Kind() != reflect.Struct {
+				return nil, fmt.Errorf("unexpected aggregate call #%d", i)
+			}
+
+			target, ok1 := tupleField(call, "Target").(common.Address)
+			data, ok2 := tupleField(call, "CallData").([]byte)
+			if !ok1 || !ok2 {
+				return nil, fmt.Errorf("unexpected aggregate call #%d", i)
+			}
+			list = append(list, batchInnerCall{to: target, data: data})
+		}
+		return list, nil
+	}
+}
+
+// tupleField provides the value of the named field of an unpacked ABI tuple, nil if not available.
+func tupleField(tuple reflect.Value, name string) interface{} {
+	f := tuple.FieldByName(name)
+	if !f.IsValid() || !f.CanInterface() {
+		return nil
+	}
+	return f.Interface()
+}
+
+// decodeSelfMulticall unpacks the inner calls of the multicall(bytes[]) of Uniswap style routers;
+// all the inner calls target the called contract itself.
+func decodeSelfMulticall(to common.Address, args []interface{}) ([]batchInnerCall, error) {
+	calls, ok := args[0].([][]byte)
+	if !ok {
+		return nil, fmt.Errorf("unexpected multicall arguments")
+	}
+
+	list := make([]batchInnerCall, 0, len(calls))
+	for _, data := range calls {
+		list = append(list, batchInnerCall{to: to, delegate: true, data: data})
+	}
+	return list, nil
+}
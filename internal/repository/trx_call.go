@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"bytes"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// callSelectorLength is the length of the method selector in the call input data.
+const callSelectorLength = 4
+
+// TransactionCall decodes the contract call of the given transaction using the ABI
+// of the target contract, or its implementation for proxy contracts. Only the raw selector
+// is provided if the contract ABI is not known. Nil is returned if the transaction
+// is not a contract call.
+func (p *proxy) TransactionCall(trx *types.Transaction) (*types.DecodedCall, error) {
+	if trx.To == nil || len(trx.InputData) < callSelectorLength {
+		return nil, nil
+	}
+
+	dc := types.DecodedCall{Selector: trx.InputData[:callSelectorLength], Args: make([]types.DecodedCallArg, 0)}
+	if sc, err := p.Contract(trx.To); err == nil && sc != nil && sc.Destroyed != nil {
+		dc.ContractDestroyed = true
+	}
+	abiDef, err := p.decodingAbi(trx.To, trx.BlockNumber)
+	if err != nil {
+		return nil, err
+	}
+	if abiDef != "" {
+		matchCallMethod(abiDef, trx.InputData, p.cfg.Repository.MaxDecodedInput, &dc)
+	}
+
+	// unpack inner calls of recognized batch calls
+	dc.SubCalls, dc.SubCallsSkipped = p.batchSubCalls(*trx.To, trx.InputData, 0, p.newBatchDecoding(trx.BlockNumber))
+	return &dc, nil
+}
+
+// DecodeTransactionInput decodes the input data of the given transaction using
+// the caller supplied contract ABI instead of the ABI known for the target contract.
+func (p *proxy) DecodeTransactionInput(hash *common.Hash, abiDef string) (*types.DecodedCall, error) {
+	ab, err := parseContractAbi(abiDef)
+	if err != nil {
+		return nil, fmt.Errorf("%w; %s", ErrInvalidAbi, err.Error())
+	}
+
+	trx, err := p.Transaction(hash, true)
+	if err != nil {
+		return nil, err
+	}
+	if trx.Hash != *hash {
+		return nil, ErrTransactionNotFound
+	}
+	if len(trx.InputData) < callSelectorLength {
+		return nil, fmt.Errorf("transaction %s is not a contract call", hash.String())
+	}
+
+	dc := types.DecodedCall{Selector: trx.InputData[:callSelectorLength], Args: make([]types.DecodedCallArg, 0)}
+	found, err := decodeCallMethod(&ab, trx.InputData, p.cfg.Repository.MaxDecodedInput, &dc)
+	if !found {
+		return nil, fmt.Errorf("%w; %s", ErrAbiMethodNotFound, hexutil.Encode(dc.Selector))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("can not decode arguments of %s; %s", dc.Signature, err.Error())
+	}
+	return &dc, nil
+}
+
+// matchCallMethod matches the call input data against methods of the given contract ABI
+// and decodes the method name and arguments, if a matching method is found.
+// Arguments of inputs longer than the given limit are not decoded; a zero limit disables the check.
+func matchCallMethod(abiDef string, data []byte, limit int, dc *types.DecodedCall) {
+	if len(data) < callSelectorLength {
+		return
+	}
+
+	ab, err := cachedContractAbi(abiDef)
+	if err != nil {
+		return
+	}
+	decodeCallMethod(ab, data, limit, dc)
+}
+
+// decodeCallMethod decodes the method name and arguments of the call input data
+// using the given parsed ABI. It reports if a method matching the call selector was found
+// and the error of the arguments decoding, if any.
+func decodeCallMethod(ab *abi.ABI, data []byte, limit int, dc *types.DecodedCall) (bool, error) {
+	for _, m := range ab.Methods {
+		if !bytes.Equal(m.ID[:callSelectorLength], data[:callSelectorLength]) {
+			continue
+		}
+
+		dc.Name = m.Name
+		dc.Signature = m.Sig
+
+		// pathological inputs are recognized, but not decoded
+		if limit > 0 && len(data) > limit {
+			dc.ArgsSkipped = true
+			return true, nil
+		}
+
+		values, err := m.Inputs.Unpack(data[callSelectorLength:])
+		if err != nil {
+			return true, err
+		}
+
+		for i, in := range m.Inputs {
+			dc.Args = append(dc.Args, types.DecodedCallArg{
+				Name:  in.Name,
+				Type:  abiTypeString(in.Type),
+				Value: abiValueString(in.Type, values[i]),
+			})
+		}
+		return true, nil
+	}
+	return false, nil
+}
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/onsi/gomega"
+	"math/big"
+	"testing"
+)
+
+// testCallAbi is the ABI of the contract used to test call method matching.
+const testCallAbi = `[{"type":"function","name":"transfer","inputs":[{"name":"to","type":"address"},{"name":"amount","type":"uint256"}],"outputs":[{"name":"","type":"bool"}]}]`
+
+func TestMatchCallMethod(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	ab, err := cachedContractAbi(testCallAbi)
+	g.Expect(err).To(gomega.BeNil())
+
+	to := common.HexToAddress("0xabc00fa001230012300abc0012300fa00face000")
+	transfer, err := ab.Pack("transfer", to, big.NewInt(1000))
+	g.Expect(err).To(gomega.BeNil())
+
+	tests := []struct {
+		name  string
+		data  []byte
+		limit int
+		want  types.DecodedCall
+	}{
+		{
+			name: "matched",
+			data: transfer,
+			want: types.DecodedCall{Name: "transfer", Signature: "transfer(address,uint256)", Args: []types.DecodedCallArg{
+				{Name: "to", Type: "address", Value: to.Hex()},
+				{Name: "amount", Type: "uint256", Value: "1000"},
+			}},
+		},
+		{
+			name:  "matched over limit",
+			data:  transfer,
+			limit: 8,
+			want:  types.DecodedCall{Name: "transfer", Signature: "transfer(address,uint256)", ArgsSkipped: true, Args: []types.DecodedCallArg{}},
+		},
+		{
+			name: "unknown selector",
+			data: hexutil.MustDecode("0xdeadbeef"),
+			want: types.DecodedCall{Args: []types.DecodedCallArg{}},
+		},
+		{
+			name: "short input",
+			data: transfer[:3],
+			want: types.DecodedCall{Args: []types.DecodedCallArg{}},
+		},
+	}
+
+	for _, tt := range tests {
+		dc := types.DecodedCall{Args: make([]types.DecodedCallArg, 0)}
+		matchCallMethod(testCallAbi, tt.data, tt.limit, &dc)
+		g.Expect(dc).To(gomega.Equal(tt.want), tt.name)
+	}
+
+	// the parsed ABI is reused
+	again, err := cachedContractAbi(testCallAbi)
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(again).To(gomega.BeIdenticalTo(ab))
+}
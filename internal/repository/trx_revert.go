@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"fantom-api-graphql/internal/types"
+)
+
+// TransactionRevertReason provides the decoded revert reason of the given failed transaction.
+// The reason is decoded on demand by replaying the transaction call and stored for future use.
+// Nil is returned for successful transactions and if the reason can not be recovered.
+func (p *proxy) TransactionRevertReason(trx *types.Transaction) (*types.RevertReason, error) {
+	// only failed transactions have a revert reason
+	if trx.Status == nil || *trx.Status != 0 {
+		return nil, nil
+	}
+
+	// do we know the reason already?
+	rr, err := p.db.TransactionRevertReason(&trx.Hash)
+	if err != nil {
+		return nil, err
+	}
+	if rr != nil {
+		// the contract may have been validated since, try to decode the custom error
+		if p.decodeCustomError(trx, rr) {
+			p.storeRevertReason(trx, rr)
+		}
+		return revertReasonOrNil(rr), nil
+	}
+
+	// replay the transaction; failed replays are not stored, they may succeed later,
+	// e.g. on an archive node
+	rr, err = p.rpc.TransactionRevertReason(trx)
+	if err != nil {
+		return nil, err
+	}
+
+	// remember replays not reverting, too, so we don't replay the transaction again
+	if rr == nil {
+		rr = &types.RevertReason{Data: make([]byte, 0)}
+	}
+	p.decodeCustomError(trx, rr)
+	p.storeRevertReason(trx, rr)
+	return revertReasonOrNil(rr), nil
+}
+
+// storeRevertReason stores the revert reason of the failed transaction.
+func (p *proxy) storeRevertReason(trx *types.Transaction, rr *types.RevertReason) {
+	if err := p.db.SetTransactionRevertReason(&trx.Hash, rr); err != nil {
+		p.log.Errorf("revert reason of %s not stored; %s", trx.Hash.String(), err.Error())
+	}
+}
+
+// revertReasonOrNil provides nil for revert reasons not carrying any information.
+func revertReasonOrNil(rr *types.RevertReason) *types.RevertReason {
+	if rr.Reason == "" && len(rr.Data) == 0 {
+		return nil
+	}
+	return rr
+}
@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+	"time"
+)
+
+// trxStatsUpdateChunk is the max time range of statistics precomputed in a single update
+// so the initial backfill of a long history does not overload the database.
+const trxStatsUpdateChunk = 7 * 24 * time.Hour
+
+// trxStatsGranularities is the list of statistics granularities we precompute.
+var trxStatsGranularities = []string{types.TrxStatsDay, types.TrxStatsHour}
+
+// TrxStats provides the transaction statistics of the given granularity in the given
+// time range; the end of the range is not included. Closed buckets are loaded precomputed,
+// the current bucket is aggregated live.
+func (p *proxy) TrxStats(ctx context.Context, from time.Time, to time.Time, gran string) ([]*types.TrxStats, error) {
+	cur := types.TrxStatsBucketStart(time.Now(), gran)
+
+	// closed buckets
+	end := to
+	if end.After(cur) {
+		end = cur
+	}
+
+	list := make([]*types.TrxStats, 0)
+	if from.Before(end) {
+		past, err := p.db.TrxStats(ctx, from, end, gran)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, past...)
+	}
+
+	// the current bucket, if requested
+	if to.After(cur) && !from.After(cur) {
+		live, err := p.db.TrxStatsLive(ctx, cur, cur.Add(types.TrxStatsBucket(gran)), gran)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, live...)
+	}
+	return list, nil
+}
+
+// TrxStatsUpdate precomputes the transaction statistics of closed buckets not stored yet.
+// The last stored bucket is always recalculated to include transactions scanned late.
+// The update continues from the end of the previously processed chunk, so a period
+// without any transactions does not stop the backfill.
+func (p *proxy) TrxStatsUpdate() {
+	for _, gran := range trxStatsGranularities {
+		from, err := p.db.TrxStatsLatest(gran)
+		if err != nil {
+			continue
+		}
+
+		// the previous chunk may have ended past the last stored bucket
+		if done, ok := p.trxStatsDone.Load(gran); ok {
+			last := done.(time.Time).Add(-types.TrxStatsBucket(gran))
+			if from == nil || last.After(*from) {
+				from = &last
+			}
+		}
+
+		// nothing stored yet; start from the beginning of the known history
+		if from == nil {
+			from, err = p.db.TransactionOldestStamp()
+			if err != nil || from == nil {
+				continue
+			}
+		}
+
+		start := types.TrxStatsBucketStart(*from, gran)
+		end := start.Add(trxStatsUpdateChunk)
+		if cur := types.TrxStatsBucketStart(time.Now(), gran); end.After(cur) {
+			end = cur
+		}
+		if !start.Before(end) {
+			continue
+		}
+
+		if err := p.db.TrxStatsUpdate(start, end, gran); err != nil {
+			p.log.Criticalf("can not update trx stats; %s", err.Error())
+			continue
+		}
+		p.trxStatsDone.Store(gran, end)
+		p.log.Debugf("%s trx stats updated from %s to %s", gran, start.String(), end.String())
+	}
+}
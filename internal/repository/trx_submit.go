@@ -0,0 +1,157 @@
+package repository
+
+import (
+	"errors"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	retypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrTrxInvalid represents an error returned if the submitted transaction can not be decoded.
+	ErrTrxInvalid = errors.New("invalid transaction data")
+
+	// ErrTrxNonceTooLow represents an error returned if the transaction nonce has already been used.
+	ErrTrxNonceTooLow = errors.New("transaction rejected; nonce too low")
+
+	// ErrTrxUnderpriced represents an error returned if the transaction gas price is too low.
+	ErrTrxUnderpriced = errors.New("transaction rejected; gas price too low")
+
+	// ErrTrxInsufficientFunds represents an error returned if the sender can not cover the transaction cost.
+	ErrTrxInsufficientFunds = errors.New("transaction rejected; insufficient funds for gas * price + value")
+
+	// ErrTrxAlreadyKnown represents an error returned if the transaction has already been submitted.
+	ErrTrxAlreadyKnown = errors.New("transaction rejected; already known")
+
+	// ErrTrxIdempotencyKeyReused represents an error returned if the idempotency key
+	// has already been used to submit a different transaction.
+	ErrTrxIdempotencyKeyReused = errors.New("idempotency key already used for a different transaction")
+)
+
+// trxRejections maps node rejection messages to their API errors.
+// The order matters, the replacement message contains the underpriced one.
+var trxRejections = []struct {
+	msg string
+	err error
+}{
+	{msg: "nonce too low", err: ErrTrxNonceTooLow},
+	{msg: "already known", err: ErrTrxAlreadyKnown},
+	{msg: "underpriced", err: ErrTrxUnderpriced},
+	{msg: "insufficient funds", err: ErrTrxInsufficientFunds},
+}
+
+// SendRawTransaction sends raw signed and RLP encoded transaction to the block chain
+// and provides its hash. The transaction is recorded as pending right away, so it can be
+// queried before the node provides it.
+func (p *proxy) SendRawTransaction(data hexutil.Bytes) (*common.Hash, error) {
+	// decode the transaction so we know what we are about to send
+	tx := new(retypes.Transaction)
+	if err := tx.UnmarshalBinary(data); err != nil {
+		p.log.Warningf("can not decode submitted transaction; %s", err.Error())
+		return nil, ErrTrxInvalid
+	}
+	from, err := retypes.Sender(retypes.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		p.log.Warningf("can not recover sender of submitted transaction; %s", err.Error())
+		return nil, ErrTrxInvalid
+	}
+
+	hash, err := p.rpc.SendTransaction(data)
+	if err != nil {
+		p.log.Errorf("can not send transaction %s to block chain; %s", tx.Hash().String(), err.Error())
+		return nil, trxSubmitError(err)
+	}
+
+	p.cache.PushPendingTransaction(pendingTransaction(tx, from))
+	p.log.Noticef("trx %s from %s submitted", hash.String(), from.String())
+	return hash, nil
+}
+
+// idempotentTrxTTL is the time the result of a transaction submission
+// is kept for repeated submissions with the same idempotency key.
+const idempotentTrxTTL = 24 * time.Hour
+
+// idempotentSubmission represents the result of a transaction submission
+// shared by concurrent submissions with the same idempotency key.
+type idempotentSubmission struct {
+	hash *common.Hash
+	sum  common.Hash
+}
+
+// SendIdempotentTransaction sends raw signed and RLP encoded transaction to the block chain
+// the same way SendRawTransaction does. Repeated submissions with the same idempotency key
+// are not sent again, the hash of the original transaction is provided instead.
+func (p *proxy) SendIdempotentTransaction(data hexutil.Bytes, key string) (*common.Hash, error) {
+	sum := crypto.Keccak256Hash(data)
+
+	// concurrent submissions with the same key wait for the first one
+	res, err, _ := p.apiRequestGroup.Do("trx_idem_"+key, func() (interface{}, error) {
+		hash, prev, err := p.db.IdempotentTransaction(key)
+		if err != nil {
+			return nil, err
+		}
+		if hash != nil {
+			p.log.Debugf("trx %s already submitted with idempotency key %s", hash.String(), key)
+			return &idempotentSubmission{hash: hash, sum: *prev}, nil
+		}
+
+		hash, err = p.SendRawTransaction(data)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := p.db.StoreIdempotentTransaction(key, hash, &sum, idempotentTrxTTL); err != nil {
+			p.log.Errorf("can not store submission of transaction %s; %s", hash.String(), err.Error())
+		}
+		return &idempotentSubmission{hash: hash, sum: sum}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// the key must not be used for a different transaction
+	sub := res.(*idempotentSubmission)
+	if sub.sum != sum {
+		p.log.Warningf("idempotency key %s reused for a different transaction", key)
+		return nil, ErrTrxIdempotencyKeyReused
+	}
+	return sub.hash, nil
+}
+
+// pendingTransaction builds the pending transaction record from the submitted transaction.
+func pendingTransaction(tx *retypes.Transaction, from common.Address) *types.Transaction {
+	trx := types.Transaction{
+		TimeStamp: time.Now().UTC(),
+		From:      from,
+		Gas:       hexutil.Uint64(tx.Gas()),
+		GasPrice:  hexutil.Big(*tx.GasPrice()),
+		Hash:      tx.Hash(),
+		Nonce:     hexutil.Uint64(tx.Nonce()),
+		To:        tx.To(),
+		Value:     hexutil.Big(*tx.Value()),
+		InputData: tx.Data(),
+	}
+
+	// dynamic fee transactions carry the fee caps
+	if tx.Type() == retypes.DynamicFeeTxType {
+		trx.MaxFeePerGas = (*hexutil.Big)(tx.GasFeeCap())
+		trx.MaxPriorityFeePerGas = (*hexutil.Big)(tx.GasTipCap())
+	}
+	return &trx
+}
+
+// trxSubmitError translates the node rejection of a submitted transaction into the API error.
+func trxSubmitError(err error) error {
+	msg := strings.ToLower(err.Error())
+	for _, r := range trxRejections {
+		if strings.Contains(msg, r.msg) {
+			return r.err
+		}
+	}
+	return fmt.Errorf("transaction rejected; %s", err.Error())
+}
@@ -0,0 +1,199 @@
+package repository
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// typedDataDomainType is the name of the EIP-712 domain type.
+const typedDataDomainType = "EIP712Domain"
+
+// typedDataDomainFields lists the fields of the EIP-712 domain in the canonical order.
+var typedDataDomainFields = []apitypes.Type{
+	{Name: "name", Type: "string"},
+	{Name: "version", Type: "string"},
+	{Name: "chainId", Type: "uint256"},
+	{Name: "verifyingContract", Type: "address"},
+	{Name: "salt", Type: "bytes32"},
+}
+
+// VerifyTypedData recovers the signer of the EIP-712 typed data signature.
+// The domain, the types and the message are expected in JSON format. The domain type
+// is derived from the domain fields if not declared and so is the primary type
+// if not specified.
+func (p *proxy) VerifyTypedData(domain string, types string, message string, primaryType *string, sig hexutil.Bytes) (*common.Address, error) {
+	td, err := typedData(domain, types, message, primaryType)
+	if err != nil {
+		return nil, fmt.Errorf("%w; %s", ErrInvalidTypedData, err.Error())
+	}
+
+	hash, err := typedDataHash(td)
+	if err != nil {
+		return nil, fmt.Errorf("%w; %s", ErrInvalidTypedData, err.Error())
+	}
+
+	if len(sig) != crypto.SignatureLength {
+		return nil, fmt.Errorf("%w; signature must be %d bytes long", ErrInvalidTypedData, crypto.SignatureLength)
+	}
+
+	// wallets produce the recovery id shifted to 27/28
+	rs := make([]byte, crypto.SignatureLength)
+	copy(rs, sig)
+	if rs[crypto.RecoveryIDOffset] >= 27 {
+		rs[crypto.RecoveryIDOffset] -= 27
+	}
+
+	pub, err := crypto.SigToPub(hash, rs)
+	if err != nil {
+		return nil, fmt.Errorf("%w; invalid signature; %s", ErrInvalidTypedData, err.Error())
+	}
+
+	addr := crypto.PubkeyToAddress(*pub)
+	return &addr, nil
+}
+
+// typedDataHash calculates the EIP-712 digest of the typed data being signed.
+func typedDataHash(td *apitypes.TypedData) ([]byte, error) {
+	// the types are validated on the domain hashing, so the error may relate to any type
+	domainSeparator, err := td.HashStruct(typedDataDomainType, td.Domain.Map())
+	if err != nil {
+		return nil, err
+	}
+
+	msgHash, err := td.HashStruct(td.PrimaryType, td.Message)
+	if err != nil {
+		return nil, fmt.Errorf("invalid message; %s", err.Error())
+	}
+
+	raw := make([]byte, 0, 2+len(domainSeparator)+len(msgHash))
+	raw = append(raw, 0x19, 0x01)
+	raw = append(raw, domainSeparator...)
+	raw = append(raw, msgHash...)
+	return crypto.Keccak256(raw), nil
+}
+
+// typedData builds the typed data structure from its JSON encoded parts.
+func typedData(domain string, types string, message string, primaryType *string) (*apitypes.TypedData, error) {
+	var td apitypes.TypedData
+
+	if err := json.Unmarshal([]byte(types), &td.Types); err != nil {
+		return nil, fmt.Errorf("invalid types; %s", err.Error())
+	}
+	if len(td.Types) == 0 {
+		return nil, fmt.Errorf("types not specified")
+	}
+
+	// numbers are kept as decimal strings so large integers do not lose precision
+	dom, err := decodeTypedDataObject(domain)
+	if err != nil {
+		return nil, fmt.Errorf("invalid domain; %s", err.Error())
+	}
+	raw, err := json.Marshal(dom)
+	if err != nil {
+		return nil, fmt.Errorf("invalid domain; %s", err.Error())
+	}
+	if err := json.Unmarshal(raw, &td.Domain); err != nil {
+		return nil, fmt.Errorf("invalid domain; %s", err.Error())
+	}
+
+	if td.Message, err = decodeTypedDataObject(message); err != nil {
+		return nil, fmt.Errorf("invalid message; %s", err.Error())
+	}
+
+	// derive the domain type from the domain fields, if not declared
+	if _, ok := td.Types[typedDataDomainType]; !ok {
+		td.Types[typedDataDomainType] = typedDataDomainTypeOf(td.Domain.Map())
+	}
+
+	// derive the primary type, if not specified
+	if primaryType != nil && *primaryType != "" {
+		td.PrimaryType = *primaryType
+	} else if td.PrimaryType, err = typedDataPrimaryType(td.Types); err != nil {
+		return nil, err
+	}
+	if _, ok := td.Types[td.PrimaryType]; !ok {
+		return nil, fmt.Errorf("primary type %s not declared", td.PrimaryType)
+	}
+	return &td, nil
+}
+
+// decodeTypedDataObject decodes the JSON object keeping the numbers as decimal strings.
+func decodeTypedDataObject(data string) (map[string]interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader([]byte(data)))
+	dec.UseNumber()
+
+	var obj map[string]interface{}
+	if err := dec.Decode(&obj); err != nil {
+		return nil, err
+	}
+	if obj == nil {
+		return nil, fmt.Errorf("object expected")
+	}
+	return numbersToStrings(obj).(map[string]interface{}), nil
+}
+
+// numbersToStrings replaces the JSON numbers of the decoded value by their decimal strings.
+func numbersToStrings(val interface{}) interface{} {
+	switch v := val.(type) {
+	case json.Number:
+		return v.String()
+	case map[string]interface{}:
+		for k := range v {
+			v[k] = numbersToStrings(v[k])
+		}
+	case []interface{}:
+		for i := range v {
+			v[i] = numbersToStrings(v[i])
+		}
+	}
+	return val
+}
+
+// typedDataDomainTypeOf derives the domain type from the fields present in the domain.
+func typedDataDomainTypeOf(domain map[string]interface{}) []apitypes.Type {
+	list := make([]apitypes.Type, 0, len(typedDataDomainFields))
+	for _, f := range typedDataDomainFields {
+		if _, ok := domain[f.Name]; ok {
+			list = append(list, f)
+		}
+	}
+	return list
+}
+
+// typedDataPrimaryType finds the only declared type not referenced by any other type.
+func typedDataPrimaryType(types apitypes.Types) (string, error) {
+	referenced := make(map[string]bool)
+	for _, fields := range types {
+		for _, f := range fields {
+			referenced[typedDataBaseType(f.Type)] = true
+		}
+	}
+
+	var primary string
+	for name := range types {
+		if name == typedDataDomainType || referenced[name] {
+			continue
+		}
+		if primary != "" {
+			return "", fmt.Errorf("primary type is ambiguous, %s or %s", primary, name)
+		}
+		primary = name
+	}
+	if primary == "" {
+		return "", fmt.Errorf("primary type not found")
+	}
+	return primary, nil
+}
+
+// typedDataBaseType strips the array suffixes of the given type name.
+func typedDataBaseType(name string) string {
+	if i := bytes.IndexByte([]byte(name), '['); i >= 0 {
+		return name[:i]
+	}
+	return name
+}
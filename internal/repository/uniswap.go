@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"errors"
+	"fantom-api-graphql/internal/config"
 	"fantom-api-graphql/internal/repository/rpc/contracts"
 	"fantom-api-graphql/internal/types"
 
@@ -8,6 +10,18 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 )
 
+// ErrUniswapNotConfigured represents an error returned if the Uniswap contracts are not configured.
+var ErrUniswapNotConfigured = errors.New("uniswap contracts are not configured")
+
+// ErrUniswapPairNotFound represents an error returned if the Uniswap pair does not exist.
+var ErrUniswapPairNotFound = errors.New("uniswap pair not found")
+
+// isUniswapConfigured checks if the Uniswap core and router contracts are configured.
+func (p *proxy) isUniswapConfigured() bool {
+	empty := common.HexToAddress(config.EmptyAddress)
+	return p.cfg.DeFi.Uniswap.Core != empty && p.cfg.DeFi.Uniswap.Router != empty
+}
+
 // NativeTokenAddress returns address of the native token wrapper, if available.
 func (p *proxy) NativeTokenAddress() (*common.Address, error) {
 	return p.rpc.NativeTokenAddress()
@@ -16,6 +30,11 @@ func (p *proxy) NativeTokenAddress() (*common.Address, error) {
 // UniswapPairs returns list of all token pairs managed by Uniswap core.
 // We use cache to store the list temporarily, the list is refreshed from RCP when the cache record expires.
 func (p *proxy) UniswapPairs() ([]common.Address, error) {
+	// no Uniswap, no pairs
+	if !p.isUniswapConfigured() {
+		return make([]common.Address, 0), nil
+	}
+
 	// try the cache first
 	l := p.cache.PullAllPairsList()
 	if l != nil {
@@ -34,12 +53,29 @@ func (p *proxy) UniswapPairs() ([]common.Address, error) {
 
 // UniswapKnownPairs returns list of all known and whitelisted token pairs managed by Uniswap core.
 func (p *proxy) UniswapKnownPairs() ([]common.Address, error) {
+	if !p.isUniswapConfigured() {
+		return make([]common.Address, 0), nil
+	}
 	return p.rpc.UniswapPairs(true)
 }
 
 // UniswapPair returns an address of an Uniswap pair for the given tokens.
+// ErrUniswapPairNotFound is returned if the pair does not exist.
 func (p *proxy) UniswapPair(tokenA *common.Address, tokenB *common.Address) (*common.Address, error) {
-	return p.rpc.UniswapPair(tokenA, tokenB)
+	if !p.isUniswapConfigured() {
+		return nil, ErrUniswapNotConfigured
+	}
+
+	pair, err := p.rpc.UniswapPair(tokenA, tokenB)
+	if err != nil {
+		return nil, err
+	}
+
+	// the factory responds with an empty address for unknown pairs
+	if *pair == common.HexToAddress(config.EmptyAddress) {
+		return nil, ErrUniswapPairNotFound
+	}
+	return pair, nil
 }
 
 // UniswapAmountsOut resolves a list of output amounts for the given
@@ -81,8 +117,24 @@ func (p *proxy) UniswapTokens(pair *common.Address) ([]common.Address, error) {
 }
 
 // UniswapReserves returns list of token reserve amounts in a Uniswap pair.
+// Reserves are cached for a short time only.
 func (p *proxy) UniswapReserves(pair *common.Address) ([]hexutil.Big, error) {
-	return p.rpc.UniswapReserves(pair)
+	if !p.isUniswapConfigured() {
+		return nil, ErrUniswapNotConfigured
+	}
+
+	// try cache first
+	if res := p.cache.PullUniswapReserves(pair); res != nil {
+		return res, nil
+	}
+
+	res, err := p.rpc.UniswapReserves(pair)
+	if err != nil {
+		return nil, err
+	}
+
+	p.cache.PushUniswapReserves(pair, res)
+	return res, nil
 }
 
 // UniswapReservesTimeStamp returns the timestamp of the reserves of a Uniswap pair.
@@ -150,6 +202,6 @@ func (p *proxy) UniswapTimeReserves(pairAddress *common.Address, resolution stri
 }
 
 // UniswapActions provides list of uniswap actions stored in the persistent storage.
-func (p *proxy) UniswapActions(pairAddress *common.Address, cursor *string, count int32, actionType int32) (*types.UniswapActionList, error) {
-	return p.db.UniswapActions(pairAddress, cursor, count, actionType)
+func (p *proxy) UniswapActions(pairAddress *common.Address, sender *common.Address, cursor *string, count int32, actionType int32) (*types.UniswapActionList, error) {
+	return p.db.UniswapActions(pairAddress, sender, cursor, count, actionType)
 }
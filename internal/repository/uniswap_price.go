@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"fantom-api-graphql/internal/config"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"math/big"
+)
+
+// uniswapPairQuote represents a price quote of a single Uniswap pair.
+type uniswapPairQuote struct {
+	price        *big.Float
+	isLowReserve bool
+}
+
+// UniswapTokenPrice derives the price of the token against the given token
+// from Uniswap pair reserves. If the against token is not provided, the configured
+// quote token is used. If there is no direct pair of the tokens, the price is routed
+// through configured base tokens. Nil is returned if no route is available.
+func (p *proxy) UniswapTokenPrice(token *common.Address, against *common.Address) (*types.UniswapTokenPrice, error) {
+	if !p.isUniswapConfigured() {
+		return nil, ErrUniswapNotConfigured
+	}
+
+	// use the configured quote token by default
+	if against == nil {
+		against = &p.cfg.DeFi.Uniswap.QuoteToken
+	}
+	if *against == common.HexToAddress(config.EmptyAddress) {
+		return nil, fmt.Errorf("quote token not specified")
+	}
+
+	// the price of a token against itself is trivial
+	res := types.UniswapTokenPrice{Token: *token, Against: *against, Route: make([]common.Address, 0)}
+	if *token == *against {
+		res.Price = 1
+		return &res, nil
+	}
+
+	// try the direct pair first
+	pq, pair, err := p.uniswapPairQuote(token, against)
+	if err == nil {
+		res.Price, _ = pq.price.Float64()
+		res.Route = append(res.Route, *pair)
+		res.IsLowConfidence = pq.isLowReserve
+		return &res, nil
+	}
+	if err != ErrUniswapPairNotFound {
+		return nil, err
+	}
+
+	// try one hop routes through base tokens
+	for i, base := range p.cfg.DeFi.Uniswap.BaseTokens {
+		if base == *token || base == *against {
+			continue
+		}
+
+		first, firstPair, err := p.uniswapPairQuote(token, &p.cfg.DeFi.Uniswap.BaseTokens[i])
+		if err == ErrUniswapPairNotFound {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		second, secondPair, err := p.uniswapPairQuote(&p.cfg.DeFi.Uniswap.BaseTokens[i], against)
+		if err == ErrUniswapPairNotFound {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		res.Price, _ = new(big.Float).Mul(first.price, second.price).Float64()
+		res.Route = append(res.Route, *firstPair, *secondPair)
+		res.IsLowConfidence = first.isLowReserve || second.isLowReserve
+		return &res, nil
+	}
+	return nil, nil
+}
+
+// uniswapPairQuote calculates the decimals adjusted price of the token
+// in units of the against token from the reserves of their Uniswap pair.
+func (p *proxy) uniswapPairQuote(token *common.Address, against *common.Address) (*uniswapPairQuote, *common.Address, error) {
+	pair, err := p.UniswapPair(token, against)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pq, err := p.uniswapQuoteOfPair(pair, token, against)
+	if err != nil {
+		return nil, nil, err
+	}
+	return pq, pair, nil
+}
+
+// uniswapQuoteOfPair calculates the decimals adjusted price of the token
+// in units of the against token from the reserves of the given Uniswap pair.
+func (p *proxy) uniswapQuoteOfPair(pair *common.Address, token *common.Address, against *common.Address) (*uniswapPairQuote, error) {
+	tokens, reserves, err := p.UniswapAdjustedReserves(pair)
+	if err != nil {
+		return nil, err
+	}
+
+	adjusted := make(map[common.Address]*big.Float, len(tokens))
+	for i := range tokens {
+		adjusted[tokens[i]] = reserves[i]
+	}
+
+	// empty pool can not provide any price
+	if adjusted[*token] == nil || adjusted[*against] == nil || adjusted[*token].Sign() == 0 || adjusted[*against].Sign() == 0 {
+		return nil, ErrUniswapPairNotFound
+	}
+
+	minReserve := big.NewFloat(p.cfg.DeFi.Uniswap.MinReserve)
+	return &uniswapPairQuote{
+		price:        new(big.Float).Quo(adjusted[*against], adjusted[*token]),
+		isLowReserve: adjusted[*token].Cmp(minReserve) < 0 || adjusted[*against].Cmp(minReserve) < 0,
+	}, nil
+}
+
+// UniswapAdjustedReserves provides the list of tokens of the given Uniswap pair
+// and their reserves adjusted by the tokens decimals, in the order of the pair tokens.
+func (p *proxy) UniswapAdjustedReserves(pair *common.Address) ([]common.Address, []*big.Float, error) {
+	tokens, err := p.UniswapTokens(pair)
+	if err != nil {
+		return nil, nil, err
+	}
+	reserves, err := p.UniswapReserves(pair)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(reserves) < len(tokens) {
+		return nil, nil, fmt.Errorf("reserves of pair %s not available", pair.String())
+	}
+
+	adjusted := make([]*big.Float, len(tokens))
+	for i := range tokens {
+		dec, err := p.Erc20Decimals(&tokens[i])
+		if err != nil {
+			return nil, nil, err
+		}
+
+		div := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(dec)), nil)
+		adjusted[i] = new(big.Float).Quo(new(big.Float).SetInt(reserves[i].ToInt()), new(big.Float).SetInt(div))
+	}
+	return tokens, adjusted, nil
+}
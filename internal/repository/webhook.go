@@ -0,0 +1,382 @@
+package repository
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+)
+
+// ErrWebhooksDisabled represents an error returned if the notification webhooks
+// are not enabled by the configuration.
+var ErrWebhooksDisabled = errors.New("notification webhooks are disabled")
+
+// ErrWebhookTargetForbidden represents an error returned if the webhook URL
+// targets a private, loopback, or link-local address.
+var ErrWebhookTargetForbidden = errors.New("webhook URL must target a public address")
+
+// webhookDeliveryBatch is the max number of webhook deliveries attempted in one pass.
+const webhookDeliveryBatch = 100
+
+// webhookErrorMaxLength is the max length of the delivery error kept with the delivery.
+const webhookErrorMaxLength = 256
+
+// webhookMaxRetryDelay is the longest delay between attempts of a failed webhook delivery.
+const webhookMaxRetryDelay = 24 * time.Hour
+
+// webhookRegistry represents the in-memory registry of addresses watched by the webhooks.
+type webhookRegistry struct {
+	mu      sync.RWMutex
+	loaded  bool
+	watched map[common.Address][]*types.Webhook
+
+	// running signals a delivery pass in progress
+	running int32
+}
+
+// newWebhookRegistry creates a new empty registry of watched addresses.
+func newWebhookRegistry() *webhookRegistry {
+	return &webhookRegistry{watched: make(map[common.Address][]*types.Webhook)}
+}
+
+// RegisterWebhook registers a new notification webhook for the given address.
+func (p *proxy) RegisterWebhook(addr *common.Address, target string) (*types.Webhook, error) {
+	if !p.cfg.Webhooks.Enabled || p.cfg.Webhooks.Secret == "" {
+		return nil, ErrWebhooksDisabled
+	}
+
+	// validate the target URL
+	u, err := webhookURL(target)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := webhookID()
+	if err != nil {
+		return nil, err
+	}
+
+	wh := types.Webhook{
+		ID:      id,
+		Address: *addr,
+		Url:     u.String(),
+		Created: time.Now().UTC(),
+	}
+	if err := p.db.AddWebhook(&wh); err != nil {
+		return nil, err
+	}
+
+	p.reloadWebhooks()
+	return &wh, nil
+}
+
+// RemoveWebhook removes the webhook of the given id with all its pending deliveries.
+// It reports if the webhook was found.
+func (p *proxy) RemoveWebhook(id string) (bool, error) {
+	found, err := p.db.RemoveWebhook(id)
+	if err != nil {
+		return false, err
+	}
+
+	p.reloadWebhooks()
+	return found, nil
+}
+
+// Webhooks provides the list of webhooks registered for the given address,
+// or all the registered webhooks if the address is not specified.
+func (p *proxy) Webhooks(addr *common.Address) ([]*types.Webhook, error) {
+	return p.db.Webhooks(addr)
+}
+
+// WebhookDeliveries provides the list of the most recent deliveries of the given webhook.
+func (p *proxy) WebhookDeliveries(id string, count int32) ([]*types.WebhookDelivery, error) {
+	return p.db.WebhookDeliveries(id, int64(count))
+}
+
+// QueueWebhookDeliveries queues deliveries of the webhooks watching the recipient
+// of the given transaction.
+func (p *proxy) QueueWebhookDeliveries(blk *types.Block, trx *types.Transaction) error {
+	if !p.cfg.Webhooks.Enabled || trx.To == nil {
+		return nil
+	}
+
+	hooks := p.watchingWebhooks(trx.To)
+	if len(hooks) == 0 {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	list := make([]*types.WebhookDelivery, 0, len(hooks))
+	for _, wh := range hooks {
+		// do not notify about transactions older than the webhook, e.g. on a re-scan
+		if wh.Created.After(trx.TimeStamp) {
+			continue
+		}
+
+		id := wh.ID + ":" + trx.Hash.String()
+		payload, err := json.Marshal(types.WebhookPayload{
+			Delivery:    id,
+			Webhook:     wh.ID,
+			Address:     wh.Address,
+			Transaction: trx.Hash,
+			Block:       blk.Number,
+			From:        trx.From,
+			Value:       trx.Value,
+			Status:      trx.Status,
+			TimeStamp:   trx.TimeStamp.Unix(),
+		})
+		if err != nil {
+			p.log.Errorf("can not encode webhook %s payload; %s", wh.ID, err.Error())
+			continue
+		}
+
+		list = append(list, &types.WebhookDelivery{
+			ID:          id,
+			WebhookID:   wh.ID,
+			Url:         wh.Url,
+			Transaction: trx.Hash,
+			Block:       blk.Number,
+			Payload:     string(payload),
+			Status:      types.WebhookDeliveryPending,
+			NextAttempt: now,
+			Created:     now,
+		})
+	}
+	return p.db.AddWebhookDeliveries(list)
+}
+
+// DeliverWebhooks attempts the pending webhook deliveries which are due.
+// It returns false if another delivery pass is still in progress.
+func (p *proxy) DeliverWebhooks() bool {
+	if !atomic.CompareAndSwapInt32(&p.webhooks.running, 0, 1) {
+		return false
+	}
+	defer atomic.StoreInt32(&p.webhooks.running, 0)
+
+	list, err := p.db.DueWebhookDeliveries(time.Now().UTC(), webhookDeliveryBatch)
+	if err != nil {
+		p.log.Errorf("can not load due webhook deliveries; %s", err.Error())
+		return true
+	}
+
+	client := webhookClient(p.cfg.Webhooks.Timeout)
+	for _, wd := range list {
+		p.deliverWebhook(client, wd)
+	}
+	return true
+}
+
+// deliverWebhook performs a single delivery attempt and stores its result.
+func (p *proxy) deliverWebhook(client *http.Client, wd *types.WebhookDelivery) {
+	err := p.postWebhook(client, wd)
+	now := time.Now().UTC()
+
+	if err == nil {
+		wd.Status = types.WebhookDeliveryDelivered
+		wd.LastError = ""
+		wd.Delivered = &now
+	} else {
+		wd.Attempts++
+		wd.LastError = err.Error()
+		if len(wd.LastError) > webhookErrorMaxLength {
+			wd.LastError = wd.LastError[:webhookErrorMaxLength]
+		}
+
+		if int(wd.Attempts) >= p.cfg.Webhooks.MaxRetries {
+			wd.Status = types.WebhookDeliveryFailed
+			p.log.Warningf("webhook delivery %s failed after %d attempts; %s", wd.ID, wd.Attempts, err.Error())
+		} else {
+			// exponential backoff of the next attempt
+			wd.NextAttempt = now.Add(webhookRetryDelay(p.cfg.Webhooks.RetryDelay, wd.Attempts))
+			p.log.Debugf("webhook delivery %s attempt %d failed; %s", wd.ID, wd.Attempts, err.Error())
+		}
+	}
+
+	if err := p.db.UpdateWebhookDelivery(wd); err != nil {
+		p.log.Errorf("can not update webhook delivery %s; %s", wd.ID, err.Error())
+	}
+}
+
+// webhookRetryDelay calculates the delay of the next attempt after the given number
+// of failed attempts; the delay doubles with each attempt up to the max retry delay.
+func webhookRetryDelay(base time.Duration, attempts int32) time.Duration {
+	if base <= 0 {
+		return 0
+	}
+
+	delay := base
+	for i := int32(1); i < attempts && delay < webhookMaxRetryDelay; i++ {
+		delay *= 2
+	}
+	if delay > webhookMaxRetryDelay {
+		return webhookMaxRetryDelay
+	}
+	return delay
+}
+
+// postWebhook posts the signed delivery payload to the webhook URL.
+func (p *proxy) postWebhook(client *http.Client, wd *types.WebhookDelivery) error {
+	body := []byte(wd.Payload)
+	req, err := http.NewRequest(http.MethodPost, wd.Url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Webhook-Delivery", wd.ID)
+	req.Header.Set("X-Webhook-Signature", webhookSignature(p.cfg.Webhooks.Secret, body))
+
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		// drain the body so the connection can be reused
+		_, _ = io.Copy(ioutil.Discard, res.Body)
+		_ = res.Body.Close()
+	}()
+
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("webhook responded with status %d", res.StatusCode)
+	}
+	return nil
+}
+
+// watchingWebhooks provides the webhooks watching the given address.
+func (p *proxy) watchingWebhooks(addr *common.Address) []*types.Webhook {
+	p.webhooks.mu.RLock()
+	loaded := p.webhooks.loaded
+	p.webhooks.mu.RUnlock()
+
+	// load the registry on first use
+	if !loaded {
+		p.reloadWebhooks()
+	}
+
+	p.webhooks.mu.RLock()
+	defer p.webhooks.mu.RUnlock()
+	return p.webhooks.watched[*addr]
+}
+
+// reloadWebhooks loads the registry of watched addresses from the persistent storage.
+func (p *proxy) reloadWebhooks() {
+	list, err := p.db.Webhooks(nil)
+	if err != nil {
+		p.log.Errorf("can not load webhooks; %s", err.Error())
+		return
+	}
+
+	watched := make(map[common.Address][]*types.Webhook)
+	for _, wh := range list {
+		watched[wh.Address] = append(watched[wh.Address], wh)
+	}
+
+	p.webhooks.mu.Lock()
+	p.webhooks.watched = watched
+	p.webhooks.loaded = true
+	p.webhooks.mu.Unlock()
+}
+
+// webhookURL validates the webhook target URL. Only http(s) URLs of hosts resolving
+// to public addresses are accepted, so the webhooks can not reach internal services.
+func webhookURL(target string) (*url.URL, error) {
+	u, err := url.Parse(target)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return nil, fmt.Errorf("invalid webhook URL %s", target)
+	}
+
+	ips, err := net.LookupIP(u.Hostname())
+	if err != nil {
+		return nil, fmt.Errorf("webhook host %s can not be resolved; %s", u.Hostname(), err.Error())
+	}
+	for _, ip := range ips {
+		if err := webhookCheckIP(ip); err != nil {
+			return nil, err
+		}
+	}
+	return u, nil
+}
+
+// webhookClient provides the HTTP client of the webhook deliveries.
+// The address is verified on each connection, so a host re-bound to an internal address
+// after the registration, or a redirect to an internal address, is refused.
+func webhookClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(_ string, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			return webhookCheckIP(net.ParseIP(host))
+		},
+	}
+
+	tr := http.DefaultTransport.(*http.Transport).Clone()
+	tr.Proxy = nil
+	tr.DialContext = dialer.DialContext
+	return &http.Client{Timeout: timeout, Transport: tr}
+}
+
+// webhookPrivateNets lists the private and shared address ranges webhooks can not target.
+var webhookPrivateNets = []*net.IPNet{
+	webhookCIDR("10.0.0.0/8"),
+	webhookCIDR("172.16.0.0/12"),
+	webhookCIDR("192.168.0.0/16"),
+	webhookCIDR("100.64.0.0/10"),
+	webhookCIDR("fc00::/7"),
+}
+
+// webhookCheckIP verifies the webhook target address is a public unicast address.
+func webhookCheckIP(ip net.IP) error {
+	if ip == nil || ip.IsLoopback() || ip.IsUnspecified() || ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsMulticast() {
+		return fmt.Errorf("%w; address %s", ErrWebhookTargetForbidden, ip)
+	}
+	for _, n := range webhookPrivateNets {
+		if n.Contains(ip) {
+			return fmt.Errorf("%w; address %s", ErrWebhookTargetForbidden, ip)
+		}
+	}
+	return nil
+}
+
+// webhookCIDR parses the given network range.
+func webhookCIDR(cidr string) *net.IPNet {
+	_, n, err := net.ParseCIDR(cidr)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+// webhookSignature calculates the signature header value of the given payload.
+func webhookSignature(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// webhookID generates a new random webhook identifier.
+func webhookID() (string, error) {
+	var id [16]byte
+	if _, err := rand.Read(id[:]); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(id[:]), nil
+}
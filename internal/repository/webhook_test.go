@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"errors"
+	"github.com/onsi/gomega"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestWebhookURL(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	tests := []struct {
+		url       string
+		forbidden bool
+		invalid   bool
+	}{
+		{url: "https://8.8.8.8/hook"},
+		{url: "http://[2001:4860:4860::8888]:8080/hook"},
+		{url: "http://127.0.0.1/hook", forbidden: true},
+		{url: "http://[::1]/hook", forbidden: true},
+		{url: "http://10.1.2.3/hook", forbidden: true},
+		{url: "http://172.20.0.1/hook", forbidden: true},
+		{url: "http://192.168.1.1/hook", forbidden: true},
+		{url: "http://169.254.169.254/latest/meta-data", forbidden: true},
+		{url: "http://0.0.0.0/hook", forbidden: true},
+		{url: "http://[fd00::1]/hook", forbidden: true},
+		{url: "ftp://8.8.8.8/hook", invalid: true},
+		{url: "file:///etc/passwd", invalid: true},
+		{url: "https:///hook", invalid: true},
+	}
+
+	for _, tc := range tests {
+		_, err := webhookURL(tc.url)
+		switch {
+		case tc.forbidden:
+			g.Expect(errors.Is(err, ErrWebhookTargetForbidden)).To(gomega.BeTrue(), tc.url)
+		case tc.invalid:
+			g.Expect(err).To(gomega.HaveOccurred(), tc.url)
+		default:
+			g.Expect(err).NotTo(gomega.HaveOccurred(), tc.url)
+		}
+	}
+}
+
+func TestWebhookClientRefusesLoopback(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	_, err := webhookClient(time.Second).Post(srv.URL, "application/json", nil)
+	g.Expect(errors.Is(err, ErrWebhookTargetForbidden)).To(gomega.BeTrue())
+}
+
+// TestWebhookRetryDelay tests the retry delay doubles with each failed attempt
+// and never exceeds the max retry delay, even for large numbers of attempts.
+func TestWebhookRetryDelay(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	g.Expect(webhookRetryDelay(30*time.Second, 1)).To(gomega.Equal(30 * time.Second))
+	g.Expect(webhookRetryDelay(30*time.Second, 2)).To(gomega.Equal(time.Minute))
+	g.Expect(webhookRetryDelay(30*time.Second, 5)).To(gomega.Equal(8 * time.Minute))
+	g.Expect(webhookRetryDelay(30*time.Second, 64)).To(gomega.Equal(webhookMaxRetryDelay))
+	g.Expect(webhookRetryDelay(30*time.Second, 1000)).To(gomega.Equal(webhookMaxRetryDelay))
+	g.Expect(webhookRetryDelay(48*time.Hour, 1)).To(gomega.Equal(webhookMaxRetryDelay))
+	g.Expect(webhookRetryDelay(0, 100)).To(gomega.BeZero())
+}
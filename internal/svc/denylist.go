@@ -0,0 +1,208 @@
+// Package svc implements blockchain data processing services.
+package svc
+
+import (
+	"bufio"
+	"fantom-api-graphql/internal/config"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"go.uber.org/atomic"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// denylistSelectorLength is the length of a call selector in the denylist.
+const denylistSelectorLength = 4
+
+// denylistAllowPrefix marks the allowlist entries in the denylist file.
+const denylistAllowPrefix = "+"
+
+// denylist represents the set of addresses and call selectors excluded from the analysis.
+// If the allowlist of call selectors is not empty, only calls of the allowed methods are analyzed.
+type denylist struct {
+	addr  map[common.Address]bool
+	sel   map[[denylistSelectorLength]byte]bool
+	allow map[[denylistSelectorLength]byte]bool
+}
+
+// activeDenylist is the denylist currently used by the dispatchers.
+var activeDenylist = struct {
+	sync.RWMutex
+	list *denylist
+}{list: &denylist{}}
+
+// DenylistStats represents the current state and the impact of the analysis denylist.
+type DenylistStats struct {
+	Addresses    int
+	Selectors    int
+	Allowed      int
+	SkippedTrx   uint64
+	SkippedLogs  uint64
+	LastReloaded *time.Time
+}
+
+// denylist counters
+var (
+	denylistSkippedTrx   = atomic.NewUint64(0)
+	denylistSkippedLogs  = atomic.NewUint64(0)
+	denylistLastReloaded = atomic.NewTime(time.Time{})
+)
+
+// Denylist provides the current state and the impact of the analysis denylist.
+func Denylist() DenylistStats {
+	activeDenylist.RLock()
+	dl := activeDenylist.list
+	activeDenylist.RUnlock()
+
+	st := DenylistStats{
+		Addresses:   len(dl.addr),
+		Selectors:   len(dl.sel),
+		Allowed:     len(dl.allow),
+		SkippedTrx:  denylistSkippedTrx.Load(),
+		SkippedLogs: denylistSkippedLogs.Load(),
+	}
+	if lr := denylistLastReloaded.Load(); !lr.IsZero() {
+		st.LastReloaded = &lr
+	}
+	return st
+}
+
+// loadDenylist builds the denylist from the configuration, and the list file, if any.
+func loadDenylist(dc *config.Denylist) (*denylist, error) {
+	dl := denylist{
+		addr:  make(map[common.Address]bool, len(dc.Addresses)),
+		sel:   make(map[[denylistSelectorLength]byte]bool, len(dc.Selectors)),
+		allow: make(map[[denylistSelectorLength]byte]bool, len(dc.Allow)),
+	}
+
+	for _, a := range dc.Addresses {
+		dl.addr[a] = true
+	}
+	for _, s := range dc.Selectors {
+		if err := dl.add(s); err != nil {
+			return nil, err
+		}
+	}
+	for _, s := range dc.Allow {
+		if err := dl.addAllowed(s); err != nil {
+			return nil, err
+		}
+	}
+
+	if dc.File == "" {
+		return &dl, nil
+	}
+
+	f, err := os.Open(dc.File)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			log.Errorf("can not close denylist file; %s", err.Error())
+		}
+	}()
+
+	if err := dl.parse(f); err != nil {
+		return nil, err
+	}
+	return &dl, nil
+}
+
+// parse adds entries from the given list, one address, or selector per line.
+// Lines starting with + are allowlist entries. Empty lines and lines starting with # are ignored.
+func (dl *denylist) parse(r io.Reader) error {
+	sc := bufio.NewScanner(r)
+	for ln := 1; sc.Scan(); ln++ {
+		s := strings.TrimSpace(sc.Text())
+		if s == "" || strings.HasPrefix(s, "#") {
+			continue
+		}
+		var err error
+		if strings.HasPrefix(s, denylistAllowPrefix) {
+			err = dl.addAllowed(strings.TrimPrefix(s, denylistAllowPrefix))
+		} else {
+			err = dl.add(s)
+		}
+		if err != nil {
+			return fmt.Errorf("line %d; %s", ln, err.Error())
+		}
+	}
+	return sc.Err()
+}
+
+// add adds an address, or a call selector in hex to the denylist.
+func (dl *denylist) add(s string) error {
+	b, err := hexutil.Decode(strings.TrimSpace(s))
+	if err != nil {
+		return fmt.Errorf("invalid denylist entry %s; %s", s, err.Error())
+	}
+
+	switch len(b) {
+	case common.AddressLength:
+		dl.addr[common.BytesToAddress(b)] = true
+	case denylistSelectorLength:
+		var sel [denylistSelectorLength]byte
+		copy(sel[:], b)
+		dl.sel[sel] = true
+	default:
+		return fmt.Errorf("invalid denylist entry %s; address, or 4 bytes selector expected", s)
+	}
+	return nil
+}
+
+// addAllowed adds a call selector in hex, or a method signature, e.g. transfer(address,uint256),
+// to the allowlist.
+func (dl *denylist) addAllowed(s string) error {
+	s = strings.TrimSpace(s)
+
+	var b []byte
+	if strings.Contains(s, "(") {
+		b = crypto.Keccak256([]byte(strings.ReplaceAll(s, " ", "")))[:denylistSelectorLength]
+	} else if d, err := hexutil.Decode(s); err == nil && len(d) == denylistSelectorLength {
+		b = d
+	} else {
+		return fmt.Errorf("invalid allowlist entry %s; 4 bytes selector, or method signature expected", s)
+	}
+
+	var sel [denylistSelectorLength]byte
+	copy(sel[:], b)
+	dl.allow[sel] = true
+	return nil
+}
+
+// isDeniedTrx checks if the given transaction calls a denied address, or a denied method.
+// Calls of methods not allowed by a non-empty allowlist are denied, too; plain transfers
+// and contract creations are never denied by the allowlist.
+func isDeniedTrx(trx *types.Transaction) bool {
+	activeDenylist.RLock()
+	dl := activeDenylist.list
+	activeDenylist.RUnlock()
+
+	if trx.To == nil {
+		return false
+	}
+	if dl.addr[*trx.To] {
+		return true
+	}
+	if len(trx.InputData) < denylistSelectorLength {
+		return false
+	}
+
+	var sel [denylistSelectorLength]byte
+	copy(sel[:], trx.InputData[:denylistSelectorLength])
+	return dl.sel[sel] || (len(dl.allow) > 0 && !dl.allow[sel])
+}
+
+// isDeniedEmitter checks if the given address is denied as the emitter of logs.
+func isDeniedEmitter(addr *common.Address) bool {
+	activeDenylist.RLock()
+	defer activeDenylist.RUnlock()
+	return activeDenylist.list.addr[*addr]
+}
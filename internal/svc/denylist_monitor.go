@@ -0,0 +1,101 @@
+// Package svc implements blockchain data processing services.
+package svc
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+// denylistMonitor represents a service reloading the analysis denylist when its file changes.
+type denylistMonitor struct {
+	service
+	ticker   *time.Ticker
+	modified time.Time
+}
+
+// name returns a human-readable name of the service used by the manager.
+func (dlm *denylistMonitor) name() string {
+	return "denylist monitor"
+}
+
+// init loads the denylist, so it's ready before the dispatchers start.
+func (dlm *denylistMonitor) init() {
+	dlm.sigStop = make(chan bool, 1)
+	dlm.reload()
+}
+
+// run starts the denylist monitoring.
+func (dlm *denylistMonitor) run() {
+	// make sure we are orchestrated
+	if dlm.mgr == nil {
+		panic(fmt.Errorf("no svc manager set on %s", dlm.name()))
+	}
+
+	// start go routine for processing
+	dlm.mgr.started(dlm)
+	go dlm.execute()
+}
+
+// close terminates the denylist monitor.
+func (dlm *denylistMonitor) close() {
+	if dlm.ticker != nil {
+		dlm.ticker.Stop()
+	}
+	if dlm.sigStop != nil {
+		dlm.sigStop <- true
+	}
+}
+
+// execute performs regular ticker based checks of the denylist file.
+func (dlm *denylistMonitor) execute() {
+	defer func() {
+		close(dlm.sigStop)
+		dlm.mgr.finished(dlm)
+	}()
+
+	// nothing to watch without the list file
+	if cfg.Denylist.File == "" || cfg.Denylist.Reload <= 0 {
+		<-dlm.sigStop
+		return
+	}
+
+	dlm.ticker = time.NewTicker(cfg.Denylist.Reload)
+	for {
+		select {
+		case <-dlm.sigStop:
+			return
+		case <-dlm.ticker.C:
+			dlm.reload()
+		}
+	}
+}
+
+// reload loads the denylist again, if the list file changed since the last load.
+// The previous list stays active if the new one can not be loaded.
+func (dlm *denylistMonitor) reload() {
+	if cfg.Denylist.File != "" {
+		fi, err := os.Stat(cfg.Denylist.File)
+		if err != nil {
+			log.Errorf("can not check denylist file %s; %s", cfg.Denylist.File, err.Error())
+			return
+		}
+		if !fi.ModTime().After(dlm.modified) {
+			return
+		}
+		dlm.modified = fi.ModTime()
+	}
+
+	dl, err := loadDenylist(&cfg.Denylist)
+	if err != nil {
+		log.Errorf("can not load denylist; %s", err.Error())
+		return
+	}
+
+	activeDenylist.Lock()
+	activeDenylist.list = dl
+	activeDenylist.Unlock()
+
+	denylistLastReloaded.Store(time.Now().UTC())
+	log.Noticef("denylist loaded with %d addresses, %d selectors and %d allowed selectors", len(dl.addr), len(dl.sel), len(dl.allow))
+}
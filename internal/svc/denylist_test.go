@@ -0,0 +1,116 @@
+package svc
+
+import (
+	"fantom-api-graphql/internal/config"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/onsi/gomega"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestDenylist tests loading of the denylist and matching of transactions against it.
+func TestDenylist(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	spam := common.HexToAddress("0x5ba5")
+	other := common.HexToAddress("0x0123")
+	listed := common.HexToAddress("0xf11e")
+
+	file := filepath.Join(t.TempDir(), "denylist.txt")
+	g.Expect(os.WriteFile(file, []byte("# spam airdrops\n\n"+listed.String()+"\n  0x095ea7b3  \n"), 0600)).To(gomega.Succeed())
+
+	dl, err := loadDenylist(&config.Denylist{
+		Addresses: []common.Address{spam},
+		Selectors: []string{"0xa9059cbb"},
+		File:      file,
+	})
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(dl.addr).To(gomega.HaveLen(2))
+	g.Expect(dl.sel).To(gomega.HaveLen(2))
+
+	activeDenylist.list = dl
+	defer func() { activeDenylist.list = &denylist{} }()
+
+	tests := []struct {
+		name   string
+		to     *common.Address
+		input  string
+		denied bool
+	}{
+		{"denied address", &spam, "0x", true},
+		{"address from file", &listed, "0x12345678", true},
+		{"denied selector", &other, "0xa9059cbb0000", true},
+		{"selector from file", &other, "0x095ea7b3", true},
+		{"other call", &other, "0x12345678", false},
+		{"short input", &other, "0xa905", false},
+		{"contract creation", nil, "0xa9059cbb", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			g.Expect(isDeniedTrx(&types.Transaction{To: tt.to, InputData: hexutil.MustDecode(tt.input)})).To(gomega.Equal(tt.denied))
+		})
+	}
+
+	g.Expect(isDeniedEmitter(&spam)).To(gomega.BeTrue())
+	g.Expect(isDeniedEmitter(&other)).To(gomega.BeFalse())
+}
+
+// TestDenylistInvalid tests invalid denylist entries are rejected.
+func TestDenylistInvalid(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	for _, s := range []string{"spam", "0x1234", "0x123456789a"} {
+		_, err := loadDenylist(&config.Denylist{Selectors: []string{s}})
+		g.Expect(err).NotTo(gomega.BeNil(), s)
+	}
+}
+
+// TestDenylistAllow tests the allowlist of call selectors combined with the denylist.
+func TestDenylistAllow(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	token := common.HexToAddress("0x70ce")
+	spam := common.HexToAddress("0x5ba5")
+
+	file := filepath.Join(t.TempDir(), "denylist.txt")
+	g.Expect(os.WriteFile(file, []byte("+ approve(address, uint256)\n"+spam.String()+"\n"), 0600)).To(gomega.Succeed())
+
+	dl, err := loadDenylist(&config.Denylist{
+		Selectors: []string{"0x23b872dd"},
+		Allow:     []string{"transfer(address,uint256)", "0x23b872dd"},
+		File:      file,
+	})
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(dl.allow).To(gomega.HaveLen(3))
+
+	activeDenylist.list = dl
+	defer func() { activeDenylist.list = &denylist{} }()
+
+	tests := []struct {
+		name   string
+		to     *common.Address
+		input  string
+		denied bool
+	}{
+		{"allowed signature", &token, "0xa9059cbb0000", false},
+		{"allowed from file", &token, "0x095ea7b3", false},
+		{"denied wins over allowed", &token, "0x23b872dd", true},
+		{"denied address", &spam, "0xa9059cbb", true},
+		{"not allowed", &token, "0x12345678", true},
+		{"plain transfer", &token, "0x", false},
+		{"contract creation", nil, "0x12345678", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			g.Expect(isDeniedTrx(&types.Transaction{To: tt.to, InputData: hexutil.MustDecode(tt.input)})).To(gomega.Equal(tt.denied))
+		})
+	}
+
+	_, err = loadDenylist(&config.Denylist{Allow: []string{"transfer"}})
+	g.Expect(err).NotTo(gomega.BeNil())
+}
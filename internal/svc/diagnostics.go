@@ -0,0 +1,58 @@
+// Package svc implements blockchain data processing services.
+package svc
+
+import (
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"sync"
+)
+
+// svcErrors keeps the last error reported by each of the services.
+var svcErrors = struct {
+	sync.Mutex
+	byName map[string]*types.ErrorTracker
+}{byName: make(map[string]*types.ErrorTracker)}
+
+// logError logs the error of the given service and records it for the diagnostics.
+func logError(s Svc, format string, args ...interface{}) {
+	msg := fmt.Sprintf(format, args...)
+	log.Error(msg)
+	errorTracker(s.name()).Fail(msg)
+}
+
+// errorTracker provides the error tracker of the service of the given name.
+func errorTracker(name string) *types.ErrorTracker {
+	svcErrors.Lock()
+	defer svcErrors.Unlock()
+
+	et, ok := svcErrors.byName[name]
+	if !ok {
+		et = new(types.ErrorTracker)
+		svcErrors.byName[name] = et
+	}
+	return et
+}
+
+// Diagnostics provides the runtime state of the block scanner, the depth of the processing
+// queues and the status of all the managed services.
+func (mgr *ServiceManager) Diagnostics() *types.SvcDiagnostics {
+	diag := types.SvcDiagnostics{
+		Scanner:  mgr.bls.status(),
+		Services: make([]types.SubsystemStatus, 0, len(mgr.svc)),
+		Queues: []types.QueueStatus{
+			{Name: "blocks", Length: len(mgr.bls.outBlock), Capacity: cap(mgr.bls.outBlock)},
+			{Name: "transactions", Length: len(mgr.bld.outTransaction), Capacity: cap(mgr.bld.outTransaction)},
+			{Name: "dispatched", Length: len(mgr.bld.outDispatched), Capacity: cap(mgr.bld.outDispatched)},
+			{Name: "accounts", Length: len(mgr.trd.outAccount), Capacity: cap(mgr.trd.outAccount)},
+			{Name: "logs", Length: len(mgr.trd.outLog), Capacity: cap(mgr.trd.outLog)},
+		},
+	}
+
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+
+	for _, s := range mgr.svc {
+		diag.Services = append(diag.Services, errorTracker(s.name()).Status(s.name(), mgr.running[s.name()]))
+	}
+	return &diag
+}
@@ -0,0 +1,30 @@
+package svc
+
+import (
+	"github.com/onsi/gomega"
+	"sync"
+	"testing"
+)
+
+// TestServiceErrorTracking tests recording of the service errors and the running state.
+func TestServiceErrorTracking(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	mgr := &ServiceManager{wg: new(sync.WaitGroup), running: make(map[string]bool)}
+	gps := &gpsMonitor{service: service{mgr: mgr}}
+
+	st := errorTracker(gps.name()).Status(gps.name(), false)
+	g.Expect(st.LastError).To(gomega.BeNil())
+
+	mgr.started(gps)
+	g.Expect(mgr.running[gps.name()]).To(gomega.BeTrue())
+
+	logError(gps, "can not load block #%d", 5)
+	st = errorTracker(gps.name()).Status(gps.name(), mgr.running[gps.name()])
+	g.Expect(st.IsUp).To(gomega.BeTrue())
+	g.Expect(st.LastError).NotTo(gomega.BeNil())
+	g.Expect(st.LastErrorMessage).To(gomega.Equal("can not load block #5"))
+
+	mgr.finished(gps)
+	g.Expect(mgr.running[gps.name()]).To(gomega.BeFalse())
+}
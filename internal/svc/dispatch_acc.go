@@ -15,12 +15,6 @@ const (
 	sfcCheckBelowBlock = 100000
 )
 
-// testAddress represents an address used to test an account reference
-var testAddress = common.HexToAddress("0xabc00FA001230012300aBc0012300Fa00FACE000")
-
-var erc721InterfaceId = [4]byte{0x80, 0xac, 0x58, 0xcd}  // ERC-721: 0x80ac58cd
-var erc1155InterfaceId = [4]byte{0xd9, 0xb6, 0x7a, 0x26} // ERC-1155: 0xd9b67a26
-
 // accDispatcher implements account dispatcher queue
 type accDispatcher struct {
 	inAccount chan *eventAcc
@@ -68,7 +62,7 @@ func (acd *accDispatcher) execute() {
 			// do the stuff
 			err := acd.process(acc)
 			if err != nil {
-				log.Errorf("failed account %s processing; %s", acc.addr.String(), err.Error())
+				logError(acd, "failed account %s processing; %s", acc.addr.String(), err.Error())
 			}
 
 			// signal this account has been processed
@@ -85,11 +79,13 @@ func (acd *accDispatcher) process(acc *eventAcc) error {
 
 	// check if the account is new; if we already know it, we are done
 	if repo.AccountIsKnown(acc.addr) {
+		acd.checkContract(acc)
 		return repo.AccountMarkActivity(acc.addr, uint64(acc.blk.TimeStamp))
 	}
 
-	// is this a simple wallet/account?
-	if acc.trx.ContractAddress != nil {
+	// is this the contract created by the transaction?
+	// the sender and the recipient of the deployment are not
+	if acc.isCreated() {
 		err := acd.processContract(acc)
 		if err != nil {
 			return err
@@ -98,6 +94,18 @@ func (acd *accDispatcher) process(acc *eventAcc) error {
 	return acd.wallet(acc)
 }
 
+// checkContract updates the state of a known contract involved in the transaction.
+// A contract deployed again to the same address by CREATE2 replaces the previous one.
+// Self-destructs are detected from the call traces, not on every contract call.
+func (acd *accDispatcher) checkContract(acc *eventAcc) {
+	if !acc.isCreated() {
+		return
+	}
+	if err := acd.processContract(acc); err != nil {
+		logError(acd, "can not update re-deployed contract %s; %s", acc.addr.String(), err.Error())
+	}
+}
+
 // wallet processes a simple non-contract wallet account into the database
 // based on the account details (it still could be the SFC, be cautious about it)
 func (acd *accDispatcher) wallet(acc *eventAcc) error {
@@ -113,7 +121,7 @@ func (acd *accDispatcher) wallet(acc *eventAcc) error {
 		TrxCounter:   1,
 	})
 	if err != nil {
-		log.Errorf("can not add account %s; %s", acc.addr.String(), err.Error())
+		logError(acd, "can not add account %s; %s", acc.addr.String(), err.Error())
 	}
 	return err
 }
@@ -126,7 +134,7 @@ func (acd *accDispatcher) processContract(acc *eventAcc) error {
 	// detect and identify contract
 	contract, accountType, err := acd.detectContract(acc.addr, acc.blk, acc.trx)
 	if err != nil {
-		log.Errorf("can not identify contract at %s; %s", acc.addr.String(), err.Error())
+		logError(acd, "can not identify contract at %s; %s", acc.addr.String(), err.Error())
 		return err
 	}
 	acc.act = accountType
@@ -134,9 +142,14 @@ func (acd *accDispatcher) processContract(acc *eventAcc) error {
 
 	// insert the contract record if possible
 	if contract != nil {
+		// contracts created by an internal call are deployed by the calling contract
+		if acc.deployer != nil {
+			contract.Deployer = acc.deployer
+		}
+
 		err = repo.StoreContract(contract)
 		if err != nil {
-			log.Errorf("can not add contract at %s; %s", acc.addr.String(), err.Error())
+			logError(acd, "can not add contract at %s; %s", acc.addr.String(), err.Error())
 			return err
 		}
 	}
@@ -145,26 +158,17 @@ func (acd *accDispatcher) processContract(acc *eventAcc) error {
 
 // detectContract tries to identify the contract type.
 func (acd *accDispatcher) detectContract(addr *common.Address, block *types.Block, trx *types.Transaction) (*types.Contract, string, error) {
-
-	isErc1155, err := repo.Erc165SupportsInterface(addr, erc1155InterfaceId)
-	if err == nil && isErc1155 {
+	ct, name := repo.DetectContractType(addr)
+	switch ct {
+	case types.AccountTypeERC1155Contract:
 		log.Noticef("ERC1155 multi-token detected at %s", addr.String())
-		contract := types.NewErcTokenContract(addr, "", block, trx, types.AccountTypeERC1155Contract, contracts.ERC1155MetaData.ABI)
-		return contract, types.AccountTypeERC1155Contract, nil
-	}
-
-	isErc721, name := acd.detectErc721Token(addr)
-	if err == nil && isErc721 {
+		return types.NewErcTokenContract(addr, "", block, trx, ct, contracts.ERC1155MetaData.ABI), ct, nil
+	case types.AccountTypeERC721Contract:
 		log.Noticef("ERC721 NFT token detected at %s", addr.String())
-		contract := types.NewErcTokenContract(addr, name, block, trx, types.AccountTypeERC721Contract, contracts.ERC721MetaData.ABI)
-		return contract, types.AccountTypeERC721Contract, nil
-	}
-
-	isErc20, name := acd.detectErc20Token(addr)
-	if isErc20 {
+		return types.NewErcTokenContract(addr, name, block, trx, ct, contracts.ERC721MetaData.ABI), ct, nil
+	case types.AccountTypeERC20Token:
 		log.Noticef("ERC20 token %s detected at %s", name, addr.String())
-		contract := types.NewErcTokenContract(addr, name, block, trx, types.AccountTypeERC20Token, contracts.ERCTwentyMetaData.ABI)
-		return contract, types.AccountTypeERC20Token, nil
+		return types.NewErcTokenContract(addr, name, block, trx, ct, contracts.ERCTwentyMetaData.ABI), ct, nil
 	}
 
 	// log that the detection failed
@@ -173,44 +177,3 @@ func (acd *accDispatcher) detectContract(addr *common.Address, block *types.Bloc
 	// set as generic contract type if no other has been detected
 	return types.NewGenericContract(addr, block, trx), types.AccountTypeContract, nil
 }
-
-// detectErc20Token identifies ERC20 token contracts by trying to call specific contract methods.
-func (acd *accDispatcher) detectErc20Token(addr *common.Address) (isErc20 bool, name string) {
-	// try to get the token name
-	name, err := repo.Erc20Name(addr)
-	if err != nil {
-		return false, ""
-	}
-
-	// try to detect symbol
-	if _, err := repo.Erc20Symbol(addr); err != nil {
-		return false, ""
-	}
-
-	// try to detect balance of
-	if _, err := repo.Erc20BalanceOf(addr, &testAddress); err != nil {
-		return false, ""
-	}
-
-	// try to detect total supply
-	if _, err := repo.Erc20TotalSupply(addr); err != nil {
-		return false, ""
-	}
-
-	return true, name
-}
-
-func (acd *accDispatcher) detectErc721Token(addr *common.Address) (isErc721 bool, name string) {
-	isErc721, err := repo.Erc165SupportsInterface(addr, erc721InterfaceId)
-	if err != nil || !isErc721 {
-		return false, ""
-	}
-
-	// try to detect name - but it is optional for ERC721
-	name, err = repo.Erc20Name(addr)
-	if err != nil {
-		return true, ""
-	}
-
-	return true, name
-}
@@ -16,6 +16,9 @@ const trxBufferCapacity = 50000
 type eventTrx struct {
 	blk *types.Block
 	trx *types.Transaction
+
+	// orphaned signals the transaction has been dropped from the chain by a reorganization
+	orphaned bool
 }
 
 // blockDispatcher implements a service responsible for processing new blocks on the blockchain.
@@ -25,6 +28,10 @@ type blockDispatcher struct {
 	inBlock        chan *types.Block
 	outTransaction chan *eventTrx
 	outDispatched  chan uint64
+	reorgs         *reorgGuard
+
+	// lastBlock is the previous block dispatched, used to detect epoch transitions
+	lastBlock *types.Block
 }
 
 // name returns the name of the service used by orchestrator.
@@ -37,6 +44,7 @@ func (bld *blockDispatcher) init() {
 	bld.sigStop = make(chan bool, 1)
 	bld.outTransaction = make(chan *eventTrx, trxBufferCapacity)
 	bld.outDispatched = make(chan uint64, blsBlockBufferCapacity)
+	bld.reorgs = newReorgGuard(cfg.Repository.Confirmations)
 }
 
 // run starts the block dispatcher
@@ -108,6 +116,14 @@ func (bld *blockDispatcher) process(blk *types.Block) bool {
 		return false
 	}
 
+	// verify the block against recently dispatched blocks
+	if !bld.guardReorg(blk) {
+		return false
+	}
+
+	// record the seal of the previous epoch if the block starts a new one
+	bld.trackEpochSeal(blk)
+
 	if blk.Txs == nil || len(blk.Txs) == 0 {
 		log.Debugf("empty block #%d processed", blk.Number)
 		return true
@@ -122,6 +138,50 @@ func (bld *blockDispatcher) process(blk *types.Block) bool {
 	return true
 }
 
+// guardReorg checks the block for a chain reorganization. If detected, the replacing canonical blocks
+// are re-processed and the transactions dropped from the chain are removed. Observe terminate signal.
+func (bld *blockDispatcher) guardReorg(blk *types.Block) bool {
+	verify := time.Since(time.Unix(int64(blk.TimeStamp), 0)) < rgVerifyAge
+	ro, err := bld.reorgs.check(blk, repo.CanonicalBlock, verify)
+	if err != nil {
+		logError(bld, "can not verify block #%d against the chain; %s", blk.Number, err.Error())
+		return true
+	}
+	if ro == nil {
+		return true
+	}
+
+	log.Warningf("chain reorganization at block #%d, %d blocks orphaned", ro.orphaned[0].Number, len(ro.orphaned))
+
+	// cached transactions of orphaned blocks may carry outdated block and receipt data
+	for _, ob := range ro.orphaned {
+		for _, th := range ob.Txs {
+			repo.EvictTransaction(th)
+		}
+	}
+
+	for _, cb := range ro.replaced {
+		log.Noticef("re-processing block #%d %s", cb.Number, cb.Hash.String())
+		if !bld.processTxs(cb) {
+			return false
+		}
+		repo.CacheBlock(cb)
+	}
+
+	// remove transactions not included in the canonical chain anymore;
+	// the removal goes through the trx queue to keep the order with previous updates
+	for _, th := range ro.dropped(blk) {
+		log.Noticef("transaction %s dropped by chain reorganization", th.String())
+		select {
+		case bld.outTransaction <- &eventTrx{blk: blk, trx: &types.Transaction{Hash: *th}, orphaned: true}:
+		case <-bld.sigStop:
+			bld.sigStop <- true
+			return false
+		}
+	}
+	return true
+}
+
 // processTxs loops all the transactions in the block and pushes them
 // into the transaction dispatcher queue observing the term signal.
 func (bld *blockDispatcher) processTxs(blk *types.Block) bool {
@@ -149,7 +209,7 @@ func (bld *blockDispatcher) load(blk *types.Block, th *common.Hash) *types.Trans
 	// get transaction
 	trx, err := repo.Transaction(th, false)
 	if err != nil {
-		log.Errorf("transaction %s detail not available; %s", th.String(), err.Error())
+		logError(bld, "transaction %s detail not available; %s", th.String(), err.Error())
 		return nil
 	}
 
@@ -2,6 +2,7 @@
 package svc
 
 import (
+	"fantom-api-graphql/internal/repository"
 	"fantom-api-graphql/internal/types"
 	"fmt"
 	"github.com/ethereum/go-ethereum/common"
@@ -21,6 +22,10 @@ const trxLogQueueCapacity = 5000
 // trxDispatchBlockUpdateTicker represents the period of block registry updater.
 const trxDispatchBlockUpdateTicker = 15 * time.Second
 
+// trxTracedEmittersCapacity is the max number of unknown log emitters remembered
+// as already checked for internal creation; the list is cleared if it grows over.
+const trxTracedEmittersCapacity = 10000
+
 // eventAcc represents a structure of a mentioned account.
 type eventAcc struct {
 	watchDog *sync.WaitGroup
@@ -29,6 +34,15 @@ type eventAcc struct {
 	blk      *types.Block
 	trx      *types.Transaction
 	deploy   *common.Hash
+
+	// deployer is the creator of a contract created by an internal call of the transaction
+	deployer *common.Address
+}
+
+// isCreated checks if the account is a contract created by the transaction,
+// either directly, or by an internal call.
+func (acc *eventAcc) isCreated() bool {
+	return acc.deployer != nil || (acc.trx.ContractAddress != nil && *acc.trx.ContractAddress == *acc.addr)
 }
 
 // trxDispatcher implements dispatcher of new transactions in the blockchain.
@@ -40,6 +54,18 @@ type trxDispatcher struct {
 	inTransaction chan *eventTrx
 	outAccount    chan *eventAcc
 	outLog        chan *types.LogRecord
+
+	// storing tracks transactions waiting to be stored by their hash,
+	// so an orphaned transaction is not removed before it's stored
+	storingMu sync.Mutex
+	storing   map[common.Hash]chan struct{}
+
+	// traced holds unknown log emitters already looked up in the call trace,
+	// so contracts created before they were tracked don't trigger a trace on each call
+	traced map[common.Address]bool
+
+	// noTrace signals the call traces are disabled, or not supported by the node
+	noTrace bool
 }
 
 // name returns the name of the service used by orchestrator.
@@ -53,6 +79,9 @@ func (trd *trxDispatcher) init() {
 	trd.blkObserver = atomic.NewUint64(1)
 	trd.outAccount = make(chan *eventAcc, trxAddressQueueCapacity)
 	trd.outLog = make(chan *types.LogRecord, trxLogQueueCapacity)
+	trd.storing = make(map[common.Hash]chan struct{})
+	trd.traced = make(map[common.Address]bool)
+	trd.noTrace = !cfg.Features.Trace
 }
 
 // run starts the transaction dispatcher job
@@ -110,6 +139,10 @@ func (trd *trxDispatcher) execute() {
 				log.Criticalf("dispatcher dry loop")
 				continue
 			}
+			if evt.orphaned {
+				trd.dropOrphaned(evt)
+				continue
+			}
 			trd.process(evt)
 		}
 	}
@@ -125,7 +158,7 @@ func (trd *trxDispatcher) updateLastSeenBlock() {
 	// make the change in the database so the progress persists
 	err := repo.UpdateLastKnownBlock((*hexutil.Uint64)(&lsb))
 	if err != nil {
-		log.Errorf("could not update last seen block; %s", err.Error())
+		logError(trd, "could not update last seen block; %s", err.Error())
 	}
 }
 
@@ -137,8 +170,18 @@ func (trd *trxDispatcher) process(evt *eventTrx) {
 		return
 	}
 
+	// calls of denied contracts and methods are recorded without the analysis of their logs
+	denied := isDeniedTrx(evt.trx)
+	if denied {
+		denylistSkippedTrx.Inc()
+	}
+
 	// process transaction logs; exit if terminated
 	for _, lg := range evt.trx.Logs {
+		if denied || isDeniedEmitter(&lg.Address) {
+			denylistSkippedLogs.Inc()
+			continue
+		}
 		if !trd.pushLog(lg, evt.blk, evt.trx, &wg) {
 			return
 		}
@@ -146,7 +189,7 @@ func (trd *trxDispatcher) process(evt *eventTrx) {
 
 	// store the transaction into the database once the processing is done
 	// we spawn a lot of go-routines here, so we should test the optimal queue length above
-	go trd.waitAndStore(evt, &wg)
+	go trd.waitAndStore(evt, &wg, trd.startStoring(&evt.trx.Hash))
 
 	// broadcast new transaction; if it can not be broadcast quickly, skip
 	select {
@@ -156,18 +199,69 @@ func (trd *trxDispatcher) process(evt *eventTrx) {
 }
 
 // waitAndStore waits for the transaction processing to finish and stores the transaction into db.
-func (trd *trxDispatcher) waitAndStore(evt *eventTrx, wg *sync.WaitGroup) {
+// The done channel is closed once the transaction is stored.
+func (trd *trxDispatcher) waitAndStore(evt *eventTrx, wg *sync.WaitGroup, done chan struct{}) {
+	defer trd.finishStoring(&evt.trx.Hash, done)
+
 	// wait until all the sub-processors finish their job
 	wg.Wait()
 	if err := repo.StoreTransaction(evt.blk, evt.trx); err != nil {
-		log.Errorf("can not store trx %s from block #%d", evt.trx.Hash.String(), evt.blk.Number)
+		logError(trd, "can not store trx %s from block #%d", evt.trx.Hash.String(), evt.blk.Number)
+	}
+
+	// notify webhooks watching the recipient
+	if err := repo.QueueWebhookDeliveries(evt.blk, evt.trx); err != nil {
+		logError(trd, "can not queue webhooks of trx %s; %s", evt.trx.Hash.String(), err.Error())
 	}
 
 	repo.IncTrxCountEstimate(1)
 	repo.CacheTransaction(evt.trx)
+	repo.DropPendingTransaction(&evt.trx.Hash)
 	trd.blkObserver.Store(uint64(evt.blk.Number))
 }
 
+// dropOrphaned removes the transaction dropped from the chain by a reorganization
+// with the records derived from it. If the transaction is still being processed,
+// the removal waits for it to be stored, so it's not stored again after the removal.
+func (trd *trxDispatcher) dropOrphaned(evt *eventTrx) {
+	trd.storingMu.Lock()
+	done := trd.storing[evt.trx.Hash]
+	trd.storingMu.Unlock()
+
+	if done != nil {
+		select {
+		case <-done:
+		case <-trd.sigStop:
+			trd.sigStop <- true
+			return
+		}
+	}
+
+	if err := repo.RemoveTransaction(&evt.trx.Hash); err != nil {
+		logError(trd, "can not remove orphaned trx %s; %s", evt.trx.Hash.String(), err.Error())
+	}
+}
+
+// startStoring registers the transaction waiting to be stored.
+func (trd *trxDispatcher) startStoring(hash *common.Hash) chan struct{} {
+	done := make(chan struct{})
+
+	trd.storingMu.Lock()
+	trd.storing[*hash] = done
+	trd.storingMu.Unlock()
+	return done
+}
+
+// finishStoring signals the transaction has been stored.
+func (trd *trxDispatcher) finishStoring(hash *common.Hash, done chan struct{}) {
+	trd.storingMu.Lock()
+	if trd.storing[*hash] == done {
+		delete(trd.storing, *hash)
+	}
+	trd.storingMu.Unlock()
+	close(done)
+}
+
 // pushAccounts pushes given transaction accounts on both sides observing terminate signal on process.
 func (trd *trxDispatcher) pushAccounts(evt *eventTrx, wg *sync.WaitGroup) bool {
 	// the sender is always present
@@ -180,28 +274,109 @@ func (trd *trxDispatcher) pushAccounts(evt *eventTrx, wg *sync.WaitGroup) bool {
 		return false
 	}
 
-	// if there is no contract created, we are done here
-	if evt.trx.ContractAddress == nil {
+	// queue the new contract to be processed as well
+	if evt.trx.ContractAddress != nil {
+		log.Debugf("contract %s found at trx %s", evt.trx.ContractAddress.String(), evt.trx.Hash.String())
+		if !trd.pushAccount(types.AccountTypeContract, evt.trx.ContractAddress, evt.blk, evt.trx, wg) {
+			return false
+		}
+	}
+	return trd.pushCreatedContracts(evt, wg)
+}
+
+// pushCreatedContracts pushes contracts created by internal calls of the transaction with their creator.
+// The receipt does not list them, so the call trace is loaded; to keep the trace calls rare,
+// only transactions with logs emitted by an account not known yet are traced.
+// Nothing is traced if the tracing is disabled, or the node does not support it.
+func (trd *trxDispatcher) pushCreatedContracts(evt *eventTrx, wg *sync.WaitGroup) bool {
+	if trd.noTrace {
 		return true
 	}
 
-	// queue the new contract to be processed as well
-	log.Debugf("contract %s found at trx %s", evt.trx.ContractAddress.String(), evt.trx.Hash.String())
-	return trd.pushAccount(types.AccountTypeContract, evt.trx.ContractAddress, evt.blk, evt.trx, wg)
+	unknown := unknownEmitters(evt.trx, func(addr *common.Address) bool {
+		return trd.traced[*addr] || repo.AccountIsKnown(addr)
+	})
+	if len(unknown) == 0 {
+		return true
+	}
+
+	if len(trd.traced)+len(unknown) > trxTracedEmittersCapacity {
+		trd.traced = make(map[common.Address]bool)
+	}
+	for _, addr := range unknown {
+		trd.traced[addr] = true
+	}
+
+	list, err := repo.InternalTransactions(&evt.trx.Hash)
+	if err == repository.ErrTraceNotSupported {
+		log.Warningf("contracts created by internal calls are not tracked; %s", err.Error())
+		trd.noTrace = true
+		return true
+	}
+	if err != nil {
+		log.Debugf("internal creations of trx %s not available; %s", evt.trx.Hash.String(), err.Error())
+		return true
+	}
+
+	for _, it := range createdContracts(evt.trx, list) {
+		log.Debugf("contract %s created by %s at trx %s", it.To.String(), it.From.String(), evt.trx.Hash.String())
+		if !trd.push(&eventAcc{watchDog: wg, addr: it.To, act: types.AccountTypeContract, blk: evt.blk, trx: evt.trx, deployer: &it.From}) {
+			return false
+		}
+	}
+	return true
+}
+
+// unknownEmitters provides the accounts not known yet emitting logs of the successful transaction,
+// other than the accounts listed in the transaction itself.
+func unknownEmitters(trx *types.Transaction, known func(*common.Address) bool) []common.Address {
+	list := make([]common.Address, 0)
+	if trx.Status == nil || *trx.Status != 1 {
+		return list
+	}
+
+	for i := range trx.Logs {
+		addr := trx.Logs[i].Address
+		if (trx.To != nil && *trx.To == addr) || (trx.ContractAddress != nil && *trx.ContractAddress == addr) {
+			continue
+		}
+		if !known(&addr) {
+			list = append(list, addr)
+		}
+	}
+	return list
+}
+
+// createdContracts picks the contracts created by internal calls from the call trace of the transaction;
+// the contract created by the transaction itself is not included.
+func createdContracts(trx *types.Transaction, list []*types.InternalTransaction) []*types.InternalTransaction {
+	res := make([]*types.InternalTransaction, 0)
+	for _, it := range list {
+		if !it.IsCreation() || (trx.ContractAddress != nil && *it.To == *trx.ContractAddress) {
+			continue
+		}
+		res = append(res, it)
+	}
+	return res
 }
 
 // pushAccount pushes given account event to output queue observing terminate signal.
 func (trd *trxDispatcher) pushAccount(at string, adr *common.Address, blk *types.Block, trx *types.Transaction, wg *sync.WaitGroup) bool {
-	wg.Add(1)
-	select {
-	case trd.outAccount <- &eventAcc{
+	return trd.push(&eventAcc{
 		watchDog: wg,
 		addr:     adr,
 		act:      at,
 		blk:      blk,
 		trx:      trx,
 		deploy:   nil,
-	}:
+	})
+}
+
+// push pushes the account event to output queue observing terminate signal.
+func (trd *trxDispatcher) push(acc *eventAcc) bool {
+	acc.watchDog.Add(1)
+	select {
+	case trd.outAccount <- acc:
 	case <-trd.sigStop:
 		trd.sigStop <- true
 		return false
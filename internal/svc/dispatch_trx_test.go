@@ -0,0 +1,67 @@
+package svc
+
+import (
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	retypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/onsi/gomega"
+	"testing"
+)
+
+// testCreationTrx makes a transaction calling a factory with logs of the given emitters.
+func testCreationTrx(status uint64, emitters ...common.Address) *types.Transaction {
+	st := hexutil.Uint64(status)
+	to := common.HexToAddress("0xfac")
+	trx := types.Transaction{Hash: common.HexToHash("0x1"), From: common.HexToAddress("0xa11ce"), To: &to, Status: &st}
+	for _, addr := range emitters {
+		trx.Logs = append(trx.Logs, retypes.Log{Address: addr})
+	}
+	return &trx
+}
+
+// TestUnknownEmitters tests only successful transactions with logs of unknown accounts
+// are selected for the internal creation lookup.
+func TestUnknownEmitters(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	known := common.HexToAddress("0xbeef")
+	fresh := common.HexToAddress("0x7e57")
+	isKnown := func(addr *common.Address) bool { return *addr == known }
+
+	g.Expect(unknownEmitters(testCreationTrx(1), isKnown)).To(gomega.BeEmpty())
+	g.Expect(unknownEmitters(testCreationTrx(1, common.HexToAddress("0xfac"), known), isKnown)).To(gomega.BeEmpty())
+	g.Expect(unknownEmitters(testCreationTrx(0, fresh), isKnown)).To(gomega.BeEmpty())
+	g.Expect(unknownEmitters(testCreationTrx(1, known, fresh), isKnown)).To(gomega.Equal([]common.Address{fresh}))
+}
+
+// TestCreatedContracts tests contracts created by internal calls are picked from the call trace
+// with the factory as their creator.
+func TestCreatedContracts(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	factory := common.HexToAddress("0xfac")
+	child := common.HexToAddress("0xc1")
+	child2 := common.HexToAddress("0xc2")
+	direct := common.HexToAddress("0xd1")
+
+	trx := testCreationTrx(1)
+	list := []*types.InternalTransaction{
+		{Type: "CALL", From: trx.From, To: &factory},
+		{Type: "CREATE", From: factory, To: &child},
+		{Type: "create2", From: child, To: &child2},
+		{Type: "CREATE", From: factory, To: nil, Error: "out of gas"},
+		{Type: "CREATE2", From: factory, To: &direct, Error: "execution reverted"},
+	}
+
+	res := createdContracts(trx, list)
+	g.Expect(res).To(gomega.HaveLen(2))
+	g.Expect(*res[0].To).To(gomega.Equal(child))
+	g.Expect(res[0].From).To(gomega.Equal(factory))
+	g.Expect(*res[1].To).To(gomega.Equal(child2))
+	g.Expect(res[1].From).To(gomega.Equal(child))
+
+	// the contract deployed by the transaction itself is not an internal creation
+	trx.To, trx.ContractAddress = nil, &direct
+	g.Expect(createdContracts(trx, []*types.InternalTransaction{{Type: "CREATE", From: trx.From, To: &direct}})).To(gomega.BeEmpty())
+}
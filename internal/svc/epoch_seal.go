@@ -0,0 +1,46 @@
+// Package svc implements blockchain data processing services.
+package svc
+
+import (
+	"fantom-api-graphql/internal/types"
+	"time"
+)
+
+// trackEpochSeal detects the transition to a new epoch between the given block
+// and the block preceding it. The epoch of the preceding block has been sealed by it
+// and the seal is recorded. Blocks without the epoch reference are ignored.
+func (bld *blockDispatcher) trackEpochSeal(blk *types.Block) {
+	prev := bld.lastBlock
+	bld.lastBlock = blk
+	if blk.Epoch == nil || blk.Number == 0 {
+		return
+	}
+
+	// blocks may not be dispatched in a sequence, e.g. on re-scan; load the preceding block
+	if prev == nil || prev.Number+1 != blk.Number {
+		num := blk.Number - 1
+
+		var err error
+		prev, err = repo.BlockByNumber(&num)
+		if err != nil {
+			log.Errorf("can not load block #%d to check epoch seal; %s", uint64(num), err.Error())
+			return
+		}
+	}
+
+	// still the same epoch?
+	if prev.Epoch == nil || *prev.Epoch >= *blk.Epoch {
+		return
+	}
+
+	es := types.EpochSeal{
+		Epoch:     *prev.Epoch,
+		Block:     prev.Number,
+		TimeStamp: time.Unix(int64(prev.TimeStamp), 0),
+	}
+	if err := repo.AddEpochSeal(&es); err != nil {
+		log.Errorf("can not store seal of epoch #%d; %s", uint64(es.Epoch), err.Error())
+		return
+	}
+	log.Noticef("epoch #%d sealed by block #%d", uint64(es.Epoch), uint64(es.Block))
+}
@@ -0,0 +1,109 @@
+// Package svc implements blockchain data processing services.
+package svc
+
+import (
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"golang.org/x/sync/singleflight"
+	"sync"
+)
+
+// erc20Heuristic promotes generic contracts emitting standard shaped ERC20 Transfer events
+// to ERC20 tokens. Some tokens do not respond to the detection calls on deployment,
+// yet they emit the token events as expected.
+type erc20Heuristic struct {
+	mu        sync.Mutex
+	threshold int
+
+	// counts keeps the number of transfers observed on candidate contracts;
+	// negative value marks contracts already decided.
+	counts map[common.Address]int
+
+	// loads shares the repository lookups of contracts seen for the first time
+	loads singleflight.Group
+}
+
+// erc20Classifier is the heuristic used by the log dispatcher.
+var erc20Classifier = &erc20Heuristic{counts: make(map[common.Address]int)}
+
+// observe registers an ERC20 shaped transfer emitted by the given contract
+// and promotes the contract once the configured number of transfers is reached.
+// The lock is held only for the counter update; repository calls run outside of it.
+func (eh *erc20Heuristic) observe(addr *common.Address) {
+	if eh.threshold <= 0 {
+		return
+	}
+
+	cnt, promote, ok := eh.count(addr, nil)
+	if !ok {
+		// the first transfer of a contract loads its state; concurrent lookups are shared
+		val, err, _ := eh.loads.Do(addr.String(), func() (interface{}, error) {
+			return eh.load(addr)
+		})
+		if err != nil || val == nil {
+			return
+		}
+
+		initial := val.(int)
+		cnt, promote, _ = eh.count(addr, &initial)
+	}
+	if !promote {
+		return
+	}
+
+	if _, err := repo.ClassifyContract(addr, types.AccountTypeERC20Token, types.ContractClassHeuristic); err != nil {
+		log.Errorf("can not promote contract %s to ERC20 token; %s", addr.String(), err.Error())
+		return
+	}
+	log.Noticef("contract %s promoted to ERC20 token after %d transfers", addr.String(), cnt)
+}
+
+// count registers a transfer of the given contract. The initial count is used for a contract
+// seen for the first time; a negative initial count marks the contract as decided.
+// It provides the number of transfers, if the contract should be promoted now,
+// and if the transfer has been registered, which is not the case for an unknown contract
+// without the initial count.
+func (eh *erc20Heuristic) count(addr *common.Address, initial *int) (int, bool, bool) {
+	eh.mu.Lock()
+	defer eh.mu.Unlock()
+
+	cnt, ok := eh.counts[*addr]
+	switch {
+	case ok && cnt < 0:
+		return cnt, false, true
+	case ok:
+		cnt++
+	case initial == nil:
+		return 0, false, false
+	default:
+		cnt = *initial
+	}
+
+	if cnt >= 0 && cnt < eh.threshold {
+		eh.counts[*addr] = cnt
+		return cnt, false, true
+	}
+
+	eh.counts[*addr] = -1
+	return cnt, cnt >= 0, true
+}
+
+// load provides the number of transfers of the given contract stored before,
+// so the count continues after restart, or -1 if the contract is not a candidate.
+// Nil is returned for a contract not known yet.
+func (eh *erc20Heuristic) load(addr *common.Address) (interface{}, error) {
+	sc, err := repo.Contract(addr)
+	if err != nil || sc == nil {
+		return nil, err
+	}
+	if !isErc20Candidate(sc) {
+		return -1, nil
+	}
+	return repo.Erc20TransferCount(addr, eh.threshold)
+}
+
+// isErc20Candidate checks if the contract may be promoted to an ERC20 token.
+// Only generic contracts not classified manually are promoted.
+func isErc20Candidate(sc *types.Contract) bool {
+	return sc.Type == types.AccountTypeContract && sc.Classification != types.ContractClassManual
+}
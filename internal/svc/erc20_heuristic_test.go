@@ -0,0 +1,60 @@
+package svc
+
+import (
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/onsi/gomega"
+	"testing"
+)
+
+// TestErc20Candidate tests selection of contracts the ERC20 heuristic may promote.
+func TestErc20Candidate(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	g.Expect(isErc20Candidate(&types.Contract{Type: types.AccountTypeContract})).To(gomega.BeTrue())
+	g.Expect(isErc20Candidate(&types.Contract{Type: types.AccountTypeContract, Classification: types.ContractClassDetected})).To(gomega.BeTrue())
+	g.Expect(isErc20Candidate(&types.Contract{Type: types.AccountTypeContract, Classification: types.ContractClassManual})).To(gomega.BeFalse())
+	g.Expect(isErc20Candidate(&types.Contract{Type: types.AccountTypeERC20Token})).To(gomega.BeFalse())
+	g.Expect(isErc20Candidate(&types.Contract{Type: types.AccountTypeERC721Contract})).To(gomega.BeFalse())
+
+	// disabled heuristic does not touch the repository
+	eh := &erc20Heuristic{counts: make(map[common.Address]int)}
+	addr := common.HexToAddress("0x7070")
+	eh.observe(&addr)
+	g.Expect(eh.counts).To(gomega.BeEmpty())
+}
+
+// TestErc20HeuristicCount tests the transfers counting of the ERC20 heuristic.
+func TestErc20HeuristicCount(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	eh := &erc20Heuristic{threshold: 3, counts: make(map[common.Address]int)}
+	token := common.HexToAddress("0x7070")
+	other := common.HexToAddress("0x8080")
+
+	// unknown contract needs the initial count
+	_, _, ok := eh.count(&token, nil)
+	g.Expect(ok).To(gomega.BeFalse())
+
+	initial := 1
+	cnt, promote, ok := eh.count(&token, &initial)
+	g.Expect([]interface{}{cnt, promote, ok}).To(gomega.Equal([]interface{}{1, false, true}))
+
+	// a lookup shared by a concurrent transfer continues the count
+	cnt, promote, _ = eh.count(&token, &initial)
+	g.Expect([]interface{}{cnt, promote}).To(gomega.Equal([]interface{}{2, false}))
+
+	// the threshold promotes the contract just once
+	cnt, promote, _ = eh.count(&token, nil)
+	g.Expect([]interface{}{cnt, promote}).To(gomega.Equal([]interface{}{3, true}))
+	_, promote, ok = eh.count(&token, nil)
+	g.Expect(promote).To(gomega.BeFalse())
+	g.Expect(ok).To(gomega.BeTrue())
+
+	// not a candidate
+	initial = -1
+	_, promote, ok = eh.count(&other, &initial)
+	g.Expect(promote).To(gomega.BeFalse())
+	g.Expect(ok).To(gomega.BeTrue())
+	g.Expect(eh.counts[other]).To(gomega.Equal(-1))
+}
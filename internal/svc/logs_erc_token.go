@@ -36,6 +36,10 @@ func handleErcTransaction(lr *types.LogRecord, trxType int32) {
 		amount := new(big.Int).SetBytes(lr.Data[:])
 		tokenId := big.NewInt(0)
 		storeTokenTransaction(lr, types.AccountTypeERC20Token, tokenTrxType(trxType, from, to), from, to, *amount, *tokenId, 0)
+
+		if trxType == types.TokenTrxTypeTransfer {
+			erc20Classifier.observe(&lr.Address)
+		}
 		return
 	}
 
@@ -9,7 +9,8 @@ import (
 )
 
 // handleSfcRewardClaim handles a rewards claim event.
-func handleSfcRewardClaim(lr *types.LogRecord, addr common.Address, valID *hexutil.Big, amo *big.Int, isRestake bool) {
+// The epoch range is optional, SFC3 events do not provide it.
+func handleSfcRewardClaim(lr *types.LogRecord, addr common.Address, valID *hexutil.Big, amo *big.Int, isRestake bool, epochs ...*hexutil.Uint64) {
 	// debug the event
 	log.Debugf("%s claimed %d in stake to #%d", addr.String(), amo.Uint64(), valID.ToInt().Uint64())
 
@@ -21,6 +22,8 @@ func handleSfcRewardClaim(lr *types.LogRecord, addr common.Address, valID *hexut
 		ClaimTrx:      lr.TxHash,
 		Amount:        (hexutil.Big)(*amo),
 		IsDelegated:   isRestake,
+		FromEpoch:     epochAt(epochs, 0),
+		UntilEpoch:    epochAt(epochs, 1),
 	}); err != nil {
 		log.Criticalf("can not store rewards claim; %s", err.Error())
 		return
@@ -96,7 +99,7 @@ func handleSfc1ClaimedDelegationReward(lr *types.LogRecord) {
 	amo := new(big.Int).SetBytes(lr.Data[:32])
 
 	// do the handling
-	handleSfcRewardClaim(lr, addr, valID, amo, false)
+	handleSfcRewardClaim(lr, addr, valID, amo, false, sfc1Epoch(lr.Data[32:64]), sfc1Epoch(lr.Data[64:]))
 }
 
 // handleSfc1UnstashedReward handles rewards un-stash request event.
@@ -148,5 +151,19 @@ func handleSfc1ClaimedValidatorReward(lr *types.LogRecord) {
 	}
 
 	// do the handling
-	handleSfcRewardClaim(lr, *addr, valID, amo, false)
+	handleSfcRewardClaim(lr, *addr, valID, amo, false, sfc1Epoch(lr.Data[32:64]), sfc1Epoch(lr.Data[64:]))
+}
+
+// sfc1Epoch decodes an epoch number from the SFC1 reward claim event data.
+func sfc1Epoch(data []byte) *hexutil.Uint64 {
+	ep := hexutil.Uint64(new(big.Int).SetBytes(data).Uint64())
+	return &ep
+}
+
+// epochAt returns the epoch of the given index in the list, if available.
+func epochAt(epochs []*hexutil.Uint64, ix int) *hexutil.Uint64 {
+	if ix < len(epochs) {
+		return epochs[ix]
+	}
+	return nil
 }
@@ -24,6 +24,19 @@ func isKnownUniswapPair(pair *common.Address) bool {
 	if !ok {
 		apr = false
 
+		// are we limited to a configured set of pairs?
+		if len(cfg.DeFi.Uniswap.WatchedPairs) > 0 {
+			for _, a := range cfg.DeFi.Uniswap.WatchedPairs {
+				if a == *pair {
+					apr = true
+					break
+				}
+			}
+
+			uniswapKnownPairs[*pair] = apr
+			return apr
+		}
+
 		// get the full list of all known pairs and use it to check this one
 		l, err := repo.UniswapPairs()
 		if err != nil {
@@ -85,7 +98,7 @@ func handleUniswapSwap(lr *types.LogRecord) {
 	err := repo.UniswapAdd(&types.Swap{
 		OrdIndex:    uniswapOrdinalIndex(lr),
 		BlockNumber: &lr.Block.Number,
-		Type:        types.SwapMint,
+		Type:        types.SwapTrade,
 		TimeStamp:   &lr.Block.TimeStamp,
 		Pair:        lr.Address,
 		Sender:      lr.Trx.From,
@@ -183,7 +196,7 @@ func handleUniswapBurn(lr *types.LogRecord) {
 	z := new(big.Int)
 
 	// lr what we got
-	log.Debugf("uniswap MINT on pair %s, block #%d, for %s, amount0: %s, amount1: %s",
+	log.Debugf("uniswap BURN on pair %s, block #%d, for %s, amount0: %s, amount1: %s",
 		lr.Address.String(),
 		lr.Block.Number,
 		sender.String(),
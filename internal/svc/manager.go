@@ -12,6 +12,10 @@ import (
 type ServiceManager struct {
 	wg *sync.WaitGroup
 
+	// names of the services currently running
+	mu      sync.Mutex
+	running map[string]bool
+
 	// special services with external dependency
 	ora *orchestrator
 	bld *blockDispatcher
@@ -19,6 +23,7 @@ type ServiceManager struct {
 	acd *accDispatcher
 	lgd *logDispatcher
 	bls *blkScanner
+	pem *pendingMonitor
 
 	// collection of all the managed services
 	svc []Svc
@@ -36,8 +41,9 @@ func newServiceManager() *ServiceManager {
 
 	// create new orchestrator
 	sm := ServiceManager{
-		wg:  new(sync.WaitGroup),
-		svc: make([]Svc, 0, 15),
+		wg:      new(sync.WaitGroup),
+		svc:     make([]Svc, 0, 15),
+		running: make(map[string]bool),
 	}
 
 	// init the orchestration
@@ -89,8 +95,16 @@ func (mgr *ServiceManager) SetTrxChannel(ch chan *types.Transaction) {
 	mgr.trd.onTransaction = ch
 }
 
+// SetPendingTrxChannel registers a channel for notifying new pending transaction events.
+func (mgr *ServiceManager) SetPendingTrxChannel(ch chan *types.Transaction) {
+	mgr.pem.onPending = ch
+}
+
 // Init the svc manager.
 func (mgr *ServiceManager) init() {
+	// make the denylist monitor first, the dispatchers need the list loaded
+	mgr.svc = append(mgr.svc, &denylistMonitor{service: service{mgr: mgr}})
+
 	// make the block dispatcher
 	mgr.bld = &blockDispatcher{service: service{mgr: mgr}}
 	mgr.svc = append(mgr.svc, mgr.bld)
@@ -108,7 +122,12 @@ func (mgr *ServiceManager) init() {
 	mgr.svc = append(mgr.svc, mgr.lgd)
 
 	// make block scanner
-	mgr.bls = &blkScanner{service: service{mgr: mgr}, cfg: cfg.RepoCommand}
+	mgr.bls = &blkScanner{
+		service: service{mgr: mgr},
+		cfg:     cfg.RepoCommand,
+		workers: cfg.Repository.ScanWorkers,
+		batch:   cfg.Repository.ScanBatch,
+	}
 	mgr.svc = append(mgr.svc, mgr.bls)
 
 	// make epoch scanner
@@ -120,6 +139,33 @@ func (mgr *ServiceManager) init() {
 	// make transaction flow monitor
 	mgr.svc = append(mgr.svc, &trxFlowMonitor{service: service{mgr: mgr}})
 
+	// make rich list monitor
+	mgr.svc = append(mgr.svc, &richListMonitor{service: service{mgr: mgr}})
+
+	// make remote token list monitor, if the tokens are not disabled
+	if cfg.Features.Tokens {
+		mgr.svc = append(mgr.svc, &tokenListMonitor{service: service{mgr: mgr}})
+	}
+
+	// make token price sampler, if any price pairs are configured
+	if cfg.Features.DeFi && len(cfg.DeFi.Uniswap.PricePairs) > 0 && cfg.DeFi.Uniswap.PriceSampling > 0 {
+		mgr.svc = append(mgr.svc, &tokenPriceSampler{service: service{mgr: mgr}})
+	}
+
+	// make webhook dispatcher, if the webhooks are enabled
+	if cfg.Webhooks.Enabled {
+		mgr.svc = append(mgr.svc, &webhookDispatcher{service: service{mgr: mgr}})
+	}
+
+	// make retention sweeper, if any data are to be pruned
+	if cfg.Retention.Enabled() {
+		mgr.svc = append(mgr.svc, &retentionSweeper{service: service{mgr: mgr}})
+	}
+
+	// make pending transactions monitor
+	mgr.pem = &pendingMonitor{service: service{mgr: mgr}}
+	mgr.svc = append(mgr.svc, mgr.pem)
+
 	// add orchestrator as the last service, so it can safely operate on all the other
 	mgr.ora = &orchestrator{service: service{mgr: mgr}}
 	mgr.svc = append(mgr.svc, mgr.ora)
@@ -129,12 +175,21 @@ func (mgr *ServiceManager) init() {
 // has been started and is functioning.
 func (mgr *ServiceManager) started(svc Svc) {
 	mgr.wg.Add(1)
+
+	mgr.mu.Lock()
+	mgr.running[svc.name()] = true
+	mgr.mu.Unlock()
+
 	log.Noticef("%s is running", svc.name())
 }
 
 // finished signals to the manager that the calling service
 // has been terminated and is no longer running.
 func (mgr *ServiceManager) finished(svc Svc) {
+	mgr.mu.Lock()
+	mgr.running[svc.name()] = false
+	mgr.mu.Unlock()
+
 	mgr.wg.Done()
 	log.Noticef("%s terminated", svc.name())
 }
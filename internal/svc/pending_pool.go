@@ -0,0 +1,93 @@
+// Package svc implements blockchain data processing services.
+package svc
+
+import (
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"time"
+)
+
+// pendingPrunePeriod represents the period of removing pending transactions
+// not mined within the configured timeout.
+const pendingPrunePeriod = 30 * time.Second
+
+// pendingMonitor represents a service collecting pending transactions
+// observed on the connected node into the pending pool.
+type pendingMonitor struct {
+	service
+	onPending chan *types.Transaction
+	prune     *time.Ticker
+}
+
+// name returns a human-readable name of the service used by the manager.
+func (pm *pendingMonitor) name() string {
+	return "pending transactions monitor"
+}
+
+// run starts the pending transactions monitor, if the pending pool is enabled.
+func (pm *pendingMonitor) run() {
+	// make sure we are orchestrated
+	if pm.mgr == nil {
+		panic(fmt.Errorf("no svc manager set on %s", pm.name()))
+	}
+
+	// the node may not expose the pending pool
+	if !repo.IsPendingPoolEnabled() {
+		log.Noticef("pending pool not enabled, %s not started", pm.name())
+		return
+	}
+
+	pm.prune = time.NewTicker(pendingPrunePeriod)
+	pm.mgr.started(pm)
+	go pm.execute()
+}
+
+// close terminates the pending transactions monitor.
+func (pm *pendingMonitor) close() {
+	if pm.prune == nil {
+		return
+	}
+
+	pm.prune.Stop()
+	if pm.sigStop != nil {
+		pm.sigStop <- true
+	}
+}
+
+// execute collects pending transactions and removes the stale ones from the pool.
+func (pm *pendingMonitor) execute() {
+	defer pm.mgr.finished(pm)
+
+	in := repo.ObservedPendingProxy()
+	for {
+		select {
+		case <-pm.sigStop:
+			return
+		case <-pm.prune.C:
+			if n := repo.PrunePendingTransactions(); n > 0 {
+				log.Debugf("%d stale pending transactions removed", n)
+			}
+		case hash, ok := <-in:
+			if !ok {
+				log.Noticef("pending channel closed, terminating %s", pm.name())
+				return
+			}
+			pm.process(hash)
+		}
+	}
+}
+
+// process adds the pending transaction to the pool and broadcasts it.
+func (pm *pendingMonitor) process(hash common.Hash) {
+	trx, err := repo.AddPendingTransaction(&hash)
+	if err != nil || trx == nil {
+		return
+	}
+
+	// broadcast new pending transaction; if it can not be broadcast quickly, skip
+	select {
+	case pm.onPending <- trx:
+	case <-time.After(200 * time.Millisecond):
+	}
+}
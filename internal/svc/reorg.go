@@ -0,0 +1,170 @@
+// Package svc implements blockchain data processing services.
+package svc
+
+import (
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"sort"
+	"time"
+)
+
+// rgVerifyAge is the max age of a dispatched block to be verified against the canonical chain
+// once it reaches the confirmation depth. Older blocks come from the historical scan
+// and are considered final already.
+const rgVerifyAge = 5 * time.Minute
+
+// canonicalLoader represents a function loading the block of the given number
+// from the canonical chain.
+type canonicalLoader func(num uint64) (*types.Block, error)
+
+// chainReorg represents a detected chain reorganization.
+type chainReorg struct {
+	// orphaned is the list of previously dispatched blocks no longer on the chain.
+	orphaned []*types.Block
+
+	// replaced is the list of canonical blocks replacing the orphaned blocks
+	// below the block being dispatched.
+	replaced []*types.Block
+}
+
+// reorgGuard keeps track of recently dispatched blocks within the confirmation depth
+// to detect chain reorganizations affecting them.
+type reorgGuard struct {
+	depth  uint64
+	recent map[uint64]*types.Block
+}
+
+// newReorgGuard creates a new reorganization guard for the given confirmation depth.
+func newReorgGuard(depth uint64) *reorgGuard {
+	return &reorgGuard{depth: depth, recent: make(map[uint64]*types.Block, depth+1)}
+}
+
+// check verifies the given block against the recently dispatched blocks and registers it.
+// The block parent must match the block dispatched before and, if verify is set, the block
+// leaving the confirmation window is compared with the canonical chain. A detected
+// reorganization is provided with the orphaned blocks and their canonical replacements.
+func (rg *reorgGuard) check(blk *types.Block, load canonicalLoader, verify bool) (*chainReorg, error) {
+	if rg.depth == 0 {
+		return nil, nil
+	}
+
+	num := uint64(blk.Number)
+	suspect := false
+	if prev, ok := rg.recent[num-1]; ok && prev.Hash != blk.ParentHash {
+		suspect = true
+	}
+	if known, ok := rg.recent[num]; ok && known.Hash != blk.Hash {
+		suspect = true
+	}
+	if fin, ok := rg.recent[num-rg.depth]; !suspect && verify && ok && num > rg.depth {
+		cb, err := load(num - rg.depth)
+		if err != nil {
+			return nil, err
+		}
+		suspect = cb.Hash != fin.Hash
+	}
+
+	var ro *chainReorg
+	if suspect {
+		var err error
+		if ro, err = rg.fork(blk, load); err != nil {
+			return nil, err
+		}
+	}
+
+	rg.register(blk)
+	return ro, nil
+}
+
+// fork walks the recent blocks down from the top until it finds the block still
+// on the canonical chain and collects the orphaned blocks on the way.
+func (rg *reorgGuard) fork(blk *types.Block, load canonicalLoader) (*chainReorg, error) {
+	num := uint64(blk.Number)
+	nums := make([]uint64, 0, len(rg.recent))
+	for n := range rg.recent {
+		nums = append(nums, n)
+	}
+	sort.Slice(nums, func(i, j int) bool { return nums[i] > nums[j] })
+
+	ro := chainReorg{orphaned: make([]*types.Block, 0), replaced: make([]*types.Block, 0)}
+	for _, n := range nums {
+		known := rg.recent[n]
+
+		// blocks at and above the dispatched one are replaced by the regular flow
+		if n >= num {
+			if n > num || known.Hash != blk.Hash {
+				ro.orphaned = append(ro.orphaned, known)
+			}
+			continue
+		}
+
+		cb, err := load(n)
+		if err != nil {
+			return nil, err
+		}
+		if cb.Hash == known.Hash {
+			break
+		}
+		ro.orphaned = append(ro.orphaned, known)
+		ro.replaced = append(ro.replaced, cb)
+	}
+
+	if len(ro.orphaned) == 0 {
+		return nil, nil
+	}
+
+	// the lists are collected top down; we need them in the chain order
+	reverseBlocks(ro.orphaned)
+	reverseBlocks(ro.replaced)
+
+	for _, ob := range ro.orphaned {
+		delete(rg.recent, uint64(ob.Number))
+	}
+	for _, cb := range ro.replaced {
+		rg.recent[uint64(cb.Number)] = cb
+	}
+	return &ro, nil
+}
+
+// register adds the block to the recent blocks and drops blocks beyond the confirmation depth.
+func (rg *reorgGuard) register(blk *types.Block) {
+	num := uint64(blk.Number)
+	rg.recent[num] = blk
+
+	for n := range rg.recent {
+		if n+rg.depth < num {
+			delete(rg.recent, n)
+		}
+	}
+}
+
+// dropped provides the list of transactions of the orphaned blocks not included
+// in the replacing blocks, nor in the given block.
+func (ro *chainReorg) dropped(blk *types.Block) []*common.Hash {
+	kept := make(map[common.Hash]bool)
+	for _, th := range blk.Txs {
+		kept[*th] = true
+	}
+	for _, cb := range ro.replaced {
+		for _, th := range cb.Txs {
+			kept[*th] = true
+		}
+	}
+
+	list := make([]*common.Hash, 0)
+	for _, ob := range ro.orphaned {
+		for _, th := range ob.Txs {
+			if !kept[*th] {
+				list = append(list, th)
+			}
+		}
+	}
+	return list
+}
+
+// reverseBlocks reverses the order of the given list of blocks in place.
+func reverseBlocks(list []*types.Block) {
+	for i, j := 0, len(list)-1; i < j; i, j = i+1, j-1 {
+		list[i], list[j] = list[j], list[i]
+	}
+}
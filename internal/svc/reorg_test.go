@@ -0,0 +1,120 @@
+package svc
+
+import (
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/onsi/gomega"
+	"testing"
+)
+
+// testChain builds a chain of blocks up to the given number; blocks from the fork number
+// on belong to the given branch. Each block carries a single transaction unique to its branch.
+func testChain(top uint64, fork uint64, branch string) map[uint64]*types.Block {
+	chain := make(map[uint64]*types.Block, top)
+	parent := common.Hash{}
+	for n := uint64(1); n <= top; n++ {
+		br := "main"
+		if n >= fork {
+			br = branch
+		}
+
+		trx := common.BytesToHash([]byte(fmt.Sprintf("trx-%s-%d", br, n)))
+		blk := &types.Block{
+			Number:     hexutil.Uint64(n),
+			Hash:       common.BytesToHash([]byte(fmt.Sprintf("blk-%s-%d", br, n))),
+			ParentHash: parent,
+			Txs:        []*common.Hash{&trx},
+		}
+		chain[n] = blk
+		parent = blk.Hash
+	}
+	return chain
+}
+
+// TestReorgGuard tests a short chain reorganization is detected with the orphaned blocks.
+func TestReorgGuard(t *testing.T) {
+	tests := []struct {
+		name     string
+		depth    uint64
+		fork     uint64
+		orphaned []uint64
+		dropped  int
+	}{
+		{name: "no reorg", depth: 5, fork: 100},
+		{name: "disabled", depth: 0, fork: 7},
+		{name: "short reorg", depth: 5, fork: 7, orphaned: []uint64{7, 8}, dropped: 2},
+		{name: "tip reorg", depth: 5, fork: 8, orphaned: []uint64{8}, dropped: 1},
+		{name: "beyond depth", depth: 1, fork: 6, orphaned: []uint64{7, 8}, dropped: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+
+			// dispatch the main chain up to #8, the node switches to the fork afterwards
+			main := testChain(8, 100, "main")
+			fork := testChain(9, tt.fork, "fork")
+			load := func(num uint64) (*types.Block, error) {
+				if blk, ok := fork[num]; ok {
+					return blk, nil
+				}
+				return nil, fmt.Errorf("block #%d not found", num)
+			}
+
+			rg := newReorgGuard(tt.depth)
+			for n := uint64(1); n <= 8; n++ {
+				ro, err := rg.check(main[n], load, false)
+				g.Expect(err).To(gomega.BeNil())
+				g.Expect(ro).To(gomega.BeNil())
+			}
+
+			ro, err := rg.check(fork[9], load, false)
+			g.Expect(err).To(gomega.BeNil())
+			if tt.orphaned == nil {
+				g.Expect(ro).To(gomega.BeNil())
+				return
+			}
+
+			g.Expect(ro).NotTo(gomega.BeNil())
+			g.Expect(ro.orphaned).To(gomega.HaveLen(len(tt.orphaned)))
+			g.Expect(ro.replaced).To(gomega.HaveLen(len(tt.orphaned)))
+			for i, n := range tt.orphaned {
+				g.Expect(ro.orphaned[i].Hash).To(gomega.Equal(main[n].Hash))
+				g.Expect(ro.replaced[i].Hash).To(gomega.Equal(fork[n].Hash))
+			}
+			g.Expect(ro.dropped(fork[9])).To(gomega.HaveLen(tt.dropped))
+
+			// the guard follows the new branch
+			next := testChain(10, tt.fork, "fork")
+			ro, err = rg.check(next[10], load, false)
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(ro).To(gomega.BeNil())
+		})
+	}
+}
+
+// TestReorgGuardVerify tests the block leaving the confirmation window is verified against the chain.
+func TestReorgGuardVerify(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	main := testChain(9, 100, "main")
+	fork := testChain(9, 4, "fork")
+	load := func(num uint64) (*types.Block, error) {
+		return fork[num], nil
+	}
+
+	// the node switched branches silently; only the verification can find out
+	rg := newReorgGuard(5)
+	for n := uint64(1); n < 9; n++ {
+		_, err := rg.check(main[n], load, false)
+		g.Expect(err).To(gomega.BeNil())
+	}
+
+	ro, err := rg.check(main[9], load, true)
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(ro).NotTo(gomega.BeNil())
+	g.Expect(ro.orphaned[0].Number).To(gomega.Equal(hexutil.Uint64(4)))
+	g.Expect(ro.replaced).To(gomega.HaveLen(5))
+}
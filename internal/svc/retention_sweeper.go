@@ -0,0 +1,66 @@
+// Package svc implements blockchain data processing services.
+package svc
+
+import (
+	"fmt"
+	"time"
+)
+
+// retentionSweeper represents a service periodically pruning the data
+// older than their configured retention windows from the persistent storage.
+type retentionSweeper struct {
+	service
+	ticker *time.Ticker
+}
+
+// name returns a human-readable name of the service used by the manager.
+func (rs *retentionSweeper) name() string {
+	return "retention sweeper"
+}
+
+// run starts the retention sweeper.
+func (rs *retentionSweeper) run() {
+	// make sure we are orchestrated
+	if rs.mgr == nil {
+		panic(fmt.Errorf("no svc manager set on %s", rs.name()))
+	}
+
+	// start go routine for processing
+	rs.mgr.started(rs)
+	go rs.execute()
+}
+
+// close terminates the retention sweeper.
+func (rs *retentionSweeper) close() {
+	if rs.ticker != nil {
+		rs.ticker.Stop()
+	}
+	if rs.sigStop != nil {
+		rs.sigStop <- true
+	}
+}
+
+// execute performs regular ticker based sweeps of the aged data.
+func (rs *retentionSweeper) execute() {
+	defer func() {
+		close(rs.sigStop)
+		rs.mgr.finished(rs)
+	}()
+
+	// nothing to do without the sweep interval
+	if cfg.Retention.Sweep <= 0 {
+		<-rs.sigStop
+		return
+	}
+
+	rs.ticker = time.NewTicker(cfg.Retention.Sweep)
+	for {
+		select {
+		case <-rs.sigStop:
+			return
+		case <-rs.ticker.C:
+			// the sweep runs in line, so the sweeps never overlap
+			repo.PruneStorage()
+		}
+	}
+}
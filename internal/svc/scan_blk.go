@@ -5,7 +5,7 @@ import (
 	"fantom-api-graphql/internal/config"
 	"fantom-api-graphql/internal/types"
 	"fmt"
-	"github.com/ethereum/go-ethereum/common/hexutil"
+	"sync"
 	"time"
 )
 
@@ -33,6 +33,8 @@ const blsReScanHysteresis = 100
 type blkScanner struct {
 	service
 	cfg            config.RepoCmd
+	workers        int
+	batch          int
 	outBlock       chan *types.Block
 	outStateSwitch chan bool
 	inDispatched   chan uint64
@@ -43,6 +45,10 @@ type blkScanner struct {
 	next           uint64
 	to             uint64
 	done           uint64
+
+	// snapshot of the scanner progress for the diagnostics
+	mu       sync.Mutex
+	snapshot types.ScannerStatus
 }
 
 // name returns the name of the service used by orchestrator.
@@ -56,6 +62,14 @@ func (bls *blkScanner) init() {
 	bls.sigStop = make(chan bool, 1)
 	bls.outStateSwitch = make(chan bool, 1)
 	bls.outBlock = make(chan *types.Block, blsBlockBufferCapacity)
+
+	// make sure we scan at least one block at a time
+	if bls.workers <= 0 {
+		bls.workers = 1
+	}
+	if bls.batch <= 0 {
+		bls.batch = 1
+	}
 }
 
 // run starts the block dispatcher
@@ -70,7 +84,7 @@ func (bls *blkScanner) run() {
 	// get the scanner range
 	start, err := bls.boundaries()
 	if err != nil {
-		log.Errorf("scanner can not proceed; %s", err.Error())
+		logError(bls, "scanner can not proceed; %s", err.Error())
 		return
 	}
 
@@ -141,16 +155,31 @@ func (bls *blkScanner) execute() {
 		case <-bls.scanTick.C:
 			bls.shift()
 		}
+		bls.publish()
 	}
 }
 
+// publish updates the snapshot of the scanner progress.
+func (bls *blkScanner) publish() {
+	bls.mu.Lock()
+	bls.snapshot = types.ScannerStatus{Next: bls.next, Target: bls.to, Dispatched: bls.done, IsIdle: bls.onIdle}
+	bls.mu.Unlock()
+}
+
+// status provides the last published snapshot of the scanner progress.
+func (bls *blkScanner) status() types.ScannerStatus {
+	bls.mu.Lock()
+	defer bls.mu.Unlock()
+	return bls.snapshot
+}
+
 // observe updates the scanner final block and logs the progress.
 // It returns expected idle state to be used to transition if needed.
 func (bls *blkScanner) observe() bool {
 	// try to get the block height
 	bh, err := repo.BlockHeight()
 	if err != nil {
-		log.Errorf("can not get current block height; %s", err.Error())
+		logError(bls, "can not get current block height; %s", err.Error())
 		return false
 	}
 
@@ -215,19 +244,26 @@ func (bls *blkScanner) shift() {
 		return
 	}
 
-	// pull the current block
-	block, err := repo.BlockByNumber((*hexutil.Uint64)(&bls.next))
-	if err != nil {
-		log.Errorf("block #%d not available; %s", bls.next, err.Error())
+	// pull the next range of blocks; never ask for blocks beyond the known head
+	count := bls.workers * bls.batch
+	if left := bls.to - bls.next + 1; uint64(count) > left {
+		count = int(left)
+	}
+	blocks := loadBlockRange(bls.next, count, bls.workers, bls.batch, repo.BlocksByNumber)
+	if len(blocks) == 0 {
+		logError(bls, "block #%d not available", bls.next)
 		return
 	}
 
-	// push the block for processing and advance to the next expected block
+	// push the blocks for processing in order and advance to the next expected block
 	// observe possible stop signal during a wait for the block queue slot
-	select {
-	case bls.outBlock <- block:
-		bls.next++
-	case <-bls.sigStop:
-		bls.sigStop <- true
+	for _, block := range blocks {
+		select {
+		case bls.outBlock <- block:
+			bls.next++
+		case <-bls.sigStop:
+			bls.sigStop <- true
+			return
+		}
 	}
 }
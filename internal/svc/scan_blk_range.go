@@ -0,0 +1,78 @@
+// Package svc implements blockchain data processing services.
+package svc
+
+import (
+	"fantom-api-graphql/internal/types"
+	"sync"
+)
+
+// blockRangeLoader represents a function loading a range of blocks starting
+// at the given number. The resulting list ends before the first block not available.
+type blockRangeLoader func(from uint64, count int) ([]*types.Block, error)
+
+// loadBlockRange loads the given number of blocks starting at the given block number.
+// The range is split into batches loaded concurrently by the given number of workers.
+// Only the continuous sequence of blocks from the start of the range is returned,
+// so a batch failing to load never leaves a gap in the list.
+func loadBlockRange(from uint64, count int, workers int, batch int, load blockRangeLoader) []*types.Block {
+	if count <= 0 {
+		return nil
+	}
+	if batch <= 0 {
+		batch = 1
+	}
+	if workers <= 0 {
+		workers = 1
+	}
+
+	// split the range into batches
+	batches := (count + batch - 1) / batch
+	if workers > batches {
+		workers = batches
+	}
+	res := make([][]*types.Block, batches)
+
+	// load the batches concurrently; each worker picks the next batch in line
+	var wg sync.WaitGroup
+	next := make(chan int, batches)
+	for i := 0; i < batches; i++ {
+		next <- i
+	}
+	close(next)
+
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				size := batch
+				if (i+1)*batch > count {
+					size = count - i*batch
+				}
+
+				list, err := load(from+uint64(i*batch), size)
+				if err != nil {
+					log.Errorf("blocks #%d to #%d not available; %s", from+uint64(i*batch), from+uint64(i*batch+size-1), err.Error())
+					continue
+				}
+				res[i] = list
+			}
+		}()
+	}
+	wg.Wait()
+
+	// collect the continuous sequence of blocks up to the first gap
+	out := make([]*types.Block, 0, count)
+	for i := range res {
+		out = append(out, res[i]...)
+
+		size := batch
+		if (i+1)*batch > count {
+			size = count - i*batch
+		}
+		if len(res[i]) < size {
+			break
+		}
+	}
+	return out
+}
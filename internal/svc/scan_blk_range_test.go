@@ -0,0 +1,93 @@
+package svc
+
+import (
+	"fantom-api-graphql/internal/config"
+	"fantom-api-graphql/internal/logger"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/onsi/gomega"
+	"testing"
+	"time"
+)
+
+// blockScanTestLatency simulates the round trip of a single RPC call to the node.
+const blockScanTestLatency = 500 * time.Microsecond
+
+func init() {
+	log = logger.New(&config.Config{Log: config.Log{Level: "CRITICAL", Format: "%{message}"}})
+}
+
+// testBlockLoader makes a block range loader failing on the given block number, if any.
+func testBlockLoader(fail uint64) blockRangeLoader {
+	return func(from uint64, count int) ([]*types.Block, error) {
+		time.Sleep(blockScanTestLatency)
+
+		list := make([]*types.Block, 0, count)
+		for i := 0; i < count; i++ {
+			num := from + uint64(i)
+			if num == fail {
+				if i == 0 {
+					return nil, fmt.Errorf("block #%d not available", num)
+				}
+				break
+			}
+			list = append(list, &types.Block{Number: hexutil.Uint64(num)})
+		}
+		return list, nil
+	}
+}
+
+// TestLoadBlockRange tests blocks are loaded in order and the range ends before the first gap.
+func TestLoadBlockRange(t *testing.T) {
+	tests := []struct {
+		name    string
+		count   int
+		workers int
+		batch   int
+		fail    uint64
+		expect  int
+	}{
+		{"sequential", 10, 1, 1, 0, 10},
+		{"concurrent batches", 100, 4, 25, 0, 100},
+		{"partial last batch", 30, 4, 8, 0, 30},
+		{"failed batch start", 100, 4, 10, 150, 50},
+		{"failed block inside batch", 100, 4, 10, 155, 55},
+		{"failed first block", 100, 4, 10, 100, 0},
+		{"more workers than batches", 5, 8, 10, 0, 5},
+		{"invalid settings", 3, 0, 0, 0, 3},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+
+			list := loadBlockRange(100, tc.count, tc.workers, tc.batch, testBlockLoader(tc.fail))
+			g.Expect(list).To(gomega.HaveLen(tc.expect))
+			for i, blk := range list {
+				g.Expect(uint64(blk.Number)).To(gomega.Equal(uint64(100 + i)))
+			}
+		})
+	}
+}
+
+// benchmarkBlockScan measures loading of a range of blocks with the given scan settings.
+func benchmarkBlockScan(b *testing.B, workers int, batch int) {
+	load := testBlockLoader(0)
+	for n := 0; n < b.N; n++ {
+		var next uint64 = 1
+		for next <= 1000 {
+			next += uint64(len(loadBlockRange(next, workers*batch, workers, batch, load)))
+		}
+	}
+}
+
+// BenchmarkBlockScanSequential measures the block scan loading one block at a time.
+func BenchmarkBlockScanSequential(b *testing.B) {
+	benchmarkBlockScan(b, 1, 1)
+}
+
+// BenchmarkBlockScanConcurrent measures the block scan with concurrent batched loading.
+func BenchmarkBlockScanConcurrent(b *testing.B) {
+	benchmarkBlockScan(b, 4, 25)
+}
@@ -0,0 +1,96 @@
+// Package svc implements blockchain data processing services.
+package svc
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// richListMonitor represents a service periodically refreshing
+// the ranking of accounts by their native balance.
+type richListMonitor struct {
+	service
+
+	// ctx interrupts the running update on close
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// name returns a human-readable name of the service used by the manager.
+func (rlm *richListMonitor) name() string {
+	return "rich list monitor"
+}
+
+// init prepares the rich list monitor to be started.
+func (rlm *richListMonitor) init() {
+	rlm.service.init()
+	rlm.ctx, rlm.cancel = context.WithCancel(context.Background())
+}
+
+// run starts the rich list monitoring.
+func (rlm *richListMonitor) run() {
+	// make sure we are orchestrated
+	if rlm.mgr == nil {
+		panic(fmt.Errorf("no svc manager set on %s", rlm.name()))
+	}
+
+	// start go routine for processing
+	rlm.mgr.started(rlm)
+	go rlm.execute()
+}
+
+// close terminates the rich list monitor.
+func (rlm *richListMonitor) close() {
+	if rlm.cancel != nil {
+		rlm.cancel()
+	}
+	if rlm.sigStop != nil {
+		rlm.sigStop <- true
+	}
+}
+
+// execute performs regular ticker based updates of the rich list.
+func (rlm *richListMonitor) execute() {
+	defer func() {
+		close(rlm.sigStop)
+		rlm.mgr.finished(rlm)
+	}()
+
+	// the rich list is disabled by a non-positive refresh interval
+	if cfg.Repository.RichListRefresh <= 0 {
+		log.Noticef("rich list updates disabled")
+		<-rlm.sigStop
+		return
+	}
+
+	ticker := time.NewTicker(cfg.Repository.RichListRefresh)
+	defer ticker.Stop()
+
+	// the initial update walks all the known accounts, the following ones
+	// only refresh accounts active since the previous update;
+	// updates run in line, so they never overlap
+	since := rlm.update(0)
+	for {
+		select {
+		case <-rlm.sigStop:
+			return
+		case <-ticker.C:
+			since = rlm.update(since)
+		}
+	}
+}
+
+// update refreshes the rich list with accounts active since the given time stamp
+// and returns the time stamp the next update continues from.
+func (rlm *richListMonitor) update(since uint64) uint64 {
+	next, err := repo.RichListUpdate(rlm.ctx, since)
+	if err != nil {
+		// the update interrupted by close is not an issue
+		if rlm.ctx.Err() == nil {
+			log.Errorf("rich list update failed; %s", err.Error())
+		}
+		return since
+	}
+	return next
+}
@@ -72,6 +72,7 @@ func (tfm *trxFlowMonitor) execute() {
 			return
 		case <-tfm.flowTicker.C:
 			repo.TrxFlowUpdate()
+			repo.TrxStatsUpdate()
 		case <-tfm.countTicker.C:
 			go tfm.updateCount()
 		}
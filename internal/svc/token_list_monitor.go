@@ -0,0 +1,75 @@
+// Package svc implements blockchain data processing services.
+package svc
+
+import (
+	"fmt"
+	"time"
+)
+
+// tokenListMonitor represents a service periodically reloading the remote token list
+// used to complete details and logos of ERC20 tokens.
+type tokenListMonitor struct {
+	service
+	ticker *time.Ticker
+}
+
+// name returns a human-readable name of the service used by the manager.
+func (tlm *tokenListMonitor) name() string {
+	return "token list monitor"
+}
+
+// run starts the token list monitoring.
+func (tlm *tokenListMonitor) run() {
+	// make sure we are orchestrated
+	if tlm.mgr == nil {
+		panic(fmt.Errorf("no svc manager set on %s", tlm.name()))
+	}
+
+	// start go routine for processing
+	tlm.mgr.started(tlm)
+	go tlm.execute()
+}
+
+// close terminates the token list monitor.
+func (tlm *tokenListMonitor) close() {
+	if tlm.ticker != nil {
+		tlm.ticker.Stop()
+	}
+	if tlm.sigStop != nil {
+		tlm.sigStop <- true
+	}
+}
+
+// execute performs regular ticker based reloads of the token list.
+func (tlm *tokenListMonitor) execute() {
+	defer func() {
+		close(tlm.sigStop)
+		tlm.mgr.finished(tlm)
+	}()
+
+	// nothing to load without the list address
+	if !cfg.TokenList.Enabled || cfg.TokenList.Url == "" || cfg.TokenList.Refresh <= 0 {
+		<-tlm.sigStop
+		return
+	}
+
+	// do initial load
+	go tlm.reload()
+
+	tlm.ticker = time.NewTicker(cfg.TokenList.Refresh)
+	for {
+		select {
+		case <-tlm.sigStop:
+			return
+		case <-tlm.ticker.C:
+			go tlm.reload()
+		}
+	}
+}
+
+// reload loads the remote token list again.
+func (tlm *tokenListMonitor) reload() {
+	if err := repo.RefreshTokenList(); err != nil {
+		log.Errorf("can not load token list %s; %s", cfg.TokenList.Url, err.Error())
+	}
+}
@@ -0,0 +1,62 @@
+// Package svc implements blockchain data processing services.
+package svc
+
+import (
+	"fmt"
+	"time"
+)
+
+// tokenPriceSampler represents a service periodically sampling the prices of the configured
+// Uniswap price pairs to build the token price history.
+type tokenPriceSampler struct {
+	service
+	ticker *time.Ticker
+}
+
+// name returns a human-readable name of the service used by the manager.
+func (tps *tokenPriceSampler) name() string {
+	return "token price sampler"
+}
+
+// run starts the token price sampling.
+func (tps *tokenPriceSampler) run() {
+	// make sure we are orchestrated
+	if tps.mgr == nil {
+		panic(fmt.Errorf("no svc manager set on %s", tps.name()))
+	}
+
+	// start go routine for processing
+	tps.mgr.started(tps)
+	go tps.execute()
+}
+
+// close terminates the token price sampler.
+func (tps *tokenPriceSampler) close() {
+	if tps.ticker != nil {
+		tps.ticker.Stop()
+	}
+	if tps.sigStop != nil {
+		tps.sigStop <- true
+	}
+}
+
+// execute performs regular ticker based sampling of the token prices.
+func (tps *tokenPriceSampler) execute() {
+	defer func() {
+		close(tps.sigStop)
+		tps.mgr.finished(tps)
+	}()
+
+	// take the first sample right away
+	repo.SampleTokenPrices()
+
+	tps.ticker = time.NewTicker(cfg.DeFi.Uniswap.PriceSampling)
+	for {
+		select {
+		case <-tps.sigStop:
+			return
+		case <-tps.ticker.C:
+			repo.SampleTokenPrices()
+		}
+	}
+}
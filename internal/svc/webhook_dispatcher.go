@@ -0,0 +1,63 @@
+// Package svc implements blockchain data processing services.
+package svc
+
+import (
+	"fmt"
+	"time"
+)
+
+// webhookDeliveryTickInterval represents the interval of webhook delivery passes.
+const webhookDeliveryTickInterval = 5 * time.Second
+
+// webhookDispatcher represents a service delivering pending notifications
+// of the registered webhooks.
+type webhookDispatcher struct {
+	service
+	ticker *time.Ticker
+}
+
+// name returns a human-readable name of the service used by the manager.
+func (whd *webhookDispatcher) name() string {
+	return "webhook dispatcher"
+}
+
+// run starts the webhook dispatcher.
+func (whd *webhookDispatcher) run() {
+	// make sure we are orchestrated
+	if whd.mgr == nil {
+		panic(fmt.Errorf("no svc manager set on %s", whd.name()))
+	}
+
+	// start go routine for processing
+	whd.mgr.started(whd)
+	go whd.execute()
+}
+
+// close terminates the webhook dispatcher.
+func (whd *webhookDispatcher) close() {
+	if whd.ticker != nil {
+		whd.ticker.Stop()
+	}
+	if whd.sigStop != nil {
+		whd.sigStop <- true
+	}
+}
+
+// execute performs regular ticker based delivery passes of the pending webhook notifications.
+func (whd *webhookDispatcher) execute() {
+	defer func() {
+		close(whd.sigStop)
+		whd.mgr.finished(whd)
+	}()
+
+	whd.ticker = time.NewTicker(webhookDeliveryTickInterval)
+	for {
+		select {
+		case <-whd.sigStop:
+			return
+		case <-whd.ticker.C:
+			// a slow pass is skipped by the repository if still running
+			go repo.DeliverWebhooks()
+		}
+	}
+}
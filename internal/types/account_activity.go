@@ -0,0 +1,63 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"time"
+)
+
+// ContractActivity represents the calls of an account to a single contract
+// broken down by the called functions.
+type ContractActivity struct {
+	// Contract is the address of the called contract.
+	Contract common.Address
+
+	// Calls is the total number of calls of the account to the contract.
+	Calls uint64
+
+	// LastCall is the time of the most recent call to the contract.
+	LastCall time.Time
+
+	// Functions is the breakdown of the calls by the called function,
+	// sorted by the number of calls from the highest.
+	Functions []*FunctionCallCount
+}
+
+// FunctionCallCount represents the number of calls of a single contract function.
+type FunctionCallCount struct {
+	// Selector is the 4 bytes selector of the called function.
+	Selector hexutil.Bytes
+
+	// Name is the name of the function; empty if not known from the contract ABI.
+	Name string
+
+	// Signature is the canonical signature of the function; empty if not known from the contract ABI.
+	Signature string
+
+	// Calls is the number of calls of the function.
+	Calls uint64
+}
+
+// AccountActivity represents a list of contracts called by an account
+// sorted by the number of calls from the highest.
+type AccountActivity struct {
+	// Collection keeps the actual list of contracts.
+	Collection []*ContractActivity
+
+	// Total indicates total number of contracts called by the account.
+	Total uint64
+
+	// IsStart indicates there are no contracts available above the list currently.
+	IsStart bool
+
+	// IsEnd indicates there are no contracts available below the list currently.
+	IsEnd bool
+}
+
+// Reverse reverses the order of contracts in the list.
+func (aa *AccountActivity) Reverse() {
+	for i, j := 0, len(aa.Collection)-1; i < j; i, j = i+1, j-1 {
+		aa.Collection[i], aa.Collection[j] = aa.Collection[j], aa.Collection[i]
+	}
+}
@@ -0,0 +1,44 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"encoding/json"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// AccountStats represents the transaction activity summary of an account.
+type AccountStats struct {
+	// Address is the address of the account.
+	Address common.Address `json:"address"`
+
+	// TrxCount is the total number of transactions sent or received by the account.
+	TrxCount hexutil.Uint64 `json:"count"`
+
+	// FirstBlock is the block of the first transaction of the account, if any.
+	FirstBlock *hexutil.Uint64 `json:"fb,omitempty"`
+
+	// FirstSeen is the unix time stamp of the first transaction of the account, if any.
+	FirstSeen *hexutil.Uint64 `json:"fts,omitempty"`
+
+	// LastBlock is the block of the most recent transaction of the account, if any.
+	LastBlock *hexutil.Uint64 `json:"lb,omitempty"`
+
+	// LastSeen is the unix time stamp of the most recent transaction of the account, if any.
+	LastSeen *hexutil.Uint64 `json:"lts,omitempty"`
+
+	// IsContract signals that the account is a smart contract.
+	IsContract bool `json:"contract"`
+}
+
+// Marshal returns the JSON encoding of account stats.
+func (as *AccountStats) Marshal() ([]byte, error) {
+	return json.Marshal(as)
+}
+
+// UnmarshalAccountStats parses the JSON-encoded account stats.
+func UnmarshalAccountStats(data []byte) (*AccountStats, error) {
+	var as AccountStats
+	err := json.Unmarshal(data, &as)
+	return &as, err
+}
@@ -36,9 +36,15 @@ type Block struct {
 	// GasUsed represents the actual total used gas by all transactions in this block.
 	GasUsed hexutil.Uint64 `json:"gasUsed"`
 
+	// BaseFeePerGas represents the base fee per gas of the block (EIP-1559). nil on pre-London blocks.
+	BaseFeePerGas *hexutil.Big `json:"baseFeePerGas,omitempty"`
+
 	// TimeStamp represents the unix timestamp for when the block was collated.
 	TimeStamp hexutil.Uint64 `json:"timestamp"`
 
+	// Epoch represents the id of the Lachesis epoch the block belongs to. nil if not provided by the node.
+	Epoch *hexutil.Uint64 `json:"epoch,omitempty"`
+
 	// Txs represents array of 32 bytes hashes of transactions included in the block.
 	Txs []*common.Hash `json:"transactions"`
 }
@@ -0,0 +1,41 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// BlockReward represents the reward attributable to a block.
+// Opera does not reward individual blocks, the rewards are distributed by the SFC
+// per sealed epoch. The base reward of the block is the share of the epoch base reward
+// minted over the time since the parent block; the transaction fees are those paid
+// by the transactions of the block, which enter the epoch fee pool.
+type BlockReward struct {
+	// Block is the number of the block.
+	Block hexutil.Uint64
+
+	// Epoch is the id of the epoch the block belongs to; nil if not provided by the node.
+	Epoch *hexutil.Uint64
+
+	// RateEpoch is the id of the sealed epoch the base reward rate comes from.
+	RateEpoch hexutil.Uint64
+
+	// Producer is the address of the block creator, nil if not provided by the node.
+	Producer *common.Address
+
+	// Duration is the number of seconds since the parent block.
+	Duration uint64
+
+	// BaseRewardPerSecond is the base reward rate of the epoch.
+	BaseRewardPerSecond hexutil.Big
+
+	// BaseReward is the base reward minted over the duration of the block.
+	BaseReward hexutil.Big
+
+	// TransactionFees is the total of fees paid by the transactions of the block.
+	TransactionFees hexutil.Big
+
+	// BurntFees is the portion of the fees burnt by the block; nil for blocks without the base fee.
+	BurntFees *hexutil.Big
+}
@@ -0,0 +1,58 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"fmt"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"strconv"
+	"strings"
+)
+
+// BlockTag identifies the block of the state used by a read call. It's either one of the named
+// tags understood by the node, or the block number encoded as 0x prefixed hexadecimal string.
+type BlockTag string
+
+// BlockTagLatest represents the latest block known to the node.
+// BlockTagSafe represents the latest block considered safe from reorganization.
+// BlockTagFinalized represents the latest finalized block.
+// BlockTagPending represents the state including the pending transactions.
+// BlockTagEarliest represents the genesis block.
+const (
+	BlockTagLatest    BlockTag = "latest"
+	BlockTagSafe      BlockTag = "safe"
+	BlockTagFinalized BlockTag = "finalized"
+	BlockTagPending   BlockTag = "pending"
+	BlockTagEarliest  BlockTag = "earliest"
+)
+
+// ParseBlockTag parses the block tag from its name, or from the block number
+// given either as a decimal, or a 0x prefixed hexadecimal number.
+func ParseBlockTag(s string) (BlockTag, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	switch BlockTag(s) {
+	case BlockTagLatest, BlockTagSafe, BlockTagFinalized, BlockTagPending, BlockTagEarliest:
+		return BlockTag(s), nil
+	}
+
+	var num uint64
+	var err error
+	if strings.HasPrefix(s, "0x") {
+		num, err = hexutil.DecodeUint64(s)
+	} else {
+		num, err = strconv.ParseUint(s, 10, 64)
+	}
+	if err != nil {
+		return "", fmt.Errorf("invalid block tag %s; use latest, safe, finalized, pending, earliest or a block number", s)
+	}
+	return BlockTagOf(hexutil.Uint64(num)), nil
+}
+
+// BlockTagOf provides the block tag of the given block number.
+func BlockTagOf(num hexutil.Uint64) BlockTag {
+	return BlockTag(num.String())
+}
+
+// IsNumber checks if the block tag identifies the block by its number.
+func (bt BlockTag) IsNumber() bool {
+	return strings.HasPrefix(string(bt), "0x")
+}
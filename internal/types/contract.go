@@ -8,6 +8,21 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"go.mongodb.org/mongo-driver/bson"
+	"strings"
+)
+
+const (
+	// ContractClassDetected marks contracts with the type detected on deployment,
+	// or on validation; contracts stored without the classification are detected, too.
+	ContractClassDetected = "detected"
+
+	// ContractClassHeuristic marks contracts promoted to a token type
+	// by the observed token events.
+	ContractClassHeuristic = "heuristic"
+
+	// ContractClassManual marks contracts with the type set manually by an admin;
+	// such contracts are not re-classified automatically.
+	ContractClassManual = "manual"
 )
 
 // Contract represents an Opera smart contract at the blockchain.
@@ -15,15 +30,30 @@ type Contract struct {
 	// Type represents a general type of the contract.
 	Type string `json:"type"`
 
+	// Classification represents the reason the contract has been assigned its type.
+	Classification string `json:"cls,omitempty"`
+
 	// Address represents the address of the contract
 	Address common.Address `json:"address"`
 
-	// TransactionHash represents the hash of the contract deployment transaction.
-	TransactionHash common.Hash `json:"tx"`
+	// TransactionHash represents the hash of the contract deployment transaction;
+	// nil if the deployment is not known, e.g. for contracts imported manually.
+	TransactionHash *common.Hash `json:"tx,omitempty"`
 
 	// TimeStamp represents the unix timestamp of the contract deployment.
 	TimeStamp hexutil.Uint64 `json:"timestamp"`
 
+	// Deployer represents the address of the account which deployed the contract.
+	// Contracts created by a factory contract have the factory as the deployer.
+	Deployer *common.Address `json:"dep,omitempty"`
+
+	// Origin represents the sender of the deployment transaction; it differs
+	// from the deployer for contracts created by a factory contract.
+	Origin *common.Address `json:"org,omitempty"`
+
+	// Block represents the number of the block the contract was deployed in.
+	Block *hexutil.Uint64 `json:"blk,omitempty"`
+
 	// Name of the smart contract, if available.
 	Name string `json:"name"`
 
@@ -61,16 +91,44 @@ type Contract struct {
 	// Validated represents the unix timestamp
 	//of the contract source validation against deployed byte code.
 	Validated *hexutil.Uint64 `json:"ok,omitempty" bson:"is_ok,omitempty"`
+
+	// IsExactMatch signals that the validated byte code matched the deployed
+	// one including the metadata hash. Partial match ignores the metadata.
+	IsExactMatch bool `json:"exact,omitempty"`
+
+	// EvmVersion represents the target EVM version used by the compiler, if known.
+	EvmVersion string `json:"evm,omitempty"`
+
+	// Metadata is the standard JSON metadata produced by the compiler, if available.
+	Metadata string `json:"meta,omitempty"`
+
+	// SourceFiles is the list of source files of the validated contract.
+	SourceFiles []ContractSourceFile `json:"files,omitempty"`
+
+	// Destroyed represents the number of the block the contract was found self-destructed at;
+	// nil for live contracts. A contract re-deployed to the same address is live again.
+	Destroyed *hexutil.Uint64 `json:"destroyed,omitempty"`
+}
+
+// ContractSourceFile represents a single source file of a validated smart contract.
+type ContractSourceFile struct {
+	Name    string `json:"name" bson:"name"`
+	Content string `json:"src" bson:"src"`
 }
 
 // BsonContract represents the contract data structure for BSON formatting.
 type BsonContract struct {
 	Address   string  `bson:"_id"`
 	Type      string  `bson:"type"`
+	Class     string  `bson:"cls"`
 	Name      string  `bson:"name"`
+	LowName   string  `bson:"lname"`
 	Ordinal   uint64  `bson:"orx"`
 	Trx       string  `bson:"trx"`
 	Created   uint64  `bson:"ts"`
+	Deployer  *string `bson:"dep"`
+	Origin    *string `bson:"org"`
+	Block     *uint64 `bson:"blk"`
 	Version   string  `bson:"ver"`
 	Support   string  `bson:"sup"`
 	License   string  `bson:"lic"`
@@ -81,6 +139,12 @@ type BsonContract struct {
 	Abi       string  `bson:"abi"`
 	SrcHash   *string `bson:"src_h"`
 	Validated *uint64 `bson:"val"`
+	Exact     bool    `bson:"exact"`
+	Evm       string  `bson:"evm"`
+	Meta      string  `bson:"meta"`
+	Destroyed *uint64 `bson:"destr"`
+
+	Files []ContractSourceFile `bson:"files"`
 }
 
 // UnmarshalContract parses the JSON-encoded smart contract data.
@@ -97,17 +161,27 @@ func (sc *Contract) Marshal() ([]byte, error) {
 
 // Uid generates unique identifier of the contract record.
 func (sc *Contract) Uid() uint64 {
-	return (uint64(sc.TimeStamp)&0xFFFFFFFFFF)<<24 | (binary.BigEndian.Uint64(sc.TransactionHash[:8]) & 0xFFFFFF)
+	var trx uint64
+	if sc.TransactionHash != nil {
+		trx = binary.BigEndian.Uint64(sc.TransactionHash[:8]) & 0xFFFFFF
+	}
+	return (uint64(sc.TimeStamp)&0xFFFFFFFFFF)<<24 | trx
 }
 
 // NewGenericContract creates new generic contract record
 func NewGenericContract(addr *common.Address, block *Block, trx *Transaction) *Contract {
+	hash := trx.Hash
+
 	// make the contract
 	return &Contract{
 		Type:            AccountTypeContract,
+		Classification:  ContractClassDetected,
 		Address:         *addr,
-		TransactionHash: trx.Hash,
+		TransactionHash: &hash,
 		TimeStamp:       block.TimeStamp,
+		Deployer:        &trx.From,
+		Origin:          &trx.From,
+		Block:           &block.Number,
 		Name:            "",
 		Version:         "",
 		SupportContact:  "",
@@ -161,9 +235,10 @@ func (sc *Contract) MarshalBSON() ([]byte, error) {
 	row := BsonContract{
 		Address:  sc.Address.String(),
 		Type:     sc.Type,
+		Class:    sc.Classification,
 		Name:     sc.Name,
+		LowName:  strings.ToLower(sc.Name),
 		Ordinal:  sc.Uid(),
-		Trx:      sc.TransactionHash.String(),
 		Created:  uint64(sc.TimeStamp),
 		Version:  sc.Version,
 		Support:  sc.SupportContact,
@@ -173,6 +248,25 @@ func (sc *Contract) MarshalBSON() ([]byte, error) {
 		OptRuns:  sc.OptimizeRuns,
 		Src:      sc.SourceCode,
 		Abi:      sc.Abi,
+		Exact:    sc.IsExactMatch,
+		Evm:      sc.EvmVersion,
+		Meta:     sc.Metadata,
+		Files:    sc.SourceFiles,
+	}
+	// do we know the deployment details?
+	if sc.TransactionHash != nil {
+		row.Trx = sc.TransactionHash.String()
+	}
+	if sc.Deployer != nil {
+		dep := sc.Deployer.String()
+		row.Deployer = &dep
+	}
+	if sc.Origin != nil {
+		org := sc.Origin.String()
+		row.Origin = &org
+	}
+	if sc.Block != nil {
+		row.Block = (*uint64)(sc.Block)
 	}
 	// is validated?
 	if sc.Validated != nil {
@@ -183,6 +277,9 @@ func (sc *Contract) MarshalBSON() ([]byte, error) {
 		val := sc.SourceCodeHash.String()
 		row.SrcHash = &val
 	}
+	if sc.Destroyed != nil {
+		row.Destroyed = (*uint64)(sc.Destroyed)
+	}
 	return bson.Marshal(row)
 }
 
@@ -203,8 +300,8 @@ func (sc *Contract) UnmarshalBSON(data []byte) (err error) {
 	// transfer data
 	sc.Address = common.HexToAddress(row.Address)
 	sc.Type = row.Type
+	sc.Classification = row.Class
 	sc.Name = row.Name
-	sc.TransactionHash = common.HexToHash(row.Trx)
 	sc.TimeStamp = hexutil.Uint64(row.Created)
 	sc.Version = row.Version
 	sc.SupportContact = row.Support
@@ -214,12 +311,89 @@ func (sc *Contract) UnmarshalBSON(data []byte) (err error) {
 	sc.OptimizeRuns = row.OptRuns
 	sc.SourceCode = row.Src
 	sc.Abi = row.Abi
+	sc.IsExactMatch = row.Exact
+	sc.EvmVersion = row.Evm
+	sc.Metadata = row.Meta
+	sc.SourceFiles = row.Files
 	if row.Validated != nil {
 		sc.Validated = (*hexutil.Uint64)(row.Validated)
 	}
+	// contracts imported before the deployment was optional have the zero hash
+	if trx := common.HexToHash(row.Trx); trx != (common.Hash{}) {
+		sc.TransactionHash = &trx
+	}
+	if row.Deployer != nil {
+		dep := common.HexToAddress(*row.Deployer)
+		sc.Deployer = &dep
+	}
+	if row.Origin != nil {
+		org := common.HexToAddress(*row.Origin)
+		sc.Origin = &org
+	}
+	if row.Block != nil {
+		sc.Block = (*hexutil.Uint64)(row.Block)
+	}
+	if row.Destroyed != nil {
+		sc.Destroyed = (*hexutil.Uint64)(row.Destroyed)
+	}
 	if row.SrcHash != nil {
 		val := common.HexToHash(*row.SrcHash)
 		sc.SourceCodeHash = &val
 	}
 	return nil
 }
+
+// ContractCreation represents the deployment details of a smart contract.
+type ContractCreation struct {
+	// Address represents the address of the contract.
+	Address common.Address
+
+	// TransactionHash represents the hash of the deployment transaction.
+	TransactionHash common.Hash
+
+	// Deployer represents the address of the account which deployed the contract.
+	Deployer common.Address
+
+	// Block represents the number of the block the contract was deployed in.
+	Block hexutil.Uint64
+
+	// TimeStamp represents the unix timestamp of the contract deployment.
+	TimeStamp hexutil.Uint64
+
+	// IsFactoryDeployment signals that the contract was created by another contract,
+	// which is the deployer in that case.
+	IsFactoryDeployment bool
+}
+
+// ContractCreationCode represents the deployment code of a smart contract
+// split into the creation bytecode and the constructor arguments.
+type ContractCreationCode struct {
+	// Address represents the address of the contract.
+	Address common.Address
+
+	// TransactionHash represents the hash of the deployment transaction.
+	TransactionHash common.Hash
+
+	// IsFactoryDeployment signals that the contract was created by another contract;
+	// the init code is taken from the call trace of the deployment transaction in that case.
+	IsFactoryDeployment bool
+
+	// InitCode represents the full init code used to deploy the contract;
+	// nil if the init code is not available.
+	InitCode []byte
+
+	// Bytecode represents the creation bytecode of the contract without
+	// the constructor arguments appended.
+	Bytecode []byte
+
+	// ConstructorArgs represents the ABI encoded constructor arguments;
+	// nil if the init code can not be split.
+	ConstructorArgs []byte
+
+	// Args is the list of decoded constructor arguments, if the ABI of the contract is known.
+	Args []DecodedCallArg
+
+	// ArgsSkipped signals the constructor arguments were not decoded since they exceed
+	// the configured decoding limit.
+	ArgsSkipped bool
+}
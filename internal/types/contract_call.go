@@ -0,0 +1,16 @@
+// Package types implements different core types of the API.
+package types
+
+import "github.com/ethereum/go-ethereum/common"
+
+// ContractCall represents a single read-only call of a contract.
+type ContractCall struct {
+	Target common.Address
+	Data   []byte
+}
+
+// ContractCallResult represents the result of a single read-only contract call.
+type ContractCallResult struct {
+	Success bool
+	Data    []byte
+}
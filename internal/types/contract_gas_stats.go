@@ -0,0 +1,22 @@
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ContractGasStats represents the gas consumed by transactions sent to a contract
+// in a range of blocks.
+type ContractGasStats struct {
+	// Address represents the address of the contract.
+	Address common.Address `json:"address"`
+
+	// FromBlock and ToBlock represent the inclusive range of blocks covered.
+	FromBlock uint64 `json:"from"`
+	ToBlock   uint64 `json:"to"`
+
+	// GasUsed represents the total gas used by the transactions.
+	GasUsed uint64 `json:"gas"`
+
+	// Calls represents the number of the transactions.
+	Calls uint64 `json:"calls"`
+}
@@ -0,0 +1,18 @@
+package types
+
+import "github.com/ethereum/go-ethereum/common"
+
+// ContractRefresh represents the outcome of a forced refresh of the contract on-chain data.
+type ContractRefresh struct {
+	// Contract is the refreshed contract.
+	Contract *Contract
+
+	// PreviousType is the type of the contract before the refresh.
+	PreviousType string
+
+	// Implementation is the current implementation of an EIP-1967 proxy contract, if any.
+	Implementation *common.Address
+
+	// Changes lists the descriptions of the changes made by the refresh.
+	Changes []string
+}
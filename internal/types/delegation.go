@@ -57,6 +57,15 @@ type Delegation struct {
 	AmountDelegated *hexutil.Big `json:"amountDelegated"`
 }
 
+// DelegationSummary represents aggregated active delegations.
+type DelegationSummary struct {
+	// Count is the number of active delegations.
+	Count hexutil.Uint64
+
+	// Amount is the total active amount of the delegations.
+	Amount hexutil.Big
+}
+
 // DelegationDecimalsCorrection is used to adjust decimal precision of a delegation active value.
 var DelegationDecimalsCorrection = new(big.Int).SetUint64(1000000000)
 
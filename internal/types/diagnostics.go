@@ -0,0 +1,100 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"sync"
+	"time"
+)
+
+// SubsystemStatus represents the health of a single subsystem of the API server.
+type SubsystemStatus struct {
+	// Name is the name of the subsystem.
+	Name string
+
+	// IsUp signals the service is running, or the connection is available.
+	IsUp bool
+
+	// LastError is the time of the most recent error of the subsystem, if any.
+	LastError *time.Time
+
+	// LastErrorMessage is the message of the most recent error of the subsystem.
+	LastErrorMessage string
+}
+
+// QueueStatus represents the depth of an internal processing queue.
+type QueueStatus struct {
+	Name     string
+	Length   int
+	Capacity int
+}
+
+// WorkerPoolStatus represents the load of a pool of background workers.
+type WorkerPoolStatus struct {
+	Name   string
+	Active int
+	Size   int
+}
+
+// ScannerStatus represents the progress of the block scanner.
+type ScannerStatus struct {
+	// Next is the number of the next block to be scanned.
+	Next uint64
+
+	// Target is the number of the last block of the current scan range.
+	Target uint64
+
+	// Dispatched is the number of the last block processed in order.
+	Dispatched uint64
+
+	// IsIdle signals the scanner follows the new heads of the node.
+	IsIdle bool
+}
+
+// SvcDiagnostics represents the runtime state of the data processing services.
+type SvcDiagnostics struct {
+	Scanner  ScannerStatus
+	Queues   []QueueStatus
+	Services []SubsystemStatus
+}
+
+// ConnectionStatus represents the state of the repository connections.
+type ConnectionStatus struct {
+	// NodeHead is the current block height of the connected node.
+	NodeHead uint64
+
+	// Rpc is the status of the blockchain node connection.
+	Rpc SubsystemStatus
+
+	// Db is the status of the persistent storage connection.
+	Db SubsystemStatus
+}
+
+// ErrorTracker keeps the time and the message of the last error of a subsystem.
+type ErrorTracker struct {
+	mu  sync.Mutex
+	at  time.Time
+	msg string
+}
+
+// Fail records a new error of the subsystem.
+func (et *ErrorTracker) Fail(msg string) {
+	et.mu.Lock()
+	defer et.mu.Unlock()
+
+	et.at = time.Now().UTC()
+	et.msg = msg
+}
+
+// Status provides the status of the subsystem of the given name with the last error, if any.
+func (et *ErrorTracker) Status(name string, up bool) SubsystemStatus {
+	et.mu.Lock()
+	defer et.mu.Unlock()
+
+	st := SubsystemStatus{Name: name, IsUp: up}
+	if !et.at.IsZero() {
+		at := et.at
+		st.LastError = &at
+		st.LastErrorMessage = et.msg
+	}
+	return st
+}
@@ -0,0 +1,29 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"time"
+)
+
+const (
+	// FiEpochSealEpoch is the name of the sealed epoch id column in the collection.
+	FiEpochSealEpoch = "_id"
+
+	// FiEpochSealBlock is the name of the sealing block number column in the collection.
+	FiEpochSealBlock = "blk"
+)
+
+// EpochSeal represents the sealing of an epoch by the Lachesis consensus.
+// An epoch is sealed by its last block; blocks of a sealed epoch are final
+// and so is the state of validators and delegations at the end of the epoch.
+type EpochSeal struct {
+	// Epoch is the id of the sealed epoch.
+	Epoch hexutil.Uint64 `bson:"_id"`
+
+	// Block is the number of the last block of the epoch, the block sealing it.
+	Block hexutil.Uint64 `bson:"blk"`
+
+	// TimeStamp is the time of the sealing block.
+	TimeStamp time.Time `bson:"ts"`
+}
@@ -22,6 +22,9 @@ type Erc20Token struct {
 	// The most common value is 18 to mimic the ETH to WEI relationship.
 	// USD pairs on ChainLink (we use for price oracles) use 8 digits.
 	Decimals int32 `json:"decimals"`
+
+	// DecimalsUnknown signals the token does not provide the number of decimals.
+	DecimalsUnknown bool `json:"dec_unknown,omitempty"`
 }
 
 // UnmarshalErc20Token parses the JSON-encoded account data.
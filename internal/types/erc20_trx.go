@@ -21,6 +21,7 @@ const (
 	FiTokenTransactionType      = "type"
 	FiTokenTransactionSender    = "from"
 	FiTokenTransactionRecipient = "to"
+	FiTokenTransactionTimeStamp = "ts"
 
 	// TokenTrxTypeTransfer represents token transfer transaction.
 	TokenTrxTypeTransfer = 1
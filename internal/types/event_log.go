@@ -0,0 +1,97 @@
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	retypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+// EventLog represents a single log record emitted by a transaction
+// along with the position of the record in the blockchain.
+type EventLog struct {
+	retypes.Log
+
+	// Cursor identifies the position of the log record in the list of logs.
+	Cursor string
+
+	// Event is the decoded event of the log record; nil if the event is not known
+	// from the ABI of the emitting contract.
+	Event *DecodedEvent
+}
+
+// DecodedEvent represents an event decoded from a log record using the contract ABI.
+type DecodedEvent struct {
+	// Name is the name of the event.
+	Name string
+
+	// Signature is the canonical signature of the event, e.g. Transfer(address,address,uint256).
+	Signature string
+
+	// Args is the list of decoded arguments of the event.
+	Args []DecodedEventArg
+}
+
+// DecodedEventArg represents a single decoded argument of an event.
+type DecodedEventArg struct {
+	Name    string
+	Type    string
+	Indexed bool
+	Value   string
+}
+
+// EventDefinition represents an event declared in the contract ABI.
+type EventDefinition struct {
+	// Name is the name of the event.
+	Name string
+
+	// Signature is the canonical signature of the event, e.g. Transfer(address,address,uint256).
+	Signature string
+
+	// Topic is the hash of the signature the log records of the event are marked with;
+	// nil for anonymous events.
+	Topic *common.Hash
+
+	// Inputs is the list of the event arguments.
+	Inputs []EventDefinitionInput
+}
+
+// EventDefinitionInput represents a single argument of an event declared in the contract ABI.
+type EventDefinitionInput struct {
+	Name    string
+	Type    string
+	Indexed bool
+}
+
+// EventLogList represents a page of log records.
+type EventLogList struct {
+	// Collection contains the list of log records on the page.
+	Collection []*EventLog
+
+	// Next is the cursor of the following page, if any.
+	Next *string
+}
+
+// EventLogFilter represents a filter of log records.
+type EventLogFilter struct {
+	// Address is the emitting contract of the logs, nil for any.
+	Address *common.Address
+
+	// Topics contains the list of alternatives for each topic position;
+	// an empty set of alternatives matches any topic.
+	Topics [][]common.Hash
+
+	// FromBlock is the first block of the range searched.
+	FromBlock uint64
+
+	// ToBlock is the last block of the range searched.
+	ToBlock uint64
+}
+
+// EventArgFilter represents a condition on the value of a decoded event argument.
+type EventArgFilter struct {
+	// Name is the name of the argument in the event declaration.
+	Name string
+
+	// Value is the expected value of the argument in its text form,
+	// e.g. a hex address, or a decimal number.
+	Value string
+}
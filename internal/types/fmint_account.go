@@ -2,6 +2,7 @@
 package types
 
 import (
+	"encoding/json"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 )
@@ -26,3 +27,15 @@ type FMintAccount struct {
 	// in ref. denomination (fUSD).
 	DebtValue hexutil.Big
 }
+
+// UnmarshalFMintAccount parses the JSON-encoded fMint account data.
+func UnmarshalFMintAccount(data []byte) (*FMintAccount, error) {
+	var acc FMintAccount
+	err := json.Unmarshal(data, &acc)
+	return &acc, err
+}
+
+// Marshal returns the JSON encoding of fMint account.
+func (fa *FMintAccount) Marshal() ([]byte, error) {
+	return json.Marshal(fa)
+}
@@ -2,6 +2,8 @@
 package types
 
 import (
+	"encoding/json"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"go.mongodb.org/mongo-driver/bson"
 	"time"
 )
@@ -70,3 +72,37 @@ func (gpp *GasPricePeriod) MarshalBSON() ([]byte, error) {
 func (gpp *GasPricePeriod) UnmarshalBSON(data []byte) (err error) {
 	return bson.Unmarshal(data, gpp)
 }
+
+// GasPriceTiers represents gas price suggestion tiers derived from
+// the gas prices paid by transactions in recent blocks.
+type GasPriceTiers struct {
+	// Suggested is the gas price suggested by the node.
+	Suggested hexutil.Big `json:"suggested"`
+
+	// Low is the gas price expected to get the transaction processed eventually.
+	Low hexutil.Big `json:"low"`
+
+	// Average is the gas price paid by the usual transaction.
+	Average hexutil.Big `json:"average"`
+
+	// High is the gas price expected to get the transaction processed quickly.
+	High hexutil.Big `json:"high"`
+
+	// Blocks is the number of recent blocks inspected.
+	Blocks int32 `json:"blocks"`
+
+	// Samples is the number of transaction gas prices the tiers are derived from.
+	Samples int32 `json:"samples"`
+}
+
+// Marshal returns the JSON encoding of gas price tiers.
+func (gt *GasPriceTiers) Marshal() ([]byte, error) {
+	return json.Marshal(gt)
+}
+
+// UnmarshalGasPriceTiers parses the JSON-encoded gas price tiers.
+func UnmarshalGasPriceTiers(data []byte) (*GasPriceTiers, error) {
+	var gt GasPriceTiers
+	err := json.Unmarshal(data, &gt)
+	return &gt, err
+}
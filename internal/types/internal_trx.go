@@ -0,0 +1,67 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// InternalTrxSelfDestruct is the type of the internal call destroying the calling contract.
+const InternalTrxSelfDestruct = "SELFDESTRUCT"
+
+// InternalTrxCreate is the prefix of the types of internal calls creating a contract (CREATE, CREATE2).
+const InternalTrxCreate = "CREATE"
+
+// InternalTransaction represents a single call executed inside of a transaction,
+// as extracted from the call trace of the transaction.
+type InternalTransaction struct {
+	// Hash represents the hash of the parent transaction.
+	Hash common.Hash `json:"hash"`
+
+	// TraceAddress represents the position of the call inside the call tree.
+	TraceAddress []int32 `json:"traceAddress"`
+
+	// Type represents the type of the call (CALL, DELEGATECALL, STATICCALL, CREATE, ...).
+	Type string `json:"type"`
+
+	// From represents the address of the caller.
+	From common.Address `json:"from"`
+
+	// To represents the address of the callee. Nil for failed contract creation.
+	To *common.Address `json:"to"`
+
+	// Value represents the amount of native tokens transferred by the call.
+	Value hexutil.Big `json:"value"`
+
+	// Gas represents the amount of gas provided to the call.
+	Gas hexutil.Uint64 `json:"gas"`
+
+	// GasUsed represents the amount of gas consumed by the call.
+	GasUsed hexutil.Uint64 `json:"gasUsed"`
+
+	// Input represents the input data of the call.
+	Input hexutil.Bytes `json:"input"`
+
+	// Error represents the error of a failed call, empty for successful calls.
+	Error string `json:"error,omitempty"`
+}
+
+// IsCreation checks if the internal call successfully created a contract.
+func (it *InternalTransaction) IsCreation() bool {
+	return strings.HasPrefix(strings.ToUpper(it.Type), InternalTrxCreate) && it.To != nil && it.Error == ""
+}
+
+// MarshalInternalTransactions returns the JSON encoding of the list of internal transactions.
+func MarshalInternalTransactions(list []*InternalTransaction) ([]byte, error) {
+	return json.Marshal(list)
+}
+
+// UnmarshalInternalTransactions parses the JSON-encoded list of internal transactions.
+func UnmarshalInternalTransactions(data []byte) ([]*InternalTransaction, error) {
+	var list []*InternalTransaction
+	err := json.Unmarshal(data, &list)
+	return list, err
+}
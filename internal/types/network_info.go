@@ -0,0 +1,44 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"encoding/json"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// NetworkInfo represents the metadata of the connected node and its network.
+type NetworkInfo struct {
+	// ChainID is the identifier of the chain used for transaction signing.
+	ChainID hexutil.Big `json:"chain"`
+
+	// ClientVersion is the version string of the connected node client.
+	ClientVersion string `json:"client"`
+
+	// IsSyncing signals the node is still catching up with the network.
+	IsSyncing bool `json:"syncing"`
+
+	// HighestBlock is the highest block known to the syncing node, if syncing.
+	HighestBlock *hexutil.Uint64 `json:"highest,omitempty"`
+
+	// BlockNumber is the number of the latest block known to the node.
+	BlockNumber hexutil.Uint64 `json:"block"`
+
+	// BlockHash is the hash of the latest block known to the node.
+	BlockHash common.Hash `json:"hash"`
+
+	// BlockTime is the time stamp of the latest block known to the node.
+	BlockTime hexutil.Uint64 `json:"ts"`
+}
+
+// UnmarshalNetworkInfo parses the JSON-encoded network info data.
+func UnmarshalNetworkInfo(data []byte) (*NetworkInfo, error) {
+	var ni NetworkInfo
+	err := json.Unmarshal(data, &ni)
+	return &ni, err
+}
+
+// Marshal returns the JSON encoding of network info.
+func (ni *NetworkInfo) Marshal() ([]byte, error) {
+	return json.Marshal(ni)
+}
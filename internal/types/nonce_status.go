@@ -0,0 +1,32 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// NonceStatus represents the state of the transaction nonces of a sender account.
+type NonceStatus struct {
+	// Address is the address of the sender account.
+	Address common.Address
+
+	// Nonce is the next nonce of the account on chain, i.e. the number of mined transactions.
+	Nonce hexutil.Uint64
+
+	// PendingNonce is the next nonce of the account including
+	// the transactions executable from the transaction pool of the node.
+	PendingNonce hexutil.Uint64
+
+	// HighestMined is the highest nonce of a stored mined transaction of the account, if any.
+	HighestMined *hexutil.Uint64
+
+	// Pending is the list of nonces of pending transactions of the account not mined yet.
+	Pending []hexutil.Uint64
+
+	// Gaps is the list of missing nonces blocking the pending transactions from being mined.
+	Gaps []hexutil.Uint64
+
+	// Unindexed is the list of recent mined nonces without a stored transaction.
+	Unindexed []hexutil.Uint64
+}
@@ -0,0 +1,52 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// PortfolioAsset represents a single valued asset of an account portfolio.
+type PortfolioAsset struct {
+	// TokenAddress is the address of the ERC20 token, nil for the native token.
+	TokenAddress *common.Address
+
+	// Symbol is the symbol of the asset.
+	Symbol string
+
+	// Decimals is the number of decimals of the asset.
+	Decimals int32
+
+	// Balance is the raw balance of the asset.
+	Balance hexutil.Big
+
+	// Price is the price of one unit of the asset in the quote token, nil if not known.
+	Price *float64
+
+	// Value is the value of the balance in the quote token, nil if not known.
+	Value *float64
+
+	// IsLowConfidence signals the price was derived from low reserves.
+	IsLowConfidence bool
+}
+
+// Portfolio represents valued assets of an account.
+type Portfolio struct {
+	// Address is the address of the account.
+	Address common.Address
+
+	// Quote is the address of the token the values are expressed in.
+	Quote common.Address
+
+	// Native is the native token balance of the account.
+	Native PortfolioAsset
+
+	// Tokens is the list of ERC20 token balances of the account.
+	Tokens []PortfolioAsset
+
+	// TotalValue is the sum of all the known values of the assets.
+	TotalValue float64
+
+	// IsComplete signals all the assets have been valued.
+	IsComplete bool
+}
@@ -0,0 +1,37 @@
+// Package types implements different core types of the API.
+package types
+
+// RevertReason represents the reason of a failed transaction
+// decoded from the data returned by its replayed call.
+type RevertReason struct {
+	// Reason is the human readable revert reason; empty if not known.
+	Reason string `json:"reason" bson:"reason"`
+
+	// Data is the raw data returned by the reverted call.
+	Data []byte `json:"data" bson:"data"`
+
+	// Error is the custom error the call reverted with, if any.
+	Error *CustomError `json:"error,omitempty" bson:"err,omitempty"`
+}
+
+// CustomError represents a Solidity custom error decoded from the revert data.
+type CustomError struct {
+	// Selector is the 4 bytes selector of the error.
+	Selector []byte `json:"selector" bson:"sel"`
+
+	// Name is the name of the error; empty if the error is not known from the contract ABI.
+	Name string `json:"name" bson:"name"`
+
+	// Signature is the canonical signature of the error, e.g. InsufficientBalance(uint256,uint256).
+	Signature string `json:"signature" bson:"sig"`
+
+	// Args is the list of decoded arguments of the error.
+	Args []CustomErrorArg `json:"args" bson:"args"`
+}
+
+// CustomErrorArg represents a single decoded argument of a custom error.
+type CustomErrorArg struct {
+	Name  string `json:"name" bson:"name"`
+	Type  string `json:"type" bson:"type"`
+	Value string `json:"value" bson:"value"`
+}
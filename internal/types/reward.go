@@ -34,19 +34,23 @@ type RewardClaim struct {
 	ClaimTrx      common.Hash
 	Amount        hexutil.Big
 	IsDelegated   bool
+	FromEpoch     *hexutil.Uint64
+	UntilEpoch    *hexutil.Uint64
 }
 
 // BsonRewardClaim represents BSON rew structure of the reward claim.
 type BsonRewardClaim struct {
-	ID        string    `bson:"_id"`
-	Ordinal   uint64    `bson:"orx"`
-	Addr      string    `bson:"addr"`
-	To        string    `bson:"to"`
-	ClaimTime uint64    `bson:"when"`
-	TimeStamp time.Time `bson:"stamp"`
-	Amount    string    `bson:"amount"`
-	Value     uint64    `bson:"value"`
-	IsDlg     bool      `bson:"red"`
+	ID         string    `bson:"_id"`
+	Ordinal    uint64    `bson:"orx"`
+	Addr       string    `bson:"addr"`
+	To         string    `bson:"to"`
+	ClaimTime  uint64    `bson:"when"`
+	TimeStamp  time.Time `bson:"stamp"`
+	Amount     string    `bson:"amount"`
+	Value      uint64    `bson:"value"`
+	IsDlg      bool      `bson:"red"`
+	FromEpoch  *uint64   `bson:"fep,omitempty"`
+	UntilEpoch *uint64   `bson:"uep,omitempty"`
 }
 
 // Pk returns a unique primary key of the claim.
@@ -76,6 +80,12 @@ func (rwc *RewardClaim) MarshalBSON() ([]byte, error) {
 		Value:     val.Uint64(),
 		IsDlg:     rwc.IsDelegated,
 	}
+
+	// the epoch range is known only for some versions of the SFC contract
+	if rwc.FromEpoch != nil && rwc.UntilEpoch != nil {
+		fe, ue := uint64(*rwc.FromEpoch), uint64(*rwc.UntilEpoch)
+		pom.FromEpoch, pom.UntilEpoch = &fe, &ue
+	}
 	return bson.Marshal(pom)
 }
 
@@ -101,5 +111,7 @@ func (rwc *RewardClaim) UnmarshalBSON(data []byte) (err error) {
 	rwc.ClaimTrx = common.HexToHash(row.ID)
 	rwc.Amount = (hexutil.Big)(*hexutil.MustDecodeBig(row.Amount))
 	rwc.IsDelegated = row.IsDlg
+	rwc.FromEpoch = (*hexutil.Uint64)(row.FromEpoch)
+	rwc.UntilEpoch = (*hexutil.Uint64)(row.UntilEpoch)
 	return nil
 }
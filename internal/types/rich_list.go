@@ -0,0 +1,58 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"math/big"
+	"time"
+)
+
+const (
+	// FiRichListAddress is the name of the rich list address field,
+	// the address serves as the primary key of the collection.
+	FiRichListAddress = "_id"
+
+	// FiRichListValue is the name of the rich list field keeping
+	// the decimals corrected balance used for sorting.
+	FiRichListValue = "val"
+
+	// FiRichListUpdated is the name of the rich list field keeping
+	// the time of the last balance update.
+	FiRichListUpdated = "upd"
+)
+
+// RichListDecimalsCorrection is used to reduce the account balance
+// to a precision suitable for sorting the rich list.
+var RichListDecimalsCorrection = new(big.Int).SetUint64(1000000000)
+
+// RichListEntry represents a single account in the ranking of accounts
+// by their native token balance.
+type RichListEntry struct {
+	Address    common.Address
+	Balance    hexutil.Big
+	IsContract bool
+	Updated    time.Time
+}
+
+// RichList represents a list of accounts sorted by their native balance.
+type RichList struct {
+	// Collection keeps the actual list of entries.
+	Collection []*RichListEntry
+
+	// Total indicates total number of accounts in the ranking.
+	Total uint64
+
+	// IsStart indicates there are no accounts available above the list currently.
+	IsStart bool
+
+	// IsEnd indicates there are no accounts available below the list currently.
+	IsEnd bool
+}
+
+// Reverse reverses the order of entries in the list.
+func (rl *RichList) Reverse() {
+	for i, j := 0, len(rl.Collection)-1; i < j; i, j = i+1, j-1 {
+		rl.Collection[i], rl.Collection[j] = rl.Collection[j], rl.Collection[i]
+	}
+}
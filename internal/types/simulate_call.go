@@ -0,0 +1,52 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// SimulationCall represents a contract call to be simulated.
+type SimulationCall struct {
+	From     *common.Address `json:"from,omitempty"`
+	To       common.Address  `json:"to"`
+	Data     hexutil.Bytes   `json:"data,omitempty"`
+	Value    *hexutil.Big    `json:"value,omitempty"`
+	Block    *hexutil.Uint64 `json:"-"`
+	Tag      BlockTag        `json:"-"`
+	Override StateOverride   `json:"-"`
+}
+
+// StateOverride represents a set of account state changes applied before a simulated call.
+type StateOverride map[common.Address]OverrideAccount
+
+// OverrideAccount represents the state changes of a single account of a simulated call.
+// State replaces the whole storage of the account, StateDiff changes only the given slots.
+type OverrideAccount struct {
+	Balance   *hexutil.Big                 `json:"balance,omitempty"`
+	Nonce     *hexutil.Uint64              `json:"nonce,omitempty"`
+	Code      *hexutil.Bytes               `json:"code,omitempty"`
+	State     *map[common.Hash]common.Hash `json:"state,omitempty"`
+	StateDiff *map[common.Hash]common.Hash `json:"stateDiff,omitempty"`
+}
+
+// SimulatedCall represents the result of a simulated contract call.
+type SimulatedCall struct {
+	// ReturnData is the raw data returned by the call, the revert data for reverted calls.
+	ReturnData []byte
+
+	// Reverted signals the call was reverted.
+	Reverted bool
+
+	// RevertReason is the human readable revert reason; empty if not known.
+	RevertReason string
+
+	// Call is the called method decoded using the contract ABI; nil if not known.
+	Call *DecodedCall
+
+	// Outputs is the list of decoded values returned by the call; nil if not decoded.
+	Outputs []DecodedCallArg
+
+	// Error is the custom error the call reverted with, if decoded from the contract ABI.
+	Error *CustomError
+}
@@ -0,0 +1,42 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"encoding/json"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// StakingApr represents an estimate of the annual yield of the stake delegated to a validator.
+type StakingApr struct {
+	// ValidatorID is the id of the validator the estimate belongs to.
+	ValidatorID hexutil.Big `json:"id"`
+
+	// Apr is the annual percentage rate estimated from the rewards of the recent epochs.
+	Apr float64 `json:"apr"`
+
+	// Apy is the annual percentage yield of the rate compounded daily.
+	Apy float64 `json:"apy"`
+
+	// FromEpoch and ToEpoch delimit the window of epochs the estimate is based on.
+	FromEpoch hexutil.Uint64 `json:"from"`
+	ToEpoch   hexutil.Uint64 `json:"to"`
+
+	// IsNetworkAverage signals the estimate is the network average used
+	// for validators without enough reward history.
+	IsNetworkAverage bool `json:"avg"`
+
+	// Reason explains why the network average is used.
+	Reason string `json:"reason,omitempty"`
+}
+
+// UnmarshalStakingApr parses the JSON-encoded staking yield estimate.
+func UnmarshalStakingApr(data []byte) (*StakingApr, error) {
+	var apr StakingApr
+	err := json.Unmarshal(data, &apr)
+	return &apr, err
+}
+
+// Marshal returns the JSON encoding of the staking yield estimate.
+func (apr *StakingApr) Marshal() ([]byte, error) {
+	return json.Marshal(apr)
+}
@@ -0,0 +1,51 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"encoding/json"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// StakingParams represents the staking protocol parameters enforced by the SFC contract
+// read at a single block.
+type StakingParams struct {
+	// WithdrawalPeriodEpochs is the min number of epochs between an un-delegation and its withdrawal.
+	WithdrawalPeriodEpochs hexutil.Big `json:"wpe"`
+
+	// WithdrawalPeriodTime is the min number of seconds between an un-delegation and its withdrawal.
+	WithdrawalPeriodTime hexutil.Big `json:"wpt"`
+
+	// MinSelfStake is the min amount of tokens self-staked by a validator.
+	MinSelfStake hexutil.Big `json:"mss"`
+
+	// MinDelegation is the min amount of tokens of a delegation;
+	// nil if the SFC does not enforce any.
+	MinDelegation *hexutil.Big `json:"md,omitempty"`
+
+	// MaxDelegatedRatio is the max ratio between the received stake and the self stake
+	// of a validator with 18 decimals.
+	MaxDelegatedRatio hexutil.Big `json:"mdr"`
+
+	// MinLockupDuration and MaxLockupDuration delimit the number of seconds
+	// a delegation can be locked for.
+	MinLockupDuration hexutil.Big `json:"minLock"`
+	MaxLockupDuration hexutil.Big `json:"maxLock"`
+
+	// Epoch is the current epoch at the time the parameters were read.
+	Epoch hexutil.Uint64 `json:"epoch"`
+
+	// Block is the number of the block the parameters were read at.
+	Block hexutil.Uint64 `json:"block"`
+}
+
+// UnmarshalStakingParams parses the JSON-encoded staking parameters.
+func UnmarshalStakingParams(data []byte) (*StakingParams, error) {
+	var sp StakingParams
+	err := json.Unmarshal(data, &sp)
+	return &sp, err
+}
+
+// Marshal returns the JSON encoding of the staking parameters.
+func (sp *StakingParams) Marshal() ([]byte, error) {
+	return json.Marshal(sp)
+}
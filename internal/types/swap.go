@@ -99,11 +99,25 @@ type DefiTimePrice struct {
 	Average float64 `json:"average" bson:"avg"`
 }
 
-// Swap types
+// Swap types; the values are stored and exposed to the API clients,
+// so they must not change. New types are added at the end.
 const (
-	SwapMint = iota
-	SwapBurn
-	SwapSync
+	SwapMint  = 0
+	SwapBurn  = 1
+	SwapSync  = 2
+	SwapTrade = 3
+)
+
+// Swap trade directions
+const (
+	// SwapDirectionNone is used for actions not trading one token for the other.
+	SwapDirectionNone = iota
+
+	// SwapDirection0To1 represents a trade of Token0 for Token1.
+	SwapDirection0To1
+
+	// SwapDirection1To0 represents a trade of Token1 for Token0.
+	SwapDirection1To0
 )
 
 // DefiTimeReserve represents a reserve for uniswap pair in history
@@ -178,3 +192,19 @@ type UniswapAction struct {
 	// amount1out is amount of outgoing tokens for Token1 in this action
 	Amount1out hexutil.Big `json:"am1out"`
 }
+
+// Direction provides the direction of the trade for swap actions.
+// The sender trades the incoming token for the outgoing one.
+func (ua *UniswapAction) Direction() int32 {
+	if ua.Type != SwapTrade {
+		return SwapDirectionNone
+	}
+
+	// the token with the bigger net inflow is the one being sold
+	net0 := new(big.Int).Sub(ua.Amount0in.ToInt(), ua.Amount0out.ToInt())
+	net1 := new(big.Int).Sub(ua.Amount1in.ToInt(), ua.Amount1out.ToInt())
+	if net0.Cmp(net1) >= 0 {
+		return SwapDirection0To1
+	}
+	return SwapDirection1To0
+}
@@ -0,0 +1,57 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"time"
+)
+
+// TokenPriceSample represents a single sample of the price of a Uniswap pair
+// derived from the pair reserves.
+type TokenPriceSample struct {
+	// Pair is the address of the sampled pair.
+	Pair common.Address
+
+	// Price is the decimals adjusted price of the first token of the pair
+	// in units of the second token.
+	Price float64
+
+	// IsLowConfidence signals the reserves of the pair were too low
+	// for the price to be reliable.
+	IsLowConfidence bool
+
+	// Time is the time of the sample.
+	Time time.Time
+}
+
+// TokenPriceTick represents the price of a token aggregated over a single interval
+// of the price history.
+type TokenPriceTick struct {
+	From  time.Time
+	To    time.Time
+	Open  float64
+	High  float64
+	Low   float64
+	Close float64
+
+	// Samples is the number of price samples taken in the interval.
+	Samples int32
+
+	// IsCarried signals there are no samples in the interval
+	// and the last known price is carried forward.
+	IsCarried bool
+
+	// IsLowConfidence signals any of the samples of the interval
+	// was derived from low pair reserves.
+	IsLowConfidence bool
+}
+
+// TokenPriceHistory represents the price history of a token against the other token
+// of the sampled Uniswap pair.
+type TokenPriceHistory struct {
+	Token    common.Address
+	Against  common.Address
+	Pair     common.Address
+	Interval time.Duration
+	Ticks    []TokenPriceTick
+}
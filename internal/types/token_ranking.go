@@ -0,0 +1,73 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"encoding/json"
+	"github.com/ethereum/go-ethereum/common"
+	"time"
+)
+
+const (
+	// TokenRankingByVolume ranks tokens by the transferred volume valued in the quote token.
+	TokenRankingByVolume = "VOLUME"
+
+	// TokenRankingByTransfers ranks tokens by the number of transfers.
+	TokenRankingByTransfers = "TRANSFERS"
+
+	// TokenRankingByHolders ranks tokens by the number of unique recipients.
+	TokenRankingByHolders = "HOLDERS"
+)
+
+// TokenTransferStats represents the transfers of an ERC20 token aggregated over a period.
+type TokenTransferStats struct {
+	// Token is the address of the ERC20 token.
+	Token common.Address `json:"tok"`
+
+	// Transfers is the number of transfers, mints and burns of the token.
+	Transfers uint64 `json:"cnt"`
+
+	// Holders is the number of unique accounts receiving the token.
+	Holders uint64 `json:"hld"`
+
+	// Amount is the sum of the transferred amounts reduced by TransactionDecimalsCorrection.
+	Amount float64 `json:"amo"`
+
+	// Volume is the transferred amount in whole tokens.
+	Volume float64 `json:"vol"`
+
+	// Price is the price of one whole token in the quote token, nil if not known.
+	Price *float64 `json:"pri,omitempty"`
+
+	// Value is the volume valued in the quote token, nil if the price is not known.
+	Value *float64 `json:"val,omitempty"`
+}
+
+// TokenRanking represents ERC20 tokens ranked by their transfers over a period.
+type TokenRanking struct {
+	// Period is the length of the period the transfers are aggregated over.
+	Period time.Duration `json:"period"`
+
+	// Metric is the metric the tokens are ranked by.
+	Metric string `json:"metric"`
+
+	// Quote is the address of the token the values are expressed in.
+	Quote common.Address `json:"quote"`
+
+	// Since is the beginning of the period.
+	Since time.Time `json:"since"`
+
+	// Entries is the ranked list of tokens.
+	Entries []TokenTransferStats `json:"list"`
+}
+
+// UnmarshalTokenRanking parses the JSON-encoded token ranking.
+func UnmarshalTokenRanking(data []byte) (*TokenRanking, error) {
+	var tr TokenRanking
+	err := json.Unmarshal(data, &tr)
+	return &tr, err
+}
+
+// Marshal returns the JSON encoding of the token ranking.
+func (tr *TokenRanking) Marshal() ([]byte, error) {
+	return json.Marshal(tr)
+}
@@ -17,6 +17,9 @@ import (
 // Larger inputs (like contract deployments) need to be loaded from the blockchain directly if needed.
 const trxLargeInputWall = 32 * 8
 
+// trxCallSelectorLength is the length of the method selector in the contract call input data.
+const trxCallSelectorLength = 4
+
 // TransactionDecimalsCorrection is used to manipulate precision of a transaction amount value
 // so it can be stored in database as INT64 without loosing too much data
 var TransactionDecimalsCorrection = new(big.Int).SetUint64(1000000000)
@@ -50,6 +53,17 @@ type Transaction struct {
 	// GasPrice represents gas price provided by the sender in Wei.
 	GasPrice hexutil.Big `json:"gasPrice"`
 
+	// EffectiveGasPrice represents the gas price actually paid by the sender in Wei. nil when its pending.
+	EffectiveGasPrice *hexutil.Big `json:"effectiveGasPrice,omitempty"`
+
+	// MaxFeePerGas represents the max total fee per gas the sender is willing to pay (EIP-1559).
+	// nil for legacy transactions.
+	MaxFeePerGas *hexutil.Big `json:"maxFeePerGas,omitempty"`
+
+	// MaxPriorityFeePerGas represents the max priority fee per gas the sender is willing to pay (EIP-1559).
+	// nil for legacy transactions.
+	MaxPriorityFeePerGas *hexutil.Big `json:"maxPriorityFeePerGas,omitempty"`
+
 	// Hash represents 32 bytes hash of the transaction.
 	Hash common.Hash `json:"hash"`
 
@@ -78,10 +92,14 @@ type Transaction struct {
 	Index *hexutil.Uint64 `json:"index,omitempty"`
 
 	// Status represents transaction status; value is either 1 (success) or 0 (failure)
+	// nil when its pending, or if the receipt does not provide the status (pre-Byzantium receipts).
 	Status *hexutil.Uint64 `json:"status,omitempty"`
 
 	// Logs represents a list of log records created along with the transaction
 	Logs []retypes.Log `json:"logs"`
+
+	// LogsOmitted indicates the log records were not loaded with the transaction.
+	LogsOmitted bool `json:"-"`
 }
 
 // BsonLog represents the transaction log record data structure for BSON formatting.
@@ -106,14 +124,18 @@ type BsonTransaction struct {
 	Amount     int64     `bson:"amo"`
 	LargeInput bool      `bson:"large"`
 	Input      []byte    `bson:"input"`
+	Selector   *string   `bson:"sel,omitempty"`
 	Gas        int64     `bson:"gas_lim"`
 	UsedGas    *uint64   `bson:"gas_use"`
 	CumGas     *uint64   `bson:"gas_cum"`
 	GasPrice   string    `bson:"gas_pri"`
+	GasEff     *string   `bson:"gas_eff"`
+	GasFeeCap  *string   `bson:"gas_max,omitempty"`
+	GasTipCap  *string   `bson:"gas_tip,omitempty"`
 	GasGWei    int64     `bson:"gwx100"`
 	Nonce      int64     `bson:"nonce"`
 	Contract   *string   `bson:"contr"`
-	Status     uint64    `bson:"stat"`
+	Status     *uint64   `bson:"stat"`
 	Stamp      time.Time `bson:"stamp"`
 	Logs       []BsonLog `bson:"logs"`
 }
@@ -157,6 +179,16 @@ func (trx *Transaction) MarshalBSON() ([]byte, error) {
 		Stamp:      trx.TimeStamp,
 	}
 
+	// dynamic fee transaction caps
+	if trx.MaxFeePerGas != nil {
+		fc := trx.MaxFeePerGas.String()
+		pom.GasFeeCap = &fc
+	}
+	if trx.MaxPriorityFeePerGas != nil {
+		tc := trx.MaxPriorityFeePerGas.String()
+		pom.GasTipCap = &tc
+	}
+
 	// store the input data along with the trx
 	if !pom.LargeInput {
 		pom.Input = trx.InputData
@@ -189,13 +221,28 @@ func (trx *Transaction) MarshalBSON() ([]byte, error) {
 		pom.UsedGas = &gu
 
 		// status
-		pom.Status = uint64(*trx.Status)
+		if trx.Status != nil {
+			st := uint64(*trx.Status)
+			pom.Status = &st
+		}
+
+		// effective gas price
+		if trx.EffectiveGasPrice != nil {
+			eff := trx.EffectiveGasPrice.String()
+			pom.GasEff = &eff
+		}
 	}
 
 	// recipient
 	if trx.To != nil {
 		to := trx.To.String()
 		pom.To = &to
+
+		// the method selector of contract calls is kept even for large inputs
+		if len(trx.InputData) >= trxCallSelectorLength {
+			sel := hexutil.Encode(trx.InputData[:trxCallSelectorLength])
+			pom.Selector = &sel
+		}
 	}
 
 	// contract address
@@ -245,7 +292,7 @@ func (trx *Transaction) UnmarshalBSON(data []byte) (err error) {
 	trx.Gas = hexutil.Uint64(row.Gas)
 	trx.GasPrice = (hexutil.Big)(*hexutil.MustDecodeBig(row.GasPrice))
 	trx.Nonce = hexutil.Uint64(row.Nonce)
-	trx.Status = (*hexutil.Uint64)(&row.Status)
+	trx.Status = (*hexutil.Uint64)(row.Status)
 	trx.InputData = row.Input
 	trx.LargeInput = row.LargeInput
 	trx.TimeStamp = row.Stamp
@@ -256,6 +303,18 @@ func (trx *Transaction) UnmarshalBSON(data []byte) (err error) {
 		trx.Value = (hexutil.Big)(*tv)
 	}
 
+	// dynamic fee transaction caps
+	if row.GasFeeCap != nil {
+		if fc, err := hexutil.DecodeBig(*row.GasFeeCap); err == nil {
+			trx.MaxFeePerGas = (*hexutil.Big)(fc)
+		}
+	}
+	if row.GasTipCap != nil {
+		if tc, err := hexutil.DecodeBig(*row.GasTipCap); err == nil {
+			trx.MaxPriorityFeePerGas = (*hexutil.Big)(tc)
+		}
+	}
+
 	// pointers
 	if row.BlockHash != nil {
 		// block hash
@@ -279,6 +338,14 @@ func (trx *Transaction) UnmarshalBSON(data []byte) (err error) {
 		// cumulative gas
 		gc := hexutil.Uint64(*row.CumGas)
 		trx.CumulativeGasUsed = &gc
+
+		// effective gas price; older records don't have it, the gas price was paid in full
+		trx.EffectiveGasPrice = &trx.GasPrice
+		if row.GasEff != nil {
+			if eff, err := hexutil.DecodeBig(*row.GasEff); err == nil {
+				trx.EffectiveGasPrice = (*hexutil.Big)(eff)
+			}
+		}
 	}
 
 	// recipient
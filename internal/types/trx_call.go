@@ -0,0 +1,60 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// DecodedCall represents a contract call decoded from the transaction input data.
+type DecodedCall struct {
+	// Selector is the 4 bytes selector of the called method.
+	Selector []byte `json:"selector"`
+
+	// Name is the name of the method; empty if the method is not known from the contract ABI.
+	Name string `json:"name"`
+
+	// Signature is the canonical signature of the method, e.g. transfer(address,uint256).
+	Signature string `json:"signature"`
+
+	// Args is the list of decoded arguments of the call.
+	Args []DecodedCallArg `json:"args"`
+
+	// ArgsSkipped signals the arguments were not decoded since the input data
+	// exceeds the configured decoding limit.
+	ArgsSkipped bool `json:"skipped"`
+
+	// ContractDestroyed signals the called contract has self-destructed since
+	// and no longer exists at the address.
+	ContractDestroyed bool `json:"destroyed"`
+
+	// SubCalls is the list of inner calls of a recognized batch call,
+	// e.g. a Gnosis Safe multiSend or a Multicall aggregate.
+	SubCalls []DecodedSubCall `json:"sub,omitempty"`
+
+	// SubCallsSkipped signals some inner calls were not decoded since
+	// the batch exceeds the configured depth or size limit.
+	SubCallsSkipped bool `json:"sub_skipped"`
+}
+
+// DecodedSubCall represents an inner call of a batch contract call.
+type DecodedSubCall struct {
+	// To is the address of the inner call target.
+	To common.Address `json:"to"`
+
+	// Value is the amount of native tokens sent with the inner call.
+	Value hexutil.Big `json:"value"`
+
+	// DelegateCall signals the inner call is executed as a delegate call.
+	DelegateCall bool `json:"delegate"`
+
+	// Call is the decoded inner call; nil for plain value transfers.
+	Call *DecodedCall `json:"call"`
+}
+
+// DecodedCallArg represents a single decoded argument of a contract call.
+type DecodedCallArg struct {
+	Name  string `json:"name"`
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
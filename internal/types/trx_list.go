@@ -42,3 +42,23 @@ func (b *TransactionList) Reverse() {
 	// swap indexes
 	b.First, b.Last = b.Last, b.First
 }
+
+// TrxFields represents the set of optional parts of the stored transactions
+// loaded with a transaction list. The core transaction data are always loaded.
+type TrxFields uint8
+
+const (
+	// TrxFieldInput represents the input data of the transaction.
+	TrxFieldInput TrxFields = 1 << iota
+
+	// TrxFieldLogs represents the log records of the transaction.
+	TrxFieldLogs
+
+	// TrxFieldsAll represents the full transaction with all the optional parts.
+	TrxFieldsAll = TrxFieldInput | TrxFieldLogs
+)
+
+// Has checks if the given optional part is in the set.
+func (tf TrxFields) Has(f TrxFields) bool {
+	return tf&f == f
+}
@@ -0,0 +1,38 @@
+package types
+
+import (
+	"time"
+)
+
+const (
+	// TrxStatsDay represents the daily granularity of the transaction statistics.
+	TrxStatsDay = "DAY"
+
+	// TrxStatsHour represents the hourly granularity of the transaction statistics.
+	TrxStatsHour = "HOUR"
+)
+
+// TrxStats represents an aggregation of the network transaction flow
+// in a single time bucket.
+type TrxStats struct {
+	ID             string    `bson:"_id"`
+	Granularity    string    `bson:"gran"`
+	Stamp          time.Time `bson:"stamp"`
+	Counter        int64     `bson:"cnt"`
+	Addresses      int64     `bson:"addr"`
+	AmountAdjusted int64     `bson:"vol"`
+	AvgGasGWei     float64   `bson:"gas"`
+}
+
+// TrxStatsBucket provides the length of a single statistics bucket of the given granularity.
+func TrxStatsBucket(gran string) time.Duration {
+	if gran == TrxStatsHour {
+		return time.Hour
+	}
+	return 24 * time.Hour
+}
+
+// TrxStatsBucketStart provides the start of the statistics bucket containing the given time.
+func TrxStatsBucketStart(t time.Time, gran string) time.Time {
+	return t.UTC().Truncate(TrxStatsBucket(gran))
+}
@@ -0,0 +1,23 @@
+// Package types implements different core types of the API.
+package types
+
+import "github.com/ethereum/go-ethereum/common"
+
+// UniswapTokenPrice represents a token price derived from Uniswap pair reserves.
+type UniswapTokenPrice struct {
+	// Token is the address of the priced token.
+	Token common.Address
+
+	// Against is the address of the token the price is expressed in.
+	Against common.Address
+
+	// Price is the decimals adjusted price of one token in units of the against token.
+	Price float64
+
+	// Route is the list of Uniswap pairs used to derive the price.
+	Route []common.Address
+
+	// IsLowConfidence signals that at least one pair of the route
+	// has reserves too small to provide a reliable price.
+	IsLowConfidence bool
+}
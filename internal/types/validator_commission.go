@@ -0,0 +1,46 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"encoding/binary"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"math/big"
+)
+
+// ValidatorCommission represents the validator commission rate taken by SFC
+// from the rewards of delegators.
+type ValidatorCommission struct {
+	// Rate is the commission rate in SFC decimal units.
+	Rate hexutil.Big
+
+	// Since is the time stamp the current rate has been observed first.
+	Since hexutil.Uint64
+
+	// Observed is the time stamp the current rate has been observed last.
+	Observed hexutil.Uint64
+}
+
+// Marshal encodes the commission into bytes slice.
+func (vc *ValidatorCommission) Marshal() ([]byte, error) {
+	// we have a 256bit number and two 64bit time stamps here
+	buf := make([]byte, 48)
+
+	vc.Rate.ToInt().FillBytes(buf[:32])
+	binary.BigEndian.PutUint64(buf[32:40], uint64(vc.Since))
+	binary.BigEndian.PutUint64(buf[40:], uint64(vc.Observed))
+	return buf, nil
+}
+
+// Unmarshal decodes the buffer into the commission.
+func (vc *ValidatorCommission) Unmarshal(buf []byte) error {
+	// check for the buffer length, we expect 48 bytes
+	if len(buf) != 48 {
+		return fmt.Errorf("expected 48 bytes, %d received", len(buf))
+	}
+
+	vc.Rate = (hexutil.Big)(*new(big.Int).SetBytes(buf[:32]))
+	vc.Since = hexutil.Uint64(binary.BigEndian.Uint64(buf[32:40]))
+	vc.Observed = hexutil.Uint64(binary.BigEndian.Uint64(buf[40:]))
+	return nil
+}
@@ -0,0 +1,68 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"time"
+)
+
+const (
+	// WebhookDeliveryPending represents a webhook delivery waiting for the next attempt.
+	WebhookDeliveryPending = "pending"
+
+	// WebhookDeliveryDelivered represents a webhook delivery accepted by the receiver.
+	WebhookDeliveryDelivered = "delivered"
+
+	// WebhookDeliveryFailed represents a webhook delivery abandoned after the max number of attempts.
+	WebhookDeliveryFailed = "failed"
+)
+
+// Webhook represents a notification webhook registered for an address.
+// The webhook is fired when the address receives a transaction.
+type Webhook struct {
+	ID      string
+	Address common.Address
+	Url     string
+	Created time.Time
+}
+
+// WebhookDelivery represents a single notification of a webhook about a transaction.
+type WebhookDelivery struct {
+	ID          string
+	WebhookID   string
+	Url         string
+	Transaction common.Hash
+	Block       hexutil.Uint64
+
+	// Payload is the JSON document posted to the webhook URL.
+	Payload string
+
+	// Status is the delivery state, one of the WebhookDelivery* constants.
+	Status string
+
+	// Attempts is the number of failed delivery attempts.
+	Attempts int32
+
+	// LastError is the error of the last failed attempt, if any.
+	LastError string
+
+	// NextAttempt is the time of the next delivery attempt of a pending delivery.
+	NextAttempt time.Time
+
+	Created   time.Time
+	Delivered *time.Time
+}
+
+// WebhookPayload represents the notification document posted to the webhook URL.
+type WebhookPayload struct {
+	Delivery    string          `json:"delivery"`
+	Webhook     string          `json:"webhook"`
+	Address     common.Address  `json:"address"`
+	Transaction common.Hash     `json:"transaction"`
+	Block       hexutil.Uint64  `json:"block"`
+	From        common.Address  `json:"from"`
+	Value       hexutil.Big     `json:"value"`
+	Status      *hexutil.Uint64 `json:"status"`
+	TimeStamp   int64           `json:"timestamp"`
+}